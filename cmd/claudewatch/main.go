@@ -3,12 +3,17 @@ package main
 
 import "github.com/blackwell-systems/claudewatch/internal/app"
 
-// version is set at build time via ldflags:
+// version, commit, and date are set at build time via ldflags:
 //
-//	go build -ldflags "-X main.version=1.0.0"
-var version = "dev"
+//	go build -ldflags "-X main.version=1.0.0 -X main.commit=abc1234 -X main.date=2026-01-01T00:00:00Z"
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
 
 func main() {
 	app.SetVersion(version)
+	app.SetBuildInfo(commit, date)
 	app.Execute()
 }
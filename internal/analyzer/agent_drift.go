@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// AgentTypeDriftMinWeeks is how many weeks with agent tasks the drift needs
+// before it compares anything.
+const AgentTypeDriftMinWeeks = 2
+
+// AgentTypeDriftMinShift is the change in an agent type's share of agent
+// tasks, in percentage points, that counts as rising or falling.
+const AgentTypeDriftMinShift = 10.0
+
+// Agent type drift trends.
+const (
+	DriftRising  = "rising"
+	DriftFalling = "falling"
+	DriftSteady  = "steady"
+)
+
+// AgentTypeShift is how one agent type's share of agent tasks moved between
+// the earlier and the recent weeks.
+type AgentTypeShift struct {
+	AgentType   string `json:"agent_type"`
+	EarlyCount  int    `json:"early_count"`
+	RecentCount int    `json:"recent_count"`
+	// EarlyShare and RecentShare are the type's percentage of all agent
+	// tasks in each half.
+	EarlyShare  float64 `json:"early_share"`
+	RecentShare float64 `json:"recent_share"`
+	// ShiftPoints is RecentShare - EarlyShare.
+	ShiftPoints float64 `json:"shift_points"`
+	// Trend is "rising", "falling", or "steady".
+	Trend string `json:"trend"`
+}
+
+// AgentTypeDrift reports how the mix of agent types changed over time.
+type AgentTypeDrift struct {
+	// Weeks is the number of ISO weeks with at least one agent task.
+	Weeks int `json:"weeks"`
+	// EarlyWeeks and RecentWeeks are how many of those weeks each half
+	// holds. With an odd number of weeks, the middle one is in neither.
+	EarlyWeeks  int `json:"early_weeks"`
+	RecentWeeks int `json:"recent_weeks"`
+	// Types lists every agent type seen in either half, from the largest
+	// rise to the largest fall. It is empty with fewer than
+	// AgentTypeDriftMinWeeks weeks.
+	Types []AgentTypeShift `json:"types"`
+}
+
+// AnalyzeAgentTypeDrift buckets tasks by the ISO week they were launched,
+// splits the weeks with tasks into an earlier and a recent half, and
+// compares each agent type's share of tasks between the two. A type whose
+// share moved by at least AgentTypeDriftMinShift points is rising or
+// falling. Tasks without a launch time or agent type are skipped.
+func AnalyzeAgentTypeDrift(tasks []claude.AgentTask) AgentTypeDrift {
+	result := AgentTypeDrift{Types: []AgentTypeShift{}}
+
+	byWeek := make(map[[2]int]map[string]int)
+	for _, t := range tasks {
+		launched := claude.ParseTimestamp(t.CreatedAt)
+		if launched.IsZero() || t.AgentType == "" {
+			continue
+		}
+		wk := weekKey(launched)
+		if byWeek[wk] == nil {
+			byWeek[wk] = make(map[string]int)
+		}
+		byWeek[wk][t.AgentType]++
+	}
+
+	result.Weeks = len(byWeek)
+	if result.Weeks < AgentTypeDriftMinWeeks {
+		return result
+	}
+
+	weeks := make([][2]int, 0, len(byWeek))
+	for wk := range byWeek {
+		weeks = append(weeks, wk)
+	}
+	sort.Slice(weeks, func(i, j int) bool {
+		if weeks[i][0] != weeks[j][0] {
+			return weeks[i][0] < weeks[j][0]
+		}
+		return weeks[i][1] < weeks[j][1]
+	})
+	half := len(weeks) / 2
+	result.EarlyWeeks, result.RecentWeeks = half, half
+
+	sum := func(weeks [][2]int) (map[string]int, int) {
+		counts := make(map[string]int)
+		total := 0
+		for _, wk := range weeks {
+			for agentType, n := range byWeek[wk] {
+				counts[agentType] += n
+				total += n
+			}
+		}
+		return counts, total
+	}
+	early, earlyTotal := sum(weeks[:half])
+	recent, recentTotal := sum(weeks[len(weeks)-half:])
+
+	types := make(map[string]bool, len(early)+len(recent))
+	for agentType := range early {
+		types[agentType] = true
+	}
+	for agentType := range recent {
+		types[agentType] = true
+	}
+	for agentType := range types {
+		s := AgentTypeShift{
+			AgentType:   agentType,
+			EarlyCount:  early[agentType],
+			RecentCount: recent[agentType],
+			EarlyShare:  float64(early[agentType]) / float64(earlyTotal) * 100,
+			RecentShare: float64(recent[agentType]) / float64(recentTotal) * 100,
+			Trend:       DriftSteady,
+		}
+		s.ShiftPoints = s.RecentShare - s.EarlyShare
+		switch {
+		case s.ShiftPoints >= AgentTypeDriftMinShift:
+			s.Trend = DriftRising
+		case s.ShiftPoints <= -AgentTypeDriftMinShift:
+			s.Trend = DriftFalling
+		}
+		result.Types = append(result.Types, s)
+	}
+
+	sort.Slice(result.Types, func(i, j int) bool {
+		a, b := result.Types[i], result.Types[j]
+		if a.ShiftPoints != b.ShiftPoints {
+			return a.ShiftPoints > b.ShiftPoints
+		}
+		return a.AgentType < b.AgentType
+	})
+	return result
+}
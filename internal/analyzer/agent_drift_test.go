@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// agentTasksOn returns n tasks of agentType launched at createdAt.
+func agentTasksOn(agentType, createdAt string, n int) []claude.AgentTask {
+	var tasks []claude.AgentTask
+	for range n {
+		tasks = append(tasks, claude.AgentTask{AgentType: agentType, CreatedAt: createdAt})
+	}
+	return tasks
+}
+
+func TestAnalyzeAgentTypeDrift_NeedsTwoWeeks(t *testing.T) {
+	tasks := append(agentTasksOn("Explore", "2026-03-02T10:00:00Z", 3), agentTasksOn("Plan", "2026-03-04T10:00:00Z", 2)...)
+	result := AnalyzeAgentTypeDrift(tasks)
+	if result.Weeks != 1 || len(result.Types) != 0 {
+		t.Errorf("result = %+v, want one week and no types", result)
+	}
+	if result := AnalyzeAgentTypeDrift(nil); result.Types == nil {
+		t.Error("Types is nil, want an empty list")
+	}
+}
+
+func TestAnalyzeAgentTypeDrift(t *testing.T) {
+	var tasks []claude.AgentTask
+	// Week 1: 8 general-purpose, 2 Explore.
+	tasks = append(tasks, agentTasksOn("general-purpose", "2026-03-02T10:00:00Z", 8)...)
+	tasks = append(tasks, agentTasksOn("Explore", "2026-03-03T10:00:00Z", 2)...)
+	// Week 2 is the middle of three and left out.
+	tasks = append(tasks, agentTasksOn("Plan", "2026-03-10T10:00:00Z", 5)...)
+	// Week 3: 5 general-purpose, 4 Explore, 1 Plan.
+	tasks = append(tasks, agentTasksOn("general-purpose", "2026-03-16T10:00:00Z", 5)...)
+	tasks = append(tasks, agentTasksOn("Explore", "2026-03-17T10:00:00Z", 4)...)
+	tasks = append(tasks, agentTasksOn("Plan", "2026-03-18T10:00:00Z", 1)...)
+	// Skipped: no launch time.
+	tasks = append(tasks, claude.AgentTask{AgentType: "Explore"})
+
+	result := AnalyzeAgentTypeDrift(tasks)
+	if result.Weeks != 3 || result.EarlyWeeks != 1 || result.RecentWeeks != 1 {
+		t.Fatalf("weeks = %d (%d early, %d recent), want 3 (1, 1)", result.Weeks, result.EarlyWeeks, result.RecentWeeks)
+	}
+	if len(result.Types) != 3 {
+		t.Fatalf("Types = %+v, want 3", result.Types)
+	}
+
+	want := []struct {
+		agentType string
+		shift     float64
+		trend     string
+	}{
+		{"Explore", 20, DriftRising},
+		{"Plan", 10, DriftRising},
+		{"general-purpose", -30, DriftFalling},
+	}
+	for i, w := range want {
+		got := result.Types[i]
+		if got.AgentType != w.agentType || math.Abs(got.ShiftPoints-w.shift) > 1e-9 || got.Trend != w.trend {
+			t.Errorf("Types[%d] = %+v, want %s %+.0f points %s", i, got, w.agentType, w.shift, w.trend)
+		}
+	}
+	if p := result.Types[1]; p.EarlyCount != 0 || p.RecentCount != 1 {
+		t.Errorf("Plan counts = %d early, %d recent; want 0 and 1", p.EarlyCount, p.RecentCount)
+	}
+}
+
+func TestAnalyzeAgentTypeDrift_Steady(t *testing.T) {
+	tasks := append(agentTasksOn("Explore", "2026-03-02T10:00:00Z", 5), agentTasksOn("Explore", "2026-03-09T10:00:00Z", 3)...)
+	result := AnalyzeAgentTypeDrift(tasks)
+	if len(result.Types) != 1 || result.Types[0].Trend != DriftSteady || result.Types[0].ShiftPoints != 0 {
+		t.Errorf("Types = %+v, want Explore steady", result.Types)
+	}
+}
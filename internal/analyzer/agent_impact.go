@@ -0,0 +1,177 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+const (
+	// AgentImpactMinSessions is how many sessions a project needs both with
+	// and without task agents before their commit rates are compared, and
+	// how many faceted sessions before their goal-achieved rates are.
+	AgentImpactMinSessions = 3
+
+	// AgentImpactThreshold is the commit-rate or goal-achieved-rate gap, as
+	// a fraction, at which agent-using sessions are said to help or hurt a
+	// project.
+	AgentImpactThreshold = 0.15
+)
+
+// AgentImpactAnalysis compares, per project, sessions that spawned task
+// agents with sessions that didn't.
+type AgentImpactAnalysis struct {
+	// Projects lists every project with enough sessions in both groups,
+	// worst delta first.
+	Projects []ProjectAgentImpact `json:"projects"`
+	// Helps and Hurts count projects whose verdict is "helps" or "hurts".
+	Helps int `json:"helps"`
+	Hurts int `json:"hurts"`
+	// Insufficient counts projects that used agents but lack
+	// AgentImpactMinSessions sessions in one of the groups.
+	Insufficient int `json:"insufficient"`
+}
+
+// ProjectAgentImpact is the with/without-agents comparison for one project.
+type ProjectAgentImpact struct {
+	ProjectPath   string          `json:"project_path"`
+	ProjectName   string          `json:"project_name"`
+	WithAgents    AgentGroupStats `json:"with_agents"`
+	WithoutAgents AgentGroupStats `json:"without_agents"`
+	// CommitRateDelta is WithAgents.CommitRate - WithoutAgents.CommitRate.
+	CommitRateDelta float64 `json:"commit_rate_delta"`
+	// OutcomeCompared is set when both groups have AgentImpactMinSessions
+	// faceted sessions, and AchievedRateDelta is then
+	// WithAgents.AchievedRate - WithoutAgents.AchievedRate.
+	OutcomeCompared   bool    `json:"outcome_compared"`
+	AchievedRateDelta float64 `json:"achieved_rate_delta"`
+	// Verdict is "hurts" when either delta is at or below
+	// -AgentImpactThreshold, "helps" when either is at or above it
+	// otherwise, and "neutral" else.
+	Verdict string `json:"verdict"`
+}
+
+// AgentGroupStats summarizes one group of sessions in a project.
+type AgentGroupStats struct {
+	Sessions int `json:"sessions"`
+	// CommitRate is the share of sessions with at least one commit.
+	CommitRate float64 `json:"commit_rate"`
+	AvgCommits float64 `json:"avg_commits"`
+	// FacetSessions counts the sessions with a facet, and AchievedRate is
+	// the share of them that achieved or mostly achieved their goal.
+	FacetSessions int     `json:"facet_sessions"`
+	AchievedRate  float64 `json:"achieved_rate"`
+}
+
+// AnalyzeAgentImpact splits each project's sessions by whether they spawned
+// any task agents and compares the groups' commit rates and, from facets,
+// goal-achieved rates. Projects without AgentImpactMinSessions sessions in
+// both groups are counted but not compared.
+func AnalyzeAgentImpact(sessions []claude.SessionMeta, tasks []claude.AgentTask, facets []claude.SessionFacet) AgentImpactAnalysis {
+	result := AgentImpactAnalysis{Projects: []ProjectAgentImpact{}}
+
+	facetByID := make(map[string]*claude.SessionFacet, len(facets))
+	for i := range facets {
+		facetByID[facets[i].SessionID] = &facets[i]
+	}
+
+	usedAgents := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		usedAgents[t.SessionID] = true
+	}
+
+	type groups struct{ with, without []claude.SessionMeta }
+	byProject := make(map[string]*groups)
+	for _, s := range sessions {
+		if s.ProjectPath == "" {
+			continue
+		}
+		g := byProject[s.ProjectPath]
+		if g == nil {
+			g = &groups{}
+			byProject[s.ProjectPath] = g
+		}
+		if usedAgents[s.SessionID] {
+			g.with = append(g.with, s)
+		} else {
+			g.without = append(g.without, s)
+		}
+	}
+
+	for path, g := range byProject {
+		if len(g.with) == 0 {
+			continue
+		}
+		if len(g.with) < AgentImpactMinSessions || len(g.without) < AgentImpactMinSessions {
+			result.Insufficient++
+			continue
+		}
+
+		p := ProjectAgentImpact{
+			ProjectPath:   path,
+			ProjectName:   projectNameFromPath(path),
+			WithAgents:    agentGroupStats(g.with, facetByID),
+			WithoutAgents: agentGroupStats(g.without, facetByID),
+			Verdict:       "neutral",
+		}
+		p.CommitRateDelta = p.WithAgents.CommitRate - p.WithoutAgents.CommitRate
+		p.OutcomeCompared = p.WithAgents.FacetSessions >= AgentImpactMinSessions &&
+			p.WithoutAgents.FacetSessions >= AgentImpactMinSessions
+		if p.OutcomeCompared {
+			p.AchievedRateDelta = p.WithAgents.AchievedRate - p.WithoutAgents.AchievedRate
+		}
+		switch {
+		case p.worstDelta() <= -AgentImpactThreshold:
+			p.Verdict = "hurts"
+			result.Hurts++
+		case p.CommitRateDelta >= AgentImpactThreshold || p.AchievedRateDelta >= AgentImpactThreshold:
+			p.Verdict = "helps"
+			result.Helps++
+		}
+		result.Projects = append(result.Projects, p)
+	}
+
+	sort.Slice(result.Projects, func(i, j int) bool {
+		if wi, wj := result.Projects[i].worstDelta(), result.Projects[j].worstDelta(); wi != wj {
+			return wi < wj
+		}
+		return result.Projects[i].ProjectPath < result.Projects[j].ProjectPath
+	})
+
+	return result
+}
+
+// worstDelta returns the lower of the compared deltas.
+func (p ProjectAgentImpact) worstDelta() float64 {
+	if p.OutcomeCompared {
+		return min(p.CommitRateDelta, p.AchievedRateDelta)
+	}
+	return p.CommitRateDelta
+}
+
+func agentGroupStats(sessions []claude.SessionMeta, facetByID map[string]*claude.SessionFacet) AgentGroupStats {
+	stats := AgentGroupStats{Sessions: len(sessions)}
+	if len(sessions) == 0 {
+		return stats
+	}
+	var withCommits, commits, achieved int
+	for _, s := range sessions {
+		commits += s.GitCommits
+		if s.GitCommits > 0 {
+			withCommits++
+		}
+		if f := facetByID[s.SessionID]; f != nil {
+			stats.FacetSessions++
+			if f.Outcome == "achieved" || f.Outcome == "mostly_achieved" {
+				achieved++
+			}
+		}
+	}
+	n := float64(len(sessions))
+	stats.CommitRate = float64(withCommits) / n
+	stats.AvgCommits = float64(commits) / n
+	if stats.FacetSessions > 0 {
+		stats.AchievedRate = float64(achieved) / float64(stats.FacetSessions)
+	}
+	return stats
+}
@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeAgentImpact_Empty(t *testing.T) {
+	result := AnalyzeAgentImpact(nil, nil, nil)
+	if len(result.Projects) != 0 || result.Helps != 0 || result.Hurts != 0 || result.Insufficient != 0 {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
+
+func TestAnalyzeAgentImpact_Verdicts(t *testing.T) {
+	var sessions []claude.SessionMeta
+	var tasks []claude.AgentTask
+	for _, g := range []struct {
+		project      string
+		agents       bool
+		n, committed int
+	}{
+		// slow: agents commit 1/4 of the time vs 3/4 without.
+		{"slow", true, 4, 1},
+		{"slow", false, 4, 3},
+		// fast: agents commit 3/3 vs 1/3 without.
+		{"fast", true, 3, 3},
+		{"fast", false, 3, 1},
+		// even: the same rate in both groups.
+		{"even", true, 3, 2},
+		{"even", false, 3, 2},
+		// thin: only two agent sessions, not enough to judge.
+		{"thin", true, 2, 0},
+		{"thin", false, 5, 5},
+		// solo: never used agents, so not part of the comparison.
+		{"solo", false, 5, 5},
+	} {
+		group := makeGroup(fmt.Sprintf("%s-%v", g.project, g.agents), "/code/"+g.project, g.n, g.committed)
+		sessions = append(sessions, group...)
+		if g.agents {
+			tasks = append(tasks, groupTasks(group, "Explore", "completed")...)
+		}
+	}
+
+	result := AnalyzeAgentImpact(sessions, tasks, nil)
+
+	if result.Helps != 1 || result.Hurts != 1 || result.Insufficient != 1 {
+		t.Errorf("Helps=%d Hurts=%d Insufficient=%d, want 1, 1, 1", result.Helps, result.Hurts, result.Insufficient)
+	}
+	if len(result.Projects) != 3 {
+		t.Fatalf("Projects = %+v, want slow, even, fast", result.Projects)
+	}
+
+	slow := result.Projects[0]
+	if slow.ProjectName != "slow" || slow.Verdict != "hurts" {
+		t.Errorf("first project = %s (%s), want slow (hurts)", slow.ProjectName, slow.Verdict)
+	}
+	if math.Abs(slow.CommitRateDelta-(-0.5)) > 1e-9 {
+		t.Errorf("slow CommitRateDelta = %.3f, want -0.5", slow.CommitRateDelta)
+	}
+	if slow.WithAgents.Sessions != 4 || slow.WithoutAgents.Sessions != 4 {
+		t.Errorf("slow groups = %+v / %+v, want 4 sessions each", slow.WithAgents, slow.WithoutAgents)
+	}
+	if result.Projects[1].ProjectName != "even" || result.Projects[1].Verdict != "neutral" {
+		t.Errorf("second project = %+v, want even (neutral)", result.Projects[1])
+	}
+	if result.Projects[2].ProjectName != "fast" || result.Projects[2].Verdict != "helps" {
+		t.Errorf("third project = %+v, want fast (helps)", result.Projects[2])
+	}
+}
+
+func TestAnalyzeAgentImpact_GoalAchievedRate(t *testing.T) {
+	// Both groups commit every time, but agent sessions meet their goal in
+	// 1 of 3 sessions against 3 of 3 without.
+	with := makeGroup("with", "/code/goals", 3, 3)
+	without := makeGroup("without", "/code/goals", 3, 3)
+	sessions := append(with, without...)
+	tasks := groupTasks(with, "Explore", "completed")
+	facets := append(groupFacets(with, 1, 0), groupFacets(without, 3, 0)...)
+
+	result := AnalyzeAgentImpact(sessions, tasks, facets)
+	if len(result.Projects) != 1 {
+		t.Fatalf("Projects = %+v, want goals", result.Projects)
+	}
+	p := result.Projects[0]
+	if !p.OutcomeCompared || p.WithAgents.FacetSessions != 3 || p.WithoutAgents.FacetSessions != 3 {
+		t.Errorf("got %+v, want the outcomes of 3 faceted sessions per group compared", p)
+	}
+	if p.CommitRateDelta != 0 || math.Abs(p.AchievedRateDelta-(-2.0/3)) > 1e-9 {
+		t.Errorf("deltas = %.3f commit, %.3f achieved; want 0 and -0.667", p.CommitRateDelta, p.AchievedRateDelta)
+	}
+	if p.Verdict != "hurts" || result.Hurts != 1 {
+		t.Errorf("Verdict = %q, Hurts = %d; want hurts, 1", p.Verdict, result.Hurts)
+	}
+
+	// Without enough facets the outcome isn't compared.
+	result = AnalyzeAgentImpact(sessions, tasks, facets[:2])
+	if p := result.Projects[0]; p.OutcomeCompared || p.AchievedRateDelta != 0 || p.Verdict != "neutral" {
+		t.Errorf("got %+v, want a neutral commit-only comparison", p)
+	}
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+// AgentResultMinLength is the result length, in characters, from which an
+// agent's result is substantial enough that ignoring it wastes the work.
+const AgentResultMinLength = 500
+
+// agentResultTopIgnored is how many ignored agents are listed, costliest
+// first.
+const agentResultTopIgnored = 5
+
+// AgentResultUsage estimates how many agents returned a substantial result
+// that the session never acted on. It is a heuristic: an agent counts as
+// ignored when no file edit or git commit follows it within the window, which
+// also catches research agents whose answer was only read.
+type AgentResultUsage struct {
+	// WindowMinutes is how soon an edit or commit had to follow.
+	WindowMinutes int `json:"window_minutes"`
+	// Considered counts successful agents with a result of at least
+	// AgentResultMinLength characters.
+	Considered int `json:"considered"`
+	Ignored    int `json:"ignored"`
+	// IgnoredRate is Ignored / Considered.
+	IgnoredRate float64 `json:"ignored_rate"`
+	// WastedTokens sums the ignored agents' tokens; EstimatedWasteUSD prices
+	// them at the input rate, as agent tokens are mostly context.
+	WastedTokens      int     `json:"wasted_tokens"`
+	EstimatedWasteUSD float64 `json:"estimated_waste_usd"`
+	// TopIgnored lists the ignored agents with the most tokens.
+	TopIgnored []IgnoredAgent `json:"top_ignored"`
+}
+
+// IgnoredAgent is one agent whose result was not followed by action.
+type IgnoredAgent struct {
+	SessionID    string    `json:"session_id"`
+	AgentType    string    `json:"agent_type"`
+	Description  string    `json:"description"`
+	CompletedAt  time.Time `json:"completed_at"`
+	ResultLength int       `json:"result_length"`
+	TotalTokens  int       `json:"total_tokens"`
+}
+
+// AnalyzeAgentResultUsage flags successful agents whose result is at least
+// AgentResultMinLength characters long but which no file edit or git commit
+// followed within window of finishing. Killed, failed, and unfinished agents
+// are skipped, as are short results, which rarely call for follow-up work.
+// window below one minute uses config.DefaultAgentResultWindowMinutes.
+func AnalyzeAgentResultUsage(spans []claude.AgentSpan, window time.Duration, pricing ModelPricing) AgentResultUsage {
+	if window < time.Minute {
+		window = config.DefaultAgentResultWindowMinutes * time.Minute
+	}
+	result := AgentResultUsage{WindowMinutes: int(window / time.Minute), TopIgnored: []IgnoredAgent{}}
+
+	var ignored []IgnoredAgent
+	for _, s := range spans {
+		if !s.Success || s.Killed || s.CompletedAt.IsZero() || s.ResultLength < AgentResultMinLength {
+			continue
+		}
+		result.Considered++
+		if !s.NextActionAt.IsZero() && s.NextActionAt.Sub(s.CompletedAt) <= window {
+			continue
+		}
+		result.Ignored++
+		result.WastedTokens += s.TotalTokens
+		ignored = append(ignored, IgnoredAgent{
+			SessionID:    s.SessionID,
+			AgentType:    s.AgentType,
+			Description:  s.Description,
+			CompletedAt:  s.CompletedAt,
+			ResultLength: s.ResultLength,
+			TotalTokens:  s.TotalTokens,
+		})
+	}
+
+	if result.Considered > 0 {
+		result.IgnoredRate = float64(result.Ignored) / float64(result.Considered)
+	}
+	result.EstimatedWasteUSD = tokensToCost(int64(result.WastedTokens), pricing.InputPerMillion)
+
+	sort.SliceStable(ignored, func(i, j int) bool {
+		return ignored[i].TotalTokens > ignored[j].TotalTokens
+	})
+	if len(ignored) > agentResultTopIgnored {
+		ignored = ignored[:agentResultTopIgnored]
+	}
+	result.TopIgnored = append(result.TopIgnored, ignored...)
+	return result
+}
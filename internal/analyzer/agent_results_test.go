@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeAgentResultUsage(t *testing.T) {
+	done := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	span := func(desc string, resultLen, tokens int, nextAction time.Duration) claude.AgentSpan {
+		s := claude.AgentSpan{
+			SessionID:    "s1",
+			AgentType:    "Explore",
+			Description:  desc,
+			Success:      true,
+			CompletedAt:  done,
+			ResultLength: resultLen,
+			TotalTokens:  tokens,
+		}
+		if nextAction >= 0 {
+			s.NextActionAt = done.Add(nextAction)
+		}
+		return s
+	}
+	killed := span("killed", 2000, 9000, -1)
+	killed.Killed = true
+
+	spans := []claude.AgentSpan{
+		span("acted on", 2000, 50_000, 3*time.Minute),
+		span("acted on too late", 2000, 200_000, 30*time.Minute),
+		span("never acted on", 2000, 800_000, -1),
+		span("short answer", AgentResultMinLength-1, 40_000, -1),
+		killed,
+	}
+	pricing := ModelPricing{InputPerMillion: 3}
+
+	got := AnalyzeAgentResultUsage(spans, 10*time.Minute, pricing)
+	if got.WindowMinutes != 10 || got.Considered != 3 || got.Ignored != 2 {
+		t.Fatalf("got window %d, %d considered, %d ignored; want 10, 3, 2", got.WindowMinutes, got.Considered, got.Ignored)
+	}
+	if got.WastedTokens != 1_000_000 {
+		t.Errorf("WastedTokens = %d, want 1000000", got.WastedTokens)
+	}
+	if math.Abs(got.EstimatedWasteUSD-3) > 1e-9 {
+		t.Errorf("EstimatedWasteUSD = %.4f, want 3", got.EstimatedWasteUSD)
+	}
+	if math.Abs(got.IgnoredRate-2.0/3) > 1e-9 {
+		t.Errorf("IgnoredRate = %.4f, want 0.6667", got.IgnoredRate)
+	}
+	if len(got.TopIgnored) != 2 || got.TopIgnored[0].Description != "never acted on" {
+		t.Errorf("TopIgnored = %+v, want the costliest ignored agent first", got.TopIgnored)
+	}
+
+	// A wider window counts the late edit as acting on the result.
+	wide := AnalyzeAgentResultUsage(spans, time.Hour, pricing)
+	if wide.Ignored != 1 {
+		t.Errorf("with a 60 min window Ignored = %d, want 1", wide.Ignored)
+	}
+}
+
+func TestAnalyzeAgentResultUsage_Empty(t *testing.T) {
+	got := AnalyzeAgentResultUsage(nil, 0, ModelPricing{})
+	if got.WindowMinutes != 10 || got.Considered != 0 || got.TopIgnored == nil {
+		t.Errorf("got %+v, want the default window, nothing considered, and a non-nil TopIgnored", got)
+	}
+}
@@ -1,11 +1,25 @@
 package analyzer
 
 import (
+	"strings"
+
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 )
 
-// AnalyzeAgents computes performance metrics for agent tasks.
-func AnalyzeAgents(tasks []claude.AgentTask) AgentPerformance {
+// CanonicalAgentType returns the name agentType is aliased to in aliases,
+// matching lowercase keys case-insensitively. Unmapped types pass through
+// unchanged.
+func CanonicalAgentType(agentType string, aliases map[string]string) string {
+	if canonical, ok := aliases[strings.ToLower(agentType)]; ok {
+		return canonical
+	}
+	return agentType
+}
+
+// AnalyzeAgents computes performance metrics for agent tasks. Agent types
+// are canonicalized through aliases before grouping, so aliased types
+// share one ByType entry; aliases may be nil.
+func AnalyzeAgents(tasks []claude.AgentTask, aliases map[string]string) AgentPerformance {
 	perf := AgentPerformance{
 		TotalAgents: len(tasks),
 		ByType:      make(map[string]AgentTypeStats),
@@ -40,11 +54,13 @@ func AnalyzeAgents(tasks []claude.AgentTask) AgentPerformance {
 		}
 
 		sessionAgentCount[task.SessionID]++
-		typeGroups[task.AgentType] = append(typeGroups[task.AgentType], task)
+		agentType := CanonicalAgentType(task.AgentType, aliases)
+		typeGroups[agentType] = append(typeGroups[agentType], task)
 	}
 
 	n := float64(len(tasks))
 	perf.SuccessRate = float64(successCount) / n
+	perf.SampleSize = len(tasks)
 	perf.KillRate = float64(killedCount) / n
 	perf.BackgroundRatio = float64(backgroundCount) / n
 	perf.AvgDurationMs = float64(totalDuration) / n
@@ -59,7 +75,7 @@ func AnalyzeAgents(tasks []claude.AgentTask) AgentPerformance {
 
 	// Compute per-type stats.
 	for agentType, typeTasks := range typeGroups {
-		var typeSuccess int
+		var typeSuccess, typeKilled int
 		var typeDuration int64
 		var typeTokens int
 
@@ -69,12 +85,16 @@ func AnalyzeAgents(tasks []claude.AgentTask) AgentPerformance {
 			if task.Status == "completed" {
 				typeSuccess++
 			}
+			if task.Status == "killed" {
+				typeKilled++
+			}
 		}
 
 		tn := float64(len(typeTasks))
 		perf.ByType[agentType] = AgentTypeStats{
 			Count:         len(typeTasks),
 			SuccessRate:   float64(typeSuccess) / tn,
+			KillRate:      float64(typeKilled) / tn,
 			AvgDurationMs: float64(typeDuration) / tn,
 			AvgTokens:     float64(typeTokens) / tn,
 		}
@@ -7,7 +7,7 @@ import (
 )
 
 func TestAnalyzeAgents_Empty(t *testing.T) {
-	perf := AnalyzeAgents(nil)
+	perf := AnalyzeAgents(nil, nil)
 	if perf.TotalAgents != 0 {
 		t.Errorf("TotalAgents = %d, want 0", perf.TotalAgents)
 	}
@@ -32,7 +32,7 @@ func TestAnalyzeAgents_SingleCompleted(t *testing.T) {
 		},
 	}
 
-	perf := AnalyzeAgents(tasks)
+	perf := AnalyzeAgents(tasks, nil)
 
 	if perf.TotalAgents != 1 {
 		t.Errorf("TotalAgents = %d, want 1", perf.TotalAgents)
@@ -65,7 +65,7 @@ func TestAnalyzeAgents_MixedStatuses(t *testing.T) {
 		{AgentID: "a4", AgentType: "reviewer", SessionID: "s2", Status: "failed", DurationMs: 500, TotalTokens: 100, Background: true},
 	}
 
-	perf := AnalyzeAgents(tasks)
+	perf := AnalyzeAgents(tasks, nil)
 
 	if perf.TotalAgents != 4 {
 		t.Errorf("TotalAgents = %d, want 4", perf.TotalAgents)
@@ -76,6 +76,9 @@ func TestAnalyzeAgents_MixedStatuses(t *testing.T) {
 	if perf.SuccessRate != expectedSuccess {
 		t.Errorf("SuccessRate = %v, want %v", perf.SuccessRate, expectedSuccess)
 	}
+	if perf.SampleSize != 4 {
+		t.Errorf("SampleSize = %d, want 4", perf.SampleSize)
+	}
 
 	// 1 killed out of 4.
 	expectedKill := 0.25
@@ -106,11 +109,17 @@ func TestAnalyzeAgents_MixedStatuses(t *testing.T) {
 	if writerStats.SuccessRate != 0.5 {
 		t.Errorf("writer success rate = %v, want 0.5", writerStats.SuccessRate)
 	}
+	if writerStats.KillRate != 0.5 {
+		t.Errorf("writer kill rate = %v, want 0.5", writerStats.KillRate)
+	}
 
 	reviewerStats := perf.ByType["reviewer"]
 	if reviewerStats.Count != 2 {
 		t.Errorf("reviewer count = %d, want 2", reviewerStats.Count)
 	}
+	if reviewerStats.KillRate != 0 {
+		t.Errorf("reviewer kill rate = %v, want 0 (failed is not killed)", reviewerStats.KillRate)
+	}
 }
 
 func TestAnalyzeAgents_SingleSession_NoParallel(t *testing.T) {
@@ -118,7 +127,7 @@ func TestAnalyzeAgents_SingleSession_NoParallel(t *testing.T) {
 		{AgentID: "a1", AgentType: "writer", SessionID: "s1", Status: "completed", DurationMs: 1000, TotalTokens: 100},
 	}
 
-	perf := AnalyzeAgents(tasks)
+	perf := AnalyzeAgents(tasks, nil)
 	if perf.ParallelSessions != 0 {
 		t.Errorf("ParallelSessions = %d, want 0 (only 1 agent in session)", perf.ParallelSessions)
 	}
@@ -130,7 +139,7 @@ func TestAnalyzeAgents_AllBackground(t *testing.T) {
 		{AgentID: "a2", SessionID: "s2", Status: "completed", Background: true, DurationMs: 200, TotalTokens: 150},
 	}
 
-	perf := AnalyzeAgents(tasks)
+	perf := AnalyzeAgents(tasks, nil)
 	if perf.BackgroundRatio != 1.0 {
 		t.Errorf("BackgroundRatio = %v, want 1.0", perf.BackgroundRatio)
 	}
@@ -142,7 +151,7 @@ func TestAnalyzeAgents_AverageDuration(t *testing.T) {
 		{AgentID: "a2", SessionID: "s2", Status: "completed", DurationMs: 3000, TotalTokens: 300},
 	}
 
-	perf := AnalyzeAgents(tasks)
+	perf := AnalyzeAgents(tasks, nil)
 	expectedAvgDuration := 2000.0
 	if perf.AvgDurationMs != expectedAvgDuration {
 		t.Errorf("AvgDurationMs = %v, want %v", perf.AvgDurationMs, expectedAvgDuration)
@@ -152,3 +161,54 @@ func TestAnalyzeAgents_AverageDuration(t *testing.T) {
 		t.Errorf("AvgTokensPerAgent = %v, want %v", perf.AvgTokensPerAgent, expectedAvgTokens)
 	}
 }
+
+func TestAnalyzeAgents_AliasesMerge(t *testing.T) {
+	tasks := []claude.AgentTask{
+		{AgentID: "a1", AgentType: "code-writer", SessionID: "s1", Status: "completed", DurationMs: 1000, TotalTokens: 100},
+		{AgentID: "a2", AgentType: "codewriter", SessionID: "s1", Status: "killed", DurationMs: 2000, TotalTokens: 200},
+		{AgentID: "a3", AgentType: "Writer", SessionID: "s2", Status: "completed", DurationMs: 3000, TotalTokens: 300},
+		{AgentID: "a4", AgentType: "reviewer", SessionID: "s2", Status: "completed", DurationMs: 4000, TotalTokens: 400},
+	}
+	aliases := map[string]string{"codewriter": "code-writer", "writer": "code-writer"}
+
+	perf := AnalyzeAgents(tasks, aliases)
+
+	if len(perf.ByType) != 2 {
+		t.Fatalf("ByType has %d entries, want 2: %v", len(perf.ByType), perf.ByType)
+	}
+	cw, ok := perf.ByType["code-writer"]
+	if !ok {
+		t.Fatal("missing merged code-writer entry")
+	}
+	if cw.Count != 3 {
+		t.Errorf("code-writer Count = %d, want 3", cw.Count)
+	}
+	if cw.AvgDurationMs != 2000 || cw.AvgTokens != 200 {
+		t.Errorf("code-writer averages = %vms %v tokens, want 2000ms 200 tokens", cw.AvgDurationMs, cw.AvgTokens)
+	}
+	if cw.KillRate != 1.0/3 {
+		t.Errorf("code-writer KillRate = %v, want 1/3", cw.KillRate)
+	}
+	// Unmapped types pass through unchanged.
+	if perf.ByType["reviewer"].Count != 1 {
+		t.Errorf("reviewer Count = %d, want 1", perf.ByType["reviewer"].Count)
+	}
+}
+
+func TestCanonicalAgentType(t *testing.T) {
+	aliases := map[string]string{"codewriter": "code-writer"}
+	tests := []struct{ in, want string }{
+		{"codewriter", "code-writer"},
+		{"CodeWriter", "code-writer"},
+		{"code-writer", "code-writer"},
+		{"Explore", "Explore"},
+	}
+	for _, tt := range tests {
+		if got := CanonicalAgentType(tt.in, aliases); got != tt.want {
+			t.Errorf("CanonicalAgentType(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := CanonicalAgentType("codewriter", nil); got != "codewriter" {
+		t.Errorf("with no aliases got %q, want codewriter", got)
+	}
+}
@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// budgetMinRateDays is the shortest window used to derive the daily spend
+// rate for month-end projections. Early in the month the window reaches back
+// into the previous month, so a single expensive first day doesn't get
+// extrapolated across the remaining thirty.
+const budgetMinRateDays = 7
+
+// MonthlyBudget reports estimated spend for the calendar month containing a
+// reference time, compared against a cap.
+type MonthlyBudget struct {
+	// Month is the first instant of the month, in the reference time's zone.
+	Month time.Time `json:"month"`
+
+	// CapUSD is the configured monthly cap; 0 means no cap.
+	CapUSD float64 `json:"cap_usd"`
+
+	// SpentUSD is the estimated cost of sessions started this month so far.
+	SpentUSD float64 `json:"spent_usd"`
+
+	// Sessions is the number of sessions counted in SpentUSD.
+	Sessions int `json:"sessions"`
+
+	// DaysInMonth is the number of calendar days in the month.
+	DaysInMonth int `json:"days_in_month"`
+
+	// DaysRemaining is the number of calendar days left after today.
+	DaysRemaining int `json:"days_remaining"`
+
+	// DailyRateUSD is the spend rate used for the projection.
+	DailyRateUSD float64 `json:"daily_rate_usd"`
+
+	// ProjectedUSD is SpentUSD plus DailyRateUSD over the rest of the month.
+	ProjectedUSD float64 `json:"projected_usd"`
+
+	// OverBudget is true when a cap is set and SpentUSD exceeds it.
+	OverBudget bool `json:"over_budget"`
+
+	// Forecast projects week and month-end spend from the daily spend trend.
+	Forecast CostForecast `json:"forecast"`
+}
+
+// AnalyzeMonthlyBudget sums EstimateSessionCost over sessions started in the
+// calendar month containing now and projects month-end spend by linear
+// extrapolation.
+//
+// The daily rate is spend over the trailing max(days elapsed, 7) days, so
+// projections stay stable in the first days of a month. Forecast adds a
+// trend-based projection with a confidence band; see ForecastCost.
+func AnalyzeMonthlyBudget(sessions []claude.SessionMeta, capUSD float64, now time.Time, pricing ModelPricing, ratio CacheRatio) MonthlyBudget {
+	loc := now.Location()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+
+	b := MonthlyBudget{
+		Month:       monthStart,
+		CapUSD:      capUSD,
+		DaysInMonth: monthEnd.AddDate(0, 0, -1).Day(),
+	}
+	b.DaysRemaining = b.DaysInMonth - now.Day()
+
+	elapsedDays := now.Sub(monthStart).Hours() / 24
+	rateDays := elapsedDays
+	if rateDays < budgetMinRateDays {
+		rateDays = budgetMinRateDays
+	}
+	rateStart := now.Add(-time.Duration(rateDays * 24 * float64(time.Hour)))
+
+	var rateSpend float64
+	for _, s := range sessions {
+		t := claude.ParseTimestamp(s.StartTime)
+		if t.IsZero() || t.After(now) {
+			continue
+		}
+		cost := EstimateSessionCost(s, pricing, ratio)
+		if !t.Before(monthStart) {
+			b.SpentUSD += cost
+			b.Sessions++
+		}
+		if !t.Before(rateStart) {
+			rateSpend += cost
+		}
+	}
+
+	b.DailyRateUSD = rateSpend / rateDays
+	b.ProjectedUSD = b.SpentUSD + b.DailyRateUSD*(monthEnd.Sub(now).Hours()/24)
+	b.OverBudget = capUSD > 0 && b.SpentUSD > capUSD
+	b.Forecast = ForecastCost(sessions, capUSD, now, pricing, ratio)
+
+	return b
+}
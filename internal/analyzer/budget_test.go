@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func budgetSession(id string, start time.Time) claude.SessionMeta {
+	return claude.SessionMeta{
+		SessionID:    id,
+		StartTime:    start.Format(time.RFC3339),
+		InputTokens:  1_000_000,
+		OutputTokens: 100_000,
+	}
+}
+
+func TestAnalyzeMonthlyBudget_SumsCurrentMonth(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	pricing := DefaultPricing["sonnet"]
+	ratio := NoCacheRatio()
+
+	sessions := []claude.SessionMeta{
+		budgetSession("a", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)),
+		budgetSession("b", time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)),
+		budgetSession("old", time.Date(2026, 2, 27, 9, 0, 0, 0, time.UTC)),
+	}
+	unit := EstimateSessionCost(sessions[0], pricing, ratio)
+
+	b := AnalyzeMonthlyBudget(sessions, 100, now, pricing, ratio)
+
+	if b.Sessions != 2 {
+		t.Errorf("Sessions = %d, want 2", b.Sessions)
+	}
+	if math.Abs(b.SpentUSD-2*unit) > 1e-9 {
+		t.Errorf("SpentUSD = %v, want %v", b.SpentUSD, 2*unit)
+	}
+	if b.DaysInMonth != 31 || b.DaysRemaining != 11 {
+		t.Errorf("days = %d remaining of %d, want 11 of 31", b.DaysRemaining, b.DaysInMonth)
+	}
+	if b.ProjectedUSD <= b.SpentUSD {
+		t.Errorf("expected projection above spend, got %v <= %v", b.ProjectedUSD, b.SpentUSD)
+	}
+	if b.OverBudget {
+		t.Error("expected not over budget")
+	}
+}
+
+func TestAnalyzeMonthlyBudget_EarlyMonthProjectionIsDamped(t *testing.T) {
+	// One expensive session a few hours into the month.
+	now := time.Date(2026, 4, 1, 6, 0, 0, 0, time.UTC)
+	pricing := DefaultPricing["sonnet"]
+	ratio := NoCacheRatio()
+	sessions := []claude.SessionMeta{
+		budgetSession("a", time.Date(2026, 4, 1, 1, 0, 0, 0, time.UTC)),
+	}
+	unit := EstimateSessionCost(sessions[0], pricing, ratio)
+
+	b := AnalyzeMonthlyBudget(sessions, 0, now, pricing, ratio)
+
+	// Naive extrapolation over 0.25 elapsed days would be ~120x the spend;
+	// a 7-day floor keeps it near 30/7 of it.
+	if b.ProjectedUSD > unit*6 {
+		t.Errorf("projection %v not damped (spend %v)", b.ProjectedUSD, unit)
+	}
+	if math.Abs(b.DailyRateUSD-unit/budgetMinRateDays) > 1e-9 {
+		t.Errorf("DailyRateUSD = %v, want %v", b.DailyRateUSD, unit/budgetMinRateDays)
+	}
+}
+
+func TestAnalyzeMonthlyBudget_OverBudget(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	pricing := DefaultPricing["sonnet"]
+	ratio := NoCacheRatio()
+	sessions := []claude.SessionMeta{
+		budgetSession("a", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)),
+	}
+	unit := EstimateSessionCost(sessions[0], pricing, ratio)
+
+	if b := AnalyzeMonthlyBudget(sessions, unit/2, now, pricing, ratio); !b.OverBudget {
+		t.Errorf("expected over budget with spend %v and cap %v", b.SpentUSD, b.CapUSD)
+	}
+	if b := AnalyzeMonthlyBudget(sessions, 0, now, pricing, ratio); b.OverBudget {
+		t.Error("expected no over-budget flag without a cap")
+	}
+}
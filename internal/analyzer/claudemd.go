@@ -4,9 +4,11 @@ import (
 	"bufio"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
 )
 
@@ -36,44 +38,59 @@ type ClaudeMDQuality struct {
 
 // ClaudeMDAnalysis is the top-level result of CLAUDE.md effectiveness analysis.
 type ClaudeMDAnalysis struct {
-	Projects             []ClaudeMDQuality  `json:"projects"`
+	Projects []ClaudeMDQuality `json:"projects"`
+	// SectionsCorrelation maps each section to the friction reduction, in
+	// percent, of projects with it over projects without. Negative values
+	// mean projects with the section have more friction.
 	SectionsCorrelation  map[string]float64 `json:"sections_correlation"`
 	MostImpactfulSection string             `json:"most_impactful_section"`
+	// SectionCautions maps sections that go with clearly more friction to
+	// how much more, in percent. Only sections with at least
+	// sectionCautionMinProjects projects on each side are included.
+	SectionCautions map[string]float64 `json:"section_cautions"`
 }
 
-// sectionDef defines a detectable section with its display name and keywords.
-type sectionDef struct {
-	Name            string
-	HeaderKeywords  []string // matched against ## header text (case-insensitive)
-	ContentKeywords []string // matched against body lines (case-insensitive)
-}
+// sectionCautionMinProjects is how many projects with sessions must have a
+// section, and how many must lack it, before it can be flagged as a caution.
+// A single prescriptive CLAUDE.md in a hard project shouldn't be enough.
+const sectionCautionMinProjects = 3
+
+// sectionCautionMinIncrease is how much more friction, in percent, projects
+// with a section must have before it is flagged as a caution.
+const sectionCautionMinIncrease = 20.0
 
-// knownSections lists the sections we detect in CLAUDE.md files.
-var knownSections = []sectionDef{
+// builtinSections are the sections detected without any configuration.
+// Keywords are lowercase and matched case-insensitively.
+var builtinSections = []config.ClaudeMDSection{
 	{
 		Name:            "build commands",
 		HeaderKeywords:  []string{"build", "compile", "make"},
 		ContentKeywords: []string{"build", "make", "go build", "npm run", "cargo build", "mvn", "gradle"},
+		Weight:          15,
 	},
 	{
 		Name:            "testing",
 		HeaderKeywords:  []string{"test", "testing"},
 		ContentKeywords: []string{"test", "go test", "pytest", "jest", "mocha", "cargo test", "npm test"},
+		Weight:          15,
 	},
 	{
 		Name:            "code conventions",
 		HeaderKeywords:  []string{"convention", "style", "naming", "format", "lint"},
 		ContentKeywords: []string{"convention", "style", "naming", "format", "lint", "prettier", "eslint", "gofmt"},
+		Weight:          10,
 	},
 	{
 		Name:            "architecture",
 		HeaderKeywords:  []string{"architecture", "structure", "layout", "overview", "packages", "organization"},
 		ContentKeywords: []string{"architecture", "structure", "layout", "packages", "directory", "modules"},
+		Weight:          15,
 	},
 	{
 		Name:            "error handling",
 		HeaderKeywords:  []string{"error", "debug", "troubleshoot", "logging"},
 		ContentKeywords: []string{"error", "debug", "troubleshoot", "logging", "panic", "exception"},
+		Weight:          10,
 	},
 	{
 		Name:            "dependencies",
@@ -82,6 +99,42 @@ var knownSections = []sectionDef{
 	},
 }
 
+// knownSections lists the sections we detect in CLAUDE.md files: the
+// built-in ones merged with any set by SetClaudeMDSections.
+var knownSections = builtinSections
+
+// SetClaudeMDSections resets the detected sections to the built-in ones and
+// merges custom over them. A custom section replaces the built-in section of
+// the same name, ignoring case, and is otherwise added after them. Keywords
+// are matched case-insensitively.
+//
+// knownSections is read without locking, so call this once at startup,
+// before any analysis runs.
+func SetClaudeMDSections(custom []config.ClaudeMDSection) {
+	sections := slices.Clone(builtinSections)
+	for _, c := range custom {
+		c.HeaderKeywords = lowerAll(c.HeaderKeywords)
+		c.ContentKeywords = lowerAll(c.ContentKeywords)
+		i := slices.IndexFunc(sections, func(s config.ClaudeMDSection) bool { return strings.EqualFold(s.Name, c.Name) })
+		if i < 0 {
+			sections = append(sections, c)
+			continue
+		}
+		c.Name = sections[i].Name
+		sections[i] = c
+	}
+	knownSections = sections
+}
+
+// lowerAll returns keywords lowercased.
+func lowerAll(keywords []string) []string {
+	out := make([]string, len(keywords))
+	for i, kw := range keywords {
+		out[i] = strings.ToLower(kw)
+	}
+	return out
+}
+
 // AnalyzeClaudeMDEffectiveness examines CLAUDE.md files across projects and
 // correlates their content quality with session friction rates from facet data.
 func AnalyzeClaudeMDEffectiveness(projects []scanner.Project, facets []claude.SessionFacet) ClaudeMDAnalysis {
@@ -104,6 +157,7 @@ func AnalyzeClaudeMDEffectiveness(projects []scanner.Project, facets []claude.Se
 	// Compute cross-project correlation: for each section, compare avg friction
 	// rate of projects that have it vs. those that don't.
 	analysis.SectionsCorrelation, analysis.MostImpactfulSection = computeSectionCorrelations(analysis.Projects)
+	analysis.SectionCautions = computeSectionCautions(analysis.Projects)
 
 	return analysis
 }
@@ -266,7 +320,8 @@ func matchesAnyKeyword(text string, keywords []string) bool {
 	return false
 }
 
-// computeQualityScore produces a 0-100 score based on the scoring rubric.
+// computeQualityScore produces a 0-100 score based on the scoring rubric;
+// each present section adds the Weight of its definition.
 func computeQualityScore(q ClaudeMDQuality) int {
 	score := 0
 
@@ -274,21 +329,13 @@ func computeQualityScore(q ClaudeMDQuality) int {
 	score += 20
 
 	// Section scores.
+	weights := make(map[string]int, len(knownSections))
+	for _, sd := range knownSections {
+		weights[sd.Name] = sd.Weight
+	}
 	for _, section := range q.Sections {
-		if !section.Present {
-			continue
-		}
-		switch section.Name {
-		case "build commands":
-			score += 15
-		case "testing":
-			score += 15
-		case "architecture":
-			score += 15
-		case "code conventions":
-			score += 10
-		case "error handling":
-			score += 10
+		if section.Present {
+			score += weights[section.Name]
 		}
 	}
 
@@ -352,23 +399,7 @@ func computeSectionCorrelations(projects []ClaudeMDQuality) (map[string]float64,
 
 	// For each section, compute avg friction for projects with vs without.
 	for _, sd := range knownSections {
-		var withFriction, withoutFriction []float64
-
-		for _, p := range projectsWithSessions {
-			hasSection := false
-			for _, s := range p.Sections {
-				if s.Name == sd.Name && s.Present {
-					hasSection = true
-					break
-				}
-			}
-
-			if hasSection {
-				withFriction = append(withFriction, p.AvgFrictionRate)
-			} else {
-				withoutFriction = append(withoutFriction, p.AvgFrictionRate)
-			}
-		}
+		withFriction, withoutFriction := sectionFrictionGroups(projectsWithSessions, sd.Name)
 
 		// Need data in both groups for a meaningful comparison.
 		if len(withFriction) == 0 || len(withoutFriction) == 0 {
@@ -400,6 +431,59 @@ func computeSectionCorrelations(projects []ClaudeMDQuality) (map[string]float64,
 	return correlations, bestSection
 }
 
+// computeSectionCautions returns the sections whose projects have at least
+// sectionCautionMinIncrease percent more friction than projects without
+// them, mapped to the increase. Both groups need sectionCautionMinProjects
+// projects with sessions, and the projects without the section need some
+// friction to compare against.
+func computeSectionCautions(projects []ClaudeMDQuality) map[string]float64 {
+	cautions := make(map[string]float64)
+
+	var projectsWithSessions []ClaudeMDQuality
+	for _, p := range projects {
+		if p.SessionCount > 0 {
+			projectsWithSessions = append(projectsWithSessions, p)
+		}
+	}
+
+	for _, sd := range knownSections {
+		withFriction, withoutFriction := sectionFrictionGroups(projectsWithSessions, sd.Name)
+		if len(withFriction) < sectionCautionMinProjects || len(withoutFriction) < sectionCautionMinProjects {
+			continue
+		}
+		avgWithout := avgFloat64(withoutFriction)
+		if avgWithout == 0 {
+			continue
+		}
+		increase := (avgFloat64(withFriction) - avgWithout) / avgWithout * 100
+		if increase >= sectionCautionMinIncrease {
+			cautions[sd.Name] = increase
+		}
+	}
+	return cautions
+}
+
+// sectionFrictionGroups splits the average friction rates of projects by
+// whether their CLAUDE.md has the named section.
+func sectionFrictionGroups(projects []ClaudeMDQuality, name string) (with, without []float64) {
+	for _, p := range projects {
+		hasSection := false
+		for _, s := range p.Sections {
+			if s.Name == name && s.Present {
+				hasSection = true
+				break
+			}
+		}
+
+		if hasSection {
+			with = append(with, p.AvgFrictionRate)
+		} else {
+			without = append(without, p.AvgFrictionRate)
+		}
+	}
+	return with, without
+}
+
 // avgFloat64 computes the mean of a float64 slice. Returns 0 for empty slices.
 func avgFloat64(vals []float64) float64 {
 	if len(vals) == 0 {
@@ -0,0 +1,213 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// ClaudeMDROIMediumReferences and ClaudeMDROIHighReferences are how many
+// reference projects a CLAUDE.md ROI estimate needs for medium and high
+// confidence. High confidence also needs MinSampleSize sessions in the
+// project being estimated.
+const (
+	ClaudeMDROIMediumReferences = 3
+	ClaudeMDROIHighReferences   = 5
+)
+
+// ClaudeMDROIEstimate projects what adding a CLAUDE.md would save one
+// project that doesn't have one.
+type ClaudeMDROIEstimate struct {
+	ProjectPath string `json:"project_path"`
+	ProjectName string `json:"project_name"`
+	Sessions    int    `json:"sessions"`
+	// MonthlyCost is the project's average spend per 30 days, measured
+	// from its first to its last session and never extrapolated past the
+	// sessions seen when they span less than 30 days.
+	MonthlyCost float64 `json:"monthly_cost"`
+	// EstimatedMonthlySavings is MonthlyCost scaled by the reference
+	// projects' average cost reduction; zero when they got no cheaper.
+	EstimatedMonthlySavings float64 `json:"estimated_monthly_savings"`
+	// FrictionPerSession is the project's friction events per faceted
+	// session, and EstimatedFrictionReduction how many of those the
+	// reference projects' average friction change would remove.
+	FrictionPerSession         float64 `json:"friction_per_session"`
+	EstimatedFrictionReduction float64 `json:"estimated_friction_reduction"`
+	// Confidence is "low", "medium", or "high", from the number of
+	// reference projects and the project's own session count.
+	Confidence string `json:"confidence"`
+}
+
+// ClaudeMDROI estimates the return on adding a CLAUDE.md to each project
+// without one, from how projects that have one changed after it was
+// written.
+type ClaudeMDROI struct {
+	// References is the number of projects with a CLAUDE.md and at least
+	// two sessions on each side of its modification time.
+	References        int `json:"references"`
+	ReferenceSessions int `json:"reference_sessions"`
+	// FrictionChangePct and CostChangePct are the references' average
+	// relative change in friction per session and cost per session after
+	// the CLAUDE.md change. Negative is an improvement.
+	FrictionChangePct float64 `json:"friction_change_pct"`
+	CostChangePct     float64 `json:"cost_change_pct"`
+	// Estimates holds one entry per project without a CLAUDE.md that has
+	// sessions, largest savings first. It is empty without references.
+	Estimates []ClaudeMDROIEstimate `json:"estimates"`
+}
+
+// EstimateClaudeMDROI projects the friction and cost savings of adding a
+// CLAUDE.md to each project in missing. Each change in changes is a
+// reference: its project's sessions are split at the CLAUDE.md modification
+// time, as AnalyzeEffectiveness does, and the relative change in friction
+// and cost per session is averaged across references. Applying those
+// averages to a missing project's own friction and monthly cost gives its
+// estimate.
+//
+// The modification time is the latest edit, not when the file was added, so
+// the references measure the effect of CLAUDE.md changes in general. The
+// result is an estimate and carries a confidence for that reason.
+func EstimateClaudeMDROI(
+	changes []ClaudeMDChange,
+	missing []string,
+	sessions []claude.SessionMeta,
+	facets []claude.SessionFacet,
+	pricing ModelPricing,
+	ratio CacheRatio,
+) ClaudeMDROI {
+	result := ClaudeMDROI{Estimates: []ClaudeMDROIEstimate{}}
+
+	sessionsByProject := make(map[string][]claude.SessionMeta)
+	for _, s := range sessions {
+		p := claude.NormalizePath(s.ProjectPath)
+		sessionsByProject[p] = append(sessionsByProject[p], s)
+	}
+	facetBySession := make(map[string]claude.SessionFacet, len(facets))
+	for _, f := range facets {
+		facetBySession[f.SessionID] = f
+	}
+
+	var frictionChanges, costChanges []float64
+	for _, change := range changes {
+		if change.ModifiedAt.IsZero() {
+			continue
+		}
+		var before, after []claude.SessionMeta
+		for _, s := range sessionsByProject[claude.NormalizePath(change.ProjectPath)] {
+			t := claude.ParseTimestamp(s.StartTime)
+			if t.IsZero() {
+				continue
+			}
+			if t.Before(change.ModifiedAt) {
+				before = append(before, s)
+			} else {
+				after = append(after, s)
+			}
+		}
+		if len(before) < 2 || len(after) < 2 {
+			continue
+		}
+		result.References++
+		result.ReferenceSessions += len(before) + len(after)
+
+		if was := avgFrictionRate(before, facetBySession); was > 0 {
+			frictionChanges = append(frictionChanges, relativeChangePct(was, avgFrictionRate(after, facetBySession)))
+		}
+		if was := costPerSession(before, pricing, ratio); was > 0 {
+			costChanges = append(costChanges, relativeChangePct(was, costPerSession(after, pricing, ratio)))
+		}
+	}
+	if result.References == 0 {
+		return result
+	}
+	result.FrictionChangePct = mean(frictionChanges)
+	result.CostChangePct = mean(costChanges)
+
+	for _, path := range missing {
+		projectSessions := sessionsByProject[claude.NormalizePath(path)]
+		if len(projectSessions) == 0 {
+			continue
+		}
+		e := ClaudeMDROIEstimate{
+			ProjectPath:        path,
+			ProjectName:        projectNameFromPath(path),
+			Sessions:           len(projectSessions),
+			MonthlyCost:        monthlyCost(projectSessions, pricing, ratio),
+			FrictionPerSession: avgFrictionRate(projectSessions, facetBySession),
+			Confidence:         claudeMDROIConfidence(result.References, len(projectSessions)),
+		}
+		if result.CostChangePct < 0 {
+			e.EstimatedMonthlySavings = e.MonthlyCost * -result.CostChangePct / 100
+		}
+		if result.FrictionChangePct < 0 {
+			e.EstimatedFrictionReduction = e.FrictionPerSession * -result.FrictionChangePct / 100
+		}
+		result.Estimates = append(result.Estimates, e)
+	}
+
+	sort.Slice(result.Estimates, func(i, j int) bool {
+		a, b := result.Estimates[i], result.Estimates[j]
+		if a.EstimatedMonthlySavings != b.EstimatedMonthlySavings {
+			return a.EstimatedMonthlySavings > b.EstimatedMonthlySavings
+		}
+		return a.ProjectName < b.ProjectName
+	})
+	return result
+}
+
+// claudeMDROIConfidence grades an estimate from the number of reference
+// projects behind it and the estimated project's own session count.
+func claudeMDROIConfidence(references, sessions int) string {
+	switch {
+	case references < ClaudeMDROIMediumReferences:
+		return "low"
+	case references < ClaudeMDROIHighReferences || sessions < MinSampleSize:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// relativeChangePct is the change from was to now as a percentage of was,
+// clamped to ±100 so one outlier can't dominate an average.
+func relativeChangePct(was, now float64) float64 {
+	return math.Max(-100, math.Min(100, (now-was)/was*100))
+}
+
+func costPerSession(sessions []claude.SessionMeta, pricing ModelPricing, ratio CacheRatio) float64 {
+	if len(sessions) == 0 {
+		return 0
+	}
+	var total float64
+	for _, s := range sessions {
+		total += EstimateSessionCost(s, pricing, ratio)
+	}
+	return total / float64(len(sessions))
+}
+
+// monthlyCost is the sessions' total cost per 30 days of the span from the
+// first to the last session, or their total cost when the span is shorter.
+func monthlyCost(sessions []claude.SessionMeta, pricing ModelPricing, ratio CacheRatio) float64 {
+	var total float64
+	var first, last time.Time
+	for _, s := range sessions {
+		total += EstimateSessionCost(s, pricing, ratio)
+		t := claude.ParseTimestamp(s.StartTime)
+		if t.IsZero() {
+			continue
+		}
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	days := last.Sub(first).Hours() / 24
+	if days <= 30 {
+		return total
+	}
+	return total * 30 / days
+}
@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// roiGroup is a run of sessions on project, one a day from start, each
+// with inputTokens input tokens and friction friction events.
+type roiGroup struct {
+	project                  string
+	start                    time.Time
+	n, inputTokens, friction int
+}
+
+// roiFixtures builds the sessions and facets for groups with makeGroup and
+// groupFacets.
+func roiFixtures(groups []roiGroup) ([]claude.SessionMeta, []claude.SessionFacet) {
+	var sessions []claude.SessionMeta
+	var facets []claude.SessionFacet
+	for _, g := range groups {
+		group := makeGroup(g.project+"-"+g.start.Format("0102"), g.project, g.n, 0)
+		for i := range group {
+			group[i].StartTime = g.start.AddDate(0, 0, i).Format(time.RFC3339)
+			group[i].InputTokens = g.inputTokens
+		}
+		sessions = append(sessions, group...)
+		facets = append(facets, groupFacets(group, 0, g.friction)...)
+	}
+	return sessions, facets
+}
+
+func TestEstimateClaudeMDROI_NoReferences(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	sessions, facets := roiFixtures([]roiGroup{{"/src/bare", start, 5, 1_000_000, 2}})
+	result := EstimateClaudeMDROI(nil, []string{"/src/bare"}, sessions, facets, DefaultPricing["sonnet"], NoCacheRatio())
+	if result.References != 0 || result.Estimates == nil || len(result.Estimates) != 0 {
+		t.Errorf("result = %+v, want no references and an empty estimate list", result)
+	}
+}
+
+func TestEstimateClaudeMDROI(t *testing.T) {
+	pricing := DefaultPricing["sonnet"]
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	changedAt := start.AddDate(0, 0, 10)
+
+	sessions, facets := roiFixtures([]roiGroup{
+		// Reference: cost and friction halve after the CLAUDE.md change.
+		{"/src/ref", start, 4, 2_000_000, 4},
+		{"/src/ref", changedAt, 4, 1_000_000, 2},
+		// Too few sessions after its change to count.
+		{"/src/thin", start, 4, 2_000_000, 4},
+		{"/src/thin", changedAt, 1, 1_000_000, 0},
+		// Missing projects: one spanning 60 days, one with no sessions.
+		{"/src/bare", start, 61, 1_000_000, 2},
+	})
+
+	changes := []ClaudeMDChange{
+		{ProjectPath: "/src/ref", ModifiedAt: changedAt},
+		{ProjectPath: "/src/thin", ModifiedAt: changedAt},
+	}
+	result := EstimateClaudeMDROI(changes, []string{"/src/bare", "/src/idle"}, sessions, facets, pricing, NoCacheRatio())
+
+	if result.References != 1 || result.ReferenceSessions != 8 {
+		t.Errorf("references = %d (%d sessions), want 1 (8)", result.References, result.ReferenceSessions)
+	}
+	if math.Abs(result.CostChangePct+50) > 1e-9 || math.Abs(result.FrictionChangePct+50) > 1e-9 {
+		t.Errorf("changes = %.1f%% cost, %.1f%% friction; want -50%% each", result.CostChangePct, result.FrictionChangePct)
+	}
+	if len(result.Estimates) != 1 {
+		t.Fatalf("Estimates = %+v, want only bare", result.Estimates)
+	}
+
+	e := result.Estimates[0]
+	perSession := EstimateSessionCost(sessions[len(sessions)-1], pricing, NoCacheRatio())
+	if e.ProjectName != "bare" || e.Sessions != 61 || e.Confidence != "low" {
+		t.Errorf("estimate = %+v", e)
+	}
+	// 61 sessions over 60 days is 30.5 sessions a month.
+	if want := perSession * 30.5; math.Abs(e.MonthlyCost-want) > 1e-9 {
+		t.Errorf("MonthlyCost = %.4f, want %.4f", e.MonthlyCost, want)
+	}
+	if math.Abs(e.EstimatedMonthlySavings-e.MonthlyCost/2) > 1e-9 {
+		t.Errorf("EstimatedMonthlySavings = %.4f, want half of %.4f", e.EstimatedMonthlySavings, e.MonthlyCost)
+	}
+	if e.FrictionPerSession != 2 || e.EstimatedFrictionReduction != 1 {
+		t.Errorf("friction = %.1f/session, reduction %.1f; want 2 and 1", e.FrictionPerSession, e.EstimatedFrictionReduction)
+	}
+}
+
+func TestEstimateClaudeMDROI_NoSavingsWhenReferencesGotWorse(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	changedAt := start.AddDate(0, 0, 10)
+	sessions, facets := roiFixtures([]roiGroup{
+		{"/src/ref", start, 3, 1_000_000, 1},
+		{"/src/ref", changedAt, 3, 2_000_000, 1},
+		{"/src/bare", start, 3, 1_000_000, 1},
+	})
+
+	result := EstimateClaudeMDROI([]ClaudeMDChange{{ProjectPath: "/src/ref", ModifiedAt: changedAt}}, []string{"/src/bare"}, sessions, facets, DefaultPricing["sonnet"], NoCacheRatio())
+	if len(result.Estimates) != 1 {
+		t.Fatalf("Estimates = %+v, want bare", result.Estimates)
+	}
+	if e := result.Estimates[0]; e.EstimatedMonthlySavings != 0 || e.EstimatedFrictionReduction != 0 {
+		t.Errorf("estimate = %+v, want no savings", e)
+	}
+}
+
+func TestClaudeMDROIConfidence(t *testing.T) {
+	tests := []struct {
+		references, sessions int
+		want                 string
+	}{
+		{1, 50, "low"},
+		{3, 50, "medium"},
+		{5, 5, "medium"},
+		{5, MinSampleSize, "high"},
+	}
+	for _, tt := range tests {
+		if got := claudeMDROIConfidence(tt.references, tt.sessions); got != tt.want {
+			t.Errorf("claudeMDROIConfidence(%d, %d) = %q, want %q", tt.references, tt.sessions, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+)
+
+// claudeMDStaleMinEdits is how many file edits, across sessions since the
+// CLAUDE.md last changed, count as heavy churn.
+const claudeMDStaleMinEdits = 50
+
+// ClaudeMDStaleness compares one project's CLAUDE.md age with the edits made
+// in its sessions since the file last changed.
+type ClaudeMDStaleness struct {
+	ProjectPath string    `json:"project_path"`
+	ProjectName string    `json:"project_name"`
+	Modified    time.Time `json:"modified"`
+	// DaysSinceUpdate is the whole days since CLAUDE.md was last modified.
+	DaysSinceUpdate int `json:"days_since_update"`
+	// SessionsSince counts the project's sessions started after CLAUDE.md
+	// was last modified; EditsSince and FilesSince sum their file-history
+	// edits and edited files.
+	SessionsSince int `json:"sessions_since"`
+	EditsSince    int `json:"edits_since"`
+	FilesSince    int `json:"files_since"`
+	// Stale is set when CLAUDE.md is at least StaleDays old and at least
+	// claudeMDStaleMinEdits edits have been made since.
+	Stale bool `json:"stale"`
+}
+
+// StalenessAnalysis is the CLAUDE.md staleness of every project that has one.
+type StalenessAnalysis struct {
+	StaleDays  int                 `json:"stale_days"`
+	StaleCount int                 `json:"stale_count"`
+	Projects   []ClaudeMDStaleness `json:"projects"`
+}
+
+// AnalyzeClaudeMDStaleness flags projects whose CLAUDE.md has gone staleDays
+// or more without changes while the code kept churning, judged by the
+// file-history edits of the project's sessions since the file's mtime.
+// Projects without a CLAUDE.md are skipped. staleDays below 1 uses
+// config.DefaultClaudeMDStaleDays. Results are sorted stale first, then by edits.
+func AnalyzeClaudeMDStaleness(projects []scanner.Project, sessions []claude.SessionMeta, fileHistory []claude.FileHistorySession, staleDays int) StalenessAnalysis {
+	if staleDays < 1 {
+		staleDays = config.DefaultClaudeMDStaleDays
+	}
+	result := StalenessAnalysis{StaleDays: staleDays}
+
+	historyBySession := make(map[string]claude.FileHistorySession, len(fileHistory))
+	for _, h := range fileHistory {
+		historyBySession[h.SessionID] = h
+	}
+
+	now := time.Now()
+	for _, p := range projects {
+		if !p.HasClaudeMD {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(p.Path, "CLAUDE.md"))
+		if err != nil {
+			continue
+		}
+
+		s := ClaudeMDStaleness{
+			ProjectPath:     p.Path,
+			ProjectName:     p.Name,
+			Modified:        info.ModTime(),
+			DaysSinceUpdate: int(now.Sub(info.ModTime()).Hours() / 24),
+		}
+		projectPath := claude.NormalizePath(p.Path)
+		for _, sess := range sessions {
+			if claude.NormalizePath(sess.ProjectPath) != projectPath {
+				continue
+			}
+			if !claude.ParseTimestamp(sess.StartTime).After(s.Modified) {
+				continue
+			}
+			s.SessionsSince++
+			h := historyBySession[sess.SessionID]
+			s.EditsSince += h.TotalEdits
+			s.FilesSince += h.UniqueFiles
+		}
+		s.Stale = s.DaysSinceUpdate >= staleDays && s.EditsSince >= claudeMDStaleMinEdits
+		if s.Stale {
+			result.StaleCount++
+		}
+		result.Projects = append(result.Projects, s)
+	}
+
+	sort.SliceStable(result.Projects, func(i, j int) bool {
+		a, b := result.Projects[i], result.Projects[j]
+		if a.Stale != b.Stale {
+			return a.Stale
+		}
+		return a.EditsSince > b.EditsSince
+	})
+	return result
+}
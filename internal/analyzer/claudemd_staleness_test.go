@@ -0,0 +1,84 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+)
+
+// claudeMDProject writes a CLAUDE.md into a temp project last modified
+// daysAgo days ago.
+func claudeMDProject(t *testing.T, name string, daysAgo int) scanner.Project {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeClaudeMD(t, dir, "# "+name+"\n")
+	mtime := time.Now().AddDate(0, 0, -daysAgo)
+	if err := os.Chtimes(filepath.Join(dir, "CLAUDE.md"), mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	return scanner.Project{Path: dir, Name: name, HasClaudeMD: true}
+}
+
+func TestAnalyzeClaudeMDStaleness(t *testing.T) {
+	stale := claudeMDProject(t, "stale", 90)
+	fresh := claudeMDProject(t, "fresh", 10)
+	quiet := claudeMDProject(t, "quiet", 90)
+	missing := scanner.Project{Path: t.TempDir(), Name: "missing"}
+
+	recent := time.Now().AddDate(0, 0, -5).Format(time.RFC3339)
+	old := time.Now().AddDate(0, 0, -120).Format(time.RFC3339)
+	sessions := []claude.SessionMeta{
+		{SessionID: "s1", ProjectPath: stale.Path, StartTime: recent},
+		{SessionID: "s2", ProjectPath: stale.Path, StartTime: recent},
+		{SessionID: "s3", ProjectPath: stale.Path, StartTime: old},
+		{SessionID: "f1", ProjectPath: fresh.Path, StartTime: recent},
+		{SessionID: "q1", ProjectPath: quiet.Path, StartTime: recent},
+		{SessionID: "m1", ProjectPath: missing.Path, StartTime: recent},
+	}
+	history := []claude.FileHistorySession{
+		{SessionID: "s1", TotalEdits: 40, UniqueFiles: 10},
+		{SessionID: "s2", TotalEdits: 30, UniqueFiles: 8},
+		// Before the CLAUDE.md change, so it doesn't count.
+		{SessionID: "s3", TotalEdits: 500, UniqueFiles: 50},
+		{SessionID: "f1", TotalEdits: 200, UniqueFiles: 20},
+		{SessionID: "q1", TotalEdits: 5, UniqueFiles: 2},
+		{SessionID: "m1", TotalEdits: 500, UniqueFiles: 50},
+	}
+
+	result := AnalyzeClaudeMDStaleness([]scanner.Project{fresh, missing, quiet, stale}, sessions, history, 60)
+	if len(result.Projects) != 3 {
+		t.Fatalf("expected 3 projects with a CLAUDE.md, got %d", len(result.Projects))
+	}
+	if result.StaleCount != 1 {
+		t.Errorf("expected 1 stale project, got %d", result.StaleCount)
+	}
+
+	s := result.Projects[0]
+	if s.ProjectName != "stale" || !s.Stale {
+		t.Fatalf("expected the stale project first, got %+v", s)
+	}
+	if s.SessionsSince != 2 || s.EditsSince != 70 || s.FilesSince != 18 {
+		t.Errorf("expected 2 sessions, 70 edits, 18 files since the update, got %+v", s)
+	}
+	if s.DaysSinceUpdate < 89 || s.DaysSinceUpdate > 90 {
+		t.Errorf("expected about 90 days since update, got %d", s.DaysSinceUpdate)
+	}
+
+	for _, p := range result.Projects[1:] {
+		if p.Stale {
+			t.Errorf("expected %s not to be stale: %+v", p.ProjectName, p)
+		}
+	}
+
+	// A longer window clears the flag.
+	if r := AnalyzeClaudeMDStaleness([]scanner.Project{stale}, sessions, history, 120); r.StaleCount != 0 {
+		t.Errorf("expected no stale projects with a 120-day window, got %d", r.StaleCount)
+	}
+}
@@ -1,11 +1,13 @@
 package analyzer
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
 )
 
@@ -381,6 +383,57 @@ func TestComputeSectionCorrelations_NoSessions(t *testing.T) {
 	}
 }
 
+// sectionProjects returns n projects with sessions and the given friction
+// rate, each with or without a testing section.
+func sectionProjects(prefix string, n int, friction float64, hasTesting bool) []ClaudeMDQuality {
+	var projects []ClaudeMDQuality
+	for i := range n {
+		projects = append(projects, ClaudeMDQuality{
+			ProjectName:     fmt.Sprintf("%s%d", prefix, i),
+			SessionCount:    5,
+			AvgFrictionRate: friction,
+			Sections:        []ClaudeMDSection{{Name: "testing", Present: hasTesting}},
+		})
+	}
+	return projects
+}
+
+func TestComputeSectionCautions(t *testing.T) {
+	projects := append(sectionProjects("with", 3, 3.0, true), sectionProjects("without", 3, 2.0, false)...)
+
+	correlations, _ := computeSectionCorrelations(projects)
+	if corr := correlations["testing"]; corr > -49 || corr < -51 {
+		t.Errorf("expected testing correlation ~-50%%, got %.2f%%", corr)
+	}
+
+	cautions := computeSectionCautions(projects)
+	if increase := cautions["testing"]; increase < 49 || increase > 51 {
+		t.Errorf("expected testing caution ~50%%, got %v", cautions)
+	}
+	if len(cautions) != 1 {
+		t.Errorf("expected only testing flagged, got %v", cautions)
+	}
+}
+
+func TestComputeSectionCautions_RequiresEnoughProjects(t *testing.T) {
+	// Friction is much higher with the section, but only two projects have it.
+	projects := append(sectionProjects("with", 2, 5.0, true), sectionProjects("without", 5, 1.0, false)...)
+	if cautions := computeSectionCautions(projects); len(cautions) != 0 {
+		t.Errorf("expected no cautions with two projects in a group, got %v", cautions)
+	}
+}
+
+func TestComputeSectionCautions_SmallIncreaseOrHelps(t *testing.T) {
+	slight := append(sectionProjects("with", 3, 2.2, true), sectionProjects("without", 3, 2.0, false)...)
+	if cautions := computeSectionCautions(slight); len(cautions) != 0 {
+		t.Errorf("expected no caution for a 10%% increase, got %v", cautions)
+	}
+	helps := append(sectionProjects("with", 3, 1.0, true), sectionProjects("without", 3, 2.0, false)...)
+	if cautions := computeSectionCautions(helps); len(cautions) != 0 {
+		t.Errorf("expected no caution for a section that reduces friction, got %v", cautions)
+	}
+}
+
 func TestComputeProjectFriction(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -446,3 +499,41 @@ func TestAvgFloat64(t *testing.T) {
 		})
 	}
 }
+
+func TestSetClaudeMDSections(t *testing.T) {
+	defer SetClaudeMDSections(nil)
+	SetClaudeMDSections([]config.ClaudeMDSection{
+		{Name: "Deployment", HeaderKeywords: []string{"Deploy"}, ContentKeywords: []string{"kubectl", "helm"}, Weight: 10},
+		// Overrides the built-in section, keeping its name.
+		{Name: "Testing", HeaderKeywords: []string{"qa"}, ContentKeywords: []string{"qa"}, Weight: 5},
+	})
+
+	if len(knownSections) != len(builtinSections)+1 {
+		t.Fatalf("got %d sections, want built-ins plus deployment", len(knownSections))
+	}
+	lines := []string{"# Project", "## Deploy to staging", "Run helm upgrade.", "## Testing", "Run go test ./..."}
+	present := make(map[string]bool)
+	for _, s := range detectSections(lines) {
+		present[s.Name] = s.Present
+	}
+	if !present["Deployment"] {
+		t.Error("custom section should be detected by its lowercased header keyword")
+	}
+	if present["testing"] {
+		t.Error("overridden testing section should only match its new keywords")
+	}
+
+	q := ClaudeMDQuality{Sections: []ClaudeMDSection{
+		{Name: "Deployment", Present: true},
+		{Name: "testing", Present: true},
+		{Name: "build commands", Present: true},
+	}}
+	if got := computeQualityScore(q); got != 20+10+5+15 {
+		t.Errorf("computeQualityScore = %d, want 50", got)
+	}
+
+	SetClaudeMDSections(nil)
+	if len(knownSections) != len(builtinSections) {
+		t.Errorf("SetClaudeMDSections(nil) should restore the built-in sections")
+	}
+}
@@ -25,6 +25,9 @@ type CommitAnalysis struct {
 	// ZeroCommitRate is the fraction of sessions with zero commits.
 	ZeroCommitRate float64 `json:"zero_commit_rate"`
 
+	// SampleSize is the number of sessions behind ZeroCommitRate.
+	SampleSize int `json:"sample_size"`
+
 	// AvgCommitsPerSession is the mean git commits across all sessions.
 	AvgCommitsPerSession float64 `json:"avg_commits_per_session"`
 
@@ -37,6 +40,53 @@ type CommitAnalysis struct {
 
 	// WeeklyCommitRates tracks the commit rate by week for trend analysis.
 	WeeklyCommitRates []WeeklyCommitRate `json:"weekly_commit_rates"`
+
+	// ByProject breaks the zero-commit rate down by project, highest rate
+	// first, so the project driving it can be named. Sessions without a
+	// project path are left out.
+	ByProject []ProjectCommitStats `json:"by_project"`
+}
+
+// ZeroCommitProjectMinSessions is how many sessions a project needs before
+// TopZeroCommitProjects reports it, so a single abandoned session doesn't
+// rank as a 100% zero-commit project.
+const ZeroCommitProjectMinSessions = 5
+
+// ProjectCommitStats is one project's commit rates.
+type ProjectCommitStats struct {
+	// ProjectPath is the project's normalized path.
+	ProjectPath string `json:"project_path"`
+
+	// ProjectName is the short directory name of the project.
+	ProjectName string `json:"project_name"`
+
+	// Sessions is the project's session count.
+	Sessions int `json:"sessions"`
+
+	// ZeroCommitSessions is the count of its sessions with zero commits.
+	ZeroCommitSessions int `json:"zero_commit_sessions"`
+
+	// ZeroCommitRate is ZeroCommitSessions / Sessions.
+	ZeroCommitRate float64 `json:"zero_commit_rate"`
+
+	// AvgCommitsPerSession is the mean git commits across its sessions.
+	AvgCommitsPerSession float64 `json:"avg_commits_per_session"`
+}
+
+// TopZeroCommitProjects returns up to n projects with at least
+// ZeroCommitProjectMinSessions sessions and a nonzero zero-commit rate,
+// highest rate first.
+func (a CommitAnalysis) TopZeroCommitProjects(n int) []ProjectCommitStats {
+	var top []ProjectCommitStats
+	for _, p := range a.ByProject {
+		if len(top) == n {
+			break
+		}
+		if p.Sessions >= ZeroCommitProjectMinSessions && p.ZeroCommitRate > 0 {
+			top = append(top, p)
+		}
+	}
+	return top
 }
 
 // ZeroCommitSession captures details about a session that produced no commits.
@@ -92,6 +142,8 @@ func AnalyzeCommits(sessions []claude.SessionMeta) CommitAnalysis {
 	weekBuckets := make(map[string]*weekBucket)
 
 	var totalCommits int
+	byProject := make(map[string]*ProjectCommitStats)
+	projectCommits := make(map[string]int)
 
 	for _, s := range sessions {
 		t := claude.ParseTimestamp(s.StartTime)
@@ -116,6 +168,20 @@ func AnalyzeCommits(sessions []claude.SessionMeta) CommitAnalysis {
 			analysis.ZeroCommitSessions = append(analysis.ZeroCommitSessions, zcs)
 		}
 
+		if s.ProjectPath != "" {
+			path := claude.NormalizePath(s.ProjectPath)
+			ps, ok := byProject[path]
+			if !ok {
+				ps = &ProjectCommitStats{ProjectPath: path, ProjectName: filepath.Base(path)}
+				byProject[path] = ps
+			}
+			ps.Sessions++
+			if s.GitCommits == 0 {
+				ps.ZeroCommitSessions++
+			}
+			projectCommits[path] += s.GitCommits
+		}
+
 		// Bucket into weekly slots.
 		monday := weekStartMonday(t)
 		key := monday.Format("2006-01-02")
@@ -136,6 +202,7 @@ func AnalyzeCommits(sessions []claude.SessionMeta) CommitAnalysis {
 
 	if analysis.TotalSessions > 0 {
 		analysis.ZeroCommitRate = float64(analysis.SessionsZeroCommits) / n
+		analysis.SampleSize = analysis.TotalSessions
 	}
 
 	// Sort zero-commit sessions by duration descending.
@@ -146,6 +213,8 @@ func AnalyzeCommits(sessions []claude.SessionMeta) CommitAnalysis {
 	// Build sorted weekly commit rates.
 	analysis.WeeklyCommitRates = buildWeeklyRates(weekBuckets)
 
+	analysis.ByProject = buildProjectCommitStats(byProject, projectCommits)
+
 	return analysis
 }
 
@@ -181,6 +250,31 @@ func buildWeeklyRates(buckets map[string]*weekBucket) []WeeklyCommitRate {
 	return rates
 }
 
+// buildProjectCommitStats fills in each project's rates and orders them by
+// zero-commit rate descending, then by session count, so busier projects
+// win ties.
+func buildProjectCommitStats(byProject map[string]*ProjectCommitStats, commits map[string]int) []ProjectCommitStats {
+	stats := make([]ProjectCommitStats, 0, len(byProject))
+	for path, ps := range byProject {
+		n := float64(ps.Sessions)
+		ps.ZeroCommitRate = float64(ps.ZeroCommitSessions) / n
+		ps.AvgCommitsPerSession = float64(commits[path]) / n
+		stats = append(stats, *ps)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.ZeroCommitRate != b.ZeroCommitRate {
+			return a.ZeroCommitRate > b.ZeroCommitRate
+		}
+		if a.Sessions != b.Sessions {
+			return a.Sessions > b.Sessions
+		}
+		return a.ProjectPath < b.ProjectPath
+	})
+	return stats
+}
+
 // topNTools returns the names of the top N tools by usage count from the
 // provided tool counts map.
 func topNTools(toolCounts map[string]int, n int) []string {
@@ -211,14 +305,15 @@ func topNTools(toolCounts map[string]int, n int) []string {
 	return tools
 }
 
-// weekStartMonday returns the Monday 00:00:00 UTC for the ISO week
-// containing the given time.
+// weekStartMonday returns midnight on the Monday of the ISO week containing
+// t, in t's own location. Session times from claude.ParseSessionTime share a
+// single configured zone, so weeks split on local midnight rather than UTC.
 func weekStartMonday(t time.Time) time.Time {
-	t = t.UTC()
 	weekday := t.Weekday()
 	if weekday == time.Sunday {
 		weekday = 7
 	}
-	monday := t.AddDate(0, 0, -int(weekday-time.Monday))
-	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, time.UTC)
+	// Step back by calendar days rather than 24h multiples so a DST
+	// transition inside the week can't land on the wrong date.
+	return time.Date(t.Year(), t.Month(), t.Day()-int(weekday-time.Monday), 0, 0, 0, 0, t.Location())
 }
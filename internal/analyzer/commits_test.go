@@ -183,6 +183,9 @@ func TestAnalyzeCommits_MixedCommits(t *testing.T) {
 	if diff := result.ZeroCommitRate - wantRate; diff > 0.001 || diff < -0.001 {
 		t.Errorf("ZeroCommitRate = %.4f, want %.4f", result.ZeroCommitRate, wantRate)
 	}
+	if result.SampleSize != 4 {
+		t.Errorf("SampleSize = %d, want 4", result.SampleSize)
+	}
 
 	// AvgCommitsPerSession = 4 / 4 = 1.0
 	if diff := result.AvgCommitsPerSession - 1.0; diff > 0.001 || diff < -0.001 {
@@ -340,7 +343,7 @@ func TestWeekStartMonday(t *testing.T) {
 			if monday.Day() != tt.wantDay {
 				t.Errorf("weekStartMonday() day = %d, want %d", monday.Day(), tt.wantDay)
 			}
-			// Should be at midnight UTC.
+			// Should be at midnight.
 			if monday.Hour() != 0 || monday.Minute() != 0 || monday.Second() != 0 {
 				t.Errorf("weekStartMonday() not at midnight: %v", monday)
 			}
@@ -426,3 +429,95 @@ func TestAnalyzeCommits_SingleSession(t *testing.T) {
 		t.Errorf("expected weekly rate 1.0, got %f", result.WeeklyCommitRates[0].Rate)
 	}
 }
+
+func TestWeekStartMonday_DSTBoundary(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	// US clocks spring forward on Sunday 2026-03-08, so the week of Monday
+	// 2026-03-02 is only 167 hours long.
+	tests := []struct {
+		name  string
+		input time.Time
+		want  time.Time
+	}{
+		{"sunday before transition", time.Date(2026, 3, 8, 1, 30, 0, 0, ny), time.Date(2026, 3, 2, 0, 0, 0, 0, ny)},
+		{"sunday after transition", time.Date(2026, 3, 8, 23, 30, 0, 0, ny), time.Date(2026, 3, 2, 0, 0, 0, 0, ny)},
+		{"monday after transition", time.Date(2026, 3, 9, 0, 30, 0, 0, ny), time.Date(2026, 3, 9, 0, 0, 0, 0, ny)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := weekStartMonday(tt.input)
+			if !got.Equal(tt.want) || got.Location() != ny {
+				t.Errorf("weekStartMonday(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCommits_WeeklyBucketsUseSessionTimeLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	claude.SetSessionTimeLocation(ny)
+	t.Cleanup(func() { claude.SetSessionTimeLocation(nil) })
+
+	// Sunday 2026-03-08 22:00 in New York is already Monday in UTC; it must
+	// still count toward the local week that started 2026-03-02.
+	sessions := []claude.SessionMeta{
+		{SessionID: "s1", StartTime: "2026-03-03T15:00:00Z", GitCommits: 1},
+		{SessionID: "s2", StartTime: "2026-03-09T02:00:00Z", GitCommits: 0},
+		{SessionID: "s3", StartTime: "2026-03-09T14:00:00Z", GitCommits: 1},
+	}
+
+	result := AnalyzeCommits(sessions)
+	if len(result.WeeklyCommitRates) != 2 {
+		t.Fatalf("expected 2 weekly buckets, got %d", len(result.WeeklyCommitRates))
+	}
+	if w := result.WeeklyCommitRates[0]; w.Sessions != 2 || w.WeekStart.Day() != 2 {
+		t.Errorf("week 1: got %d sessions starting day %d, want 2 sessions starting day 2", w.Sessions, w.WeekStart.Day())
+	}
+	if w := result.WeeklyCommitRates[1]; w.Sessions != 1 || w.WeekStart.Day() != 9 {
+		t.Errorf("week 2: got %d sessions starting day %d, want 1 session starting day 9", w.Sessions, w.WeekStart.Day())
+	}
+}
+
+func TestAnalyzeCommits_ByProject(t *testing.T) {
+	var sessions []claude.SessionMeta
+	add := func(project string, commits ...int) {
+		for _, c := range commits {
+			sessions = append(sessions, claude.SessionMeta{ProjectPath: project, GitCommits: c})
+		}
+	}
+	add("/src/api", 2, 0, 1, 1, 0)
+	add("/src/sandbox", 0, 0, 0, 0, 0, 1)
+	add("/src/web", 3, 1, 0, 0, 0)
+	add("/src/once", 0)
+	add("", 0)
+
+	result := AnalyzeCommits(sessions)
+	want := []ProjectCommitStats{
+		{ProjectPath: "/src/once", ProjectName: "once", Sessions: 1, ZeroCommitSessions: 1, ZeroCommitRate: 1, AvgCommitsPerSession: 0},
+		{ProjectPath: "/src/sandbox", ProjectName: "sandbox", Sessions: 6, ZeroCommitSessions: 5, ZeroCommitRate: 5.0 / 6, AvgCommitsPerSession: 1.0 / 6},
+		{ProjectPath: "/src/web", ProjectName: "web", Sessions: 5, ZeroCommitSessions: 3, ZeroCommitRate: 0.6, AvgCommitsPerSession: 0.8},
+		{ProjectPath: "/src/api", ProjectName: "api", Sessions: 5, ZeroCommitSessions: 2, ZeroCommitRate: 0.4, AvgCommitsPerSession: 0.8},
+	}
+	if len(result.ByProject) != len(want) {
+		t.Fatalf("ByProject = %+v, want %d projects", result.ByProject, len(want))
+	}
+	for i := range want {
+		if result.ByProject[i] != want[i] {
+			t.Errorf("ByProject[%d] = %+v, want %+v", i, result.ByProject[i], want[i])
+		}
+	}
+
+	// once has too few sessions to rank.
+	top := result.TopZeroCommitProjects(2)
+	if len(top) != 2 || top[0].ProjectName != "sandbox" || top[1].ProjectName != "web" {
+		t.Errorf("TopZeroCommitProjects(2) = %+v, want sandbox then web", top)
+	}
+}
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/blackwell-systems/claudewatch/internal/claude"
@@ -34,6 +35,45 @@ func makeFacet(sessionID string, frictionCounts map[string]int) claude.SessionFa
 	}
 }
 
+// makeGroup creates n sessions with makeMeta on project, with IDs prefix-0
+// onwards, the first committed of which have one commit each.
+func makeGroup(prefix, project string, n, committed int) []claude.SessionMeta {
+	sessions := make([]claude.SessionMeta, n)
+	for i := range sessions {
+		commits := 0
+		if i < committed {
+			commits = 1
+		}
+		sessions[i] = makeMeta(fmt.Sprintf("%s-%d", prefix, i), "2026-01-01T10:00:00Z", 0, 0, commits, 0)
+		sessions[i].ProjectPath = project
+	}
+	return sessions
+}
+
+// groupFacets creates a facet with makeFacet for each session, with friction
+// wrong_approach events, an achieved outcome for the first achieved sessions,
+// and not_achieved for the rest.
+func groupFacets(sessions []claude.SessionMeta, achieved, friction int) []claude.SessionFacet {
+	facets := make([]claude.SessionFacet, len(sessions))
+	for i, s := range sessions {
+		facets[i] = makeFacet(s.SessionID, map[string]int{"wrong_approach": friction})
+		facets[i].Outcome = "not_achieved"
+		if i < achieved {
+			facets[i].Outcome = "achieved"
+		}
+	}
+	return facets
+}
+
+// groupTasks creates one agentType task with status for each session.
+func groupTasks(sessions []claude.SessionMeta, agentType, status string) []claude.AgentTask {
+	tasks := make([]claude.AgentTask, len(sessions))
+	for i, s := range sessions {
+		tasks[i] = claude.AgentTask{SessionID: s.SessionID, AgentType: agentType, Status: status}
+	}
+	return tasks
+}
+
 func TestCompareSAWVsSequential_AllSAW(t *testing.T) {
 	sessions := []claude.SessionMeta{
 		makeMeta("s1", "2026-01-01T10:00:00Z", 1_000_000, 100_000, 3, 0),
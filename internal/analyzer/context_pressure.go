@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// ContextPressureThreshold is the share of the context window a session's
+// peak context must reach to count as near the limit.
+const ContextPressureThreshold = 0.8
+
+// contextPressureWorstN is how many near-limit sessions are listed.
+const contextPressureWorstN = 5
+
+// ContextPressureAnalysis reports sessions whose context approached the
+// model's context window, where quality degrades and /compact or splitting
+// the task would help.
+type ContextPressureAnalysis struct {
+	// Window is the context window size in tokens the sessions were measured
+	// against.
+	Window int `json:"window"`
+	// SessionsAnalyzed counts sessions with any token data.
+	SessionsAnalyzed int `json:"sessions_analyzed"`
+	// Estimated counts analyzed sessions without per-turn transcript usage,
+	// whose peak was approximated from the session's total input tokens.
+	Estimated int `json:"estimated"`
+	// NearLimit counts sessions whose peak reached ContextPressureThreshold
+	// of Window.
+	NearLimit int `json:"near_limit"`
+	// NearLimitRate is NearLimit / SessionsAnalyzed.
+	NearLimitRate float64 `json:"near_limit_rate"`
+	// Worst lists the near-limit sessions with the highest peak usage.
+	Worst []SessionContextPressure `json:"worst,omitempty"`
+}
+
+// SessionContextPressure is the peak context usage of a single session.
+type SessionContextPressure struct {
+	SessionID   string `json:"session_id"`
+	ProjectName string `json:"project_name"`
+	PeakTokens  int    `json:"peak_tokens"`
+	// Usage is PeakTokens / Window; it can exceed 1 for estimated sessions.
+	Usage       float64 `json:"usage"`
+	Compactions int     `json:"compactions"`
+	// Estimated is set when PeakTokens is the session's total input tokens
+	// rather than a measured per-turn peak.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// AnalyzeContextPressure flags sessions whose context approached window
+// tokens. A session's peak comes from peaks, the per-turn maximum parsed from
+// its transcript. Sessions missing from peaks fall back to their total input
+// tokens, a rough approximation that sums uncached input across turns and
+// leaves out cached input. A non-positive window yields an empty analysis.
+func AnalyzeContextPressure(sessions []claude.SessionMeta, peaks map[string]claude.ContextPeak, window int) ContextPressureAnalysis {
+	result := ContextPressureAnalysis{Window: window}
+	if window <= 0 {
+		return result
+	}
+
+	var near []SessionContextPressure
+	for _, s := range sessions {
+		sp := SessionContextPressure{SessionID: s.SessionID, ProjectName: projectNameFromPath(s.ProjectPath)}
+		if p, ok := peaks[s.SessionID]; ok {
+			sp.PeakTokens = p.PeakTokens
+			sp.Compactions = p.Compactions
+		} else {
+			sp.PeakTokens = s.InputTokens
+			sp.Estimated = true
+		}
+		if sp.PeakTokens <= 0 {
+			continue
+		}
+		result.SessionsAnalyzed++
+		if sp.Estimated {
+			result.Estimated++
+		}
+		sp.Usage = float64(sp.PeakTokens) / float64(window)
+		if sp.Usage >= ContextPressureThreshold {
+			near = append(near, sp)
+		}
+	}
+
+	result.NearLimit = len(near)
+	if result.SessionsAnalyzed > 0 {
+		result.NearLimitRate = float64(result.NearLimit) / float64(result.SessionsAnalyzed)
+	}
+
+	sort.Slice(near, func(i, j int) bool {
+		if near[i].PeakTokens != near[j].PeakTokens {
+			return near[i].PeakTokens > near[j].PeakTokens
+		}
+		return near[i].SessionID < near[j].SessionID
+	})
+	if len(near) > contextPressureWorstN {
+		near = near[:contextPressureWorstN]
+	}
+	result.Worst = near
+	return result
+}
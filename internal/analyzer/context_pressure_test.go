@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeContextPressure_Empty(t *testing.T) {
+	result := AnalyzeContextPressure(nil, nil, 200_000)
+	if result.SessionsAnalyzed != 0 || result.NearLimit != 0 || len(result.Worst) != 0 {
+		t.Errorf("expected zero result, got %+v", result)
+	}
+	if result.Window != 200_000 {
+		t.Errorf("Window = %d, want 200000", result.Window)
+	}
+}
+
+func TestAnalyzeContextPressure_FlagsNearLimit(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "a", ProjectPath: "/code/proj", InputTokens: 900_000},
+		{SessionID: "b", ProjectPath: "/code/proj"},
+		{SessionID: "c", ProjectPath: "/code/other"},
+		// No transcript usage: falls back to total input tokens.
+		{SessionID: "d", ProjectPath: "/code/other", InputTokens: 170_000},
+		// No data at all: not analyzed.
+		{SessionID: "e", ProjectPath: "/code/other"},
+	}
+	peaks := map[string]claude.ContextPeak{
+		"a": {PeakTokens: 120_000, Turns: 40},
+		"b": {PeakTokens: 190_000, Turns: 80, Compactions: 2},
+		"c": {PeakTokens: 160_000, Turns: 30},
+	}
+
+	result := AnalyzeContextPressure(sessions, peaks, 200_000)
+
+	if result.SessionsAnalyzed != 4 || result.Estimated != 1 {
+		t.Errorf("SessionsAnalyzed=%d Estimated=%d, want 4 and 1", result.SessionsAnalyzed, result.Estimated)
+	}
+	if result.NearLimit != 3 {
+		t.Fatalf("NearLimit = %d, want 3", result.NearLimit)
+	}
+	if result.NearLimitRate != 0.75 {
+		t.Errorf("NearLimitRate = %v, want 0.75", result.NearLimitRate)
+	}
+	var ids []string
+	for _, w := range result.Worst {
+		ids = append(ids, w.SessionID)
+	}
+	if len(ids) != 3 || ids[0] != "b" || ids[1] != "d" || ids[2] != "c" {
+		t.Errorf("Worst order = %v, want [b d c]", ids)
+	}
+	b := result.Worst[0]
+	if b.ProjectName != "proj" || b.Compactions != 2 || b.Usage != 0.95 || b.Estimated {
+		t.Errorf("unexpected worst entry %+v", b)
+	}
+	if !result.Worst[1].Estimated {
+		t.Error("expected session d to be marked estimated")
+	}
+}
+
+func TestAnalyzeContextPressure_LimitsWorst(t *testing.T) {
+	var sessions []claude.SessionMeta
+	peaks := make(map[string]claude.ContextPeak)
+	for _, id := range []string{"s1", "s2", "s3", "s4", "s5", "s6", "s7"} {
+		sessions = append(sessions, claude.SessionMeta{SessionID: id})
+		peaks[id] = claude.ContextPeak{PeakTokens: 180_000, Turns: 1}
+	}
+
+	result := AnalyzeContextPressure(sessions, peaks, 200_000)
+
+	if result.NearLimit != 7 {
+		t.Errorf("NearLimit = %d, want 7", result.NearLimit)
+	}
+	if len(result.Worst) != contextPressureWorstN {
+		t.Errorf("len(Worst) = %d, want %d", len(result.Worst), contextPressureWorstN)
+	}
+}
+
+func TestAnalyzeContextPressure_NoWindow(t *testing.T) {
+	sessions := []claude.SessionMeta{{SessionID: "a", InputTokens: 500_000}}
+	result := AnalyzeContextPressure(sessions, nil, 0)
+	if result.SessionsAnalyzed != 0 {
+		t.Errorf("expected no analysis without a window, got %+v", result)
+	}
+}
@@ -188,6 +188,18 @@ func AnalyzeConversations(claudeDir string) (ConversationAnalysis, error) {
 	return aggregateConversations(allMetrics), nil
 }
 
+// FilterConversations narrows a to the sessions whose IDs are in keep and
+// recomputes its summary statistics over them.
+func FilterConversations(a ConversationAnalysis, keep map[string]bool) ConversationAnalysis {
+	var sessions []ConversationMetrics
+	for _, s := range a.Sessions {
+		if keep[s.SessionID] {
+			sessions = append(sessions, s)
+		}
+	}
+	return aggregateConversations(sessions)
+}
+
 // aggregateConversations computes summary statistics from per-session metrics.
 func aggregateConversations(sessions []ConversationMetrics) ConversationAnalysis {
 	analysis := ConversationAnalysis{
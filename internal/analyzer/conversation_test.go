@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"encoding/json"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -311,3 +312,22 @@ func TestAnalyzeConversations_ProjectHash(t *testing.T) {
 		t.Errorf("expected session ID 'session_x', got %q", result.Sessions[0].SessionID)
 	}
 }
+
+func TestFilterConversations(t *testing.T) {
+	all := aggregateConversations([]ConversationMetrics{
+		{SessionID: "a", CorrectionRate: 0.5, LongMessageRate: 0.2},
+		{SessionID: "b", CorrectionRate: 0.1, LongMessageRate: 0.4},
+		{SessionID: "c", CorrectionRate: 0.4},
+	})
+
+	got := FilterConversations(all, map[string]bool{"b": true, "c": true})
+	if len(got.Sessions) != 2 || got.Sessions[0].SessionID != "b" {
+		t.Fatalf("Sessions = %+v, want b and c", got.Sessions)
+	}
+	if math.Abs(got.AvgCorrectionRate-0.25) > 1e-9 || math.Abs(got.AvgLongMsgRate-0.2) > 1e-9 {
+		t.Errorf("averages = %.3f, %.3f; want 0.25, 0.2", got.AvgCorrectionRate, got.AvgLongMsgRate)
+	}
+	if got.HighCorrectionSessions != 1 {
+		t.Errorf("HighCorrectionSessions = %d, want 1", got.HighCorrectionSessions)
+	}
+}
@@ -16,7 +16,8 @@ type ModelPricing struct {
 }
 
 // DefaultPricing maps model tier names to their per-million-token pricing
-// as of Feb 2026 for Claude models.
+// as of Feb 2026 for Claude models. SetPricingOverrides replaces these with
+// configured rates at startup.
 var DefaultPricing = map[string]ModelPricing{
 	"opus": {
 		InputPerMillion:      15.0,
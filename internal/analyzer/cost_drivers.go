@@ -0,0 +1,247 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// CostSpikeDays is the length of the recent window compared against earlier
+// sessions when looking for a cost spike.
+const CostSpikeDays = 7
+
+// CostSpikeMinIncrease is how much the recent cost per session must exceed
+// the earlier average, as a fraction, to count as a spike.
+const CostSpikeMinIncrease = 0.5
+
+// costSpikeMinSessions is how many sessions each side of the comparison needs
+// before a spike is reported; fewer make the averages noise.
+const costSpikeMinSessions = 3
+
+// costDriverTop is how many drivers Top and a spike's Drivers list.
+const costDriverTop = 3
+
+// Cost driver kinds.
+const (
+	CostDriverTool  = "tool"
+	CostDriverAgent = "agent"
+)
+
+// CostDriver is the estimated cost attributed to one tool category or agent
+// type.
+type CostDriver struct {
+	// Kind is CostDriverTool or CostDriverAgent.
+	Kind string  `json:"kind"`
+	Name string  `json:"name"`
+	Cost float64 `json:"cost"`
+	// Share is Cost as a fraction of the total cost analyzed.
+	Share float64 `json:"share"`
+	// Approximate is set when the cost was split by call counts because
+	// per-tool token counts aren't recorded.
+	Approximate bool `json:"approximate"`
+}
+
+// CostSpike compares the last CostSpikeDays days against earlier sessions.
+type CostSpike struct {
+	Days               int     `json:"days"`
+	RecentPerSession   float64 `json:"recent_per_session"`
+	BaselinePerSession float64 `json:"baseline_per_session"`
+	ChangePercent      float64 `json:"change_percent"`
+	// Drivers lists the drivers whose cost per session grew most; their
+	// Cost is that growth and Share its fraction of the total growth.
+	Drivers []CostDriver `json:"drivers"`
+}
+
+// CostDrivers attributes estimated cost to tool categories and agent types.
+// The two views overlap, since agents run tools of their own and are
+// launched with the Task tool; each sums to at most the total on its own.
+type CostDrivers struct {
+	TotalCost float64 `json:"total_cost"`
+	// ByTool splits each session's cost across tool categories in
+	// proportion to its tool calls, so every entry is approximate.
+	ByTool []CostDriver `json:"by_tool"`
+	// ByAgent prices each agent's tokens at its session's cost per token.
+	ByAgent []CostDriver `json:"by_agent"`
+	// Top lists the costliest drivers of either kind.
+	Top []CostDriver `json:"top"`
+	// Spike is set when recent sessions cost markedly more than earlier
+	// ones.
+	Spike *CostSpike `json:"spike,omitempty"`
+}
+
+// ToolCategory groups a tool name into the category cost is attributed to:
+// read, edit, shell, web, agent, mcp, or other.
+func ToolCategory(tool string) string {
+	switch tool {
+	case "Read", "Glob", "Grep", "LS", "NotebookRead":
+		return "read"
+	case "Edit", "MultiEdit", "Write", "NotebookEdit":
+		return "edit"
+	case "Bash", "BashOutput", "KillShell", "KillBash":
+		return "shell"
+	case "WebFetch", "WebSearch":
+		return "web"
+	case "Task":
+		return "agent"
+	}
+	if strings.HasPrefix(tool, "mcp__") {
+		return "mcp"
+	}
+	return "other"
+}
+
+// AnalyzeCostDrivers attributes the estimated cost of sessions to the tool
+// categories and agent types that drove it, and reports which grew most when
+// the last CostSpikeDays days cost at least CostSpikeMinIncrease more per
+// session than the sessions before them.
+//
+// Agent costs come from the agents' own token counts, priced at their
+// session's cost per token and capped at the session's cost. Tool costs are
+// approximate: sessions record tool calls but not tokens per tool, so each
+// session's cost is split by its share of calls. Sessions without tool calls
+// count as "none".
+func AnalyzeCostDrivers(sessions []claude.SessionMeta, tasks []claude.AgentTask, pricing ModelPricing, ratio CacheRatio) CostDrivers {
+	result := CostDrivers{ByTool: []CostDriver{}, ByAgent: []CostDriver{}, Top: []CostDriver{}}
+
+	tasksBySession := make(map[string][]claude.AgentTask)
+	for _, t := range tasks {
+		tasksBySession[t.SessionID] = append(tasksBySession[t.SessionID], t)
+	}
+
+	// Per-session attribution, kept for the spike comparison.
+	type attribution struct {
+		start time.Time
+		cost  float64
+		costs map[string]float64 // keyed by kind + ":" + name
+	}
+	var attributed []attribution
+	total := make(map[string]float64)
+
+	for _, s := range sessions {
+		cost := EstimateSessionCost(s, pricing, ratio)
+		a := attribution{start: claude.ParseTimestamp(s.StartTime), cost: cost, costs: make(map[string]float64)}
+		result.TotalCost += cost
+
+		calls := 0
+		for _, n := range s.ToolCounts {
+			calls += n
+		}
+		if calls == 0 {
+			a.costs[CostDriverTool+":none"] += cost
+		}
+		for tool, n := range s.ToolCounts {
+			a.costs[CostDriverTool+":"+ToolCategory(tool)] += cost * float64(n) / float64(calls)
+		}
+
+		if tokens := s.InputTokens + s.OutputTokens; tokens > 0 {
+			remaining := cost
+			for _, t := range tasksBySession[s.SessionID] {
+				agentCost := min(cost*float64(t.TotalTokens)/float64(tokens), remaining)
+				if agentCost <= 0 {
+					continue
+				}
+				remaining -= agentCost
+				a.costs[CostDriverAgent+":"+t.AgentType] += agentCost
+			}
+		}
+
+		for key, c := range a.costs {
+			total[key] += c
+		}
+		attributed = append(attributed, a)
+	}
+
+	drivers := costDriverList(total, result.TotalCost)
+	for _, d := range drivers {
+		if d.Kind == CostDriverTool {
+			result.ByTool = append(result.ByTool, d)
+		} else {
+			result.ByAgent = append(result.ByAgent, d)
+		}
+	}
+	result.Top = append(result.Top, drivers[:min(costDriverTop, len(drivers))]...)
+
+	// Spike: the last CostSpikeDays days, ending at the newest session,
+	// against everything before them.
+	var newest time.Time
+	for _, a := range attributed {
+		if a.start.After(newest) {
+			newest = a.start
+		}
+	}
+	if newest.IsZero() {
+		return result
+	}
+	cutoff := newest.AddDate(0, 0, -CostSpikeDays)
+	var recentN, baselineN int
+	var recentCost, baselineCost float64
+	recent := make(map[string]float64)
+	baseline := make(map[string]float64)
+	for _, a := range attributed {
+		if a.start.IsZero() {
+			continue
+		}
+		side, n, c := baseline, &baselineN, &baselineCost
+		if a.start.After(cutoff) {
+			side, n, c = recent, &recentN, &recentCost
+		}
+		*n++
+		*c += a.cost
+		for key, v := range a.costs {
+			side[key] += v
+		}
+	}
+	if recentN < costSpikeMinSessions || baselineN < costSpikeMinSessions || baselineCost == 0 {
+		return result
+	}
+	spike := CostSpike{
+		Days:               CostSpikeDays,
+		RecentPerSession:   recentCost / float64(recentN),
+		BaselinePerSession: baselineCost / float64(baselineN),
+	}
+	increase := spike.RecentPerSession/spike.BaselinePerSession - 1
+	if increase < CostSpikeMinIncrease {
+		return result
+	}
+	spike.ChangePercent = increase * 100
+
+	growth := make(map[string]float64)
+	for key := range recent {
+		if g := recent[key]/float64(recentN) - baseline[key]/float64(baselineN); g > 0 {
+			growth[key] = g
+		}
+	}
+	grown := costDriverList(growth, spike.RecentPerSession-spike.BaselinePerSession)
+	spike.Drivers = append([]CostDriver{}, grown[:min(costDriverTop, len(grown))]...)
+	result.Spike = &spike
+	return result
+}
+
+// costDriverList turns costs keyed by kind:name into drivers, costliest
+// first, with shares of total. Drivers that cost nothing are left out.
+func costDriverList(costs map[string]float64, total float64) []CostDriver {
+	drivers := make([]CostDriver, 0, len(costs))
+	for key, cost := range costs {
+		if cost <= 0 {
+			continue
+		}
+		kind, name, _ := strings.Cut(key, ":")
+		d := CostDriver{Kind: kind, Name: name, Cost: cost, Approximate: kind == CostDriverTool}
+		if total > 0 {
+			d.Share = cost / total
+		}
+		drivers = append(drivers, d)
+	}
+	sort.Slice(drivers, func(i, j int) bool {
+		if drivers[i].Cost != drivers[j].Cost {
+			return drivers[i].Cost > drivers[j].Cost
+		}
+		if drivers[i].Kind != drivers[j].Kind {
+			return drivers[i].Kind < drivers[j].Kind
+		}
+		return drivers[i].Name < drivers[j].Name
+	})
+	return drivers
+}
@@ -0,0 +1,129 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestToolCategory(t *testing.T) {
+	cases := map[string]string{
+		"Read":             "read",
+		"Grep":             "read",
+		"MultiEdit":        "edit",
+		"Bash":             "shell",
+		"WebFetch":         "web",
+		"Task":             "agent",
+		"mcp__github__pr":  "mcp",
+		"TodoWrite":        "other",
+		"SomethingUnknown": "other",
+	}
+	for tool, want := range cases {
+		if got := ToolCategory(tool); got != want {
+			t.Errorf("ToolCategory(%q) = %q, want %q", tool, got, want)
+		}
+	}
+}
+
+func TestAnalyzeCostDrivers_Empty(t *testing.T) {
+	result := AnalyzeCostDrivers(nil, nil, testPricing, NoCacheRatio())
+	if result.TotalCost != 0 || len(result.Top) != 0 || result.Spike != nil {
+		t.Errorf("expected zero result, got %+v", result)
+	}
+	if result.ByTool == nil || result.ByAgent == nil || result.Top == nil {
+		t.Error("driver lists should be empty, not nil")
+	}
+}
+
+func TestAnalyzeCostDrivers_Attribution(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		// $3.00, three quarters shell calls, half the tokens spent by an agent.
+		{SessionID: "s1", StartTime: "2026-01-10T09:00:00Z", InputTokens: 1_000_000,
+			ToolCounts: map[string]int{"Bash": 3, "Read": 1}},
+		// $3.00 with no tool calls.
+		{SessionID: "s2", StartTime: "2026-01-11T09:00:00Z", InputTokens: 1_000_000},
+	}
+	tasks := []claude.AgentTask{
+		{SessionID: "s1", AgentType: "Explore", TotalTokens: 500_000},
+		// An agent claiming more tokens than its session is capped at the
+		// session's remaining cost.
+		{SessionID: "s1", AgentType: "Plan", TotalTokens: 5_000_000},
+	}
+
+	result := AnalyzeCostDrivers(sessions, tasks, testPricing, NoCacheRatio())
+
+	if math.Abs(result.TotalCost-6.0) > 1e-9 {
+		t.Errorf("TotalCost = %.2f, want 6.00", result.TotalCost)
+	}
+	tools := make(map[string]CostDriver)
+	for _, d := range result.ByTool {
+		tools[d.Name] = d
+		if !d.Approximate {
+			t.Errorf("tool driver %s should be approximate", d.Name)
+		}
+	}
+	if math.Abs(tools["none"].Cost-3.0) > 1e-9 || math.Abs(tools["shell"].Cost-2.25) > 1e-9 || math.Abs(tools["read"].Cost-0.75) > 1e-9 {
+		t.Errorf("ByTool = %+v, want none $3.00, shell $2.25, read $0.75", result.ByTool)
+	}
+	if math.Abs(tools["shell"].Share-0.375) > 1e-9 {
+		t.Errorf("shell share = %.3f, want 0.375", tools["shell"].Share)
+	}
+
+	agents := make(map[string]CostDriver)
+	for _, d := range result.ByAgent {
+		agents[d.Name] = d
+		if d.Approximate {
+			t.Errorf("agent driver %s should not be approximate", d.Name)
+		}
+	}
+	if math.Abs(agents["Explore"].Cost-1.5) > 1e-9 || math.Abs(agents["Plan"].Cost-1.5) > 1e-9 {
+		t.Errorf("ByAgent = %+v, want Explore and Plan at $1.50 each", result.ByAgent)
+	}
+
+	if len(result.Top) != 3 || result.Top[0].Name != "none" || result.Top[1].Name != "shell" {
+		t.Errorf("Top = %+v, want none then shell first", result.Top)
+	}
+	if result.Spike != nil {
+		t.Errorf("Spike = %+v, want none with two sessions", result.Spike)
+	}
+}
+
+func TestAnalyzeCostDrivers_Spike(t *testing.T) {
+	var sessions []claude.SessionMeta
+	// Three cheap reading sessions, then three costly shell-heavy ones in
+	// the last week.
+	for _, start := range []string{"2026-01-01T09:00:00Z", "2026-01-02T09:00:00Z", "2026-01-03T09:00:00Z"} {
+		sessions = append(sessions, claude.SessionMeta{SessionID: start, StartTime: start,
+			InputTokens: 1_000_000, ToolCounts: map[string]int{"Read": 1}})
+	}
+	for _, start := range []string{"2026-01-20T09:00:00Z", "2026-01-21T09:00:00Z", "2026-01-22T09:00:00Z"} {
+		sessions = append(sessions, claude.SessionMeta{SessionID: start, StartTime: start,
+			InputTokens: 2_000_000, ToolCounts: map[string]int{"Read": 1, "Bash": 1}})
+	}
+
+	result := AnalyzeCostDrivers(sessions, nil, testPricing, NoCacheRatio())
+
+	s := result.Spike
+	if s == nil {
+		t.Fatal("expected a spike")
+	}
+	if math.Abs(s.RecentPerSession-6.0) > 1e-9 || math.Abs(s.BaselinePerSession-3.0) > 1e-9 || math.Abs(s.ChangePercent-100) > 1e-9 {
+		t.Errorf("spike = %+v, want $6.00 vs $3.00, +100%%", s)
+	}
+	// Shell went from $0 to $3/session; read stayed at $3.
+	if len(s.Drivers) != 1 || s.Drivers[0].Name != "shell" || math.Abs(s.Drivers[0].Cost-3.0) > 1e-9 {
+		t.Errorf("spike drivers = %+v, want only shell at +$3.00", s.Drivers)
+	}
+}
+
+func TestAnalyzeCostDrivers_NoSpikeWhenSteady(t *testing.T) {
+	var sessions []claude.SessionMeta
+	for _, start := range []string{"2026-01-01T09:00:00Z", "2026-01-02T09:00:00Z", "2026-01-03T09:00:00Z",
+		"2026-01-20T09:00:00Z", "2026-01-21T09:00:00Z", "2026-01-22T09:00:00Z"} {
+		sessions = append(sessions, claude.SessionMeta{SessionID: start, StartTime: start, InputTokens: 1_000_000})
+	}
+	if result := AnalyzeCostDrivers(sessions, nil, testPricing, NoCacheRatio()); result.Spike != nil {
+		t.Errorf("Spike = %+v, want none", result.Spike)
+	}
+}
@@ -62,7 +62,7 @@ func ComputeSessionDashboard(activePath string, pricing claude.CostPricing) (*Da
 	// Parse duration from start time.
 	var durationMinutes float64
 	if meta.StartTime != "" {
-		t, err := time.Parse(time.RFC3339, meta.StartTime)
+		t, err := claude.ParseSessionTime(meta.StartTime)
 		if err == nil {
 			durationMinutes = time.Since(t).Minutes()
 		}
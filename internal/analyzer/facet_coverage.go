@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// FacetCoverage reports how many sessions have a facet (Claude Code's
+// post-session label with satisfaction, outcome, and friction data). Low
+// coverage means satisfaction and outcome stats describe only a slice of
+// sessions; zero coverage across many sessions usually means facet generation
+// is not running.
+type FacetCoverage struct {
+	// TotalSessions is the number of sessions considered.
+	TotalSessions int `json:"total_sessions"`
+
+	// WithFacets is the number of those sessions that have a facet.
+	WithFacets int `json:"with_facets"`
+
+	// CoveragePct is WithFacets / TotalSessions as a percentage (0-100).
+	CoveragePct float64 `json:"coverage_pct"`
+
+	// Projects holds per-project coverage, lowest coverage first.
+	Projects []ProjectFacetCoverage `json:"projects"`
+}
+
+// ProjectFacetCoverage is facet coverage for a single project.
+type ProjectFacetCoverage struct {
+	ProjectPath   string  `json:"project_path"`
+	ProjectName   string  `json:"project_name"`
+	TotalSessions int     `json:"total_sessions"`
+	WithFacets    int     `json:"with_facets"`
+	CoveragePct   float64 `json:"coverage_pct"`
+}
+
+// AnalyzeFacetCoverage counts sessions with and without a matching facet,
+// overall and per project. Facets whose session isn't in sessions are ignored.
+func AnalyzeFacetCoverage(sessions []claude.SessionMeta, facets []claude.SessionFacet) FacetCoverage {
+	cov := FacetCoverage{
+		TotalSessions: len(sessions),
+		Projects:      []ProjectFacetCoverage{},
+	}
+
+	hasFacet := make(map[string]bool, len(facets))
+	for _, f := range facets {
+		hasFacet[f.SessionID] = true
+	}
+
+	byProject := make(map[string]*ProjectFacetCoverage)
+	for _, s := range sessions {
+		path := claude.NormalizePath(s.ProjectPath)
+		p, ok := byProject[path]
+		if !ok {
+			p = &ProjectFacetCoverage{ProjectPath: path, ProjectName: filepath.Base(path)}
+			byProject[path] = p
+		}
+		p.TotalSessions++
+		if hasFacet[s.SessionID] {
+			p.WithFacets++
+			cov.WithFacets++
+		}
+	}
+
+	cov.CoveragePct = coveragePct(cov.WithFacets, cov.TotalSessions)
+	for _, p := range byProject {
+		p.CoveragePct = coveragePct(p.WithFacets, p.TotalSessions)
+		cov.Projects = append(cov.Projects, *p)
+	}
+	sort.Slice(cov.Projects, func(i, j int) bool {
+		if cov.Projects[i].CoveragePct != cov.Projects[j].CoveragePct {
+			return cov.Projects[i].CoveragePct < cov.Projects[j].CoveragePct
+		}
+		return cov.Projects[i].ProjectPath < cov.Projects[j].ProjectPath
+	})
+
+	return cov
+}
+
+// coveragePct returns n/total as a percentage, or 0 when total is 0.
+func coveragePct(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
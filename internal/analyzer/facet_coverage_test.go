@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeFacetCoverage_Empty(t *testing.T) {
+	cov := AnalyzeFacetCoverage(nil, nil)
+	if cov.TotalSessions != 0 || cov.WithFacets != 0 || cov.CoveragePct != 0 {
+		t.Errorf("expected zero coverage, got %+v", cov)
+	}
+	if cov.Projects == nil {
+		t.Error("expected non-nil Projects slice")
+	}
+}
+
+func TestAnalyzeFacetCoverage_OverallAndPerProject(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "a1", ProjectPath: "/code/alpha"},
+		{SessionID: "a2", ProjectPath: "/code/alpha"},
+		{SessionID: "a3", ProjectPath: "/code/alpha/"},
+		{SessionID: "a4", ProjectPath: "/code/alpha"},
+		{SessionID: "b1", ProjectPath: "/code/beta"},
+	}
+	facets := []claude.SessionFacet{
+		{SessionID: "a1"},
+		{SessionID: "a2"},
+		{SessionID: "a3"},
+		{SessionID: "orphan"}, // no matching session; ignored
+	}
+
+	cov := AnalyzeFacetCoverage(sessions, facets)
+
+	if cov.TotalSessions != 5 || cov.WithFacets != 3 {
+		t.Errorf("got %d/%d, want 3/5", cov.WithFacets, cov.TotalSessions)
+	}
+	if cov.CoveragePct != 60 {
+		t.Errorf("CoveragePct = %v, want 60", cov.CoveragePct)
+	}
+	if len(cov.Projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d: %+v", len(cov.Projects), cov.Projects)
+	}
+
+	// Lowest coverage first.
+	beta, alpha := cov.Projects[0], cov.Projects[1]
+	if beta.ProjectName != "beta" || beta.CoveragePct != 0 {
+		t.Errorf("expected beta at 0%% first, got %+v", beta)
+	}
+	if alpha.ProjectName != "alpha" || alpha.TotalSessions != 4 || alpha.WithFacets != 3 || alpha.CoveragePct != 75 {
+		t.Errorf("unexpected alpha coverage: %+v", alpha)
+	}
+}
@@ -0,0 +1,176 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+// firstPromptMinFacetSessions is how many faceted sessions a bucket needs
+// before it is compared.
+const firstPromptMinFacetSessions = 3
+
+// firstPromptOutcomeGap is how many points lower an extreme bucket's outcome
+// rate must be than the middle buckets' to count as worse.
+const firstPromptOutcomeGap = 0.15
+
+// FirstPromptAnalysis relates the length of a session's first prompt to its
+// friction and outcome. The first and last buckets hold the very short and
+// very long prompts and are compared against the buckets in between.
+type FirstPromptAnalysis struct {
+	// Boundaries are the ascending bucket boundaries, in characters.
+	Boundaries []int `json:"boundaries"`
+	// Sessions counts sessions with a non-empty first prompt.
+	Sessions int                 `json:"sessions"`
+	Buckets  []FirstPromptBucket `json:"buckets"`
+	// Middle aggregates the buckets between the first and the last.
+	Middle FirstPromptBucket `json:"middle"`
+	// ShortWorse and LongWorse report that very short or very long first
+	// prompts had a lower outcome rate or more friction than the middle.
+	ShortWorse bool `json:"short_worse"`
+	LongWorse  bool `json:"long_worse"`
+}
+
+// FirstPromptBucket summarizes sessions whose first prompt length falls in
+// [MinChars, MaxChars). MaxChars is 0 for the open-ended last bucket.
+type FirstPromptBucket struct {
+	Label    string `json:"label"`
+	MinChars int    `json:"min_chars"`
+	MaxChars int    `json:"max_chars,omitempty"`
+	Sessions int    `json:"sessions"`
+	// FacetSessions is the number of sessions with a facet; AvgFriction and
+	// OutcomeRate are computed over these.
+	FacetSessions int     `json:"facet_sessions"`
+	AvgFriction   float64 `json:"avg_friction"`
+	// OutcomeRate is the share of faceted sessions whose outcome was
+	// achieved or mostly_achieved.
+	OutcomeRate float64 `json:"outcome_rate"`
+
+	friction, achieved int
+}
+
+// AnalyzeFirstPromptLength buckets sessions by the character length of their
+// first prompt and compares friction and outcome across buckets. boundaries
+// must be ascending and have at least two entries; otherwise
+// config.DefaultFirstPromptBuckets is used. Sessions with an empty first
+// prompt are skipped.
+func AnalyzeFirstPromptLength(sessions []claude.SessionMeta, facets []claude.SessionFacet, boundaries []int) FirstPromptAnalysis {
+	if !validFirstPromptBuckets(boundaries) {
+		boundaries = config.DefaultFirstPromptBuckets
+	}
+	result := FirstPromptAnalysis{
+		Boundaries: append([]int(nil), boundaries...),
+		Buckets:    make([]FirstPromptBucket, len(boundaries)+1),
+	}
+	for i := range result.Buckets {
+		b := &result.Buckets[i]
+		if i > 0 {
+			b.MinChars = boundaries[i-1]
+		}
+		if i < len(boundaries) {
+			b.MaxChars = boundaries[i]
+			b.Label = fmt.Sprintf("%d-%d", b.MinChars, b.MaxChars-1)
+		} else {
+			b.Label = fmt.Sprintf("%d+", b.MinChars)
+		}
+	}
+	result.Buckets[0].Label = fmt.Sprintf("<%d", boundaries[0])
+
+	facetBySession := make(map[string]*claude.SessionFacet, len(facets))
+	for i := range facets {
+		facetBySession[facets[i].SessionID] = &facets[i]
+	}
+
+	for _, s := range sessions {
+		n := utf8.RuneCountInString(strings.TrimSpace(s.FirstPrompt))
+		if n == 0 {
+			continue
+		}
+		result.Sessions++
+		b := &result.Buckets[firstPromptBucketIndex(n, boundaries)]
+		b.Sessions++
+		if f, ok := facetBySession[s.SessionID]; ok {
+			b.FacetSessions++
+			for _, count := range f.FrictionCounts {
+				b.friction += count
+			}
+			if f.Outcome == "achieved" || f.Outcome == "mostly_achieved" {
+				b.achieved++
+			}
+		}
+	}
+
+	last := len(result.Buckets) - 1
+	result.Middle = FirstPromptBucket{
+		Label:    fmt.Sprintf("%d-%d", boundaries[0], boundaries[len(boundaries)-1]-1),
+		MinChars: boundaries[0],
+		MaxChars: boundaries[len(boundaries)-1],
+	}
+	for i := range result.Buckets {
+		b := &result.Buckets[i]
+		b.finish()
+		if i == 0 || i == last {
+			continue
+		}
+		result.Middle.Sessions += b.Sessions
+		result.Middle.FacetSessions += b.FacetSessions
+		result.Middle.friction += b.friction
+		result.Middle.achieved += b.achieved
+	}
+	result.Middle.finish()
+
+	result.ShortWorse = result.Buckets[0].worseThan(result.Middle)
+	result.LongWorse = result.Buckets[last].worseThan(result.Middle)
+	return result
+}
+
+// finish computes the averages from the accumulated totals.
+func (b *FirstPromptBucket) finish() {
+	if b.FacetSessions == 0 {
+		return
+	}
+	b.AvgFriction = float64(b.friction) / float64(b.FacetSessions)
+	b.OutcomeRate = float64(b.achieved) / float64(b.FacetSessions)
+}
+
+// worseThan reports whether b had a clearly lower outcome rate or clearly
+// more friction than base. Both need enough faceted sessions to compare.
+func (b FirstPromptBucket) worseThan(base FirstPromptBucket) bool {
+	if b.FacetSessions < firstPromptMinFacetSessions || base.FacetSessions < firstPromptMinFacetSessions {
+		return false
+	}
+	if b.OutcomeRate <= base.OutcomeRate-firstPromptOutcomeGap {
+		return true
+	}
+	// At least 50% more friction, and at least half an event per session.
+	return b.AvgFriction >= base.AvgFriction*1.5 && b.AvgFriction-base.AvgFriction >= 0.5
+}
+
+// firstPromptBucketIndex returns the bucket a prompt of n characters falls in.
+func firstPromptBucketIndex(n int, boundaries []int) int {
+	for i, limit := range boundaries {
+		if n < limit {
+			return i
+		}
+	}
+	return len(boundaries)
+}
+
+// validFirstPromptBuckets reports whether boundaries has at least two
+// positive, strictly ascending entries.
+func validFirstPromptBuckets(boundaries []int) bool {
+	if len(boundaries) < 2 {
+		return false
+	}
+	prev := 0
+	for _, b := range boundaries {
+		if b <= prev {
+			return false
+		}
+		prev = b
+	}
+	return true
+}
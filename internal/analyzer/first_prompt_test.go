@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+func TestAnalyzeFirstPromptLength_Empty(t *testing.T) {
+	result := AnalyzeFirstPromptLength(nil, nil, nil)
+	if result.Sessions != 0 {
+		t.Errorf("expected 0 sessions, got %d", result.Sessions)
+	}
+	if len(result.Buckets) != len(config.DefaultFirstPromptBuckets)+1 {
+		t.Errorf("expected %d buckets, got %d", len(config.DefaultFirstPromptBuckets)+1, len(result.Buckets))
+	}
+	if result.ShortWorse || result.LongWorse {
+		t.Error("expected no findings for empty input")
+	}
+}
+
+func TestAnalyzeFirstPromptLength_Buckets(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "empty", FirstPrompt: "   "},
+		{SessionID: "short", FirstPrompt: "fix it"},
+		{SessionID: "edge", FirstPrompt: strings.Repeat("x", 10)},
+		{SessionID: "mid", FirstPrompt: strings.Repeat("é", 50)},
+		{SessionID: "long", FirstPrompt: strings.Repeat("x", 500)},
+	}
+	result := AnalyzeFirstPromptLength(sessions, nil, []int{10, 100})
+	if result.Sessions != 4 {
+		t.Fatalf("expected empty first prompt to be excluded, got %d sessions", result.Sessions)
+	}
+
+	want := []struct {
+		label    string
+		sessions int
+	}{{"<10", 1}, {"10-99", 2}, {"100+", 1}}
+	if len(result.Buckets) != len(want) {
+		t.Fatalf("expected %d buckets, got %d", len(want), len(result.Buckets))
+	}
+	for i, w := range want {
+		b := result.Buckets[i]
+		if b.Label != w.label || b.Sessions != w.sessions {
+			t.Errorf("bucket %d = %s with %d sessions, want %s with %d", i, b.Label, b.Sessions, w.label, w.sessions)
+		}
+	}
+}
+
+func TestAnalyzeFirstPromptLength_LongPromptsWorse(t *testing.T) {
+	var sessions []claude.SessionMeta
+	var facets []claude.SessionFacet
+	for _, g := range []struct {
+		prefix             string
+		chars              int
+		achieved, friction int
+	}{
+		{"short", 50, 4, 0},
+		{"mid", 500, 4, 0},
+		{"long", 5000, 0, 3},
+	} {
+		group := makeGroup(g.prefix, "", 4, 0)
+		for i := range group {
+			group[i].FirstPrompt = strings.Repeat("x", g.chars)
+		}
+		sessions = append(sessions, group...)
+		facets = append(facets, groupFacets(group, g.achieved, g.friction)...)
+	}
+
+	result := AnalyzeFirstPromptLength(sessions, facets, nil)
+	if !result.LongWorse {
+		t.Error("expected long first prompts to be flagged as worse")
+	}
+	if result.ShortWorse {
+		t.Error("expected short first prompts not to be flagged")
+	}
+	long := result.Buckets[len(result.Buckets)-1]
+	if long.OutcomeRate != 0 || long.AvgFriction != 3 {
+		t.Errorf("unexpected long bucket: %+v", long)
+	}
+	if result.Middle.FacetSessions != 4 || result.Middle.OutcomeRate != 1 {
+		t.Errorf("unexpected middle bucket: %+v", result.Middle)
+	}
+}
+
+func TestAnalyzeFirstPromptLength_NeedsEnoughFacets(t *testing.T) {
+	mid := makeGroup("mid", "", 4, 0)
+	for i := range mid {
+		mid[i].FirstPrompt = strings.Repeat("x", 500)
+	}
+	long := makeGroup("long", "", 2, 0)
+	for i := range long {
+		long[i].FirstPrompt = strings.Repeat("x", 5000)
+	}
+	facets := append(groupFacets(mid, 4, 0), groupFacets(long, 0, 5)...)
+
+	result := AnalyzeFirstPromptLength(append(mid, long...), facets, nil)
+	if result.LongWorse {
+		t.Errorf("expected no finding with only %d long sessions", len(long))
+	}
+}
+
+func TestAnalyzeFirstPromptLength_InvalidBoundariesFallBack(t *testing.T) {
+	for _, b := range [][]int{nil, {100}, {100, 50}, {0, 100}} {
+		result := AnalyzeFirstPromptLength(nil, nil, b)
+		if fmt.Sprint(result.Boundaries) != fmt.Sprint(config.DefaultFirstPromptBuckets) {
+			t.Errorf("boundaries %v: expected default fallback, got %v", b, result.Boundaries)
+		}
+	}
+}
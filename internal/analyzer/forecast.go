@@ -0,0 +1,220 @@
+package analyzer
+
+import (
+	"math"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+const (
+	// forecastWindowDays is how many complete days before today the daily
+	// spend trend is fitted over.
+	forecastWindowDays = 28
+
+	// forecastMinActiveDays is the fewest days with any spend in the window
+	// for a trend fit. With fewer the forecast assumes a flat rate, widens
+	// its band, and is marked low-confidence.
+	forecastMinActiveDays = 7
+
+	// forecastBandZ is the normal quantile for the confidence band (80%).
+	forecastBandZ = 1.28
+
+	// forecastSparseWidening multiplies the band of a low-confidence forecast.
+	forecastSparseWidening = 2
+)
+
+// SpendProjection is forecast spend for a period ending after now.
+type SpendProjection struct {
+	// End is the first instant after the period.
+	End time.Time `json:"end"`
+
+	// SpentUSD is the estimated cost of sessions started in the period so far.
+	SpentUSD float64 `json:"spent_usd"`
+
+	// ProjectedUSD is SpentUSD plus the forecast spend for the rest of the
+	// period.
+	ProjectedUSD float64 `json:"projected_usd"`
+
+	// LowUSD and HighUSD bound ProjectedUSD with the confidence band. LowUSD
+	// is never below SpentUSD.
+	LowUSD  float64 `json:"low_usd"`
+	HighUSD float64 `json:"high_usd"`
+}
+
+// CostForecast projects end-of-week and end-of-month spend from the trend
+// in daily spend.
+type CostForecast struct {
+	// WindowDays is the number of complete days the trend was fitted over;
+	// fewer than forecastWindowDays when history is shorter.
+	WindowDays int `json:"window_days"`
+
+	// ActiveDays is the number of days in the window with any spend.
+	ActiveDays int `json:"active_days"`
+
+	// DailyRateUSD is the fitted spend for today.
+	DailyRateUSD float64 `json:"daily_rate_usd"`
+
+	// TrendUSDPerDay is the fitted change in daily spend per day; 0 for a
+	// low-confidence forecast.
+	TrendUSDPerDay float64 `json:"trend_usd_per_day"`
+
+	// Week runs through Sunday of the current ISO week.
+	Week SpendProjection `json:"week"`
+
+	// Month runs through the end of the current calendar month.
+	Month SpendProjection `json:"month"`
+
+	// BudgetUSD is the monthly cap; 0 means no cap.
+	BudgetUSD float64 `json:"budget_usd"`
+
+	// OverBudget is true when a cap is set and Month.ProjectedUSD exceeds it.
+	OverBudget bool `json:"over_budget"`
+
+	// LowConfidence is true when the window has fewer than
+	// forecastMinActiveDays active days.
+	LowConfidence bool `json:"low_confidence"`
+}
+
+// ForecastCost fits a linear trend to daily spend over the complete days
+// before now (up to forecastWindowDays, starting no earlier than the first
+// session) and projects spend through the end of the current week and month.
+//
+// The band is forecastBandZ residual standard deviations, scaled by the
+// square root of the days left in the period. Sparse history falls back to
+// the flat mean with a band forecastSparseWidening times as wide, and at
+// least that wide a multiple of the mean.
+func ForecastCost(sessions []claude.SessionMeta, budgetUSD float64, now time.Time, pricing ModelPricing, ratio CacheRatio) CostForecast {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	weekStart := weekStartMonday(now)
+
+	f := CostForecast{
+		BudgetUSD: budgetUSD,
+		Week:      SpendProjection{End: weekStart.AddDate(0, 0, 7)},
+		Month:     SpendProjection{End: monthStart.AddDate(0, 1, 0)},
+	}
+
+	windowStart := today.AddDate(0, 0, -forecastWindowDays)
+	var first time.Time
+	daily := make([]float64, forecastWindowDays)
+	for _, s := range sessions {
+		t := claude.ParseTimestamp(s.StartTime)
+		if t.IsZero() || t.After(now) {
+			continue
+		}
+		t = t.In(loc)
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		cost := EstimateSessionCost(s, pricing, ratio)
+		if !t.Before(weekStart) {
+			f.Week.SpentUSD += cost
+		}
+		if !t.Before(monthStart) {
+			f.Month.SpentUSD += cost
+		}
+		if !t.Before(windowStart) && t.Before(today) {
+			daily[dayIndex(windowStart, t)] += cost
+		}
+	}
+
+	// Skip the leading days before the first session, so a new install
+	// isn't forecast from weeks of zeros.
+	if !first.IsZero() && first.After(windowStart) {
+		firstDay := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+		if firstDay.After(today) {
+			firstDay = today
+		}
+		daily = daily[dayIndex(windowStart, firstDay):]
+	}
+	if first.IsZero() {
+		daily = nil
+	}
+	f.WindowDays = len(daily)
+	for _, v := range daily {
+		if v > 0 {
+			f.ActiveDays++
+		}
+	}
+	f.LowConfidence = f.ActiveDays < forecastMinActiveDays
+
+	intercept, slope, sd := fitDailySpend(daily, !f.LowConfidence)
+	if f.LowConfidence {
+		// Too few points to trust the residuals: take at least the mean
+		// as the spread.
+		sd = math.Max(sd, intercept) * forecastSparseWidening
+	}
+	f.TrendUSDPerDay = slope
+	n := float64(len(daily))
+	f.DailyRateUSD = math.Max(0, intercept+slope*n)
+
+	project := func(p *SpendProjection) {
+		// The rest of today, then each whole day until the period ends.
+		todayLeft := today.AddDate(0, 0, 1).Sub(now).Hours() / 24
+		remaining := todayLeft * f.DailyRateUSD
+		days := todayLeft
+		for d := today.AddDate(0, 0, 1); d.Before(p.End); d = d.AddDate(0, 0, 1) {
+			x := n + float64(dayIndex(today, d))
+			remaining += math.Max(0, intercept+slope*x)
+			days++
+		}
+		band := forecastBandZ * sd * math.Sqrt(days)
+		p.ProjectedUSD = p.SpentUSD + remaining
+		p.LowUSD = math.Max(p.SpentUSD, p.ProjectedUSD-band)
+		p.HighUSD = p.ProjectedUSD + band
+	}
+	project(&f.Week)
+	project(&f.Month)
+
+	f.OverBudget = budgetUSD > 0 && f.Month.ProjectedUSD > budgetUSD
+	return f
+}
+
+// dayIndex returns the number of calendar days from start to t's date.
+func dayIndex(start, t time.Time) int {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, start.Location())
+	return int(math.Round(d.Sub(start).Hours() / 24))
+}
+
+// fitDailySpend fits y = intercept + slope*x to daily spend, x being the day
+// index, and returns the residual standard deviation. Without trend, or with
+// fewer than three days, slope is 0 and intercept is the mean.
+func fitDailySpend(daily []float64, trend bool) (intercept, slope, sd float64) {
+	n := float64(len(daily))
+	if n == 0 {
+		return 0, 0, 0
+	}
+	var meanX, meanY float64
+	for i, y := range daily {
+		meanX += float64(i)
+		meanY += y
+	}
+	meanX /= n
+	meanY /= n
+
+	intercept = meanY
+	dof := n - 1
+	if trend && n >= 3 {
+		var sxy, sxx float64
+		for i, y := range daily {
+			dx := float64(i) - meanX
+			sxy += dx * (y - meanY)
+			sxx += dx * dx
+		}
+		slope = sxy / sxx
+		intercept = meanY - slope*meanX
+		dof = n - 2
+	}
+	if dof < 1 {
+		return intercept, slope, 0
+	}
+
+	var ss float64
+	for i, y := range daily {
+		r := y - (intercept + slope*float64(i))
+		ss += r * r
+	}
+	return intercept, slope, math.Sqrt(ss / dof)
+}
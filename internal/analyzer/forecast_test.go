@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// dailySessions returns perDay(i) sessions at 09:00 on each of the days
+// days before now through now's date, i counting from 0 on the first day.
+func dailySessions(now time.Time, days int, perDay func(i int) int) []claude.SessionMeta {
+	var sessions []claude.SessionMeta
+	for i := 0; i <= days; i++ {
+		d := now.AddDate(0, 0, i-days)
+		start := time.Date(d.Year(), d.Month(), d.Day(), 9, 0, 0, 0, now.Location())
+		for j := 0; j < perDay(i); j++ {
+			sessions = append(sessions, budgetSession(fmt.Sprintf("s%d-%d", i, j), start))
+		}
+	}
+	return sessions
+}
+
+func TestForecastCost_SteadySpend(t *testing.T) {
+	// Wednesday noon, after a session every day for four weeks.
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	pricing := DefaultPricing["sonnet"]
+	ratio := NoCacheRatio()
+	sessions := dailySessions(now, 40, func(int) int { return 1 })
+	unit := EstimateSessionCost(sessions[0], pricing, ratio)
+
+	f := ForecastCost(sessions, 0, now, pricing, ratio)
+
+	if f.WindowDays != forecastWindowDays || f.ActiveDays != forecastWindowDays {
+		t.Errorf("window = %d active of %d, want %d of %d", f.ActiveDays, f.WindowDays, forecastWindowDays, forecastWindowDays)
+	}
+	if f.LowConfidence {
+		t.Error("expected a confident forecast")
+	}
+	if math.Abs(f.DailyRateUSD-unit) > 1e-9 || math.Abs(f.TrendUSDPerDay) > 1e-9 {
+		t.Errorf("rate = %v trend %v, want %v and flat", f.DailyRateUSD, f.TrendUSDPerDay, unit)
+	}
+
+	// Week: Mon-Wed spent, half of today and Thu-Sun to come.
+	if math.Abs(f.Week.SpentUSD-3*unit) > 1e-9 || math.Abs(f.Week.ProjectedUSD-7.5*unit) > 1e-9 {
+		t.Errorf("week = %v projected from %v, want %v from %v", f.Week.ProjectedUSD, f.Week.SpentUSD, 7.5*unit, 3*unit)
+	}
+	// Month: 18 days spent, half of today and 13 more days to come.
+	if math.Abs(f.Month.ProjectedUSD-31.5*unit) > 1e-9 {
+		t.Errorf("month projected = %v, want %v", f.Month.ProjectedUSD, 31.5*unit)
+	}
+	// Identical days leave no residual spread.
+	if math.Abs(f.Month.HighUSD-f.Month.LowUSD) > 1e-9 {
+		t.Errorf("band = %v-%v, want none", f.Month.LowUSD, f.Month.HighUSD)
+	}
+	if !f.Week.End.Equal(time.Date(2026, 3, 23, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("week end = %v, want Monday 23 March", f.Week.End)
+	}
+}
+
+func TestForecastCost_RisingTrend(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	pricing := DefaultPricing["sonnet"]
+	ratio := NoCacheRatio()
+
+	flat := ForecastCost(dailySessions(now, 28, func(int) int { return 2 }), 0, now, pricing, ratio)
+	rising := ForecastCost(dailySessions(now, 28, func(i int) int { return i / 7 }), 0, now, pricing, ratio)
+
+	if rising.TrendUSDPerDay <= 0 {
+		t.Errorf("TrendUSDPerDay = %v, want rising", rising.TrendUSDPerDay)
+	}
+	// The rising history averages under two sessions a day but ends at
+	// three, so its projection overtakes the flat one.
+	if rising.Month.ProjectedUSD <= flat.Month.ProjectedUSD {
+		t.Errorf("rising projection %v not above flat %v", rising.Month.ProjectedUSD, flat.Month.ProjectedUSD)
+	}
+	if rising.Month.HighUSD <= rising.Month.ProjectedUSD || rising.Month.LowUSD >= rising.Month.ProjectedUSD {
+		t.Errorf("band %v-%v doesn't straddle %v", rising.Month.LowUSD, rising.Month.HighUSD, rising.Month.ProjectedUSD)
+	}
+}
+
+func TestForecastCost_SparseDataIsLowConfidence(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	pricing := DefaultPricing["sonnet"]
+	ratio := NoCacheRatio()
+	sessions := []claude.SessionMeta{
+		budgetSession("a", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)),
+		budgetSession("b", time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)),
+		budgetSession("c", time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)),
+	}
+
+	f := ForecastCost(sessions, 0, now, pricing, ratio)
+
+	if !f.LowConfidence {
+		t.Error("expected a low-confidence forecast")
+	}
+	if f.ActiveDays != 3 {
+		t.Errorf("ActiveDays = %d, want 3", f.ActiveDays)
+	}
+	// The window starts at the first session, not four weeks back.
+	if f.WindowDays != 18 {
+		t.Errorf("WindowDays = %d, want 18", f.WindowDays)
+	}
+	if f.TrendUSDPerDay != 0 {
+		t.Errorf("TrendUSDPerDay = %v, want no trend fitted", f.TrendUSDPerDay)
+	}
+	if f.Month.HighUSD-f.Month.LowUSD <= f.DailyRateUSD {
+		t.Errorf("band %v-%v not widened", f.Month.LowUSD, f.Month.HighUSD)
+	}
+	if f.Month.LowUSD < f.Month.SpentUSD {
+		t.Errorf("LowUSD %v below spend so far %v", f.Month.LowUSD, f.Month.SpentUSD)
+	}
+}
+
+func TestForecastCost_OverBudget(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	pricing := DefaultPricing["sonnet"]
+	ratio := NoCacheRatio()
+	sessions := dailySessions(now, 28, func(int) int { return 1 })
+	unit := EstimateSessionCost(sessions[0], pricing, ratio)
+
+	if f := ForecastCost(sessions, 20*unit, now, pricing, ratio); !f.OverBudget {
+		t.Errorf("projected %v against budget %v, want over budget", f.Month.ProjectedUSD, 20*unit)
+	}
+	if f := ForecastCost(sessions, 40*unit, now, pricing, ratio); f.OverBudget {
+		t.Errorf("projected %v against budget %v, want within budget", f.Month.ProjectedUSD, 40*unit)
+	}
+}
+
+func TestForecastCost_NoSessions(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	f := ForecastCost(nil, 100, now, DefaultPricing["sonnet"], NoCacheRatio())
+
+	if f.WindowDays != 0 || f.Month.ProjectedUSD != 0 || f.Month.HighUSD != 0 {
+		t.Errorf("got %+v, want an empty forecast", f)
+	}
+	if !f.LowConfidence || f.OverBudget {
+		t.Errorf("LowConfidence = %v OverBudget = %v, want true and false", f.LowConfidence, f.OverBudget)
+	}
+}
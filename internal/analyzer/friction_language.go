@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// UnknownLanguage groups sessions with no language data, and facets with no
+// matching session.
+const UnknownLanguage = "unknown"
+
+// frictionByLanguageTopN is how many friction types are listed per language.
+const frictionByLanguageTopN = 3
+
+// FrictionByLanguage breaks friction down by each session's dominant
+// language, so friction that only shows up in, say, Python sessions stands
+// out.
+type FrictionByLanguage struct {
+	// Languages is ordered by faceted session count, most first, with
+	// UnknownLanguage last.
+	Languages []LanguageFriction `json:"languages"`
+}
+
+// LanguageFriction is the friction in sessions whose dominant language is
+// Language.
+type LanguageFriction struct {
+	Language string `json:"language"`
+	// Sessions counts faceted sessions in this language.
+	Sessions             int     `json:"sessions"`
+	SessionsWithFriction int     `json:"sessions_with_friction"`
+	FrictionEvents       int     `json:"friction_events"`
+	FrictionPerSession   float64 `json:"friction_per_session"`
+	// TopTypes lists the most frequent friction types, most first.
+	TopTypes []FrictionTypeCount `json:"top_types,omitempty"`
+}
+
+// FrictionTypeCount is how often one friction type occurred.
+type FrictionTypeCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// HasKnownLanguage reports whether any session had language data, i.e. the
+// breakdown is more than a single UnknownLanguage entry.
+func (f FrictionByLanguage) HasKnownLanguage() bool {
+	for _, l := range f.Languages {
+		if l.Language != UnknownLanguage {
+			return true
+		}
+	}
+	return false
+}
+
+// AnalyzeFrictionByLanguage joins facets to sessions and groups their
+// friction by the session's dominant language: the language with the most
+// edits in SessionMeta.Languages, ties going to the alphabetically first.
+// Sessions without language data, and facets without a matching session,
+// count as UnknownLanguage.
+func AnalyzeFrictionByLanguage(sessions []claude.SessionMeta, facets []claude.SessionFacet) FrictionByLanguage {
+	languageBySession := make(map[string]string, len(sessions))
+	for _, s := range sessions {
+		languageBySession[s.SessionID] = dominantLanguage(s.Languages)
+	}
+
+	type accumulator struct {
+		LanguageFriction
+		byType map[string]int
+	}
+	byLanguage := make(map[string]*accumulator)
+	for _, f := range facets {
+		lang, ok := languageBySession[f.SessionID]
+		if !ok {
+			lang = UnknownLanguage
+		}
+		acc := byLanguage[lang]
+		if acc == nil {
+			acc = &accumulator{LanguageFriction: LanguageFriction{Language: lang}, byType: make(map[string]int)}
+			byLanguage[lang] = acc
+		}
+		acc.Sessions++
+		events := 0
+		for typ, n := range f.FrictionCounts {
+			acc.byType[typ] += n
+			events += n
+		}
+		if events > 0 {
+			acc.SessionsWithFriction++
+			acc.FrictionEvents += events
+		}
+	}
+
+	result := FrictionByLanguage{Languages: []LanguageFriction{}}
+	for _, acc := range byLanguage {
+		lf := acc.LanguageFriction
+		lf.FrictionPerSession = float64(lf.FrictionEvents) / float64(lf.Sessions)
+		for typ, n := range acc.byType {
+			if n > 0 {
+				lf.TopTypes = append(lf.TopTypes, FrictionTypeCount{Type: typ, Count: n})
+			}
+		}
+		sort.Slice(lf.TopTypes, func(i, j int) bool {
+			if lf.TopTypes[i].Count != lf.TopTypes[j].Count {
+				return lf.TopTypes[i].Count > lf.TopTypes[j].Count
+			}
+			return lf.TopTypes[i].Type < lf.TopTypes[j].Type
+		})
+		if len(lf.TopTypes) > frictionByLanguageTopN {
+			lf.TopTypes = lf.TopTypes[:frictionByLanguageTopN]
+		}
+		result.Languages = append(result.Languages, lf)
+	}
+
+	sort.Slice(result.Languages, func(i, j int) bool {
+		a, b := result.Languages[i], result.Languages[j]
+		if (a.Language == UnknownLanguage) != (b.Language == UnknownLanguage) {
+			return b.Language == UnknownLanguage
+		}
+		if a.Sessions != b.Sessions {
+			return a.Sessions > b.Sessions
+		}
+		return a.Language < b.Language
+	})
+	return result
+}
+
+// dominantLanguage returns the language with the highest count, or
+// UnknownLanguage when there is none.
+func dominantLanguage(languages map[string]int) string {
+	best, bestCount := UnknownLanguage, 0
+	for lang, n := range languages {
+		if lang == "" || n <= 0 {
+			continue
+		}
+		if n > bestCount || (n == bestCount && lang < best) {
+			best, bestCount = lang, n
+		}
+	}
+	return best
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeFrictionByLanguage_Empty(t *testing.T) {
+	result := AnalyzeFrictionByLanguage(nil, nil)
+	if result.Languages == nil {
+		t.Fatal("expected non-nil Languages slice")
+	}
+	if len(result.Languages) != 0 {
+		t.Errorf("expected 0 languages, got %d", len(result.Languages))
+	}
+	if result.HasKnownLanguage() {
+		t.Error("expected HasKnownLanguage false for empty input")
+	}
+}
+
+func TestAnalyzeFrictionByLanguage_GroupsByDominantLanguage(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "s1", Languages: map[string]int{"Go": 10, "Markdown": 2}},
+		{SessionID: "s2", Languages: map[string]int{"Go": 3}},
+		{SessionID: "s3", Languages: map[string]int{"Python": 5, "Go": 1}},
+		{SessionID: "s4"},
+	}
+	facets := []claude.SessionFacet{
+		{SessionID: "s1", FrictionCounts: map[string]int{"buggy_code": 2}},
+		{SessionID: "s2"},
+		{SessionID: "s3", FrictionCounts: map[string]int{"wrong_approach": 3}},
+		{SessionID: "s4", FrictionCounts: map[string]int{"misunderstood_request": 1}},
+		{SessionID: "orphan", FrictionCounts: map[string]int{"buggy_code": 1}},
+	}
+
+	result := AnalyzeFrictionByLanguage(sessions, facets)
+	if len(result.Languages) != 3 {
+		t.Fatalf("expected 3 languages, got %d: %+v", len(result.Languages), result.Languages)
+	}
+	if !result.HasKnownLanguage() {
+		t.Error("expected HasKnownLanguage true")
+	}
+
+	goLang := result.Languages[0]
+	if goLang.Language != "Go" {
+		t.Fatalf("expected Go first, got %q", goLang.Language)
+	}
+	if goLang.Sessions != 2 || goLang.SessionsWithFriction != 1 || goLang.FrictionEvents != 2 {
+		t.Errorf("unexpected Go counts: %+v", goLang)
+	}
+	if goLang.FrictionPerSession != 1.0 {
+		t.Errorf("expected Go friction per session 1.0, got %v", goLang.FrictionPerSession)
+	}
+
+	if result.Languages[1].Language != "Python" {
+		t.Errorf("expected Python second, got %q", result.Languages[1].Language)
+	}
+
+	// s4 has no language data and "orphan" has no session; both are unknown
+	// and unknown sorts last even though it ties Go on session count.
+	unknown := result.Languages[2]
+	if unknown.Language != UnknownLanguage {
+		t.Fatalf("expected %q last, got %q", UnknownLanguage, unknown.Language)
+	}
+	if unknown.Sessions != 2 || unknown.FrictionEvents != 2 {
+		t.Errorf("unexpected unknown counts: %+v", unknown)
+	}
+}
+
+func TestAnalyzeFrictionByLanguage_TopTypes(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "s1", Languages: map[string]int{"Rust": 1}},
+	}
+	facets := []claude.SessionFacet{
+		{SessionID: "s1", FrictionCounts: map[string]int{
+			"wrong_approach":        5,
+			"buggy_code":            3,
+			"excessive_changes":     3,
+			"misunderstood_request": 1,
+			"tool_error":            0,
+		}},
+	}
+
+	result := AnalyzeFrictionByLanguage(sessions, facets)
+	if len(result.Languages) != 1 {
+		t.Fatalf("expected 1 language, got %d", len(result.Languages))
+	}
+	top := result.Languages[0].TopTypes
+	want := []FrictionTypeCount{
+		{Type: "wrong_approach", Count: 5},
+		{Type: "buggy_code", Count: 3},
+		{Type: "excessive_changes", Count: 3},
+	}
+	if len(top) != len(want) {
+		t.Fatalf("expected %d top types, got %d: %+v", len(want), len(top), top)
+	}
+	for i := range want {
+		if top[i] != want[i] {
+			t.Errorf("top[%d] = %+v, want %+v", i, top[i], want[i])
+		}
+	}
+}
+
+func TestAnalyzeFrictionByLanguage_OnlyUnknown(t *testing.T) {
+	facets := []claude.SessionFacet{
+		{SessionID: "s1", FrictionCounts: map[string]int{"buggy_code": 1}},
+	}
+	result := AnalyzeFrictionByLanguage([]claude.SessionMeta{{SessionID: "s1"}}, facets)
+	if len(result.Languages) != 1 || result.Languages[0].Language != UnknownLanguage {
+		t.Fatalf("expected a single unknown entry, got %+v", result.Languages)
+	}
+	if result.HasKnownLanguage() {
+		t.Error("expected HasKnownLanguage false when only unknown is present")
+	}
+}
+
+func TestDominantLanguage(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages map[string]int
+		want      string
+	}{
+		{"nil", nil, UnknownLanguage},
+		{"zero counts", map[string]int{"Go": 0}, UnknownLanguage},
+		{"highest wins", map[string]int{"Go": 2, "Python": 7}, "Python"},
+		{"tie goes alphabetical", map[string]int{"TypeScript": 4, "Go": 4}, "Go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dominantLanguage(tt.languages); got != tt.want {
+				t.Errorf("dominantLanguage(%v) = %q, want %q", tt.languages, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"math"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// FrictionVelocityMinWeeks is how many weeks with faceted sessions the
+// velocity needs before it is reported.
+const FrictionVelocityMinWeeks = 4
+
+// frictionVelocityStablePct is the weekly rate of change, in percent, below
+// which friction counts as holding steady.
+const frictionVelocityStablePct = 2.0
+
+// Friction velocity directions.
+const (
+	VelocityImproving           = "improving"
+	VelocityStable              = "stable"
+	VelocityWorsening           = "worsening"
+	VelocityInsufficientHistory = "insufficient_history"
+)
+
+// FrictionVelocity is the overall rate at which friction per session is
+// changing, fitted across every week of history.
+type FrictionVelocity struct {
+	// Direction is "improving", "stable", "worsening", or
+	// "insufficient_history" when fewer than FrictionVelocityMinWeeks weeks
+	// have data.
+	Direction string `json:"direction"`
+	// Weeks is the number of weeks with at least one faceted session.
+	Weeks int `json:"weeks"`
+	// SlopePerWeek is the fitted change in friction per session per week.
+	SlopePerWeek float64 `json:"slope_per_week"`
+	// WeeklyChangePct is SlopePerWeek as a percentage of the average weekly
+	// friction per session. Negative means friction is falling.
+	WeeklyChangePct float64 `json:"weekly_change_pct"`
+	// AvgFrictionPerSession is the mean of the weekly friction per session.
+	AvgFrictionPerSession float64 `json:"avg_friction_per_session"`
+}
+
+// AnalyzeFrictionVelocity fits a least-squares line through each week's
+// friction per session, using the same ISO-week buckets as
+// AnalyzeFrictionPersistence, to answer whether friction overall is getting
+// better. Weeks without faceted sessions are left out of the fit but keep
+// their place on the time axis. Facets without a matching session are
+// skipped.
+func AnalyzeFrictionVelocity(facets []claude.SessionFacet, sessions []claude.SessionMeta) FrictionVelocity {
+	result := FrictionVelocity{Direction: VelocityInsufficientHistory}
+
+	sessionTime := sessionStartTimes(sessions)
+	type weekTotals struct{ sessions, friction int }
+	byWeek := make(map[[2]int]*weekTotals)
+	var earliest, latest time.Time
+	for _, f := range facets {
+		ts, ok := sessionTime[f.SessionID]
+		if !ok {
+			continue
+		}
+		if earliest.IsZero() || ts.Before(earliest) {
+			earliest = ts
+		}
+		if ts.After(latest) {
+			latest = ts
+		}
+		wk := weekKey(ts)
+		w, ok := byWeek[wk]
+		if !ok {
+			w = &weekTotals{}
+			byWeek[wk] = w
+		}
+		w.sessions++
+		for _, count := range f.FrictionCounts {
+			w.friction += count
+		}
+	}
+
+	result.Weeks = len(byWeek)
+	if result.Weeks < FrictionVelocityMinWeeks {
+		return result
+	}
+
+	var xs, ys []float64
+	for i, wk := range weeksBetween(earliest, latest) {
+		w, ok := byWeek[wk]
+		if !ok {
+			continue
+		}
+		xs = append(xs, float64(i))
+		ys = append(ys, float64(w.friction)/float64(w.sessions))
+	}
+
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var cov, varX float64
+	for i := range xs {
+		cov += (xs[i] - meanX) * (ys[i] - meanY)
+		varX += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+
+	result.AvgFrictionPerSession = meanY
+	if varX > 0 {
+		result.SlopePerWeek = cov / varX
+	}
+	if meanY > 0 {
+		result.WeeklyChangePct = result.SlopePerWeek / meanY * 100
+	}
+
+	switch {
+	case math.Abs(result.WeeklyChangePct) < frictionVelocityStablePct:
+		result.Direction = VelocityStable
+	case result.WeeklyChangePct < 0:
+		result.Direction = VelocityImproving
+	default:
+		result.Direction = VelocityWorsening
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// weeklyFriction builds one session per entry in perWeek, each a week apart
+// starting Monday Jan 5 2026, with that many friction events.
+func weeklyFriction(perWeek []int) ([]claude.SessionFacet, []claude.SessionMeta) {
+	start := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	var facets []claude.SessionFacet
+	var metas []claude.SessionMeta
+	for i, n := range perWeek {
+		id := fmt.Sprintf("s%d", i)
+		facets = append(facets, claude.SessionFacet{SessionID: id, FrictionCounts: map[string]int{"wrong_approach": n}})
+		metas = append(metas, claude.SessionMeta{SessionID: id, StartTime: start.AddDate(0, 0, 7*i).Format(time.RFC3339)})
+	}
+	return facets, metas
+}
+
+func TestAnalyzeFrictionVelocity_InsufficientHistory(t *testing.T) {
+	facets, metas := weeklyFriction([]int{4, 3, 2})
+	v := AnalyzeFrictionVelocity(facets, metas)
+	if v.Direction != VelocityInsufficientHistory {
+		t.Errorf("expected insufficient history with 3 weeks, got %q", v.Direction)
+	}
+	if v.Weeks != 3 {
+		t.Errorf("expected 3 weeks, got %d", v.Weeks)
+	}
+}
+
+func TestAnalyzeFrictionVelocity_Improving(t *testing.T) {
+	facets, metas := weeklyFriction([]int{4, 3, 2, 1})
+	v := AnalyzeFrictionVelocity(facets, metas)
+	if v.Direction != VelocityImproving {
+		t.Fatalf("expected improving, got %q", v.Direction)
+	}
+	if math.Abs(v.SlopePerWeek+1) > 1e-9 {
+		t.Errorf("expected slope -1/week, got %v", v.SlopePerWeek)
+	}
+	// Mean friction per session is 2.5, so -1/week is -40%/week.
+	if math.Abs(v.WeeklyChangePct+40) > 1e-9 {
+		t.Errorf("expected -40%%/week, got %v", v.WeeklyChangePct)
+	}
+}
+
+func TestAnalyzeFrictionVelocity_WorseningAndStable(t *testing.T) {
+	facets, metas := weeklyFriction([]int{1, 2, 3, 4, 5})
+	if v := AnalyzeFrictionVelocity(facets, metas); v.Direction != VelocityWorsening {
+		t.Errorf("expected worsening, got %q", v.Direction)
+	}
+
+	facets, metas = weeklyFriction([]int{2, 2, 2, 2})
+	v := AnalyzeFrictionVelocity(facets, metas)
+	if v.Direction != VelocityStable || v.SlopePerWeek != 0 {
+		t.Errorf("expected stable with zero slope, got %q %v", v.Direction, v.SlopePerWeek)
+	}
+}
+
+func TestAnalyzeFrictionVelocity_GapWeeksKeepSpacing(t *testing.T) {
+	// Weeks 0, 1, 2 and 5: the gap stretches the time axis, flattening the slope.
+	facets, metas := weeklyFriction([]int{6, 5, 4, 0, 0, 3})
+	facets = append(facets[:3], facets[5])
+	v := AnalyzeFrictionVelocity(facets, metas)
+	if v.Weeks != 4 {
+		t.Fatalf("expected 4 weeks with data, got %d", v.Weeks)
+	}
+	// Least-squares fit through (0,6) (1,5) (2,4) (5,3).
+	if math.Abs(v.SlopePerWeek-(-0.5714285714)) > 1e-6 {
+		t.Errorf("expected slope -4/7, got %v", v.SlopePerWeek)
+	}
+}
@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// messageEfficiencyPercentile is the user-message-count percentile above
+// which a session counts as high-message.
+const messageEfficiencyPercentile = 0.75
+
+// messageEfficiencyMinFacetSessions is how many faceted sessions each group
+// needs before their goal-achieved rates are compared.
+const messageEfficiencyMinFacetSessions = 5
+
+// messageEfficiencyOutcomeGap is how many points lower the high-message
+// group's goal-achieved rate must be to count as worse.
+const messageEfficiencyOutcomeGap = 0.15
+
+// MessageEfficiency relates how many messages the user sent in a session to
+// whether the session achieved its goal and committed anything. Many
+// back-and-forth messages often mean Claude has lost the thread.
+type MessageEfficiency struct {
+	// Sessions counts sessions with at least one user message.
+	Sessions int `json:"sessions"`
+	// Threshold is the 75th percentile of user messages per session;
+	// sessions with more are high-message.
+	Threshold int `json:"threshold"`
+	// High and Normal split Sessions at Threshold.
+	High   MessageGroup `json:"high"`
+	Normal MessageGroup `json:"normal"`
+	// OutcomeCorrelation is the Pearson r between user messages and goal
+	// achieved (1) or not (0), over faceted sessions.
+	OutcomeCorrelation float64 `json:"outcome_correlation"`
+	// CommitCorrelation is the Pearson r between user messages and commits.
+	CommitCorrelation float64 `json:"commit_correlation"`
+	// OutcomeGap is Normal.AchievedRate - High.AchievedRate, in points;
+	// positive when high-message sessions achieve their goals less often.
+	OutcomeGap float64 `json:"outcome_gap"`
+	// HighWorse reports that both groups had enough faceted sessions and
+	// high-message sessions achieved their goals clearly less often.
+	HighWorse bool `json:"high_worse"`
+}
+
+// MessageGroup summarizes the sessions on one side of the threshold.
+type MessageGroup struct {
+	Sessions      int     `json:"sessions"`
+	AvgMessages   float64 `json:"avg_messages"`
+	CommitRate    float64 `json:"commit_rate"`
+	AvgCommits    float64 `json:"avg_commits"`
+	FacetSessions int     `json:"facet_sessions"`
+	AchievedRate  float64 `json:"achieved_rate"`
+
+	messages, withCommits, commits, achievedFacets int
+}
+
+// AnalyzeMessageEfficiency splits sessions at the 75th percentile of user
+// messages per session and compares the goal-achieved and commit rates of
+// the two groups. Sessions without user messages are skipped, and outcomes
+// come from facets, so sessions without one count toward commits only.
+func AnalyzeMessageEfficiency(sessions []claude.SessionMeta, facets []claude.SessionFacet) MessageEfficiency {
+	var result MessageEfficiency
+
+	facetByID := make(map[string]*claude.SessionFacet, len(facets))
+	for i := range facets {
+		facetByID[facets[i].SessionID] = &facets[i]
+	}
+
+	var counts []int
+	for _, s := range sessions {
+		if s.UserMessageCount > 0 {
+			counts = append(counts, s.UserMessageCount)
+		}
+	}
+	if len(counts) == 0 {
+		return result
+	}
+	result.Sessions = len(counts)
+	sort.Ints(counts)
+	result.Threshold = counts[nearestRank(len(counts), messageEfficiencyPercentile)]
+
+	var msgs, commits, facetMsgs, achieved []float64
+	for _, s := range sessions {
+		if s.UserMessageCount == 0 {
+			continue
+		}
+		g := &result.Normal
+		if s.UserMessageCount > result.Threshold {
+			g = &result.High
+		}
+		g.Sessions++
+		g.messages += s.UserMessageCount
+		g.commits += s.GitCommits
+		if s.GitCommits > 0 {
+			g.withCommits++
+		}
+		msgs = append(msgs, float64(s.UserMessageCount))
+		commits = append(commits, float64(s.GitCommits))
+
+		f := facetByID[s.SessionID]
+		if f == nil {
+			continue
+		}
+		g.FacetSessions++
+		ok := 0.0
+		if f.Outcome == "achieved" || f.Outcome == "mostly_achieved" {
+			g.achievedFacets++
+			ok = 1
+		}
+		facetMsgs = append(facetMsgs, float64(s.UserMessageCount))
+		achieved = append(achieved, ok)
+	}
+	result.High.finish()
+	result.Normal.finish()
+
+	result.CommitCorrelation = pearsonR(msgs, commits)
+	result.OutcomeCorrelation = pearsonR(facetMsgs, achieved)
+
+	if result.High.FacetSessions > 0 && result.Normal.FacetSessions > 0 {
+		result.OutcomeGap = result.Normal.AchievedRate - result.High.AchievedRate
+	}
+	result.HighWorse = result.High.FacetSessions >= messageEfficiencyMinFacetSessions &&
+		result.Normal.FacetSessions >= messageEfficiencyMinFacetSessions &&
+		result.OutcomeGap >= messageEfficiencyOutcomeGap
+	return result
+}
+
+// finish computes the rates from the accumulated totals.
+func (g *MessageGroup) finish() {
+	if g.Sessions > 0 {
+		g.AvgMessages = float64(g.messages) / float64(g.Sessions)
+		g.CommitRate = float64(g.withCommits) / float64(g.Sessions)
+		g.AvgCommits = float64(g.commits) / float64(g.Sessions)
+	}
+	if g.FacetSessions > 0 {
+		g.AchievedRate = float64(g.achievedFacets) / float64(g.FacetSessions)
+	}
+}
+
+// nearestRank returns the index of the p-th percentile in a sorted slice of
+// n values, by the nearest-rank method.
+func nearestRank(n int, p float64) int {
+	i := int(math.Ceil(p*float64(n))) - 1
+	return max(0, min(i, n-1))
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeMessageEfficiency_Empty(t *testing.T) {
+	result := AnalyzeMessageEfficiency([]claude.SessionMeta{{SessionID: "a"}}, nil)
+	if result.Sessions != 0 || result.Threshold != 0 || result.HighWorse {
+		t.Errorf("expected empty result for sessions without user messages, got %+v", result)
+	}
+}
+
+func TestAnalyzeMessageEfficiency_HighMessageSessionsWorse(t *testing.T) {
+	// 15 short sessions, 12 of them achieved; 5 long ones, 1 achieved.
+	short := makeGroup("s", "", 15, 12)
+	long := makeGroup("l", "", 5, 1)
+	for i := range short {
+		short[i].UserMessageCount = 6
+	}
+	for i := range long {
+		long[i].UserMessageCount = 40
+	}
+	facets := append(groupFacets(short, 12, 0), groupFacets(long, 1, 0)...)
+
+	result := AnalyzeMessageEfficiency(append(short, long...), facets)
+
+	if result.Sessions != 20 {
+		t.Errorf("Sessions = %d, want 20", result.Sessions)
+	}
+	// The 75th percentile of twenty sessions is the 15th, a short one.
+	if result.Threshold != 6 {
+		t.Errorf("Threshold = %d, want 6", result.Threshold)
+	}
+	if result.High.Sessions != 5 || result.Normal.Sessions != 15 {
+		t.Errorf("groups = %d high, %d normal; want 5, 15", result.High.Sessions, result.Normal.Sessions)
+	}
+	if result.High.AchievedRate != 0.2 || result.Normal.AchievedRate != 0.8 {
+		t.Errorf("achieved = %v high, %v normal; want 0.2, 0.8", result.High.AchievedRate, result.Normal.AchievedRate)
+	}
+	if result.OutcomeGap < 0.6-1e-9 || result.OutcomeGap > 0.6+1e-9 {
+		t.Errorf("OutcomeGap = %v, want 0.6", result.OutcomeGap)
+	}
+	if !result.HighWorse {
+		t.Error("expected high-message sessions to be worse")
+	}
+	if result.OutcomeCorrelation >= 0 || result.CommitCorrelation >= 0 {
+		t.Errorf("correlations = %v outcome, %v commits; want both negative", result.OutcomeCorrelation, result.CommitCorrelation)
+	}
+}
+
+func TestAnalyzeMessageEfficiency_ThresholdFollowsDistribution(t *testing.T) {
+	var sessions []claude.SessionMeta
+	for i := 1; i <= 8; i++ {
+		sessions = append(sessions, claude.SessionMeta{SessionID: fmt.Sprint(i), UserMessageCount: i * 10})
+	}
+
+	result := AnalyzeMessageEfficiency(sessions, nil)
+
+	if result.Threshold != 60 {
+		t.Errorf("Threshold = %d, want 60 (75th percentile of 10..80)", result.Threshold)
+	}
+	if result.High.Sessions != 2 {
+		t.Errorf("High.Sessions = %d, want 2", result.High.Sessions)
+	}
+	// No facets: nothing to compare outcomes on.
+	if result.HighWorse || result.OutcomeGap != 0 {
+		t.Errorf("HighWorse = %v OutcomeGap = %v, want false and 0 without facets", result.HighWorse, result.OutcomeGap)
+	}
+}
+
+func TestAnalyzeMessageEfficiency_TooFewFacetedSessions(t *testing.T) {
+	short := makeGroup("s", "", 12, 12)
+	long := makeGroup("l", "", 3, 0)
+	for i := range short {
+		short[i].UserMessageCount = 6
+	}
+	for i := range long {
+		long[i].UserMessageCount = 40
+	}
+	facets := append(groupFacets(short, 12, 0), groupFacets(long, 0, 0)...)
+
+	result := AnalyzeMessageEfficiency(append(short, long...), facets)
+
+	if result.OutcomeGap != 1 {
+		t.Errorf("OutcomeGap = %v, want 1", result.OutcomeGap)
+	}
+	if result.HighWorse {
+		t.Error("expected no verdict with only 3 faceted high-message sessions")
+	}
+}
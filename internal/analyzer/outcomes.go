@@ -32,6 +32,9 @@ type OutcomeAnalysis struct {
 	TotalFilesModified  int     `json:"total_files_modified"`
 	TotalLinesAdded     int     `json:"total_lines_added"`
 	GoalAchievementRate float64 `json:"goal_achievement_rate"`
+	// SampleSize is the number of sessions with an outcome facet behind
+	// GoalAchievementRate.
+	SampleSize int `json:"sample_size"`
 
 	AvgCostPerCommit    float64 `json:"avg_cost_per_commit"`
 	AvgCostPerFile      float64 `json:"avg_cost_per_file"`
@@ -44,6 +47,14 @@ type OutcomeAnalysis struct {
 
 	// Per-project breakdown.
 	ByProject []ProjectOutcome `json:"by_project"`
+
+	// Rework following not_achieved sessions.
+	Rework ReworkAnalysis `json:"rework"`
+
+	// Drivers attributes the cost to tool categories and agent types; it
+	// needs agent tasks, so callers that have them fill it in with
+	// AnalyzeCostDrivers.
+	Drivers CostDrivers `json:"drivers"`
 }
 
 // ProjectOutcome aggregates cost-per-outcome for a single project.
@@ -136,6 +147,7 @@ func AnalyzeOutcomes(sessions []claude.SessionMeta, facets []claude.SessionFacet
 			}
 		}
 	}
+	result.SampleSize = goalsTotal
 	if goalsTotal > 0 {
 		result.GoalAchievementRate = float64(goalsAchieved) / float64(goalsTotal)
 	}
@@ -155,6 +167,8 @@ func AnalyzeOutcomes(sessions []claude.SessionMeta, facets []claude.SessionFacet
 	// Per-project breakdown.
 	result.ByProject = computeProjectOutcomes(result.Sessions)
 
+	result.Rework = AnalyzeRework(sessions, facets, pricing, ratio)
+
 	return result
 }
 
@@ -169,14 +183,79 @@ func EstimateSessionCost(s claude.SessionMeta, pricing ModelPricing, ratio Cache
 	}
 
 	// Fallback: single-tier pricing for older sessions without ModelUsage.
-	inputTokens := float64(s.InputTokens)
-	uncachedCost := inputTokens / 1_000_000.0 * pricing.InputPerMillion
-	cacheReadCost := (inputTokens * ratio.CacheReadMultiplier) / 1_000_000.0 * pricing.CacheReadPerMillion
-	cacheWriteCost := (inputTokens * ratio.CacheWriteMultiplier) / 1_000_000.0 * pricing.CacheWritePerMillion
-	outputCost := float64(s.OutputTokens) / 1_000_000.0 * pricing.OutputPerMillion
+	return EstimateTokenCost(s.InputTokens, s.OutputTokens, pricing, ratio)
+}
+
+// EstimateTokenCost prices input and output token counts at one tier's
+// rates, adding the cache reads and writes ratio predicts for that much
+// input. It is the single-tier math EstimateSessionCost uses for sessions
+// without per-model usage.
+func EstimateTokenCost(inputTokens, outputTokens int, pricing ModelPricing, ratio CacheRatio) float64 {
+	input := float64(inputTokens)
+	uncachedCost := input / 1_000_000.0 * pricing.InputPerMillion
+	cacheReadCost := (input * ratio.CacheReadMultiplier) / 1_000_000.0 * pricing.CacheReadPerMillion
+	cacheWriteCost := (input * ratio.CacheWriteMultiplier) / 1_000_000.0 * pricing.CacheWritePerMillion
+	outputCost := float64(outputTokens) / 1_000_000.0 * pricing.OutputPerMillion
 	return uncachedCost + cacheReadCost + cacheWriteCost + outputCost
 }
 
+// Cost breakdown bases: how the cache components of a CostBreakdown were
+// derived.
+const (
+	CostBasisModelUsage = "model_usage" // the session's own per-model token counts
+	CostBasisCacheRatio = "cache_ratio" // estimated from the stats-cache ratio
+	CostBasisNoCache    = "no_cache"    // no cache data; all input priced uncached
+)
+
+// CostBreakdown splits a session's estimated cost into its components.
+type CostBreakdown struct {
+	Input      float64 `json:"input"`
+	Output     float64 `json:"output"`
+	CacheRead  float64 `json:"cache_read"`
+	CacheWrite float64 `json:"cache_write"`
+	// CacheSavings is what caching saved against pricing every cached token
+	// as uncached input: the discount on cache reads less the premium on
+	// cache writes. It is negative when writes outweighed reads.
+	CacheSavings float64 `json:"cache_savings"`
+	Total        float64 `json:"total"`
+	// Basis is one of the CostBasis constants.
+	Basis string `json:"basis"`
+}
+
+// EstimateSessionCostBreakdown splits the cost EstimateSessionCost computes
+// into input, output, and cache components, priced the same way.
+func EstimateSessionCostBreakdown(s claude.SessionMeta, pricing ModelPricing, ratio CacheRatio) CostBreakdown {
+	var b CostBreakdown
+	add := func(p ModelPricing, input, output, cacheRead, cacheWrite float64) {
+		b.Input += input / 1_000_000.0 * p.InputPerMillion
+		b.Output += output / 1_000_000.0 * p.OutputPerMillion
+		b.CacheRead += cacheRead / 1_000_000.0 * p.CacheReadPerMillion
+		b.CacheWrite += cacheWrite / 1_000_000.0 * p.CacheWritePerMillion
+		b.CacheSavings += cacheRead/1_000_000.0*(p.InputPerMillion-p.CacheReadPerMillion) -
+			cacheWrite/1_000_000.0*(p.CacheWritePerMillion-p.InputPerMillion)
+	}
+
+	switch {
+	case len(s.ModelUsage) > 0:
+		b.Basis = CostBasisModelUsage
+		for modelName, stats := range s.ModelUsage {
+			add(getPricingForTier(ClassifyModelTier(modelName)),
+				float64(stats.InputTokens), float64(stats.OutputTokens),
+				float64(stats.CacheReadInputTokens), float64(stats.CacheCreationInputTokens))
+		}
+	case ratio == NoCacheRatio():
+		b.Basis = CostBasisNoCache
+		add(pricing, float64(s.InputTokens), float64(s.OutputTokens), 0, 0)
+	default:
+		b.Basis = CostBasisCacheRatio
+		input := float64(s.InputTokens)
+		add(pricing, input, float64(s.OutputTokens), input*ratio.CacheReadMultiplier, input*ratio.CacheWriteMultiplier)
+	}
+
+	b.Total = b.Input + b.Output + b.CacheRead + b.CacheWrite
+	return b
+}
+
 // estimateFromModelUsage computes cost by summing per-model costs from
 // SessionMeta.ModelUsage. Each model is classified via ClassifyModelTier
 // and priced via getPricingForTier (both already exist in models.go).
@@ -299,6 +378,18 @@ func computeProjectOutcomes(sessions []SessionOutcome) []ProjectOutcome {
 	return results
 }
 
+// GroupProjectOutcomes aggregates sessions like OutcomeAnalysis.ByProject,
+// but into the groups groupOf maps their project paths to, such as parent
+// directories. Each result's ProjectPath is its group.
+func GroupProjectOutcomes(sessions []SessionOutcome, groupOf func(projectPath string) string) []ProjectOutcome {
+	grouped := make([]SessionOutcome, len(sessions))
+	for i, s := range sessions {
+		s.ProjectPath = groupOf(s.ProjectPath)
+		grouped[i] = s
+	}
+	return computeProjectOutcomes(grouped)
+}
+
 // projectNameFromPath extracts the last path component as the project name.
 func projectNameFromPath(path string) string {
 	for i := len(path) - 1; i >= 0; i-- {
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"math"
 	"testing"
 
 	"github.com/blackwell-systems/claudewatch/internal/claude"
@@ -74,6 +75,9 @@ func TestAnalyzeOutcomes_GoalAchievement(t *testing.T) {
 	if result.GoalAchievementRate < 0.66 || result.GoalAchievementRate > 0.67 {
 		t.Errorf("expected ~66%% goal rate, got %.2f", result.GoalAchievementRate)
 	}
+	if result.SampleSize != 3 {
+		t.Errorf("SampleSize = %d, want 3", result.SampleSize)
+	}
 }
 
 func TestAnalyzeOutcomes_Trend(t *testing.T) {
@@ -203,6 +207,71 @@ func TestEstimateSessionCost_PerModel(t *testing.T) {
 	}
 }
 
+func TestEstimateSessionCostBreakdown_NoCache(t *testing.T) {
+	s := claude.SessionMeta{InputTokens: 1_000_000, OutputTokens: 100_000}
+
+	b := EstimateSessionCostBreakdown(s, testPricing, NoCacheRatio())
+	if b.Basis != CostBasisNoCache {
+		t.Errorf("Basis = %q, want %q", b.Basis, CostBasisNoCache)
+	}
+	if !approxEqual(b.Input, 3.00) || !approxEqual(b.Output, 1.50) {
+		t.Errorf("Input/Output = %.4f/%.4f, want 3.00/1.50", b.Input, b.Output)
+	}
+	if b.CacheRead != 0 || b.CacheWrite != 0 || b.CacheSavings != 0 {
+		t.Errorf("expected no cache components, got %+v", b)
+	}
+	if !approxEqual(b.Total, EstimateSessionCost(s, testPricing, NoCacheRatio())) {
+		t.Errorf("Total = %.4f, want EstimateSessionCost", b.Total)
+	}
+}
+
+func TestEstimateSessionCostBreakdown_CacheRatio(t *testing.T) {
+	s := claude.SessionMeta{InputTokens: 1_000_000, OutputTokens: 100_000}
+	ratio := CacheRatio{CacheReadMultiplier: 10, CacheWriteMultiplier: 1}
+
+	b := EstimateSessionCostBreakdown(s, testPricing, ratio)
+	if b.Basis != CostBasisCacheRatio {
+		t.Errorf("Basis = %q, want %q", b.Basis, CostBasisCacheRatio)
+	}
+	// 10M cache reads at $0.30/M and 1M cache writes at $3.75/M.
+	if !approxEqual(b.CacheRead, 3.00) || !approxEqual(b.CacheWrite, 3.75) {
+		t.Errorf("CacheRead/CacheWrite = %.4f/%.4f, want 3.00/3.75", b.CacheRead, b.CacheWrite)
+	}
+	// Reads saved 10M × $2.70/M; writes cost 1M × $0.75/M extra.
+	if !approxEqual(b.CacheSavings, 26.25) {
+		t.Errorf("CacheSavings = %.4f, want 26.25", b.CacheSavings)
+	}
+	if !approxEqual(b.Total, EstimateSessionCost(s, testPricing, ratio)) {
+		t.Errorf("Total = %.4f, want %.4f", b.Total, EstimateSessionCost(s, testPricing, ratio))
+	}
+}
+
+func TestEstimateSessionCostBreakdown_ModelUsage(t *testing.T) {
+	s := claude.SessionMeta{
+		ModelUsage: map[string]claude.ModelStats{
+			"claude-3-opus-20240229": {InputTokens: 1_000_000, OutputTokens: 100_000, CacheReadInputTokens: 2_000_000},
+			"claude-sonnet-4-5":      {InputTokens: 1_000_000, CacheCreationInputTokens: 1_000_000},
+		},
+	}
+
+	b := EstimateSessionCostBreakdown(s, testPricing, NoCacheRatio())
+	if b.Basis != CostBasisModelUsage {
+		t.Errorf("Basis = %q, want %q", b.Basis, CostBasisModelUsage)
+	}
+	if b.CacheRead == 0 || b.CacheWrite == 0 {
+		t.Errorf("expected cache components from model usage, got %+v", b)
+	}
+	if !approxEqual(b.Total, EstimateSessionCost(s, testPricing, NoCacheRatio())) {
+		t.Errorf("Total = %.4f, want %.4f", b.Total, EstimateSessionCost(s, testPricing, NoCacheRatio()))
+	}
+}
+
+// approxEqual reports whether two dollar amounts agree to within a tenth of
+// a cent.
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 0.001
+}
+
 func TestEstimateSessionCost_PerModelMulti(t *testing.T) {
 	// Session with both Sonnet and Opus in ModelUsage.
 	s := claude.SessionMeta{
@@ -365,3 +434,24 @@ func TestAnalyzeOutcomes_WithCacheRatio(t *testing.T) {
 		t.Errorf("expected total cost ~$%.4f, got $%.4f", expected, withCache.TotalCost)
 	}
 }
+
+func TestGroupProjectOutcomes(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "s1", ProjectPath: "/clients/acme/api", StartTime: "2026-01-01T10:00:00Z", InputTokens: 1_000_000, GitCommits: 2},
+		{SessionID: "s2", ProjectPath: "/clients/acme/web", StartTime: "2026-01-02T10:00:00Z", InputTokens: 1_000_000, GitCommits: 1},
+		{SessionID: "s3", ProjectPath: "/clients/beta/app", StartTime: "2026-01-03T10:00:00Z", InputTokens: 500_000},
+	}
+	result := AnalyzeOutcomes(sessions, nil, testPricing, NoCacheRatio())
+
+	groups := GroupProjectOutcomes(result.Sessions, func(path string) string { return path[:len("/clients/acme")] })
+
+	if len(groups) != 2 || groups[0].ProjectPath != "/clients/acme" || groups[1].ProjectPath != "/clients/beta" {
+		t.Fatalf("groups = %+v, want /clients/acme then /clients/beta", groups)
+	}
+	if groups[0].Sessions != 2 || groups[0].TotalCommits != 3 || groups[0].TotalCost != 6.0 || groups[0].CostPerCommit != 2.0 {
+		t.Errorf("acme = %+v, want 2 sessions, 3 commits, $6.00, $2.00/commit", groups[0])
+	}
+	if result.Sessions[0].ProjectPath != "/clients/acme/api" {
+		t.Error("grouping should not modify the analyzed sessions")
+	}
+}
@@ -0,0 +1,186 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// Dependency heuristics for EstimateParallelSavings. Agent transcripts don't
+// record which task consumed another's output, so dependency is approximated
+// by time adjacency.
+const (
+	// parallelChainGap is the largest idle gap between one agent finishing and
+	// the next starting for the two to be treated as chained (the second was
+	// launched in direct response to the first's result).
+	parallelChainGap = 10 * time.Second
+
+	// parallelBurstGap is the largest idle gap between independent agents for
+	// them to be considered part of the same batch of work. Agents further
+	// apart than this belong to different phases of the session and could not
+	// realistically have been launched together.
+	parallelBurstGap = 5 * time.Minute
+)
+
+// ParallelSavings estimates the wall-clock time that could have been saved by
+// running sequential, independent agent tasks concurrently.
+type ParallelSavings struct {
+	// Sessions holds per-session estimates for sessions with savings > 0.
+	Sessions []SessionParallelSavings `json:"sessions"`
+
+	// ParallelizableTasks is the number of agent tasks that ran sequentially
+	// alongside at least one other independent task in the same batch.
+	ParallelizableTasks int `json:"parallelizable_tasks"`
+
+	// SequentialMs is the summed duration of the parallelizable batches as
+	// they actually ran, one after another.
+	SequentialMs int64 `json:"sequential_ms"`
+
+	// CriticalPathMs is the summed duration of the same batches had their
+	// independent units run concurrently (longest unit per batch).
+	CriticalPathMs int64 `json:"critical_path_ms"`
+
+	// EstimatedSavedMs is SequentialMs - CriticalPathMs.
+	EstimatedSavedMs int64 `json:"estimated_saved_ms"`
+}
+
+// SessionParallelSavings is the parallelization estimate for one session.
+type SessionParallelSavings struct {
+	SessionID           string `json:"session_id"`
+	ParallelizableTasks int    `json:"parallelizable_tasks"`
+	SequentialMs        int64  `json:"sequential_ms"`
+	CriticalPathMs      int64  `json:"critical_path_ms"`
+	EstimatedSavedMs    int64  `json:"estimated_saved_ms"`
+}
+
+// EstimatedSavedMinutes returns the total estimated savings in minutes.
+func (p ParallelSavings) EstimatedSavedMinutes() float64 {
+	return float64(p.EstimatedSavedMs) / float64(time.Minute/time.Millisecond)
+}
+
+// agentUnit is a group of agent tasks that must run as one sequential block:
+// tasks that overlapped (already concurrent) or were chained back-to-back.
+type agentUnit struct {
+	start time.Time
+	end   time.Time
+	tasks int
+}
+
+// EstimateParallelSavings computes, per session, how much wall-clock time was
+// spent running independent foreground agents one after another.
+//
+// Within a session, foreground tasks are ordered by launch time and merged into
+// units: a task joins the current unit when it overlaps it or starts within
+// parallelChainGap of it ending, since either suggests a dependency. Units
+// separated by at most parallelBurstGap form a batch. For each batch with two
+// or more units, the sequential cost is the sum of unit spans and the critical
+// path is the longest unit span; the difference is the estimated saving.
+//
+// Background tasks and tasks without a parseable CreatedAt or positive
+// DurationMs are ignored.
+func EstimateParallelSavings(tasks []claude.AgentTask) ParallelSavings {
+	result := ParallelSavings{
+		Sessions: []SessionParallelSavings{},
+	}
+
+	bySession := make(map[string][]claude.AgentTask)
+	for _, task := range tasks {
+		if task.Background || task.DurationMs <= 0 {
+			continue
+		}
+		if claude.ParseTimestamp(task.CreatedAt).IsZero() {
+			continue
+		}
+		bySession[task.SessionID] = append(bySession[task.SessionID], task)
+	}
+
+	for sessionID, sessionTasks := range bySession {
+		est := estimateSessionParallelSavings(sessionTasks)
+		if est.EstimatedSavedMs <= 0 {
+			continue
+		}
+		est.SessionID = sessionID
+
+		result.Sessions = append(result.Sessions, est)
+		result.ParallelizableTasks += est.ParallelizableTasks
+		result.SequentialMs += est.SequentialMs
+		result.CriticalPathMs += est.CriticalPathMs
+		result.EstimatedSavedMs += est.EstimatedSavedMs
+	}
+
+	sort.Slice(result.Sessions, func(i, j int) bool {
+		if result.Sessions[i].EstimatedSavedMs != result.Sessions[j].EstimatedSavedMs {
+			return result.Sessions[i].EstimatedSavedMs > result.Sessions[j].EstimatedSavedMs
+		}
+		return result.Sessions[i].SessionID < result.Sessions[j].SessionID
+	})
+
+	return result
+}
+
+// estimateSessionParallelSavings applies the unit/batch model to the
+// foreground tasks of a single session.
+func estimateSessionParallelSavings(tasks []claude.AgentTask) SessionParallelSavings {
+	var est SessionParallelSavings
+	if len(tasks) < 2 {
+		return est
+	}
+
+	type span struct {
+		start time.Time
+		end   time.Time
+	}
+	spans := make([]span, len(tasks))
+	for i, task := range tasks {
+		start := claude.ParseTimestamp(task.CreatedAt)
+		spans[i] = span{start: start, end: start.Add(time.Duration(task.DurationMs) * time.Millisecond)}
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start.Before(spans[j].start)
+	})
+
+	// Merge overlapping or chained tasks into units.
+	var units []agentUnit
+	for _, sp := range spans {
+		if n := len(units); n > 0 && sp.start.Before(units[n-1].end.Add(parallelChainGap)) {
+			if sp.end.After(units[n-1].end) {
+				units[n-1].end = sp.end
+			}
+			units[n-1].tasks++
+			continue
+		}
+		units = append(units, agentUnit{start: sp.start, end: sp.end, tasks: 1})
+	}
+
+	// Group units into batches and accumulate savings for multi-unit batches.
+	flush := func(batch []agentUnit) {
+		if len(batch) < 2 {
+			return
+		}
+		var total, longest time.Duration
+		for _, u := range batch {
+			d := u.end.Sub(u.start)
+			total += d
+			if d > longest {
+				longest = d
+			}
+			est.ParallelizableTasks += u.tasks
+		}
+		est.SequentialMs += total.Milliseconds()
+		est.CriticalPathMs += longest.Milliseconds()
+		est.EstimatedSavedMs += (total - longest).Milliseconds()
+	}
+
+	var batch []agentUnit
+	for _, u := range units {
+		if n := len(batch); n > 0 && u.start.Sub(batch[n-1].end) > parallelBurstGap {
+			flush(batch)
+			batch = nil
+		}
+		batch = append(batch, u)
+	}
+	flush(batch)
+
+	return est
+}
@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// parallelTask builds a foreground agent task starting at base+offset and
+// running for the given duration.
+func parallelTask(sessionID string, base time.Time, offset, duration time.Duration) claude.AgentTask {
+	return claude.AgentTask{
+		SessionID:  sessionID,
+		AgentType:  "Explore",
+		Status:     "completed",
+		CreatedAt:  base.Add(offset).Format(time.RFC3339),
+		DurationMs: duration.Milliseconds(),
+	}
+}
+
+func TestEstimateParallelSavings_Empty(t *testing.T) {
+	got := EstimateParallelSavings(nil)
+	if got.EstimatedSavedMs != 0 {
+		t.Errorf("EstimatedSavedMs = %d, want 0", got.EstimatedSavedMs)
+	}
+	if got.Sessions == nil {
+		t.Error("Sessions should be initialized, not nil")
+	}
+}
+
+func TestEstimateParallelSavings_IndependentSequential(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	// Three independent agents separated by 1-minute gaps: 4m, 2m, 3m.
+	tasks := []claude.AgentTask{
+		parallelTask("s1", base, 0, 4*time.Minute),
+		parallelTask("s1", base, 5*time.Minute, 2*time.Minute),
+		parallelTask("s1", base, 8*time.Minute, 3*time.Minute),
+	}
+
+	got := EstimateParallelSavings(tasks)
+
+	if got.ParallelizableTasks != 3 {
+		t.Errorf("ParallelizableTasks = %d, want 3", got.ParallelizableTasks)
+	}
+	if want := (9 * time.Minute).Milliseconds(); got.SequentialMs != want {
+		t.Errorf("SequentialMs = %d, want %d", got.SequentialMs, want)
+	}
+	if want := (4 * time.Minute).Milliseconds(); got.CriticalPathMs != want {
+		t.Errorf("CriticalPathMs = %d, want %d", got.CriticalPathMs, want)
+	}
+	if got.EstimatedSavedMinutes() != 5 {
+		t.Errorf("EstimatedSavedMinutes = %v, want 5", got.EstimatedSavedMinutes())
+	}
+	if len(got.Sessions) != 1 || got.Sessions[0].SessionID != "s1" {
+		t.Errorf("expected a single s1 session entry, got %+v", got.Sessions)
+	}
+}
+
+func TestEstimateParallelSavings_ChainedTasksNotParallelizable(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	// Each agent starts 2s after the previous one ends: a dependency chain.
+	tasks := []claude.AgentTask{
+		parallelTask("s1", base, 0, time.Minute),
+		parallelTask("s1", base, time.Minute+2*time.Second, time.Minute),
+		parallelTask("s1", base, 2*time.Minute+4*time.Second, time.Minute),
+	}
+
+	got := EstimateParallelSavings(tasks)
+	if got.EstimatedSavedMs != 0 {
+		t.Errorf("EstimatedSavedMs = %d, want 0 for chained tasks", got.EstimatedSavedMs)
+	}
+	if len(got.Sessions) != 0 {
+		t.Errorf("expected no session entries, got %d", len(got.Sessions))
+	}
+}
+
+func TestEstimateParallelSavings_OverlappingTasksNotParallelizable(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	// Already concurrent.
+	tasks := []claude.AgentTask{
+		parallelTask("s1", base, 0, 3*time.Minute),
+		parallelTask("s1", base, 30*time.Second, 2*time.Minute),
+	}
+
+	got := EstimateParallelSavings(tasks)
+	if got.EstimatedSavedMs != 0 {
+		t.Errorf("EstimatedSavedMs = %d, want 0 for overlapping tasks", got.EstimatedSavedMs)
+	}
+}
+
+func TestEstimateParallelSavings_DistantPhasesNotCombined(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	// An hour apart: different phases of the session.
+	tasks := []claude.AgentTask{
+		parallelTask("s1", base, 0, 2*time.Minute),
+		parallelTask("s1", base, time.Hour, 2*time.Minute),
+	}
+
+	got := EstimateParallelSavings(tasks)
+	if got.EstimatedSavedMs != 0 {
+		t.Errorf("EstimatedSavedMs = %d, want 0 for distant tasks", got.EstimatedSavedMs)
+	}
+}
+
+func TestEstimateParallelSavings_IgnoresBackgroundAndUntimed(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	bg := parallelTask("s1", base, 3*time.Minute, 2*time.Minute)
+	bg.Background = true
+	untimed := parallelTask("s1", base, 6*time.Minute, 2*time.Minute)
+	untimed.CreatedAt = ""
+
+	tasks := []claude.AgentTask{
+		parallelTask("s1", base, 0, 2*time.Minute),
+		bg,
+		untimed,
+	}
+
+	got := EstimateParallelSavings(tasks)
+	if got.EstimatedSavedMs != 0 {
+		t.Errorf("EstimatedSavedMs = %d, want 0 when only one eligible task", got.EstimatedSavedMs)
+	}
+}
+
+func TestEstimateParallelSavings_SessionsIsolated(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	// Same timing but different sessions: never combined.
+	tasks := []claude.AgentTask{
+		parallelTask("s1", base, 0, 2*time.Minute),
+		parallelTask("s2", base, 3*time.Minute, 2*time.Minute),
+	}
+
+	got := EstimateParallelSavings(tasks)
+	if got.EstimatedSavedMs != 0 {
+		t.Errorf("EstimatedSavedMs = %d, want 0 across sessions", got.EstimatedSavedMs)
+	}
+}
@@ -34,18 +34,26 @@ type FrictionPersistence struct {
 	// ConsecutiveWeeks is how many consecutive weeks this friction type appeared.
 	ConsecutiveWeeks int `json:"consecutive_weeks"`
 
-	// Stale is true when the friction has appeared for 3+ consecutive weeks without improving.
+	// Stale is true when the friction has appeared for at least the stale
+	// threshold of consecutive weeks without improving.
 	Stale bool `json:"stale"`
 }
 
+// DefaultStaleWeeks is the number of consecutive weeks a friction type must
+// persist without improving to be considered stale.
+const DefaultStaleWeeks = 3
+
 // PersistenceAnalysis is the result of analyzing friction persistence across sessions.
 type PersistenceAnalysis struct {
 	// Patterns contains persistence data for each observed friction type.
 	Patterns []FrictionPersistence `json:"patterns"`
 
-	// StaleCount is the number of patterns present 3+ weeks without improving.
+	// StaleCount is the number of patterns present StaleWeeks+ weeks without improving.
 	StaleCount int `json:"stale_count"`
 
+	// StaleWeeks is the consecutive-week threshold used to mark patterns stale.
+	StaleWeeks int `json:"stale_weeks"`
+
 	// ImprovingCount is the number of patterns trending downward.
 	ImprovingCount int `json:"improving_count"`
 
@@ -150,36 +158,42 @@ func consecutiveWeeksFromEnd(allWeeks [][2]int, weekPresence map[[2]int]bool) in
 	return count
 }
 
+// sessionStartTimes maps session IDs to their parsed start times, skipping
+// sessions without an ID or a parseable start time.
+func sessionStartTimes(metas []claude.SessionMeta) map[string]time.Time {
+	sessionTime := make(map[string]time.Time, len(metas))
+	for _, m := range metas {
+		if m.SessionID == "" || m.StartTime == "" {
+			continue
+		}
+		t, err := claude.ParseSessionTime(m.StartTime)
+		if err != nil {
+			continue
+		}
+		sessionTime[m.SessionID] = t
+	}
+	return sessionTime
+}
+
 // AnalyzeFrictionPersistence examines whether friction patterns persist across
 // sessions over time. It correlates facets with session metadata to obtain
 // timestamps, then buckets friction occurrences into weekly bins to compute
-// trends and staleness.
+// trends and staleness. A pattern is stale once it has appeared in staleWeeks
+// consecutive weeks without improving; values below 1 use DefaultStaleWeeks.
 //
 // Sessions in facets that have no matching entry in metas (and thus no timestamp)
 // are excluded from the analysis.
-func AnalyzeFrictionPersistence(facets []claude.SessionFacet, metas []claude.SessionMeta) PersistenceAnalysis {
-	result := PersistenceAnalysis{}
+func AnalyzeFrictionPersistence(facets []claude.SessionFacet, metas []claude.SessionMeta, staleWeeks int) PersistenceAnalysis {
+	if staleWeeks < 1 {
+		staleWeeks = DefaultStaleWeeks
+	}
+	result := PersistenceAnalysis{StaleWeeks: staleWeeks}
 
 	if len(facets) == 0 {
 		return result
 	}
 
-	// Build session-id to start-time lookup from session metadata.
-	sessionTime := make(map[string]time.Time, len(metas))
-	for _, m := range metas {
-		if m.SessionID == "" || m.StartTime == "" {
-			continue
-		}
-		t, err := time.Parse(time.RFC3339, m.StartTime)
-		if err != nil {
-			// Try alternative formats that Claude Code might use.
-			t, err = time.Parse("2006-01-02T15:04:05", m.StartTime)
-			if err != nil {
-				continue
-			}
-		}
-		sessionTime[m.SessionID] = t
-	}
+	sessionTime := sessionStartTimes(metas)
 
 	// Pair each facet with its timestamp, skipping those without metadata.
 	type timedFacet struct {
@@ -259,7 +273,7 @@ func AnalyzeFrictionPersistence(facets []claude.SessionFacet, metas []claude.Ses
 		trend := computeTrend(weeklyCounts)
 		consec := consecutiveWeeksFromEnd(allWeeks, fd.weekPresence)
 		freq := float64(fd.sessionCount) / float64(totalSessions)
-		stale := consec >= 3 && trend != "improving"
+		stale := consec >= staleWeeks && trend != "improving"
 
 		p := FrictionPersistence{
 			FrictionType:     frictionType,
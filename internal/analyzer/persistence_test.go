@@ -8,12 +8,12 @@ import (
 )
 
 func TestAnalyzeFrictionPersistence_Empty(t *testing.T) {
-	result := AnalyzeFrictionPersistence(nil, nil)
+	result := AnalyzeFrictionPersistence(nil, nil, DefaultStaleWeeks)
 	if len(result.Patterns) != 0 {
 		t.Errorf("expected 0 patterns for nil input, got %d", len(result.Patterns))
 	}
 
-	result = AnalyzeFrictionPersistence([]claude.SessionFacet{}, []claude.SessionMeta{})
+	result = AnalyzeFrictionPersistence([]claude.SessionFacet{}, []claude.SessionMeta{}, DefaultStaleWeeks)
 	if len(result.Patterns) != 0 {
 		t.Errorf("expected 0 patterns for empty input, got %d", len(result.Patterns))
 	}
@@ -24,7 +24,7 @@ func TestAnalyzeFrictionPersistence_NoMatchingMetas(t *testing.T) {
 		{SessionID: "s1", FrictionCounts: map[string]int{"wrong_approach": 1}},
 	}
 	// No metas to match, so no timed facets.
-	result := AnalyzeFrictionPersistence(facets, nil)
+	result := AnalyzeFrictionPersistence(facets, nil, DefaultStaleWeeks)
 	if len(result.Patterns) != 0 {
 		t.Errorf("expected 0 patterns when no metas match, got %d", len(result.Patterns))
 	}
@@ -38,7 +38,7 @@ func TestAnalyzeFrictionPersistence_SingleSession(t *testing.T) {
 		{SessionID: "s1", StartTime: "2026-01-05T10:00:00Z"},
 	}
 
-	result := AnalyzeFrictionPersistence(facets, metas)
+	result := AnalyzeFrictionPersistence(facets, metas, DefaultStaleWeeks)
 	if len(result.Patterns) != 1 {
 		t.Fatalf("expected 1 pattern, got %d", len(result.Patterns))
 	}
@@ -94,7 +94,7 @@ func TestAnalyzeFrictionPersistence_ImprovingTrend(t *testing.T) {
 		{SessionID: "s8", StartTime: "2026-01-26T10:00:00Z"},
 	}
 
-	result := AnalyzeFrictionPersistence(facets, metas)
+	result := AnalyzeFrictionPersistence(facets, metas, DefaultStaleWeeks)
 	if len(result.Patterns) != 1 {
 		t.Fatalf("expected 1 pattern, got %d", len(result.Patterns))
 	}
@@ -135,7 +135,7 @@ func TestAnalyzeFrictionPersistence_WorseningTrend(t *testing.T) {
 		{SessionID: "s8", StartTime: "2026-01-28T10:00:00Z"},
 	}
 
-	result := AnalyzeFrictionPersistence(facets, metas)
+	result := AnalyzeFrictionPersistence(facets, metas, DefaultStaleWeeks)
 	if len(result.Patterns) != 1 {
 		t.Fatalf("expected 1 pattern, got %d", len(result.Patterns))
 	}
@@ -169,7 +169,7 @@ func TestAnalyzeFrictionPersistence_StalePattern(t *testing.T) {
 		{SessionID: "s4", StartTime: "2026-01-26T10:00:00Z"},
 	}
 
-	result := AnalyzeFrictionPersistence(facets, metas)
+	result := AnalyzeFrictionPersistence(facets, metas, DefaultStaleWeeks)
 	if len(result.Patterns) != 1 {
 		t.Fatalf("expected 1 pattern, got %d", len(result.Patterns))
 	}
@@ -205,7 +205,7 @@ func TestAnalyzeFrictionPersistence_SortOrder(t *testing.T) {
 		{SessionID: "s3", StartTime: "2026-01-19T10:00:00Z"},
 	}
 
-	result := AnalyzeFrictionPersistence(facets, metas)
+	result := AnalyzeFrictionPersistence(facets, metas, DefaultStaleWeeks)
 	if len(result.Patterns) < 2 {
 		t.Fatalf("expected at least 2 patterns, got %d", len(result.Patterns))
 	}
@@ -225,7 +225,7 @@ func TestAnalyzeFrictionPersistence_MultipleFrictionTypes(t *testing.T) {
 		{SessionID: "s2", StartTime: "2026-01-06T10:00:00Z"},
 	}
 
-	result := AnalyzeFrictionPersistence(facets, metas)
+	result := AnalyzeFrictionPersistence(facets, metas, DefaultStaleWeeks)
 	if len(result.Patterns) != 2 {
 		t.Fatalf("expected 2 patterns, got %d", len(result.Patterns))
 	}
@@ -348,3 +348,40 @@ func TestWeeksBetween(t *testing.T) {
 		t.Errorf("reversed: expected nil, got %v", weeks)
 	}
 }
+
+func TestAnalyzeFrictionPersistence_StaleWeeksThreshold(t *testing.T) {
+	// Same friction every week for 3 weeks: stale at 2 or 3, not at 4.
+	facets := []claude.SessionFacet{
+		{SessionID: "s1", FrictionCounts: map[string]int{"wrong_approach": 1}},
+		{SessionID: "s2", FrictionCounts: map[string]int{"wrong_approach": 1}},
+		{SessionID: "s3", FrictionCounts: map[string]int{"wrong_approach": 1}},
+	}
+	metas := []claude.SessionMeta{
+		{SessionID: "s1", StartTime: "2026-01-05T10:00:00Z"},
+		{SessionID: "s2", StartTime: "2026-01-12T10:00:00Z"},
+		{SessionID: "s3", StartTime: "2026-01-19T10:00:00Z"},
+	}
+
+	tests := []struct {
+		staleWeeks int
+		wantStale  bool
+		wantWeeks  int
+	}{
+		{staleWeeks: 2, wantStale: true, wantWeeks: 2},
+		{staleWeeks: 3, wantStale: true, wantWeeks: 3},
+		{staleWeeks: 4, wantStale: false, wantWeeks: 4},
+		{staleWeeks: 0, wantStale: true, wantWeeks: DefaultStaleWeeks},
+	}
+	for _, tt := range tests {
+		result := AnalyzeFrictionPersistence(facets, metas, tt.staleWeeks)
+		if len(result.Patterns) != 1 {
+			t.Fatalf("staleWeeks=%d: expected 1 pattern, got %d", tt.staleWeeks, len(result.Patterns))
+		}
+		if got := result.Patterns[0].Stale; got != tt.wantStale {
+			t.Errorf("staleWeeks=%d: Stale = %v, want %v", tt.staleWeeks, got, tt.wantStale)
+		}
+		if result.StaleWeeks != tt.wantWeeks {
+			t.Errorf("staleWeeks=%d: StaleWeeks = %d, want %d", tt.staleWeeks, result.StaleWeeks, tt.wantWeeks)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+const (
+	// PlanModeMinSessions is how many sessions a project needs both with and
+	// without plan agents before the groups are compared.
+	PlanModeMinSessions = 5
+
+	// PlanModeRateThreshold is the gap in commit rate or goal-achieved rate,
+	// as a fraction, at which planning is said to help or hurt.
+	PlanModeRateThreshold = 0.15
+
+	// PlanModeFrictionThreshold is the gap in friction events per faceted
+	// session at which planning is said to help or hurt.
+	PlanModeFrictionThreshold = 0.5
+)
+
+// PlanModeEffect compares, per project, sessions that ran plan agents with
+// sessions that didn't.
+type PlanModeEffect struct {
+	// Projects lists every project with enough sessions in both groups,
+	// worst commit-rate delta first.
+	Projects []ProjectPlanModeEffect `json:"projects"`
+	// Helps and Hurts count projects whose verdict is "helps" or "hurts".
+	Helps int `json:"helps"`
+	Hurts int `json:"hurts"`
+	// Insufficient counts projects that planned but lack
+	// PlanModeMinSessions sessions in one of the groups.
+	Insufficient int `json:"insufficient"`
+}
+
+// ProjectPlanModeEffect is the with/without-planning comparison for one
+// project.
+type ProjectPlanModeEffect struct {
+	ProjectPath  string             `json:"project_path"`
+	ProjectName  string             `json:"project_name"`
+	WithPlan     PlanModeGroupStats `json:"with_plan"`
+	WithoutPlan  PlanModeGroupStats `json:"without_plan"`
+	PlanKillRate float64            `json:"plan_kill_rate"`
+	// CommitRateDelta is WithPlan.CommitRate - WithoutPlan.CommitRate.
+	CommitRateDelta float64 `json:"commit_rate_delta"`
+	// AchievedRateDelta is WithPlan.AchievedRate - WithoutPlan.AchievedRate,
+	// or 0 when either group has no faceted sessions.
+	AchievedRateDelta float64 `json:"achieved_rate_delta"`
+	// FrictionDelta is WithPlan.AvgFriction - WithoutPlan.AvgFriction, or 0
+	// when either group has no faceted sessions.
+	FrictionDelta float64 `json:"friction_delta"`
+	// Verdict is "hurts" when planned sessions do worse on some measure and
+	// better on none, "helps" for the reverse, "mixed" when they do both,
+	// and "neutral" when no gap passes its threshold.
+	Verdict string `json:"verdict"`
+}
+
+// PlanModeGroupStats summarizes one group of sessions in a project.
+type PlanModeGroupStats struct {
+	Sessions int `json:"sessions"`
+	// CommitRate is the share of sessions with at least one commit.
+	CommitRate float64 `json:"commit_rate"`
+	// Faceted is the number of sessions with a facet, which AchievedRate and
+	// AvgFriction are computed over.
+	Faceted int `json:"faceted"`
+	// AchievedRate is the share of faceted sessions whose goal was achieved
+	// or mostly achieved.
+	AchievedRate float64 `json:"achieved_rate"`
+	// AvgFriction is friction events per faceted session.
+	AvgFriction float64 `json:"avg_friction"`
+}
+
+// IsPlanAgent reports whether agentType is a planning agent.
+func IsPlanAgent(agentType string) bool {
+	return strings.Contains(strings.ToLower(agentType), "plan")
+}
+
+// AnalyzePlanModeEffect splits each project's sessions by whether they ran
+// any plan agents and compares the groups' commit rates, goal-achieved
+// rates, and friction. Projects without PlanModeMinSessions sessions in
+// both groups are counted but not compared.
+func AnalyzePlanModeEffect(sessions []claude.SessionMeta, tasks []claude.AgentTask, facets []claude.SessionFacet) PlanModeEffect {
+	result := PlanModeEffect{Projects: []ProjectPlanModeEffect{}}
+
+	planned := make(map[string]bool)
+	for _, t := range tasks {
+		if IsPlanAgent(t.AgentType) {
+			planned[t.SessionID] = true
+		}
+	}
+
+	facetByID := make(map[string]*claude.SessionFacet, len(facets))
+	for i := range facets {
+		facetByID[facets[i].SessionID] = &facets[i]
+	}
+
+	type groups struct{ with, without []claude.SessionMeta }
+	byProject := make(map[string]*groups)
+	projectOf := make(map[string]string, len(sessions))
+	for _, s := range sessions {
+		path := claude.NormalizePath(s.ProjectPath)
+		if path == "" {
+			continue
+		}
+		projectOf[s.SessionID] = path
+		g := byProject[path]
+		if g == nil {
+			g = &groups{}
+			byProject[path] = g
+		}
+		if planned[s.SessionID] {
+			g.with = append(g.with, s)
+		} else {
+			g.without = append(g.without, s)
+		}
+	}
+
+	planTotal := make(map[string]int)
+	planKilled := make(map[string]int)
+	for _, t := range tasks {
+		if !IsPlanAgent(t.AgentType) {
+			continue
+		}
+		path := projectOf[t.SessionID]
+		planTotal[path]++
+		if t.Status == "killed" {
+			planKilled[path]++
+		}
+	}
+
+	for path, g := range byProject {
+		if len(g.with) == 0 {
+			continue
+		}
+		if len(g.with) < PlanModeMinSessions || len(g.without) < PlanModeMinSessions {
+			result.Insufficient++
+			continue
+		}
+
+		p := ProjectPlanModeEffect{
+			ProjectPath: path,
+			ProjectName: projectNameFromPath(path),
+			WithPlan:    planModeGroupStats(g.with, facetByID),
+			WithoutPlan: planModeGroupStats(g.without, facetByID),
+		}
+		if planTotal[path] > 0 {
+			p.PlanKillRate = float64(planKilled[path]) / float64(planTotal[path])
+		}
+		p.CommitRateDelta = p.WithPlan.CommitRate - p.WithoutPlan.CommitRate
+		if p.WithPlan.Faceted > 0 && p.WithoutPlan.Faceted > 0 {
+			p.AchievedRateDelta = p.WithPlan.AchievedRate - p.WithoutPlan.AchievedRate
+			p.FrictionDelta = p.WithPlan.AvgFriction - p.WithoutPlan.AvgFriction
+		}
+		p.Verdict = planModeVerdict(p)
+		switch p.Verdict {
+		case "helps":
+			result.Helps++
+		case "hurts":
+			result.Hurts++
+		}
+		result.Projects = append(result.Projects, p)
+	}
+
+	sort.Slice(result.Projects, func(i, j int) bool {
+		if result.Projects[i].CommitRateDelta != result.Projects[j].CommitRateDelta {
+			return result.Projects[i].CommitRateDelta < result.Projects[j].CommitRateDelta
+		}
+		return result.Projects[i].ProjectPath < result.Projects[j].ProjectPath
+	})
+
+	return result
+}
+
+// ForProject returns the comparison for the project at path, or nil when
+// the project wasn't compared.
+func (e PlanModeEffect) ForProject(path string) *ProjectPlanModeEffect {
+	path = claude.NormalizePath(path)
+	for i := range e.Projects {
+		if e.Projects[i].ProjectPath == path {
+			return &e.Projects[i]
+		}
+	}
+	return nil
+}
+
+// planModeVerdict weighs the gaps of p against their thresholds. Higher
+// commit and achieved rates are better; more friction is worse.
+func planModeVerdict(p ProjectPlanModeEffect) string {
+	var worse, better bool
+	for _, d := range []float64{p.CommitRateDelta, p.AchievedRateDelta} {
+		worse = worse || d <= -PlanModeRateThreshold
+		better = better || d >= PlanModeRateThreshold
+	}
+	worse = worse || p.FrictionDelta >= PlanModeFrictionThreshold
+	better = better || p.FrictionDelta <= -PlanModeFrictionThreshold
+
+	switch {
+	case worse && better:
+		return "mixed"
+	case worse:
+		return "hurts"
+	case better:
+		return "helps"
+	default:
+		return "neutral"
+	}
+}
+
+func planModeGroupStats(sessions []claude.SessionMeta, facetByID map[string]*claude.SessionFacet) PlanModeGroupStats {
+	stats := PlanModeGroupStats{Sessions: len(sessions)}
+	if len(sessions) == 0 {
+		return stats
+	}
+	var withCommits, achieved, friction int
+	for _, s := range sessions {
+		if s.GitCommits > 0 {
+			withCommits++
+		}
+		f := facetByID[s.SessionID]
+		if f == nil {
+			continue
+		}
+		stats.Faceted++
+		if f.Outcome == "achieved" || f.Outcome == "mostly_achieved" {
+			achieved++
+		}
+		for _, c := range f.FrictionCounts {
+			friction += c
+		}
+	}
+	stats.CommitRate = float64(withCommits) / float64(len(sessions))
+	if stats.Faceted > 0 {
+		stats.AchievedRate = float64(achieved) / float64(stats.Faceted)
+		stats.AvgFriction = float64(friction) / float64(stats.Faceted)
+	}
+	return stats
+}
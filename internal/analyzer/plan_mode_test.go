@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzePlanModeEffect_Empty(t *testing.T) {
+	result := AnalyzePlanModeEffect(nil, nil, nil)
+	if len(result.Projects) != 0 || result.Helps != 0 || result.Hurts != 0 || result.Insufficient != 0 {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
+
+func TestAnalyzePlanModeEffect_Verdicts(t *testing.T) {
+	var sessions []claude.SessionMeta
+	var tasks []claude.AgentTask
+	for _, g := range []struct {
+		project      string
+		plan         bool
+		n, committed int
+	}{
+		// Planned sessions commit less: 1/5 vs 4/5.
+		{"worse", true, 5, 1},
+		{"worse", false, 5, 4},
+		// Plans are all killed, but the sessions still commit more: 5/5 vs 2/5.
+		{"better", true, 5, 5},
+		{"better", false, 5, 2},
+		// Too few planned sessions.
+		{"sparse", true, 3, 0},
+		{"sparse", false, 10, 10},
+		// Never planned: neither compared nor counted.
+		{"unplanned", false, 10, 5},
+	} {
+		group := makeGroup(fmt.Sprintf("%s-%v", g.project, g.plan), "/code/"+g.project, g.n, g.committed)
+		sessions = append(sessions, group...)
+		if g.plan {
+			tasks = append(tasks, groupTasks(group, "Plan", "killed")...)
+		}
+		// Other agents don't count as planning.
+		tasks = append(tasks, groupTasks(group, "Explore", "completed")...)
+	}
+
+	result := AnalyzePlanModeEffect(sessions, tasks, nil)
+
+	if len(result.Projects) != 2 || result.Hurts != 1 || result.Helps != 1 || result.Insufficient != 1 {
+		t.Fatalf("got %d projects, %d hurts, %d helps, %d insufficient; want 2, 1, 1, 1",
+			len(result.Projects), result.Hurts, result.Helps, result.Insufficient)
+	}
+
+	// Worst commit-rate delta first.
+	worse := result.Projects[0]
+	if worse.ProjectName != "worse" || worse.Verdict != "hurts" {
+		t.Errorf("Projects[0] = %s (%s), want worse (hurts)", worse.ProjectName, worse.Verdict)
+	}
+	if math.Abs(worse.CommitRateDelta-(-0.6)) > 1e-9 {
+		t.Errorf("CommitRateDelta = %v, want -0.6", worse.CommitRateDelta)
+	}
+	if worse.PlanKillRate != 1 {
+		t.Errorf("PlanKillRate = %v, want 1", worse.PlanKillRate)
+	}
+
+	better := result.Projects[1]
+	if better.ProjectName != "better" || better.Verdict != "helps" || better.PlanKillRate != 1 {
+		t.Errorf("Projects[1] = %+v, want better (helps) despite every plan killed", better)
+	}
+}
+
+func TestAnalyzePlanModeEffect_FacetSignals(t *testing.T) {
+	with := makeGroup("with", "/code/api", 5, 5)
+	without := makeGroup("without", "/code/api", 5, 5)
+	sessions := append(with, without...)
+	tasks := groupTasks(with, "Plan", "completed")
+
+	// Commit rates match, but planned sessions achieve less and hit more
+	// friction.
+	facets := append(groupFacets(with, 1, 2), groupFacets(without, 5, 0)...)
+
+	result := AnalyzePlanModeEffect(sessions, tasks, facets)
+
+	if len(result.Projects) != 1 {
+		t.Fatalf("got %d projects, want 1", len(result.Projects))
+	}
+	p := result.Projects[0]
+	if p.CommitRateDelta != 0 {
+		t.Errorf("CommitRateDelta = %v, want 0", p.CommitRateDelta)
+	}
+	if math.Abs(p.AchievedRateDelta-(-0.8)) > 1e-9 || p.FrictionDelta != 2 {
+		t.Errorf("AchievedRateDelta = %v FrictionDelta = %v, want -0.8 and 2", p.AchievedRateDelta, p.FrictionDelta)
+	}
+	if p.Verdict != "hurts" {
+		t.Errorf("Verdict = %q, want hurts", p.Verdict)
+	}
+}
+
+func TestPlanModeVerdict(t *testing.T) {
+	cases := []struct {
+		name string
+		p    ProjectPlanModeEffect
+		want string
+	}{
+		{"small gaps", ProjectPlanModeEffect{CommitRateDelta: -0.1, FrictionDelta: 0.4}, "neutral"},
+		{"fewer commits", ProjectPlanModeEffect{CommitRateDelta: -0.2}, "hurts"},
+		{"less friction", ProjectPlanModeEffect{FrictionDelta: -1}, "helps"},
+		{"fewer commits, more achieved", ProjectPlanModeEffect{CommitRateDelta: -0.2, AchievedRateDelta: 0.2}, "mixed"},
+	}
+	for _, c := range cases {
+		if got := planModeVerdict(c.p); got != c.want {
+			t.Errorf("%s: planModeVerdict = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestPlanModeEffect_ForProject(t *testing.T) {
+	e := PlanModeEffect{Projects: []ProjectPlanModeEffect{{ProjectPath: "/code/api"}}}
+	if e.ForProject("/code/api/") == nil {
+		t.Error("ForProject with trailing slash = nil, want the api project")
+	}
+	if e.ForProject("/code/web") != nil {
+		t.Error("ForProject(/code/web) != nil")
+	}
+}
@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"maps"
+	"strings"
+)
+
+// builtinPricing is the compiled-in pricing that overrides are applied on top
+// of, kept so SetPricingOverrides can be called more than once.
+var builtinPricing = maps.Clone(DefaultPricing)
+
+// SetPricingOverrides resets DefaultPricing to the compiled-in rates and then
+// applies each layer of overrides in order. A non-zero rate in an override
+// replaces the current one; zero rates are left alone, so an override can
+// change just the rates that moved. Tier names are matched case-insensitively
+// and tiers without built-in pricing are added.
+//
+// DefaultPricing is read without locking, so call this once at startup,
+// before any analysis runs.
+func SetPricingOverrides(layers ...map[string]ModelPricing) {
+	pricing := maps.Clone(builtinPricing)
+	for _, layer := range layers {
+		for tier, over := range layer {
+			tier = strings.ToLower(tier)
+			pricing[tier] = mergePricing(pricing[tier], over)
+		}
+	}
+	clear(DefaultPricing)
+	maps.Copy(DefaultPricing, pricing)
+}
+
+// mergePricing returns base with every non-zero rate of over applied.
+func mergePricing(base, over ModelPricing) ModelPricing {
+	if over.InputPerMillion != 0 {
+		base.InputPerMillion = over.InputPerMillion
+	}
+	if over.OutputPerMillion != 0 {
+		base.OutputPerMillion = over.OutputPerMillion
+	}
+	if over.CacheReadPerMillion != 0 {
+		base.CacheReadPerMillion = over.CacheReadPerMillion
+	}
+	if over.CacheWritePerMillion != 0 {
+		base.CacheWritePerMillion = over.CacheWritePerMillion
+	}
+	return base
+}
@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestSetPricingOverrides(t *testing.T) {
+	t.Cleanup(func() { SetPricingOverrides() })
+	builtin := DefaultPricing["sonnet"]
+
+	SetPricingOverrides(
+		map[string]ModelPricing{
+			"sonnet": {InputPerMillion: 4, OutputPerMillion: 20},
+			"Custom": {InputPerMillion: 30},
+		},
+		map[string]ModelPricing{"sonnet": {InputPerMillion: 5}},
+	)
+
+	got := DefaultPricing["sonnet"]
+	want := ModelPricing{
+		InputPerMillion:      5,
+		OutputPerMillion:     20,
+		CacheReadPerMillion:  builtin.CacheReadPerMillion,
+		CacheWritePerMillion: builtin.CacheWritePerMillion,
+	}
+	if got != want {
+		t.Errorf("sonnet = %+v, want %+v", got, want)
+	}
+	if DefaultPricing["custom"].InputPerMillion != 30 {
+		t.Errorf("expected new tier to be added lowercased, got %+v", DefaultPricing)
+	}
+
+	// A later call starts over from the built-in rates.
+	SetPricingOverrides()
+	if DefaultPricing["sonnet"] != builtin {
+		t.Errorf("sonnet = %+v after reset, want %+v", DefaultPricing["sonnet"], builtin)
+	}
+	if _, ok := DefaultPricing["custom"]; ok {
+		t.Error("expected reset to drop added tiers")
+	}
+
+	// EstimateSessionCost prices per-model usage from the overridden rates.
+	SetPricingOverrides(map[string]ModelPricing{"opus": {OutputPerMillion: 100}})
+	s := claude.SessionMeta{ModelUsage: map[string]claude.ModelStats{
+		"claude-opus-4": {OutputTokens: 1_000_000},
+	}}
+	if got := EstimateSessionCost(s, ModelPricing{}, NoCacheRatio()); got != 100 {
+		t.Errorf("EstimateSessionCost = %g, want 100", got)
+	}
+}
@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// DefaultResumeGap is the longest gap between the end of one session and the
+// start of the next on the same project for the latter to count as a resume.
+const DefaultResumeGap = 15 * time.Minute
+
+// ResumeAnalysis estimates how many logical sessions the raw session files
+// represent. Interrupting a session and resuming it shortly after produces two
+// session files for one piece of work, which skews per-session averages.
+type ResumeAnalysis struct {
+	// GapMinutes is the resume threshold the analysis used.
+	GapMinutes int `json:"gap_minutes"`
+	// RawSessions is the number of session files analyzed.
+	RawSessions int `json:"raw_sessions"`
+	// LogicalSessions is RawSessions with resumes merged into the session
+	// they continue.
+	LogicalSessions int `json:"logical_sessions"`
+	// Resumes counts sessions that continued an earlier one.
+	Resumes int `json:"resumes"`
+	// ResumeRate is Resumes / RawSessions.
+	ResumeRate float64 `json:"resume_rate"`
+}
+
+// AnalyzeResumePatterns detects likely resumes: a session on the same project
+// that starts within gap of the end of the previous one (start plus
+// DurationMinutes). Chains of resumes count as one logical session. Sessions
+// without a project or a parseable start time are never merged. A gap of zero
+// or less uses DefaultResumeGap.
+func AnalyzeResumePatterns(sessions []claude.SessionMeta, gap time.Duration) ResumeAnalysis {
+	if gap <= 0 {
+		gap = DefaultResumeGap
+	}
+	result := ResumeAnalysis{
+		GapMinutes:  int(gap / time.Minute),
+		RawSessions: len(sessions),
+	}
+
+	type span struct {
+		start, end time.Time
+	}
+	byProject := make(map[string][]span)
+	for _, s := range sessions {
+		start := claude.ParseTimestamp(s.StartTime)
+		if s.ProjectPath == "" || start.IsZero() {
+			continue
+		}
+		end := start.Add(time.Duration(s.DurationMinutes) * time.Minute)
+		byProject[s.ProjectPath] = append(byProject[s.ProjectPath], span{start, end})
+	}
+
+	for _, spans := range byProject {
+		sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+		// Track the latest end of the current chain so a short session
+		// inside a long one doesn't break it.
+		chainEnd := spans[0].end
+		for _, sp := range spans[1:] {
+			if sp.start.Sub(chainEnd) <= gap {
+				result.Resumes++
+			}
+			if sp.end.After(chainEnd) {
+				chainEnd = sp.end
+			}
+		}
+	}
+
+	result.LogicalSessions = result.RawSessions - result.Resumes
+	if result.RawSessions > 0 {
+		result.ResumeRate = float64(result.Resumes) / float64(result.RawSessions)
+	}
+	return result
+}
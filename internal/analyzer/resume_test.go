@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeResumePatterns_Empty(t *testing.T) {
+	result := AnalyzeResumePatterns(nil, 0)
+	if result.RawSessions != 0 || result.LogicalSessions != 0 || result.Resumes != 0 {
+		t.Errorf("expected zero result, got %+v", result)
+	}
+	if result.GapMinutes != 15 {
+		t.Errorf("GapMinutes = %d, want default 15", result.GapMinutes)
+	}
+}
+
+func TestAnalyzeResumePatterns_MergesChainsPerProject(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		// proj: 09:00-09:30, resumed 09:40-10:05, resumed again at 10:15,
+		// then a new task at 13:00.
+		{SessionID: "a1", ProjectPath: "/code/proj", StartTime: "2026-01-10T09:00:00Z", DurationMinutes: 30},
+		{SessionID: "a3", ProjectPath: "/code/proj", StartTime: "2026-01-10T10:15:00Z", DurationMinutes: 5},
+		{SessionID: "a2", ProjectPath: "/code/proj", StartTime: "2026-01-10T09:40:00Z", DurationMinutes: 25},
+		{SessionID: "a4", ProjectPath: "/code/proj", StartTime: "2026-01-10T13:00:00Z", DurationMinutes: 10},
+		// other: starts 5 minutes after proj's session ended, but on a
+		// different project, so it is not a resume.
+		{SessionID: "b1", ProjectPath: "/code/other", StartTime: "2026-01-10T09:35:00Z", DurationMinutes: 10},
+		// No project or no start time: never merged.
+		{SessionID: "c1", StartTime: "2026-01-10T09:31:00Z"},
+		{SessionID: "c2", ProjectPath: "/code/proj"},
+	}
+
+	result := AnalyzeResumePatterns(sessions, 15*time.Minute)
+	if result.RawSessions != 7 {
+		t.Errorf("RawSessions = %d, want 7", result.RawSessions)
+	}
+	if result.Resumes != 2 {
+		t.Errorf("Resumes = %d, want 2", result.Resumes)
+	}
+	if result.LogicalSessions != 5 {
+		t.Errorf("LogicalSessions = %d, want 5", result.LogicalSessions)
+	}
+	if want := 2.0 / 7.0; result.ResumeRate != want {
+		t.Errorf("ResumeRate = %v, want %v", result.ResumeRate, want)
+	}
+}
+
+func TestAnalyzeResumePatterns_GapIsConfigurable(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "a1", ProjectPath: "/code/proj", StartTime: "2026-01-10T09:00:00Z", DurationMinutes: 30},
+		{SessionID: "a2", ProjectPath: "/code/proj", StartTime: "2026-01-10T09:50:00Z", DurationMinutes: 10},
+	}
+
+	if got := AnalyzeResumePatterns(sessions, 15*time.Minute).Resumes; got != 0 {
+		t.Errorf("15m gap: Resumes = %d, want 0", got)
+	}
+	result := AnalyzeResumePatterns(sessions, 30*time.Minute)
+	if result.Resumes != 1 || result.LogicalSessions != 1 || result.GapMinutes != 30 {
+		t.Errorf("30m gap: got %+v, want 1 resume, 1 logical session, GapMinutes 30", result)
+	}
+}
+
+func TestAnalyzeResumePatterns_OverlappingSessionKeepsChain(t *testing.T) {
+	// A short session inside a long one must not shorten the chain: the
+	// third session starts 10 minutes after the long one ends.
+	sessions := []claude.SessionMeta{
+		{SessionID: "a1", ProjectPath: "/code/proj", StartTime: "2026-01-10T09:00:00Z", DurationMinutes: 120},
+		{SessionID: "a2", ProjectPath: "/code/proj", StartTime: "2026-01-10T09:10:00Z", DurationMinutes: 5},
+		{SessionID: "a3", ProjectPath: "/code/proj", StartTime: "2026-01-10T11:10:00Z", DurationMinutes: 5},
+	}
+
+	result := AnalyzeResumePatterns(sessions, 15*time.Minute)
+	if result.Resumes != 2 || result.LogicalSessions != 1 {
+		t.Errorf("got %+v, want 2 resumes and 1 logical session", result)
+	}
+}
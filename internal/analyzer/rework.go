@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// ReworkWindow is the longest gap between the end of a not_achieved session
+// and the start of the next session on the same project for the latter to
+// count as rework.
+const ReworkWindow = 24 * time.Hour
+
+// ReworkAnalysis measures sessions spent cleaning up after a failed one.
+// A rework pair is a session whose facet outcome is not_achieved, followed
+// by the next session on the same project starting within ReworkWindow.
+type ReworkAnalysis struct {
+	// TotalSessions is the number of sessions with a parseable start time.
+	TotalSessions int `json:"total_sessions"`
+	// NotAchieved counts sessions whose facet outcome is not_achieved.
+	NotAchieved int `json:"not_achieved"`
+	// ReworkPairs counts not_achieved sessions followed by rework.
+	ReworkPairs int `json:"rework_pairs"`
+	// ReworkRate is ReworkPairs / TotalSessions: the share of all sessions
+	// that were rework.
+	ReworkRate float64 `json:"rework_rate"`
+	// ReworkCost is the estimated cost of the rework sessions.
+	ReworkCost float64 `json:"rework_cost"`
+	// ByProject lists projects with at least one rework pair, costliest first.
+	ByProject []ProjectRework `json:"by_project,omitempty"`
+}
+
+// ProjectRework is the rework in a single project.
+type ProjectRework struct {
+	ProjectPath string  `json:"project_path"`
+	ProjectName string  `json:"project_name"`
+	ReworkPairs int     `json:"rework_pairs"`
+	ReworkCost  float64 `json:"rework_cost"`
+}
+
+// AnalyzeRework finds rework pairs per project. Only not_achieved sessions
+// with facet data can start a pair, and a project needs at least two
+// consecutive sessions to have one. The rework session's cost is estimated
+// with the same pricing as AnalyzeOutcomes.
+func AnalyzeRework(sessions []claude.SessionMeta, facets []claude.SessionFacet, pricing ModelPricing, ratio CacheRatio) ReworkAnalysis {
+	var result ReworkAnalysis
+
+	outcomeBySession := make(map[string]string, len(facets))
+	for _, f := range facets {
+		outcomeBySession[f.SessionID] = f.Outcome
+	}
+
+	type timedSession struct {
+		meta  claude.SessionMeta
+		start time.Time
+	}
+	byProject := make(map[string][]timedSession)
+	for _, s := range sessions {
+		start := claude.ParseTimestamp(s.StartTime)
+		if start.IsZero() {
+			continue
+		}
+		result.TotalSessions++
+		if outcomeBySession[s.SessionID] == "not_achieved" {
+			result.NotAchieved++
+		}
+		if s.ProjectPath != "" {
+			byProject[s.ProjectPath] = append(byProject[s.ProjectPath], timedSession{s, start})
+		}
+	}
+
+	for path, list := range byProject {
+		sort.Slice(list, func(i, j int) bool { return list[i].start.Before(list[j].start) })
+
+		pr := ProjectRework{ProjectPath: path, ProjectName: projectNameFromPath(path)}
+		for i := 0; i+1 < len(list); i++ {
+			failed, next := list[i], list[i+1]
+			if outcomeBySession[failed.meta.SessionID] != "not_achieved" {
+				continue
+			}
+			end := failed.start.Add(time.Duration(failed.meta.DurationMinutes) * time.Minute)
+			if next.start.Sub(end) > ReworkWindow {
+				continue
+			}
+			pr.ReworkPairs++
+			pr.ReworkCost += EstimateSessionCost(next.meta, pricing, ratio)
+		}
+
+		if pr.ReworkPairs > 0 {
+			result.ReworkPairs += pr.ReworkPairs
+			result.ReworkCost += pr.ReworkCost
+			result.ByProject = append(result.ByProject, pr)
+		}
+	}
+
+	if result.TotalSessions > 0 {
+		result.ReworkRate = float64(result.ReworkPairs) / float64(result.TotalSessions)
+	}
+
+	sort.Slice(result.ByProject, func(i, j int) bool {
+		if result.ByProject[i].ReworkCost != result.ByProject[j].ReworkCost {
+			return result.ByProject[i].ReworkCost > result.ByProject[j].ReworkCost
+		}
+		return result.ByProject[i].ProjectPath < result.ByProject[j].ProjectPath
+	})
+
+	return result
+}
@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeRework_Empty(t *testing.T) {
+	result := AnalyzeRework(nil, nil, testPricing, NoCacheRatio())
+	if result.TotalSessions != 0 || result.ReworkPairs != 0 || result.ReworkRate != 0 {
+		t.Errorf("expected zero result, got %+v", result)
+	}
+}
+
+func TestAnalyzeRework_CountsPairsWithinWindow(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		// proj: failed, then rework 2h later ($3.00), then an unrelated session.
+		{SessionID: "a1", ProjectPath: "/code/proj", StartTime: "2026-01-10T09:00:00Z", DurationMinutes: 60},
+		{SessionID: "a2", ProjectPath: "/code/proj", StartTime: "2026-01-10T12:00:00Z", InputTokens: 1_000_000},
+		{SessionID: "a3", ProjectPath: "/code/proj", StartTime: "2026-01-12T12:00:00Z"},
+		// other: failed, next session three days later is too late to count.
+		{SessionID: "b1", ProjectPath: "/code/other", StartTime: "2026-01-10T09:00:00Z"},
+		{SessionID: "b2", ProjectPath: "/code/other", StartTime: "2026-01-13T09:00:00Z", InputTokens: 1_000_000},
+	}
+	facets := []claude.SessionFacet{
+		{SessionID: "a1", Outcome: "not_achieved"},
+		{SessionID: "a2", Outcome: "achieved"},
+		{SessionID: "b1", Outcome: "not_achieved"},
+	}
+
+	result := AnalyzeRework(sessions, facets, testPricing, NoCacheRatio())
+
+	if result.TotalSessions != 5 || result.NotAchieved != 2 {
+		t.Errorf("TotalSessions=%d NotAchieved=%d, want 5 and 2", result.TotalSessions, result.NotAchieved)
+	}
+	if result.ReworkPairs != 1 {
+		t.Fatalf("ReworkPairs = %d, want 1", result.ReworkPairs)
+	}
+	if math.Abs(result.ReworkRate-0.2) > 1e-9 {
+		t.Errorf("ReworkRate = %.3f, want 0.2", result.ReworkRate)
+	}
+	if math.Abs(result.ReworkCost-3.0) > 1e-9 {
+		t.Errorf("ReworkCost = %.2f, want 3.00", result.ReworkCost)
+	}
+	if len(result.ByProject) != 1 || result.ByProject[0].ProjectName != "proj" {
+		t.Errorf("ByProject = %+v, want only proj", result.ByProject)
+	}
+}
+
+func TestAnalyzeRework_RequiresFacetAndFollowUp(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		// No facet for the first session: can't tell it failed.
+		{SessionID: "c1", ProjectPath: "/code/c", StartTime: "2026-01-10T09:00:00Z"},
+		{SessionID: "c2", ProjectPath: "/code/c", StartTime: "2026-01-10T10:00:00Z"},
+		// Failed, but the only session on its project.
+		{SessionID: "d1", ProjectPath: "/code/d", StartTime: "2026-01-10T09:00:00Z"},
+		// Failed on a different project than the session that follows it.
+		{SessionID: "e1", ProjectPath: "/code/e", StartTime: "2026-01-10T11:00:00Z"},
+	}
+	facets := []claude.SessionFacet{
+		{SessionID: "d1", Outcome: "not_achieved"},
+		{SessionID: "e1", Outcome: "not_achieved"},
+	}
+
+	result := AnalyzeRework(sessions, facets, testPricing, NoCacheRatio())
+	if result.ReworkPairs != 0 {
+		t.Errorf("ReworkPairs = %d, want 0", result.ReworkPairs)
+	}
+	if result.NotAchieved != 2 {
+		t.Errorf("NotAchieved = %d, want 2", result.NotAchieved)
+	}
+}
+
+func TestAnalyzeRework_WindowMeasuredFromSessionEnd(t *testing.T) {
+	// A 10-hour session ending at 19:00 followed 20h later is within the
+	// window, even though the starts are 30h apart.
+	sessions := []claude.SessionMeta{
+		{SessionID: "f1", ProjectPath: "/code/f", StartTime: "2026-01-10T09:00:00Z", DurationMinutes: 600},
+		{SessionID: "f2", ProjectPath: "/code/f", StartTime: "2026-01-11T15:00:00Z"},
+	}
+	facets := []claude.SessionFacet{{SessionID: "f1", Outcome: "not_achieved"}}
+
+	if got := AnalyzeRework(sessions, facets, testPricing, NoCacheRatio()).ReworkPairs; got != 1 {
+		t.Errorf("ReworkPairs = %d, want 1", got)
+	}
+}
+
+func TestAnalyzeOutcomes_IncludesRework(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "g1", ProjectPath: "/code/g", StartTime: "2026-01-10T09:00:00Z"},
+		{SessionID: "g2", ProjectPath: "/code/g", StartTime: "2026-01-10T10:00:00Z"},
+	}
+	facets := []claude.SessionFacet{{SessionID: "g1", Outcome: "not_achieved"}}
+
+	result := AnalyzeOutcomes(sessions, facets, testPricing, NoCacheRatio())
+	if result.Rework.ReworkPairs != 1 {
+		t.Errorf("Rework.ReworkPairs = %d, want 1", result.Rework.ReworkPairs)
+	}
+}
@@ -53,6 +53,7 @@ func AnalyzeSatisfaction(facets []claude.SessionFacet) SatisfactionScore {
 		}
 	}
 
+	score.SampleSize = totalEntries
 	if totalEntries > 0 {
 		score.WeightedScore = (totalWeight / float64(totalEntries)) * 100.0
 	}
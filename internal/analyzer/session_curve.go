@@ -0,0 +1,162 @@
+package analyzer
+
+import (
+	"math"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// SessionCurveBucketMinutes is the width of the intervals the session
+// productivity curve measures commits over.
+const SessionCurveBucketMinutes = 10
+
+// sessionCurveMinSessions is how many sessions a curve point, or the cliff,
+// needs; fewer make the rate noise.
+const sessionCurveMinSessions = 5
+
+// sessionCurveBands are the session-length bands, in minutes, compared when
+// only session totals are available. The last band is open-ended.
+var sessionCurveBands = []int{0, 30, 60, 120}
+
+// SessionCurvePoint is the commit rate over one stretch of session time.
+type SessionCurvePoint struct {
+	StartMinute int `json:"start_minute"`
+	// EndMinute is 0 for the open-ended last band of an estimated curve.
+	EndMinute int `json:"end_minute"`
+	// Sessions counts the sessions the rate is averaged over.
+	Sessions int `json:"sessions"`
+	// CommitRate is commits per SessionCurveBucketMinutes minutes per
+	// session.
+	CommitRate float64 `json:"commit_rate"`
+}
+
+// SessionCurve describes how productive sessions are as they go on.
+type SessionCurve struct {
+	// Sessions counts the sessions the curve is built from.
+	Sessions int                 `json:"sessions"`
+	Points   []SessionCurvePoint `json:"points"`
+	// CliffMinute is the minute after which sessions typically stop
+	// committing, or 0 when no cliff stands out.
+	CliffMinute int `json:"cliff_minute"`
+	// PlateauSessions counts sessions that kept going at least
+	// SessionCurveBucketMinutes minutes after their last commit.
+	PlateauSessions int `json:"plateau_sessions"`
+	// Estimated is set when no transcript timings were available and the
+	// curve compares sessions of different lengths by their totals instead.
+	Estimated bool `json:"estimated"`
+}
+
+// AnalyzeSessionCurve estimates how the average session's productivity,
+// in commits per SessionCurveBucketMinutes minutes, changes with elapsed
+// time, and finds the productivity cliff: the median minute of the last
+// commit among sessions that kept going at least one more interval after
+// it. Only sessions with transcript activity are used.
+//
+// When none of sessions has transcript activity it falls back to session
+// totals: the curve compares the commit rate of short and long sessions,
+// and the cliff is the start of the first length band after the most
+// productive one whose rate falls below half of it.
+func AnalyzeSessionCurve(sessions []claude.SessionMeta, activity map[string]claude.SessionActivity) SessionCurve {
+	var timed []claude.SessionActivity
+	for _, s := range sessions {
+		if a, ok := activity[s.SessionID]; ok {
+			timed = append(timed, a)
+		}
+	}
+	if len(timed) == 0 {
+		return sessionCurveFromTotals(sessions)
+	}
+
+	curve := SessionCurve{Sessions: len(timed), Points: []SessionCurvePoint{}}
+	var cliffs []float64
+	for i := 0; ; i++ {
+		start := float64(i * SessionCurveBucketMinutes)
+		end := start + SessionCurveBucketMinutes
+		running, commits := 0, 0
+		for _, a := range timed {
+			if a.Minutes <= start {
+				continue
+			}
+			running++
+			for _, m := range a.CommitMinutes {
+				if m >= start && m < end {
+					commits++
+				}
+			}
+		}
+		if running < sessionCurveMinSessions {
+			break
+		}
+		curve.Points = append(curve.Points, SessionCurvePoint{
+			StartMinute: int(start),
+			EndMinute:   int(end),
+			Sessions:    running,
+			CommitRate:  float64(commits) / float64(running),
+		})
+	}
+	for _, a := range timed {
+		if len(a.CommitMinutes) == 0 {
+			continue
+		}
+		last := a.CommitMinutes[0]
+		for _, m := range a.CommitMinutes {
+			last = max(last, m)
+		}
+		if a.Minutes-last >= SessionCurveBucketMinutes {
+			cliffs = append(cliffs, last)
+		}
+	}
+	curve.PlateauSessions = len(cliffs)
+	if len(cliffs) >= sessionCurveMinSessions {
+		curve.CliffMinute = int(math.Round(medianFloat64(cliffs)))
+	}
+	return curve
+}
+
+// sessionCurveFromTotals builds an estimated curve from each session's
+// duration and commit count.
+func sessionCurveFromTotals(sessions []claude.SessionMeta) SessionCurve {
+	curve := SessionCurve{Points: []SessionCurvePoint{}, Estimated: true}
+	for i, start := range sessionCurveBands {
+		end := 0
+		if i+1 < len(sessionCurveBands) {
+			end = sessionCurveBands[i+1]
+		}
+		var n, commits, minutes int
+		for _, s := range sessions {
+			if s.DurationMinutes <= 0 || s.DurationMinutes < start || (end > 0 && s.DurationMinutes >= end) {
+				continue
+			}
+			n++
+			commits += s.GitCommits
+			minutes += s.DurationMinutes
+		}
+		if n < sessionCurveMinSessions {
+			continue
+		}
+		curve.Sessions += n
+		curve.Points = append(curve.Points, SessionCurvePoint{
+			StartMinute: start,
+			EndMinute:   end,
+			Sessions:    n,
+			CommitRate:  float64(commits) / float64(minutes) * SessionCurveBucketMinutes,
+		})
+	}
+
+	peak := -1
+	for i, p := range curve.Points {
+		if peak < 0 || p.CommitRate > curve.Points[peak].CommitRate {
+			peak = i
+		}
+	}
+	if peak < 0 || curve.Points[peak].CommitRate == 0 {
+		return curve
+	}
+	for _, p := range curve.Points[peak+1:] {
+		if p.CommitRate < curve.Points[peak].CommitRate/2 {
+			curve.CliffMinute = p.StartMinute
+			break
+		}
+	}
+	return curve
+}
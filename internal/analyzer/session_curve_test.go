@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeSessionCurve_FromTranscripts(t *testing.T) {
+	var sessions []claude.SessionMeta
+	activity := make(map[string]claude.SessionActivity)
+	// Six 60-minute sessions committing at minutes 5 and 15, then idling.
+	for i := range 6 {
+		id := fmt.Sprintf("long-%d", i)
+		sessions = append(sessions, claude.SessionMeta{SessionID: id})
+		activity[id] = claude.SessionActivity{Minutes: 60, CommitMinutes: []float64{5, 15}}
+	}
+	// A session that commits right up to its end never plateaus.
+	sessions = append(sessions, claude.SessionMeta{SessionID: "steady"})
+	activity["steady"] = claude.SessionActivity{Minutes: 30, CommitMinutes: []float64{10, 25}}
+	// Activity for sessions outside the analyzed set is ignored.
+	activity["other"] = claude.SessionActivity{Minutes: 500, CommitMinutes: []float64{400}}
+
+	curve := AnalyzeSessionCurve(sessions, activity)
+
+	if curve.Estimated || curve.Sessions != 7 {
+		t.Errorf("Estimated = %v, Sessions = %d; want transcript curve over 7 sessions", curve.Estimated, curve.Sessions)
+	}
+	if curve.CliffMinute != 15 || curve.PlateauSessions != 6 {
+		t.Errorf("CliffMinute = %d, PlateauSessions = %d; want 15 and 6", curve.CliffMinute, curve.PlateauSessions)
+	}
+	// 0-10: 6 commits over 7 sessions; 10-20: 7 over 7; 30-60: 6 sessions, none.
+	if len(curve.Points) != 6 {
+		t.Fatalf("Points = %+v, want six 10-minute points", curve.Points)
+	}
+	if p := curve.Points[1]; p.StartMinute != 10 || p.Sessions != 7 || p.CommitRate != 1 {
+		t.Errorf("Points[1] = %+v, want 10-20 at 1 commit/session", p)
+	}
+	if p := curve.Points[3]; p.Sessions != 6 || p.CommitRate != 0 {
+		t.Errorf("Points[3] = %+v, want 6 sessions, no commits", p)
+	}
+}
+
+func TestAnalyzeSessionCurve_TooFewPlateausHasNoCliff(t *testing.T) {
+	activity := map[string]claude.SessionActivity{"a": {Minutes: 60, CommitMinutes: []float64{5}}}
+	curve := AnalyzeSessionCurve([]claude.SessionMeta{{SessionID: "a"}}, activity)
+	if curve.CliffMinute != 0 || len(curve.Points) != 0 {
+		t.Errorf("curve = %+v, want no points or cliff from one session", curve)
+	}
+}
+
+func TestAnalyzeSessionCurve_FallsBackToTotals(t *testing.T) {
+	var sessions []claude.SessionMeta
+	add := func(n, minutes, commits int) {
+		for range n {
+			sessions = append(sessions, claude.SessionMeta{SessionID: fmt.Sprint(len(sessions)), DurationMinutes: minutes, GitCommits: commits})
+		}
+	}
+	add(5, 20, 2)  // 1.0 commits per 10 min
+	add(5, 45, 4)  // 0.89
+	add(5, 90, 3)  // 0.33: the cliff
+	add(2, 200, 1) // too few to count
+
+	curve := AnalyzeSessionCurve(sessions, nil)
+
+	if !curve.Estimated || curve.Sessions != 15 || len(curve.Points) != 3 {
+		t.Fatalf("curve = %+v, want an estimated curve over three bands", curve)
+	}
+	if math.Abs(curve.Points[0].CommitRate-1) > 1e-9 || curve.Points[2].EndMinute != 120 {
+		t.Errorf("Points = %+v", curve.Points)
+	}
+	if curve.CliffMinute != 60 {
+		t.Errorf("CliffMinute = %d, want 60", curve.CliffMinute)
+	}
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// HighStruggleScore is the struggle score at or above which a session counts
+// as a slog: five tool errors for every commit, or five errors and none.
+const HighStruggleScore = 5.0
+
+// struggleTop is how many of the worst-struggling sessions are reported.
+const struggleTop = 5
+
+// SessionStruggle is one session's struggle score.
+type SessionStruggle struct {
+	SessionID   string  `json:"session_id"`
+	ProjectPath string  `json:"project_path"`
+	StartTime   string  `json:"start_time"`
+	ToolErrors  int     `json:"tool_errors"`
+	Commits     int     `json:"commits"`
+	Score       float64 `json:"score"`
+}
+
+// StruggleAnalysis reports sessions that fought their tools for little
+// committed work.
+type StruggleAnalysis struct {
+	// HighStruggleSessions counts sessions scoring at least
+	// HighStruggleScore.
+	HighStruggleSessions int `json:"high_struggle_sessions"`
+	// Worst lists up to five sessions with tool errors, highest score
+	// first.
+	Worst []SessionStruggle `json:"worst"`
+}
+
+// StruggleScore is a session's tool errors per commit, counting one extra
+// commit so sessions without any still score: tool errors / (commits + 1).
+// Sessions without tool errors score zero.
+func StruggleScore(s claude.SessionMeta) float64 {
+	return float64(s.ToolErrors) / float64(s.GitCommits+1)
+}
+
+// AnalyzeStruggle scores every session with StruggleScore, counts the
+// high-struggle ones, and returns the worst. It catches painful sessions
+// whose facets, if any, recorded no friction.
+func AnalyzeStruggle(sessions []claude.SessionMeta) StruggleAnalysis {
+	result := StruggleAnalysis{Worst: []SessionStruggle{}}
+	for _, s := range sessions {
+		score := StruggleScore(s)
+		if score == 0 {
+			continue
+		}
+		if score >= HighStruggleScore {
+			result.HighStruggleSessions++
+		}
+		result.Worst = append(result.Worst, SessionStruggle{
+			SessionID:   s.SessionID,
+			ProjectPath: s.ProjectPath,
+			StartTime:   s.StartTime,
+			ToolErrors:  s.ToolErrors,
+			Commits:     s.GitCommits,
+			Score:       score,
+		})
+	}
+	sort.Slice(result.Worst, func(i, j int) bool {
+		a, b := result.Worst[i], result.Worst[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.SessionID < b.SessionID
+	})
+	if len(result.Worst) > struggleTop {
+		result.Worst = result.Worst[:struggleTop]
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestStruggleScore(t *testing.T) {
+	cases := []struct {
+		errors, commits int
+		want            float64
+	}{
+		{20, 1, 10},
+		{5, 0, 5},
+		{0, 0, 0},
+		{0, 3, 0},
+		{3, 2, 1},
+	}
+	for _, c := range cases {
+		s := claude.SessionMeta{ToolErrors: c.errors, GitCommits: c.commits}
+		if got := StruggleScore(s); got != c.want {
+			t.Errorf("StruggleScore(%d errors, %d commits) = %.2f, want %.2f", c.errors, c.commits, got, c.want)
+		}
+	}
+}
+
+func TestAnalyzeStruggle(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "slog", ToolErrors: 20, GitCommits: 1},
+		{SessionID: "clean", GitCommits: 4},
+		{SessionID: "stuck", ToolErrors: 6},
+		{SessionID: "fine", ToolErrors: 2, GitCommits: 3},
+	}
+	for i := range 5 {
+		sessions = append(sessions, claude.SessionMeta{SessionID: string(rune('a' + i)), ToolErrors: 1, GitCommits: 1})
+	}
+
+	result := AnalyzeStruggle(sessions)
+
+	if result.HighStruggleSessions != 2 {
+		t.Errorf("HighStruggleSessions = %d, want 2", result.HighStruggleSessions)
+	}
+	if len(result.Worst) != struggleTop {
+		t.Fatalf("len(Worst) = %d, want %d", len(result.Worst), struggleTop)
+	}
+	if result.Worst[0].SessionID != "slog" || result.Worst[0].Score != 10 || result.Worst[1].SessionID != "stuck" {
+		t.Errorf("Worst = %+v, want slog (10) then stuck", result.Worst)
+	}
+	for _, w := range result.Worst {
+		if w.SessionID == "clean" {
+			t.Error("sessions without tool errors should not be listed")
+		}
+	}
+}
+
+func TestAnalyzeStruggle_Empty(t *testing.T) {
+	result := AnalyzeStruggle(nil)
+	if result.HighStruggleSessions != 0 || result.Worst == nil || len(result.Worst) != 0 {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
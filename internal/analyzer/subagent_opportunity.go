@@ -0,0 +1,124 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// SubagentMinSessions is how many sessions a project needs before it is
+// judged a subagent candidate; fewer make its averages noise.
+const SubagentMinSessions = 5
+
+// SubagentMinExplorationShare is the share of tool calls that must be reads
+// and searches for a project's sessions to count as exploration-heavy.
+const SubagentMinExplorationShare = 0.5
+
+// SubagentMinAvgMinutes is the average session length, in minutes, above
+// which a project's sessions count as long.
+const SubagentMinAvgMinutes = 30.0
+
+// SubagentMaxAgentRate is the largest share of sessions using agents that
+// still counts as near-zero agent usage.
+const SubagentMaxAgentRate = 0.1
+
+// SubagentCandidate is a project whose long, exploration-heavy sessions
+// rarely delegate to agents.
+type SubagentCandidate struct {
+	ProjectPath        string  `json:"project_path"`
+	ProjectName        string  `json:"project_name"`
+	Sessions           int     `json:"sessions"`
+	AvgDurationMinutes float64 `json:"avg_duration_minutes"`
+	// ExplorationShare is read and search calls (Read, Glob, Grep, LS) as a
+	// fraction of all tool calls.
+	ExplorationShare float64 `json:"exploration_share"`
+	// ExplorationPerSession is read and search calls per session.
+	ExplorationPerSession float64 `json:"exploration_per_session"`
+	// AgentSessionRate is the fraction of sessions that launched an agent.
+	AgentSessionRate float64 `json:"agent_session_rate"`
+}
+
+// SubagentOpportunity lists the projects that would most benefit from
+// handing research to background agents.
+type SubagentOpportunity struct {
+	// ProjectsAnalyzed counts projects with at least SubagentMinSessions
+	// sessions.
+	ProjectsAnalyzed int `json:"projects_analyzed"`
+	// Candidates holds the projects meeting every threshold, most
+	// exploration calls first.
+	Candidates []SubagentCandidate `json:"candidates"`
+}
+
+// AnalyzeSubagentOpportunity finds projects with at least
+// SubagentMinSessions sessions that average more than SubagentMinAvgMinutes
+// minutes, spend at least SubagentMinExplorationShare of their tool calls
+// reading and searching, and launch agents in at most SubagentMaxAgentRate
+// of their sessions. A session used an agent when its meta says so, it
+// called the Task tool, or tasks holds one of its agents.
+func AnalyzeSubagentOpportunity(sessions []claude.SessionMeta, tasks []claude.AgentTask) SubagentOpportunity {
+	result := SubagentOpportunity{Candidates: []SubagentCandidate{}}
+
+	withAgents := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		withAgents[t.SessionID] = true
+	}
+
+	type projectTotals struct {
+		sessions, agentSessions, minutes int
+		calls, exploration               int
+	}
+	byProject := make(map[string]*projectTotals)
+	for _, s := range sessions {
+		path := claude.NormalizePath(s.ProjectPath)
+		p, ok := byProject[path]
+		if !ok {
+			p = &projectTotals{}
+			byProject[path] = p
+		}
+		p.sessions++
+		p.minutes += s.DurationMinutes
+		if s.UsesTaskAgent || s.ToolCounts["Task"] > 0 || withAgents[s.SessionID] {
+			p.agentSessions++
+		}
+		for tool, n := range s.ToolCounts {
+			p.calls += n
+			if ToolCategory(tool) == "read" {
+				p.exploration += n
+			}
+		}
+	}
+
+	for path, p := range byProject {
+		if p.sessions < SubagentMinSessions {
+			continue
+		}
+		result.ProjectsAnalyzed++
+		if p.calls == 0 {
+			continue
+		}
+		c := SubagentCandidate{
+			ProjectPath:           path,
+			ProjectName:           filepath.Base(path),
+			Sessions:              p.sessions,
+			AvgDurationMinutes:    float64(p.minutes) / float64(p.sessions),
+			ExplorationShare:      float64(p.exploration) / float64(p.calls),
+			ExplorationPerSession: float64(p.exploration) / float64(p.sessions),
+			AgentSessionRate:      float64(p.agentSessions) / float64(p.sessions),
+		}
+		if c.AvgDurationMinutes > SubagentMinAvgMinutes &&
+			c.ExplorationShare >= SubagentMinExplorationShare &&
+			c.AgentSessionRate <= SubagentMaxAgentRate {
+			result.Candidates = append(result.Candidates, c)
+		}
+	}
+
+	sort.Slice(result.Candidates, func(i, j int) bool {
+		a, b := result.Candidates[i], result.Candidates[j]
+		if ea, eb := a.ExplorationPerSession*float64(a.Sessions), b.ExplorationPerSession*float64(b.Sessions); ea != eb {
+			return ea > eb
+		}
+		return a.ProjectPath < b.ProjectPath
+	})
+	return result
+}
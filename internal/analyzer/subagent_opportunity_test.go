@@ -0,0 +1,74 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// exploringSessions returns n sessions in project, each minutes long with
+// reads Read calls and edits Edit calls.
+func exploringSessions(project string, n, minutes, reads, edits int) []claude.SessionMeta {
+	var sessions []claude.SessionMeta
+	for i := range n {
+		sessions = append(sessions, claude.SessionMeta{
+			SessionID:       fmt.Sprintf("%s-%d", project, i),
+			ProjectPath:     project,
+			DurationMinutes: minutes,
+			ToolCounts:      map[string]int{"Read": reads, "Edit": edits},
+		})
+	}
+	return sessions
+}
+
+func TestAnalyzeSubagentOpportunity_Empty(t *testing.T) {
+	result := AnalyzeSubagentOpportunity(nil, nil)
+	if result.ProjectsAnalyzed != 0 || result.Candidates == nil || len(result.Candidates) != 0 {
+		t.Errorf("result = %+v, want no projects and an empty candidate list", result)
+	}
+}
+
+func TestAnalyzeSubagentOpportunity(t *testing.T) {
+	var sessions []claude.SessionMeta
+	// Long, exploration-heavy, no agents: a candidate.
+	sessions = append(sessions, exploringSessions("/src/explorer", 6, 60, 30, 10)...)
+	// Same shape but agents in half the sessions.
+	delegating := exploringSessions("/src/delegator", 6, 60, 30, 10)
+	delegating[0].UsesTaskAgent = true
+	delegating[1].ToolCounts["Task"] = 1
+	delegating[2].SessionID = "agent-session"
+	sessions = append(sessions, delegating...)
+	// Long sessions that mostly edit.
+	sessions = append(sessions, exploringSessions("/src/editor", 6, 60, 5, 20)...)
+	// Short exploration sessions.
+	sessions = append(sessions, exploringSessions("/src/quick", 6, 10, 30, 10)...)
+	// Too few sessions to judge.
+	sessions = append(sessions, exploringSessions("/src/new", 4, 90, 50, 1)...)
+	tasks := []claude.AgentTask{{SessionID: "agent-session", AgentType: "Explore"}}
+
+	result := AnalyzeSubagentOpportunity(sessions, tasks)
+
+	if result.ProjectsAnalyzed != 4 {
+		t.Errorf("ProjectsAnalyzed = %d, want 4", result.ProjectsAnalyzed)
+	}
+	if len(result.Candidates) != 1 {
+		t.Fatalf("Candidates = %+v, want only explorer", result.Candidates)
+	}
+	c := result.Candidates[0]
+	if c.ProjectName != "explorer" || c.Sessions != 6 || c.AvgDurationMinutes != 60 || c.AgentSessionRate != 0 {
+		t.Errorf("candidate = %+v", c)
+	}
+	if math.Abs(c.ExplorationShare-0.75) > 1e-9 || c.ExplorationPerSession != 30 {
+		t.Errorf("exploration = %.2f share, %.1f/session; want 0.75 and 30", c.ExplorationShare, c.ExplorationPerSession)
+	}
+}
+
+func TestAnalyzeSubagentOpportunity_OrdersByExploration(t *testing.T) {
+	sessions := append(exploringSessions("/src/a", 5, 45, 20, 5), exploringSessions("/src/b", 8, 45, 20, 5)...)
+	result := AnalyzeSubagentOpportunity(sessions, nil)
+	if len(result.Candidates) != 2 || result.Candidates[0].ProjectName != "b" {
+		t.Errorf("Candidates = %+v, want b (more exploration) first", result.Candidates)
+	}
+}
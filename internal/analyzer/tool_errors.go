@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// ToolErrorMinUses is how many calls a tool needs before its error rate is
+// ranked; below it, a single failure would swamp the rate.
+const ToolErrorMinUses = 20
+
+// toolErrorTop is how many of the flakiest tools are reported.
+const toolErrorTop = 5
+
+// ToolErrorRate is one tool's error rate across the analyzed sessions.
+type ToolErrorRate struct {
+	Tool   string `json:"tool"`
+	Uses   int    `json:"uses"`
+	Errors int    `json:"errors"`
+	// ErrorRate is the fraction of calls that failed.
+	ErrorRate float64 `json:"error_rate"`
+}
+
+// ToolErrorRates ranks tools by how often their calls fail.
+type ToolErrorRates struct {
+	// Sessions is the number of sessions with per-tool error data; only
+	// these count towards uses and errors.
+	Sessions int `json:"sessions"`
+	// Flakiest lists up to five tools with at least ToolErrorMinUses calls
+	// and one error, worst rate first.
+	Flakiest []ToolErrorRate `json:"flakiest"`
+}
+
+// AnalyzeToolErrorRates computes each tool's error rate (errors / uses) and
+// returns the tools that fail most often. Sessions without per-tool error
+// counts, such as cache entries that couldn't be backfilled from their
+// transcript, are skipped so their calls don't dilute the rates. Tools with
+// fewer than ToolErrorMinUses calls are left out.
+func AnalyzeToolErrorRates(sessions []claude.SessionMeta) ToolErrorRates {
+	var result ToolErrorRates
+	uses := make(map[string]int)
+	errs := make(map[string]int)
+	for _, s := range sessions {
+		if s.ToolErrorsByTool == nil {
+			continue
+		}
+		result.Sessions++
+		for tool, n := range s.ToolCounts {
+			uses[tool] += n
+		}
+		for tool, n := range s.ToolErrorsByTool {
+			errs[tool] += n
+		}
+	}
+
+	for tool, n := range uses {
+		if n < ToolErrorMinUses || errs[tool] == 0 {
+			continue
+		}
+		result.Flakiest = append(result.Flakiest, ToolErrorRate{
+			Tool:      tool,
+			Uses:      n,
+			Errors:    errs[tool],
+			ErrorRate: float64(errs[tool]) / float64(n),
+		})
+	}
+	sort.Slice(result.Flakiest, func(i, j int) bool {
+		a, b := result.Flakiest[i], result.Flakiest[j]
+		if a.ErrorRate != b.ErrorRate {
+			return a.ErrorRate > b.ErrorRate
+		}
+		return a.Tool < b.Tool
+	})
+	if len(result.Flakiest) > toolErrorTop {
+		result.Flakiest = result.Flakiest[:toolErrorTop]
+	}
+	return result
+}
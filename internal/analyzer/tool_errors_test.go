@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeToolErrorRates_Empty(t *testing.T) {
+	result := AnalyzeToolErrorRates(nil)
+	if result.Sessions != 0 || len(result.Flakiest) != 0 {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
+
+func TestAnalyzeToolErrorRates_RanksByRate(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{
+			ToolCounts:       map[string]int{"Bash": 30, "Read": 50, "Edit": 25, "WebFetch": 5},
+			ToolErrorsByTool: map[string]int{"Bash": 12, "Read": 2, "WebFetch": 4},
+		},
+		{
+			ToolCounts:       map[string]int{"Bash": 10, "Read": 50, "Edit": 15},
+			ToolErrorsByTool: map[string]int{"Bash": 4, "Edit": 8},
+		},
+	}
+
+	result := AnalyzeToolErrorRates(sessions)
+	if result.Sessions != 2 {
+		t.Errorf("Sessions = %d, want 2", result.Sessions)
+	}
+	// WebFetch has too few uses to rank.
+	if len(result.Flakiest) != 3 {
+		t.Fatalf("expected 3 tools, got %+v", result.Flakiest)
+	}
+	want := []struct {
+		tool string
+		rate float64
+	}{
+		{"Bash", 0.4},
+		{"Edit", 0.2},
+		{"Read", 0.02},
+	}
+	for i, w := range want {
+		got := result.Flakiest[i]
+		if got.Tool != w.tool || got.ErrorRate != w.rate {
+			t.Errorf("Flakiest[%d] = %s %.2f, want %s %.2f", i, got.Tool, got.ErrorRate, w.tool, w.rate)
+		}
+	}
+	if b := result.Flakiest[0]; b.Uses != 40 || b.Errors != 16 {
+		t.Errorf("Bash uses/errors = %d/%d, want 40/16", b.Uses, b.Errors)
+	}
+}
+
+func TestAnalyzeToolErrorRates_SkipsSessionsWithoutBreakdown(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{
+			ToolCounts:       map[string]int{"Bash": 20},
+			ToolErrorsByTool: map[string]int{"Bash": 10},
+		},
+		// Cached before per-tool errors were recorded: its calls must not
+		// dilute the rate.
+		{
+			ToolCounts: map[string]int{"Bash": 80},
+			ToolErrors: 3,
+		},
+	}
+
+	result := AnalyzeToolErrorRates(sessions)
+	if result.Sessions != 1 {
+		t.Errorf("Sessions = %d, want 1", result.Sessions)
+	}
+	if len(result.Flakiest) != 1 || result.Flakiest[0].ErrorRate != 0.5 {
+		t.Errorf("Flakiest = %+v, want Bash at 0.5", result.Flakiest)
+	}
+}
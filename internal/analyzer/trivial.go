@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+// TrivialSession defines a trivial session: a quick one-off that lasted less
+// than MinDurationMinutes and had fewer than MinUserMessages user messages.
+// Meeting either threshold makes a session non-trivial. It converts to and
+// from config.TrivialSession.
+type TrivialSession struct {
+	MinDurationMinutes int `json:"min_duration_minutes"`
+	MinUserMessages    int `json:"min_user_messages"`
+}
+
+// DefaultTrivialSession is the trivial-session definition used when none is
+// configured, config.DefaultTrivialSession.
+var DefaultTrivialSession = TrivialSession(config.DefaultTrivialSession)
+
+// IsTrivial reports whether s is a trivial session. The zero TrivialSession
+// means DefaultTrivialSession.
+func (t TrivialSession) IsTrivial(s claude.SessionMeta) bool {
+	if t == (TrivialSession{}) {
+		t = DefaultTrivialSession
+	}
+	return s.DurationMinutes < t.MinDurationMinutes && s.UserMessageCount < t.MinUserMessages
+}
+
+// FilterTrivialSessions returns sessions that are not trivial under t, and
+// the number dropped.
+func FilterTrivialSessions(sessions []claude.SessionMeta, t TrivialSession) ([]claude.SessionMeta, int) {
+	var result []claude.SessionMeta
+	for _, s := range sessions {
+		if !t.IsTrivial(s) {
+			result = append(result, s)
+		}
+	}
+	return result, len(sessions) - len(result)
+}
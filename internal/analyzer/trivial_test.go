@@ -0,0 +1,49 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestTrivialSession_IsTrivial(t *testing.T) {
+	short := claude.SessionMeta{DurationMinutes: 3, UserMessageCount: 1}
+	long := claude.SessionMeta{DurationMinutes: 30, UserMessageCount: 2}
+	chatty := claude.SessionMeta{DurationMinutes: 5, UserMessageCount: 8}
+
+	def := TrivialSession{}
+	if !def.IsTrivial(short) {
+		t.Error("expected a short, quiet session to be trivial by default")
+	}
+	if def.IsTrivial(long) || def.IsTrivial(chatty) {
+		t.Error("expected meeting either default threshold to make a session non-trivial")
+	}
+
+	strict := TrivialSession{MinDurationMinutes: 60, MinUserMessages: 10}
+	if !strict.IsTrivial(long) || !strict.IsTrivial(chatty) {
+		t.Error("expected higher thresholds to count more sessions as trivial")
+	}
+
+	loose := TrivialSession{MinDurationMinutes: 1, MinUserMessages: 1}
+	if loose.IsTrivial(short) {
+		t.Error("expected thresholds of 1 to count only empty sessions as trivial")
+	}
+}
+
+func TestFilterTrivialSessions(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "trivial", DurationMinutes: 2, UserMessageCount: 1},
+		{SessionID: "long", DurationMinutes: 25, UserMessageCount: 1},
+		{SessionID: "chatty", DurationMinutes: 4, UserMessageCount: 6},
+	}
+
+	kept, dropped := FilterTrivialSessions(sessions, DefaultTrivialSession)
+	if dropped != 1 || len(kept) != 2 {
+		t.Fatalf("expected 2 kept and 1 dropped, got %d kept, %d dropped", len(kept), dropped)
+	}
+	for _, s := range kept {
+		if s.SessionID == "trivial" {
+			t.Error("expected the trivial session to be dropped")
+		}
+	}
+}
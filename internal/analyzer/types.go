@@ -1,6 +1,10 @@
 // Package analyzer provides friction, velocity, satisfaction, and efficiency analysis.
 package analyzer
 
+// MinSampleSize is the sample size below which a rate is low confidence:
+// with fewer observations, one outlier moves it by ten points or more.
+const MinSampleSize = 10
+
 // AnalysisResult is the top-level result of analyzing all session data.
 type AnalysisResult struct {
 	Friction     FrictionSummary   `json:"friction"`
@@ -56,6 +60,9 @@ type SatisfactionScore struct {
 	// WeightedScore is the overall satisfaction score (0-100).
 	WeightedScore float64 `json:"weighted_score"`
 
+	// SampleSize is the number of satisfaction ratings behind WeightedScore.
+	SampleSize int `json:"sample_size"`
+
 	// SatisfactionCounts maps satisfaction level to count.
 	SatisfactionCounts map[string]int `json:"satisfaction_counts"`
 
@@ -110,6 +117,9 @@ type AgentPerformance struct {
 	// SuccessRate is the fraction of agents that completed successfully.
 	SuccessRate float64 `json:"success_rate"`
 
+	// SampleSize is the number of agents behind SuccessRate and KillRate.
+	SampleSize int `json:"sample_size"`
+
 	// KillRate is the fraction of agents that were killed via TaskStop.
 	KillRate float64 `json:"kill_rate"`
 
@@ -133,6 +143,7 @@ type AgentPerformance struct {
 type AgentTypeStats struct {
 	Count         int     `json:"count"`
 	SuccessRate   float64 `json:"success_rate"`
+	KillRate      float64 `json:"kill_rate"`
 	AvgDurationMs float64 `json:"avg_duration_ms"`
 	AvgTokens     float64 `json:"avg_tokens"`
 }
@@ -46,7 +46,17 @@ func FilterSessionsByDays(sessions []claude.SessionMeta, days int) []claude.Sess
 		return sessions
 	}
 
-	cutoff := time.Now().AddDate(0, 0, -days)
+	return FilterSessionsSince(sessions, time.Now().AddDate(0, 0, -days))
+}
+
+// FilterSessionsSince returns sessions whose StartTime falls after the given
+// cutoff. Sessions with an unparseable StartTime are dropped. If cutoff is the
+// zero time, all sessions are returned.
+func FilterSessionsSince(sessions []claude.SessionMeta, cutoff time.Time) []claude.SessionMeta {
+	if cutoff.IsZero() {
+		return sessions
+	}
+
 	var filtered []claude.SessionMeta
 
 	for _, s := range sessions {
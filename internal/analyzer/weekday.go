@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// WeekdayPatterns compares sessions started on weekdays with those started on
+// weekends. Days are judged in the session time zone (the configured
+// timezone, or the system local zone), so "weekend" is the user's weekend.
+type WeekdayPatterns struct {
+	Weekday DayGroupStats `json:"weekday"`
+	Weekend DayGroupStats `json:"weekend"`
+}
+
+// DayGroupStats summarizes one group of sessions. Averages are zero when the
+// group is empty; friction and outcome rate are zero when it has no facets.
+type DayGroupStats struct {
+	Sessions           int     `json:"sessions"`
+	AvgCommits         float64 `json:"avg_commits"`
+	AvgDurationMinutes float64 `json:"avg_duration_minutes"`
+	// FacetSessions is the number of sessions in the group with a facet;
+	// AvgFriction and OutcomeRate are computed over these.
+	FacetSessions int     `json:"facet_sessions"`
+	AvgFriction   float64 `json:"avg_friction"`
+	// OutcomeRate is the share of faceted sessions whose outcome was
+	// achieved or mostly_achieved.
+	OutcomeRate float64 `json:"outcome_rate"`
+}
+
+// AnalyzeWeekdayPatterns splits sessions by whether StartTime falls on a
+// Saturday or Sunday and compares the two groups. Sessions without a
+// parseable StartTime are skipped.
+func AnalyzeWeekdayPatterns(sessions []claude.SessionMeta, facets []claude.SessionFacet) WeekdayPatterns {
+	facetBySession := make(map[string]*claude.SessionFacet, len(facets))
+	for i := range facets {
+		facetBySession[facets[i].SessionID] = &facets[i]
+	}
+
+	type totals struct {
+		commits, duration, friction, achieved int
+	}
+	var weekday, weekend totals
+	var result WeekdayPatterns
+
+	for _, s := range sessions {
+		start := claude.ParseTimestamp(s.StartTime)
+		if start.IsZero() {
+			continue
+		}
+
+		group, t := &result.Weekday, &weekday
+		if wd := start.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			group, t = &result.Weekend, &weekend
+		}
+
+		group.Sessions++
+		t.commits += s.GitCommits
+		t.duration += s.DurationMinutes
+
+		if f, ok := facetBySession[s.SessionID]; ok {
+			group.FacetSessions++
+			for _, count := range f.FrictionCounts {
+				t.friction += count
+			}
+			if f.Outcome == "achieved" || f.Outcome == "mostly_achieved" {
+				t.achieved++
+			}
+		}
+	}
+
+	for _, g := range []struct {
+		stats *DayGroupStats
+		t     totals
+	}{{&result.Weekday, weekday}, {&result.Weekend, weekend}} {
+		if n := g.stats.Sessions; n > 0 {
+			g.stats.AvgCommits = float64(g.t.commits) / float64(n)
+			g.stats.AvgDurationMinutes = float64(g.t.duration) / float64(n)
+		}
+		if n := g.stats.FacetSessions; n > 0 {
+			g.stats.AvgFriction = float64(g.t.friction) / float64(n)
+			g.stats.OutcomeRate = float64(g.t.achieved) / float64(n)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestAnalyzeWeekdayPatterns(t *testing.T) {
+	claude.SetSessionTimeLocation(time.UTC)
+	t.Cleanup(func() { claude.SetSessionTimeLocation(nil) })
+
+	// 2026-03-02 is a Monday; 2026-03-07 a Saturday.
+	sessions := []claude.SessionMeta{
+		{SessionID: "mon", StartTime: "2026-03-02T10:00:00Z", GitCommits: 3, DurationMinutes: 40},
+		{SessionID: "tue", StartTime: "2026-03-03T10:00:00Z", GitCommits: 1, DurationMinutes: 20},
+		{SessionID: "sat", StartTime: "2026-03-07T10:00:00Z", GitCommits: 0, DurationMinutes: 90},
+		{SessionID: "bad", StartTime: "not a time", GitCommits: 9},
+	}
+	facets := []claude.SessionFacet{
+		{SessionID: "mon", Outcome: "achieved"},
+		{SessionID: "tue", Outcome: "not_achieved", FrictionCounts: map[string]int{"wrong_approach": 2}},
+		{SessionID: "sat", Outcome: "mostly_achieved", FrictionCounts: map[string]int{"tool_error": 1}},
+	}
+
+	p := AnalyzeWeekdayPatterns(sessions, facets)
+
+	wd := p.Weekday
+	if wd.Sessions != 2 || wd.AvgCommits != 2 || wd.AvgDurationMinutes != 30 {
+		t.Errorf("weekday = %+v, want 2 sessions, 2 commits, 30 min", wd)
+	}
+	if wd.FacetSessions != 2 || wd.AvgFriction != 1 || wd.OutcomeRate != 0.5 {
+		t.Errorf("weekday facets = %+v, want friction 1, outcome rate 0.5", wd)
+	}
+
+	we := p.Weekend
+	if we.Sessions != 1 || we.AvgCommits != 0 || we.AvgDurationMinutes != 90 || we.OutcomeRate != 1 {
+		t.Errorf("weekend = %+v, want 1 session, 0 commits, 90 min, outcome rate 1", we)
+	}
+}
+
+func TestAnalyzeWeekdayPatterns_UsesSessionTimeZone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tz data unavailable: %v", err)
+	}
+	claude.SetSessionTimeLocation(ny)
+	t.Cleanup(func() { claude.SetSessionTimeLocation(nil) })
+
+	// Saturday 02:00 UTC is still Friday evening in New York.
+	p := AnalyzeWeekdayPatterns([]claude.SessionMeta{{SessionID: "s", StartTime: "2026-03-07T02:00:00Z"}}, nil)
+	if p.Weekday.Sessions != 1 || p.Weekend.Sessions != 0 {
+		t.Errorf("got weekday %d, weekend %d sessions; want 1, 0", p.Weekday.Sessions, p.Weekend.Sessions)
+	}
+}
+
+func TestAnalyzeWeekdayPatterns_EmptyGroup(t *testing.T) {
+	p := AnalyzeWeekdayPatterns(nil, nil)
+	if p.Weekday != (DayGroupStats{}) || p.Weekend != (DayGroupStats{}) {
+		t.Errorf("expected zero stats for no sessions, got %+v", p)
+	}
+}
@@ -0,0 +1,316 @@
+// Package anonymize replaces project paths and names in command results with
+// stable pseudonyms, so output can be shared without revealing them.
+package anonymize
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Anonymizer maps project paths, and their basenames, to pseudonyms such as
+// "project-a1b2". Pseudonyms are derived from a hash of the path, so they are
+// the same on every run for the same project.
+type Anonymizer struct {
+	pseudonyms map[string]string // real path -> pseudonym
+	byName     map[string]string // basename -> pseudonym
+	home       string
+}
+
+// New returns an Anonymizer for the given project paths. Occurrences of home
+// outside a project path are shown as "~". Either may be empty.
+func New(paths []string, home string) *Anonymizer {
+	a := &Anonymizer{
+		pseudonyms: make(map[string]string),
+		byName:     make(map[string]string),
+		home:       strings.TrimRight(home, "/"),
+	}
+
+	unique := make(map[string]bool)
+	for _, p := range paths {
+		p = strings.TrimRight(p, "/")
+		if p != "" && p != a.home {
+			unique[p] = true
+		}
+	}
+	sorted := make([]string, 0, len(unique))
+	for p := range unique {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	used := make(map[string]bool)
+	for _, p := range sorted {
+		name := pseudonym(p, used)
+		used[name] = true
+		a.pseudonyms[p] = name
+	}
+
+	// A basename shared by several projects is ambiguous; it takes the
+	// pseudonym of the first path in sorted order so output stays stable.
+	for _, p := range sorted {
+		base := filepath.Base(p)
+		if _, ok := a.byName[base]; !ok {
+			a.byName[base] = a.pseudonyms[p]
+		}
+	}
+	return a
+}
+
+// pseudonym returns "project-" plus the shortest prefix (at least four hex
+// digits) of the path's hash not already in use.
+func pseudonym(path string, used map[string]bool) string {
+	sum := sha256.Sum256([]byte(path))
+	digest := hex.EncodeToString(sum[:])
+	for n := 4; n < len(digest); n++ {
+		if name := "project-" + digest[:n]; !used[name] {
+			return name
+		}
+	}
+	return "project-" + digest
+}
+
+// Path returns p with a known project path at its start replaced by the
+// project's pseudonym, or else the home directory replaced by "~". Other
+// paths are returned unchanged.
+func (a *Anonymizer) Path(p string) string {
+	clean := strings.TrimRight(p, "/")
+	if name, ok := a.pseudonyms[clean]; ok {
+		return name
+	}
+	// The longest matching project wins, so a nested project isn't shown as
+	// a directory of its parent.
+	var best string
+	for path := range a.pseudonyms {
+		if strings.HasPrefix(p, path+"/") && len(path) > len(best) {
+			best = path
+		}
+	}
+	if best != "" {
+		return a.pseudonyms[best] + p[len(best):]
+	}
+	if a.home != "" && (p == a.home || strings.HasPrefix(p, a.home+"/")) {
+		return "~" + p[len(a.home):]
+	}
+	return p
+}
+
+// Name returns the pseudonym for a known project basename, or name unchanged.
+func (a *Anonymizer) Name(name string) string {
+	if pseudonym, ok := a.byName[name]; ok {
+		return pseudonym
+	}
+	return name
+}
+
+// Identifier anonymizes s as a path if it contains a slash and as a project
+// name otherwise. Only the whole value is matched, after any surrounding ANSI
+// styling and padding, so a name inside other text is left alone.
+func (a *Anonymizer) Identifier(s string) string {
+	start, end := 0, len(s)
+	for start < end && (s[start] == 0x1b || s[start] == ' ') {
+		if s[start] == ' ' {
+			start++
+		} else {
+			start = skipEscape(s, start)
+		}
+	}
+	for start < end && s[end-1] == ' ' {
+		end--
+	}
+	// Trailing escapes end in a letter, such as the "m" of "\x1b[0m".
+	for {
+		i := strings.LastIndexByte(s[start:end], 0x1b)
+		if i < 0 || skipEscape(s, start+i) != end {
+			break
+		}
+		end = start + i
+	}
+	core := s[start:end]
+	if core == "" {
+		return s
+	}
+	var replaced string
+	if strings.Contains(core, "/") {
+		replaced = a.Path(core)
+	} else {
+		replaced = a.Name(core)
+	}
+	if replaced == core {
+		return s
+	}
+	return s[:start] + replaced + s[end:]
+}
+
+// Mentions returns text with the project at path, written as its path or as
+// its basename on its own, replaced by the project's pseudonym. It is for
+// text known to be about that project, such as a finding's title; other
+// words are left alone even if they match another project's name.
+func (a *Anonymizer) Mentions(text, path string) string {
+	path = strings.TrimRight(path, "/")
+	pseudonym, ok := a.pseudonyms[path]
+	if !ok {
+		return text
+	}
+	text = replaceWord(text, path, pseudonym, true)
+	return replaceWord(text, filepath.Base(path), pseudonym, false)
+}
+
+// replaceWord replaces each occurrence of word in s that isn't part of a
+// longer name or, unless word is a path whose subpaths count, a path.
+func replaceWord(s, word, with string, isPath bool) string {
+	var b strings.Builder
+	last := 0
+	for i := 0; ; {
+		j := strings.Index(s[i:], word)
+		if j < 0 {
+			break
+		}
+		start, end := i+j, i+j+len(word)
+		i = end
+		if start > 0 && (isNameByte(s[start-1]) || s[start-1] == '/') {
+			continue
+		}
+		if end < len(s) && (isNameByte(s[end]) || (s[end] == '/' && !isPath)) {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(with)
+		last = end
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// isNameByte reports whether c can be part of a project basename. Bytes of
+// multi-byte UTF-8 characters count, so non-ASCII names stay whole.
+func isNameByte(c byte) bool {
+	return c == '_' || c == '-' ||
+		(c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+// skipEscape returns the index just past the ANSI escape sequence at s[i].
+func skipEscape(s string, i int) int {
+	i++
+	if i < len(s) && s[i] == '[' {
+		i++
+		for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+			i++
+		}
+	}
+	return min(i+1, len(s))
+}
+
+// pathFields are struct fields, besides those named for projects, that hold
+// a path.
+var pathFields = map[string]bool{"Path": true, "Dir": true, "Repo": true, "RepoRoot": true}
+
+// isIdentifierField reports whether f of owner holds a project path or name:
+// its name mentions Project (ProjectPath, Projects, ByProject), it is a
+// path, or it is the Name of a type named for projects, such as
+// scanner.Project.
+func isIdentifierField(owner reflect.Type, f reflect.StructField) bool {
+	if f.Name == "Name" {
+		return strings.Contains(owner.Name(), "Project")
+	}
+	return strings.Contains(f.Name, "Project") || pathFields[f.Name]
+}
+
+// Value returns a deep copy of v with the project paths and names in its
+// identifier fields anonymized: string fields, string slices, and the keys
+// of maps such as per-project totals. Other text, such as headings and
+// messages, is copied unchanged. Unexported fields are shared with v.
+func (a *Anonymizer) Value(v any) any {
+	if v == nil {
+		return nil
+	}
+	return a.copy(reflect.ValueOf(v), false).Interface()
+}
+
+// copy returns a copy of v, anonymizing its strings when ident is set.
+func (a *Anonymizer) copy(v reflect.Value, ident bool) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		out := reflect.New(v.Type()).Elem()
+		if ident {
+			out.SetString(a.Identifier(v.String()))
+		} else {
+			out.SetString(v.String())
+		}
+		return out
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(a.copy(v.Elem(), ident))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(a.copy(v.Elem(), ident))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			out.Field(i).Set(a.copy(v.Field(i), isIdentifierField(v.Type(), f)))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(a.copy(v.Index(i), ident))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(a.copy(v.Index(i), ident))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(a.copy(iter.Key(), ident), a.copy(iter.Value(), false))
+		}
+		return out
+	}
+	return v
+}
+
+// Mapping returns the real path to pseudonym mapping.
+func (a *Anonymizer) Mapping() map[string]string {
+	return maps.Clone(a.pseudonyms)
+}
+
+// WriteMap writes the real path to pseudonym mapping to path as JSON.
+func (a *Anonymizer) WriteMap(path string) error {
+	data, err := json.MarshalIndent(a.Mapping(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
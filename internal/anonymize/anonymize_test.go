@@ -0,0 +1,138 @@
+package anonymize
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIdentifier(t *testing.T) {
+	a := New([]string{"/home/alice/clients/acme", "/home/alice/code/widget/", "/home/alice/clients/acme/api"}, "/home/alice")
+	acme := a.Mapping()["/home/alice/clients/acme"]
+	api := a.Mapping()["/home/alice/clients/acme/api"]
+	if !strings.HasPrefix(acme, "project-") || len(acme) != len("project-")+4 {
+		t.Fatalf("unexpected pseudonym %q", acme)
+	}
+
+	for _, tc := range []struct{ in, want string }{
+		{"/home/alice/clients/acme", acme},
+		{"/home/alice/clients/acme/", acme},
+		{"/home/alice/clients/acme/src/main.go", acme + "/src/main.go"},
+		// The nested project wins over its parent.
+		{"/home/alice/clients/acme/api/go.mod", api + "/go.mod"},
+		{"acme", acme},
+		{"\x1b[1macme\x1b[0m  ", "\x1b[1m" + acme + "\x1b[0m  "},
+		// Home outside any project.
+		{"/home/alice/.config", "~/.config"},
+		// Not a whole known name or path.
+		{"acme has 3 gaps", "acme has 3 gaps"},
+		{"acme-two", "acme-two"},
+		{"/home/alice/clients/acme-two", "~/clients/acme-two"},
+		{"/opt/acme", "/opt/acme"},
+	} {
+		if got := a.Identifier(tc.in); got != tc.want {
+			t.Errorf("Identifier(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestMentions(t *testing.T) {
+	a := New([]string{"/code/acme", "/code/widget"}, "")
+	acme := a.Mapping()["/code/acme"]
+
+	for _, tc := range []struct{ in, want string }{
+		{"Stale CLAUDE.md: acme", "Stale CLAUDE.md: " + acme},
+		{"/code/acme/CLAUDE.md is 90 days old.", acme + "/CLAUDE.md is 90 days old."},
+		{"Run `sessions --project acme`", "Run `sessions --project " + acme + "`"},
+		// Other projects, and acme as part of another name, are left alone.
+		{"acme-two and widget", "acme-two and widget"},
+		{"/srv/acme", "/srv/acme"},
+	} {
+		if got := a.Mentions(tc.in, "/code/acme"); got != tc.want {
+			t.Errorf("Mentions(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+	if got := a.Mentions("acme", "/code/unknown"); got != "acme" {
+		t.Errorf("Mentions for an unknown project = %q, want the text unchanged", got)
+	}
+}
+
+func TestValue(t *testing.T) {
+	type ProjectStats struct {
+		Name     string
+		Path     string
+		Sessions int
+	}
+	type report struct {
+		Title       string
+		Tool        string
+		ProjectName string
+		Projects    []string
+		Stats       []ProjectStats
+		ByProject   map[string]int
+		Top         *ProjectStats
+		private     string
+	}
+	a := New([]string{"/code/acme"}, "")
+	acme := a.Mapping()["/code/acme"]
+
+	in := report{
+		Title:       "acme",
+		Tool:        "/code/acme",
+		ProjectName: "acme",
+		Projects:    []string{"/code/acme"},
+		Stats:       []ProjectStats{{Name: "acme", Path: "/code/acme/CLAUDE.md", Sessions: 3}},
+		ByProject:   map[string]int{"acme": 3},
+		Top:         &ProjectStats{Name: "acme"},
+		private:     "acme",
+	}
+	got := a.Value(in).(report)
+
+	want := report{
+		// Not project fields.
+		Title:       "acme",
+		Tool:        "/code/acme",
+		ProjectName: acme,
+		Projects:    []string{acme},
+		Stats:       []ProjectStats{{Name: acme, Path: acme + "/CLAUDE.md", Sessions: 3}},
+		ByProject:   map[string]int{acme: 3},
+		Top:         &ProjectStats{Name: acme},
+		private:     "acme",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Value = %+v, want %+v", got, want)
+	}
+	if in.Stats[0].Name != "acme" || in.Top.Name != "acme" {
+		t.Error("Value modified its input")
+	}
+}
+
+func TestPseudonymsAreStable(t *testing.T) {
+	a := New([]string{"/code/acme"}, "")
+	b := New([]string{"/code/other", "/code/acme"}, "")
+	if a.Mapping()["/code/acme"] != b.Mapping()["/code/acme"] {
+		t.Errorf("pseudonym changed between runs: %q vs %q", a.Mapping()["/code/acme"], b.Mapping()["/code/acme"])
+	}
+}
+
+func TestWriteMap(t *testing.T) {
+	a := New([]string{"/code/acme"}, "")
+	path := filepath.Join(t.TempDir(), "map.json")
+	if err := a.WriteMap(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["/code/acme"] != a.Mapping()["/code/acme"] {
+		t.Errorf("map = %v", m)
+	}
+}
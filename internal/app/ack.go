@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/watcher"
+	"github.com/spf13/cobra"
+)
+
+var ackRemove bool
+
+var ackCmd = &cobra.Command{
+	Use:   "ack [signature]",
+	Short: "Acknowledge a watch alert so it stops repeating",
+	Long: `Acknowledge an alert raised by 'claudewatch watch'. Every alert is printed
+with a short signature derived from its level and title; acknowledging it
+suppresses that alert in the watcher until the underlying condition gets
+materially worse (its measure grows by more than 20%), or until it escalates
+to a different level. Alerts that are new still come through.
+
+A running watcher picks up acknowledgments on its next check. Without a
+signature, ack lists acknowledged alerts and recent alerts that can be
+acknowledged.
+
+Examples:
+  claudewatch ack                    # list acknowledged and recent alerts
+  claudewatch ack 3fa2c1d8           # acknowledge an alert
+  claudewatch ack --remove 3fa2c1d8  # show it again`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAck,
+}
+
+func init() {
+	ackCmd.Flags().BoolVar(&ackRemove, "remove", false, "Remove the acknowledgment so the alert shows again")
+	ackCmd.Flags().BoolVar(&flagJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(ackCmd)
+}
+
+// ackListOutput is the JSON form of ack without a signature.
+type ackListOutput struct {
+	Acknowledged []watcher.Ack   `json:"acknowledged"`
+	Recent       []watcher.Alert `json:"recent"`
+}
+
+func runAck(cmd *cobra.Command, args []string) error {
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	acks, err := watcher.LoadAcks(ackFilePath())
+	if err != nil {
+		return fmt.Errorf("reading acknowledgments: %w", err)
+	}
+
+	if len(args) == 0 {
+		if ackRemove {
+			return fmt.Errorf("--remove requires a signature")
+		}
+		return listAcks(acks, recentWatchAlerts())
+	}
+
+	sig := strings.ToLower(strings.TrimSpace(args[0]))
+	if ackRemove {
+		k, ok := acks[sig]
+		if !ok {
+			return fmt.Errorf("no acknowledged alert with signature %q", sig)
+		}
+		delete(acks, sig)
+		if err := watcher.SaveAcks(ackFilePath(), acks); err != nil {
+			return fmt.Errorf("writing acknowledgments: %w", err)
+		}
+		fmt.Printf("Removed acknowledgment %s: %s\n", sig, k.Title)
+		return nil
+	}
+
+	var alert *watcher.Alert
+	for _, a := range recentWatchAlerts() {
+		if a.Signature == sig {
+			alert = &a
+			break
+		}
+	}
+	if alert == nil {
+		return fmt.Errorf("no recent watch alert with signature %q; run 'claudewatch ack' to list them", sig)
+	}
+
+	acks[sig] = watcher.NewAck(*alert, time.Now())
+	if err := watcher.SaveAcks(ackFilePath(), acks); err != nil {
+		return fmt.Errorf("writing acknowledgments: %w", err)
+	}
+	fmt.Printf("Acknowledged %s: %s\n", sig, alert.Title)
+	fmt.Println(output.StyleMuted.Render(fmt.Sprintf("The watcher hides it until it worsens by more than %.0f%%.", watcher.AckBreakthroughRatio*100)))
+	return nil
+}
+
+// recentWatchAlerts returns the alerts the watcher last recorded in its
+// baseline, newest first, or nil when there is no baseline.
+func recentWatchAlerts() []watcher.Alert {
+	b, err := watcher.LoadBaseline(baselineFilePath())
+	if err != nil {
+		return nil
+	}
+	return b.RecentAlerts
+}
+
+// listAcks prints acknowledged alerts, then recent alerts not yet
+// acknowledged.
+func listAcks(acks map[string]watcher.Ack, recent []watcher.Alert) error {
+	out := ackListOutput{Acknowledged: []watcher.Ack{}, Recent: []watcher.Alert{}}
+	for _, a := range recent {
+		if _, ok := acks[a.Signature]; !ok {
+			out.Recent = append(out.Recent, a)
+		}
+	}
+	for _, a := range recent {
+		if k, ok := acks[a.Signature]; ok {
+			out.Acknowledged = append(out.Acknowledged, k)
+		}
+	}
+	// Acknowledgments for alerts no longer in the recent list come last,
+	// oldest first.
+	var stale []watcher.Ack
+	for _, k := range acks {
+		if !containsAck(out.Acknowledged, k.Signature) {
+			stale = append(stale, k)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].AckedAt.Before(stale[j].AckedAt) })
+	out.Acknowledged = append(out.Acknowledged, stale...)
+
+	if flagJSON {
+		return writeJSON(out)
+	}
+
+	fmt.Println(output.Section("Acknowledged Alerts"))
+	if len(out.Acknowledged) == 0 {
+		fmt.Printf(" %s\n", output.StyleMuted.Render("None"))
+	} else {
+		tbl := output.NewTable("Signature", "Level", "Alert", "Acknowledged")
+		for _, k := range out.Acknowledged {
+			tbl.AddRow(k.Signature, k.Level, k.Title, k.AckedAt.Local().Format("2006-01-02 15:04"))
+		}
+		tbl.Print()
+	}
+	fmt.Println()
+
+	fmt.Println(output.Section("Recent Alerts"))
+	if len(out.Recent) == 0 {
+		fmt.Printf(" %s\n", output.StyleMuted.Render("No unacknowledged alerts recorded by the watcher"))
+	} else {
+		tbl := output.NewTable("Signature", "Level", "Alert", "Last seen")
+		for _, a := range out.Recent {
+			tbl.AddRow(a.Signature, a.Level, a.Title, a.Time.Local().Format("2006-01-02 15:04"))
+		}
+		tbl.Print()
+		fmt.Printf("\n %s\n", output.StyleMuted.Render("Acknowledge one with 'claudewatch ack <signature>'."))
+	}
+	return nil
+}
+
+func containsAck(acks []watcher.Ack, sig string) bool {
+	for _, k := range acks {
+		if k.Signature == sig {
+			return true
+		}
+	}
+	return false
+}
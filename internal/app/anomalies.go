@@ -1,7 +1,6 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -47,7 +46,7 @@ func init() {
 }
 
 func runAnomalies(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -141,17 +140,15 @@ func runAnomalies(cmd *cobra.Command, args []string) error {
 	anomalies := analyzer.DetectAnomalies(projectSessions, facets, *baseline, pricing, cacheRatio, anomaliesFlagThreshold)
 
 	if flagJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(map[string]interface{}{
-			"project":   project,
+		return writeJSON(map[string]interface{}{
+			"project":   anonymizedName(project),
 			"baseline":  baseline,
 			"threshold": anomaliesFlagThreshold,
 			"anomalies": anomalies,
 		})
 	}
 
-	renderAnomalies(anomalies, project, *baseline, anomaliesFlagThreshold)
+	renderAnomalies(anonymized(anomalies), anonymizedName(project), anonymized(*baseline), anomaliesFlagThreshold)
 	return nil
 }
 
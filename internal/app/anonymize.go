@@ -0,0 +1,106 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/blackwell-systems/claudewatch/internal/anonymize"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/logging"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+)
+
+// activeAnonymizer returns the anonymizer for --anonymize, building it on
+// first use so a command that prints nothing never parses sessions for it.
+// It is nil unless --anonymize is active.
+var activeAnonymizer func() *anonymize.Anonymizer
+
+// startAnonymize turns on --anonymize: results passed through anonymized,
+// which writeJSON does for every command, and table cells that hold a
+// project path or name get pseudonyms instead. With --anonymize-map the
+// real path to pseudonym mapping is written to that file.
+func startAnonymize() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	activeAnonymizer = sync.OnceValue(func() *anonymize.Anonymizer {
+		return buildAnonymizer(cfg)
+	})
+
+	// Tables anonymize their cells before measuring them, so pseudonyms
+	// don't break column alignment.
+	output.SetCellFilter(func(s string) string {
+		return activeAnonymizer().Identifier(s)
+	})
+
+	if flagAnonymizeMap != "" {
+		if err := activeAnonymizer().WriteMap(flagAnonymizeMap); err != nil {
+			return fmt.Errorf("writing anonymize map: %w", err)
+		}
+	}
+	return nil
+}
+
+// anonymized returns a copy of v with the project paths and names in its
+// project fields replaced by pseudonyms when --anonymize is active, and v
+// itself otherwise. Commands pass their results through it before rendering.
+func anonymized[T any](v T) T {
+	if activeAnonymizer == nil {
+		return v
+	}
+	out, _ := activeAnonymizer().Value(v).(T)
+	return out
+}
+
+// anonymizedGaps is anonymized for gaps, whose titles and details are also
+// stripped of the project each gap is about.
+func anonymizedGaps(gaps []gap) []gap {
+	if activeAnonymizer == nil {
+		return gaps
+	}
+	a := activeAnonymizer()
+	out := make([]gap, len(gaps))
+	for i, g := range gaps {
+		if g.Project != "" {
+			g.Title = a.Mentions(g.Title, g.Project)
+			g.Detail = a.Mentions(g.Detail, g.Project)
+		}
+		out[i] = anonymized(g)
+	}
+	return out
+}
+
+// anonymizedName returns the pseudonym for a project name given on the
+// command line when --anonymize is active, for matching anonymized results.
+func anonymizedName(name string) string {
+	if activeAnonymizer == nil {
+		return name
+	}
+	return activeAnonymizer().Name(name)
+}
+
+// buildAnonymizer returns an anonymizer for the project paths of every
+// session and every discovered project.
+func buildAnonymizer(cfg *config.Config) *anonymize.Anonymizer {
+	var paths []string
+	if sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome); err == nil {
+		for _, s := range sessions {
+			paths = append(paths, claude.NormalizePath(s.ProjectPath))
+		}
+	} else {
+		logging.Warn("anonymize: parsing session meta", "err", err)
+	}
+	if projects, err := scanner.DiscoverProjects(cfg.ScanPaths); err == nil {
+		for _, p := range projects {
+			paths = append(paths, claude.NormalizePath(p.Path))
+		}
+	} else {
+		logging.Warn("anonymize: discovering projects", "err", err)
+	}
+	home, _ := os.UserHomeDir()
+	return anonymize.New(paths, home)
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/anonymize"
+	"github.com/stretchr/testify/assert"
+)
+
+// withAnonymizer activates --anonymize for the given project paths until the
+// test ends.
+func withAnonymizer(t *testing.T, paths ...string) *anonymize.Anonymizer {
+	t.Helper()
+	a := anonymize.New(paths, "/home/u")
+	orig := activeAnonymizer
+	activeAnonymizer = func() *anonymize.Anonymizer { return a }
+	t.Cleanup(func() { activeAnonymizer = orig })
+	return a
+}
+
+func TestAnonymized_InactiveReturnsInput(t *testing.T) {
+	g := gap{Title: "widget has no CLAUDE.md", Project: "widget"}
+	assert.Equal(t, g, anonymized(g))
+	assert.Equal(t, "widget", anonymizedName("widget"))
+}
+
+func TestAnonymizedGaps_ReplacesOnlyTheGapsProject(t *testing.T) {
+	a := withAnonymizer(t, "/home/u/code/widget", "/home/u/code/bash")
+	gaps := []gap{{
+		Title:   "High friction: widget",
+		Detail:  "Sessions in /home/u/code/widget lean on bash heavily.",
+		Project: "/home/u/code/widget",
+	}}
+
+	got := anonymizedGaps(gaps)
+
+	pseudonym := a.Name("widget")
+	assert.Equal(t, pseudonym, got[0].Project)
+	assert.Equal(t, "High friction: "+pseudonym, got[0].Title)
+	assert.Equal(t, "Sessions in "+pseudonym+" lean on bash heavily.", got[0].Detail,
+		"a tool named like another project must be left alone")
+	assert.Equal(t, "/home/u/code/widget", gaps[0].Project, "input must not be modified")
+}
+
+func TestAnonymizedName(t *testing.T) {
+	a := withAnonymizer(t, "/home/u/code/widget")
+	assert.Equal(t, a.Name("widget"), anonymizedName("widget"))
+	assert.True(t, strings.HasPrefix(anonymizedName("widget"), "project-"))
+	assert.Equal(t, "unknown", anonymizedName("unknown"))
+}
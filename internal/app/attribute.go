@@ -1,12 +1,9 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
-	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/blackwell-systems/claudewatch/internal/store"
 	"github.com/spf13/cobra"
@@ -33,7 +30,7 @@ func init() {
 }
 
 func runAttribute(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -62,7 +59,7 @@ func runAttribute(cmd *cobra.Command, args []string) error {
 	}
 
 	if flagJSON {
-		return json.NewEncoder(os.Stdout).Encode(rows)
+		return writeJSONCompact(rows)
 	}
 
 	fmt.Println(output.Section("Cost Attribution"))
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"runtime/pprof"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+var benchFlagCPUProfile string
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Time each parse and analyzer phase over your data",
+	Long: `Run every parser and analyzer once over the real data in the Claude home
+directory and print how long each phase took, in the order they ran. Use it
+to find where time goes as your history grows.
+
+bench only reads: rebuilt session metadata is not written back to the
+session-meta cache, a pricing.url fetch is not cached, and no snapshots or
+other state are stored. A cold
+cache therefore stays cold, and repeated runs time the same work.
+
+Examples:
+  claudewatch bench
+  claudewatch bench --cpuprofile cpu.pprof
+  claudewatch bench --json`,
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE:   runBench,
+}
+
+func init() {
+	benchCmd.Flags().StringVar(&benchFlagCPUProfile, "cpuprofile", "", "Write a pprof CPU profile of the run to this file")
+	rootCmd.AddCommand(benchCmd)
+}
+
+// benchPhase is the timing of a single parse or analyzer phase.
+type benchPhase struct {
+	Group    string        `json:"group"`
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"-"`
+	Millis   float64       `json:"ms"`
+	// Items is the number of records the phase produced, for parse phases.
+	Items int `json:"items,omitempty"`
+}
+
+// benchOutput is the JSON form of the bench command.
+type benchOutput struct {
+	Phases     []benchPhase `json:"phases"`
+	TotalMs    float64      `json:"total_ms"`
+	CPUProfile string       `json:"cpu_profile,omitempty"`
+}
+
+// benchRecorder times phases in the order they run.
+type benchRecorder struct {
+	phases []benchPhase
+}
+
+// time runs fn and records its duration under group and name. fn returns the
+// number of items it produced, or 0 when a count doesn't apply.
+func (r *benchRecorder) time(group, name string, fn func() int) {
+	start := time.Now()
+	items := fn()
+	d := time.Since(start)
+	r.phases = append(r.phases, benchPhase{
+		Group:    group,
+		Name:     name,
+		Duration: d,
+		Millis:   float64(d.Microseconds()) / 1000,
+		Items:    items,
+	})
+}
+
+func (r *benchRecorder) total() time.Duration {
+	var total time.Duration
+	for _, p := range r.phases {
+		total += p.Duration
+	}
+	return total
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	if benchFlagCPUProfile != "" {
+		f, err := os.Create(benchFlagCPUProfile)
+		if err != nil {
+			return fmt.Errorf("creating CPU profile: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return fmt.Errorf("starting CPU profile: %w", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	claude.SetSessionMetaCacheReadOnly(true)
+	defer claude.SetSessionMetaCacheReadOnly(false)
+
+	r := benchPhases(cfg.ClaudeHome, cfg.ScanPaths, time.Duration(cfg.ResumeGapMinutes)*time.Minute, cfg.Friction.StaleWeeks, cfg.ContextWindowTokens)
+
+	if flagJSON {
+		return writeJSON(benchOutput{
+			Phases:     r.phases,
+			TotalMs:    float64(r.total().Microseconds()) / 1000,
+			CPUProfile: benchFlagCPUProfile,
+		})
+	}
+	renderBench(anonymized(r))
+	if benchFlagCPUProfile != "" {
+		fmt.Println()
+		fmt.Println(output.StyleMuted.Render(" CPU profile written to " + benchFlagCPUProfile + "; inspect it with 'go tool pprof'."))
+	}
+	return nil
+}
+
+// benchPhases runs each parser, then each analyzer over the parsed data, and
+// records how long every phase took. Errors are ignored: a phase that fails
+// still shows how long it took to fail.
+func benchPhases(claudeHome string, scanPaths []string, resumeGap time.Duration, staleWeeks, contextWindow int) *benchRecorder {
+	r := &benchRecorder{}
+
+	var (
+		sessions    []claude.SessionMeta
+		facets      []claude.SessionFacet
+		tasks       []claude.AgentTask
+		todos       []claude.SessionTodos
+		fileHistory []claude.FileHistorySession
+		stats       *claude.StatsCache
+		peaks       map[string]claude.ContextPeak
+		activity    map[string]claude.SessionActivity
+		projects    []scanner.Project
+	)
+	r.time("parse", "parse sessions", func() int {
+		sessions, _ = claude.ParseAllSessionMeta(claudeHome)
+		return len(sessions)
+	})
+	r.time("parse", "parse facets", func() int {
+		facets, _ = claude.ParseAllFacets(claudeHome)
+		return len(facets)
+	})
+	r.time("parse", "parse transcripts", func() int {
+		tasks, _ = claude.ParseAgentTasks(claudeHome)
+		return len(tasks)
+	})
+	r.time("parse", "parse context peaks", func() int {
+		peaks, _ = claude.ParseContextPeaks(claudeHome)
+		return len(peaks)
+	})
+	r.time("parse", "parse session activity", func() int {
+		activity, _ = claude.ParseSessionActivity(claudeHome)
+		return len(activity)
+	})
+	r.time("parse", "parse todos", func() int {
+		todos, _ = claude.ParseAllTodos(claudeHome)
+		return len(todos)
+	})
+	r.time("parse", "parse file history", func() int {
+		fileHistory, _ = claude.ParseAllFileHistory(claudeHome)
+		return len(fileHistory)
+	})
+	r.time("parse", "parse stats cache", func() int {
+		stats, _ = claude.ParseStatsCache(claudeHome)
+		return 0
+	})
+	r.time("parse", "discover projects", func() int {
+		projects, _ = scanner.DiscoverProjects(scanPaths)
+		return len(projects)
+	})
+
+	pricing := analyzer.DefaultPricing["sonnet"]
+	ratio := analyzer.NoCacheRatio()
+	if stats != nil {
+		ratio = analyzer.ComputeCacheRatio(*stats)
+	}
+	analyzers := []struct {
+		name string
+		fn   func()
+	}{
+		{"analyze velocity", func() { analyzer.AnalyzeVelocity(sessions, 0) }},
+		{"analyze weekday patterns", func() { analyzer.AnalyzeWeekdayPatterns(sessions, facets) }},
+		{"analyze session curve", func() { analyzer.AnalyzeSessionCurve(sessions, activity) }},
+		{"analyze efficiency", func() { analyzer.AnalyzeEfficiency(sessions) }},
+		{"analyze struggle", func() { analyzer.AnalyzeStruggle(sessions) }},
+		{"analyze satisfaction", func() { analyzer.AnalyzeSatisfaction(facets) }},
+		{"analyze facet coverage", func() { analyzer.AnalyzeFacetCoverage(sessions, facets) }},
+		{"analyze agents", func() { analyzer.AnalyzeAgents(tasks, nil) }},
+		{"analyze agent type drift", func() { analyzer.AnalyzeAgentTypeDrift(tasks) }},
+		{"analyze agent impact", func() { analyzer.AnalyzeAgentImpact(sessions, tasks, facets) }},
+		{"analyze subagent opportunity", func() { analyzer.AnalyzeSubagentOpportunity(sessions, tasks) }},
+		{"analyze commits", func() { analyzer.AnalyzeCommits(sessions) }},
+		{"analyze confidence", func() { analyzer.AnalyzeConfidence(sessions) }},
+		{"analyze resumes", func() { analyzer.AnalyzeResumePatterns(sessions, resumeGap) }},
+		{"analyze persistence", func() { analyzer.AnalyzeFrictionPersistence(facets, sessions, staleWeeks) }},
+		{"analyze friction by language", func() { analyzer.AnalyzeFrictionByLanguage(sessions, facets) }},
+		{"analyze outcomes", func() { analyzer.AnalyzeOutcomes(sessions, facets, pricing, ratio) }},
+		{"analyze rework", func() { analyzer.AnalyzeRework(sessions, facets, pricing, ratio) }},
+		{"analyze cost drivers", func() { analyzer.AnalyzeCostDrivers(sessions, tasks, pricing, ratio) }},
+		{"analyze first prompts", func() { analyzer.AnalyzeFirstPromptLength(sessions, facets, nil) }},
+		{"analyze message efficiency", func() { analyzer.AnalyzeMessageEfficiency(sessions, facets) }},
+		{"analyze context pressure", func() { analyzer.AnalyzeContextPressure(sessions, peaks, contextWindow) }},
+		{"analyze planning", func() { analyzer.AnalyzePlanning(todos, fileHistory) }},
+		{"analyze models", func() { analyzer.AnalyzeModelsFromSessions(sessions) }},
+		{"analyze conversations", func() { _, _ = analyzer.AnalyzeConversations(claudeHome) }},
+	}
+	for _, a := range analyzers {
+		r.time("analyze", a.name, func() int {
+			a.fn()
+			return 0
+		})
+	}
+	return r
+}
+
+func renderBench(r *benchRecorder) {
+	fmt.Println(output.Section("Benchmark"))
+	fmt.Println()
+
+	total := r.total()
+	tbl := output.NewTable("Phase", "Time", "Share", "Items")
+	for _, p := range r.phases {
+		share := ""
+		if total > 0 {
+			share = fmt.Sprintf("%.0f%%", 100*float64(p.Duration)/float64(total))
+		}
+		items := ""
+		if p.Items > 0 {
+			items = fmt.Sprintf("%d", p.Items)
+		}
+		tbl.AddRow(p.Name, formatBenchDuration(p.Duration), share, items)
+	}
+	tbl.Print()
+	fmt.Println()
+	fmt.Printf(" %s %s\n", output.StyleLabel.Render("Total"), output.StyleBold.Render(formatBenchDuration(total)))
+}
+
+// formatBenchDuration renders d in seconds from one second up and in
+// milliseconds below, e.g. "1.24s" or "3.1ms".
+func formatBenchDuration(d time.Duration) string {
+	if d >= time.Second {
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBenchCmd_Hidden(t *testing.T) {
+	if !benchCmd.Hidden {
+		t.Error("expected bench to be hidden from help")
+	}
+	if benchCmd.Flags().Lookup("cpuprofile") == nil {
+		t.Error("expected --cpuprofile flag to be registered on benchCmd")
+	}
+}
+
+func TestBenchPhases_RecordsEveryPhase(t *testing.T) {
+	home := t.TempDir()
+	r := benchPhases(home, nil, 15*time.Minute, 3, 200_000)
+
+	if len(r.phases) == 0 {
+		t.Fatal("expected phases to be recorded")
+	}
+	if r.phases[0].Name != "parse sessions" {
+		t.Errorf("first phase = %q, want parse sessions", r.phases[0].Name)
+	}
+	var total time.Duration
+	for _, p := range r.phases {
+		if p.Group != "parse" && p.Group != "analyze" {
+			t.Errorf("phase %q has group %q", p.Name, p.Group)
+		}
+		total += p.Duration
+	}
+	if r.total() != total {
+		t.Errorf("total() = %v, want %v", r.total(), total)
+	}
+
+	entries, err := os.ReadDir(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected bench to leave the Claude home untouched, found %v", entries[0].Name())
+	}
+	if _, err := os.Stat(filepath.Join(home, "usage-data")); !os.IsNotExist(err) {
+		t.Errorf("expected no usage-data directory, stat err = %v", err)
+	}
+}
+
+func TestFormatBenchDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{1240 * time.Millisecond, "1.24s"},
+		{3100 * time.Microsecond, "3.1ms"},
+		{0, "0.0ms"},
+	}
+	for _, tt := range tests {
+		if got := formatBenchDuration(tt.d); got != tt.want {
+			t.Errorf("formatBenchDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
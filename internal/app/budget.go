@@ -0,0 +1,165 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var budgetFlagMonthly float64
+
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Show this month's estimated spend against the monthly cap",
+	Long: `Sum the estimated cost of sessions started this calendar month and compare
+it against the monthly cap (budget.monthly_usd in the config file).
+
+Shows spend vs cap, days remaining, and a projected month-end spend based on
+the recent daily rate. Early in the month the rate is taken over the last 7
+days, so one expensive day doesn't dominate the projection.
+
+Exits with a non-zero status when spend exceeds the cap, for use in scripts.
+
+Examples:
+  claudewatch budget
+  claudewatch budget --monthly 200
+  claudewatch budget --json`,
+	RunE: runBudget,
+}
+
+func init() {
+	budgetCmd.Flags().Float64Var(&budgetFlagMonthly, "monthly", 0, "Monthly cap in USD (overrides budget.monthly_usd; 0 for no cap)")
+	rootCmd.AddCommand(budgetCmd)
+}
+
+func runBudget(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	if budgetFlagMonthly < 0 {
+		return fmt.Errorf("--monthly must be non-negative, got %.2f", budgetFlagMonthly)
+	}
+	capUSD := cfg.Budget.MonthlyUSD
+	if cmd.Flags().Changed("monthly") {
+		capUSD = budgetFlagMonthly
+	}
+
+	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
+	if err != nil {
+		return fmt.Errorf("parsing session meta: %w", err)
+	}
+
+	pricing := analyzer.DefaultPricing["sonnet"]
+	cacheRatio := analyzer.NoCacheRatio()
+	if sc, scErr := claude.ParseStatsCache(cfg.ClaudeHome); scErr == nil && sc != nil {
+		cacheRatio = analyzer.ComputeCacheRatio(*sc)
+	}
+
+	budget := analyzer.AnalyzeMonthlyBudget(sessions, capUSD, time.Now(), pricing, cacheRatio)
+
+	if flagJSON {
+		if err := writeJSON(budget); err != nil {
+			return err
+		}
+	} else {
+		renderBudget(budget)
+	}
+
+	if budget.OverBudget {
+		return fmt.Errorf("monthly budget exceeded: $%.2f spent of $%.2f cap", budget.SpentUSD, budget.CapUSD)
+	}
+	return nil
+}
+
+func renderBudget(b analyzer.MonthlyBudget) {
+	fmt.Println(output.Section(fmt.Sprintf("Monthly Budget (%s)", b.Month.Format("January 2006"))))
+
+	col := func(label, value string) {
+		// Values can exceed StyleValue's fixed width, so only bold them.
+		fmt.Printf(" %s %s\n", output.StyleLabel.Render(label), output.StyleBold.Render(value))
+	}
+
+	if b.CapUSD > 0 {
+		col("Spent", fmt.Sprintf("$%.2f of $%.2f", b.SpentUSD, b.CapUSD))
+		fmt.Printf(" %s %s\n", output.StyleLabel.Render(""), output.UsageBar(b.SpentUSD, b.CapUSD, 30))
+	} else {
+		col("Spent", fmt.Sprintf("$%.2f", b.SpentUSD))
+	}
+	col("Sessions", fmt.Sprintf("%d", b.Sessions))
+	col("Days remaining", fmt.Sprintf("%d of %d", b.DaysRemaining, b.DaysInMonth))
+	col("Daily rate", fmt.Sprintf("$%.2f", b.DailyRateUSD))
+
+	projected := fmt.Sprintf("$%.2f", b.ProjectedUSD)
+	if b.CapUSD > 0 && b.ProjectedUSD > b.CapUSD {
+		projected = output.StyleWarning.Render(projected + " (over cap)")
+	}
+	col("Projected month-end", projected)
+
+	fmt.Println()
+	switch {
+	case b.CapUSD == 0:
+		fmt.Printf(" %s\n", output.StyleMuted.Render("No monthly cap set. Add budget.monthly_usd to your config or pass --monthly."))
+	case b.OverBudget:
+		fmt.Printf(" %s\n", output.StyleError.Render(fmt.Sprintf("Over budget by $%.2f.", b.SpentUSD-b.CapUSD)))
+	default:
+		fmt.Printf(" %s\n", output.StyleMuted.Render(fmt.Sprintf("$%.2f left this month.", b.CapUSD-b.SpentUSD)))
+	}
+	fmt.Println()
+
+	renderCostForecast(b.Forecast)
+}
+
+// renderCostForecast prints the trend-based week and month-end forecast.
+func renderCostForecast(f analyzer.CostForecast) {
+	title := "Forecast"
+	if f.LowConfidence {
+		title += " (low confidence)"
+	}
+	fmt.Println(output.Section(title))
+
+	projection := func(label string, p analyzer.SpendProjection) {
+		fmt.Printf(" %s %s %s\n", output.StyleLabel.Render(label),
+			output.StyleBold.Render(fmt.Sprintf("$%.2f", p.ProjectedUSD)),
+			output.StyleMuted.Render(fmt.Sprintf("($%.2f–$%.2f)", p.LowUSD, p.HighUSD)))
+	}
+	projection("End of week", f.Week)
+	projection("End of month", f.Month)
+
+	trend := "flat"
+	switch {
+	case f.LowConfidence:
+		trend = "not fitted"
+	case f.TrendUSDPerDay > 0.005:
+		trend = fmt.Sprintf("rising $%.2f/day", f.TrendUSDPerDay)
+	case f.TrendUSDPerDay < -0.005:
+		trend = fmt.Sprintf("falling $%.2f/day", -f.TrendUSDPerDay)
+	}
+	fmt.Printf(" %s %s\n", output.StyleLabel.Render("Daily trend"), output.StyleBold.Render(trend))
+	fmt.Println()
+
+	// The over-cap warning belongs to the budget's own projection above; the
+	// trend forecast is labeled as such so the two month-end figures don't
+	// read as one.
+	summary := fmt.Sprintf("On the daily trend you'd spend $%.2f this month", f.Month.ProjectedUSD)
+	if f.BudgetUSD > 0 {
+		summary += fmt.Sprintf(" (budget $%.2f)", f.BudgetUSD)
+	}
+	fmt.Printf(" %s\n", output.StyleMuted.Render(summary+"."))
+	switch {
+	case f.WindowDays == 0:
+		fmt.Printf(" %s\n", output.StyleMuted.Render("No complete days of history yet; the forecast is spend so far."))
+	case f.LowConfidence:
+		fmt.Printf(" %s\n", output.StyleMuted.Render(fmt.Sprintf("Only %d active days in the last %d; the range is widened.", f.ActiveDays, f.WindowDays)))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+)
+
+func TestBudgetFlags_Registered(t *testing.T) {
+	f := budgetCmd.Flags().Lookup("monthly")
+	if f == nil {
+		t.Fatal("expected --monthly flag to be registered on budgetCmd")
+	}
+	if f.DefValue != "0" {
+		t.Errorf("expected --monthly default %q, got %q", "0", f.DefValue)
+	}
+}
+
+func TestRenderBudget_NoPanic(t *testing.T) {
+	month := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, b := range []analyzer.MonthlyBudget{
+		{Month: month, DaysInMonth: 31, DaysRemaining: 10},
+		{Month: month, CapUSD: 50, SpentUSD: 20, ProjectedUSD: 70, DaysInMonth: 31, DaysRemaining: 10},
+		{Month: month, CapUSD: 50, SpentUSD: 60, ProjectedUSD: 90, OverBudget: true, DaysInMonth: 31},
+	} {
+		renderBudget(b)
+	}
+}
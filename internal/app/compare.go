@@ -1,16 +1,13 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
-	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -40,7 +37,7 @@ func init() {
 }
 
 func runCompare(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -125,12 +122,10 @@ func runCompare(cmd *cobra.Command, args []string) error {
 	)
 
 	if flagJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(report)
+		return writeJSON(report)
 	}
 
-	renderCompare(report)
+	renderCompare(anonymized(report))
 	return nil
 }
 
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect claudewatch configuration",
+	Long: `Inspect the configuration claudewatch resolves from its config file,
+built-in defaults, and the active profile.
+
+Subcommands: show`,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+}
+
+// config show
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration",
+	Long: `Print the effective configuration after defaults are applied and the
+active profile (if any) is merged over the base config.
+
+Use --profile to preview a profile without switching to it:
+
+  claudewatch config show --profile ci`,
+	RunE: runConfigShow,
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	cfg, err := loadConfigUnsynced()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagJSON {
+		return writeJSON(cfg)
+	}
+
+	entries, err := flattenConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("rendering config: %w", err)
+	}
+
+	title := "Effective Config"
+	if cfg.Profile != "" {
+		title = fmt.Sprintf("Effective Config (profile: %s)", cfg.Profile)
+	}
+	fmt.Println(output.Section(title))
+
+	width := 0
+	for _, e := range entries {
+		if len(e.key) > width {
+			width = len(e.key)
+		}
+	}
+	for _, e := range entries {
+		// StyleLabel/StyleValue have fixed widths that would wrap long keys.
+		fmt.Printf(" %s  %s\n",
+			output.StyleMuted.Render(fmt.Sprintf("%-*s", width, e.key)),
+			output.StyleBold.Render(e.value))
+	}
+	fmt.Println()
+	return nil
+}
+
+// configEntry is one dotted key and its rendered value.
+type configEntry struct {
+	key   string
+	value string
+}
+
+// flattenConfig renders v as sorted dotted-key entries using its JSON field
+// names, which match the config file keys.
+func flattenConfig(v any) ([]configEntry, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	var entries []configEntry
+	var walk func(prefix string, node any)
+	walk = func(prefix string, node any) {
+		m, ok := node.(map[string]any)
+		if !ok || len(m) == 0 {
+			entries = append(entries, configEntry{key: prefix, value: formatConfigValue(node)})
+			return
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			walk(key, m[k])
+		}
+	}
+	walk("", tree)
+	return entries, nil
+}
+
+// formatConfigValue renders a decoded JSON leaf for display.
+func formatConfigValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return val
+	case []any:
+		parts := make([]string, len(val))
+		for i, item := range val {
+			parts[i] = formatConfigValue(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case map[string]any:
+		return "{}"
+	default:
+		return fmt.Sprint(val)
+	}
+}
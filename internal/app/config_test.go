@@ -0,0 +1,52 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+func TestProfileFlag_Registered(t *testing.T) {
+	f := rootCmd.PersistentFlags().Lookup("profile")
+	if f == nil {
+		t.Fatal("expected --profile persistent flag on rootCmd")
+	}
+	if f.DefValue != "" {
+		t.Errorf("expected --profile default to be empty, got %q", f.DefValue)
+	}
+}
+
+func TestFlattenConfig(t *testing.T) {
+	cfg := &config.Config{
+		ScanPaths:       []string{"/a", "/b"},
+		ActiveThreshold: 7,
+		Weights:         config.Weights{HookAdoption: 2.5},
+		Profile:         "ci",
+	}
+
+	entries, err := flattenConfig(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]string, len(entries))
+	for i, e := range entries {
+		got[e.key] = e.value
+		if i > 0 && entries[i-1].key > e.key {
+			t.Errorf("entries not sorted: %q before %q", entries[i-1].key, e.key)
+		}
+	}
+
+	want := map[string]string{
+		"scan_paths":            "[/a, /b]",
+		"active_threshold":      "7",
+		"weights.hook_adoption": "2.5",
+		"profile":               "ci",
+		"custom_metrics":        "-",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+}
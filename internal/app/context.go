@@ -108,9 +108,7 @@ func runContext(cmd *cobra.Command, args []string) error {
 
 	// Render output
 	if flagJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(result)
+		return writeJSON(result)
 	}
 
 	// Print warnings if some sources failed
@@ -1,14 +1,11 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
-	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -73,7 +70,7 @@ func runCorrelate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -137,10 +134,10 @@ func runCorrelate(cmd *cobra.Command, args []string) error {
 	}
 
 	if flagJSON {
-		return json.NewEncoder(os.Stdout).Encode(report)
+		return writeJSONCompact(report)
 	}
 
-	renderCorrelate(report)
+	renderCorrelate(anonymized(report))
 	return nil
 }
 
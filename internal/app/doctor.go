@@ -1,7 +1,6 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -50,7 +49,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		output.SetNoColor(true)
 	}
 
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -63,6 +62,9 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// 2. Session data — at least 1 session-meta file exists.
 	checks = append(checks, checkSessionData(cfg.ClaudeHome))
 
+	// 2b. Transcripts — every session transcript is readable.
+	checks = append(checks, checkTranscripts(cfg.ClaudeHome))
+
 	// 3. Stats cache — stats-cache.json exists and parses.
 	checks = append(checks, checkStatsCache(cfg.ClaudeHome))
 
@@ -118,9 +120,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 			PassedCount: passed,
 			TotalCount:  len(checks),
 		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(out)
+		return writeJSON(out)
 	}
 
 	// Render styled output.
@@ -203,6 +203,32 @@ func checkSessionData(claudeHome string) doctorCheck {
 	}
 }
 
+// checkTranscripts verifies that every session transcript could be read.
+// Unreadable files are skipped by the parsers, which silently lowers agent
+// counts, so they are worth surfacing here.
+func checkTranscripts(claudeHome string) doctorCheck {
+	_, report, err := claude.ParseSessionTranscriptsReport(claudeHome)
+	if err != nil {
+		return doctorCheck{
+			Name:    "Session transcripts",
+			Passed:  false,
+			Message: fmt.Sprintf("error reading transcripts: %v", err),
+		}
+	}
+	if n := len(report.Skipped); n > 0 {
+		return doctorCheck{
+			Name:    "Session transcripts",
+			Passed:  false,
+			Message: fmt.Sprintf("%d of %d transcripts unreadable, e.g. %s (run with -v for details)", n, report.Transcripts, report.Skipped[0].Path),
+		}
+	}
+	return doctorCheck{
+		Name:    "Session transcripts",
+		Passed:  true,
+		Message: fmt.Sprintf("%d transcripts readable", report.Transcripts),
+	}
+}
+
 // checkStatsCache verifies that stats-cache.json exists and parses successfully.
 func checkStatsCache(claudeHome string) doctorCheck {
 	sc, err := claude.ParseStatsCache(claudeHome)
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dumpDays        int
+	dumpProject     string
+	dumpProjectPath string
+)
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Stream every analyzer's raw result as newline-delimited JSON",
+	Long: `Run every analyzer and write each result as one line of JSON, tagged
+with the analyzer's name:
+
+  {"analyzer":"velocity","result":{...}}
+
+The stream is a superset of 'claudewatch metrics --json', adding friction,
+friction velocity and language breakdowns, tool usage, and CLAUDE.md
+effectiveness, estimated ROI, and staleness, and is meant for data pipelines:
+
+  claudewatch dump --days 90 | jq -c 'select(.analyzer == "velocity")'
+
+Analyzers whose data is missing still emit their empty result, so every
+line is present on every run. --days and --project narrow the sessions
+analyzed the same way they do for metrics.`,
+	RunE: runDump,
+}
+
+func init() {
+	dumpCmd.Flags().IntVar(&dumpDays, "days", 30, "Number of days to analyze (0 = all time)")
+	dumpCmd.Flags().StringVar(&dumpProject, "project", "", "Filter to the project matching this name (fuzzy)")
+	dumpCmd.Flags().StringVar(&dumpProjectPath, "project-path", "", "Filter to the project at exactly this path")
+	dumpCmd.MarkFlagsMutuallyExclusive("project", "project-path")
+	rootCmd.AddCommand(dumpCmd)
+}
+
+// dumpRecord is one line of dump output.
+type dumpRecord struct {
+	Analyzer string `json:"analyzer"`
+	Result   any    `json:"result"`
+}
+
+func runDump(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	records, err := dumpAnalyzers(cfg, dumpDays, dumpProject, dumpProjectPath)
+	if err != nil {
+		return err
+	}
+	return writeDump(os.Stdout, records)
+}
+
+// dumpAnalyzers loads the session data, narrows it to the project and the
+// last days days, and runs every analyzer over it.
+func dumpAnalyzers(cfg *config.Config, days int, projectName, projectPath string) ([]dumpRecord, error) {
+	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
+	if err != nil {
+		return nil, fmt.Errorf("parsing session meta: %w", err)
+	}
+	project, err := resolveProjectFilter(projectName, projectPath, sessions)
+	if err != nil {
+		return nil, err
+	}
+	if project != "" {
+		sessions = filterSessionsByProject(sessions, project)
+	}
+	sessions = analyzer.FilterSessionsByDays(sessions, days)
+
+	keep := make(map[string]bool, len(sessions))
+	for _, s := range sessions {
+		keep[s.SessionID] = true
+	}
+
+	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
+	if err != nil {
+		return nil, fmt.Errorf("parsing facets: %w", err)
+	}
+	facets = filterFacetsBySessionIDs(facets, sessions)
+
+	// The rest is optional: analyzers without their data emit empty results.
+	agentSpans, _ := claude.ParseSessionTranscripts(cfg.ClaudeHome)
+	agentSpans = filterAgentSpansBySessionIDs(agentSpans, sessions)
+	agentTasks := claude.AgentTasksFromSpans(agentSpans)
+	contextPeaks, _ := claude.ParseContextPeaks(cfg.ClaudeHome)
+	activity, _ := claude.ParseSessionActivity(cfg.ClaudeHome)
+	todos, _ := claude.ParseAllTodos(cfg.ClaudeHome)
+	todos = slices.DeleteFunc(todos, func(t claude.SessionTodos) bool { return !keep[t.SessionID] })
+	fileHistory, _ := claude.ParseAllFileHistory(cfg.ClaudeHome)
+	fileHistory = slices.DeleteFunc(fileHistory, func(f claude.FileHistorySession) bool { return !keep[f.SessionID] })
+	projects, _ := scanner.DiscoverProjects(cfg.ScanPaths)
+	if project != "" {
+		projects = slices.DeleteFunc(projects, func(p scanner.Project) bool { return claude.NormalizePath(p.Path) != project })
+	}
+	var conversations analyzer.ConversationAnalysis
+	if ca, err := analyzer.AnalyzeConversations(cfg.ClaudeHome); err == nil {
+		conversations = analyzer.FilterConversations(ca, keep)
+	}
+
+	pricing := analyzer.DefaultPricing["sonnet"]
+	cacheRatio := analyzer.NoCacheRatio()
+	if statsCache, err := claude.ParseStatsCache(cfg.ClaudeHome); err == nil && statsCache != nil {
+		cacheRatio = analyzer.ComputeCacheRatio(*statsCache)
+	}
+
+	tokens := computeTokenUsage(sessions)
+	tokens.ContextPressure = analyzer.AnalyzeContextPressure(sessions, contextPeaks, cfg.ContextWindowTokens)
+	outcomes := analyzer.AnalyzeOutcomes(sessions, facets, pricing, cacheRatio)
+	outcomes.Drivers = analyzer.AnalyzeCostDrivers(sessions, agentTasks, pricing, cacheRatio)
+	effectiveness := []analyzer.EffectivenessResult{}
+	claudeMDChanges := detectClaudeMDChanges(projects)
+	if len(claudeMDChanges) > 0 {
+		effectiveness = analyzer.EffectivenessTimeline(claudeMDChanges, sessions, facets, pricing, cacheRatio)
+	}
+	var missingClaudeMD []string
+	for _, p := range projects {
+		if !p.HasClaudeMD {
+			missingClaudeMD = append(missingClaudeMD, p.Path)
+		}
+	}
+
+	// Names follow the metrics --json keys where the two overlap.
+	return []dumpRecord{
+		{"velocity", analyzer.AnalyzeVelocity(sessions, 0)},
+		{"weekday_patterns", analyzer.AnalyzeWeekdayPatterns(sessions, facets)},
+		{"session_curve", analyzer.AnalyzeSessionCurve(sessions, activity)},
+		{"resumes", analyzer.AnalyzeResumePatterns(sessions, time.Duration(cfg.ResumeGapMinutes)*time.Minute)},
+		{"efficiency", analyzer.AnalyzeEfficiency(sessions)},
+		{"tool_errors", analyzer.AnalyzeToolErrorRates(sessions)},
+		{"struggle", analyzer.AnalyzeStruggle(sessions)},
+		{"tool_usage", analyzer.AnalyzeToolUsage(sessions, projects)},
+		{"satisfaction", analyzer.AnalyzeSatisfaction(facets)},
+		{"facet_coverage", analyzer.AnalyzeFacetCoverage(sessions, facets)},
+		{"agents", analyzer.AnalyzeAgents(agentTasks, cfg.AgentAliases)},
+		{"agent_type_drift", analyzer.AnalyzeAgentTypeDrift(agentTasks)},
+		{"agent_impact", analyzer.AnalyzeAgentImpact(sessions, agentTasks, facets)},
+		{"subagent_opportunity", analyzer.AnalyzeSubagentOpportunity(sessions, agentTasks)},
+		{"agent_results", analyzer.AnalyzeAgentResultUsage(agentSpans, time.Duration(cfg.AgentResultWindowMinutes)*time.Minute, pricing)},
+		{"tokens", tokens},
+		{"models", analyzer.AnalyzeModelsFromSessions(sessions)},
+		{"commits", analyzer.AnalyzeCommits(sessions)},
+		{"conversation", conversations},
+		{"first_prompt", analyzer.AnalyzeFirstPromptLength(sessions, facets, cfg.FirstPromptBuckets)},
+		{"message_efficiency", analyzer.AnalyzeMessageEfficiency(sessions, facets)},
+		{"confidence", analyzer.AnalyzeConfidence(sessions)},
+		{"friction", analyzer.AnalyzeFriction(facets, cfg.Friction.RecurringThreshold)},
+		{"friction_trends", analyzer.AnalyzeFrictionPersistence(facets, sessions, cfg.Friction.StaleWeeks)},
+		{"friction_velocity", analyzer.AnalyzeFrictionVelocity(facets, sessions)},
+		{"friction_by_language", analyzer.AnalyzeFrictionByLanguage(sessions, facets)},
+		{"cost_per_outcome", outcomes},
+		{"effectiveness", effectiveness},
+		{"claudemd", analyzer.AnalyzeClaudeMDEffectiveness(projects, facets)},
+		{"claudemd_roi", analyzer.EstimateClaudeMDROI(claudeMDChanges, missingClaudeMD, sessions, facets, pricing, cacheRatio)},
+		{"claudemd_staleness", analyzer.AnalyzeClaudeMDStaleness(projects, sessions, fileHistory, cfg.ClaudeMDStaleDays)},
+		{"planning", analyzer.AnalyzePlanning(todos, fileHistory)},
+	}, nil
+}
+
+// writeDump writes each record to w as one line of JSON.
+func writeDump(w io.Writer, records []dumpRecord) error {
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("encoding %s: %w", r.Analyzer, err)
+		}
+	}
+	return nil
+}
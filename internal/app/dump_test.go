@@ -0,0 +1,31 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDump(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeDump(&buf, []dumpRecord{
+		{"velocity", map[string]int{"sessions": 2}},
+		{"effectiveness", []string{}},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2, "one line per record")
+	var first struct {
+		Analyzer string         `json:"analyzer"`
+		Result   map[string]int `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "velocity", first.Analyzer)
+	assert.Equal(t, 2, first.Result["sessions"])
+	assert.Equal(t, `{"analyzer":"effectiveness","result":[]}`, lines[1])
+}
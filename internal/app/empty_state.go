@@ -0,0 +1,33 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+// printNoSessions explains an empty result in place of empty sections. When
+// no sessions were found at all (total is 0), the likely cause is a new
+// install or claude_home pointing at the wrong directory, so it says where
+// claudewatch looked and how to change that. Otherwise the filters matched
+// nothing, and scope describes them (e.g. "in the last 30 days").
+func printNoSessions(w io.Writer, cfg *config.Config, total int, scope string) {
+	if total > 0 {
+		_, _ = fmt.Fprintf(w, " No sessions %s (%d in total).\n", scope, total)
+		_, _ = fmt.Fprintf(w, " %s\n", output.StyleMuted.Render("Widen the window or drop filters to include more."))
+		return
+	}
+
+	configPath := flagConfig
+	if configPath == "" {
+		configPath = filepath.Join(config.ConfigDir(), config.DefaultConfigFile)
+	}
+	_, _ = fmt.Fprintln(w, " No Claude Code sessions found.")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, " claudewatch reads session data from %s.\n", filepath.Join(cfg.ClaudeHome, "projects"))
+	_, _ = fmt.Fprintf(w, " %s\n", output.StyleMuted.Render(fmt.Sprintf("If Claude Code keeps its data elsewhere, set claude_home in %s.", configPath)))
+	_, _ = fmt.Fprintf(w, " %s\n", output.StyleMuted.Render("Run 'claudewatch doctor' to check your setup."))
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+func TestPrintNoSessions_NoneFound(t *testing.T) {
+	output.SetNoColor(true)
+	defer output.SetNoColor(false)
+
+	home := filepath.Join(t.TempDir(), ".claude")
+	var buf bytes.Buffer
+	printNoSessions(&buf, &config.Config{ClaudeHome: home}, 0, "in the last 30 days")
+
+	got := buf.String()
+	for _, want := range []string{
+		"No Claude Code sessions found",
+		filepath.Join(home, "projects"),
+		"claude_home",
+		"claudewatch doctor",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to mention %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestPrintNoSessions_FilteredOut(t *testing.T) {
+	output.SetNoColor(true)
+	defer output.SetNoColor(false)
+
+	var buf bytes.Buffer
+	printNoSessions(&buf, &config.Config{ClaudeHome: t.TempDir()}, 12, "in the last 7 days")
+
+	got := buf.String()
+	if !strings.Contains(got, "No sessions in the last 7 days (12 in total)") {
+		t.Errorf("expected the filtered-out message, got:\n%s", got)
+	}
+	if strings.Contains(got, "claudewatch doctor") {
+		t.Errorf("expected no setup guidance when sessions exist, got:\n%s", got)
+	}
+}
@@ -1,9 +1,7 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
@@ -46,7 +44,7 @@ func init() {
 }
 
 func runExperimentStart(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -84,7 +82,7 @@ func init() {
 }
 
 func runExperimentStop(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -136,7 +134,7 @@ func init() {
 }
 
 func runExperimentTag(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -192,7 +190,7 @@ func init() {
 }
 
 func runExperimentReport(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -262,12 +260,10 @@ func runExperimentReport(cmd *cobra.Command, args []string) error {
 	report := analyzer.AnalyzeExperiment(*exp, filteredSessions, filteredFacets, assignments, pricing, ratio)
 
 	if flagJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(report)
+		return writeJSON(report)
 	}
 
-	renderExperimentReport(report)
+	renderExperimentReport(anonymized(report))
 	return nil
 }
 
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/export"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +18,7 @@ var (
 	exportPerModel      bool
 	exportSAWComparison bool
 	exportDetailed      bool
+	exportNullValue     string
 )
 
 var exportCmd = &cobra.Command{
@@ -37,6 +37,10 @@ Supported formats:
 
 Output to stdout by default, or specify --output to write to a file.
 
+Averages and ratios with nothing to compute from, such as cost per commit
+without commits, are written as 0 by default. --null-value writes them as
+empty, null, or nan instead, so they can't be mistaken for a real zero.
+
 Granular reporting options:
   --per-project      One row/object per project instead of aggregate
   --per-day          Daily time series over the window
@@ -49,7 +53,8 @@ Examples:
   claudewatch export --per-day --days 30        # Daily time series
   claudewatch export --saw-comparison           # SAW vs non-SAW comparison
   claudewatch export --detailed --format csv    # Session-level CSV export
-  claudewatch export --per-model --days 7       # Last 7 days by model`,
+  claudewatch export --per-model --days 7       # Last 7 days by model
+  claudewatch export --format csv --null-value empty  # Blank cells for missing data`,
 	RunE: runExport,
 }
 
@@ -63,11 +68,12 @@ func init() {
 	exportCmd.Flags().BoolVar(&exportPerModel, "per-model", false, "Split metrics by model")
 	exportCmd.Flags().BoolVar(&exportSAWComparison, "saw-comparison", false, "Compare SAW vs non-SAW sessions")
 	exportCmd.Flags().BoolVar(&exportDetailed, "detailed", false, "Output session-level rows (not aggregated)")
+	exportCmd.Flags().StringVar(&exportNullValue, "null-value", "", "Write metrics without data as empty, null, or nan instead of 0")
 	rootCmd.AddCommand(exportCmd)
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -77,11 +83,24 @@ func runExport(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	nullValue, err := export.ParseNullValue(exportNullValue)
+	if err != nil {
+		return err
+	}
+
 	// Get the appropriate exporter
 	exporter, err := export.GetExporter(exportFormat)
 	if err != nil {
 		return err
 	}
+	switch exporter.(type) {
+	case *export.JSONExporter:
+		exporter = &export.JSONExporter{Compact: flagCompactJSON, NullValue: nullValue}
+	case *export.CSVExporter:
+		exporter = &export.CSVExporter{NullValue: nullValue}
+	case *export.PrometheusExporter:
+		exporter = &export.PrometheusExporter{NullValue: nullValue}
+	}
 
 	var output []byte
 
@@ -1,8 +1,6 @@
 package app
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,16 +10,19 @@ import (
 
 	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/fixer"
+	"github.com/blackwell-systems/claudewatch/internal/guard"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
 )
 
 var (
-	fixFlagDryRun bool
-	fixFlagAll    bool
-	fixFlagJSON   bool
-	fixFlagAI     bool
-	fixFlagModel  string
+	fixFlagDryRun        bool
+	fixFlagApply         bool
+	fixFlagAll           bool
+	fixFlagJSON          bool
+	fixFlagAI            bool
+	fixFlagModel         string
+	fixFlagMinConfidence float64
 )
 
 var fixCmd = &cobra.Command{
@@ -32,22 +33,28 @@ additions based on observed friction patterns, missing sections, recurring
 corrections, and wasted sessions.
 
 The fix command never removes existing content — it only proposes additions.
-By default it presents proposed changes for confirmation before writing.`,
+By default it only previews them, with the reason and confidence for each.
+--apply appends them to CLAUDE.md, first copying the current file to
+CLAUDE.md.bak. --min-confidence leaves out additions backed by little data.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runFix,
 }
 
 func init() {
+	fixCmd.Flags().BoolVar(&fixFlagApply, "apply", false, "Write the additions to CLAUDE.md, keeping a CLAUDE.md.bak backup")
 	fixCmd.Flags().BoolVar(&fixFlagDryRun, "dry-run", false, "Print proposed additions without applying")
+	_ = fixCmd.Flags().MarkDeprecated("dry-run", "previewing is now the default; pass --apply to write changes")
+	fixCmd.Flags().Float64Var(&fixFlagMinConfidence, "min-confidence", 0, "Leave out additions with confidence below this (0-1)")
 	fixCmd.Flags().BoolVar(&fixFlagAll, "all", false, "Fix all projects with score < 50")
 	fixCmd.Flags().BoolVar(&fixFlagJSON, "json", false, "Output proposed changes as JSON")
 	fixCmd.Flags().BoolVar(&fixFlagAI, "ai", false, "Use Claude API for project-specific CLAUDE.md generation")
 	fixCmd.Flags().StringVar(&fixFlagModel, "model", "claude-sonnet-4-6", "Claude model to use for AI generation")
+	fixCmd.MarkFlagsMutuallyExclusive("apply", "dry-run")
 	rootCmd.AddCommand(fixCmd)
 }
 
 func runFix(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -56,6 +63,16 @@ func runFix(cmd *cobra.Command, args []string) error {
 		output.SetNoColor(true)
 	}
 
+	if fixFlagMinConfidence < 0 || fixFlagMinConfidence > 1 {
+		return fmt.Errorf("--min-confidence must be between 0 and 1, got %g", fixFlagMinConfidence)
+	}
+
+	if fixFlagAI {
+		if err := guard.Network("fix --ai"); err != nil {
+			return err
+		}
+	}
+
 	// Discover all projects.
 	projects, err := scanner.DiscoverProjects(cfg.ScanPaths)
 	if err != nil {
@@ -93,7 +110,7 @@ func runFix(cmd *cobra.Command, args []string) error {
 	// Process each target project.
 	for _, target := range targets {
 		if err := fixProject(target, cfg); err != nil {
-			fmt.Fprintf(os.Stderr, " Error fixing %s: %v\n", target.Name, err)
+			fmt.Fprintf(os.Stderr, " Error fixing %s: %v\n", anonymizedName(target.Name), err)
 			continue
 		}
 
@@ -149,34 +166,49 @@ func fixProject(project scanner.Project, cfg *config.Config) error {
 		return fmt.Errorf("generating fix: %w", err)
 	}
 
-	if len(fix.Additions) == 0 {
-		fmt.Printf(" %s: no improvements identified.\n", project.Name)
+	proposed := len(fix.Additions)
+	fix.FilterByConfidence(fixFlagMinConfidence)
+	jsonOut := fixFlagJSON || flagJSON
+
+	if len(fix.Additions) == 0 && !jsonOut {
+		if proposed > 0 {
+			fmt.Printf(" %s: no improvements with confidence %.1f or higher (%d below).\n", anonymizedName(project.Name), fixFlagMinConfidence, proposed)
+			return nil
+		}
+		fmt.Printf(" %s: no improvements identified.\n", anonymizedName(project.Name))
 		return nil
 	}
 
+	var applied *fixer.ApplyResult
+	if fixFlagApply && len(fix.Additions) > 0 {
+		result, err := fixer.ApplyAdditions(fix)
+		if err != nil {
+			return err
+		}
+		applied = &result
+	}
+
 	// JSON output mode.
-	if fixFlagJSON || flagJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(fix)
+	if jsonOut {
+		return writeJSON(fixJSON{ProposedFix: fix, Applied: applied})
 	}
 
 	// Render terminal output.
-	renderFixProposal(fix, ctx)
+	renderFixProposal(anonymized(fix), anonymized(ctx))
 
-	// In dry-run mode, stop here.
-	if fixFlagDryRun {
-		return nil
-	}
-
-	// Ask for confirmation.
-	if !confirmApply() {
-		fmt.Println(" Changes not applied.")
+	if applied == nil {
+		fmt.Printf(" %s\n", output.StyleMuted.Render("Preview only. Run with --apply to write these to CLAUDE.md."))
 		return nil
 	}
+	renderApplied(anonymized(*applied))
+	return nil
+}
 
-	// Apply the changes.
-	return applyFix(fix, ctx)
+// fixJSON is the --json output of fix: the proposed fix and, with --apply,
+// where it was written.
+type fixJSON struct {
+	*fixer.ProposedFix
+	Applied *fixer.ApplyResult `json:"applied,omitempty"`
 }
 
 // resolveProject finds a project by name or path from the discovered projects list.
@@ -350,46 +382,13 @@ func wrapText(text string, maxWidth int) []string {
 	return lines
 }
 
-// confirmApply prompts the user for confirmation before applying changes.
-func confirmApply() bool {
-	fmt.Print("  Apply these changes? [y/n] ")
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return false
-	}
-	input = strings.TrimSpace(strings.ToLower(input))
-	return input == "y" || input == "yes"
-}
-
-// applyFix writes the proposed additions to the project's CLAUDE.md file.
-func applyFix(fix *fixer.ProposedFix, ctx *fixer.FixContext) error {
-	claudeMDPath := filepath.Join(fix.ProjectPath, "CLAUDE.md")
-	hasExisting := ctx.ExistingClaudeMD != ""
-
-	markdown := fixer.RenderMarkdown(fix, hasExisting)
-
-	if hasExisting {
-		// Append to existing file.
-		f, err := os.OpenFile(claudeMDPath, os.O_APPEND|os.O_WRONLY, 0644)
-		if err != nil {
-			return fmt.Errorf("opening CLAUDE.md for append: %w", err)
-		}
-		defer func() { _ = f.Close() }()
-
-		if _, err := f.WriteString(markdown); err != nil {
-			return fmt.Errorf("writing additions: %w", err)
-		}
-	} else {
-		// Create new file.
-		if err := os.WriteFile(claudeMDPath, []byte(markdown), 0644); err != nil {
-			return fmt.Errorf("creating CLAUDE.md: %w", err)
-		}
-	}
-
-	fmt.Printf("\n %s Changes written to %s\n",
+// renderApplied reports where --apply wrote the additions.
+func renderApplied(r fixer.ApplyResult) {
+	fmt.Printf(" %s %d additions written to %s\n",
 		output.StyleSuccess.Render("\u2713"),
-		claudeMDPath)
-
-	return nil
+		r.Additions,
+		r.Path)
+	if r.BackupPath != "" {
+		fmt.Printf(" %s\n", output.StyleMuted.Render("Previous version saved to "+r.BackupPath))
+	}
 }
@@ -1,33 +1,58 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/logging"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
 	"github.com/spf13/cobra"
 )
 
+var (
+	gapsSince string
+	gapsDays  int
+	gapsQuiet bool
+)
+
 var gapsCmd = &cobra.Command{
 	Use:   "gaps",
 	Short: "Surface friction patterns and missing configuration",
 	Long: `Analyze Claude Code usage data to identify gaps in configuration,
 recurring friction patterns, missing hooks, unused skills, and
-project-specific friction.`,
+project-specific friction.
+
+By default all historical sessions are analyzed. Use --since or --days to
+restrict the analysis to a recent window; projects with no sessions in the
+window do not generate CLAUDE.md gaps.
+
+The report ends with next steps: the claudewatch commands that act on the
+most severe gaps found. --quiet prints only the gap list.
+
+Examples:
+  claudewatch gaps                      # all-time analysis
+  claudewatch gaps --days 30            # last 30 days only
+  claudewatch gaps --since 2026-01-01   # sessions on or after a date`,
 	RunE: runGaps,
 }
 
 func init() {
 	gapsCmd.Flags().BoolVar(&flagJSON, "json", false, "Output as JSON")
+	gapsCmd.Flags().StringVar(&gapsSince, "since", "", "Only consider sessions on or after this date (YYYY-MM-DD)")
+	gapsCmd.Flags().IntVar(&gapsDays, "days", 0, "Only consider sessions from the last N days (0 = all time)")
+	gapsCmd.Flags().BoolVar(&gapsQuiet, "quiet", false, "Print only the gap list, without summaries or next steps")
+	gapsCmd.MarkFlagsMutuallyExclusive("since", "days")
 	rootCmd.AddCommand(gapsCmd)
 }
 
@@ -42,16 +67,31 @@ type gap struct {
 
 // gapsOutput is the JSON-serializable output for the gaps command.
 type gapsOutput struct {
-	Gaps      []gap                    `json:"gaps"`
-	Friction  analyzer.FrictionSummary `json:"friction"`
-	GapCount  int                      `json:"gap_count"`
-	Critical  int                      `json:"critical"`
-	Warnings  int                      `json:"warnings"`
-	InfoCount int                      `json:"info"`
+	Since           string                   `json:"since,omitempty"`
+	Gaps            []gap                    `json:"gaps"`
+	Friction        analyzer.FrictionSummary `json:"friction"`
+	ProjectFriction []ProjectFrictionStat    `json:"project_friction"`
+	// FrictionByLanguage is informational and never produces gaps.
+	FrictionByLanguage analyzer.FrictionByLanguage `json:"friction_by_language"`
+	FrictionVelocity   analyzer.FrictionVelocity   `json:"friction_velocity"`
+	GapCount           int                         `json:"gap_count"`
+	Critical           int                         `json:"critical"`
+	Warnings           int                         `json:"warnings"`
+	InfoCount          int                         `json:"info"`
+}
+
+// ProjectFrictionStat is one project's friction rate over its sessions with
+// facet data, whether or not it crossed the project_friction gap threshold.
+type ProjectFrictionStat struct {
+	Project            string  `json:"project"`
+	Name               string  `json:"name"`
+	FrictionEvents     int     `json:"friction_events"`
+	Sessions           int     `json:"sessions"`
+	FrictionPerSession float64 `json:"friction_per_session"`
 }
 
 func runGaps(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -60,64 +100,39 @@ func runGaps(cmd *cobra.Command, args []string) error {
 		output.SetNoColor(true)
 	}
 
+	cutoff, err := gapsCutoff(gapsSince, gapsDays, time.Now())
+	if err != nil {
+		return err
+	}
+
 	// Load all data sources.
 	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
 	if err != nil {
 		return fmt.Errorf("parsing session meta: %w", err)
 	}
+	total := len(sessions)
 
 	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
 	if err != nil {
 		return fmt.Errorf("parsing facets: %w", err)
 	}
 
-	settings, err := claude.ParseSettings(cfg.ClaudeHome)
-	if err != nil {
-		settings = nil
+	// Restrict to the requested window before any analysis so stale projects
+	// don't keep generating gaps.
+	if !cutoff.IsZero() {
+		sessions = analyzer.FilterSessionsSince(sessions, cutoff)
+		facets = filterFacetsBySessionIDs(facets, sessions)
 	}
 
-	commands, err := claude.ListCommands(cfg.ClaudeHome)
-	if err != nil {
-		commands = nil
+	if len(sessions) == 0 && !flagJSON {
+		printNoSessions(os.Stdout, cfg, total, "since "+formatGapsCutoff(cutoff))
+		return nil
 	}
 
-	// Run friction analysis.
-	friction := analyzer.AnalyzeFriction(facets, cfg.Friction.RecurringThreshold)
-
-	// Collect gaps.
-	var gaps []gap
-
-	// 1. CLAUDE.md gaps: projects with sessions but no CLAUDE.md.
-	claudeMDGaps := findClaudeMDGaps(sessions, cfg.ScanPaths)
-	gaps = append(gaps, claudeMDGaps...)
-
-	// 2. Recurring friction.
-	frictionGaps := findRecurringFrictionGaps(friction, facets)
-	gaps = append(gaps, frictionGaps...)
-
-	// 3. Missing hooks.
-	hookGaps := findMissingHookGaps(settings)
-	gaps = append(gaps, hookGaps...)
-
-	// 4. Unused skills.
-	skillGaps := findUnusedSkillGaps(commands)
-	gaps = append(gaps, skillGaps...)
-
-	// 5. Project-specific friction.
-	projectFrictionGaps := findProjectFrictionGaps(facets, sessions)
-	gaps = append(gaps, projectFrictionGaps...)
-
-	// 6. CLAUDE.md quality gaps.
-	claudeMDQualityGaps := findClaudeMDQualityGaps(cfg.ScanPaths, facets)
-	gaps = append(gaps, claudeMDQualityGaps...)
-
-	// 7. Stale friction gaps.
-	staleFrictionGaps := findStaleFrictionGaps(facets, sessions)
-	gaps = append(gaps, staleFrictionGaps...)
-
-	// 8. Tool anomaly gaps.
-	toolAnomalyGaps := findToolAnomalyGaps(sessions, cfg.ScanPaths)
-	gaps = append(gaps, toolAnomalyGaps...)
+	gaps, friction := collectGaps(cfg, sessions, facets, cutoff, os.Stderr)
+	gaps = anonymizedGaps(gaps)
+	byLanguage := analyzer.AnalyzeFrictionByLanguage(sessions, facets)
+	velocity := analyzer.AnalyzeFrictionVelocity(facets, sessions)
 
 	// Count severities.
 	var critical, warnings, infoCount int
@@ -135,20 +150,29 @@ func runGaps(cmd *cobra.Command, args []string) error {
 	// JSON output mode.
 	if flagJSON {
 		out := gapsOutput{
-			Gaps:      gaps,
-			Friction:  friction,
-			GapCount:  len(gaps),
-			Critical:  critical,
-			Warnings:  warnings,
-			InfoCount: infoCount,
+			Since:              formatGapsCutoff(cutoff),
+			Gaps:               gaps,
+			Friction:           friction,
+			ProjectFriction:    projectFrictionStats(facets, sessions),
+			FrictionByLanguage: byLanguage,
+			FrictionVelocity:   velocity,
+			GapCount:           len(gaps),
+			Critical:           critical,
+			Warnings:           warnings,
+			InfoCount:          infoCount,
 		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(out)
+		if out.ProjectFriction == nil {
+			out.ProjectFriction = []ProjectFrictionStat{}
+		}
+		return writeJSON(out)
 	}
 
 	// Render styled output.
-	fmt.Println(output.Section("Gap Analysis"))
+	title := "Gap Analysis"
+	if !cutoff.IsZero() {
+		title = fmt.Sprintf("Gap Analysis (since %s)", formatGapsCutoff(cutoff))
+	}
+	fmt.Println(output.Section(title))
 	fmt.Printf(" Found %d gaps: %s critical, %s warnings, %s info\n\n",
 		len(gaps),
 		output.StyleError.Render(fmt.Sprintf("%d", critical)),
@@ -156,10 +180,14 @@ func runGaps(cmd *cobra.Command, args []string) error {
 		output.StyleMuted.Render(fmt.Sprintf("%d", infoCount)))
 
 	renderGapsByCategory(gaps)
+	if gapsQuiet {
+		return nil
+	}
 
 	// Friction summary.
 	if friction.TotalFrictionEvents > 0 {
 		fmt.Println(output.Section("Friction Summary"))
+		fmt.Printf(" %s\n\n", frictionVelocityHeadline(velocity))
 		fmt.Printf(" %s %s\n",
 			output.StyleLabel.Render("Total friction events"),
 			output.StyleValue.Render(fmt.Sprintf("%d", friction.TotalFrictionEvents)))
@@ -179,9 +207,170 @@ func runGaps(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	renderFrictionByLanguage(byLanguage)
+	renderGapsNextSteps(gaps)
+
 	return nil
 }
 
+// frictionVelocityHeadline summarizes the overall friction trend in one line.
+func frictionVelocityHeadline(v analyzer.FrictionVelocity) string {
+	rate := fmt.Sprintf("%.1f%%/week", math.Abs(v.WeeklyChangePct))
+	switch v.Direction {
+	case analyzer.VelocityImproving:
+		return output.StyleSuccess.Render("Friction is improving at " + rate)
+	case analyzer.VelocityWorsening:
+		return output.StyleError.Render("Friction is worsening at " + rate)
+	case analyzer.VelocityStable:
+		return output.StyleMuted.Render(fmt.Sprintf("Friction is holding steady (%+.1f%%/week)", v.WeeklyChangePct))
+	default:
+		return output.StyleMuted.Render(fmt.Sprintf("Friction trend: insufficient history (%d of %d weeks needed)", v.Weeks, analyzer.FrictionVelocityMinWeeks))
+	}
+}
+
+// renderFrictionByLanguage prints friction per dominant session language. It
+// is skipped when no session has language data.
+func renderFrictionByLanguage(f analyzer.FrictionByLanguage) {
+	if !f.HasKnownLanguage() {
+		return
+	}
+	fmt.Println(output.Section("Friction by Language"))
+	fmt.Println()
+	tbl := output.NewTable("Language", "Sessions", "Friction/session", "Top friction types")
+	for _, l := range f.Languages {
+		var types []string
+		for _, t := range l.TopTypes {
+			types = append(types, fmt.Sprintf("%s (%d)", t.Type, t.Count))
+		}
+		top := strings.Join(types, ", ")
+		if top == "" {
+			top = output.StyleMuted.Render("none")
+		}
+		tbl.AddRow(l.Language, fmt.Sprintf("%d", l.Sessions), fmt.Sprintf("%.2f", l.FrictionPerSession), top)
+	}
+	tbl.Print()
+	fmt.Printf("\n %s\n\n", output.StyleMuted.Render("Sessions are grouped by the language they edited most. Informational only; no gaps are raised."))
+}
+
+// collectGaps runs every gap detector over sessions and facets, which should
+// already be restricted to the cutoff window. A zero cutoff means all-time.
+// Per-project detectors use each project's effective config; problems
+// loading a project's config file are reported to warn.
+func collectGaps(cfg *config.Config, sessions []claude.SessionMeta, facets []claude.SessionFacet, cutoff time.Time, warn io.Writer) ([]gap, analyzer.FrictionSummary) {
+	projects, err := scanner.DiscoverProjects(cfg.ScanPaths)
+	if err != nil {
+		logging.Warn("could not discover projects for gap analysis", "err", err)
+	}
+	configs := loadProjectConfigs(cfg, projects, warn)
+
+	settings, err := claude.ParseSettings(cfg.ClaudeHome)
+	if err != nil {
+		settings = nil
+	}
+
+	commands, err := claude.ListCommands(cfg.ClaudeHome)
+	if err != nil {
+		commands = nil
+	}
+
+	// Run friction analysis.
+	friction := analyzer.AnalyzeFriction(facets, cfg.Friction.RecurringThreshold)
+
+	// Collect gaps.
+	var gaps []gap
+
+	// 1. CLAUDE.md gaps: projects with sessions but no CLAUDE.md.
+	claudeMDGaps := findClaudeMDGaps(sessions, cfg.ScanPaths)
+	addClaudeMDSavings(claudeMDGaps, estimateClaudeMDROI(cfg, projects, claudeMDGaps, sessions, facets))
+	gaps = append(gaps, claudeMDGaps...)
+
+	// 2. Recurring friction.
+	frictionGaps := findRecurringFrictionGaps(friction, facets)
+	gaps = append(gaps, frictionGaps...)
+
+	// 3. Missing hooks.
+	hookGaps := findMissingHookGaps(settings)
+	gaps = append(gaps, hookGaps...)
+
+	// 4. Unused skills.
+	skillGaps := findUnusedSkillGaps(commands)
+	gaps = append(gaps, skillGaps...)
+
+	// 5. Project-specific friction.
+	projectFrictionGaps := findProjectFrictionGaps(facets, sessions, configs)
+	gaps = append(gaps, projectFrictionGaps...)
+
+	// 6. CLAUDE.md quality gaps.
+	claudeMDQualityGaps := findClaudeMDQualityGaps(projects, facets, configs)
+	if !cutoff.IsZero() {
+		claudeMDQualityGaps = filterGapsToActiveProjects(claudeMDQualityGaps, sessions)
+	}
+	gaps = append(gaps, claudeMDQualityGaps...)
+
+	// 7. Stale friction gaps.
+	staleFrictionGaps := findStaleFrictionGaps(facets, sessions, cfg.Friction.StaleWeeks)
+	gaps = append(gaps, staleFrictionGaps...)
+
+	// 8. Tool anomaly gaps.
+	toolAnomalyGaps := findToolAnomalyGaps(sessions, projects)
+	gaps = append(gaps, toolAnomalyGaps...)
+
+	// 9. Stale CLAUDE.md gaps.
+	staleClaudeMDGaps := findStaleClaudeMDGaps(cfg, projects, sessions, configs)
+	gaps = append(gaps, staleClaudeMDGaps...)
+
+	return gaps, friction
+}
+
+// gapsCutoff resolves the --since and --days flags into a session cutoff time.
+// The zero time means no cutoff (all-time analysis).
+func gapsCutoff(since string, days int, now time.Time) (time.Time, error) {
+	if since != "" {
+		t, err := time.ParseInLocation("2006-01-02", since, time.Local)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --since date %q (expected YYYY-MM-DD)", since)
+		}
+		// Sessions are kept when they start after the cutoff, so step back
+		// one instant to include sessions starting exactly at midnight.
+		return t.Add(-time.Nanosecond), nil
+	}
+	if days < 0 {
+		return time.Time{}, fmt.Errorf("--days must be non-negative, got %d", days)
+	}
+	if days > 0 {
+		return now.AddDate(0, 0, -days), nil
+	}
+	return time.Time{}, nil
+}
+
+// formatGapsCutoff renders a cutoff as a date for display, or "" for all-time.
+func formatGapsCutoff(cutoff time.Time) string {
+	if cutoff.IsZero() {
+		return ""
+	}
+	return cutoff.Add(time.Nanosecond).Format("2006-01-02")
+}
+
+// filterGapsToActiveProjects drops project-scoped gaps whose project has no
+// sessions in the given (already windowed) session list.
+func filterGapsToActiveProjects(gaps []gap, sessions []claude.SessionMeta) []gap {
+	active := make(map[string]bool, len(sessions))
+	for _, s := range sessions {
+		if s.ProjectPath != "" {
+			active[claude.NormalizePath(s.ProjectPath)] = true
+		}
+	}
+
+	var filtered []gap
+	for _, g := range gaps {
+		if g.Project != "" && !active[claude.NormalizePath(g.Project)] {
+			continue
+		}
+		filtered = append(filtered, g)
+	}
+	return filtered
+}
+
 // findClaudeMDGaps identifies projects with sessions but no CLAUDE.md.
 func findClaudeMDGaps(sessions []claude.SessionMeta, scanPaths []string) []gap {
 	// Collect unique project paths from sessions.
@@ -214,6 +403,38 @@ func findClaudeMDGaps(sessions []claude.SessionMeta, scanPaths []string) []gap {
 	return gaps
 }
 
+// estimateClaudeMDROI estimates what adding a CLAUDE.md would save each
+// project in claudeMDGaps, using projects that have one as references.
+func estimateClaudeMDROI(cfg *config.Config, projects []scanner.Project, claudeMDGaps []gap, sessions []claude.SessionMeta, facets []claude.SessionFacet) analyzer.ClaudeMDROI {
+	missing := make([]string, len(claudeMDGaps))
+	for i, g := range claudeMDGaps {
+		missing[i] = g.Project
+	}
+	cacheRatio := analyzer.NoCacheRatio()
+	if sc, err := claude.ParseStatsCache(cfg.ClaudeHome); err == nil && sc != nil {
+		cacheRatio = analyzer.ComputeCacheRatio(*sc)
+	}
+	return analyzer.EstimateClaudeMDROI(detectClaudeMDChanges(projects), missing, sessions, facets, analyzer.DefaultPricing["sonnet"], cacheRatio)
+}
+
+// addClaudeMDSavings appends each missing-CLAUDE.md gap's estimated monthly
+// savings, with the estimate's confidence and sample size, to its detail.
+// Gaps without projected savings are left alone.
+func addClaudeMDSavings(claudeMDGaps []gap, roi analyzer.ClaudeMDROI) {
+	byProject := make(map[string]analyzer.ClaudeMDROIEstimate, len(roi.Estimates))
+	for _, e := range roi.Estimates {
+		byProject[e.ProjectPath] = e
+	}
+	for i, g := range claudeMDGaps {
+		e, ok := byProject[g.Project]
+		if !ok || e.EstimatedMonthlySavings <= 0 {
+			continue
+		}
+		claudeMDGaps[i].Detail += fmt.Sprintf("; estimated monthly savings: ~$%.2f (estimate, %s confidence, n=%d projects with a CLAUDE.md)",
+			e.EstimatedMonthlySavings, e.Confidence, roi.References)
+	}
+}
+
 // findRecurringFrictionGaps flags friction types appearing in >30% of sessions.
 func findRecurringFrictionGaps(friction analyzer.FrictionSummary, facets []claude.SessionFacet) []gap {
 	var gaps []gap
@@ -310,9 +531,91 @@ func findUnusedSkillGaps(commands []claude.CommandFile) []gap {
 	return gaps
 }
 
+// projectFrictionSignificance is the p-value below which a project's
+// elevated friction counts as more than chance.
+const projectFrictionSignificance = 0.05
+
 // findProjectFrictionGaps cross-references facets with sessions to identify
-// projects with disproportionate friction.
-func findProjectFrictionGaps(facets []claude.SessionFacet, sessions []claude.SessionMeta) []gap {
+// projects with disproportionate friction: more than their
+// thresholds.project_friction_multiplier times the average. Projects with at
+// least analyzer.MinSampleSize faceted sessions must also pass a significance
+// test, so a noisy rate from a handful of sessions isn't flagged; smaller
+// projects fall back to the rate and event count alone.
+func findProjectFrictionGaps(facets []claude.SessionFacet, sessions []claude.SessionMeta, configs projectConfigs) []gap {
+	stats := projectFrictionStats(facets, sessions)
+
+	// Calculate average friction per session across projects with friction.
+	totalFriction := 0
+	totalSessions := 0
+	for _, st := range stats {
+		if st.FrictionEvents == 0 {
+			continue
+		}
+		totalFriction += st.FrictionEvents
+		totalSessions += st.Sessions
+	}
+
+	if totalSessions == 0 {
+		return nil
+	}
+
+	avgFriction := float64(totalFriction) / float64(totalSessions)
+
+	// Flag projects with friction significantly above average.
+	var gaps []gap
+	for _, st := range stats {
+		multiplier := configs.For(st.Project).Thresholds.ProjectFrictionMultiplier
+		if st.FrictionPerSession <= avgFriction*multiplier || st.FrictionEvents <= 2 {
+			continue
+		}
+		significance := "too few sessions to test significance"
+		if st.Sessions >= analyzer.MinSampleSize {
+			p := frictionPValue(st.FrictionEvents, st.Sessions, avgFriction)
+			if p >= projectFrictionSignificance {
+				continue
+			}
+			significance = fmt.Sprintf("p=%s", formatPValue(p))
+		}
+		gaps = append(gaps, gap{
+			Severity: "warning",
+			Category: "project_friction",
+			Title:    fmt.Sprintf("High friction: %s", st.Name),
+			Detail:   fmt.Sprintf("%.1f friction/session vs %.1f average (%d sessions, %s)", st.FrictionPerSession, avgFriction, st.Sessions, significance),
+			Project:  st.Project,
+		})
+	}
+
+	return gaps
+}
+
+// frictionPValue is the chance of seeing at least events friction events in
+// sessions sessions if the project had the average rate, treating friction
+// events as Poisson distributed.
+func frictionPValue(events, sessions int, rate float64) float64 {
+	lambda := rate * float64(sessions)
+	if lambda <= 0 {
+		return 0
+	}
+	// P(X >= events) = 1 - P(X < events).
+	below := 0.0
+	for k := range events {
+		lgamma, _ := math.Lgamma(float64(k + 1))
+		below += math.Exp(float64(k)*math.Log(lambda) - lambda - lgamma)
+	}
+	return math.Max(0, 1-below)
+}
+
+// formatPValue renders p to three decimals, or as "<0.001" below that.
+func formatPValue(p float64) string {
+	if p < 0.001 {
+		return "<0.001"
+	}
+	return fmt.Sprintf("%.3f", p)
+}
+
+// projectFrictionStats aggregates friction events per project over sessions
+// with facet data, sorted by friction per session, highest first.
+func projectFrictionStats(facets []claude.SessionFacet, sessions []claude.SessionMeta) []ProjectFrictionStat {
 	// Build a session-to-project mapping.
 	sessionProject := make(map[string]string)
 	for _, s := range sessions {
@@ -334,56 +637,33 @@ func findProjectFrictionGaps(facets []claude.SessionFacet, sessions []claude.Ses
 		}
 	}
 
-	// Calculate average friction per session across all projects.
-	totalFriction := 0
-	totalSessions := 0
-	for project, friction := range projectFriction {
-		totalFriction += friction
-		totalSessions += projectSessions[project]
-	}
-
-	if totalSessions == 0 {
-		return nil
+	stats := make([]ProjectFrictionStat, 0, len(projectSessions))
+	for project, n := range projectSessions {
+		stats = append(stats, ProjectFrictionStat{
+			Project:            project,
+			Name:               filepath.Base(project),
+			FrictionEvents:     projectFriction[project],
+			Sessions:           n,
+			FrictionPerSession: float64(projectFriction[project]) / float64(n),
+		})
 	}
-
-	avgFriction := float64(totalFriction) / float64(totalSessions)
-
-	// Flag projects with friction significantly above average.
-	var gaps []gap
-	for project, friction := range projectFriction {
-		sessions := projectSessions[project]
-		if sessions == 0 {
-			continue
-		}
-		projectAvg := float64(friction) / float64(sessions)
-		if projectAvg > avgFriction*2 && friction > 2 {
-			gaps = append(gaps, gap{
-				Severity: "warning",
-				Category: "project_friction",
-				Title:    fmt.Sprintf("High friction: %s", filepath.Base(project)),
-				Detail:   fmt.Sprintf("%.1f friction/session vs %.1f average (%d sessions)", projectAvg, avgFriction, sessions),
-				Project:  project,
-			})
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].FrictionPerSession != stats[j].FrictionPerSession {
+			return stats[i].FrictionPerSession > stats[j].FrictionPerSession
 		}
-	}
-
-	return gaps
+		return stats[i].Project < stats[j].Project
+	})
+	return stats
 }
 
 // findClaudeMDQualityGaps runs the CLAUDE.md effectiveness analyzer and flags
-// projects with quality scores below 50.
-func findClaudeMDQualityGaps(scanPaths []string, facets []claude.SessionFacet) []gap {
-	projects, err := scanner.DiscoverProjects(scanPaths)
-	if err != nil {
-		log.Printf("Warning: could not discover projects for CLAUDE.md quality analysis: %v", err)
-		return nil
-	}
-
+// projects with quality scores below their thresholds.claude_md_quality.
+func findClaudeMDQualityGaps(projects []scanner.Project, facets []claude.SessionFacet, configs projectConfigs) []gap {
 	analysis := analyzer.AnalyzeClaudeMDEffectiveness(projects, facets)
 
 	var gaps []gap
 	for _, proj := range analysis.Projects {
-		if proj.QualityScore < 50 {
+		if proj.QualityScore < configs.For(proj.ProjectPath).Thresholds.ClaudeMDQuality {
 			missing := strings.Join(proj.MissingSections, ", ")
 			if missing == "" {
 				missing = "none detected"
@@ -401,10 +681,10 @@ func findClaudeMDQualityGaps(scanPaths []string, facets []claude.SessionFacet) [
 	return gaps
 }
 
-// findStaleFrictionGaps flags friction types that have persisted for 3+ consecutive
-// weeks without improvement.
-func findStaleFrictionGaps(facets []claude.SessionFacet, sessions []claude.SessionMeta) []gap {
-	persistence := analyzer.AnalyzeFrictionPersistence(facets, sessions)
+// findStaleFrictionGaps flags friction types that have persisted for staleWeeks
+// or more consecutive weeks without improvement.
+func findStaleFrictionGaps(facets []claude.SessionFacet, sessions []claude.SessionMeta, staleWeeks int) []gap {
+	persistence := analyzer.AnalyzeFrictionPersistence(facets, sessions, staleWeeks)
 
 	var gaps []gap
 	for _, p := range persistence.Patterns {
@@ -421,14 +701,58 @@ func findStaleFrictionGaps(facets []claude.SessionFacet, sessions []claude.Sessi
 	return gaps
 }
 
-// findToolAnomalyGaps runs the tool usage analyzer and flags detected anomalies.
-func findToolAnomalyGaps(sessions []claude.SessionMeta, scanPaths []string) []gap {
-	projects, err := scanner.DiscoverProjects(scanPaths)
+// findStaleClaudeMDGaps flags projects whose CLAUDE.md has gone their
+// claude_md_stale_days without changes while their sessions kept editing
+// code.
+func findStaleClaudeMDGaps(cfg *config.Config, projects []scanner.Project, sessions []claude.SessionMeta, configs projectConfigs) []gap {
+	if len(projects) == 0 {
+		return nil
+	}
+	fileHistory, err := claude.ParseAllFileHistory(cfg.ClaudeHome)
 	if err != nil {
-		log.Printf("Warning: could not discover projects for tool anomaly analysis: %v", err)
+		logging.Warn("parsing file history; skipping CLAUDE.md staleness", "err", err)
 		return nil
 	}
 
+	// Projects can override the stale age, so analyze each age's projects
+	// together.
+	byStaleDays := make(map[int][]scanner.Project)
+	for _, p := range projects {
+		days := configs.For(p.Path).ClaudeMDStaleDays
+		byStaleDays[days] = append(byStaleDays[days], p)
+	}
+	ages := make([]int, 0, len(byStaleDays))
+	for days := range byStaleDays {
+		ages = append(ages, days)
+	}
+	sort.Ints(ages)
+
+	var gaps []gap
+	for _, days := range ages {
+		analysis := analyzer.AnalyzeClaudeMDStaleness(byStaleDays[days], sessions, fileHistory, days)
+		for _, p := range analysis.Projects {
+			if !p.Stale {
+				continue
+			}
+			gaps = append(gaps, gap{
+				Severity: "warning",
+				Category: "stale_claude_md",
+				Title:    fmt.Sprintf("Stale CLAUDE.md: %s", p.ProjectName),
+				Detail: fmt.Sprintf("Unchanged for %d days while %d sessions made %d edits across %d files; review and refresh it",
+					p.DaysSinceUpdate, p.SessionsSince, p.EditsSince, p.FilesSince),
+				Project: p.ProjectPath,
+			})
+		}
+	}
+	return gaps
+}
+
+// findToolAnomalyGaps runs the tool usage analyzer over projects and flags
+// detected anomalies.
+func findToolAnomalyGaps(sessions []claude.SessionMeta, projects []scanner.Project) []gap {
+	if len(projects) == 0 {
+		return nil
+	}
 	toolAnalysis := analyzer.AnalyzeToolUsage(sessions, projects)
 
 	var gaps []gap
@@ -458,8 +782,105 @@ func severityEmoji(severity string) string {
 	}
 }
 
+// gapsMaxNextSteps caps the next-steps footer.
+const gapsMaxNextSteps = 3
+
+// gapsNextSteps maps gaps, most severe first, to the claudewatch commands
+// that act on them, one step per distinct command and at most
+// gapsMaxNextSteps. Gaps with nothing to run, such as custom commands or tool
+// anomalies, contribute no step.
+func gapsNextSteps(gaps []gap) []string {
+	// Where a category has gaps in several projects, the command covers them
+	// all rather than naming the first.
+	projects := make(map[string]map[string]bool)
+	for _, g := range gaps {
+		if g.Project == "" {
+			continue
+		}
+		if projects[g.Category] == nil {
+			projects[g.Category] = make(map[string]bool)
+		}
+		projects[g.Category][g.Project] = true
+	}
+	fixTarget := func(g gap) string {
+		if len(projects[g.Category]) > 1 {
+			return "claudewatch fix --all"
+		}
+		return "claudewatch fix " + filepath.Base(g.Project)
+	}
+
+	sorted := slices.Clone(gaps)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return severityRank(sorted[i].Severity) < severityRank(sorted[j].Severity)
+	})
+
+	var steps []string
+	for _, g := range sorted {
+		var step string
+		switch g.Category {
+		case "claude_md":
+			step = fmt.Sprintf("Run `%s` to generate CLAUDE.md additions from session data", fixTarget(g))
+		case "claude_md_quality":
+			step = fmt.Sprintf("Run `%s` to fill in missing CLAUDE.md sections", fixTarget(g))
+		case "stale_claude_md":
+			step = fmt.Sprintf("Run `%s` to bring CLAUDE.md in line with recent sessions", fixTarget(g))
+		case "friction", "stale_friction":
+			step = "Run `claudewatch sessions --worst` to inspect the sessions with the most friction"
+		case "project_friction":
+			if len(projects[g.Category]) > 1 {
+				step = "Run `claudewatch sessions --worst` to inspect the sessions with the most friction"
+			} else {
+				step = fmt.Sprintf("Run `claudewatch sessions --worst --project %s` to inspect its friction", filepath.Base(g.Project))
+			}
+		case "hooks":
+			step = "Run `claudewatch suggest --category configuration` for hook and settings recommendations"
+		}
+		if step == "" || slices.Contains(steps, step) {
+			continue
+		}
+		steps = append(steps, step)
+		if len(steps) == gapsMaxNextSteps {
+			break
+		}
+	}
+	return steps
+}
+
+// severityRank orders gap severities, most severe first.
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 0
+	case "warning":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// renderGapsNextSteps prints the next-steps footer, if any gap has one.
+func renderGapsNextSteps(gaps []gap) {
+	steps := gapsNextSteps(gaps)
+	if len(steps) == 0 {
+		return
+	}
+	fmt.Println(output.Section("Next Steps"))
+	for _, step := range steps {
+		fmt.Printf(" %s %s\n", output.StyleMuted.Render("→"), step)
+	}
+	fmt.Println()
+}
+
 // renderGapsByCategory renders gaps grouped by category.
 func renderGapsByCategory(gaps []gap) {
+	for _, line := range gapsByCategoryLines(gaps) {
+		fmt.Println(line)
+	}
+}
+
+// gapsByCategoryLines formats gaps grouped by category, in order of first
+// appearance, with a blank line after each group.
+func gapsByCategoryLines(gaps []gap) []string {
 	// Group by category.
 	categories := make(map[string][]gap)
 	var categoryOrder []string
@@ -471,17 +892,20 @@ func renderGapsByCategory(gaps []gap) {
 		categories[g.Category] = append(categories[g.Category], g)
 	}
 
+	var lines []string
 	for _, cat := range categoryOrder {
 		catGaps := categories[cat]
-		fmt.Printf(" %s\n", output.StyleBold.Render(categoryLabel(cat)))
+		lines = append(lines, fmt.Sprintf(" %s", output.StyleBold.Render(categoryLabel(cat))))
 
 		for _, g := range catGaps {
 			emoji := severityEmoji(g.Severity)
-			fmt.Printf("  %s %s\n", emoji, g.Title)
-			fmt.Printf("    %s\n", output.StyleMuted.Render(g.Detail))
+			lines = append(lines,
+				fmt.Sprintf("  %s %s", emoji, g.Title),
+				fmt.Sprintf("    %s", output.StyleMuted.Render(g.Detail)))
 		}
-		fmt.Println()
+		lines = append(lines, "")
 	}
+	return lines
 }
 
 // categoryLabel returns a human-readable label for a gap category.
@@ -491,6 +915,8 @@ func categoryLabel(cat string) string {
 		return "CLAUDE.md Gaps"
 	case "claude_md_quality":
 		return "CLAUDE.md Quality"
+	case "stale_claude_md":
+		return "Stale CLAUDE.md"
 	case "friction":
 		return "Recurring Friction"
 	case "stale_friction":
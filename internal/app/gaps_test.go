@@ -0,0 +1,330 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+func TestGapsCutoff_DefaultIsAllTime(t *testing.T) {
+	cutoff, err := gapsCutoff("", 0, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cutoff.IsZero() {
+		t.Errorf("expected zero cutoff for all-time default, got %v", cutoff)
+	}
+}
+
+func TestGapsCutoff_Days(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	cutoff, err := gapsCutoff("", 7, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	if !cutoff.Equal(want) {
+		t.Errorf("cutoff = %v, want %v", cutoff, want)
+	}
+}
+
+func TestGapsCutoff_SinceIncludesMidnight(t *testing.T) {
+	cutoff, err := gapsCutoff("2026-03-01", 0, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	midnight := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	if !midnight.After(cutoff) {
+		t.Errorf("expected a session at %v to fall after cutoff %v", midnight, cutoff)
+	}
+	if got := formatGapsCutoff(cutoff); got != "2026-03-01" {
+		t.Errorf("formatGapsCutoff = %q, want %q", got, "2026-03-01")
+	}
+}
+
+func TestGapsCutoff_InvalidSince(t *testing.T) {
+	if _, err := gapsCutoff("last week", 0, time.Now()); err == nil {
+		t.Fatal("expected error for malformed --since date, got nil")
+	}
+}
+
+func TestGapsCutoff_NegativeDays(t *testing.T) {
+	if _, err := gapsCutoff("", -3, time.Now()); err == nil {
+		t.Fatal("expected error for negative --days, got nil")
+	}
+}
+
+// TestFindClaudeMDGaps_WindowExcludesStaleProjects verifies that a project whose
+// only sessions fall outside the window no longer produces a missing CLAUDE.md gap.
+func TestFindClaudeMDGaps_WindowExcludesStaleProjects(t *testing.T) {
+	recent := t.TempDir()
+	stale := t.TempDir()
+	now := time.Now()
+
+	sessions := []claude.SessionMeta{
+		{SessionID: "a", ProjectPath: recent, StartTime: now.Add(-24 * time.Hour).Format(time.RFC3339)},
+		{SessionID: "b", ProjectPath: stale, StartTime: now.AddDate(0, -6, 0).Format(time.RFC3339)},
+	}
+
+	all := findClaudeMDGaps(sessions, nil)
+	if len(all) != 2 {
+		t.Fatalf("expected 2 gaps without a window, got %d", len(all))
+	}
+
+	cutoff, err := gapsCutoff("", 30, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	windowed := findClaudeMDGaps(analyzer.FilterSessionsSince(sessions, cutoff), nil)
+	if len(windowed) != 1 {
+		t.Fatalf("expected 1 gap within the window, got %d", len(windowed))
+	}
+	if windowed[0].Project != recent {
+		t.Errorf("expected gap for %s, got %s", recent, windowed[0].Project)
+	}
+}
+
+func TestAddClaudeMDSavings(t *testing.T) {
+	gaps := []gap{
+		{Title: "Missing CLAUDE.md", Detail: "app has 12 sessions but no CLAUDE.md", Project: "/code/app"},
+		{Title: "Missing CLAUDE.md", Detail: "cheap has 3 sessions but no CLAUDE.md", Project: "/code/cheap"},
+	}
+	roi := analyzer.ClaudeMDROI{
+		References: 4,
+		Estimates: []analyzer.ClaudeMDROIEstimate{
+			{ProjectPath: "/code/app", EstimatedMonthlySavings: 7.5, Confidence: "medium"},
+			{ProjectPath: "/code/cheap", Confidence: "low"},
+		},
+	}
+
+	addClaudeMDSavings(gaps, roi)
+
+	want := "app has 12 sessions but no CLAUDE.md; estimated monthly savings: ~$7.50 (estimate, medium confidence, n=4 projects with a CLAUDE.md)"
+	if gaps[0].Detail != want {
+		t.Errorf("Detail = %q, want %q", gaps[0].Detail, want)
+	}
+	if gaps[1].Detail != "cheap has 3 sessions but no CLAUDE.md" {
+		t.Errorf("Detail = %q, want it unchanged without savings", gaps[1].Detail)
+	}
+}
+
+func TestFilterGapsToActiveProjects(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "a", ProjectPath: "/code/active"},
+	}
+	gaps := []gap{
+		{Category: "claude_md_quality", Project: "/code/active/"},
+		{Category: "claude_md_quality", Project: "/code/dormant"},
+		{Category: "hooks"},
+	}
+
+	got := filterGapsToActiveProjects(gaps, sessions)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 gaps after filtering, got %d: %+v", len(got), got)
+	}
+	if got[0].Project != "/code/active/" {
+		t.Errorf("expected active project gap to be kept, got %q", got[0].Project)
+	}
+	if got[1].Category != "hooks" {
+		t.Errorf("expected project-less gap to be kept, got %q", got[1].Category)
+	}
+}
+
+func TestGapsFlags_Registered(t *testing.T) {
+	for _, name := range []string{"since", "days", "quiet"} {
+		f := gapsCmd.Flags().Lookup(name)
+		if f == nil {
+			t.Fatalf("expected --%s flag to be registered on gapsCmd", name)
+		}
+	}
+	if def := gapsCmd.Flags().Lookup("days").DefValue; def != "0" {
+		t.Errorf("expected --days default %q (all time), got %q", "0", def)
+	}
+}
+
+func TestProjectFrictionStats_IncludesEveryProjectSorted(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "a1", ProjectPath: "/work/alpha"},
+		{SessionID: "a2", ProjectPath: "/work/alpha"},
+		{SessionID: "a3", ProjectPath: "/work/alpha"},
+		{SessionID: "a4", ProjectPath: "/work/alpha"},
+		{SessionID: "b1", ProjectPath: "/work/beta"},
+		{SessionID: "c1", ProjectPath: "/work/calm"},
+		{SessionID: "x1", ProjectPath: ""},
+	}
+	facets := []claude.SessionFacet{
+		{SessionID: "a1", FrictionCounts: map[string]int{"wrong_approach": 1}},
+		{SessionID: "a2", FrictionCounts: map[string]int{"buggy_code": 1}},
+		{SessionID: "a3", FrictionCounts: map[string]int{"buggy_code": 1}},
+		{SessionID: "a4", FrictionCounts: map[string]int{"wrong_approach": 1}},
+		{SessionID: "b1", FrictionCounts: map[string]int{"wrong_approach": 4}},
+		{SessionID: "c1"},
+		{SessionID: "x1", FrictionCounts: map[string]int{"buggy_code": 9}},
+	}
+
+	stats := projectFrictionStats(facets, sessions)
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 projects, got %d: %+v", len(stats), stats)
+	}
+
+	want := []ProjectFrictionStat{
+		{Project: "/work/beta", Name: "beta", FrictionEvents: 4, Sessions: 1, FrictionPerSession: 4},
+		{Project: "/work/alpha", Name: "alpha", FrictionEvents: 4, Sessions: 4, FrictionPerSession: 1},
+		{Project: "/work/calm", Name: "calm", FrictionEvents: 0, Sessions: 1, FrictionPerSession: 0},
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("stats[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+
+	// Only beta is more than twice the average of projects with friction.
+	configs := projectConfigs{base: &config.Config{Thresholds: config.DefaultThresholds}}
+	gaps := findProjectFrictionGaps(facets, sessions, configs)
+	if len(gaps) != 1 || gaps[0].Project != "/work/beta" {
+		t.Errorf("expected a single gap for beta, got %+v", gaps)
+	}
+}
+
+// frictionSessions returns n sessions in project with facets recording
+// events friction events in total, spread as evenly as possible.
+func frictionSessions(project string, n, events int) ([]claude.SessionMeta, []claude.SessionFacet) {
+	var sessions []claude.SessionMeta
+	var facets []claude.SessionFacet
+	for i := range n {
+		id := fmt.Sprintf("%s-%d", project, i)
+		sessions = append(sessions, claude.SessionMeta{SessionID: id, ProjectPath: project})
+		count := events / n
+		if i < events%n {
+			count++
+		}
+		facets = append(facets, claude.SessionFacet{SessionID: id, FrictionCounts: map[string]int{"wrong_approach": count}})
+	}
+	return sessions, facets
+}
+
+func TestFindProjectFrictionGaps_Significance(t *testing.T) {
+	configs := projectConfigs{base: &config.Config{Thresholds: config.DefaultThresholds}}
+	gapsFor := func(projects ...[2]int) []gap {
+		var sessions []claude.SessionMeta
+		var facets []claude.SessionFacet
+		for i, p := range projects {
+			s, f := frictionSessions(fmt.Sprintf("/work/p%d", i), p[0], p[1])
+			sessions = append(sessions, s...)
+			facets = append(facets, f...)
+		}
+		return findProjectFrictionGaps(facets, sessions, configs)
+	}
+
+	// 30 events in 10 sessions against a 1.4 average is far beyond chance.
+	gaps := gapsFor([2]int{40, 40}, [2]int{10, 30})
+	if len(gaps) != 1 || gaps[0].Project != "/work/p1" || !strings.Contains(gaps[0].Detail, "p=<0.001") {
+		t.Errorf("expected a significant gap for p1, got %+v", gaps)
+	}
+
+	// 3 events in 10 sessions is over twice the 0.12 average, but not
+	// significant (p ≈ 0.12).
+	if gaps := gapsFor([2]int{100, 10}, [2]int{10, 3}); len(gaps) != 0 {
+		t.Errorf("expected no gap for an insignificant rate, got %+v", gaps)
+	}
+
+	// Below MinSampleSize sessions the count threshold decides alone.
+	gaps = gapsFor([2]int{100, 10}, [2]int{3, 3})
+	if len(gaps) != 1 || !strings.Contains(gaps[0].Detail, "too few sessions to test significance") {
+		t.Errorf("expected a fallback gap for p1, got %+v", gaps)
+	}
+}
+
+func TestFrictionPValue(t *testing.T) {
+	tests := []struct {
+		events, sessions int
+		rate, want       float64
+	}{
+		{0, 10, 1, 1},
+		{1, 1, 1, 1 - math.Exp(-1)},
+		{3, 10, 0.118, 0.1158},
+		{5, 10, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := frictionPValue(tt.events, tt.sessions, tt.rate); math.Abs(got-tt.want) > 1e-3 {
+			t.Errorf("frictionPValue(%d, %d, %.3f) = %.4f, want %.4f", tt.events, tt.sessions, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestFrictionVelocityHeadline(t *testing.T) {
+	output.SetNoColor(true)
+	defer output.SetNoColor(false)
+
+	tests := []struct {
+		v    analyzer.FrictionVelocity
+		want string
+	}{
+		{analyzer.FrictionVelocity{Direction: analyzer.VelocityImproving, WeeklyChangePct: -4.25}, "Friction is improving at 4.2%/week"},
+		{analyzer.FrictionVelocity{Direction: analyzer.VelocityWorsening, WeeklyChangePct: 12}, "Friction is worsening at 12.0%/week"},
+		{analyzer.FrictionVelocity{Direction: analyzer.VelocityStable, WeeklyChangePct: 0.5}, "Friction is holding steady (+0.5%/week)"},
+		{analyzer.FrictionVelocity{Direction: analyzer.VelocityInsufficientHistory, Weeks: 2}, "Friction trend: insufficient history (2 of 4 weeks needed)"},
+	}
+	for _, tt := range tests {
+		if got := frictionVelocityHeadline(tt.v); got != tt.want {
+			t.Errorf("frictionVelocityHeadline(%s) = %q, want %q", tt.v.Direction, got, tt.want)
+		}
+	}
+}
+
+func TestGapsNextSteps(t *testing.T) {
+	gaps := []gap{
+		{Severity: "info", Category: "hooks", Title: "No SessionEnd hook configured"},
+		{Severity: "info", Category: "skills", Title: "No custom commands defined"},
+		{Severity: "warning", Category: "friction", Title: "Recurring: wrong_approach"},
+		{Severity: "warning", Category: "stale_friction", Title: "Stale: tool_error"},
+		{Severity: "critical", Category: "claude_md", Title: "Missing CLAUDE.md", Project: "/home/u/code/alpha"},
+	}
+
+	steps := gapsNextSteps(gaps)
+	want := []string{
+		"Run `claudewatch fix alpha` to generate CLAUDE.md additions from session data",
+		"Run `claudewatch sessions --worst` to inspect the sessions with the most friction",
+		"Run `claudewatch suggest --category configuration` for hook and settings recommendations",
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("gapsNextSteps() = %q, want %q", steps, want)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("step %d = %q, want %q", i, steps[i], want[i])
+		}
+	}
+}
+
+func TestGapsNextSteps_SeveralProjectsAndCap(t *testing.T) {
+	gaps := []gap{
+		{Severity: "critical", Category: "claude_md", Project: "/code/alpha"},
+		{Severity: "critical", Category: "claude_md", Project: "/code/beta"},
+		{Severity: "warning", Category: "project_friction", Project: "/code/gamma"},
+		{Severity: "warning", Category: "claude_md_quality", Project: "/code/delta"},
+		{Severity: "warning", Category: "stale_claude_md", Project: "/code/epsilon"},
+	}
+
+	steps := gapsNextSteps(gaps)
+	if len(steps) != gapsMaxNextSteps {
+		t.Fatalf("got %d steps, want %d: %q", len(steps), gapsMaxNextSteps, steps)
+	}
+	if want := "Run `claudewatch fix --all` to generate CLAUDE.md additions from session data"; steps[0] != want {
+		t.Errorf("step 0 = %q, want %q", steps[0], want)
+	}
+	if want := "Run `claudewatch sessions --worst --project gamma` to inspect its friction"; steps[1] != want {
+		t.Errorf("step 1 = %q, want %q", steps[1], want)
+	}
+
+	if steps := gapsNextSteps([]gap{{Severity: "info", Category: "skills"}}); len(steps) != 0 {
+		t.Errorf("gapsNextSteps(skills only) = %q, want none", steps)
+	}
+}
@@ -47,7 +47,7 @@ func init() {
 }
 
 func runHook(cmd *cobra.Command, args []string) {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return
 	}
@@ -0,0 +1,314 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/guard"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/ui"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	initYes        bool
+	initForce      bool
+	initNoSnapshot bool
+)
+
+// initScanCandidates are the directories under $HOME that init offers to
+// scan when they hold git repositories.
+var initScanCandidates = []string{"src", "code", "projects"}
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up claudewatch: detect Claude home and scan paths, write the config",
+	Long: `Walk through first-time setup:
+
+1. Detects Claude Code's data directory (~/.claude)
+2. Looks for git repositories in ~/src, ~/code, and ~/projects and asks
+   which of them to scan, plus any other directories
+3. Writes the config file (~/.config/claudewatch/config.yaml, or --config)
+4. Takes an initial snapshot, so later track runs have a baseline
+5. Prints a summary and next steps
+
+With --yes, or when stdin or stdout isn't a terminal, nothing is asked: the
+detected Claude home and every candidate directory holding a git repository
+are used, falling back to ~/code when none does.
+
+An existing config file is only replaced after confirming, or with --force.`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+func init() {
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Accept the detected defaults without prompting")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing config file")
+	initCmd.Flags().BoolVar(&initNoSnapshot, "no-snapshot", false, "Skip the initial snapshot")
+	rootCmd.AddCommand(initCmd)
+}
+
+// initCandidate is a directory init offers to scan.
+type initCandidate struct {
+	Path  string
+	Repos int
+}
+
+// initPrompter asks setup questions, or answers them with their defaults
+// when not interactive.
+type initPrompter struct {
+	in          *bufio.Reader
+	out         io.Writer
+	interactive bool
+}
+
+// confirm asks a yes/no question and returns def for an empty answer, at
+// end of input, or when not interactive.
+func (p *initPrompter) confirm(question string, def bool) bool {
+	if !p.interactive {
+		return def
+	}
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	_, _ = fmt.Fprintf(p.out, " %s [%s] ", question, hint)
+	answer, err := p.in.ReadString('\n')
+	if err != nil && answer == "" {
+		_, _ = fmt.Fprintln(p.out)
+		return def
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return def
+	}
+}
+
+// ask asks for a value and returns def for an empty answer, at end of
+// input, or when not interactive.
+func (p *initPrompter) ask(question, def string) string {
+	if !p.interactive {
+		return def
+	}
+	if def != "" {
+		_, _ = fmt.Fprintf(p.out, " %s [%s]: ", question, def)
+	} else {
+		_, _ = fmt.Fprintf(p.out, " %s: ", question)
+	}
+	answer, err := p.in.ReadString('\n')
+	if err != nil && answer == "" {
+		_, _ = fmt.Fprintln(p.out)
+		return def
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return def
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("finding home directory: %w", err)
+	}
+	path := config.FilePath(flagConfig)
+	p := &initPrompter{in: bufio.NewReader(os.Stdin), out: os.Stdout, interactive: !initYes && ui.IsTTY()}
+
+	fmt.Println(output.Section("claudewatch init"))
+	fmt.Println()
+	if !initYes && !p.interactive {
+		fmt.Printf(" %s\n\n", output.StyleMuted.Render("Not running in a terminal: using the detected defaults."))
+	}
+
+	if _, err := os.Stat(path); err == nil && !initForce {
+		if !p.confirm(fmt.Sprintf("%s already exists. Replace it?", tildePath(home, path)), false) {
+			return fmt.Errorf("%s already exists; pass --force to replace it", path)
+		}
+	}
+
+	// Claude home.
+	claudeHome := filepath.Join(home, ".claude")
+	if info, err := os.Stat(claudeHome); err == nil && info.IsDir() {
+		fmt.Printf(" %s Found Claude Code data in %s\n", output.StyleSuccess.Render("✓"), tildePath(home, claudeHome))
+	} else {
+		fmt.Printf(" %s No Claude Code data in %s yet; sessions appear there once Claude Code has run\n", output.StyleWarning.Render("!"), tildePath(home, claudeHome))
+	}
+	claudeHome = expandHome(home, p.ask("Claude home", tildePath(home, claudeHome)))
+
+	// Scan paths.
+	var scanPaths []string
+	candidates := detectInitCandidates(home)
+	if len(candidates) == 0 {
+		fmt.Printf(" %s No git repositories in ~/src, ~/code, or ~/projects\n", output.StyleWarning.Render("!"))
+	}
+	for _, c := range candidates {
+		if p.confirm(fmt.Sprintf("Scan %s (%d git repos)?", tildePath(home, c.Path), c.Repos), true) {
+			scanPaths = append(scanPaths, c.Path)
+		}
+	}
+	for _, extra := range strings.Split(p.ask("Other directories to scan, comma-separated", ""), ",") {
+		if extra = strings.TrimSpace(extra); extra != "" {
+			scanPaths = append(scanPaths, expandHome(home, extra))
+		}
+	}
+	if len(scanPaths) == 0 {
+		for _, d := range config.DefaultScanPaths {
+			scanPaths = append(scanPaths, expandHome(home, d))
+		}
+	}
+	fmt.Println()
+
+	if err := writeInitConfig(path, tildePath(home, claudeHome), tildePaths(home, scanPaths)); err != nil {
+		return err
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading the new config: %w", err)
+	}
+
+	repos := 0
+	for _, dir := range cfg.ScanPaths {
+		repos += countGitRepos(dir)
+	}
+	sessions, _ := claude.ParseAllSessionMeta(cfg.ClaudeHome)
+
+	snapshot := "recorded"
+	switch {
+	case initNoSnapshot:
+		snapshot = "skipped (--no-snapshot)"
+	case guard.ReadOnly():
+		snapshot = "skipped (--read-only)"
+	case len(sessions) == 0:
+		snapshot = "skipped (no sessions yet)"
+	default:
+		fmt.Printf(" %s\n", output.StyleMuted.Render("Taking an initial snapshot..."))
+		if err := runTrack(cmd, nil); err != nil {
+			return fmt.Errorf("taking the initial snapshot: %w", err)
+		}
+	}
+
+	renderInitSummary(home, path, cfg.ClaudeHome, cfg.ScanPaths, repos, len(sessions), snapshot)
+	return nil
+}
+
+// detectInitCandidates returns the initScanCandidates under home that hold
+// at least one git repository.
+func detectInitCandidates(home string) []initCandidate {
+	var candidates []initCandidate
+	for _, name := range initScanCandidates {
+		dir := filepath.Join(home, name)
+		if n := countGitRepos(dir); n > 0 {
+			candidates = append(candidates, initCandidate{Path: dir, Repos: n})
+		}
+	}
+	return candidates
+}
+
+// countGitRepos counts the git repositories directly inside dir, the
+// projects scanner.DiscoverProjects would find there.
+func countGitRepos(dir string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, e.Name(), ".git")); err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// initConfig is the config file init writes.
+type initConfig struct {
+	ClaudeHome string   `yaml:"claude_home"`
+	ScanPaths  []string `yaml:"scan_paths"`
+}
+
+// writeInitConfig writes a config file at path with the given Claude home
+// and scan paths, creating its directory.
+func writeInitConfig(path, claudeHome string, scanPaths []string) error {
+	data, err := yaml.Marshal(initConfig{ClaudeHome: claudeHome, ScanPaths: scanPaths})
+	if err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	header := "# Written by claudewatch init. Every setting is described under `config`\n# in docs/cli.md.\n"
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(header), data...), 0o644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+// renderInitSummary prints what init set up and what to run next.
+func renderInitSummary(home, path, claudeHome string, scanPaths []string, repos, sessions int, snapshot string) {
+	fmt.Println(output.Section("You're set up"))
+	fmt.Println()
+	label := func(l, v string) {
+		fmt.Printf(" %s %s\n", output.StyleLabel.Render(l), output.StyleBold.Render(v))
+	}
+	label("Config", tildePath(home, path))
+	label("Claude home", tildePath(home, claudeHome))
+	label("Scan paths", strings.Join(tildePaths(home, scanPaths), ", "))
+	label("Projects", fmt.Sprintf("%d git repos", repos))
+	label("Sessions", fmt.Sprintf("%d", sessions))
+	label("Snapshot", snapshot)
+
+	fmt.Printf("\n %s\n", output.StyleMuted.Render("Next steps:"))
+	fmt.Printf("   %s\n", "claudewatch scan      # readiness of each project")
+	fmt.Printf("   %s\n", "claudewatch metrics   # how your sessions are going")
+	fmt.Printf("   %s\n", "claudewatch install   # rules and MCP tools for Claude Code")
+	fmt.Println()
+}
+
+// expandHome expands a leading ~ in path to home.
+func expandHome(home, path string) string {
+	if path == "~" {
+		return home
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
+
+// tildePath shortens a path under home to its ~ form.
+func tildePath(home, path string) string {
+	if rel, err := filepath.Rel(home, path); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+		if rel == "." {
+			return "~"
+		}
+		return "~/" + rel
+	}
+	return path
+}
+
+// tildePaths applies tildePath to each of paths.
+func tildePaths(home string, paths []string) []string {
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = tildePath(home, p)
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+func TestInitPrompter(t *testing.T) {
+	p := &initPrompter{
+		in:          bufio.NewReader(strings.NewReader("n\n\nyes\n~/work\n")),
+		out:         io.Discard,
+		interactive: true,
+	}
+	if p.confirm("Scan ~/src?", true) {
+		t.Error("confirm: answer n, got true")
+	}
+	if !p.confirm("Scan ~/code?", true) {
+		t.Error("confirm: empty answer should take the default true")
+	}
+	if !p.confirm("Replace it?", false) {
+		t.Error("confirm: answer yes, got false")
+	}
+	if got := p.ask("Other directories", ""); got != "~/work" {
+		t.Errorf("ask = %q, want ~/work", got)
+	}
+	// Input is exhausted: defaults win.
+	if got := p.ask("Claude home", "~/.claude"); got != "~/.claude" {
+		t.Errorf("ask at EOF = %q, want the default", got)
+	}
+
+	quiet := &initPrompter{in: bufio.NewReader(strings.NewReader("n\n")), out: io.Discard}
+	if !quiet.confirm("Scan ~/src?", true) || quiet.ask("Claude home", "~/.claude") != "~/.claude" {
+		t.Error("non-interactive prompter should answer with the defaults")
+	}
+}
+
+func TestDetectInitCandidates(t *testing.T) {
+	home := t.TempDir()
+	for _, dir := range []string{"code/a/.git", "code/b/.git", "code/.hidden/.git", "code/plain", "projects/c/.git", "src/d"} {
+		if err := os.MkdirAll(filepath.Join(home, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := detectInitCandidates(home)
+	want := []initCandidate{
+		{Path: filepath.Join(home, "code"), Repos: 2},
+		{Path: filepath.Join(home, "projects"), Repos: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("detectInitCandidates = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteInitConfig_LoadsBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+	if err := writeInitConfig(path, "/data/claude", []string{"/src", "/work"}); err != nil {
+		t.Fatalf("writeInitConfig: %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.ClaudeHome != "/data/claude" || !reflect.DeepEqual(cfg.ScanPaths, []string{"/src", "/work"}) {
+		t.Errorf("loaded claude_home %q, scan_paths %v", cfg.ClaudeHome, cfg.ScanPaths)
+	}
+}
+
+func TestTildePath(t *testing.T) {
+	tests := []struct{ path, want string }{
+		{"/home/u/code", "~/code"},
+		{"/home/u", "~"},
+		{"/home/user2/code", "/home/user2/code"},
+		{"/opt/src", "/opt/src"},
+	}
+	for _, tt := range tests {
+		if got := tildePath("/home/u", tt.path); got != tt.want {
+			t.Errorf("tildePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+		if got := expandHome("/home/u", tildePath("/home/u", tt.path)); got != tt.path {
+			t.Errorf("expandHome(tildePath(%q)) = %q", tt.path, got)
+		}
+	}
+}
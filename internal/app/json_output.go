@@ -0,0 +1,63 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/ui"
+)
+
+// writeJSON encodes v as two-space indented JSON to stdout, or as a single
+// line with --compact-json. When stdout is a terminal and color is enabled
+// the output is syntax-highlighted; otherwise the bytes are exactly what
+// json.Encoder would have written.
+func writeJSON(v any) error {
+	return encodeJSON(v, jsonIndent())
+}
+
+// writeJSONCompact is writeJSON without indentation.
+func writeJSONCompact(v any) error {
+	return encodeJSON(v, "")
+}
+
+// jsonIndent returns the indentation for --json output: two spaces by
+// default, none with --compact-json.
+func jsonIndent() string {
+	if flagCompactJSON {
+		return ""
+	}
+	return "  "
+}
+
+func encodeJSON(v any, indent string) error {
+	// --anonymize applies to the JSON output of every command.
+	v = anonymized(v)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	if indent != "" {
+		enc.SetIndent("", indent)
+	}
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	if !shouldColorizeJSON() {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	_, err := fmt.Fprint(os.Stdout, output.ColorizeJSON(buf.Bytes()))
+	return err
+}
+
+// shouldColorizeJSON reports whether JSON output should be syntax-highlighted:
+// only on an interactive stdout with color enabled and --color-json not
+// turned off.
+func shouldColorizeJSON() bool {
+	if !flagColorJSON || flagNoColor || output.IsNoColor() {
+		return false
+	}
+	return ui.IsStdoutTTY()
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+)
+
+// captureStdout runs fn with os.Stdout redirected to a pipe and returns what
+// was written.
+func captureStdout(t *testing.T, fn func() error) []byte {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fnErr := fn()
+	_ = w.Close()
+	out, readErr := io.ReadAll(r)
+	if fnErr != nil {
+		t.Fatalf("unexpected error: %v", fnErr)
+	}
+	if readErr != nil {
+		t.Fatalf("reading pipe: %v", readErr)
+	}
+	return out
+}
+
+// TestWriteJSON_NonTTYMatchesEncoder verifies that piped output is byte-for-byte
+// what json.Encoder with a two-space indent produces.
+func TestWriteJSON_NonTTYMatchesEncoder(t *testing.T) {
+	v := map[string]any{"gaps": []string{"<a>", "b"}, "count": 2}
+
+	var want bytes.Buffer
+	enc := json.NewEncoder(&want)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	got := captureStdout(t, func() error { return writeJSON(v) })
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("writeJSON output differs from json.Encoder.\ngot:  %q\nwant: %q", got, want.Bytes())
+	}
+}
+
+func TestWriteJSONCompact_NonTTYMatchesEncoder(t *testing.T) {
+	v := []int{1, 2, 3}
+
+	var want bytes.Buffer
+	if err := json.NewEncoder(&want).Encode(v); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	got := captureStdout(t, func() error { return writeJSONCompact(v) })
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("writeJSONCompact output = %q, want %q", got, want.Bytes())
+	}
+}
+
+func TestShouldColorizeJSON_Disabled(t *testing.T) {
+	orig := flagColorJSON
+	defer func() { flagColorJSON = orig }()
+
+	flagColorJSON = false
+	if shouldColorizeJSON() {
+		t.Error("expected no colorization with --color-json=false")
+	}
+}
+
+func TestColorJSONFlag_Registered(t *testing.T) {
+	f := rootCmd.PersistentFlags().Lookup("color-json")
+	if f == nil {
+		t.Fatal("expected --color-json persistent flag to be registered")
+	}
+	if f.DefValue != "true" {
+		t.Errorf("expected --color-json default %q, got %q", "true", f.DefValue)
+	}
+}
+
+func TestWriteJSON_CompactJSONFlag(t *testing.T) {
+	orig := flagCompactJSON
+	defer func() { flagCompactJSON = orig }()
+
+	v := map[string]any{"gaps": []string{"a", "b"}, "count": 2}
+	var want bytes.Buffer
+	if err := json.NewEncoder(&want).Encode(v); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	flagCompactJSON = true
+	got := captureStdout(t, func() error { return writeJSON(v) })
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("writeJSON with --compact-json = %q, want %q", got, want.Bytes())
+	}
+}
+
+func TestCompactJSONFlag_Registered(t *testing.T) {
+	f := rootCmd.PersistentFlags().Lookup("compact-json")
+	if f == nil {
+		t.Fatal("expected --compact-json persistent flag to be registered")
+	}
+	if f.DefValue != "false" {
+		t.Errorf("expected --compact-json default %q, got %q", "false", f.DefValue)
+	}
+}
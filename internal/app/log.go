@@ -3,7 +3,6 @@ package app
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -58,7 +57,7 @@ func init() {
 }
 
 func runLog(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -241,9 +240,7 @@ func runLogList(db *store.DB, cfg *config.Config) error {
 	}
 
 	if logJSON || flagJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(rows)
+		return writeJSON(rows)
 	}
 
 	if len(rows) == 0 {
@@ -268,8 +265,8 @@ func runLogList(db *store.DB, cfg *config.Config) error {
 		}
 
 		timeStr := ""
-		if t, err := time.Parse(time.RFC3339, r.LoggedAt); err == nil {
-			timeStr = t.Local().Format("2006-01-02 15:04")
+		if t, err := claude.ParseSessionTime(r.LoggedAt); err == nil {
+			timeStr = t.Format("2006-01-02 15:04")
 		} else {
 			timeStr = r.LoggedAt
 		}
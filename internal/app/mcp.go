@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/mcp"
 	"github.com/spf13/cobra"
 )
@@ -38,10 +37,11 @@ func init() {
 }
 
 func runMCP(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
 	srv := mcp.NewServer(cfg, mcpBudget)
+	srv.SetVersion(appVersion)
 	return srv.Run(cmd.Context(), os.Stdin, os.Stdout)
 }
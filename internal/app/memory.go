@@ -250,7 +250,7 @@ func runMemoryClear(cmd *cobra.Command, args []string) error {
 }
 
 func runMemoryExtract(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -1,24 +1,33 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/logging"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
+	"github.com/blackwell-systems/claudewatch/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	metricsDays    int
-	metricsProject string
+	metricsDays         int
+	metricsProject      string
+	metricsProjectPath  string
+	metricsProgress     bool
+	metricsCompact      bool
+	metricsTrivial      bool
+	metricsGroupByDir   int
+	metricsExpand       bool
+	metricsBaselineFile string
 )
 
 var metricsCmd = &cobra.Command{
@@ -27,14 +36,49 @@ var metricsCmd = &cobra.Command{
 	Long: `Analyze Claude Code session data to compute and display productivity,
 efficiency, satisfaction, and agent performance metrics.
 
-Metrics are computed from session-meta, facets, and agent task data.`,
+Metrics are computed from session-meta, facets, and agent task data.
+
+On a terminal, a spinner on stderr shows the current phase while large
+datasets load. It is off for --json and non-terminal stderr; --progress=false
+turns it off everywhere.
+
+--compact collapses each section into a single line, for CI logs and narrow
+terminals. It has no effect on --json.
+
+Every session counts by default. --include-trivial=false leaves out quick
+one-off sessions, as defined by trivial_session in the config (by default,
+under 10 minutes with fewer than 5 user messages).
+
+--project narrows to one project by name; --project-path by its exact path,
+for repos that share a name.
+
+--group-by-dir <depth> adds a section rolling cost and outcomes up by parent
+directory, such as one group per client for repos under ~/clients/<client>/.
+Depth counts directories below your home directory (or below / outside it);
+shallower projects are grouped under "root". --expand lists each group's
+projects under it.
+
+--baseline <file> checks for regressions, for CI. When the file doesn't
+exist, the run records its aggregate metrics there. Later runs compare
+against it and exit non-zero when a metric got worse by more than its
+tolerance: baseline.tolerance_pct of the baseline value (default 5), or the
+metric's entry in baseline.tolerances. Record and compare with the same
+--days and --project.`,
 	RunE: runMetrics,
 }
 
 func init() {
 	metricsCmd.Flags().IntVar(&metricsDays, "days", 30, "Number of days to analyze")
-	metricsCmd.Flags().StringVar(&metricsProject, "project", "", "Filter to a specific project path")
+	metricsCmd.Flags().StringVar(&metricsProject, "project", "", "Filter to the project matching this name (fuzzy)")
+	metricsCmd.Flags().StringVar(&metricsProjectPath, "project-path", "", "Filter to the project at exactly this path")
+	metricsCmd.MarkFlagsMutuallyExclusive("project", "project-path")
 	metricsCmd.Flags().BoolVar(&flagJSON, "json", false, "Output as JSON")
+	metricsCmd.Flags().BoolVar(&metricsProgress, "progress", true, "Show a progress spinner on stderr while loading")
+	metricsCmd.Flags().BoolVar(&metricsCompact, "compact", false, "Render each section as one dense line")
+	metricsCmd.Flags().BoolVar(&metricsTrivial, "include-trivial", true, "Count trivial sessions (see trivial_session in the config)")
+	metricsCmd.Flags().IntVar(&metricsGroupByDir, "group-by-dir", 0, "Roll metrics up by parent directory at this depth below home")
+	metricsCmd.Flags().BoolVar(&metricsExpand, "expand", false, "With --group-by-dir, list each group's projects")
+	metricsCmd.Flags().StringVar(&metricsBaselineFile, "baseline", "", "Compare against this baseline file, or record it if missing; exits non-zero on regressions")
 	rootCmd.AddCommand(metricsCmd)
 }
 
@@ -43,19 +87,48 @@ type metricsOutput struct {
 	Days           int                            `json:"days"`
 	Project        string                         `json:"project,omitempty"`
 	Sessions       int                            `json:"total_sessions"`
+	TrivialSkipped int                            `json:"trivial_skipped,omitempty"`
+	Resumes        analyzer.ResumeAnalysis        `json:"resumes"`
 	Velocity       analyzer.VelocityMetrics       `json:"velocity"`
+	Weekday        analyzer.WeekdayPatterns       `json:"weekday_patterns"`
+	SessionCurve   analyzer.SessionCurve          `json:"session_curve"`
 	Efficiency     analyzer.EfficiencyMetrics     `json:"efficiency"`
+	ToolErrors     analyzer.ToolErrorRates        `json:"tool_errors"`
+	Struggle       analyzer.StruggleAnalysis      `json:"struggle"`
 	Satisfaction   analyzer.SatisfactionScore     `json:"satisfaction"`
+	FacetCoverage  analyzer.FacetCoverage         `json:"facet_coverage"`
 	Agents         analyzer.AgentPerformance      `json:"agents"`
+	AgentTypeDrift analyzer.AgentTypeDrift        `json:"agent_type_drift"`
+	AgentImpact    analyzer.AgentImpactAnalysis   `json:"agent_impact"`
+	AgentResults   analyzer.AgentResultUsage      `json:"agent_results"`
 	Tokens         tokenUsage                     `json:"tokens"`
 	Models         *analyzer.ModelAnalysis        `json:"models,omitempty"`
 	Commits        analyzer.CommitAnalysis        `json:"commits"`
 	Conversation   *analyzer.ConversationAnalysis `json:"conversation,omitempty"`
+	FirstPrompt    analyzer.FirstPromptAnalysis   `json:"first_prompt"`
+	Messages       analyzer.MessageEfficiency     `json:"message_efficiency"`
 	Confidence     analyzer.ConfidenceAnalysis    `json:"confidence"`
 	FrictionTrends analyzer.PersistenceAnalysis   `json:"friction_trends"`
 	CostPerOutcome analyzer.OutcomeAnalysis       `json:"cost_per_outcome"`
 	Effectiveness  []analyzer.EffectivenessResult `json:"effectiveness,omitempty"`
 	Planning       analyzer.PlanningAnalysis      `json:"planning"`
+	Groups         []outcomeGroup                 `json:"groups,omitempty"`
+	Baseline       *baselineComparison            `json:"baseline,omitempty"`
+}
+
+// outcomeGroup is the cost and outcomes of the projects under one parent
+// directory, for --group-by-dir.
+type outcomeGroup struct {
+	Dir              string  `json:"dir"`
+	Projects         int     `json:"projects"`
+	Sessions         int     `json:"sessions"`
+	TotalCost        float64 `json:"total_cost"`
+	TotalCommits     int     `json:"total_commits"`
+	CostPerCommit    float64 `json:"cost_per_commit"`
+	CostPerSession   float64 `json:"cost_per_session"`
+	GoalAchievedRate float64 `json:"goal_achieved_rate"`
+	// Members lists the group's projects, costliest first, with --expand.
+	Members []analyzer.ProjectOutcome `json:"members,omitempty"`
 }
 
 // tokenUsage captures token metrics computed from session data.
@@ -67,10 +140,12 @@ type tokenUsage struct {
 	AvgTokensPerSession int64   `json:"avg_tokens_per_session"`
 	AvgInputPerSession  int64   `json:"avg_input_per_session"`
 	AvgOutputPerSession int64   `json:"avg_output_per_session"`
+
+	ContextPressure analyzer.ContextPressureAnalysis `json:"context_pressure"`
 }
 
 func runMetrics(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -78,59 +153,203 @@ func runMetrics(cmd *cobra.Command, args []string) error {
 	if flagNoColor {
 		output.SetNoColor(true)
 	}
+	if err := checkGroupByDir(metricsGroupByDir, metricsExpand); err != nil {
+		return err
+	}
+
+	progress := startProgress(metricsProgress, flagJSON)
+	defer progress.Stop()
+
+	out, total, err := buildMetrics(cfg, metricsOptions{
+		days:           metricsDays,
+		project:        metricsProject,
+		projectPath:    metricsProjectPath,
+		includeTrivial: metricsTrivial,
+		groupByDir:     metricsGroupByDir,
+		expand:         metricsExpand,
+		baselineFile:   metricsBaselineFile,
+		// JSON output still gets the full, empty result.
+		stopWhenEmpty: !flagJSON,
+	}, progress)
+	progress.Stop()
+	if err != nil {
+		return err
+	}
+
+	// With nothing to analyze, explain why instead of rendering empty
+	// sections.
+	if out.Sessions == 0 && !flagJSON {
+		printNoSessions(os.Stdout, cfg, total, metricsScope(out.Project))
+		return nil
+	}
+
+	// JSON output mode.
+	if flagJSON {
+		if err := writeJSON(out); err != nil {
+			return err
+		}
+		return baselineError(out.Baseline)
+	}
+
+	out = anonymized(out)
+	if metricsCompact {
+		renderMetricsCompact(out)
+		return baselineError(out.Baseline)
+	}
+
+	// Render styled output.
+	renderSessionVolume(out.Velocity, out.Resumes, out.TrivialSkipped)
+	renderProductivity(out.Velocity, out.Weekday, out.SessionCurve)
+	renderEfficiency(out.Efficiency, out.ToolErrors, out.Struggle)
+	renderSatisfaction(out.Satisfaction, out.FacetCoverage)
+	renderTokenUsage(out.Tokens, out.Sessions)
+	if out.Models != nil {
+		renderModelUsage(*out.Models)
+	}
+	renderFeatureAdoption(out.Efficiency.FeatureAdoption)
+	renderAgentPerformance(out.Agents, out.AgentTypeDrift, out.AgentImpact, out.AgentResults)
+	renderCommitPatterns(out.Commits)
+
+	if out.Conversation != nil {
+		renderConversationQuality(*out.Conversation, out.FirstPrompt, out.Messages)
+	}
+
+	renderProjectConfidence(out.Confidence)
+	renderFrictionTrends(out.FrictionTrends)
+	renderCostPerOutcome(out.CostPerOutcome)
+	if metricsGroupByDir > 0 {
+		renderOutcomeGroups(out.Groups, metricsGroupByDir)
+	}
 
+	if len(out.Effectiveness) > 0 {
+		renderEffectiveness(out.Effectiveness)
+	}
+
+	renderPlanning(out.Planning)
+
+	if out.Baseline != nil {
+		renderBaseline(out.Baseline)
+	}
+
+	return baselineError(out.Baseline)
+}
+
+// metricsOptions selects the sessions buildMetrics analyzes and the extras
+// it adds, as set by the metrics command's flags.
+type metricsOptions struct {
+	days           int
+	project        string
+	projectPath    string
+	includeTrivial bool
+	groupByDir     int
+	expand         bool
+	baselineFile   string
+	// stopWhenEmpty returns as soon as filtering leaves no sessions,
+	// skipping the analyzers.
+	stopWhenEmpty bool
+}
+
+// buildMetrics parses session data and runs every metrics analyzer over the
+// sessions opts selects, reporting phases on progress, which may be nil. It
+// also returns the number of sessions before filtering, for printNoSessions.
+// The metrics command and the tui Metrics tab both render its result.
+func buildMetrics(cfg *config.Config, opts metricsOptions, progress *ui.Progress) (metricsOutput, int, error) {
 	// Load session meta data.
+	progress.Phase("Parsing sessions")
 	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
 	if err != nil {
-		return fmt.Errorf("parsing session meta: %w", err)
+		return metricsOutput{}, 0, fmt.Errorf("parsing session meta: %w", err)
 	}
+	total := len(sessions)
 
 	// Filter by project if specified.
-	if metricsProject != "" {
-		sessions = filterSessionsByProject(sessions, metricsProject)
+	project, err := resolveProjectFilter(opts.project, opts.projectPath, sessions)
+	if err != nil {
+		return metricsOutput{}, total, err
+	}
+	if project != "" {
+		sessions = filterSessionsByProject(sessions, project)
 	}
 
 	// Filter by days — applied early so all downstream analyzers see the same window.
-	sessions = analyzer.FilterSessionsByDays(sessions, metricsDays)
+	sessions = analyzer.FilterSessionsByDays(sessions, opts.days)
+
+	var trivialSkipped int
+	if !opts.includeTrivial {
+		sessions, trivialSkipped = analyzer.FilterTrivialSessions(sessions, trivialSession(cfg))
+	}
+
+	if len(sessions) == 0 && opts.stopWhenEmpty {
+		return metricsOutput{Days: opts.days, Project: project, TrivialSkipped: trivialSkipped}, total, nil
+	}
 
 	// Load facets.
+	progress.Phase("Parsing facets")
 	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
 	if err != nil {
-		return fmt.Errorf("parsing facets: %w", err)
+		return metricsOutput{}, total, fmt.Errorf("parsing facets: %w", err)
 	}
 
-	if metricsProject != "" {
-		facets = scanner.FilterFacetsByProject(facets, sessions, metricsProject)
+	if project != "" {
+		facets = scanner.FilterFacetsByProject(facets, sessions, project)
 	}
 
 	// Filter facets to the same session window as the day-filtered sessions.
 	facets = filterFacetsBySessionIDs(facets, sessions)
 
-	// Load agent tasks from session transcripts.
-	agentTasks, err := claude.ParseAgentTasks(cfg.ClaudeHome)
+	// Load agent spans from session transcripts.
+	progress.Phase("Parsing transcripts")
+	agentSpans, err := claude.ParseSessionTranscripts(cfg.ClaudeHome)
 	if err != nil {
 		// Non-fatal if transcript parsing fails.
-		agentTasks = nil
+		agentSpans = nil
 	}
 
-	// Filter agent tasks to the active session window.
-	agentTasks = filterAgentTasksBySessionIDs(agentTasks, sessions)
+	// Filter agent spans to the active session window.
+	agentSpans = filterAgentSpansBySessionIDs(agentSpans, sessions)
+	agentTasks := claude.AgentTasksFromSpans(agentSpans)
+
+	// Per-turn context sizes; sessions without them fall back to totals.
+	contextPeaks, err := claude.ParseContextPeaks(cfg.ClaudeHome)
+	if err != nil {
+		logging.Warn("parsing context peaks", "err", err)
+	}
+
+	// Commit timings within sessions; without them the session curve is
+	// estimated from totals.
+	activity, err := claude.ParseSessionActivity(cfg.ClaudeHome)
+	if err != nil {
+		logging.Warn("parsing session activity", "err", err)
+	}
 
 	// Run analyzers.
+	progress.Phase("Analyzing")
 	// Sessions are pre-filtered by days above; pass 0 to skip the internal re-filter.
 	velocity := analyzer.AnalyzeVelocity(sessions, 0)
+	weekday := analyzer.AnalyzeWeekdayPatterns(sessions, facets)
+	sessionCurve := analyzer.AnalyzeSessionCurve(sessions, activity)
 	efficiency := analyzer.AnalyzeEfficiency(sessions)
+	toolErrors := analyzer.AnalyzeToolErrorRates(sessions)
+	struggle := analyzer.AnalyzeStruggle(sessions)
 	satisfaction := analyzer.AnalyzeSatisfaction(facets)
-	agents := analyzer.AnalyzeAgents(agentTasks)
+	facetCoverage := analyzer.AnalyzeFacetCoverage(sessions, facets)
+	agents := analyzer.AnalyzeAgents(agentTasks, cfg.AgentAliases)
+	agentImpact := analyzer.AnalyzeAgentImpact(sessions, agentTasks, facets)
+	agentDrift := analyzer.AnalyzeAgentTypeDrift(agentTasks)
+	agentResults := analyzer.AnalyzeAgentResultUsage(agentSpans, time.Duration(cfg.AgentResultWindowMinutes)*time.Minute, analyzer.DefaultPricing["sonnet"])
 	commitAnalysis := analyzer.AnalyzeCommits(sessions)
 	confidence := analyzer.AnalyzeConfidence(sessions)
-	persistence := analyzer.AnalyzeFrictionPersistence(facets, sessions)
+	resumes := analyzer.AnalyzeResumePatterns(sessions, time.Duration(cfg.ResumeGapMinutes)*time.Minute)
+	persistence := analyzer.AnalyzeFrictionPersistence(facets, sessions, cfg.Friction.StaleWeeks)
 	pricing := analyzer.DefaultPricing["sonnet"]
 	cacheRatio := analyzer.NoCacheRatio()
 	if statsCache, err := claude.ParseStatsCache(cfg.ClaudeHome); err == nil && statsCache != nil {
 		cacheRatio = analyzer.ComputeCacheRatio(*statsCache)
 	}
 	outcomes := analyzer.AnalyzeOutcomes(sessions, facets, pricing, cacheRatio)
+	outcomes.Drivers = analyzer.AnalyzeCostDrivers(sessions, agentTasks, pricing, cacheRatio)
+	firstPrompt := analyzer.AnalyzeFirstPromptLength(sessions, facets, cfg.FirstPromptBuckets)
+	messages := analyzer.AnalyzeMessageEfficiency(sessions, facets)
 
 	// Load todos and file-history for planning analysis.
 	todos, _ := claude.ParseAllTodos(cfg.ClaudeHome)
@@ -139,6 +358,7 @@ func runMetrics(cmd *cobra.Command, args []string) error {
 
 	// Compute token usage from sessions.
 	tokens := computeTokenUsage(sessions)
+	tokens.ContextPressure = analyzer.AnalyzeContextPressure(sessions, contextPeaks, cfg.ContextWindowTokens)
 
 	// Analyze model usage from sessions.
 	var modelAnalysis *analyzer.ModelAnalysis
@@ -160,67 +380,82 @@ func runMetrics(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// JSON output mode.
-	if flagJSON {
-		out := metricsOutput{
-			Days:           metricsDays,
-			Project:        metricsProject,
-			Sessions:       len(sessions),
-			Velocity:       velocity,
-			Efficiency:     efficiency,
-			Satisfaction:   satisfaction,
-			Agents:         agents,
-			Tokens:         tokens,
-			Models:         modelAnalysis,
-			Commits:        commitAnalysis,
-			Conversation:   convAnalysis,
-			Confidence:     confidence,
-			FrictionTrends: persistence,
-			CostPerOutcome: outcomes,
-			Effectiveness:  effectiveness,
-			Planning:       planning,
-		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(out)
-	}
-
-	// Render styled output.
-	renderSessionVolume(velocity)
-	renderProductivity(velocity)
-	renderEfficiency(efficiency)
-	renderSatisfaction(satisfaction)
-	renderTokenUsage(sessions)
-	if modelAnalysis != nil {
-		renderModelUsage(*modelAnalysis)
+	out := metricsOutput{
+		Days:           opts.days,
+		Project:        project,
+		Sessions:       len(sessions),
+		TrivialSkipped: trivialSkipped,
+		Resumes:        resumes,
+		Velocity:       velocity,
+		SessionCurve:   sessionCurve,
+		Weekday:        weekday,
+		Efficiency:     efficiency,
+		ToolErrors:     toolErrors,
+		Struggle:       struggle,
+		Satisfaction:   satisfaction,
+		FacetCoverage:  facetCoverage,
+		Agents:         agents,
+		AgentTypeDrift: agentDrift,
+		AgentImpact:    agentImpact,
+		AgentResults:   agentResults,
+		Tokens:         tokens,
+		Models:         modelAnalysis,
+		Commits:        commitAnalysis,
+		Conversation:   convAnalysis,
+		FirstPrompt:    firstPrompt,
+		Messages:       messages,
+		Confidence:     confidence,
+		FrictionTrends: persistence,
+		CostPerOutcome: outcomes,
+		Effectiveness:  effectiveness,
+		Planning:       planning,
+	}
+	if opts.groupByDir > 0 {
+		out.Groups = groupOutcomesByDir(outcomes, projectDirGrouper(opts.groupByDir), opts.expand)
+	}
+	if opts.baselineFile != "" {
+		friction := analyzer.AnalyzeFriction(facets, cfg.Friction.RecurringThreshold)
+		aggregate := buildAggregateMetrics(friction, velocity, satisfaction, efficiency, agents)
+		out.Baseline, err = applyMetricsBaseline(opts.baselineFile, cfg.Baseline, opts.days, project, aggregate)
+		if err != nil {
+			return metricsOutput{}, total, err
+		}
 	}
-	renderFeatureAdoption(efficiency.FeatureAdoption)
-	renderAgentPerformance(agents)
-	renderCommitPatterns(commitAnalysis)
+	return out, total, nil
+}
 
-	if convAnalysis != nil {
-		renderConversationQuality(*convAnalysis)
+// metricsScope describes the metrics filters, with project the resolved
+// project path, for printNoSessions.
+func metricsScope(project string) string {
+	scope := fmt.Sprintf("in the last %d days", metricsDays)
+	if project != "" {
+		scope += fmt.Sprintf(" for project %s", project)
 	}
-
-	renderProjectConfidence(confidence)
-	renderFrictionTrends(persistence)
-	renderCostPerOutcome(outcomes)
-
-	if len(effectiveness) > 0 {
-		renderEffectiveness(effectiveness)
+	if !metricsTrivial {
+		scope += " (trivial sessions excluded)"
 	}
-
-	renderPlanning(planning)
-
-	return nil
+	return scope
 }
 
-func renderSessionVolume(v analyzer.VelocityMetrics) {
+func renderSessionVolume(v analyzer.VelocityMetrics, r analyzer.ResumeAnalysis, trivialSkipped int) {
 	fmt.Println(output.Section("Session Volume"))
 
-	fmt.Printf(" %s %s\n",
-		output.StyleLabel.Render("Total sessions"),
-		output.StyleValue.Render(fmt.Sprintf("%d", v.TotalSessions)))
+	if trivialSkipped > 0 {
+		fmt.Printf(" %s %s %s\n",
+			output.StyleLabel.Render("Total sessions"),
+			output.StyleValue.Render(fmt.Sprintf("%d", v.TotalSessions)),
+			output.StyleMuted.Render(fmt.Sprintf("(%d trivial skipped)", trivialSkipped)))
+	} else {
+		fmt.Printf(" %s %s\n",
+			output.StyleLabel.Render("Total sessions"),
+			output.StyleValue.Render(fmt.Sprintf("%d", v.TotalSessions)))
+	}
+	if r.Resumes > 0 {
+		fmt.Printf(" %s %s %s\n",
+			output.StyleLabel.Render("Logical sessions"),
+			output.StyleValue.Render(fmt.Sprintf("%d", r.LogicalSessions)),
+			output.StyleMuted.Render(fmt.Sprintf("(%d resumed within %d min merged)", r.Resumes, r.GapMinutes)))
+	}
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Avg duration"),
 		output.StyleValue.Render(fmt.Sprintf("%.0f min", v.AvgDurationMinutes)))
@@ -231,7 +466,7 @@ func renderSessionVolume(v analyzer.VelocityMetrics) {
 	fmt.Println()
 }
 
-func renderProductivity(v analyzer.VelocityMetrics) {
+func renderProductivity(v analyzer.VelocityMetrics, w analyzer.WeekdayPatterns, curve analyzer.SessionCurve) {
 	fmt.Println(output.Section("Productivity"))
 
 	fmt.Printf(" %s %s\n",
@@ -243,10 +478,44 @@ func renderProductivity(v analyzer.VelocityMetrics) {
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Files modified/session"),
 		output.StyleValue.Render(fmt.Sprintf("%.1f", v.AvgFilesModifiedPerSession)))
+	if w.Weekday.Sessions > 0 || w.Weekend.Sessions > 0 {
+		fmt.Printf(" %s %s\n", output.StyleLabel.Render("Weekdays"), dayGroupSummary(w.Weekday))
+		fmt.Printf(" %s %s\n", output.StyleLabel.Render("Weekends"), dayGroupSummary(w.Weekend))
+	}
+	if curve.CliffMinute > 0 {
+		note := fmt.Sprintf("(%d sessions kept going %d+ min after their last commit)", curve.PlateauSessions, analyzer.SessionCurveBucketMinutes)
+		if curve.Estimated {
+			note = "(estimated from session totals)"
+		}
+		fmt.Printf(" %s %s %s\n",
+			output.StyleLabel.Render("Plateau"),
+			output.StyleValue.Render(fmt.Sprintf("Sessions tend to plateau after ~%d minutes", curve.CliffMinute)),
+			output.StyleMuted.Render(note))
+		fmt.Printf(" %s\n", output.StyleMuted.Render("  Past that point, consider wrapping up or running /compact."))
+	}
 	fmt.Println()
 }
 
-func renderEfficiency(e analyzer.EfficiencyMetrics) {
+// dayGroupSummary is a one-line summary of a weekday or weekend group.
+// Friction and outcome rate are left out when the group has no facets.
+func dayGroupSummary(g analyzer.DayGroupStats) string {
+	if g.Sessions == 0 {
+		return output.StyleMuted.Render("no sessions")
+	}
+	parts := []string{
+		fmt.Sprintf("%d sessions", g.Sessions),
+		fmt.Sprintf("%.1f commits", g.AvgCommits),
+		fmt.Sprintf("%.0f min", g.AvgDurationMinutes),
+	}
+	if g.FacetSessions > 0 {
+		parts = append(parts,
+			fmt.Sprintf("%.1f friction", g.AvgFriction),
+			fmt.Sprintf("%.0f%% achieved", g.OutcomeRate*100))
+	}
+	return output.StyleValue.Render(strings.Join(parts, " · "))
+}
+
+func renderEfficiency(e analyzer.EfficiencyMetrics, toolErrors analyzer.ToolErrorRates, struggle analyzer.StruggleAnalysis) {
 	fmt.Println(output.Section("Efficiency"))
 
 	fmt.Printf(" %s %s\n",
@@ -255,6 +524,12 @@ func renderEfficiency(e analyzer.EfficiencyMetrics) {
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Interruptions/session"),
 		output.StyleValue.Render(fmt.Sprintf("%.1f", e.AvgInterruptionsPerSession)))
+	if struggle.HighStruggleSessions > 0 {
+		fmt.Printf(" %s %s %s\n",
+			output.StyleLabel.Render("High-struggle sessions"),
+			output.StyleWarning.Render(fmt.Sprintf("%d", struggle.HighStruggleSessions)),
+			output.StyleMuted.Render(fmt.Sprintf("(%.0f+ tool errors per commit; see sessions --sort struggle)", analyzer.HighStruggleScore)))
+	}
 
 	// Show top error categories if any exist.
 	if len(e.ErrorCategoryTotals) > 0 {
@@ -286,18 +561,36 @@ func renderEfficiency(e analyzer.EfficiencyMetrics) {
 		}
 	}
 
+	// Show the tools whose calls fail most often.
+	if len(toolErrors.Flakiest) > 0 {
+		fmt.Printf("\n %s\n", output.StyleMuted.Render("Flakiest tools:"))
+		for _, t := range toolErrors.Flakiest {
+			name := t.Tool
+			if len(name) > 22 {
+				name = name[:22] + ".."
+			}
+			fmt.Printf("   %s %s %s\n",
+				output.StyleLabel.Render(name),
+				output.StyleValue.Render(fmt.Sprintf("%.0f%%", t.ErrorRate*100)),
+				output.StyleMuted.Render(fmt.Sprintf("(%d of %d calls failed)", t.Errors, t.Uses)))
+		}
+	}
+
 	fmt.Println()
 }
 
-func renderSatisfaction(s analyzer.SatisfactionScore) {
+func renderSatisfaction(s analyzer.SatisfactionScore, coverage analyzer.FacetCoverage) {
 	fmt.Println(output.Section("Satisfaction"))
 
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Weighted score"),
-		output.StyleValue.Render(fmt.Sprintf("%.0f/100", s.WeightedScore)))
+		withSampleSize(fmt.Sprintf("%.0f/100", s.WeightedScore), s.SampleSize))
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Facets analyzed"),
 		output.StyleValue.Render(fmt.Sprintf("%d", s.TotalFacets)))
+	if note := facetCoverageNote(coverage); note != "" {
+		fmt.Printf(" %s\n", note)
+	}
 
 	if len(s.SatisfactionCounts) > 0 {
 		fmt.Printf("\n %s\n", output.StyleMuted.Render("Satisfaction distribution:"))
@@ -320,53 +613,115 @@ func renderSatisfaction(s analyzer.SatisfactionScore) {
 	fmt.Println()
 }
 
-func renderTokenUsage(sessions []claude.SessionMeta) {
+// withSampleSize renders a rate with the sample size behind it, e.g.
+// "85% (n=4) ⚠ low confidence" when n is below analyzer.MinSampleSize.
+func withSampleSize(value string, n int) string {
+	return output.StyleValue.Render(value) + sampleSizeNote(n)
+}
+
+// sampleSizeNote is the " (n=4) ⚠ low confidence" suffix withSampleSize adds
+// after a rate, for rates rendered with their own styling.
+func sampleSizeNote(n int) string {
+	return " " + output.StyleMuted.Render(fmt.Sprintf("(n=%d)", n)) + lowConfidenceMarker(n)
+}
+
+// lowConfidenceMarker returns " ⚠ low confidence" when n is below
+// analyzer.MinSampleSize, and "" otherwise.
+func lowConfidenceMarker(n int) string {
+	if n >= analyzer.MinSampleSize {
+		return ""
+	}
+	return " " + output.StyleWarning.Render("⚠ low confidence")
+}
+
+// facetCoverageNote returns a one-line note on how representative the
+// satisfaction score is, or "" when there are no sessions.
+func facetCoverageNote(c analyzer.FacetCoverage) string {
+	if c.TotalSessions == 0 {
+		return ""
+	}
+	line := fmt.Sprintf("Facet coverage: %d of %d sessions (%.0f%%)", c.WithFacets, c.TotalSessions, c.CoveragePct)
+	switch {
+	case c.WithFacets == 0 && c.TotalSessions >= 5:
+		return output.StyleWarning.Render(line + " — facet generation may not be running")
+	case c.CoveragePct < 50:
+		return output.StyleWarning.Render(line + " — score may not be representative")
+	default:
+		return output.StyleMuted.Render(line)
+	}
+}
+
+func renderTokenUsage(t tokenUsage, sessions int) {
 	fmt.Println(output.Section("Token Usage"))
 
-	if len(sessions) == 0 {
+	if sessions == 0 {
 		fmt.Printf(" %s\n\n", output.StyleMuted.Render("No sessions to analyze"))
 		return
 	}
 
-	var totalInput, totalOutput int64
-	for _, s := range sessions {
-		totalInput += int64(s.InputTokens)
-		totalOutput += int64(s.OutputTokens)
-	}
-	totalTokens := totalInput + totalOutput
-
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Total tokens"),
-		output.StyleValue.Render(formatTokenCount(totalTokens)))
+		output.StyleValue.Render(formatTokenCount(t.TotalTokens)))
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Input"),
-		output.StyleValue.Render(formatTokenCount(totalInput)))
+		output.StyleValue.Render(formatTokenCount(t.TotalInput)))
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Output"),
-		output.StyleValue.Render(formatTokenCount(totalOutput)))
+		output.StyleValue.Render(formatTokenCount(t.TotalOutput)))
 
-	if totalOutput > 0 {
-		ratio := float64(totalInput) / float64(totalOutput)
+	if t.TotalOutput > 0 {
 		fmt.Printf(" %s %s\n",
 			output.StyleLabel.Render("Input/output ratio"),
-			output.StyleValue.Render(fmt.Sprintf("%.1f:1", ratio)))
+			output.StyleValue.Render(fmt.Sprintf("%.1f:1", t.InputOutputRatio)))
 	}
 
-	n := int64(len(sessions))
 	fmt.Printf("\n %s\n", output.StyleMuted.Render("Per session:"))
 	fmt.Printf("   %s %s\n",
 		output.StyleLabel.Render("Avg input"),
-		output.StyleValue.Render(formatTokenCount(totalInput/n)))
+		output.StyleValue.Render(formatTokenCount(t.AvgInputPerSession)))
 	fmt.Printf("   %s %s\n",
 		output.StyleLabel.Render("Avg output"),
-		output.StyleValue.Render(formatTokenCount(totalOutput/n)))
+		output.StyleValue.Render(formatTokenCount(t.AvgOutputPerSession)))
 	fmt.Printf("   %s %s\n",
 		output.StyleLabel.Render("Avg total"),
-		output.StyleValue.Render(formatTokenCount(totalTokens/n)))
+		output.StyleValue.Render(formatTokenCount(t.AvgTokensPerSession)))
+
+	renderContextPressure(t.ContextPressure)
 
 	fmt.Println()
 }
 
+// renderContextPressure prints the near-limit count and worst offenders
+// within the token section.
+func renderContextPressure(p analyzer.ContextPressureAnalysis) {
+	if p.SessionsAnalyzed == 0 {
+		return
+	}
+	fmt.Printf("\n %s\n", output.StyleMuted.Render(fmt.Sprintf("Context window (%s tokens):", formatTokenCount(int64(p.Window)))))
+	near := fmt.Sprintf("%d of %d sessions (%.0f%%)", p.NearLimit, p.SessionsAnalyzed, p.NearLimitRate*100)
+	style := output.StyleValue
+	if p.NearLimit > 0 {
+		style = output.StyleWarning
+	}
+	fmt.Printf("   %s %s\n", output.StyleLabel.Render("Near limit"), style.Render(near))
+	for _, w := range p.Worst {
+		line := fmt.Sprintf("%-20s %s  peak %s (%.0f%%)", w.ProjectName, truncateID(w.SessionID), formatTokenCount(int64(w.PeakTokens)), w.Usage*100)
+		if w.Compactions > 0 {
+			line += fmt.Sprintf(", compacted %dx", w.Compactions)
+		}
+		if w.Estimated {
+			line += " (estimated)"
+		}
+		fmt.Printf("     %s\n", line)
+	}
+	if p.NearLimit > 0 {
+		fmt.Printf("   %s\n", output.StyleMuted.Render(fmt.Sprintf("Sessions past %.0f%% of the window degrade; use /compact or split the task.", analyzer.ContextPressureThreshold*100)))
+	}
+	if p.Estimated > 0 {
+		fmt.Printf("   %s\n", output.StyleMuted.Render(fmt.Sprintf("%d sessions lack per-turn usage; their peak is estimated from total input.", p.Estimated)))
+	}
+}
+
 func renderModelUsage(ma analyzer.ModelAnalysis) {
 	fmt.Println(output.Section("Model Usage"))
 
@@ -452,7 +807,7 @@ func renderAdoptionLine(name string, count int, total float64) {
 		output.StyleMuted.Render(fmt.Sprintf("(%.0f%%)", pct)))
 }
 
-func renderAgentPerformance(a analyzer.AgentPerformance) {
+func renderAgentPerformance(a analyzer.AgentPerformance, drift analyzer.AgentTypeDrift, impact analyzer.AgentImpactAnalysis, results analyzer.AgentResultUsage) {
 	fmt.Println(output.Section("Agent Performance"))
 
 	if a.TotalAgents == 0 {
@@ -465,10 +820,10 @@ func renderAgentPerformance(a analyzer.AgentPerformance) {
 		output.StyleValue.Render(fmt.Sprintf("%d", a.TotalAgents)))
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Success rate"),
-		output.StyleValue.Render(fmt.Sprintf("%.0f%%", a.SuccessRate*100)))
+		withSampleSize(fmt.Sprintf("%.0f%%", a.SuccessRate*100), a.SampleSize))
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Kill rate"),
-		output.StyleValue.Render(fmt.Sprintf("%.0f%%", a.KillRate*100)))
+		withSampleSize(fmt.Sprintf("%.0f%%", a.KillRate*100), a.SampleSize))
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Background ratio"),
 		output.StyleValue.Render(fmt.Sprintf("%.0f%%", a.BackgroundRatio*100)))
@@ -499,14 +854,116 @@ func renderAgentPerformance(a analyzer.AgentPerformance) {
 		})
 
 		for _, e := range entries {
-			fmt.Printf("   %-20s %3d  (%3.0f%% success)  avg %.0fs\n",
-				e.name, e.stats.Count, e.stats.SuccessRate*100, e.stats.AvgDurationMs/1000)
+			fmt.Printf("   %-20s %3d  (%3.0f%% success)  avg %.0fs%s\n",
+				e.name, e.stats.Count, e.stats.SuccessRate*100, e.stats.AvgDurationMs/1000,
+				lowConfidenceMarker(e.stats.Count))
 		}
 	}
 
+	renderAgentTypeDrift(drift)
+	renderAgentImpact(impact)
+	renderAgentResultUsage(results)
+
 	fmt.Println()
 }
 
+// renderAgentResultUsage estimates how many substantial agent results were
+// never followed by an edit or commit, and what they cost.
+func renderAgentResultUsage(r analyzer.AgentResultUsage) {
+	if r.Considered == 0 {
+		return
+	}
+	fmt.Printf("\n %s\n", output.StyleMuted.Render(
+		fmt.Sprintf("Ignored results (estimate, no edit or commit within %d min):", r.WindowMinutes)))
+	if r.Ignored == 0 {
+		fmt.Printf("   %s\n", output.StyleMuted.Render(
+			fmt.Sprintf("None of %d agents with substantial results", r.Considered)))
+		return
+	}
+	fmt.Printf("   %s of %d agents with substantial results  %s\n",
+		output.StyleWarning.Render(fmt.Sprintf("%d (%.0f%%)", r.Ignored, r.IgnoredRate*100)),
+		r.Considered,
+		output.StyleMuted.Render(fmt.Sprintf("~%s tokens, ~$%.2f", formatTokenCount(int64(r.WastedTokens)), r.EstimatedWasteUSD)))
+	for _, a := range r.TopIgnored {
+		desc := a.Description
+		if desc == "" {
+			desc = a.AgentType
+		}
+		fmt.Printf("   %-20s %-40s %s\n", a.AgentType, truncateString(desc, 40),
+			output.StyleMuted.Render(formatTokenCount(int64(a.TotalTokens))+" tokens"))
+	}
+}
+
+// renderAgentTypeDrift lists the agent types rising or falling as a share of
+// agent tasks. It prints nothing without enough weeks to compare.
+func renderAgentTypeDrift(d analyzer.AgentTypeDrift) {
+	if len(d.Types) == 0 {
+		return
+	}
+	fmt.Printf("\n %s\n", output.StyleMuted.Render(
+		fmt.Sprintf("Rising/falling agent types (first %d vs last %d of %d weeks):", d.EarlyWeeks, d.RecentWeeks, d.Weeks)))
+
+	moved := 0
+	for _, t := range d.Types {
+		var trend string
+		switch t.Trend {
+		case analyzer.DriftRising:
+			trend = output.StyleSuccess.Render("rising ")
+		case analyzer.DriftFalling:
+			trend = output.StyleWarning.Render("falling")
+		default:
+			continue
+		}
+		moved++
+		fmt.Printf("   %s %-20s %3.0f%% → %3.0f%% of agents  %s\n",
+			trend, t.AgentType, t.EarlyShare, t.RecentShare,
+			output.StyleMuted.Render(fmt.Sprintf("(%+.0f pts)", t.ShiftPoints)))
+	}
+	if moved == 0 {
+		fmt.Printf("   %s\n", output.StyleMuted.Render("The agent type mix is steady"))
+	}
+}
+
+// renderAgentImpact notes the projects where agent-using sessions commit, or
+// achieve their goals, measurably more or less often than sessions without
+// agents.
+func renderAgentImpact(impact analyzer.AgentImpactAnalysis) {
+	if len(impact.Projects) == 0 && impact.Insufficient == 0 {
+		return
+	}
+	fmt.Printf("\n %s\n", output.StyleMuted.Render("Agents help/hurt:"))
+
+	if impact.Helps == 0 && impact.Hurts == 0 {
+		msg := fmt.Sprintf("No measurable difference in %d projects", len(impact.Projects))
+		if len(impact.Projects) == 0 {
+			msg = fmt.Sprintf("Not enough sessions to compare (need %d with and %d without agents per project)",
+				analyzer.AgentImpactMinSessions, analyzer.AgentImpactMinSessions)
+		}
+		fmt.Printf("   %s\n", output.StyleMuted.Render(msg))
+		return
+	}
+
+	for _, p := range impact.Projects {
+		var verdict string
+		switch p.Verdict {
+		case "hurts":
+			verdict = output.StyleError.Render("hurt ")
+		case "helps":
+			verdict = output.StyleSuccess.Render("help ")
+		default:
+			continue
+		}
+		fmt.Printf("   %s %-20s commit rate %3.0f%% with agents vs %3.0f%% without  %s\n",
+			verdict, p.ProjectName, p.WithAgents.CommitRate*100, p.WithoutAgents.CommitRate*100,
+			output.StyleMuted.Render(fmt.Sprintf("(%d/%d sessions)", p.WithAgents.Sessions, p.WithoutAgents.Sessions)))
+		if p.OutcomeCompared {
+			fmt.Printf("   %26s goals met  %3.0f%% with agents vs %3.0f%% without  %s\n",
+				"", p.WithAgents.AchievedRate*100, p.WithoutAgents.AchievedRate*100,
+				output.StyleMuted.Render(fmt.Sprintf("(%d/%d with facets)", p.WithAgents.FacetSessions, p.WithoutAgents.FacetSessions)))
+		}
+	}
+}
+
 // formatTokenCount formats large token counts with K/M suffixes.
 func formatTokenCount(tokens int64) string {
 	switch {
@@ -550,9 +1007,10 @@ func renderCommitPatterns(ca analyzer.CommitAnalysis) {
 	if zeroCommitPct > 30 {
 		zeroCommitLabel = output.StyleError.Render(fmt.Sprintf("%.0f%% ⚠", zeroCommitPct))
 	}
-	fmt.Printf(" %s %s\n",
+	fmt.Printf(" %s %s%s\n",
 		output.StyleLabel.Render("Zero-commit rate"),
-		zeroCommitLabel)
+		zeroCommitLabel,
+		sampleSizeNote(ca.SampleSize))
 	fmt.Printf(" %s %s\n",
 		output.StyleLabel.Render("Avg commits/session"),
 		output.StyleValue.Render(fmt.Sprintf("%.1f", ca.AvgCommitsPerSession)))
@@ -560,10 +1018,20 @@ func renderCommitPatterns(ca analyzer.CommitAnalysis) {
 		output.StyleLabel.Render("Max commits (session)"),
 		output.StyleValue.Render(fmt.Sprintf("%d", ca.MaxCommitsInSession)))
 
+	if top := ca.TopZeroCommitProjects(3); len(top) > 0 {
+		fmt.Printf("\n %s\n", output.StyleMuted.Render("Most zero-commit projects:"))
+		for _, p := range top {
+			fmt.Printf("   %s %s %s\n",
+				output.StyleLabel.Render(truncateString(p.ProjectName, 24)),
+				output.StyleValue.Render(fmt.Sprintf("%.0f%%", p.ZeroCommitRate*100)),
+				output.StyleMuted.Render(fmt.Sprintf("(%d of %d sessions, %.1f commits/session)", p.ZeroCommitSessions, p.Sessions, p.AvgCommitsPerSession)))
+		}
+	}
+
 	fmt.Println()
 }
 
-func renderConversationQuality(ca analyzer.ConversationAnalysis) {
+func renderConversationQuality(ca analyzer.ConversationAnalysis, fp analyzer.FirstPromptAnalysis, me analyzer.MessageEfficiency) {
 	fmt.Println(output.Section("Conversation Quality"))
 
 	if len(ca.Sessions) == 0 {
@@ -581,9 +1049,61 @@ func renderConversationQuality(ca analyzer.ConversationAnalysis) {
 		output.StyleLabel.Render("Avg long message rate"),
 		output.StyleValue.Render(fmt.Sprintf("%.0f%%", ca.AvgLongMsgRate*100)))
 
+	renderFirstPromptNote(fp)
+	renderMessageEfficiencyNote(me)
+
 	fmt.Println()
 }
 
+// renderMessageEfficiencyNote notes whether sessions with many user messages
+// achieved their goals less often, a sign a session is going in circles and
+// worth restarting.
+func renderMessageEfficiencyNote(me analyzer.MessageEfficiency) {
+	if me.High.Sessions == 0 {
+		return
+	}
+	if !me.HighWorse {
+		fmt.Printf(" %s\n", output.StyleMuted.Render(fmt.Sprintf(
+			"Message count: no clear effect on outcomes across %d sessions", me.Sessions)))
+		return
+	}
+	fmt.Printf(" %s %s\n",
+		output.StyleLabel.Render("Long conversations"),
+		output.StyleWarning.Render(fmt.Sprintf("sessions with >%d messages achieve goals %.0f%% less often (%.0f%% vs %.0f%%, %d sessions)",
+			me.Threshold, me.OutcomeGap*100, me.High.AchievedRate*100, me.Normal.AchievedRate*100, me.High.FacetSessions)))
+	fmt.Printf(" %s\n", output.StyleMuted.Render("  Consider restarting a session that's going back and forth."))
+}
+
+// renderFirstPromptNote notes whether very short or very long first prompts
+// went worse than mid-length ones.
+func renderFirstPromptNote(fp analyzer.FirstPromptAnalysis) {
+	if fp.Sessions == 0 || len(fp.Buckets) == 0 {
+		return
+	}
+	if !fp.ShortWorse && !fp.LongWorse {
+		fmt.Printf(" %s\n", output.StyleMuted.Render(fmt.Sprintf(
+			"First prompt length: no clear effect on outcomes across %d sessions", fp.Sessions)))
+		return
+	}
+	extremes := []struct {
+		worse bool
+		kind  string
+		b     analyzer.FirstPromptBucket
+	}{
+		{fp.ShortWorse, "Short", fp.Buckets[0]},
+		{fp.LongWorse, "Long", fp.Buckets[len(fp.Buckets)-1]},
+	}
+	for _, e := range extremes {
+		if !e.worse {
+			continue
+		}
+		fmt.Printf(" %s %s\n",
+			output.StyleLabel.Render(e.kind+" first prompts"),
+			output.StyleWarning.Render(fmt.Sprintf("%s chars: %.0f%% achieved, %.1f friction/session vs %.0f%%, %.1f for %s chars (%d sessions)",
+				e.b.Label, e.b.OutcomeRate*100, e.b.AvgFriction, fp.Middle.OutcomeRate*100, fp.Middle.AvgFriction, fp.Middle.Label, e.b.FacetSessions)))
+	}
+}
+
 func renderFrictionTrends(pa analyzer.PersistenceAnalysis) {
 	fmt.Println(output.Section("Friction Trends"))
 
@@ -651,7 +1171,7 @@ func renderCostPerOutcome(o analyzer.OutcomeAnalysis) {
 	if o.GoalAchievementRate > 0 {
 		fmt.Printf(" %s %s\n",
 			output.StyleLabel.Render("Goal achievement"),
-			output.StyleValue.Render(fmt.Sprintf("%.0f%%", o.GoalAchievementRate*100)))
+			withSampleSize(fmt.Sprintf("%.0f%%", o.GoalAchievementRate*100), o.SampleSize))
 
 		achievedAvg, notAchievedAvg := analyzer.CostPerGoal(o)
 		if achievedAvg > 0 && notAchievedAvg > 0 {
@@ -679,6 +1199,23 @@ func renderCostPerOutcome(o analyzer.OutcomeAnalysis) {
 			styled)
 	}
 
+	// Rework after not_achieved sessions.
+	if o.Rework.NotAchieved > 0 {
+		rework := fmt.Sprintf("%.0f%%", o.Rework.ReworkRate*100)
+		if o.Rework.ReworkPairs > 0 {
+			rework = output.StyleWarning.Render(rework)
+		}
+		fmt.Printf(" %s %s %s\n",
+			output.StyleLabel.Render("Rework rate"),
+			output.StyleValue.Render(rework),
+			output.StyleMuted.Render(fmt.Sprintf("(%d of %d sessions were rework, after %d not_achieved)", o.Rework.ReworkPairs, o.Rework.TotalSessions, o.Rework.NotAchieved)))
+		if o.Rework.ReworkPairs > 0 {
+			fmt.Printf(" %s %s\n",
+				output.StyleLabel.Render("Est. wasted on rework"),
+				output.StyleValue.Render(fmt.Sprintf("$%.2f", o.Rework.ReworkCost)))
+		}
+	}
+
 	// Per-project breakdown (top 5).
 	if len(o.ByProject) > 0 {
 		fmt.Printf("\n %s\n", output.StyleMuted.Render("By project:"))
@@ -696,9 +1233,114 @@ func renderCostPerOutcome(o analyzer.OutcomeAnalysis) {
 		}
 	}
 
+	renderCostDrivers(o.Drivers)
+
 	fmt.Println()
 }
 
+// groupOutcomesByDir rolls o's per-project outcomes up into the groups dirOf
+// gives their paths, costliest first. With expand, each group lists its
+// projects as Members.
+func groupOutcomesByDir(o analyzer.OutcomeAnalysis, dirOf func(path string) string, expand bool) []outcomeGroup {
+	members := make(map[string][]analyzer.ProjectOutcome)
+	for _, p := range o.ByProject {
+		dir := dirOf(p.ProjectPath)
+		members[dir] = append(members[dir], p)
+	}
+
+	var groups []outcomeGroup
+	for _, g := range analyzer.GroupProjectOutcomes(o.Sessions, dirOf) {
+		og := outcomeGroup{
+			Dir:              g.ProjectPath,
+			Projects:         len(members[g.ProjectPath]),
+			Sessions:         g.Sessions,
+			TotalCost:        g.TotalCost,
+			TotalCommits:     g.TotalCommits,
+			CostPerCommit:    g.CostPerCommit,
+			CostPerSession:   g.CostPerSession,
+			GoalAchievedRate: g.GoalAchievedRate,
+		}
+		if expand {
+			og.Members = members[g.ProjectPath]
+		}
+		groups = append(groups, og)
+	}
+	return groups
+}
+
+func renderOutcomeGroups(groups []outcomeGroup, depth int) {
+	fmt.Println(output.Section(fmt.Sprintf("Projects by Directory (depth %d)", depth)))
+
+	if len(groups) == 0 {
+		fmt.Printf(" %s\n\n", output.StyleMuted.Render("No sessions to analyze"))
+		return
+	}
+
+	tbl := output.NewTable("Directory", "Projects", "Sessions", "Cost", "Cost/commit")
+	row := func(name string, projects string, sessions, commits int, cost, cpc float64) {
+		perCommit := "N/A"
+		if commits > 0 {
+			perCommit = fmt.Sprintf("$%.2f", cpc)
+		}
+		tbl.AddRow(name, projects, fmt.Sprintf("%d", sessions), fmt.Sprintf("$%.2f", cost), perCommit)
+	}
+	for _, g := range groups {
+		row(g.Dir, fmt.Sprintf("%d", g.Projects), g.Sessions, g.TotalCommits, g.TotalCost, g.CostPerCommit)
+		for _, p := range g.Members {
+			row(output.StyleMuted.Render("  "+p.ProjectName), "", p.Sessions, p.TotalCommits, p.TotalCost, p.CostPerCommit)
+		}
+	}
+	tbl.Print()
+	fmt.Println()
+}
+
+// renderCostDrivers lists the costliest tool categories and agent types and,
+// when recent sessions cost markedly more, what grew.
+func renderCostDrivers(d analyzer.CostDrivers) {
+	if len(d.Top) == 0 {
+		return
+	}
+	fmt.Printf("\n %s\n", output.StyleMuted.Render("Top cost drivers:"))
+	for _, c := range d.Top {
+		fmt.Printf("   %-24s %s  %s\n", costDriverLabel(c), costDriverAmount(c),
+			output.StyleMuted.Render(fmt.Sprintf("(%.0f%%)", c.Share*100)))
+	}
+
+	if s := d.Spike; s != nil {
+		fmt.Printf("\n %s\n", output.StyleWarning.Render(fmt.Sprintf(
+			"Cost spike: last %d days at $%.2f/session, up %.0f%% from $%.2f",
+			s.Days, s.RecentPerSession, s.ChangePercent, s.BaselinePerSession)))
+		for _, c := range s.Drivers {
+			fmt.Printf("   %-24s %s\n", costDriverLabel(c), costDriverAmount(c)+"/session more")
+		}
+	}
+	for _, c := range d.Top {
+		if c.Approximate {
+			fmt.Printf(" %s\n", output.StyleMuted.Render("~ approximate: split by tool calls, as tokens per tool aren't recorded"))
+			break
+		}
+	}
+}
+
+// costDriverLabel names a cost driver, e.g. "shell tools" or "Explore agents".
+func costDriverLabel(c analyzer.CostDriver) string {
+	switch {
+	case c.Kind == analyzer.CostDriverAgent:
+		return c.Name + " agents"
+	case c.Name == "none":
+		return "no tool calls"
+	}
+	return c.Name + " tools"
+}
+
+// costDriverAmount formats a driver's cost, marked "~" when approximate.
+func costDriverAmount(c analyzer.CostDriver) string {
+	if c.Approximate {
+		return fmt.Sprintf("~$%.2f", c.Cost)
+	}
+	return fmt.Sprintf("$%.2f", c.Cost)
+}
+
 func renderEffectiveness(results []analyzer.EffectivenessResult) {
 	fmt.Println(output.Section("CLAUDE.md Effectiveness"))
 
@@ -901,6 +1543,24 @@ func filterFacetsBySessionIDs(facets []claude.SessionFacet, sessions []claude.Se
 	return filtered
 }
 
+// filterAgentSpansBySessionIDs keeps only agent spans whose SessionID is in the given sessions.
+func filterAgentSpansBySessionIDs(spans []claude.AgentSpan, sessions []claude.SessionMeta) []claude.AgentSpan {
+	if len(sessions) == 0 {
+		return nil
+	}
+	ids := make(map[string]struct{}, len(sessions))
+	for _, s := range sessions {
+		ids[s.SessionID] = struct{}{}
+	}
+	var filtered []claude.AgentSpan
+	for _, s := range spans {
+		if _, ok := ids[s.SessionID]; ok {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
 // filterAgentTasksBySessionIDs keeps only agent tasks whose SessionID is in the given sessions.
 func filterAgentTasksBySessionIDs(tasks []claude.AgentTask, sessions []claude.SessionMeta) []claude.AgentTask {
 	if len(sessions) == 0 {
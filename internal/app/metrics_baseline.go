@@ -0,0 +1,192 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+// metricsBaseline is the file metrics --baseline writes on its first run
+// and compares later runs against.
+type metricsBaseline struct {
+	CreatedAt time.Time `json:"created_at"`
+	Days      int       `json:"days"`
+	Project   string    `json:"project,omitempty"`
+	// Metrics holds the aggregate metrics track records, by name.
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// baselineCheck compares one metric against its baseline value.
+type baselineCheck struct {
+	Name     string  `json:"name"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+	Delta    float64 `json:"delta"`
+	// Direction is "improved", "regressed", or "unchanged".
+	Direction    string  `json:"direction"`
+	TolerancePct float64 `json:"tolerance_pct"`
+	// Regressed is set when the metric got worse by more than its tolerance.
+	Regressed bool `json:"regressed"`
+}
+
+// baselineComparison is the metrics --baseline result.
+type baselineComparison struct {
+	Path string `json:"path"`
+	// Written is set when no baseline existed and the current metrics were
+	// saved as the new one; nothing is compared then.
+	Written     bool            `json:"written"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Checks      []baselineCheck `json:"checks,omitempty"`
+	Regressions int             `json:"regressions"`
+}
+
+// checkBaselineTolerances rejects tolerance overrides for metrics the
+// baseline doesn't record, which are most likely typos.
+func checkBaselineTolerances(b config.Baseline) error {
+	var unknown []string
+	for name := range b.Tolerances {
+		if _, ok := metricDirection[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	valid := make([]string, 0, len(metricDirection))
+	for name := range metricDirection {
+		valid = append(valid, name)
+	}
+	sort.Strings(unknown)
+	sort.Strings(valid)
+	return fmt.Errorf("unknown metric in baseline.tolerances: %s (valid: %s)", strings.Join(unknown, ", "), strings.Join(valid, ", "))
+}
+
+// applyMetricsBaseline compares current against the baseline at path, or
+// writes current there as the baseline when the file doesn't exist. A
+// baseline recorded for a different --days or project is an error, since
+// its numbers aren't comparable.
+func applyMetricsBaseline(path string, tolerances config.Baseline, days int, project string, current map[string]float64) (*baselineComparison, error) {
+	if err := checkBaselineTolerances(tolerances); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		base := metricsBaseline{CreatedAt: time.Now().UTC(), Days: days, Project: project, Metrics: current}
+		data, err := json.MarshalIndent(base, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return nil, fmt.Errorf("writing baseline: %w", err)
+		}
+		return &baselineComparison{Path: path, Written: true, CreatedAt: base.CreatedAt}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+
+	var base metricsBaseline
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
+	}
+	if base.Days != days {
+		return nil, fmt.Errorf("baseline %s was recorded with --days %d; run with --days %d or record a new baseline", path, base.Days, base.Days)
+	}
+	if base.Project != project {
+		recorded := base.Project
+		if recorded == "" {
+			recorded = "all projects"
+		}
+		return nil, fmt.Errorf("baseline %s was recorded for %s; use the same project filter or record a new baseline", path, recorded)
+	}
+
+	cmp := &baselineComparison{Path: path, CreatedAt: base.CreatedAt}
+	cmp.Checks = compareToBaseline(base.Metrics, current, tolerances)
+	for _, c := range cmp.Checks {
+		if c.Regressed {
+			cmp.Regressions++
+		}
+	}
+	return cmp, nil
+}
+
+// compareToBaseline checks each metric in both base and current, sorted by
+// name. A metric regresses when it moved in its worse direction, per
+// metricDirection, by more than its tolerance percent of the baseline value;
+// from a baseline of zero, any worsening regresses.
+func compareToBaseline(base, current map[string]float64, tolerances config.Baseline) []baselineCheck {
+	checks := []baselineCheck{}
+	for name, was := range base {
+		now, ok := current[name]
+		if !ok {
+			continue
+		}
+		delta := now - was
+		c := baselineCheck{
+			Name:         name,
+			Baseline:     was,
+			Current:      now,
+			Delta:        delta,
+			Direction:    deltaDirection(name, delta),
+			TolerancePct: tolerances.Tolerance(name),
+		}
+		c.Regressed = c.Direction == "regressed" && math.Abs(delta) > math.Abs(was)*c.TolerancePct/100
+		checks = append(checks, c)
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return checks
+}
+
+// baselineError reports the regressions in cmp, for a non-zero exit.
+func baselineError(cmp *baselineComparison) error {
+	if cmp == nil || cmp.Regressions == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d metrics regressed against baseline %s", cmp.Regressions, len(cmp.Checks), cmp.Path)
+}
+
+// renderBaseline prints the metrics --baseline result: a note when the
+// baseline was written, otherwise each metric that moved.
+func renderBaseline(cmp *baselineComparison) {
+	fmt.Println(output.Section("Baseline"))
+
+	if cmp.Written {
+		fmt.Printf(" %s\n", output.StyleSuccess.Render("No baseline found; recorded the current metrics to "+cmp.Path))
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf(" %s\n", output.StyleMuted.Render(fmt.Sprintf("Compared with %s, recorded %s", cmp.Path, cmp.CreatedAt.Local().Format("2006-01-02 15:04"))))
+	for _, c := range cmp.Checks {
+		if c.Direction == "unchanged" {
+			continue
+		}
+		unit := metricUnits[c.Name]
+		change := fmt.Sprintf("%s → %s (%s)", formatUnitValue(unit, c.Baseline), formatUnitValue(unit, c.Current), formatUnitDelta(unit, c.Delta))
+		style := output.StyleSuccess
+		switch {
+		case c.Regressed:
+			style = output.StyleError
+		case c.Direction == "regressed":
+			style = output.StyleWarning
+			change += fmt.Sprintf(" within %g%%", c.TolerancePct)
+		}
+		fmt.Printf(" %s %s\n", output.StyleLabel.Render(metricShortName(c.Name)), style.Render(change))
+	}
+	if cmp.Regressions == 0 {
+		fmt.Printf(" %s\n", output.StyleSuccess.Render(fmt.Sprintf("No regressions across %d metrics", len(cmp.Checks))))
+	} else {
+		fmt.Printf(" %s\n", output.StyleError.Render(fmt.Sprintf("%d of %d metrics regressed beyond tolerance", cmp.Regressions, len(cmp.Checks))))
+	}
+	fmt.Println()
+}
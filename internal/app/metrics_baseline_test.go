@@ -0,0 +1,83 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyMetricsBaseline_WritesThenCompares(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	tolerances := config.Baseline{TolerancePct: 5}
+	base := map[string]float64{
+		"avg_tool_errors":         2,
+		"avg_commits_per_session": 1,
+		"satisfaction_score":      80,
+		"total_friction_events":   0,
+	}
+
+	cmp, err := applyMetricsBaseline(path, tolerances, 30, "", base)
+	require.NoError(t, err)
+	assert.True(t, cmp.Written)
+	assert.Empty(t, cmp.Checks)
+	assert.NoError(t, baselineError(cmp))
+
+	current := map[string]float64{
+		"avg_tool_errors":         2.05, // worse, within 5%
+		"avg_commits_per_session": 0.5,  // worse, beyond 5%
+		"satisfaction_score":      90,   // better
+		"total_friction_events":   1,    // worse from zero
+		"agent_total":             3,    // not in the baseline
+	}
+	cmp, err = applyMetricsBaseline(path, tolerances, 30, "", current)
+	require.NoError(t, err)
+	assert.False(t, cmp.Written)
+	require.Len(t, cmp.Checks, 4)
+
+	byName := make(map[string]baselineCheck)
+	for _, c := range cmp.Checks {
+		byName[c.Name] = c
+	}
+	assert.Equal(t, "regressed", byName["avg_tool_errors"].Direction)
+	assert.False(t, byName["avg_tool_errors"].Regressed)
+	assert.True(t, byName["avg_commits_per_session"].Regressed)
+	assert.Equal(t, "improved", byName["satisfaction_score"].Direction)
+	assert.True(t, byName["total_friction_events"].Regressed)
+	assert.Equal(t, 2, cmp.Regressions)
+	assert.Error(t, baselineError(cmp))
+}
+
+func TestApplyMetricsBaseline_PerMetricTolerance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	tolerances := config.Baseline{TolerancePct: 5, Tolerances: map[string]float64{"avg_commits_per_session": 60}}
+	_, err := applyMetricsBaseline(path, tolerances, 30, "", map[string]float64{"avg_commits_per_session": 1})
+	require.NoError(t, err)
+
+	cmp, err := applyMetricsBaseline(path, tolerances, 30, "", map[string]float64{"avg_commits_per_session": 0.5})
+	require.NoError(t, err)
+	require.Len(t, cmp.Checks, 1)
+	assert.Equal(t, 60.0, cmp.Checks[0].TolerancePct)
+	assert.Zero(t, cmp.Regressions)
+}
+
+func TestApplyMetricsBaseline_MismatchedScope(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	metrics := map[string]float64{"total_sessions": 10}
+	_, err := applyMetricsBaseline(path, config.Baseline{}, 30, "/src/app", metrics)
+	require.NoError(t, err)
+
+	_, err = applyMetricsBaseline(path, config.Baseline{}, 7, "/src/app", metrics)
+	assert.ErrorContains(t, err, "--days 30")
+	_, err = applyMetricsBaseline(path, config.Baseline{}, 30, "", metrics)
+	assert.ErrorContains(t, err, "/src/app")
+}
+
+func TestApplyMetricsBaseline_UnknownTolerance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	_, err := applyMetricsBaseline(path, config.Baseline{Tolerances: map[string]float64{"avg_tool_error": 10}}, 30, "", nil)
+	assert.ErrorContains(t, err, "avg_tool_error")
+	assert.NoFileExists(t, path)
+}
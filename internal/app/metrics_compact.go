@@ -0,0 +1,375 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+// compactLabelWidth pads compact labels so values line up.
+const compactLabelWidth = 14
+
+// renderMetricsCompact prints metrics with one line per section, in the same
+// order as the full rendering.
+func renderMetricsCompact(m metricsOutput) {
+	for _, line := range compactMetricsLines(m) {
+		fmt.Println(line)
+	}
+}
+
+// compactMetricsLines returns the compact line for each section. Optional
+// sections are left out under the same conditions as the full rendering.
+func compactMetricsLines(m metricsOutput) []string {
+	lines := []string{
+		compactSessionVolume(m.Velocity, m.Resumes),
+		compactProductivity(m.Velocity, m.Weekday, m.SessionCurve),
+		compactEfficiency(m.Efficiency, m.ToolErrors, m.Struggle),
+		compactSatisfaction(m.Satisfaction, m.FacetCoverage),
+		compactTokens(m.Sessions, m.Tokens),
+	}
+	if m.Models != nil {
+		lines = append(lines, compactModels(*m.Models))
+	}
+	lines = append(lines,
+		compactFeatureAdoption(m.Efficiency.FeatureAdoption),
+		compactAgents(m.Agents, m.AgentTypeDrift, m.AgentImpact, m.AgentResults),
+		compactCommits(m.Commits),
+	)
+	if m.Conversation != nil {
+		lines = append(lines, compactConversation(*m.Conversation, m.FirstPrompt, m.Messages))
+	}
+	lines = append(lines,
+		compactConfidence(m.Confidence),
+		compactFrictionTrends(m.FrictionTrends),
+		compactCostPerOutcome(m.CostPerOutcome),
+	)
+	if len(m.Effectiveness) > 0 {
+		lines = append(lines, compactEffectiveness(m.Effectiveness))
+	}
+	if m.Planning.Todos.TotalTasks > 0 || m.Planning.FileChurn.TotalSessions > 0 {
+		lines = append(lines, compactPlanning(m.Planning))
+	}
+	if m.Baseline != nil {
+		lines = append(lines, compactBaseline(*m.Baseline))
+	}
+	return lines
+}
+
+// compactLine renders a section label followed by its comma-separated parts.
+// The label and value styles have fixed widths meant for one value per line,
+// so compact mode drops them.
+func compactLine(label string, parts ...string) string {
+	return fmt.Sprintf("%s %s",
+		output.StyleLabel.UnsetWidth().Render(fmt.Sprintf("%-*s", compactLabelWidth, label+":")),
+		strings.Join(parts, output.StyleMuted.Render(", ")))
+}
+
+// compactEmpty renders a section with nothing to show.
+func compactEmpty(label, msg string) string {
+	return compactLine(label, output.StyleMuted.Render(msg))
+}
+
+// compactValue formats a part in the value style, without its fixed width.
+func compactValue(format string, args ...any) string {
+	return output.StyleValue.UnsetWidth().Render(fmt.Sprintf(format, args...))
+}
+
+func compactSessionVolume(v analyzer.VelocityMetrics, r analyzer.ResumeAnalysis) string {
+	sessions := compactValue("%d sessions", v.TotalSessions)
+	if r.Resumes > 0 {
+		sessions = compactValue("%d sessions (%d logical)", v.TotalSessions, r.LogicalSessions)
+	}
+	return compactLine("Sessions",
+		sessions,
+		compactValue("%.0fmin avg", v.AvgDurationMinutes),
+		compactValue("%.1f msgs/sess", v.AvgMessagesPerSession))
+}
+
+func compactProductivity(v analyzer.VelocityMetrics, w analyzer.WeekdayPatterns, curve analyzer.SessionCurve) string {
+	parts := []string{
+		compactValue("%.0f lines/sess", v.AvgLinesAddedPerSession),
+		compactValue("%.1f commits/sess", v.AvgCommitsPerSession),
+		compactValue("%.1f files/sess", v.AvgFilesModifiedPerSession),
+	}
+	if w.Weekday.Sessions > 0 && w.Weekend.Sessions > 0 {
+		parts = append(parts, compactValue("weekday %.1f vs weekend %.1f commits", w.Weekday.AvgCommits, w.Weekend.AvgCommits))
+	}
+	if curve.CliffMinute > 0 {
+		parts = append(parts, compactValue("plateau ~%dmin", curve.CliffMinute))
+	}
+	return compactLine("Productivity", parts...)
+}
+
+func compactEfficiency(e analyzer.EfficiencyMetrics, toolErrors analyzer.ToolErrorRates, struggle analyzer.StruggleAnalysis) string {
+	parts := []string{
+		compactValue("%.1f tool errors/sess", e.AvgToolErrorsPerSession),
+		compactValue("%.1f interruptions/sess", e.AvgInterruptionsPerSession),
+	}
+	if sorted := sortMapByValue(e.ErrorCategoryTotals); len(sorted) > 0 {
+		parts = append(parts, compactValue("top error %s (%d)", sorted[0].key, sorted[0].value))
+	}
+	if sorted := sortMapByValue(e.ToolUsageTotals); len(sorted) > 0 {
+		parts = append(parts, compactValue("top tool %s (%d)", sorted[0].key, sorted[0].value))
+	}
+	if len(toolErrors.Flakiest) > 0 {
+		t := toolErrors.Flakiest[0]
+		parts = append(parts, compactValue("flakiest %s (%.0f%%)", t.Tool, t.ErrorRate*100))
+	}
+	if struggle.HighStruggleSessions > 0 {
+		parts = append(parts, compactValue("%d high-struggle", struggle.HighStruggleSessions))
+	}
+	return compactLine("Efficiency", parts...)
+}
+
+func compactSatisfaction(s analyzer.SatisfactionScore, c analyzer.FacetCoverage) string {
+	parts := []string{
+		compactValue("%.0f/100", s.WeightedScore),
+		compactValue("%d facets", s.TotalFacets),
+	}
+	if c.TotalSessions > 0 {
+		coverage := fmt.Sprintf("%.0f%% coverage", c.CoveragePct)
+		if c.CoveragePct < 50 {
+			parts = append(parts, output.StyleWarning.Render(coverage))
+		} else {
+			parts = append(parts, output.StyleMuted.Render(coverage))
+		}
+	}
+	return compactLine("Satisfaction", parts...)
+}
+
+func compactTokens(sessions int, t tokenUsage) string {
+	if sessions == 0 {
+		return compactEmpty("Tokens", "no sessions")
+	}
+	parts := []string{
+		compactValue("%s total", formatTokenCount(t.TotalTokens)),
+		compactValue("%s in / %s out", formatTokenCount(t.TotalInput), formatTokenCount(t.TotalOutput)),
+		compactValue("%s/sess", formatTokenCount(t.AvgTokensPerSession)),
+	}
+	if p := t.ContextPressure; p.SessionsAnalyzed > 0 {
+		near := fmt.Sprintf("%d/%d near context limit", p.NearLimit, p.SessionsAnalyzed)
+		if p.NearLimit > 0 {
+			parts = append(parts, output.StyleWarning.Render(near))
+		} else {
+			parts = append(parts, compactValue("%s", near))
+		}
+	}
+	return compactLine("Tokens", parts...)
+}
+
+func compactModels(ma analyzer.ModelAnalysis) string {
+	if len(ma.Models) == 0 {
+		return compactEmpty("Models", "no model usage data")
+	}
+	var parts []string
+	for i, m := range ma.Models {
+		if i == 3 {
+			parts = append(parts, output.StyleMuted.Render(fmt.Sprintf("+%d more", len(ma.Models)-3)))
+			break
+		}
+		parts = append(parts, compactValue("%s $%.2f (%.0f%%)", normalizeModelName(m.ModelName), m.CostUSD, m.CostPercent))
+	}
+	return compactLine("Models", parts...)
+}
+
+func compactFeatureAdoption(fa analyzer.FeatureAdoption) string {
+	if fa.TotalSessions == 0 {
+		return compactEmpty("Features", "no sessions")
+	}
+	total := float64(fa.TotalSessions)
+	pct := func(n int) float64 { return float64(n) / total * 100 }
+	return compactLine("Features",
+		compactValue("agents %.0f%%", pct(fa.TaskAgentSessions)),
+		compactValue("MCP %.0f%%", pct(fa.MCPSessions)),
+		compactValue("web search %.0f%%", pct(fa.WebSearchSessions)),
+		compactValue("web fetch %.0f%%", pct(fa.WebFetchSessions)))
+}
+
+func compactAgents(a analyzer.AgentPerformance, drift analyzer.AgentTypeDrift, impact analyzer.AgentImpactAnalysis, results analyzer.AgentResultUsage) string {
+	if a.TotalAgents == 0 {
+		return compactEmpty("Agents", "no agent tasks")
+	}
+	parts := []string{
+		compactValue("%d spawned", a.TotalAgents),
+		compactValue("%.0f%% success", a.SuccessRate*100),
+		compactValue("%.0f%% killed", a.KillRate*100),
+		compactValue("%.0fs avg", a.AvgDurationMs/1000),
+	}
+	for _, t := range drift.Types {
+		switch t.Trend {
+		case analyzer.DriftRising:
+			parts = append(parts, output.StyleSuccess.Render(t.AgentType+" rising"))
+		case analyzer.DriftFalling:
+			parts = append(parts, output.StyleWarning.Render(t.AgentType+" falling"))
+		}
+	}
+	if impact.Helps > 0 {
+		parts = append(parts, output.StyleSuccess.Render(fmt.Sprintf("help in %d projects", impact.Helps)))
+	}
+	if impact.Hurts > 0 {
+		parts = append(parts, output.StyleError.Render(fmt.Sprintf("hurt in %d projects", impact.Hurts)))
+	}
+	if results.Ignored > 0 {
+		parts = append(parts, output.StyleWarning.Render(fmt.Sprintf("~%d results ignored", results.Ignored)))
+	}
+	return compactLine("Agents", parts...)
+}
+
+func compactCommits(ca analyzer.CommitAnalysis) string {
+	if ca.TotalSessions == 0 {
+		return compactEmpty("Commits", "no sessions")
+	}
+	zero := fmt.Sprintf("%.0f%% zero-commit", ca.ZeroCommitRate*100)
+	if ca.ZeroCommitRate*100 > 30 {
+		zero = output.StyleError.Render(zero)
+	} else {
+		zero = compactValue("%s", zero)
+	}
+	return compactLine("Commits",
+		zero,
+		compactValue("%.1f avg", ca.AvgCommitsPerSession),
+		compactValue("%d max", ca.MaxCommitsInSession))
+}
+
+func compactConversation(ca analyzer.ConversationAnalysis, fp analyzer.FirstPromptAnalysis, me analyzer.MessageEfficiency) string {
+	if len(ca.Sessions) == 0 {
+		return compactEmpty("Conversation", "no conversation data")
+	}
+	parts := []string{
+		compactValue("%.0f%% corrections", ca.AvgCorrectionRate*100),
+		compactValue("%d high-correction sessions", ca.HighCorrectionSessions),
+		compactValue("%.0f%% long messages", ca.AvgLongMsgRate*100),
+	}
+	if fp.ShortWorse {
+		parts = append(parts, output.StyleWarning.Render("short first prompts go worse"))
+	}
+	if fp.LongWorse {
+		parts = append(parts, output.StyleWarning.Render("long first prompts go worse"))
+	}
+	if me.HighWorse {
+		parts = append(parts, output.StyleWarning.Render(fmt.Sprintf(">%d messages go worse", me.Threshold)))
+	}
+	return compactLine("Conversation", parts...)
+}
+
+func compactConfidence(ca analyzer.ConfidenceAnalysis) string {
+	if len(ca.Projects) == 0 {
+		return compactEmpty("Confidence", "not enough data")
+	}
+	parts := []string{compactValue("%d projects", len(ca.Projects))}
+	if ca.LowConfidenceCount > 0 {
+		parts = append(parts, output.StyleError.Render(fmt.Sprintf("%d low confidence", ca.LowConfidenceCount)))
+	}
+	return compactLine("Confidence", parts...)
+}
+
+func compactFrictionTrends(pa analyzer.PersistenceAnalysis) string {
+	if len(pa.Patterns) == 0 {
+		return compactEmpty("Friction", "no persistence data")
+	}
+	stale := fmt.Sprintf("%d stale", pa.StaleCount)
+	if pa.StaleCount > 0 {
+		stale = output.StyleWarning.Render(stale)
+	} else {
+		stale = compactValue("%s", stale)
+	}
+	return compactLine("Friction",
+		stale,
+		compactValue("%d improving", pa.ImprovingCount),
+		compactValue("%d worsening", pa.WorseningCount))
+}
+
+func compactCostPerOutcome(o analyzer.OutcomeAnalysis) string {
+	if len(o.Sessions) == 0 {
+		return compactEmpty("Cost", "no sessions")
+	}
+	parts := []string{
+		compactValue("$%.2f total", o.TotalCost),
+		compactValue("$%.2f/sess", o.AvgCostPerSession),
+	}
+	if o.TotalCommits > 0 {
+		parts = append(parts, compactValue("$%.2f/commit", o.AvgCostPerCommit))
+	}
+	if o.GoalAchievementRate > 0 {
+		parts = append(parts, compactValue("%.0f%% goals", o.GoalAchievementRate*100))
+	}
+	switch o.CostPerCommitTrend {
+	case "improving":
+		parts = append(parts, output.StyleSuccess.Render("improving"))
+	case "worsening":
+		parts = append(parts, output.StyleError.Render("worsening"))
+	}
+	if o.Rework.ReworkPairs > 0 {
+		parts = append(parts, output.StyleWarning.Render(fmt.Sprintf("%.0f%% rework", o.Rework.ReworkRate*100)))
+	}
+	if len(o.Drivers.Top) > 0 {
+		parts = append(parts, compactValue("%s", "top: "+costDriverLabel(o.Drivers.Top[0])))
+	}
+	if s := o.Drivers.Spike; s != nil {
+		parts = append(parts, output.StyleWarning.Render(fmt.Sprintf("spike +%.0f%%", s.ChangePercent)))
+	}
+	return compactLine("Cost", parts...)
+}
+
+func compactEffectiveness(results []analyzer.EffectivenessResult) string {
+	var effective, neutral, regression int
+	for _, r := range results {
+		switch r.Verdict {
+		case "effective":
+			effective++
+		case "neutral":
+			neutral++
+		case "regression":
+			regression++
+		}
+	}
+	if effective+neutral+regression == 0 {
+		return compactEmpty("CLAUDE.md", "insufficient before/after data")
+	}
+	parts := []string{
+		output.StyleSuccess.Render(fmt.Sprintf("%d effective", effective)),
+		compactValue("%d neutral", neutral),
+	}
+	regressions := fmt.Sprintf("%d regression", regression)
+	if regression > 0 {
+		parts = append(parts, output.StyleError.Render(regressions))
+	} else {
+		parts = append(parts, compactValue("%s", regressions))
+	}
+	return compactLine("CLAUDE.md", parts...)
+}
+
+func compactPlanning(p analyzer.PlanningAnalysis) string {
+	var parts []string
+	if p.Todos.TotalTasks > 0 {
+		parts = append(parts, compactValue("%d tasks (%.0f%% done)", p.Todos.TotalTasks, p.Todos.CompletionRate*100))
+		if p.Todos.PendingTasks > 0 {
+			parts = append(parts, output.StyleError.Render(fmt.Sprintf("%d pending", p.Todos.PendingTasks)))
+		}
+	}
+	if p.FileChurn.TotalSessions > 0 {
+		parts = append(parts, compactValue("%d files, %.1f edits/file", p.FileChurn.TotalFiles, p.FileChurn.AvgEditsPerFile))
+	}
+	return compactLine("Planning", parts...)
+}
+
+func compactBaseline(b baselineComparison) string {
+	if b.Written {
+		return compactLine("Baseline", output.StyleSuccess.Render("recorded to "+b.Path))
+	}
+	parts := []string{compactValue("%d metrics vs %s", len(b.Checks), b.Path)}
+	if b.Regressions > 0 {
+		var names []string
+		for _, c := range b.Checks {
+			if c.Regressed {
+				names = append(names, c.Name)
+			}
+		}
+		parts = append(parts, output.StyleError.Render(fmt.Sprintf("%d regressed (%s)", b.Regressions, strings.Join(names, ", "))))
+	} else {
+		parts = append(parts, output.StyleSuccess.Render("no regressions"))
+	}
+	return compactLine("Baseline", parts...)
+}
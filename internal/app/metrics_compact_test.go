@@ -0,0 +1,92 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+func TestCompactMetricsLines_OneLinePerSection(t *testing.T) {
+	output.SetNoColor(true)
+	defer output.SetNoColor(false)
+
+	m := metricsOutput{
+		Sessions:   2,
+		Velocity:   analyzer.VelocityMetrics{TotalSessions: 2, AvgDurationMinutes: 38, AvgCommitsPerSession: 2.1},
+		Tokens:     tokenUsage{TotalTokens: 1_500_000, TotalInput: 1_200_000, TotalOutput: 300_000, AvgTokensPerSession: 750_000},
+		Models:     &analyzer.ModelAnalysis{Models: []analyzer.ModelBreakdown{{ModelName: "claude-sonnet-4-5", CostUSD: 1.5, CostPercent: 100}}},
+		Planning:   analyzer.PlanningAnalysis{Todos: analyzer.TodoAnalysis{TotalTasks: 4, CompletionRate: 0.75, PendingTasks: 1}},
+		ToolErrors: analyzer.ToolErrorRates{Flakiest: []analyzer.ToolErrorRate{{Tool: "Bash", Uses: 40, Errors: 16, ErrorRate: 0.4}}},
+	}
+
+	lines := compactMetricsLines(m)
+	// 11 always-present sections plus Models and Planning.
+	if len(lines) != 13 {
+		t.Fatalf("expected 13 lines, got %d:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "\n") {
+			t.Errorf("compact line spans multiple lines: %q", line)
+		}
+	}
+
+	if !strings.HasPrefix(lines[0], "Sessions:") || !strings.Contains(lines[0], "2 sessions, 38min avg") {
+		t.Errorf("unexpected sessions line: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "2.1 commits/sess") {
+		t.Errorf("unexpected productivity line: %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "flakiest Bash (40%)") {
+		t.Errorf("unexpected efficiency line: %q", lines[2])
+	}
+	if !strings.Contains(lines[4], "1.5M total") {
+		t.Errorf("unexpected tokens line: %q", lines[4])
+	}
+	if !strings.Contains(lines[5], "claude-sonnet-4.5 $1.50 (100%)") {
+		t.Errorf("unexpected models line: %q", lines[5])
+	}
+	if last := lines[len(lines)-1]; !strings.Contains(last, "4 tasks (75% done), 1 pending") {
+		t.Errorf("unexpected planning line: %q", last)
+	}
+}
+
+func TestCompactMetricsLines_SkipsOptionalSections(t *testing.T) {
+	output.SetNoColor(true)
+	defer output.SetNoColor(false)
+
+	lines := compactMetricsLines(metricsOutput{})
+	if len(lines) != 11 {
+		t.Fatalf("expected 11 lines without models, conversation, effectiveness, or planning, got %d:\n%s",
+			len(lines), strings.Join(lines, "\n"))
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Models:") || strings.HasPrefix(line, "Planning:") {
+			t.Errorf("unexpected optional section: %q", line)
+		}
+	}
+	if !strings.Contains(lines[4], "no sessions") {
+		t.Errorf("expected empty tokens line, got %q", lines[4])
+	}
+}
+
+func TestCompactEffectiveness_CountsVerdicts(t *testing.T) {
+	output.SetNoColor(true)
+	defer output.SetNoColor(false)
+
+	got := compactEffectiveness([]analyzer.EffectivenessResult{
+		{Verdict: "effective"},
+		{Verdict: "effective"},
+		{Verdict: "regression"},
+		{Verdict: "insufficient_data"},
+	})
+	if !strings.Contains(got, "2 effective, 0 neutral, 1 regression") {
+		t.Errorf("unexpected effectiveness line: %q", got)
+	}
+
+	got = compactEffectiveness([]analyzer.EffectivenessResult{{Verdict: "insufficient_data"}})
+	if !strings.Contains(got, "insufficient") {
+		t.Errorf("expected insufficient-data note, got %q", got)
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSampleSize(t *testing.T) {
+	output.SetNoColor(true)
+	defer output.SetNoColor(false)
+
+	low := withSampleSize("85%", 4)
+	assert.Contains(t, low, "85%")
+	assert.True(t, strings.HasSuffix(low, " (n=4) ⚠ low confidence"), low)
+
+	enough := withSampleSize("85%", analyzer.MinSampleSize)
+	assert.True(t, strings.HasSuffix(enough, "(n=10)"), enough)
+	assert.NotContains(t, enough, "low confidence")
+}
@@ -0,0 +1,17 @@
+package app
+
+import (
+	"os"
+
+	"github.com/blackwell-systems/claudewatch/internal/ui"
+)
+
+// startProgress starts a phase spinner on stderr for a long-running command.
+// It returns nil, which is a no-op, when enabled is false, for JSON output,
+// with --verbose (whose logs share stderr), or when stderr is not a terminal.
+func startProgress(enabled, jsonOutput bool) *ui.Progress {
+	if !enabled || jsonOutput || flagVerbose || !ui.IsStderrTTY() {
+		return nil
+	}
+	return ui.StartProgress(os.Stderr)
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+)
+
+// projectConfigs holds the effective config of each project with a
+// .claudewatch.yaml; every other project uses the global config.
+type projectConfigs struct {
+	base   *config.Config
+	byPath map[string]*config.Config
+}
+
+// loadProjectConfigs merges each project's config file over base. A file
+// that fails to load is reported to warn, and that project falls back to
+// base rather than failing the run.
+func loadProjectConfigs(base *config.Config, projects []scanner.Project, warn io.Writer) projectConfigs {
+	pc := projectConfigs{base: base, byPath: make(map[string]*config.Config)}
+	for _, p := range projects {
+		if p.ConfigFile == "" {
+			continue
+		}
+		eff, err := base.ForProject(p.Path)
+		if err != nil {
+			fmt.Fprintf(warn, "warning: %v; using the global config for %s\n", err, p.Name)
+			continue
+		}
+		pc.byPath[claude.NormalizePath(p.Path)] = eff
+	}
+	return pc
+}
+
+// For returns the effective config for the project at path.
+func (pc projectConfigs) For(path string) *config.Config {
+	if eff, ok := pc.byPath[claude.NormalizePath(path)]; ok {
+		return eff
+	}
+	return pc.base
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+)
+
+// projectWithConfig returns a project in a temp dir whose .claudewatch.yaml
+// holds content.
+func projectWithConfig(t *testing.T, name, content string) scanner.Project {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, config.ProjectConfigFile)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return scanner.Project{Path: dir, Name: name, ConfigFile: path}
+}
+
+func TestLoadProjectConfigs(t *testing.T) {
+	base := &config.Config{Thresholds: config.DefaultThresholds, Friction: config.DefaultFriction, ClaudeMDStaleDays: 30}
+	research := projectWithConfig(t, "research", "thresholds:\n  zero_commit_rate: 1\n")
+	broken := projectWithConfig(t, "broken", "thresholds:\n  zero_commit_rate: 5\n")
+	plain := scanner.Project{Path: "/src/plain", Name: "plain"}
+
+	var warn bytes.Buffer
+	configs := loadProjectConfigs(base, []scanner.Project{research, broken, plain}, &warn)
+
+	if got := configs.For(research.Path + "/").Thresholds.ZeroCommitRate; got != 1 {
+		t.Errorf("research zero_commit_rate = %g, want its override of 1", got)
+	}
+	if configs.For(broken.Path) != base || configs.For(plain.Path) != base {
+		t.Error("expected broken and plain to use the global config")
+	}
+	if !strings.Contains(warn.String(), "using the global config for broken") {
+		t.Errorf("warning = %q, want one naming broken", warn.String())
+	}
+}
+
+func TestFindClaudeMDQualityGaps_ProjectThreshold(t *testing.T) {
+	base := &config.Config{Thresholds: config.DefaultThresholds, Friction: config.DefaultFriction, ClaudeMDStaleDays: 30}
+	lenient := projectWithConfig(t, "lenient", "thresholds:\n  claude_md_quality: 0\n")
+	strict := projectWithConfig(t, "strict", "thresholds:\n  claude_md_quality: 100\n")
+	for _, p := range []*scanner.Project{&lenient, &strict} {
+		if err := os.WriteFile(filepath.Join(p.Path, "CLAUDE.md"), []byte("# Notes\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		p.HasClaudeMD = true
+	}
+	projects := []scanner.Project{lenient, strict}
+	configs := loadProjectConfigs(base, projects, &bytes.Buffer{})
+
+	gaps := findClaudeMDQualityGaps(projects, []claude.SessionFacet{}, configs)
+	if len(gaps) != 1 || gaps[0].Project != strict.Path {
+		t.Errorf("expected a single gap for strict, got %+v", gaps)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// resolveProjectFilter turns the --project and --project-path flags into the
+// normalized path of the one project to filter to, or "" when neither is set.
+//
+// --project-path names the project by path and is matched exactly, after
+// expanding ~ and making it absolute. --project names it fuzzily, against the
+// project paths in sessions: a full path, then an exact base name (any case),
+// then a substring of the name or path. Whichever step first matches decides;
+// when it matches several projects, as for two repos both named api, the
+// error lists their paths for --project-path.
+func resolveProjectFilter(name, path string, sessions []claude.SessionMeta) (string, error) {
+	if path != "" {
+		if strings.HasPrefix(path, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		return claude.NormalizePath(path), nil
+	}
+	if name == "" {
+		return "", nil
+	}
+
+	var paths []string
+	for _, s := range sessions {
+		if p := claude.NormalizePath(s.ProjectPath); p != "" && !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+
+	lower := strings.ToLower(name)
+	steps := []func(p string) bool{
+		func(p string) bool { return p == claude.NormalizePath(name) },
+		func(p string) bool { return strings.EqualFold(filepath.Base(p), name) },
+		func(p string) bool { return strings.Contains(strings.ToLower(p), lower) },
+	}
+	for _, match := range steps {
+		var candidates []string
+		for _, p := range paths {
+			if match(p) {
+				candidates = append(candidates, p)
+			}
+		}
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return candidates[0], nil
+		default:
+			return "", fmt.Errorf("--project %q matches %d projects; pick one with --project-path:\n  %s",
+				name, len(candidates), strings.Join(candidates, "\n  "))
+		}
+	}
+	// Nothing matches: filter to the name as given, which selects nothing.
+	return claude.NormalizePath(name), nil
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveProjectFilter(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{ProjectPath: "/work/acme/api"},
+		{ProjectPath: "/work/beta/api"},
+		{ProjectPath: "/work/acme/claudewatch"},
+		{ProjectPath: "/work/acme/web-app/"},
+	}
+
+	tests := []struct {
+		name, want string
+	}{
+		{"", ""},
+		{"/work/acme/api", "/work/acme/api"},
+		{"ClaudeWatch", "/work/acme/claudewatch"},
+		{"web", "/work/acme/web-app"},
+		{"missing", "missing"},
+	}
+	for _, tt := range tests {
+		got, err := resolveProjectFilter(tt.name, "", sessions)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, got, tt.name)
+	}
+
+	// Two repos named api: the error names both for --project-path.
+	_, err := resolveProjectFilter("api", "", sessions)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--project-path")
+	assert.Contains(t, err.Error(), "/work/acme/api")
+	assert.Contains(t, err.Error(), "/work/beta/api")
+
+	// Several fuzzy matches are ambiguous too.
+	_, err = resolveProjectFilter("acme", "", sessions)
+	assert.ErrorContains(t, err, `--project "acme" matches 3 projects`)
+}
+
+func TestResolveProjectFilter_Path(t *testing.T) {
+	got, err := resolveProjectFilter("", "/work/beta/api/", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/work/beta/api", got)
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	got, err = resolveProjectFilter("", "~/src/api", nil)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "src", "api"), got)
+}
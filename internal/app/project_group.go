@@ -0,0 +1,49 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// rootDirGroup is the group for projects whose path is shallower than the
+// --group-by-dir depth.
+const rootDirGroup = "root"
+
+// checkGroupByDir validates the --group-by-dir and --expand flags.
+func checkGroupByDir(depth int, expand bool) error {
+	if depth < 0 {
+		return fmt.Errorf("--group-by-dir must be at least 1, got %d", depth)
+	}
+	if expand && depth == 0 {
+		return fmt.Errorf("--expand needs --group-by-dir")
+	}
+	return nil
+}
+
+// projectDirGrouper returns a function mapping a project path to its
+// --group-by-dir group: the path cut to its first depth directories,
+// counted below the home directory for projects under it (shown as ~/...)
+// and below / otherwise. ~/clients/acme/api at depth 2 is in ~/clients/acme.
+// Paths with fewer than depth directories are in rootDirGroup.
+func projectDirGrouper(depth int) func(path string) string {
+	home, _ := os.UserHomeDir()
+	home = filepath.ToSlash(claude.NormalizePath(home))
+	return func(path string) string {
+		path = filepath.ToSlash(claude.NormalizePath(path))
+		prefix, rel := "/", strings.TrimPrefix(path, "/")
+		if home != "" && home != "/" {
+			if r, ok := strings.CutPrefix(path, home+"/"); ok {
+				prefix, rel = "~/", r
+			}
+		}
+		parts := strings.Split(rel, "/")
+		if rel == "" || len(parts) < depth {
+			return rootDirGroup
+		}
+		return prefix + strings.Join(parts[:depth], "/")
+	}
+}
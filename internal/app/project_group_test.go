@@ -0,0 +1,36 @@
+package app
+
+import "testing"
+
+func TestProjectDirGrouper(t *testing.T) {
+	t.Setenv("HOME", "/home/dev")
+	group := projectDirGrouper(2)
+
+	cases := map[string]string{
+		"/home/dev/clients/acme/api": "~/clients/acme",
+		"/home/dev/clients/acme":     "~/clients/acme",
+		"/home/dev/notes":            rootDirGroup,
+		"/srv/code/tools/cli":        "/srv/code",
+		"/srv":                       rootDirGroup,
+	}
+	for path, want := range cases {
+		if got := group(path); got != want {
+			t.Errorf("group(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestCheckGroupByDir(t *testing.T) {
+	if err := checkGroupByDir(0, false); err != nil {
+		t.Errorf("no grouping: %v", err)
+	}
+	if err := checkGroupByDir(2, true); err != nil {
+		t.Errorf("--group-by-dir 2 --expand: %v", err)
+	}
+	if err := checkGroupByDir(-1, false); err == nil {
+		t.Error("negative depth should be rejected")
+	}
+	if err := checkGroupByDir(0, true); err == nil {
+		t.Error("--expand without --group-by-dir should be rejected")
+	}
+}
@@ -0,0 +1,586 @@
+package app
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+// unknownLanguage is the group for projects with no detected language.
+const unknownLanguage = "unknown"
+
+var (
+	projectsFlagGroupBy    string
+	projectsFlagGroupByDir int
+	projectsFlagExpand     bool
+	projectsFlagColumns    []string
+	projectsFlagFormat     string
+	projectsFlagSort       string
+)
+
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "List projects with readiness, session volume, and friction",
+	Long: `List discovered projects with their readiness score, session count, and
+average friction per session (from facet data).
+
+Each project with at least 3 sessions also gets a 0-100 health score and
+letter grade combining readiness, inverse friction rate, commit rate, and agent
+success. Tune the mix with health_weights in the config file; projects with
+fewer sessions are shown as "insufficient data".
+
+Projects are ranked by weighted score: readiness scaled by session volume,
+score × (1 + weight × log_base(1 + sessions)), so the configs that affect the
+most work come first. Tune it with readiness_volume.log_base (default 10) and
+readiness_volume.weight (default 1) in the config file.
+
+Use --group-by language to aggregate projects by primary language and see
+where tooling investment would pay off. Projects with no detected language
+are grouped under "unknown". Groups are sorted by session volume.
+
+Use --group-by-dir <depth> to roll projects up by parent directory, such as
+one group per client for repos under ~/clients/<client>/. Depth counts
+directories below your home directory (or below / outside it), so depth 2
+puts ~/clients/acme/api in ~/clients/acme. Projects with shallower paths are
+grouped under "root". --expand lists each group's projects under it.
+
+--columns picks the table's columns and their order from: name, lang,
+score, weighted, health, sessions, friction, claudemd. --sort orders rows by
+one of them, names ascending and numbers highest first. --format csv and
+--format json write every field of every project whatever --columns says.
+
+Examples:
+  claudewatch projects
+  claudewatch projects --columns name,score,sessions,lang,claudemd --sort sessions
+  claudewatch projects --format csv > projects.csv
+  claudewatch projects --group-by language
+  claudewatch projects --group-by language --json
+  claudewatch projects --group-by-dir 2 --expand`,
+	RunE: runProjects,
+}
+
+func init() {
+	projectsCmd.Flags().StringVar(&projectsFlagGroupBy, "group-by", "", "Aggregate projects by: language")
+	projectsCmd.Flags().IntVar(&projectsFlagGroupByDir, "group-by-dir", 0, "Aggregate projects by parent directory at this depth below home")
+	projectsCmd.Flags().BoolVar(&projectsFlagExpand, "expand", false, "With --group-by-dir, list each group's projects")
+	projectsCmd.Flags().StringSliceVar(&projectsFlagColumns, "columns", nil, "Table columns, in order (default name,lang,score,weighted,health,sessions,friction)")
+	projectsCmd.Flags().StringVar(&projectsFlagFormat, "format", "table", "Output format (table|json|csv)")
+	projectsCmd.Flags().StringVar(&projectsFlagSort, "sort", "", "Sort rows by a column (default: weighted score, then sessions)")
+	projectsCmd.MarkFlagsMutuallyExclusive("group-by", "group-by-dir")
+	rootCmd.AddCommand(projectsCmd)
+}
+
+// projectRow is one project's readiness and friction summary.
+type projectRow struct {
+	Name           string  `json:"name"`
+	Path           string  `json:"path"`
+	Language       string  `json:"language"`
+	Score          float64 `json:"score"`
+	WeightedScore  float64 `json:"weighted_score"`
+	Sessions       int     `json:"sessions"`
+	FacetSessions  int     `json:"facet_sessions"`
+	FrictionEvents int     `json:"friction_events"`
+	AvgFriction    float64 `json:"avg_friction"`
+	ClaudeMD       bool    `json:"claude_md"`
+
+	Health scanner.HealthScore `json:"health"`
+}
+
+// dirGroup aggregates projectRows under the same parent directory.
+type dirGroup struct {
+	Dir            string  `json:"dir"`
+	Projects       int     `json:"projects"`
+	AvgScore       float64 `json:"avg_score"`
+	Sessions       int     `json:"sessions"`
+	FacetSessions  int     `json:"facet_sessions"`
+	FrictionEvents int     `json:"friction_events"`
+	AvgFriction    float64 `json:"avg_friction"`
+	// Members lists the group's projects, with --expand.
+	Members []projectRow `json:"members,omitempty"`
+}
+
+// languageGroup aggregates projectRows sharing a primary language.
+type languageGroup struct {
+	Language       string  `json:"language"`
+	Projects       int     `json:"projects"`
+	AvgScore       float64 `json:"avg_score"`
+	Sessions       int     `json:"sessions"`
+	FacetSessions  int     `json:"facet_sessions"`
+	FrictionEvents int     `json:"friction_events"`
+	AvgFriction    float64 `json:"avg_friction"`
+}
+
+func runProjects(cmd *cobra.Command, args []string) error {
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	groupBy := strings.ToLower(projectsFlagGroupBy)
+	if groupBy != "" && groupBy != "language" {
+		return fmt.Errorf("invalid --group-by %q (valid: language)", projectsFlagGroupBy)
+	}
+	if err := checkGroupByDir(projectsFlagGroupByDir, projectsFlagExpand); err != nil {
+		return err
+	}
+	format, err := projectsOutputFormat(projectsFlagFormat, flagJSON)
+	if err != nil {
+		return err
+	}
+	columns, err := resolveProjectColumns(projectsFlagColumns)
+	if err != nil {
+		return err
+	}
+	sortBy := strings.ToLower(strings.TrimSpace(projectsFlagSort))
+	if sortBy != "" {
+		if _, ok := projectColumnByKey(sortBy); !ok {
+			return fmt.Errorf("invalid --sort %q (valid: %s)", projectsFlagSort, strings.Join(projectColumnKeys(), ", "))
+		}
+		if len(projectsFlagColumns) > 0 && format == "table" && !slices.ContainsFunc(columns, func(c projectColumn) bool { return c.Key == sortBy }) {
+			return fmt.Errorf("--sort %s isn't one of the selected --columns", sortBy)
+		}
+	}
+	grouped := groupBy != "" || projectsFlagGroupByDir > 0
+	if grouped && (len(projectsFlagColumns) > 0 || sortBy != "" || format == "csv") {
+		return fmt.Errorf("--columns, --sort, and --format csv apply to project rows and can't be combined with --group-by or --group-by-dir")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	projects, err := scanner.DiscoverProjects(cfg.ScanPaths)
+	if err != nil {
+		return fmt.Errorf("discovering projects: %w", err)
+	}
+
+	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
+	if err != nil {
+		return fmt.Errorf("parsing session meta: %w", err)
+	}
+
+	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
+	if err != nil {
+		return fmt.Errorf("parsing facets: %w", err)
+	}
+
+	settings, err := claude.ParseSettings(cfg.ClaudeHome)
+	if err != nil {
+		return fmt.Errorf("parsing settings: %w", err)
+	}
+	if settings == nil {
+		settings = &claude.GlobalSettings{}
+	}
+
+	// Agent tasks are optional; a transcript parse failure leaves them empty.
+	agentTasks, _ := claude.ParseAgentTasks(cfg.ClaudeHome)
+
+	rows := buildProjectRows(projects, sessions, facets, agentTasks, settings, cfg.ReadinessVolume, cfg.HealthWeights)
+
+	if groupBy == "language" {
+		groups := groupProjectsByLanguage(rows)
+		if format == "json" {
+			return writeJSON(groups)
+		}
+		renderLanguageGroups(anonymized(groups))
+		return nil
+	}
+	if projectsFlagGroupByDir > 0 {
+		groups := groupProjectsByDir(rows, projectDirGrouper(projectsFlagGroupByDir), projectsFlagExpand)
+		if format == "json" {
+			return writeJSON(groups)
+		}
+		renderDirGroups(anonymized(groups), projectsFlagGroupByDir)
+		return nil
+	}
+
+	if sortBy != "" {
+		sortProjectRows(rows, sortBy)
+	}
+	switch format {
+	case "json":
+		return writeJSON(rows)
+	case "csv":
+		return writeProjectsCSV(os.Stdout, rows)
+	}
+	renderProjectRows(anonymized(rows), columns)
+	return nil
+}
+
+// projectsOutputFormat validates --format and folds --json into it.
+func projectsOutputFormat(format string, jsonFlag bool) (string, error) {
+	format = strings.ToLower(format)
+	switch format {
+	case "table", "csv", "json":
+	default:
+		return "", fmt.Errorf("invalid --format %q (valid: table, json, csv)", format)
+	}
+	if jsonFlag {
+		if format == "csv" {
+			return "", fmt.Errorf("--json conflicts with --format %s", format)
+		}
+		return "json", nil
+	}
+	return format, nil
+}
+
+// buildProjectRows scores each project for readiness and health and
+// aggregates friction from the facets of its sessions. Rows are sorted by
+// weighted score, then session count.
+func buildProjectRows(projects []scanner.Project, sessions []claude.SessionMeta, facets []claude.SessionFacet, tasks []claude.AgentTask, settings *claude.GlobalSettings, w config.ReadinessVolume, hw config.HealthWeights) []projectRow {
+	rows := make([]projectRow, 0, len(projects))
+	for i := range projects {
+		p := &projects[i]
+
+		lang := p.PrimaryLanguage
+		if lang == "" {
+			lang = unknownLanguage
+		}
+
+		row := projectRow{
+			Name:     p.Name,
+			Path:     p.Path,
+			Language: lang,
+			ClaudeMD: p.HasClaudeMD,
+			Score:    scanner.ComputeReadiness(p, sessions, facets, settings),
+			Sessions: len(filterSessionsByProject(sessions, p.Path)),
+		}
+		row.WeightedScore = scanner.WeightedReadiness(row.Score, row.Sessions, w)
+		row.Health = scanner.ComputeProjectHealth(p, sessions, facets, tasks, settings, hw)
+
+		projectFacets := scanner.FilterFacetsByProject(facets, sessions, p.Path)
+		row.FacetSessions = len(projectFacets)
+		for _, f := range projectFacets {
+			for _, count := range f.FrictionCounts {
+				row.FrictionEvents += count
+			}
+		}
+		if row.FacetSessions > 0 {
+			row.AvgFriction = float64(row.FrictionEvents) / float64(row.FacetSessions)
+		}
+
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].WeightedScore != rows[j].WeightedScore {
+			return rows[i].WeightedScore > rows[j].WeightedScore
+		}
+		if rows[i].Sessions != rows[j].Sessions {
+			return rows[i].Sessions > rows[j].Sessions
+		}
+		return strings.ToLower(rows[i].Name) < strings.ToLower(rows[j].Name)
+	})
+	return rows
+}
+
+// groupProjectsByLanguage buckets rows by language. AvgScore is the mean
+// readiness of the group's projects; AvgFriction is friction events per
+// faceted session across the group. Groups are sorted by session volume.
+func groupProjectsByLanguage(rows []projectRow) []languageGroup {
+	byLang := make(map[string]*languageGroup)
+	for _, r := range rows {
+		g, ok := byLang[r.Language]
+		if !ok {
+			g = &languageGroup{Language: r.Language}
+			byLang[r.Language] = g
+		}
+		g.Projects++
+		g.AvgScore += r.Score
+		g.Sessions += r.Sessions
+		g.FacetSessions += r.FacetSessions
+		g.FrictionEvents += r.FrictionEvents
+	}
+
+	groups := make([]languageGroup, 0, len(byLang))
+	for _, g := range byLang {
+		g.AvgScore /= float64(g.Projects)
+		if g.FacetSessions > 0 {
+			g.AvgFriction = float64(g.FrictionEvents) / float64(g.FacetSessions)
+		}
+		groups = append(groups, *g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Sessions != groups[j].Sessions {
+			return groups[i].Sessions > groups[j].Sessions
+		}
+		return groups[i].Language < groups[j].Language
+	})
+	return groups
+}
+
+// groupProjectsByDir buckets rows by the group dirOf gives their path,
+// aggregating like groupProjectsByLanguage. With expand, each group keeps its
+// rows, in their original order, as Members.
+func groupProjectsByDir(rows []projectRow, dirOf func(path string) string, expand bool) []dirGroup {
+	byDir := make(map[string]*dirGroup)
+	for _, r := range rows {
+		dir := dirOf(r.Path)
+		g, ok := byDir[dir]
+		if !ok {
+			g = &dirGroup{Dir: dir}
+			byDir[dir] = g
+		}
+		g.Projects++
+		g.AvgScore += r.Score
+		g.Sessions += r.Sessions
+		g.FacetSessions += r.FacetSessions
+		g.FrictionEvents += r.FrictionEvents
+		if expand {
+			g.Members = append(g.Members, r)
+		}
+	}
+
+	groups := make([]dirGroup, 0, len(byDir))
+	for _, g := range byDir {
+		g.AvgScore /= float64(g.Projects)
+		if g.FacetSessions > 0 {
+			g.AvgFriction = float64(g.FrictionEvents) / float64(g.FacetSessions)
+		}
+		groups = append(groups, *g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Sessions != groups[j].Sessions {
+			return groups[i].Sessions > groups[j].Sessions
+		}
+		return groups[i].Dir < groups[j].Dir
+	})
+	return groups
+}
+
+// projectColumn is one selectable column of the projects table.
+type projectColumn struct {
+	Key    string
+	Header string
+	Cell   func(r projectRow) string
+	// Less orders rows for --sort: names ascending, numbers highest first.
+	Less func(a, b projectRow) bool
+}
+
+// projectColumns lists every column in its default order. claudemd is the
+// only one left out of the default table.
+var projectColumns = []projectColumn{
+	{
+		Key: "name", Header: "Project",
+		Cell: func(r projectRow) string { return r.Name },
+		Less: func(a, b projectRow) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	},
+	{
+		Key: "lang", Header: "Language",
+		Cell: func(r projectRow) string { return r.Language },
+		Less: func(a, b projectRow) bool { return strings.ToLower(a.Language) < strings.ToLower(b.Language) },
+	},
+	{
+		Key: "score", Header: "Score",
+		Cell: func(r projectRow) string { return fmt.Sprintf("%.0f", r.Score) },
+		Less: func(a, b projectRow) bool { return a.Score > b.Score },
+	},
+	{
+		Key: "weighted", Header: "Weighted",
+		Cell: func(r projectRow) string { return fmt.Sprintf("%.0f", r.WeightedScore) },
+		Less: func(a, b projectRow) bool { return a.WeightedScore > b.WeightedScore },
+	},
+	{
+		Key: "health", Header: "Health",
+		Cell: func(r projectRow) string { return formatHealth(r.Health) },
+		// Ungraded projects score zero, so they sort last.
+		Less: func(a, b projectRow) bool { return a.Health.Score > b.Health.Score },
+	},
+	{
+		Key: "sessions", Header: "Sessions",
+		Cell: func(r projectRow) string { return fmt.Sprintf("%d", r.Sessions) },
+		Less: func(a, b projectRow) bool { return a.Sessions > b.Sessions },
+	},
+	{
+		Key: "friction", Header: "Friction/session",
+		Cell: func(r projectRow) string { return formatAvgFriction(r.AvgFriction, r.FacetSessions) },
+		Less: func(a, b projectRow) bool {
+			// Projects without facet data sort after every measured one.
+			if (a.FacetSessions == 0) != (b.FacetSessions == 0) {
+				return b.FacetSessions == 0
+			}
+			return a.AvgFriction > b.AvgFriction
+		},
+	},
+	{
+		Key: "claudemd", Header: "CLAUDE.md",
+		Cell: func(r projectRow) string {
+			if r.ClaudeMD {
+				return "yes"
+			}
+			return output.StyleMuted.Render("no")
+		},
+		Less: func(a, b projectRow) bool { return a.ClaudeMD && !b.ClaudeMD },
+	},
+}
+
+// projectColumnByKey looks up a column by its --columns key.
+func projectColumnByKey(key string) (projectColumn, bool) {
+	for _, c := range projectColumns {
+		if c.Key == key {
+			return c, true
+		}
+	}
+	return projectColumn{}, false
+}
+
+// projectColumnKeys returns every column key, in default order.
+func projectColumnKeys() []string {
+	keys := make([]string, len(projectColumns))
+	for i, c := range projectColumns {
+		keys[i] = c.Key
+	}
+	return keys
+}
+
+// resolveProjectColumns turns --columns into columns in the given order.
+// With none given it returns the default table's columns; an unknown or
+// repeated key is an error.
+func resolveProjectColumns(keys []string) ([]projectColumn, error) {
+	if len(keys) == 0 {
+		return slices.DeleteFunc(slices.Clone(projectColumns), func(c projectColumn) bool { return c.Key == "claudemd" }), nil
+	}
+	columns := make([]projectColumn, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		k = strings.ToLower(strings.TrimSpace(k))
+		c, ok := projectColumnByKey(k)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (valid: %s)", k, strings.Join(projectColumnKeys(), ", "))
+		}
+		if seen[k] {
+			return nil, fmt.Errorf("column %q listed twice", k)
+		}
+		seen[k] = true
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+// sortProjectRows reorders rows by the column keyed sortBy. The sort is
+// stable, so ties keep the weighted-score ranking.
+func sortProjectRows(rows []projectRow, sortBy string) {
+	c, ok := projectColumnByKey(sortBy)
+	if !ok {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return c.Less(rows[i], rows[j]) })
+}
+
+// writeProjectsCSV writes every field of rows as CSV. Health score and
+// grade are blank for projects with too little data to grade.
+func writeProjectsCSV(w io.Writer, rows []projectRow) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"name", "path", "language", "score", "weighted_score", "health_score", "health_grade", "sessions", "facet_sessions", "friction_events", "avg_friction", "claude_md"})
+	for _, r := range rows {
+		healthScore, healthGrade := "", ""
+		if !r.Health.Insufficient() {
+			healthScore, healthGrade = csvFloat(r.Health.Score), r.Health.Grade
+		}
+		_ = cw.Write([]string{
+			r.Name, r.Path, r.Language,
+			csvFloat(r.Score), csvFloat(r.WeightedScore),
+			healthScore, healthGrade,
+			strconv.Itoa(r.Sessions), strconv.Itoa(r.FacetSessions), strconv.Itoa(r.FrictionEvents),
+			csvFloat(r.AvgFriction), strconv.FormatBool(r.ClaudeMD),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func renderProjectRows(rows []projectRow, columns []projectColumn) {
+	fmt.Println(output.Section(fmt.Sprintf("Projects (%d)", len(rows))))
+	fmt.Println()
+
+	if len(rows) == 0 {
+		fmt.Println(output.StyleMuted.Render(" No projects found. Check scan_paths in your config."))
+		fmt.Println()
+		return
+	}
+
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+	}
+	tbl := output.NewTable(headers...)
+	for _, r := range rows {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = c.Cell(r)
+		}
+		tbl.AddRow(cells...)
+	}
+	tbl.Print()
+	fmt.Println()
+}
+
+func renderLanguageGroups(groups []languageGroup) {
+	fmt.Println(output.Section("Projects by Language"))
+	fmt.Println()
+
+	if len(groups) == 0 {
+		fmt.Println(output.StyleMuted.Render(" No projects found. Check scan_paths in your config."))
+		fmt.Println()
+		return
+	}
+
+	tbl := output.NewTable("Language", "Projects", "Avg score", "Sessions", "Friction/session")
+	for _, g := range groups {
+		tbl.AddRow(g.Language, fmt.Sprintf("%d", g.Projects), fmt.Sprintf("%.0f", g.AvgScore), fmt.Sprintf("%d", g.Sessions), formatAvgFriction(g.AvgFriction, g.FacetSessions))
+	}
+	tbl.Print()
+	fmt.Println()
+}
+
+func renderDirGroups(groups []dirGroup, depth int) {
+	fmt.Println(output.Section(fmt.Sprintf("Projects by Directory (depth %d)", depth)))
+	fmt.Println()
+
+	if len(groups) == 0 {
+		fmt.Println(output.StyleMuted.Render(" No projects found. Check scan_paths in your config."))
+		fmt.Println()
+		return
+	}
+
+	tbl := output.NewTable("Directory", "Projects", "Avg score", "Sessions", "Friction/session")
+	for _, g := range groups {
+		tbl.AddRow(g.Dir, fmt.Sprintf("%d", g.Projects), fmt.Sprintf("%.0f", g.AvgScore), fmt.Sprintf("%d", g.Sessions), formatAvgFriction(g.AvgFriction, g.FacetSessions))
+		for _, r := range g.Members {
+			tbl.AddRow(output.StyleMuted.Render("  "+r.Name), "", fmt.Sprintf("%.0f", r.Score), fmt.Sprintf("%d", r.Sessions), formatAvgFriction(r.AvgFriction, r.FacetSessions))
+		}
+	}
+	tbl.Print()
+	fmt.Println()
+}
+
+// formatHealth renders a health score with its grade, or a muted note when
+// the project has too little data to grade.
+func formatHealth(h scanner.HealthScore) string {
+	if h.Insufficient() {
+		return output.StyleMuted.Render("insufficient data")
+	}
+	return fmt.Sprintf("%.0f (%s)", h.Score, h.Grade)
+}
+
+// formatAvgFriction renders a friction rate, or a muted dash when there is no
+// facet data to compute one from.
+func formatAvgFriction(avg float64, facetSessions int) string {
+	if facetSessions == 0 {
+		return output.StyleMuted.Render("—")
+	}
+	return fmt.Sprintf("%.1f", avg)
+}
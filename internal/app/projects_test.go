@@ -0,0 +1,281 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+)
+
+func TestBuildProjectRows_FrictionAndUnknownLanguage(t *testing.T) {
+	projects := []scanner.Project{
+		{Name: "api", Path: "/code/api", PrimaryLanguage: "Go"},
+		{Name: "notes", Path: "/code/notes"},
+	}
+	sessions := []claude.SessionMeta{
+		{SessionID: "s1", ProjectPath: "/code/api"},
+		{SessionID: "s2", ProjectPath: "/code/api"},
+		{SessionID: "s3", ProjectPath: "/code/notes"},
+	}
+	facets := []claude.SessionFacet{
+		{SessionID: "s1", FrictionCounts: map[string]int{"wrong_approach": 2, "tool_error": 1}},
+		{SessionID: "s2", FrictionCounts: map[string]int{"wrong_approach": 1}},
+	}
+
+	rows := buildProjectRows(projects, sessions, facets, nil, &claude.GlobalSettings{}, config.DefaultReadinessVolume, config.DefaultHealthWeights)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	api := rows[0]
+	if api.Name != "api" || api.Sessions != 2 {
+		t.Fatalf("expected api first with 2 sessions, got %+v", api)
+	}
+	if api.FrictionEvents != 4 || api.AvgFriction != 2 {
+		t.Errorf("api friction = %d events, %.1f avg; want 4, 2.0", api.FrictionEvents, api.AvgFriction)
+	}
+	if rows[1].Language != unknownLanguage {
+		t.Errorf("expected project without language to be %q, got %q", unknownLanguage, rows[1].Language)
+	}
+}
+
+func TestBuildProjectRows_RankedByWeightedScore(t *testing.T) {
+	// Both projects have CLAUDE.md; "busy" has slightly lower readiness but
+	// far more sessions, so it leads the weighted leaderboard.
+	projects := []scanner.Project{
+		{Name: "polished", Path: "/code/polished", HasClaudeMD: true, ClaudeMDSize: 1000, HasDotClaude: true},
+		{Name: "busy", Path: "/code/busy", HasClaudeMD: true},
+	}
+	sessions := []claude.SessionMeta{{SessionID: "p1", ProjectPath: "/code/polished"}}
+	for i := 0; i < 99; i++ {
+		sessions = append(sessions, claude.SessionMeta{SessionID: fmt.Sprintf("b%d", i), ProjectPath: "/code/busy"})
+	}
+
+	rows := buildProjectRows(projects, sessions, nil, nil, &claude.GlobalSettings{}, config.DefaultReadinessVolume, config.DefaultHealthWeights)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].Name != "busy" {
+		t.Fatalf("expected busy first, got %+v", rows)
+	}
+	if rows[0].Score != 30 || rows[0].WeightedScore != 90 {
+		t.Errorf("busy score = %v, weighted = %v; want 30, 90", rows[0].Score, rows[0].WeightedScore)
+	}
+	if rows[1].Score != 50 || rows[1].WeightedScore <= rows[1].Score {
+		t.Errorf("polished score = %v, weighted = %v; want 50 and a boost", rows[1].Score, rows[1].WeightedScore)
+	}
+
+	// With volume weighting off, raw readiness decides.
+	rows = buildProjectRows(projects, sessions, nil, nil, &claude.GlobalSettings{}, config.ReadinessVolume{LogBase: 10}, config.DefaultHealthWeights)
+	if rows[0].Name != "polished" {
+		t.Errorf("expected polished first without weighting, got %+v", rows)
+	}
+}
+
+func TestBuildProjectRows_Health(t *testing.T) {
+	projects := []scanner.Project{
+		{Name: "api", Path: "/code/api", HasClaudeMD: true},
+		{Name: "notes", Path: "/code/notes"},
+	}
+	sessions := []claude.SessionMeta{
+		{SessionID: "a1", ProjectPath: "/code/api", GitCommits: 1},
+		{SessionID: "a2", ProjectPath: "/code/api", GitCommits: 2},
+		{SessionID: "a3", ProjectPath: "/code/api"},
+		{SessionID: "n1", ProjectPath: "/code/notes"},
+	}
+	tasks := []claude.AgentTask{{SessionID: "a1", Status: "completed"}}
+
+	rows := buildProjectRows(projects, sessions, nil, tasks, &claude.GlobalSettings{}, config.DefaultReadinessVolume, config.DefaultHealthWeights)
+	if len(rows) != 2 || rows[0].Name != "api" {
+		t.Fatalf("expected api first, got %+v", rows)
+	}
+	if rows[0].Health.Insufficient() || rows[0].Health.Components.Agents == nil {
+		t.Errorf("api health = %+v, want a graded score with agent success", rows[0].Health)
+	}
+	if !rows[1].Health.Insufficient() {
+		t.Errorf("notes health grade = %q, want %q", rows[1].Health.Grade, scanner.GradeInsufficient)
+	}
+}
+
+func TestGroupProjectsByDir(t *testing.T) {
+	rows := []projectRow{
+		{Name: "api", Path: "/clients/acme/api", Score: 80, Sessions: 3, FacetSessions: 2, FrictionEvents: 4},
+		{Name: "web", Path: "/clients/acme/web", Score: 40, Sessions: 2, FacetSessions: 2},
+		{Name: "app", Path: "/clients/beta/app", Score: 50, Sessions: 1},
+		{Name: "tool", Path: "/tool", Score: 10, Sessions: 9},
+	}
+	dirOf := func(path string) string {
+		if strings.Count(path, "/") < 3 {
+			return rootDirGroup
+		}
+		return path[:strings.LastIndex(path, "/")]
+	}
+
+	groups := groupProjectsByDir(rows, dirOf, false)
+	var dirs []string
+	for _, g := range groups {
+		dirs = append(dirs, g.Dir)
+		if g.Members != nil {
+			t.Errorf("%s: members listed without expand", g.Dir)
+		}
+	}
+	if want := []string{rootDirGroup, "/clients/acme", "/clients/beta"}; !slices.Equal(dirs, want) {
+		t.Fatalf("groups = %v, want %v (by session volume)", dirs, want)
+	}
+	acme := groups[1]
+	if acme.Projects != 2 || acme.Sessions != 5 || acme.AvgScore != 60 || acme.AvgFriction != 1 {
+		t.Errorf("acme = %+v, want 2 projects, 5 sessions, score 60, friction 1", acme)
+	}
+
+	expanded := groupProjectsByDir(rows, dirOf, true)
+	if m := expanded[1].Members; len(m) != 2 || m[0].Name != "api" || m[1].Name != "web" {
+		t.Errorf("acme members = %+v, want api and web", m)
+	}
+}
+
+func TestGroupProjectsByLanguage(t *testing.T) {
+	rows := []projectRow{
+		{Name: "a", Language: "Go", Score: 80, Sessions: 3, FacetSessions: 2, FrictionEvents: 4},
+		{Name: "b", Language: "Go", Score: 40, Sessions: 1, FacetSessions: 2, FrictionEvents: 0},
+		{Name: "c", Language: "Python", Score: 50, Sessions: 10},
+		{Name: "d", Language: unknownLanguage, Score: 10, Sessions: 1},
+	}
+
+	groups := groupProjectsByLanguage(rows)
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(groups))
+	}
+
+	// Sorted by session volume descending, ties by name.
+	want := []string{"Python", "Go", unknownLanguage}
+	for i, lang := range want {
+		if groups[i].Language != lang {
+			t.Errorf("groups[%d] = %q, want %q", i, groups[i].Language, lang)
+		}
+	}
+
+	goGroup := groups[1]
+	if goGroup.Projects != 2 || goGroup.Sessions != 4 {
+		t.Errorf("Go group = %d projects, %d sessions; want 2, 4", goGroup.Projects, goGroup.Sessions)
+	}
+	if goGroup.AvgScore != 60 {
+		t.Errorf("Go AvgScore = %v, want 60", goGroup.AvgScore)
+	}
+	if goGroup.AvgFriction != 1 {
+		t.Errorf("Go AvgFriction = %v, want 1 (4 events / 4 faceted sessions)", goGroup.AvgFriction)
+	}
+}
+
+func TestProjectsFlags_Registered(t *testing.T) {
+	for _, name := range []string{"group-by", "columns", "format", "sort"} {
+		if projectsCmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected --%s flag to be registered on projectsCmd", name)
+		}
+	}
+}
+
+func TestResolveProjectColumns(t *testing.T) {
+	keys := func(cols []projectColumn) []string {
+		var out []string
+		for _, c := range cols {
+			out = append(out, c.Key)
+		}
+		return out
+	}
+
+	def, err := resolveProjectColumns(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"name", "lang", "score", "weighted", "health", "sessions", "friction"}; !slices.Equal(keys(def), want) {
+		t.Errorf("default columns = %v, want %v", keys(def), want)
+	}
+
+	cols, err := resolveProjectColumns([]string{"name", " Score", "sessions", "lang", "claudemd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"name", "score", "sessions", "lang", "claudemd"}; !slices.Equal(keys(cols), want) {
+		t.Errorf("columns = %v, want %v in the order given", keys(cols), want)
+	}
+
+	_, err = resolveProjectColumns([]string{"name", "stars"})
+	if err == nil || !strings.Contains(err.Error(), "stars") || !strings.Contains(err.Error(), "claudemd") {
+		t.Errorf("unknown column error = %v, want it to name the column and list the valid ones", err)
+	}
+	if _, err := resolveProjectColumns([]string{"name", "name"}); err == nil {
+		t.Error("expected an error for a repeated column")
+	}
+}
+
+func TestSortProjectRows(t *testing.T) {
+	rows := []projectRow{
+		{Name: "beta", Sessions: 2, FacetSessions: 1, AvgFriction: 0.5},
+		{Name: "Alpha", Sessions: 9},
+		{Name: "gamma", Sessions: 2, FacetSessions: 3, AvgFriction: 2, ClaudeMD: true},
+	}
+	names := func() []string {
+		var out []string
+		for _, r := range rows {
+			out = append(out, r.Name)
+		}
+		return out
+	}
+
+	sortProjectRows(rows, "name")
+	if want := []string{"Alpha", "beta", "gamma"}; !slices.Equal(names(), want) {
+		t.Errorf("by name = %v, want %v", names(), want)
+	}
+	// Unmeasured friction sorts last.
+	sortProjectRows(rows, "friction")
+	if want := []string{"gamma", "beta", "Alpha"}; !slices.Equal(names(), want) {
+		t.Errorf("by friction = %v, want %v", names(), want)
+	}
+	// Ties keep their previous order.
+	sortProjectRows(rows, "sessions")
+	if want := []string{"Alpha", "gamma", "beta"}; !slices.Equal(names(), want) {
+		t.Errorf("by sessions = %v, want %v", names(), want)
+	}
+	sortProjectRows(rows, "claudemd")
+	if names()[0] != "gamma" {
+		t.Errorf("by claudemd = %v, want gamma first", names())
+	}
+}
+
+func TestWriteProjectsCSV(t *testing.T) {
+	rows := []projectRow{
+		{Name: "api", Path: "/code/api", Language: "Go", Score: 80, WeightedScore: 120, Sessions: 5, FacetSessions: 2, FrictionEvents: 3, AvgFriction: 1.5, ClaudeMD: true,
+			Health: scanner.HealthScore{Score: 85, Grade: "B"}},
+		{Name: "notes", Path: "/code/notes", Language: unknownLanguage, Health: scanner.HealthScore{Grade: scanner.GradeInsufficient}},
+	}
+
+	var b strings.Builder
+	if err := writeProjectsCSV(&b, rows); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,path,language,score,weighted_score,health_score,health_grade,sessions,facet_sessions,friction_events,avg_friction,claude_md\n" +
+		"api,/code/api,Go,80.00,120.00,85.00,B,5,2,3,1.50,true\n" +
+		"notes,/code/notes,unknown,0.00,0.00,,,0,0,0,0.00,false\n"
+	if b.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestProjectsOutputFormat(t *testing.T) {
+	if f, err := projectsOutputFormat("CSV", false); err != nil || f != "csv" {
+		t.Errorf("CSV = %q, %v; want csv", f, err)
+	}
+	if f, err := projectsOutputFormat("table", true); err != nil || f != "json" {
+		t.Errorf("--json = %q, %v; want json", f, err)
+	}
+	if _, err := projectsOutputFormat("csv", true); err == nil {
+		t.Error("expected --json with --format csv to conflict")
+	}
+	if _, err := projectsOutputFormat("yaml", false); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
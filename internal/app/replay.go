@@ -1,12 +1,9 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
-	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/blackwell-systems/claudewatch/internal/store"
 	"github.com/spf13/cobra"
@@ -41,7 +38,7 @@ func init() {
 }
 
 func runReplay(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -76,7 +73,7 @@ func runReplay(cmd *cobra.Command, args []string) error {
 	}
 
 	if flagJSON {
-		return json.NewEncoder(os.Stdout).Encode(replay)
+		return writeJSONCompact(replay)
 	}
 
 	fmt.Println(output.Section(fmt.Sprintf("Session Replay — %s", sessionID[:min(12, len(sessionID))])))
@@ -2,29 +2,45 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/guard"
+	"github.com/blackwell-systems/claudewatch/internal/logging"
 	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/pricing"
+	"github.com/blackwell-systems/claudewatch/internal/remote"
+	"github.com/blackwell-systems/claudewatch/internal/store"
+	"github.com/blackwell-systems/claudewatch/internal/workers"
 	"github.com/spf13/cobra"
 )
 
-var appVersion = "dev"
+var (
+	flagNoColor     bool
+	flagJSON        bool
+	flagVerbose     bool
+	flagConfig      string
+	flagProfile     string
+	flagColorJSON   bool
+	flagCompactJSON bool
+	flagTheme       string
 
-// SetVersion sets the application version (called from main with ldflags value).
-func SetVersion(v string) {
-	appVersion = v
-	rootCmd.Version = v
-}
+	flagAnonymize    bool
+	flagAnonymizeMap string
 
-var (
-	flagNoColor bool
-	flagJSON    bool
-	flagVerbose bool
-	flagConfig  string
+	flagOffline  bool
+	flagReadOnly bool
+	flagJobs     int
+
+	flagRedactPrompts bool
 )
 
 var rootCmd = &cobra.Command{
@@ -37,12 +53,39 @@ scores project readiness, surfaces friction, and tracks improvement over time.
 Run 'claudewatch' with no arguments to see a quick dashboard summary.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Diagnostics go to stderr so they never corrupt --json on stdout.
+		if flagVerbose {
+			logging.Enable(os.Stderr)
+			logging.Debug("command start", "command", cmd.CommandPath(), "version", appVersion)
+		}
+		// loadConfig widens these with the offline, read_only, and
+		// redact_prompts settings.
+		guard.SetOffline(flagOffline)
+		guard.SetReadOnly(flagReadOnly)
+		claude.SetRedactPrompts(flagRedactPrompts)
+		if flagJobs < 0 {
+			return fmt.Errorf("--jobs must not be negative")
+		}
+		workers.SetLimit(flagJobs)
+		pricingCacheReadOnly = cmd == benchCmd
+		if flagAnonymize || flagAnonymizeMap != "" {
+			if err := startAnonymize(); err != nil {
+				return err
+			}
+		}
+		startBackgroundUpdateCheck(cmd)
+		return nil
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		finishBackgroundUpdateCheck()
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if flagNoColor {
 			output.SetNoColor(true)
 		}
 
-		cfg, err := config.Load(flagConfig)
+		cfg, err := loadConfig()
 		if err != nil {
 			return fmt.Errorf("loading config: %w", err)
 		}
@@ -57,7 +100,10 @@ Run 'claudewatch' with no arguments to see a quick dashboard summary.`,
 			return nil
 		}
 
-		facets, _ := claude.ParseAllFacets(cfg.ClaudeHome)
+		facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
+		if err != nil {
+			logging.Warn("parsing facets; continuing without them", "err", err)
+		}
 
 		velocity := analyzer.AnalyzeVelocity(sessions, 30)
 		satisfaction := analyzer.AnalyzeSatisfaction(facets)
@@ -71,7 +117,7 @@ Run 'claudewatch' with no arguments to see a quick dashboard summary.`,
 		}
 		outcomes := analyzer.AnalyzeOutcomes(sessions, facets, pricing, cacheRatio)
 
-		renderDashboard(velocity, satisfaction, efficiency, commits, outcomes)
+		renderDashboard(velocity, satisfaction, efficiency, commits, anonymized(outcomes))
 		return nil
 	},
 }
@@ -79,6 +125,9 @@ Run 'claudewatch' with no arguments to see a quick dashboard summary.`,
 // Execute is the entry point called from main.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		if guard.ReadOnly() && store.IsReadOnlyError(err) && !errors.Is(err, guard.ErrReadOnly) {
+			err = fmt.Errorf("%w: %w", err, guard.ErrReadOnly)
+		}
 		fmt.Fprintln(os.Stderr, "error:", err)
 		os.Exit(1)
 	}
@@ -86,9 +135,161 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&flagConfig, "config", "", "Config file path (default: ~/.config/claudewatch/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&flagProfile, "profile", "", "Config profile to merge over the base config (env: "+config.ProfileEnvVar+")")
 	rootCmd.PersistentFlags().BoolVar(&flagNoColor, "no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().StringVar(&flagTheme, "theme", "", "Color theme: "+strings.Join(output.ThemeNames(), ", ")+" (overrides output.theme)")
 	rootCmd.PersistentFlags().BoolVar(&flagJSON, "json", false, "Output as JSON")
-	rootCmd.PersistentFlags().BoolVar(&flagVerbose, "verbose", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "Log parse phases, file counts, timings, and swallowed errors to stderr")
+	rootCmd.PersistentFlags().BoolVar(&flagColorJSON, "color-json", true, "Syntax-highlight JSON output when stdout is a terminal")
+	rootCmd.PersistentFlags().BoolVar(&flagCompactJSON, "compact-json", false, "Write JSON output on a single line instead of indented")
+	rootCmd.PersistentFlags().BoolVar(&flagAnonymize, "anonymize", false, "Replace project paths and names in output with stable pseudonyms")
+	rootCmd.PersistentFlags().StringVar(&flagAnonymizeMap, "anonymize-map", "", "Write the real path to pseudonym mapping to this file (implies --anonymize)")
+	rootCmd.PersistentFlags().BoolVar(&flagOffline, "offline", false, "Never touch the network: no AI fixes, update checks, or pricing fetches")
+	rootCmd.PersistentFlags().BoolVar(&flagReadOnly, "read-only", false, "Never write the claudewatch database; track only previews")
+	rootCmd.PersistentFlags().BoolVar(&flagRedactPrompts, "redact-prompts", false, "Replace session first prompts with their length in output and AI fix requests")
+	rootCmd.PersistentFlags().IntVar(&flagJobs, "jobs", 0, "Projects or transcripts to process at once (default: jobs from the config, else one per CPU; 1 = sequential)")
+}
+
+// loadConfig loads the config file selected by --config with the profile
+// selected by --profile (or CLAUDEWATCH_PROFILE) applied, sets the zone
+// session timestamps are parsed in from its timezone setting, turns on
+// offline, read-only, and prompt redaction when the config asks for them,
+// applies jobs unless --jobs overrides it, and applies the configured noise
+// bands, pricing, and CLAUDE.md sections.
+func loadConfig() (*config.Config, error) {
+	cfg, err := loadConfigUnsynced()
+	if err != nil {
+		return nil, err
+	}
+	if err := mirrorRemoteClaudeHome(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadConfigUnsynced is loadConfig without mirroring a remote claude_home,
+// for commands that only report the configuration. ClaudeHome keeps its
+// ssh:// form.
+func loadConfigUnsynced() (*config.Config, error) {
+	cfg, err := config.LoadProfile(flagConfig, flagProfile)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	claude.SetSessionTimeLocation(loc)
+	guard.SetOffline(flagOffline || cfg.Offline)
+	guard.SetReadOnly(flagReadOnly || cfg.ReadOnly)
+	claude.SetRedactPrompts(flagRedactPrompts || cfg.RedactPrompts)
+	if flagJobs == 0 {
+		workers.SetLimit(cfg.Jobs)
+	}
+	if err := applyTheme(cfg); err != nil {
+		return nil, err
+	}
+	if err := applyNoiseBands(cfg); err != nil {
+		return nil, err
+	}
+	applyPricing(cfg)
+	applyClaudeMDSections(cfg)
+	return cfg, nil
+}
+
+// mirrorRemoteClaudeHome replaces an ssh:// claude_home with the path of its
+// local mirror, syncing the mirror first, and makes the claude parsers read
+// through a remote.FS that keeps it fresh. A failed sync falls back to an
+// earlier mirror with a warning on stderr.
+func mirrorRemoteClaudeHome(cfg *config.Config) error {
+	if !remote.IsRemote(cfg.ClaudeHome) {
+		return nil
+	}
+	h, err := remote.Parse(cfg.ClaudeHome)
+	if err != nil {
+		return err
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return fmt.Errorf("locating cache directory for remote claude_home: %w", err)
+	}
+	fsys := remote.NewFS(filepath.Join(cacheDir, "claudewatch"), h, func(err error) {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	})
+	// Sync now so a host that can't be reached fails before any output, and
+	// readers outside the claude parsers, such as the transcript index, see
+	// the data.
+	if err := fsys.Sync(); err != nil {
+		return err
+	}
+	logging.Debug("remote claude_home mirrored", "remote", h.String(), "dir", fsys.Dir())
+	claude.SetFS(fsys)
+	cfg.ClaudeHome = fsys.Dir()
+	return nil
+}
+
+// applyClaudeMDSections merges the configured claude_md_sections over the
+// built-in CLAUDE.md sections. LoadProfile has already validated them.
+func applyClaudeMDSections(cfg *config.Config) {
+	analyzer.SetClaudeMDSections(cfg.ClaudeMDSections)
+}
+
+// applyTheme activates the --theme flag, or output.theme when it is unset,
+// after checking that the theme exists.
+func applyTheme(cfg *config.Config) error {
+	name, source := cfg.Output.Theme, "output.theme"
+	if flagTheme != "" {
+		name, source = flagTheme, "--theme"
+	}
+	t, ok := output.LookupTheme(name)
+	if !ok {
+		return fmt.Errorf("invalid %s %q (valid: %s)", source, name, strings.Join(output.ThemeNames(), ", "))
+	}
+	output.SetTheme(t)
+	return nil
+}
+
+// pricingFetchTimeout bounds how long a stale pricing.url cache can delay a
+// command.
+const pricingFetchTimeout = 3 * time.Second
+
+// pricingCacheReadOnly stops applyPricing from caching a pricing.url fetch.
+// It is set for bench, which must not write any state.
+var pricingCacheReadOnly bool
+
+// applyPricing layers the configured pricing over the built-in rates: rates
+// fetched from pricing.url first, then pricing.models from the config file.
+// A failed fetch falls back to the last cached document, if any, and is
+// otherwise only logged.
+func applyPricing(cfg *config.Config) {
+	var layers []map[string]analyzer.ModelPricing
+	if cfg.Pricing.URL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), pricingFetchTimeout)
+		fetcher := pricing.NewFetcher(config.ConfigDir(), cfg.Pricing.URL)
+		fetcher.ReadOnlyCache = pricingCacheReadOnly
+		remote, err := fetcher.Fetch(ctx)
+		cancel()
+		if err != nil {
+			logging.Warn("fetching pricing failed", "url", cfg.Pricing.URL, "err", err)
+		}
+		layers = append(layers, modelPricing(remote))
+	}
+	layers = append(layers, modelPricing(cfg.Pricing.Models))
+	analyzer.SetPricingOverrides(layers...)
+}
+
+// modelPricing converts config rates to analyzer pricing.
+func modelPricing(rates map[string]config.ModelRates) map[string]analyzer.ModelPricing {
+	out := make(map[string]analyzer.ModelPricing, len(rates))
+	for model, r := range rates {
+		out[model] = analyzer.ModelPricing{
+			InputPerMillion:      r.Input,
+			OutputPerMillion:     r.Output,
+			CacheReadPerMillion:  r.CacheRead,
+			CacheWritePerMillion: r.CacheWrite,
+		}
+	}
+	return out
 }
 
 func renderDashboard(
@@ -147,3 +348,8 @@ func renderEmptyDashboard() {
 	fmt.Printf(" %s\n", output.StyleMuted.Render("claudewatch gaps       find friction patterns"))
 	fmt.Println()
 }
+
+// trivialSession returns the configured trivial-session definition.
+func trivialSession(cfg *config.Config) analyzer.TrivialSession {
+	return analyzer.TrivialSession(cfg.TrivialSession)
+}
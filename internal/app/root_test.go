@@ -0,0 +1,28 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+func TestVerboseFlag_Shorthand(t *testing.T) {
+	f := rootCmd.PersistentFlags().Lookup("verbose")
+	if f == nil {
+		t.Fatal("expected --verbose persistent flag on rootCmd")
+	}
+	if f.Shorthand != "v" {
+		t.Errorf("expected --verbose shorthand %q, got %q", "v", f.Shorthand)
+	}
+	if f.DefValue != "false" {
+		t.Errorf("expected --verbose to default to quiet, got %q", f.DefValue)
+	}
+}
+
+func TestMirrorRemoteClaudeHome_InvalidAddress(t *testing.T) {
+	cfg := &config.Config{ClaudeHome: "ssh://devbox"}
+	if err := mirrorRemoteClaudeHome(cfg); err == nil || !strings.Contains(err.Error(), "missing path") {
+		t.Errorf("mirrorRemoteClaudeHome() = %v, want a missing path error", err)
+	}
+}
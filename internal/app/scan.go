@@ -1,9 +1,7 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -12,7 +10,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/blackwell-systems/claudewatch/internal/claude"
-	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
 )
@@ -38,7 +35,7 @@ func init() {
 	scanCmd.Flags().StringSliceVar(&scanFlagPaths, "path", nil, "Additional paths to scan (can be repeated)")
 	scanCmd.Flags().Float64Var(&scanFlagMinScore, "min-score", 0, "Only show projects with score >= this value")
 	scanCmd.Flags().BoolVar(&scanFlagJSON, "json", false, "Output as JSON")
-	scanCmd.Flags().StringVar(&scanFlagSort, "sort", "score", "Sort by: score, name, sessions, last-active")
+	scanCmd.Flags().StringVar(&scanFlagSort, "sort", "score", "Sort by: score, weighted, name, sessions, last-active")
 	scanCmd.Flags().BoolVar(&scanFlagIncludeActive, "include-active", false,
 		"Include any currently active (live) Claude Code session in scan output")
 
@@ -53,7 +50,7 @@ type scanResult struct {
 
 func runScan(cmd *cobra.Command, args []string) error {
 	// Load configuration.
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -113,6 +110,7 @@ func runScan(cmd *cobra.Command, args []string) error {
 		// Enrich with session count and last session date.
 		projectSessions := filterSessionsByProject(sessions, p.Path)
 		p.SessionCount = len(projectSessions)
+		p.WeightedScore = scanner.WeightedReadiness(score, p.SessionCount, cfg.ReadinessVolume)
 		if len(projectSessions) > 0 {
 			p.LastSessionDate = projectSessions[len(projectSessions)-1].StartTime
 		}
@@ -134,8 +132,8 @@ func runScan(cmd *cobra.Command, args []string) error {
 		// is intentionally excluded to keep the JSON schema stable.
 		return renderScanJSON(results)
 	}
-	renderScanTable(results, activeMeta)
-	renderScanSummary(results)
+	renderScanTable(anonymized(results), anonymized(activeMeta))
+	renderScanSummary(anonymized(results))
 	return nil
 }
 
@@ -148,6 +146,8 @@ func sortResults(results []scanResult, sortBy string) {
 			return results[i].SessionCount > results[j].SessionCount
 		case "last-active":
 			return results[i].LastSessionDate > results[j].LastSessionDate
+		case "weighted":
+			return results[i].WeightedScore > results[j].WeightedScore
 		default: // "score"
 			return results[i].Score > results[j].Score
 		}
@@ -155,9 +155,7 @@ func sortResults(results []scanResult, sortBy string) {
 }
 
 func renderScanJSON(results []scanResult) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(results)
+	return writeJSON(results)
 }
 
 func renderScanTable(results []scanResult, activeMeta *claude.SessionMeta) {
@@ -238,16 +236,12 @@ func renderScanSummary(results []scanResult) {
 	fmt.Println()
 }
 
-// formatRelativeTime converts an RFC3339 timestamp to a human-friendly
+// formatRelativeTime converts a session timestamp to a human-friendly
 // relative time string like "2d ago", "12h ago", "just now".
 func formatRelativeTime(timestamp string) string {
-	t, err := time.Parse(time.RFC3339, timestamp)
+	t, err := claude.ParseSessionTime(timestamp)
 	if err != nil {
-		// Try date-only format.
-		t, err = time.Parse("2006-01-02", timestamp)
-		if err != nil {
-			return timestamp
-		}
+		return timestamp
 	}
 
 	d := time.Since(t)
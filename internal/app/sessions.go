@@ -1,10 +1,10 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -13,15 +13,25 @@ import (
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/config"
 	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/store"
 	"github.com/spf13/cobra"
 )
 
 var (
-	sessionsFlagSort    string
-	sessionsFlagProject string
-	sessionsFlagDays    int
-	sessionsFlagLimit   int
-	sessionsFlagWorst   bool
+	sessionsFlagSort        string
+	sessionsFlagProject     string
+	sessionsFlagProjectPath string
+	sessionsFlagDays        int
+	sessionsFlagAll         bool
+	sessionsFlagLimit       int
+	sessionsFlagWorst       bool
+	sessionsFlagOutcome     string
+	sessionsFlagNote        string
+	sessionsFlagTag         string
+	sessionsFlagTrivial     bool
+	sessionsFlagCommit      bool
+	sessionsFlagStats       bool
+	sessionsFlagSearch      []string
 )
 
 var sessionsCmd = &cobra.Command{
@@ -34,29 +44,86 @@ understanding where time and tokens went.
 Examples:
   claudewatch sessions                          # recent sessions
   claudewatch sessions --sort friction          # most friction first
+  claudewatch sessions --sort struggle          # most tool errors per commit first
   claudewatch sessions --sort cost              # most expensive first
   claudewatch sessions --worst                  # shortcut for --sort friction
   claudewatch sessions --project claudewatch    # filter by project name
+  claudewatch sessions --project-path ~/src/api # filter by exact project path
   claudewatch sessions --days 7 --limit 5       # last 7 days, top 5
-  claudewatch sessions abc12345                 # inspect a single session by ID prefix`,
+  claudewatch sessions --all --sort cost        # most expensive sessions ever
+  claudewatch sessions --outcome not_achieved   # only failed sessions
+  claudewatch sessions --outcome none           # sessions without a facet
+  claudewatch sessions --tag bug                # only sessions tagged "bug"
+  claudewatch sessions --include-trivial=false  # hide quick one-off sessions
+  claudewatch sessions --since-last-commit      # what happened since the last commit
+  claudewatch sessions --days 7 --stats         # just the totals for the last 7 days
+  claudewatch sessions --all --search auth --search refactor
+  claudewatch sessions abc12345 --search auth   # inspect with "auth" highlighted
+  claudewatch sessions abc12345                 # inspect a single session by ID prefix
+  claudewatch sessions abc12345 --note "auth bug introduced here"
+  claudewatch sessions abc12345 --tag bug       # attach a tag
+
+Without --days, sessions looks back sessions.default_days from the config
+(30 by default). --all drops the window and reads every session on record,
+which can be slow with a large history; --limit still caps the list, so
+combine --all with a --sort to find e.g. the costliest sessions ever.
+
+--search keeps sessions whose first prompt, or facet summary or goal,
+contains the keyword, ignoring case. Repeat it to require several keywords;
+each may match a different field. It combines with the other filters. With
+a session ID, it highlights the keywords when inspecting the session.
+
+--stats skips the table and prints only the totals, averages, and cost and
+duration percentiles of every session matching the filters; --limit and
+--sort don't apply. With --json it emits just that stats object.
+
+Notes and tags are stored in the claudewatch database, independent of track
+snapshots, and shown when inspecting the session.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runSessions,
 }
 
 func init() {
-	sessionsCmd.Flags().StringVar(&sessionsFlagSort, "sort", "recent", "Sort by: recent, friction, cost, duration, commits")
-	sessionsCmd.Flags().StringVar(&sessionsFlagProject, "project", "", "Filter to sessions matching project name or path")
-	sessionsCmd.Flags().IntVar(&sessionsFlagDays, "days", 30, "Number of days to look back")
+	sessionsCmd.Flags().StringVar(&sessionsFlagSort, "sort", "recent", "Sort by: recent, friction, struggle, cost, duration, commits")
+	sessionsCmd.Flags().StringVar(&sessionsFlagProject, "project", "", "Filter to the project matching this name (fuzzy)")
+	sessionsCmd.Flags().StringVar(&sessionsFlagProjectPath, "project-path", "", "Filter to the project at exactly this path")
+	sessionsCmd.MarkFlagsMutuallyExclusive("project", "project-path")
+	sessionsCmd.Flags().IntVar(&sessionsFlagDays, "days", config.DefaultSessionsDays, "Number of days to look back (overrides sessions.default_days)")
+	sessionsCmd.Flags().BoolVar(&sessionsFlagAll, "all", false, "Look back over every session, ignoring --days")
+	sessionsCmd.MarkFlagsMutuallyExclusive("days", "all")
 	sessionsCmd.Flags().IntVar(&sessionsFlagLimit, "limit", 15, "Maximum sessions to display")
 	sessionsCmd.Flags().BoolVar(&sessionsFlagWorst, "worst", false, "Shortcut for --sort friction")
+	sessionsCmd.Flags().StringVar(&sessionsFlagOutcome, "outcome", "", `Filter by facet outcome (e.g. achieved, not_achieved, partial); "" or none for sessions without a facet`)
+	sessionsCmd.Flags().StringVar(&sessionsFlagNote, "note", "", "With a session ID, attach a note to the session")
+	sessionsCmd.Flags().StringVar(&sessionsFlagTag, "tag", "", "With a session ID, tag the session; without one, list only sessions with this tag")
+	sessionsCmd.Flags().BoolVar(&sessionsFlagTrivial, "include-trivial", true, "List trivial sessions (see trivial_session in the config)")
+	sessionsCmd.Flags().BoolVar(&sessionsFlagCommit, "since-last-commit", false, "List only sessions after the most recent one with a commit (per --project)")
+	sessionsCmd.Flags().StringArrayVar(&sessionsFlagSearch, "search", nil, "List only sessions whose first prompt, facet summary, or goal contains this keyword (repeatable; all must match)")
+	sessionsCmd.Flags().BoolVar(&sessionsFlagStats, "stats", false, "Print only aggregate stats for the matching sessions, without the table")
 	rootCmd.AddCommand(sessionsCmd)
 }
 
 // sessionRow combines meta and facet data for a single session.
 type sessionRow struct {
-	Meta          claude.SessionMeta   `json:"meta"`
-	Facet         *claude.SessionFacet `json:"facet,omitempty"`
-	EstimatedCost float64              `json:"estimated_cost"`
+	Meta          claude.SessionMeta     `json:"meta"`
+	Facet         *claude.SessionFacet   `json:"facet,omitempty"`
+	EstimatedCost float64                `json:"estimated_cost"`
+	CostBreakdown analyzer.CostBreakdown `json:"cost_breakdown"`
+	// StruggleScore is the session's analyzer.StruggleScore.
+	StruggleScore float64             `json:"struggle_score"`
+	Notes         []store.SessionNote `json:"notes,omitempty"`
+}
+
+// newSessionRow builds the row for s, pricing it with pricing and cacheRatio.
+func newSessionRow(s claude.SessionMeta, facet *claude.SessionFacet, pricing analyzer.ModelPricing, cacheRatio analyzer.CacheRatio) sessionRow {
+	s.FirstPrompt = claude.RedactPrompt(s.FirstPrompt)
+	return sessionRow{
+		Meta:          s,
+		Facet:         facet,
+		EstimatedCost: analyzer.EstimateSessionCost(s, pricing, cacheRatio),
+		CostBreakdown: analyzer.EstimateSessionCostBreakdown(s, pricing, cacheRatio),
+		StruggleScore: analyzer.StruggleScore(s),
+	}
 }
 
 func (s sessionRow) projectName() string {
@@ -78,7 +145,7 @@ func (s sessionRow) frictionTotal() int {
 }
 
 func runSessions(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -87,6 +154,23 @@ func runSessions(cmd *cobra.Command, args []string) error {
 		output.SetNoColor(true)
 	}
 
+	sessionsFlagNote = strings.TrimSpace(sessionsFlagNote)
+	sessionsFlagTag = strings.TrimSpace(sessionsFlagTag)
+	annotate := cmd.Flags().Changed("note") || (cmd.Flags().Changed("tag") && len(args) == 1)
+	if cmd.Flags().Changed("note") && len(args) == 0 {
+		return fmt.Errorf("--note needs a session ID: claudewatch sessions <id> --note \"text\"")
+	}
+	if annotate && sessionsFlagNote == "" && sessionsFlagTag == "" {
+		return fmt.Errorf("--note and --tag must not be empty")
+	}
+	if sessionsFlagStats && len(args) == 1 {
+		return fmt.Errorf("--stats summarizes the sessions matching the filters and takes no session ID")
+	}
+	search, err := normalizeSearchTerms(sessionsFlagSearch)
+	if err != nil {
+		return err
+	}
+
 	// Load stats-cache once for accurate cost estimation (non-fatal).
 	pricing := analyzer.DefaultPricing["sonnet"]
 	cacheRatio := analyzer.NoCacheRatio()
@@ -98,6 +182,7 @@ func runSessions(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("parsing session meta: %w", err)
 	}
+	total := len(sessions)
 
 	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
 	if err != nil {
@@ -110,15 +195,158 @@ func runSessions(cmd *cobra.Command, args []string) error {
 		facetMap[facets[i].SessionID] = &facets[i]
 	}
 
-	// --inspect mode: a positional session-id argument was provided.
+	// A positional session-id argument: annotate or inspect that session.
 	if len(args) == 1 {
-		return runInspect(args[0], sessions, facetMap, pricing, cacheRatio)
+		matched, err := findSessionByPrefix(args[0], sessions)
+		if err != nil {
+			return err
+		}
+		if annotate {
+			return annotateSession(matched.SessionID, sessionsFlagNote, sessionsFlagTag)
+		}
+		return runInspect(*matched, facetMap, pricing, cacheRatio, search)
+	}
+
+	if !sessionsFlagTrivial {
+		sessions, _ = analyzer.FilterTrivialSessions(sessions, trivialSession(cfg))
 	}
 
 	// Build combined rows.
-	var rows []sessionRow
-	cutoff := time.Now().AddDate(0, 0, -sessionsFlagDays)
+	cutoff := sessionsCutoff(time.Now(), cmd.Flags().Changed("days"), sessionsFlagDays, sessionsFlagAll, cfg)
+	project, err := resolveProjectFilter(sessionsFlagProject, sessionsFlagProjectPath, sessions)
+	if err != nil {
+		return err
+	}
+	rows := buildSessionRows(sessions, facetMap, cutoff, project, pricing, cacheRatio)
+	var note string
+	if sessionsFlagCommit {
+		var anchor *sessionRow
+		rows, anchor = sessionRowsSinceLastCommit(rows)
+		note = sinceLastCommitNote(anchor, len(rows))
+		if anchor != nil && len(rows) == 0 && !flagJSON {
+			fmt.Printf(" %s\n", note)
+			return nil
+		}
+	}
+	if cmd.Flags().Changed("outcome") {
+		rows = filterSessionRowsByOutcome(rows, sessionsFlagOutcome)
+	}
+	if sessionsFlagTag != "" {
+		tagged, err := loadTaggedSessionIDs(sessionsFlagTag)
+		if err != nil {
+			return err
+		}
+		rows = filterSessionRowsByID(rows, tagged)
+	}
+	if len(search) > 0 {
+		rows = filterSessionRowsBySearch(rows, search)
+	}
 
+	if sessionsFlagStats && flagJSON {
+		return writeJSON(computeSessionStats(rows))
+	}
+
+	if len(rows) == 0 {
+		if flagJSON {
+			return writeJSON([]sessionRow{})
+		}
+		if total == 0 {
+			printNoSessions(os.Stdout, cfg, 0, "")
+			return nil
+		}
+		fmt.Println(" No sessions found matching filters.")
+		return nil
+	}
+
+	if sessionsFlagStats {
+		renderSessionStats(anonymized(computeSessionStats(rows)), note)
+		return nil
+	}
+
+	// Sort.
+	sortKey := sessionsFlagSort
+	if sessionsFlagWorst {
+		sortKey = "friction"
+	}
+	sortSessionRows(rows, sortKey)
+
+	// Limit.
+	if sessionsFlagLimit > 0 && len(rows) > sessionsFlagLimit {
+		rows = rows[:sessionsFlagLimit]
+	}
+
+	// JSON output.
+	if flagJSON {
+		return writeJSON(rows)
+	}
+
+	renderSessions(anonymized(rows), sortKey, note)
+	return nil
+}
+
+// sessionRowsSinceLastCommit returns the rows that started after the most
+// recent row with a git commit, along with that row. With no committing row
+// it returns every row and a nil anchor.
+func sessionRowsSinceLastCommit(rows []sessionRow) ([]sessionRow, *sessionRow) {
+	var anchor *sessionRow
+	var anchorTime time.Time
+	for i := range rows {
+		if rows[i].Meta.GitCommits == 0 {
+			continue
+		}
+		if t := claude.ParseTimestamp(rows[i].Meta.StartTime); anchor == nil || t.After(anchorTime) {
+			anchor, anchorTime = &rows[i], t
+		}
+	}
+	if anchor == nil {
+		return rows, nil
+	}
+
+	since := *anchor
+	var kept []sessionRow
+	for _, r := range rows {
+		if claude.ParseTimestamp(r.Meta.StartTime).After(anchorTime) {
+			kept = append(kept, r)
+		}
+	}
+	return kept, &since
+}
+
+// sinceLastCommitNote describes the --since-last-commit anchor for the
+// listing header.
+func sinceLastCommitNote(anchor *sessionRow, n int) string {
+	if anchor == nil {
+		return "No session with a commit in range; listing every session."
+	}
+	last := fmt.Sprintf("session %s (%s, %s)", truncateID(anchor.Meta.SessionID),
+		anchor.projectName(), claude.ParseTimestamp(anchor.Meta.StartTime).Local().Format("Jan 02 15:04"))
+	switch n {
+	case 0:
+		return "No sessions since the last commit, in " + last + "."
+	case 1:
+		return "1 session without a commit since " + last + "."
+	default:
+		return fmt.Sprintf("%d sessions without a commit since %s.", n, last)
+	}
+}
+
+// buildSessionRows joins sessions started on or after cutoff with their facet
+// and estimated cost. A non-empty project, a path from resolveProjectFilter,
+// keeps only that project's sessions.
+// sessionsCutoff returns the earliest start time listed: none with --all,
+// --days back when given, and otherwise sessions.default_days back.
+func sessionsCutoff(now time.Time, daysSet bool, days int, all bool, cfg *config.Config) time.Time {
+	switch {
+	case all:
+		return time.Time{}
+	case !daysSet:
+		days = cfg.Sessions.DefaultDays
+	}
+	return now.AddDate(0, 0, -days)
+}
+
+func buildSessionRows(sessions []claude.SessionMeta, facetMap map[string]*claude.SessionFacet, cutoff time.Time, project string, pricing analyzer.ModelPricing, cacheRatio analyzer.CacheRatio) []sessionRow {
+	var rows []sessionRow
 	for _, s := range sessions {
 		t := claude.ParseTimestamp(s.StartTime)
 		if t.IsZero() {
@@ -128,35 +356,38 @@ func runSessions(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		row := sessionRow{
-			Meta:          s,
-			Facet:         facetMap[s.SessionID],
-			EstimatedCost: analyzer.EstimateSessionCost(s, pricing, cacheRatio),
-		}
-
 		// Project filter.
-		if sessionsFlagProject != "" {
-			name := filepath.Base(s.ProjectPath)
-			if !strings.Contains(strings.ToLower(name), strings.ToLower(sessionsFlagProject)) &&
-				!strings.Contains(strings.ToLower(s.ProjectPath), strings.ToLower(sessionsFlagProject)) {
-				continue
-			}
+		if project != "" && claude.NormalizePath(s.ProjectPath) != project {
+			continue
 		}
 
-		rows = append(rows, row)
-	}
-
-	if len(rows) == 0 {
-		fmt.Println(" No sessions found matching filters.")
-		return nil
+		rows = append(rows, newSessionRow(s, facetMap[s.SessionID], pricing, cacheRatio))
 	}
+	return rows
+}
 
-	// Sort.
-	sortKey := sessionsFlagSort
-	if sessionsFlagWorst {
-		sortKey = "friction"
+// filterSessionRowsByOutcome keeps rows whose facet outcome matches outcome
+// (case-insensitive). An empty outcome or "none" keeps only rows without a
+// facet.
+func filterSessionRowsByOutcome(rows []sessionRow, outcome string) []sessionRow {
+	outcome = strings.ToLower(strings.TrimSpace(outcome))
+	var kept []sessionRow
+	for _, r := range rows {
+		switch {
+		case outcome == "" || outcome == "none":
+			if r.Facet == nil {
+				kept = append(kept, r)
+			}
+		case r.Facet != nil && strings.ToLower(r.Facet.Outcome) == outcome:
+			kept = append(kept, r)
+		}
 	}
+	return kept
+}
 
+// sortSessionRows orders rows by the given --sort key, most recent first for
+// unknown keys.
+func sortSessionRows(rows []sessionRow, sortKey string) {
 	switch sortKey {
 	case "friction":
 		sort.Slice(rows, func(i, j int) bool {
@@ -170,6 +401,13 @@ func runSessions(cmd *cobra.Command, args []string) error {
 		sort.Slice(rows, func(i, j int) bool {
 			return rows[i].EstimatedCost > rows[j].EstimatedCost
 		})
+	case "struggle":
+		sort.Slice(rows, func(i, j int) bool {
+			if rows[i].StruggleScore != rows[j].StruggleScore {
+				return rows[i].StruggleScore > rows[j].StruggleScore
+			}
+			return rows[i].Meta.ToolErrors > rows[j].Meta.ToolErrors
+		})
 	case "duration":
 		sort.Slice(rows, func(i, j int) bool {
 			return rows[i].Meta.DurationMinutes > rows[j].Meta.DurationMinutes
@@ -183,57 +421,118 @@ func runSessions(cmd *cobra.Command, args []string) error {
 			return rows[i].Meta.StartTime > rows[j].Meta.StartTime
 		})
 	}
-
-	// Limit.
-	if sessionsFlagLimit > 0 && len(rows) > sessionsFlagLimit {
-		rows = rows[:sessionsFlagLimit]
-	}
-
-	// JSON output.
-	if flagJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(rows)
-	}
-
-	renderSessions(rows, sortKey)
-	return nil
 }
 
-// runInspect finds a session by full ID or prefix and renders a detailed view.
-func runInspect(prefix string, sessions []claude.SessionMeta, facetMap map[string]*claude.SessionFacet, pricing analyzer.ModelPricing, cacheRatio analyzer.CacheRatio) error {
+// findSessionByPrefix returns the session whose ID equals prefix or starts
+// with it, and errors when none or several match.
+func findSessionByPrefix(prefix string, sessions []claude.SessionMeta) (*claude.SessionMeta, error) {
 	var matched *claude.SessionMeta
 	for i := range sessions {
 		s := &sessions[i]
 		if s.SessionID == prefix || strings.HasPrefix(s.SessionID, prefix) {
 			if matched != nil {
-				return fmt.Errorf("ambiguous session prefix %q — matches multiple sessions; use more characters", prefix)
+				return nil, fmt.Errorf("ambiguous session prefix %q — matches multiple sessions; use more characters", prefix)
 			}
 			matched = s
 		}
 	}
 	if matched == nil {
-		return fmt.Errorf("no session found matching %q", prefix)
+		return nil, fmt.Errorf("no session found matching %q", prefix)
 	}
+	return matched, nil
+}
+
+// runInspect renders a detailed view of a single session, highlighting the
+// search terms, if any.
+func runInspect(meta claude.SessionMeta, facetMap map[string]*claude.SessionFacet, pricing analyzer.ModelPricing, cacheRatio analyzer.CacheRatio, search []string) error {
+	row := newSessionRow(meta, facetMap[meta.SessionID], pricing, cacheRatio)
 
-	row := sessionRow{
-		Meta:          *matched,
-		Facet:         facetMap[matched.SessionID],
-		EstimatedCost: analyzer.EstimateSessionCost(*matched, pricing, cacheRatio),
+	notes, err := loadSessionNotes(meta.SessionID)
+	if err != nil {
+		return err
 	}
+	row.Notes = notes
 
 	if flagJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(row)
+		return writeJSON(row)
 	}
 
-	renderInspect(row)
+	renderInspect(anonymized(row), searchPattern(search))
 	return nil
 }
 
-// renderInspect prints a detailed single-session view.
-func renderInspect(r sessionRow) {
+// annotateSession attaches a note, a tag, or both to the session with the
+// given full ID.
+func annotateSession(sessionID, note, tag string) error {
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if note != "" {
+		if err := db.AddSessionNote(sessionID, note); err != nil {
+			return err
+		}
+		fmt.Printf("Added note to session %s.\n", sessionID)
+	}
+	if tag != "" {
+		added, err := db.AddSessionTag(sessionID, tag)
+		if err != nil {
+			return err
+		}
+		if added {
+			fmt.Printf("Tagged session %s: %s\n", sessionID, tag)
+		} else {
+			fmt.Printf("Session %s is already tagged %s.\n", sessionID, tag)
+		}
+	}
+	return nil
+}
+
+// loadSessionNotes returns the notes and tags attached to a session. Reading
+// them never creates the database.
+func loadSessionNotes(sessionID string) ([]store.SessionNote, error) {
+	if _, err := os.Stat(config.DBPath()); os.IsNotExist(err) {
+		return nil, nil
+	}
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+	return db.GetSessionNotes(sessionID)
+}
+
+// loadTaggedSessionIDs returns the IDs of sessions carrying tag. Reading them
+// never creates the database.
+func loadTaggedSessionIDs(tag string) (map[string]bool, error) {
+	if _, err := os.Stat(config.DBPath()); os.IsNotExist(err) {
+		return nil, nil
+	}
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+	return db.SessionIDsWithTag(tag)
+}
+
+// filterSessionRowsByID keeps the rows whose session ID is in ids.
+func filterSessionRowsByID(rows []sessionRow, ids map[string]bool) []sessionRow {
+	var kept []sessionRow
+	for _, r := range rows {
+		if ids[r.Meta.SessionID] {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// renderInspect prints a detailed single-session view, highlighting the
+// matches of search, which may be nil, in the goal, summary, and first
+// prompt.
+func renderInspect(r sessionRow, search *regexp.Regexp) {
 	fmt.Println(output.Section("Session Inspect"))
 	fmt.Println()
 
@@ -258,6 +557,8 @@ func renderInspect(r sessionRow) {
 
 	fmt.Println()
 
+	renderSessionNotes(r.Notes)
+
 	// Messages
 	fmt.Println(output.Section("Messages"))
 	fmt.Println()
@@ -271,6 +572,11 @@ func renderInspect(r sessionRow) {
 	fmt.Println()
 	muted("Input tokens", fmt.Sprintf("%d", r.Meta.InputTokens))
 	muted("Output tokens", fmt.Sprintf("%d", r.Meta.OutputTokens))
+	if r.Meta.CacheReadInputTokens > 0 || r.Meta.CacheCreationInputTokens > 0 {
+		muted("Cache read tokens", fmt.Sprintf("%d", r.Meta.CacheReadInputTokens))
+		muted("Cache write tokens", fmt.Sprintf("%d", r.Meta.CacheCreationInputTokens))
+	}
+	renderCostBreakdown(r.CostBreakdown, muted)
 	label("Estimated cost", fmt.Sprintf("$%.4f", r.EstimatedCost))
 
 	fmt.Println()
@@ -354,35 +660,79 @@ func renderInspect(r sessionRow) {
 
 		fmt.Printf(" %s  %s\n", output.StyleLabel.Render("Outcome"), outcomeStyled)
 		muted("Claude helpfulness", r.Facet.ClaudeHelpfulness)
-		muted("Goal", r.Facet.UnderlyingGoal)
-		muted("Summary", r.Facet.BriefSummary)
+		fmt.Printf(" %s  %s\n", output.StyleLabel.Render("Goal"), highlightMatches(r.Facet.UnderlyingGoal, search))
+		fmt.Printf(" %s  %s\n", output.StyleLabel.Render("Summary"), highlightMatches(r.Facet.BriefSummary, search))
 	}
 
 	fmt.Println()
 
-	// First prompt (truncated to 200 chars)
+	// First prompt (truncated to 200 chars, around the first match when
+	// searching)
 	fmt.Println(output.Section("First Prompt"))
 	fmt.Println()
 	prompt := r.Meta.FirstPrompt
 	if len(prompt) == 0 {
 		fmt.Printf(" %s\n", output.StyleMuted.Render("(none recorded)"))
 	} else {
-		if len(prompt) > 200 {
-			prompt = prompt[:200] + "…"
-		}
-		fmt.Printf(" %s\n", output.StyleMuted.Render(prompt))
+		fmt.Printf(" %s\n", highlightMatches(promptExcerpt(prompt, 200, search), search))
 	}
 
 	fmt.Println()
 }
 
-func renderSessions(rows []sessionRow, sortKey string) {
+// renderSessionNotes prints the user's tags and notes for a session, if any.
+func renderSessionNotes(notes []store.SessionNote) {
+	if len(notes) == 0 {
+		return
+	}
+	fmt.Println(output.Section("Notes & Tags"))
+	fmt.Println()
+	var tags []string
+	for _, n := range notes {
+		if n.Kind == store.SessionNoteKindTag {
+			tags = append(tags, n.Text)
+		}
+	}
+	if len(tags) > 0 {
+		fmt.Printf(" %s  %s\n", output.StyleLabel.Render("Tags"), output.StyleBold.Render(strings.Join(tags, ", ")))
+	}
+	for _, n := range notes {
+		if n.Kind != store.SessionNoteKindNote {
+			continue
+		}
+		fmt.Printf(" %s  %s\n", output.StyleLabel.Render(n.CreatedAt.Local().Format("2006-01-02 15:04")), n.Text)
+	}
+	fmt.Println()
+}
+
+func renderSessions(rows []sessionRow, sortKey, note string) {
 	fmt.Println(output.Section("Sessions"))
 	fmt.Println()
 	fmt.Printf(" %s  sorted by %s\n\n",
 		output.StyleMuted.Render(fmt.Sprintf("%d sessions", len(rows))),
 		output.StyleBold.Render(sortKey))
+	if note != "" {
+		fmt.Printf(" %s\n\n", output.StyleWarning.Render(note))
+	}
+
+	sessionsTable(rows).Print()
 
+	// Summary stats footer.
+	stats := computeSessionStats(rows)
+	fmt.Println()
+	fmt.Printf(" %s\n", output.StyleBold.Render(fmt.Sprintf(
+		"Totals: $%.2f cost · %d commits · %.1f avg friction · %.0fm avg duration",
+		stats.TotalCost, stats.TotalCommits, stats.AvgFriction, stats.AvgDurationMinutes,
+	)))
+	fmt.Println()
+	fmt.Printf(" %s\n", output.StyleMuted.Render("Use --sort friction|struggle|cost|duration|commits to reorder"))
+	fmt.Printf(" %s\n", output.StyleMuted.Render("Use --project <name> to filter, --json for machine output"))
+	fmt.Printf(" %s\n", output.StyleMuted.Render("Use claudewatch sessions <session-id> to inspect a session"))
+}
+
+// sessionsTable renders rows as the sessions listing table, highlighting
+// high-friction and high-error cells.
+func sessionsTable(rows []sessionRow) *output.Table {
 	tbl := output.NewTable("Date", "Project", "Duration", "User Msgs", "Commits", "Friction", "Errors", "Cost", "Outcome")
 
 	for _, r := range rows {
@@ -429,36 +779,26 @@ func renderSessions(rows []sessionRow, sortKey string) {
 		)
 	}
 
-	tbl.Print()
-
-	// Summary stats footer.
-	var totalCost float64
-	var totalFriction int
-	var totalCommits int
-	var totalDuration int
+	return tbl
+}
 
-	for _, r := range rows {
-		totalCost += r.EstimatedCost
-		totalFriction += r.frictionTotal()
-		totalCommits += r.Meta.GitCommits
-		totalDuration += r.Meta.DurationMinutes
+// renderCostBreakdown prints the components of a session's estimated cost,
+// noting how the cache components were derived.
+func renderCostBreakdown(b analyzer.CostBreakdown, line func(label, value string)) {
+	line("Input cost", fmt.Sprintf("$%.4f", b.Input))
+	line("Output cost", fmt.Sprintf("$%.4f", b.Output))
+	if b.Basis == analyzer.CostBasisNoCache {
+		line("Cache", "no cache data; all input priced as uncached")
+		return
 	}
-
-	n := len(rows)
-	avgFriction := 0.0
-	avgDuration := 0.0
-	if n > 0 {
-		avgFriction = float64(totalFriction) / float64(n)
-		avgDuration = float64(totalDuration) / float64(n)
+	line("Cache read cost", fmt.Sprintf("$%.4f", b.CacheRead))
+	line("Cache write cost", fmt.Sprintf("$%.4f", b.CacheWrite))
+	savings := fmt.Sprintf("$%.4f", b.CacheSavings)
+	if b.CacheSavings < 0 {
+		savings = fmt.Sprintf("-$%.4f (writes outweighed reads)", -b.CacheSavings)
 	}
-
-	fmt.Println()
-	fmt.Printf(" %s\n", output.StyleBold.Render(fmt.Sprintf(
-		"Totals: $%.2f cost · %d commits · %.1f avg friction · %.0fm avg duration",
-		totalCost, totalCommits, avgFriction, avgDuration,
-	)))
-	fmt.Println()
-	fmt.Printf(" %s\n", output.StyleMuted.Render("Use --sort friction|cost|duration|commits to reorder"))
-	fmt.Printf(" %s\n", output.StyleMuted.Render("Use --project <name> to filter, --json for machine output"))
-	fmt.Printf(" %s\n", output.StyleMuted.Render("Use claudewatch sessions <session-id> to inspect a session"))
+	if b.Basis == analyzer.CostBasisCacheRatio {
+		savings += " (estimated from your overall cache ratio)"
+	}
+	line("Cache savings", savings)
 }
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+// normalizeSearchTerms trims the --search values and rejects empty ones.
+func normalizeSearchTerms(terms []string) ([]string, error) {
+	normalized := make([]string, 0, len(terms))
+	for _, t := range terms {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			return nil, fmt.Errorf("--search must not be empty")
+		}
+		normalized = append(normalized, t)
+	}
+	return normalized, nil
+}
+
+// searchText returns the texts of a row --search looks in: the first prompt
+// and, with a facet, its brief summary and underlying goal.
+func (s sessionRow) searchText() []string {
+	texts := []string{s.Meta.FirstPrompt}
+	if s.Facet != nil {
+		texts = append(texts, s.Facet.BriefSummary, s.Facet.UnderlyingGoal)
+	}
+	return texts
+}
+
+// matchesSearch reports whether every term appears, ignoring case, in at
+// least one of the row's search texts.
+func (s sessionRow) matchesSearch(terms []string) bool {
+	texts := s.searchText()
+	for i := range texts {
+		texts[i] = strings.ToLower(texts[i])
+	}
+	for _, term := range terms {
+		term = strings.ToLower(term)
+		found := false
+		for _, text := range texts {
+			if strings.Contains(text, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// filterSessionRowsBySearch keeps the rows matching every term.
+func filterSessionRowsBySearch(rows []sessionRow, terms []string) []sessionRow {
+	var kept []sessionRow
+	for _, r := range rows {
+		if r.matchesSearch(terms) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// searchPattern returns a case-insensitive pattern matching any of terms,
+// or nil without terms.
+func searchPattern(terms []string) *regexp.Regexp {
+	if len(terms) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = regexp.QuoteMeta(t)
+	}
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
+
+// highlightMatches renders text muted with the matches of pattern
+// highlighted. A nil pattern renders it all muted.
+func highlightMatches(text string, pattern *regexp.Regexp) string {
+	if pattern == nil {
+		return output.StyleMuted.Render(text)
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range pattern.FindAllStringIndex(text, -1) {
+		if m[0] > last {
+			b.WriteString(output.StyleMuted.Render(text[last:m[0]]))
+		}
+		b.WriteString(output.StyleWarning.Bold(true).Render(text[m[0]:m[1]]))
+		last = m[1]
+	}
+	if last < len(text) {
+		b.WriteString(output.StyleMuted.Render(text[last:]))
+	}
+	return b.String()
+}
+
+// promptExcerpt cuts prompt to limit bytes for display. When the first
+// match of pattern lies beyond the cut, the excerpt starts shortly before
+// it instead, so the match stays visible.
+func promptExcerpt(prompt string, limit int, pattern *regexp.Regexp) string {
+	if len(prompt) <= limit {
+		return prompt
+	}
+	start := 0
+	if pattern != nil {
+		if m := pattern.FindStringIndex(prompt); m != nil && m[1] > limit {
+			start = max(0, m[0]-limit/4)
+			for start > 0 && !utf8.RuneStart(prompt[start]) {
+				start--
+			}
+		}
+	}
+	end := min(start+limit, len(prompt))
+	for end < len(prompt) && !utf8.RuneStart(prompt[end]) {
+		end--
+	}
+	excerpt := prompt[start:end]
+	if start > 0 {
+		excerpt = "…" + excerpt
+	}
+	if end < len(prompt) {
+		excerpt += "…"
+	}
+	return excerpt
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+// sessionPercentiles summarizes the spread of one per-session value.
+type sessionPercentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P95 float64 `json:"p95"`
+	Max float64 `json:"max"`
+}
+
+// sessionStats is the aggregate summary of a set of session rows, the
+// sessions footer on its own with sessions --stats.
+type sessionStats struct {
+	Sessions           int                `json:"sessions"`
+	TotalCost          float64            `json:"total_cost"`
+	TotalCommits       int                `json:"total_commits"`
+	AvgFriction        float64            `json:"avg_friction"`
+	AvgDurationMinutes float64            `json:"avg_duration_minutes"`
+	Cost               sessionPercentiles `json:"cost"`
+	DurationMinutes    sessionPercentiles `json:"duration_minutes"`
+}
+
+// computeSessionStats totals rows and averages their friction and duration.
+func computeSessionStats(rows []sessionRow) sessionStats {
+	stats := sessionStats{Sessions: len(rows)}
+	if len(rows) == 0 {
+		return stats
+	}
+
+	var totalFriction, totalDuration int
+	costs := make([]float64, len(rows))
+	durations := make([]float64, len(rows))
+	for i, r := range rows {
+		stats.TotalCost += r.EstimatedCost
+		stats.TotalCommits += r.Meta.GitCommits
+		totalFriction += r.frictionTotal()
+		totalDuration += r.Meta.DurationMinutes
+		costs[i] = r.EstimatedCost
+		durations[i] = float64(r.Meta.DurationMinutes)
+	}
+	n := float64(len(rows))
+	stats.AvgFriction = float64(totalFriction) / n
+	stats.AvgDurationMinutes = float64(totalDuration) / n
+	stats.Cost = percentilesOf(costs)
+	stats.DurationMinutes = percentilesOf(durations)
+	return stats
+}
+
+// percentilesOf sorts vals in place and returns their percentiles.
+func percentilesOf(vals []float64) sessionPercentiles {
+	if len(vals) == 0 {
+		return sessionPercentiles{}
+	}
+	sort.Float64s(vals)
+	return sessionPercentiles{
+		P50: percentile(vals, 50),
+		P90: percentile(vals, 90),
+		P95: percentile(vals, 95),
+		Max: vals[len(vals)-1],
+	}
+}
+
+// percentile returns the pth percentile of sorted, interpolating linearly
+// between the two nearest values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+// renderSessionStats prints sessions --stats: the totals and averages, then
+// the cost and duration percentiles.
+func renderSessionStats(stats sessionStats, note string) {
+	fmt.Println(output.Section("Session Stats"))
+	fmt.Println()
+	fmt.Printf(" %s\n\n", output.StyleMuted.Render(fmt.Sprintf("%d sessions", stats.Sessions)))
+	if note != "" {
+		fmt.Printf(" %s\n\n", output.StyleWarning.Render(note))
+	}
+
+	label := func(l, v string) {
+		fmt.Printf(" %s  %s\n", output.StyleLabel.Render(l), output.StyleBold.Render(v))
+	}
+	label("Total cost", fmt.Sprintf("$%.2f", stats.TotalCost))
+	label("Commits", fmt.Sprintf("%d", stats.TotalCommits))
+	label("Avg friction", fmt.Sprintf("%.1f", stats.AvgFriction))
+	label("Avg duration", fmt.Sprintf("%.0fm", stats.AvgDurationMinutes))
+	fmt.Println()
+
+	tbl := output.NewTable("", "p50", "p90", "p95", "Max")
+	c, d := stats.Cost, stats.DurationMinutes
+	tbl.AddRow("Cost",
+		fmt.Sprintf("$%.2f", c.P50), fmt.Sprintf("$%.2f", c.P90), fmt.Sprintf("$%.2f", c.P95), fmt.Sprintf("$%.2f", c.Max))
+	tbl.AddRow("Duration",
+		fmt.Sprintf("%.0fm", d.P50), fmt.Sprintf("%.0fm", d.P90), fmt.Sprintf("%.0fm", d.P95), fmt.Sprintf("%.0fm", d.Max))
+	tbl.Print()
+}
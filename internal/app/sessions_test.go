@@ -0,0 +1,332 @@
+package app
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+func TestFilterSessionRowsByOutcome(t *testing.T) {
+	rows := []sessionRow{
+		{Meta: claude.SessionMeta{SessionID: "a"}, Facet: &claude.SessionFacet{SessionID: "a", Outcome: "achieved"}},
+		{Meta: claude.SessionMeta{SessionID: "b"}, Facet: &claude.SessionFacet{SessionID: "b", Outcome: "not_achieved"}},
+		{Meta: claude.SessionMeta{SessionID: "c"}},
+		{Meta: claude.SessionMeta{SessionID: "d"}, Facet: &claude.SessionFacet{SessionID: "d", Outcome: "partial"}},
+		{Meta: claude.SessionMeta{SessionID: "e"}, Facet: &claude.SessionFacet{SessionID: "e", Outcome: "not_achieved"}},
+	}
+
+	tests := []struct {
+		outcome string
+		want    []string
+	}{
+		{"not_achieved", []string{"b", "e"}},
+		{"NOT_ACHIEVED", []string{"b", "e"}},
+		{"achieved", []string{"a"}},
+		{"partial", []string{"d"}},
+		{"", []string{"c"}},
+		{"none", []string{"c"}},
+		{"mostly_achieved", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, r := range filterSessionRowsByOutcome(rows, tt.outcome) {
+			got = append(got, r.Meta.SessionID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("outcome %q: got %v, want %v", tt.outcome, got, tt.want)
+		}
+	}
+}
+
+func TestFilterSessionRowsByOutcome_CombinesWithProjectAndSort(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "a", ProjectPath: "/code/api", StartTime: "2026-03-10T09:00:00Z", DurationMinutes: 10},
+		{SessionID: "b", ProjectPath: "/code/api", StartTime: "2026-03-11T09:00:00Z", DurationMinutes: 50},
+		{SessionID: "c", ProjectPath: "/code/web", StartTime: "2026-03-12T09:00:00Z", DurationMinutes: 90},
+		{SessionID: "d", ProjectPath: "/code/api", StartTime: "2026-01-01T09:00:00Z", DurationMinutes: 99},
+	}
+	facetMap := map[string]*claude.SessionFacet{
+		"a": {SessionID: "a", Outcome: "not_achieved"},
+		"b": {SessionID: "b", Outcome: "not_achieved"},
+		"c": {SessionID: "c", Outcome: "not_achieved"},
+		"d": {SessionID: "d", Outcome: "not_achieved"},
+	}
+	cutoff := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := buildSessionRows(sessions, facetMap, cutoff, "/code/api", analyzer.DefaultPricing["sonnet"], analyzer.NoCacheRatio())
+	rows = filterSessionRowsByOutcome(rows, "not_achieved")
+	sortSessionRows(rows, "duration")
+
+	var got []string
+	for _, r := range rows {
+		got = append(got, r.Meta.SessionID)
+	}
+	if want := []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSortSessionRows_Struggle(t *testing.T) {
+	pricing, ratio := analyzer.DefaultPricing["sonnet"], analyzer.NoCacheRatio()
+	var rows []sessionRow
+	for _, s := range []claude.SessionMeta{
+		{SessionID: "clean", GitCommits: 5},
+		{SessionID: "slog", ToolErrors: 20, GitCommits: 1},
+		{SessionID: "rough", ToolErrors: 2},
+		{SessionID: "noisy", ToolErrors: 8, GitCommits: 3},
+	} {
+		rows = append(rows, newSessionRow(s, nil, pricing, ratio))
+	}
+
+	sortSessionRows(rows, "struggle")
+
+	var got []string
+	for _, r := range rows {
+		got = append(got, r.Meta.SessionID)
+	}
+	// slog 10, noisy and rough 2 (more errors first), clean 0.
+	if want := []string{"slog", "noisy", "rough", "clean"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if rows[0].StruggleScore != 10 {
+		t.Errorf("slog StruggleScore = %.1f, want 10", rows[0].StruggleScore)
+	}
+}
+
+func TestSessionsFlags_OutcomeRegistered(t *testing.T) {
+	f := sessionsCmd.Flags().Lookup("outcome")
+	if f == nil {
+		t.Fatal("expected --outcome flag to be registered on sessionsCmd")
+	}
+	if f.DefValue != "" {
+		t.Errorf("expected --outcome default %q, got %q", "", f.DefValue)
+	}
+}
+
+func TestFindSessionByPrefix(t *testing.T) {
+	sessions := []claude.SessionMeta{
+		{SessionID: "abc123"},
+		{SessionID: "abd456"},
+	}
+
+	s, err := findSessionByPrefix("abc", sessions)
+	if err != nil || s.SessionID != "abc123" {
+		t.Errorf("findSessionByPrefix(abc) = %v, %v; want abc123", s, err)
+	}
+	if _, err := findSessionByPrefix("ab", sessions); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected ambiguous prefix error, got %v", err)
+	}
+	if _, err := findSessionByPrefix("zzz", sessions); err == nil || !strings.Contains(err.Error(), "no session found") {
+		t.Errorf("expected no session found error, got %v", err)
+	}
+}
+
+func TestFilterSessionRowsByID(t *testing.T) {
+	rows := []sessionRow{
+		{Meta: claude.SessionMeta{SessionID: "a"}},
+		{Meta: claude.SessionMeta{SessionID: "b"}},
+		{Meta: claude.SessionMeta{SessionID: "c"}},
+	}
+
+	var got []string
+	for _, r := range filterSessionRowsByID(rows, map[string]bool{"a": true, "c": true, "x": true}) {
+		got = append(got, r.Meta.SessionID)
+	}
+	if want := []string{"a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if kept := filterSessionRowsByID(rows, nil); len(kept) != 0 {
+		t.Errorf("expected no rows without tagged IDs, got %d", len(kept))
+	}
+}
+
+func TestNewSessionRow_CostBreakdownMatchesEstimate(t *testing.T) {
+	s := claude.SessionMeta{SessionID: "a", InputTokens: 2_000_000, OutputTokens: 50_000}
+	ratio := analyzer.CacheRatio{CacheReadMultiplier: 4, CacheWriteMultiplier: 0.5}
+
+	row := newSessionRow(s, nil, analyzer.DefaultPricing["sonnet"], ratio)
+	if row.CostBreakdown.Basis != analyzer.CostBasisCacheRatio {
+		t.Errorf("Basis = %q, want %q", row.CostBreakdown.Basis, analyzer.CostBasisCacheRatio)
+	}
+	if diff := row.CostBreakdown.Total - row.EstimatedCost; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("breakdown total %.6f does not match estimated cost %.6f", row.CostBreakdown.Total, row.EstimatedCost)
+	}
+
+	row = newSessionRow(s, nil, analyzer.DefaultPricing["sonnet"], analyzer.NoCacheRatio())
+	if row.CostBreakdown.Basis != analyzer.CostBasisNoCache || row.CostBreakdown.CacheRead != 0 {
+		t.Errorf("expected a no-cache breakdown, got %+v", row.CostBreakdown)
+	}
+}
+
+func TestSessionRowsSinceLastCommit(t *testing.T) {
+	rows := []sessionRow{
+		{Meta: claude.SessionMeta{SessionID: "a", StartTime: "2026-03-01T10:00:00Z", GitCommits: 2}},
+		{Meta: claude.SessionMeta{SessionID: "b", StartTime: "2026-03-03T10:00:00Z", GitCommits: 1}},
+		{Meta: claude.SessionMeta{SessionID: "c", StartTime: "2026-03-02T10:00:00Z"}},
+		{Meta: claude.SessionMeta{SessionID: "d", StartTime: "2026-03-04T10:00:00Z"}},
+		{Meta: claude.SessionMeta{SessionID: "e", StartTime: "2026-03-05T10:00:00Z"}},
+	}
+
+	kept, anchor := sessionRowsSinceLastCommit(rows)
+	if anchor == nil || anchor.Meta.SessionID != "b" {
+		t.Fatalf("expected session b as the last commit, got %+v", anchor)
+	}
+	if len(kept) != 2 || kept[0].Meta.SessionID != "d" || kept[1].Meta.SessionID != "e" {
+		t.Errorf("expected sessions d and e after the last commit, got %+v", kept)
+	}
+	if note := sinceLastCommitNote(anchor, len(kept)); !strings.HasPrefix(note, "2 sessions without a commit since session b (") {
+		t.Errorf("unexpected note %q", note)
+	}
+
+	uncommitted := []sessionRow{rows[2], rows[3]}
+	kept, anchor = sessionRowsSinceLastCommit(uncommitted)
+	if anchor != nil || len(kept) != 2 {
+		t.Errorf("expected every row and no anchor without commits, got %d rows, %+v", len(kept), anchor)
+	}
+	if note := sinceLastCommitNote(nil, len(kept)); !strings.Contains(note, "listing every session") {
+		t.Errorf("unexpected note %q", note)
+	}
+}
+
+func TestComputeSessionStats(t *testing.T) {
+	var rows []sessionRow
+	for i, cost := range []float64{4, 1, 3, 2, 10} {
+		rows = append(rows, sessionRow{
+			Meta:          claude.SessionMeta{GitCommits: 1, DurationMinutes: (i + 1) * 10},
+			Facet:         &claude.SessionFacet{FrictionCounts: map[string]int{"wrong_approach": i}},
+			EstimatedCost: cost,
+		})
+	}
+
+	stats := computeSessionStats(rows)
+	if stats.Sessions != 5 || stats.TotalCost != 20 || stats.TotalCommits != 5 {
+		t.Errorf("totals = %+v, want 5 sessions, $20, 5 commits", stats)
+	}
+	if stats.AvgFriction != 2 || stats.AvgDurationMinutes != 30 {
+		t.Errorf("averages = %.1f friction, %.1fm; want 2 and 30", stats.AvgFriction, stats.AvgDurationMinutes)
+	}
+	if want := (sessionPercentiles{P50: 3, P90: 7.6, P95: 8.8, Max: 10}); !reflect.DeepEqual(roundPercentiles(stats.Cost), want) {
+		t.Errorf("Cost = %+v, want %+v", stats.Cost, want)
+	}
+	if want := (sessionPercentiles{P50: 30, P90: 46, P95: 48, Max: 50}); !reflect.DeepEqual(roundPercentiles(stats.DurationMinutes), want) {
+		t.Errorf("DurationMinutes = %+v, want %+v", stats.DurationMinutes, want)
+	}
+}
+
+func TestComputeSessionStats_Empty(t *testing.T) {
+	if got := computeSessionStats(nil); got != (sessionStats{}) {
+		t.Errorf("computeSessionStats(nil) = %+v, want zero", got)
+	}
+}
+
+func TestSessionsFlags_StatsRegistered(t *testing.T) {
+	if sessionsCmd.Flags().Lookup("stats") == nil {
+		t.Fatal("sessions --stats flag not registered")
+	}
+}
+
+func TestSessionsCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	cfg := &config.Config{Sessions: config.Sessions{DefaultDays: 7}}
+
+	if got, want := sessionsCutoff(now, false, 30, false, cfg), now.AddDate(0, 0, -7); !got.Equal(want) {
+		t.Errorf("without --days = %v, want sessions.default_days back (%v)", got, want)
+	}
+	if got, want := sessionsCutoff(now, true, 90, false, cfg), now.AddDate(0, 0, -90); !got.Equal(want) {
+		t.Errorf("--days 90 = %v, want %v", got, want)
+	}
+	if got := sessionsCutoff(now, false, 30, true, cfg); !got.IsZero() {
+		t.Errorf("--all = %v, want no cutoff", got)
+	}
+}
+
+// roundPercentiles rounds p to 6 decimal places to absorb float error.
+func roundPercentiles(p sessionPercentiles) sessionPercentiles {
+	r := func(v float64) float64 { return math.Round(v*1e6) / 1e6 }
+	return sessionPercentiles{P50: r(p.P50), P90: r(p.P90), P95: r(p.P95), Max: r(p.Max)}
+}
+
+func TestFilterSessionRowsBySearch(t *testing.T) {
+	rows := []sessionRow{
+		{Meta: claude.SessionMeta{SessionID: "a", FirstPrompt: "Refactor the Auth middleware"}},
+		{Meta: claude.SessionMeta{SessionID: "b", FirstPrompt: "fix flaky test"},
+			Facet: &claude.SessionFacet{BriefSummary: "Finished the auth refactor", UnderlyingGoal: "stabilize CI"}},
+		{Meta: claude.SessionMeta{SessionID: "c", FirstPrompt: "auth"},
+			Facet: &claude.SessionFacet{UnderlyingGoal: "Refactor login"}},
+		{Meta: claude.SessionMeta{SessionID: "d", FirstPrompt: "update docs"}},
+	}
+
+	tests := []struct {
+		terms []string
+		want  []string
+	}{
+		{[]string{"AUTH"}, []string{"a", "b", "c"}},
+		{[]string{"auth", "refactor"}, []string{"a", "b", "c"}},
+		{[]string{"auth refactor"}, []string{"b"}},
+		{[]string{"auth", "ci"}, []string{"b"}},
+		{[]string{"auth", "docs"}, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, r := range filterSessionRowsBySearch(rows, tt.terms) {
+			got = append(got, r.Meta.SessionID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("search %q: got %v, want %v", tt.terms, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeSearchTerms(t *testing.T) {
+	got, err := normalizeSearchTerms([]string{" auth ", "refactor"})
+	if err != nil || !reflect.DeepEqual(got, []string{"auth", "refactor"}) {
+		t.Errorf("normalizeSearchTerms = %q, %v; want [auth refactor]", got, err)
+	}
+	if _, err := normalizeSearchTerms([]string{"auth", " "}); err == nil {
+		t.Error("expected an error for an empty keyword")
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	prev := output.IsNoColor()
+	output.SetNoColor(true)
+	defer output.SetNoColor(prev)
+
+	// Without color the text passes through unchanged.
+	if got := highlightMatches("Auth refactor for auth", searchPattern([]string{"auth"})); got != "Auth refactor for auth" {
+		t.Errorf("highlightMatches = %q", got)
+	}
+	pattern := searchPattern([]string{"auth", "a.b"})
+	if m := pattern.FindAllString("AUTH a.b axb", -1); !reflect.DeepEqual(m, []string{"AUTH", "a.b"}) {
+		t.Errorf("pattern matches %q, want [AUTH a.b]: case-insensitive and literal", m)
+	}
+	if searchPattern(nil) != nil {
+		t.Error("searchPattern(nil) != nil")
+	}
+}
+
+func TestPromptExcerpt(t *testing.T) {
+	long := strings.Repeat("x", 300) + " auth " + strings.Repeat("y", 100)
+
+	if got := promptExcerpt("short", 200, nil); got != "short" {
+		t.Errorf("short prompt = %q", got)
+	}
+	if got := promptExcerpt(long, 200, nil); got != strings.Repeat("x", 200)+"…" {
+		t.Errorf("without search = %q, want the first 200 bytes", got)
+	}
+	got := promptExcerpt(long, 200, searchPattern([]string{"AUTH"}))
+	if !strings.HasPrefix(got, "…") || !strings.Contains(got, "auth") {
+		t.Errorf("with search = %q, want an excerpt around the match", got)
+	}
+	if got := promptExcerpt(strings.Repeat("é", 150), 201, nil); !utf8.ValidString(got) {
+		t.Errorf("excerpt split a rune: %q", got)
+	}
+}
@@ -32,7 +32,7 @@ func init() {
 }
 
 func runStartup(cmd *cobra.Command, args []string) {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return
 	}
@@ -111,13 +111,14 @@ func runStartup(cmd *cobra.Command, args []string) {
 			projectTaskCompleted++
 		}
 
-		// Track by agent type
-		if agentByType[task.AgentType] == nil {
-			agentByType[task.AgentType] = &agentTypeSummary{}
+		// Track by canonical agent type
+		agentType := analyzer.CanonicalAgentType(task.AgentType, cfg.AgentAliases)
+		if agentByType[agentType] == nil {
+			agentByType[agentType] = &agentTypeSummary{}
 		}
-		agentByType[task.AgentType].count++
+		agentByType[agentType].count++
 		if task.Status == "completed" {
-			agentByType[task.AgentType].completed++
+			agentByType[agentType].completed++
 		}
 	}
 	if projectTaskCount > 0 {
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var storeCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Back up and restore the claudewatch database",
+	Long: `Move the history recorded by 'claudewatch track' in and out of the
+SQLite database as a portable, versioned JSON document.
+
+Subcommands: export, import`,
+}
+
+func init() {
+	rootCmd.AddCommand(storeCmd)
+}
+
+// store export
+
+var storeExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write every snapshot and its rows to a JSON file",
+	Long: `Write every track snapshot, with its project scores, aggregate metrics,
+friction events, agent tasks, and suggestions, and every session note and
+tag, to a JSON file that 'claudewatch store import' can read on this or
+another machine.
+
+  claudewatch store export claudewatch-backup.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStoreExport,
+}
+
+func init() {
+	storeCmd.AddCommand(storeExportCmd)
+}
+
+func runStoreExport(cmd *cobra.Command, args []string) error {
+	if _, err := loadConfig(); err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	// Exporting must not create a database.
+	if _, err := os.Stat(config.DBPath()); os.IsNotExist(err) {
+		return fmt.Errorf("no database at %s; run 'claudewatch track' first", config.DBPath())
+	}
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	export, err := db.Export()
+	if err != nil {
+		return fmt.Errorf("exporting database: %w", err)
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding export: %w", err)
+	}
+	if err := os.WriteFile(args[0], append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", args[0], err)
+	}
+
+	if flagJSON {
+		return writeJSON(map[string]any{"file": args[0], "version": export.Version, "snapshots": len(export.Snapshots), "session_notes": len(export.SessionNotes)})
+	}
+	fmt.Printf(" Exported %d snapshot(s) and %d session note(s) to %s (format v%d).\n",
+		len(export.Snapshots), len(export.SessionNotes), args[0], export.Version)
+	return nil
+}
+
+// store import
+
+var storeImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Add the snapshots in an exported JSON file to the database",
+	Long: `Add the snapshots in a file written by 'claudewatch store export' to the
+database. Importing is additive: existing history is never overwritten,
+imported snapshots get new IDs with their rows remapped to them, and
+snapshots already present (same time, command, and version) are skipped,
+as are session notes and tags already present, so importing the same file
+twice is harmless.
+
+  claudewatch store import claudewatch-backup.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStoreImport,
+}
+
+func init() {
+	storeCmd.AddCommand(storeImportCmd)
+}
+
+func runStoreImport(cmd *cobra.Command, args []string) error {
+	if _, err := loadConfig(); err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", args[0], err)
+	}
+	var export store.Export
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("parsing %s: %w", args[0], err)
+	}
+
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	result, err := db.Import(&export)
+	if err != nil {
+		return fmt.Errorf("importing %s: %w", args[0], err)
+	}
+
+	if flagJSON {
+		return writeJSON(result)
+	}
+	fmt.Printf(" Imported %d snapshot(s) and %d session note(s) from %s", result.Imported, result.SessionNotes, args[0])
+	if result.Skipped > 0 {
+		fmt.Printf(", skipped %d snapshot(s) already present", result.Skipped)
+	}
+	fmt.Println(".")
+	return nil
+}
@@ -1,17 +1,22 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
+	"io"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/logging"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
+	"github.com/blackwell-systems/claudewatch/internal/store"
 	"github.com/blackwell-systems/claudewatch/internal/suggest"
 	"github.com/spf13/cobra"
 )
@@ -21,14 +26,45 @@ var (
 	suggestCategory string
 	suggestJSON     bool
 	suggestProject  string
+	suggestHistory  bool
+	suggestStatus   string
+	suggestFailOn   string
+	suggestMinImp   float64
 )
 
 var suggestCmd = &cobra.Command{
-	Use:   "suggest",
-	Short: "Generate ranked improvement recommendations",
+	Use:     "suggest",
+	Aliases: []string{"suggestions"},
+	Short:   "Generate ranked improvement recommendations",
 	Long: `Analyze projects, sessions, and configuration to generate actionable,
 ranked improvement recommendations. Suggestions are scored by impact and
-sorted from highest to lowest.`,
+sorted from highest to lowest.
+
+With --history, lists the suggestions stored by 'claudewatch track' instead:
+when each was first raised, when it was resolved (explicitly, or by no longer
+being raised), and how long it stayed open. Suggestions closed by the
+suggestion_ttl setting show as expired rather than resolved. --category and
+--status filter the list.
+
+The list, resolve, and reopen subcommands manage the stored backlog: list
+shows open suggestions with their IDs, and resolve marks advice you acted on
+as done so later snapshots don't raise it again.
+
+For CI, --fail-on-priority exits non-zero when any suggestion at or above
+the given priority remains after filtering, after printing the usual output
+(including --json) so the job log shows what triggered it. --min-impact
+drops suggestions below an impact score first.
+
+Custom rules in ~/.config/claudewatch/suggest-rules.yaml add suggestions
+when a metric crosses a threshold. They run after the built-in rules; invalid
+rules are reported with their line numbers and skipped.
+
+Examples:
+  claudewatch suggest
+  claudewatch suggestions --history
+  claudewatch suggestions --history --status open --category friction
+  claudewatch suggestions resolve 42
+  claudewatch suggest --json --fail-on-priority high --min-impact 5`,
 	RunE: runSuggest,
 }
 
@@ -37,11 +73,15 @@ func init() {
 	suggestCmd.Flags().StringVar(&suggestCategory, "category", "", "Filter by category (configuration, friction, quality, adoption, agents, custom_metrics)")
 	suggestCmd.Flags().BoolVar(&suggestJSON, "json", false, "Output as JSON")
 	suggestCmd.Flags().StringVar(&suggestProject, "project", "", "Filter suggestions for a specific project")
+	suggestCmd.Flags().BoolVar(&suggestHistory, "history", false, "List stored suggestions across track snapshots")
+	suggestCmd.Flags().StringVar(&suggestStatus, "status", "", "With --history, filter by status (open|resolved|expired)")
+	suggestCmd.Flags().StringVar(&suggestFailOn, "fail-on-priority", "", "Exit non-zero if any suggestion is at or above this priority (critical|high|medium|low)")
+	suggestCmd.Flags().Float64Var(&suggestMinImp, "min-impact", 0, "Ignore suggestions with an impact score below this")
 	rootCmd.AddCommand(suggestCmd)
 }
 
 func runSuggest(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -50,15 +90,32 @@ func runSuggest(cmd *cobra.Command, args []string) error {
 		output.SetNoColor(true)
 	}
 
+	if suggestHistory {
+		if suggestFailOn != "" || suggestMinImp != 0 {
+			return fmt.Errorf("--fail-on-priority and --min-impact don't apply to --history")
+		}
+		return runSuggestHistory(time.Now())
+	}
+	if suggestStatus != "" {
+		return fmt.Errorf("--status requires --history")
+	}
+	failPriority := 0
+	if suggestFailOn != "" {
+		if failPriority, err = parseSuggestPriority(suggestFailOn); err != nil {
+			return err
+		}
+	}
+
 	// Build the analysis context from all data sources.
-	ctx, err := buildAnalysisContext(cfg)
+	ctx, err := buildAnalysisContext(cfg, os.Stderr)
 	if err != nil {
 		return fmt.Errorf("building analysis context: %w", err)
 	}
 
-	// Run the suggest engine.
-	engine := suggest.NewEngine()
-	suggestions := engine.Run(ctx)
+	// Run the suggest engine. With --anonymize, suggestions name projects by
+	// pseudonym.
+	engine := newSuggestEngine(os.Stderr)
+	suggestions := engine.Run(anonymized(ctx))
 
 	// Filter by category if specified.
 	if suggestCategory != "" {
@@ -67,7 +124,20 @@ func runSuggest(cmd *cobra.Command, args []string) error {
 
 	// Filter by project if specified.
 	if suggestProject != "" {
-		suggestions = filterByProject(suggestions, suggestProject)
+		suggestions = filterByProject(suggestions, anonymizedName(suggestProject))
+	}
+
+	// Drop low-impact noise.
+	if suggestMinImp > 0 {
+		suggestions = slices.DeleteFunc(suggestions, func(s suggest.Suggestion) bool {
+			return s.ImpactScore < suggestMinImp
+		})
+	}
+
+	// Check the threshold before --limit so a cut suggestion still fails.
+	var failing []suggest.Suggestion
+	if failPriority > 0 {
+		failing = suggestionsAtPriority(suggestions, failPriority)
 	}
 
 	// Apply limit.
@@ -76,21 +146,73 @@ func runSuggest(cmd *cobra.Command, args []string) error {
 	}
 
 	if suggestJSON || flagJSON {
-		return outputSuggestJSON(suggestions)
+		if err := outputSuggestJSON(suggestions); err != nil {
+			return err
+		}
+	} else {
+		renderSuggestions(suggestions)
 	}
 
-	renderSuggestions(suggestions)
+	if len(failing) > 0 {
+		return fmt.Errorf("%s priority or above: %d suggestion(s), starting with %q",
+			strings.ToLower(suggestFailOn), len(failing), failing[0].Title)
+	}
 	return nil
 }
 
+// parseSuggestPriority maps a --fail-on-priority name to its priority level.
+func parseSuggestPriority(name string) (int, error) {
+	switch strings.ToLower(name) {
+	case "critical":
+		return suggest.PriorityCritical, nil
+	case "high":
+		return suggest.PriorityHigh, nil
+	case "medium":
+		return suggest.PriorityMedium, nil
+	case "low":
+		return suggest.PriorityLow, nil
+	default:
+		return 0, fmt.Errorf("invalid --fail-on-priority %q: must be critical, high, medium, or low", name)
+	}
+}
+
+// suggestionsAtPriority returns the suggestions at priority or above. Lower
+// numbers are more urgent.
+func suggestionsAtPriority(suggestions []suggest.Suggestion, priority int) []suggest.Suggestion {
+	var result []suggest.Suggestion
+	for _, s := range suggestions {
+		if s.Priority <= priority {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// newSuggestEngine returns a suggest engine with the built-in rules plus the
+// custom rules in the rules file. Invalid custom rules are reported to warn
+// and skipped; the built-in rules always run.
+func newSuggestEngine(warn io.Writer) *suggest.Engine {
+	engine := suggest.NewEngine()
+	rules, err := suggest.LoadCustomRules(config.SuggestRulesPath())
+	if err != nil {
+		fmt.Fprintf(warn, "warning: skipping invalid custom suggestion rules:\n%v\n", err)
+	}
+	for _, r := range rules {
+		engine.AddRules(r.Rule())
+	}
+	return engine
+}
+
 // buildAnalysisContext loads all data sources and constructs the AnalysisContext
-// needed by the suggest engine.
-func buildAnalysisContext(cfg *config.Config) (*suggest.AnalysisContext, error) {
+// needed by the suggest engine. Problems loading a project's config file are
+// reported to warn.
+func buildAnalysisContext(cfg *config.Config, warn io.Writer) (*suggest.AnalysisContext, error) {
 	// Discover projects.
 	projects, err := scanner.DiscoverProjects(cfg.ScanPaths)
 	if err != nil {
 		return nil, fmt.Errorf("discovering projects: %w", err)
 	}
+	configs := loadProjectConfigs(cfg, projects, warn)
 
 	// Parse session metadata.
 	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
@@ -181,9 +303,10 @@ func buildAnalysisContext(cfg *config.Config) (*suggest.AnalysisContext, error)
 		typeSuccess := make(map[string]int)
 		totalSuccess := 0
 		for _, task := range agentTasks {
-			typeCount[task.AgentType]++
+			agentType := analyzer.CanonicalAgentType(task.AgentType, cfg.AgentAliases)
+			typeCount[agentType]++
 			if task.Status == "completed" {
-				typeSuccess[task.AgentType]++
+				typeSuccess[agentType]++
 				totalSuccess++
 			}
 		}
@@ -193,14 +316,26 @@ func buildAnalysisContext(cfg *config.Config) (*suggest.AnalysisContext, error)
 		}
 	}
 
+	// Projects whose long, exploration-heavy sessions rarely use agents.
+	subagentCandidates := make(map[string]analyzer.SubagentCandidate)
+	for _, c := range analyzer.AnalyzeSubagentOpportunity(sessions, agentTasks).Candidates {
+		subagentCandidates[c.ProjectPath] = c
+	}
+
 	// Build project contexts.
 	projectContexts := make([]suggest.ProjectContext, len(projects))
+	ownZeroCommitThreshold := make(map[string]bool)
 	for i, p := range projects {
 		// Count sessions for this project.
-		var projectToolErrors, projectInterruptions, projectAgents, projectSequential int
+		var projectSessions, projectZeroCommits, projectToolErrors, projectInterruptions, projectAgents, projectSequential int
+		var projectTasks []claude.AgentTask
 		hasFacets := false
 		for _, s := range sessions {
 			if claude.NormalizePath(s.ProjectPath) == claude.NormalizePath(p.Path) {
+				projectSessions++
+				if s.GitCommits == 0 {
+					projectZeroCommits++
+				}
 				projectToolErrors += s.ToolErrors
 				projectInterruptions += s.UserInterruptions
 			}
@@ -214,26 +349,48 @@ func buildAnalysisContext(cfg *config.Config) (*suggest.AnalysisContext, error)
 		for _, task := range agentTasks {
 			if claude.NormalizePath(sessionProject[task.SessionID]) == claude.NormalizePath(p.Path) {
 				projectAgents++
+				projectTasks = append(projectTasks, task)
 				if !task.Background {
 					projectSequential++
 				}
 			}
 		}
 
+		thresholds := suggest.ThresholdsFor(cfg, configs.For(p.Path))
+		if thresholds.ZeroCommitRate > 0 {
+			ownZeroCommitThreshold[claude.NormalizePath(p.Path)] = true
+		}
+		zeroCommitRate := 0.0
+		if projectSessions > 0 {
+			zeroCommitRate = float64(projectZeroCommits) / float64(projectSessions)
+		}
+
+		score := scanner.ComputeReadiness(&projects[i], sessions, facets, settings)
 		projectContexts[i] = suggest.ProjectContext{
-			Path:            p.Path,
-			Name:            p.Name,
-			HasClaudeMD:     p.HasClaudeMD,
-			SessionCount:    p.SessionCount,
-			ToolErrors:      projectToolErrors,
-			Interruptions:   projectInterruptions,
-			Score:           p.Score,
-			HasFacets:       hasFacets,
-			AgentCount:      projectAgents,
-			SequentialCount: projectSequential,
+			Path:                   p.Path,
+			Name:                   p.Name,
+			HasClaudeMD:            p.HasClaudeMD,
+			SessionCount:           projectSessions,
+			ToolErrors:             projectToolErrors,
+			Interruptions:          projectInterruptions,
+			Score:                  score,
+			WeightedScore:          scanner.WeightedReadiness(score, projectSessions, cfg.ReadinessVolume),
+			HasFacets:              hasFacets,
+			AgentCount:             projectAgents,
+			SequentialCount:        projectSequential,
+			ParallelSavingsMinutes: analyzer.EstimateParallelSavings(projectTasks).EstimatedSavedMinutes(),
+			AgentTypeStats:         suggest.AgentTypeStatsFor(projectTasks, cfg.AgentAliases),
+			SubagentOpportunity:    suggest.SubagentOpportunityFor(subagentCandidates, p.Path),
+			ZeroCommitRate:         zeroCommitRate,
+			Thresholds:             thresholds,
 		}
 	}
 
+	// Highest-traffic projects first, so their suggestions win impact ties.
+	sort.SliceStable(projectContexts, func(i, j int) bool {
+		return projectContexts[i].WeightedScore > projectContexts[j].WeightedScore
+	})
+
 	// Custom metric trends: placeholder for now (populated by track command).
 	customMetricTrends := make(map[string]string)
 
@@ -251,8 +408,15 @@ func buildAnalysisContext(cfg *config.Config) (*suggest.AnalysisContext, error)
 		}
 	}
 
-	// Commit analysis for zero-commit rate.
-	commitAnalysis := analyzer.AnalyzeCommits(sessions)
+	// Commit analysis for zero-commit rate, leaving out projects judged
+	// against their own threshold.
+	zeroCommitSessions := sessions
+	if len(ownZeroCommitThreshold) > 0 {
+		zeroCommitSessions = slices.DeleteFunc(slices.Clone(sessions), func(s claude.SessionMeta) bool {
+			return ownZeroCommitThreshold[claude.NormalizePath(s.ProjectPath)]
+		})
+	}
+	commitAnalysis := analyzer.AnalyzeCommits(zeroCommitSessions)
 
 	// Cost analysis for cache savings.
 	var cacheSavingsPercent, totalCost float64
@@ -266,7 +430,24 @@ func buildAnalysisContext(cfg *config.Config) (*suggest.AnalysisContext, error)
 		cacheSavingsPercent = costEst.CacheSavingsPercent
 		totalCost = costEst.TotalCost
 	} else if err != nil {
-		log.Printf("Warning: could not parse stats cache for cost analysis: %v", err)
+		logging.Warn("could not parse stats cache for cost analysis", "err", err)
+	}
+
+	// Estimated savings of adding a CLAUDE.md to projects without one.
+	cacheRatio := analyzer.NoCacheRatio()
+	if statsCache != nil {
+		cacheRatio = analyzer.ComputeCacheRatio(*statsCache)
+	}
+	var missingClaudeMD []string
+	for _, p := range projects {
+		if !p.HasClaudeMD {
+			missingClaudeMD = append(missingClaudeMD, p.Path)
+		}
+	}
+	roi := analyzer.EstimateClaudeMDROI(detectClaudeMDChanges(projects), missingClaudeMD, sessions, facets, analyzer.DefaultPricing["sonnet"], cacheRatio)
+	savings := suggest.ClaudeMDSavingsByProject(roi)
+	for i := range projectContexts {
+		projectContexts[i].ClaudeMDSavings = savings[claude.NormalizePath(projectContexts[i].Path)]
 	}
 
 	ctx := &suggest.AnalysisContext{
@@ -281,7 +462,11 @@ func buildAnalysisContext(cfg *config.Config) (*suggest.AnalysisContext, error)
 		AgentTypeStats:             agentTypeStats,
 		CustomMetricTrends:         customMetricTrends,
 		ClaudeMDSectionCorrelation: claudeMDAnalysis.SectionsCorrelation,
+		ClaudeMDSectionCautions:    claudeMDAnalysis.SectionCautions,
 		ZeroCommitRate:             commitAnalysis.ZeroCommitRate,
+		ZeroCommitSessions:         len(zeroCommitSessions),
+		ZeroCommitThreshold:        cfg.Thresholds.ZeroCommitRate,
+		WorstZeroCommitProject:     suggest.WorstZeroCommitProject(commitAnalysis),
 		CacheSavingsPercent:        cacheSavingsPercent,
 		TotalCost:                  totalCost,
 	}
@@ -312,8 +497,6 @@ func filterByProject(suggestions []suggest.Suggestion, project string) []suggest
 }
 
 func outputSuggestJSON(suggestions []suggest.Suggestion) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
 	type suggestionOut struct {
 		suggest.Suggestion
 		PriorityLabel string `json:"priority_label"`
@@ -325,7 +508,7 @@ func outputSuggestJSON(suggestions []suggest.Suggestion) error {
 		label = label[1 : len(label)-1]
 		out[i] = suggestionOut{s, label}
 	}
-	return enc.Encode(out)
+	return writeJSON(out)
 }
 
 func renderSuggestions(suggestions []suggest.Suggestion) {
@@ -339,15 +522,26 @@ func renderSuggestions(suggestions []suggest.Suggestion) {
 	fmt.Println(output.Section("Improvement Suggestions"))
 	fmt.Println()
 
+	for _, line := range suggestionLines(suggestions) {
+		fmt.Println(line)
+	}
+}
+
+// suggestionLines formats ranked suggestions as numbered entries, each
+// followed by a blank line.
+func suggestionLines(suggestions []suggest.Suggestion) []string {
+	var lines []string
 	for i, s := range suggestions {
 		priorityLabel := priorityToLabel(s.Priority)
 		priorityStyled := stylePriority(s.Priority, priorityLabel)
 
-		fmt.Printf(" #%d %s %s\n", i+1, priorityStyled, output.StyleBold.Render(s.Title))
-		fmt.Printf("    Impact: %.1f  |  Category: %s\n", s.ImpactScore, s.Category)
-		fmt.Printf("    %s\n", s.Description)
-		fmt.Println()
+		lines = append(lines,
+			fmt.Sprintf(" #%d %s %s", i+1, priorityStyled, output.StyleBold.Render(s.Title)),
+			fmt.Sprintf("    Impact: %.1f  |  Category: %s", s.ImpactScore, s.Category),
+			fmt.Sprintf("    %s", s.Description),
+			"")
 	}
+	return lines
 }
 
 func priorityToLabel(priority int) string {
@@ -375,3 +569,301 @@ func stylePriority(priority int, label string) string {
 		return output.StyleMuted.Render(label)
 	}
 }
+
+// suggestionHistoryRow is a stored suggestion's history with how long it has
+// been, or was, open.
+type suggestionHistoryRow struct {
+	store.SuggestionHistory
+	OpenDays float64 `json:"open_days"`
+}
+
+func runSuggestHistory(now time.Time) error {
+	switch suggestStatus {
+	case "", "open", "resolved", "expired":
+	default:
+		return fmt.Errorf("invalid --status %q (valid: open, resolved, expired)", suggestStatus)
+	}
+
+	// Reading history must not create a database.
+	if _, err := os.Stat(config.DBPath()); os.IsNotExist(err) {
+		if suggestJSON || flagJSON {
+			return writeJSON([]suggestionHistoryRow{})
+		}
+		fmt.Println(" No snapshots yet. Run 'claudewatch track' to start recording suggestions.")
+		return nil
+	}
+
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	history, err := db.GetSuggestionHistory()
+	if err != nil {
+		return fmt.Errorf("loading suggestion history: %w", err)
+	}
+	rows := buildSuggestionHistoryRows(history, suggestCategory, suggestStatus, now)
+
+	if suggestJSON || flagJSON {
+		return writeJSON(rows)
+	}
+	renderSuggestionHistory(rows)
+	return nil
+}
+
+// buildSuggestionHistoryRows filters history by category and status (either
+// may be empty) and computes how long each suggestion stayed open, up to now
+// for those still open.
+func buildSuggestionHistoryRows(history []store.SuggestionHistory, category, status string, now time.Time) []suggestionHistoryRow {
+	rows := []suggestionHistoryRow{}
+	for _, h := range history {
+		if category != "" && h.Category != category {
+			continue
+		}
+		if status != "" && h.Status != status {
+			continue
+		}
+		end := now
+		if h.ResolvedAt != nil {
+			end = *h.ResolvedAt
+		}
+		rows = append(rows, suggestionHistoryRow{
+			SuggestionHistory: h,
+			OpenDays:          max(end.Sub(h.FirstRaised).Hours()/24, 0),
+		})
+	}
+	return rows
+}
+
+func renderSuggestionHistory(rows []suggestionHistoryRow) {
+	fmt.Println(output.Section("Suggestion History"))
+	fmt.Println()
+
+	if len(rows) == 0 {
+		fmt.Println(" No stored suggestions match. Run 'claudewatch track' to record them.")
+		return
+	}
+
+	var open, resolved, expired int
+	var resolvedDays float64
+	tbl := output.NewTable("Category", "Title", "First raised", "Resolved", "Open for", "Snapshots")
+	for _, r := range rows {
+		resolvedAt := output.StyleWarning.Render("open")
+		switch {
+		case r.Status == "expired":
+			resolvedAt = output.StyleMuted.Render(r.ResolvedAt.Local().Format("2006-01-02") + " (expired)")
+			expired++
+		case r.ResolvedAt != nil:
+			resolvedAt = r.ResolvedAt.Local().Format("2006-01-02")
+			resolved++
+			resolvedDays += r.OpenDays
+		default:
+			open++
+		}
+		tbl.AddRow(
+			r.Category,
+			truncateString(r.Title, 50),
+			r.FirstRaised.Local().Format("2006-01-02"),
+			resolvedAt,
+			fmt.Sprintf("%.0fd", r.OpenDays),
+			fmt.Sprintf("%d", r.Snapshots),
+		)
+	}
+	tbl.Print()
+
+	fmt.Println()
+	fmt.Printf(" %d suggestions: %d open, %d resolved", len(rows), open, resolved)
+	if expired > 0 {
+		fmt.Printf(", %d expired", expired)
+	}
+	if resolved > 0 {
+		fmt.Printf("; resolved ones stayed open %.1f days on average", resolvedDays/float64(resolved))
+	}
+	fmt.Println()
+}
+
+// suggest list
+
+var suggestListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List open stored suggestions with their IDs",
+	Long: `List the open suggestions stored by 'claudewatch track', one per category
+and title, with the ID to pass to 'suggestions resolve' or 'suggestions reopen'.
+
+Examples:
+  claudewatch suggestions list
+  claudewatch suggestions list --json`,
+	Args: cobra.NoArgs,
+	RunE: runSuggestList,
+}
+
+// suggest resolve
+
+var suggestResolveCmd = &cobra.Command{
+	Use:   "resolve <id>",
+	Short: "Mark a stored suggestion as done",
+	Long: `Mark a stored suggestion as resolved. Use it for advice you acted on that
+track can't detect by itself. Every open copy of the suggestion from earlier
+snapshots is resolved too, and later snapshots that raise it again store it
+as resolved, so it stays out of the backlog until you reopen it.
+
+Examples:
+  claudewatch suggestions resolve 42`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSuggestResolve,
+}
+
+// suggest reopen
+
+var suggestReopenCmd = &cobra.Command{
+	Use:   "reopen <id>",
+	Short: "Reopen a resolved suggestion",
+	Long: `Mark a stored suggestion as open again, undoing 'suggestions resolve'. Later
+snapshots that raise it store it as open.
+
+Examples:
+  claudewatch suggestions reopen 42`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSuggestReopen,
+}
+
+func init() {
+	suggestCmd.AddCommand(suggestListCmd)
+	suggestCmd.AddCommand(suggestResolveCmd)
+	suggestCmd.AddCommand(suggestReopenCmd)
+}
+
+func runSuggestList(cmd *cobra.Command, args []string) error {
+	if _, err := loadConfig(); err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	// Listing must not create a database.
+	if _, err := os.Stat(config.DBPath()); os.IsNotExist(err) {
+		if flagJSON {
+			return writeJSON([]store.Suggestion{})
+		}
+		fmt.Println(" No snapshots yet. Run 'claudewatch track' to start recording suggestions.")
+		return nil
+	}
+
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	open, err := db.GetOpenSuggestions()
+	if err != nil {
+		return fmt.Errorf("loading open suggestions: %w", err)
+	}
+	open = latestSuggestions(open)
+
+	if flagJSON {
+		return writeJSON(open)
+	}
+
+	fmt.Println(output.Section("Open Suggestions"))
+	fmt.Println()
+	if len(open) == 0 {
+		fmt.Println(" No open suggestions.")
+		return nil
+	}
+	tbl := output.NewTable("ID", "Category", "Title", "Impact", "First seen")
+	for _, s := range open {
+		firstSeen := "-"
+		if !s.FirstSeen.IsZero() {
+			firstSeen = s.FirstSeen.Local().Format("2006-01-02")
+		}
+		tbl.AddRow(
+			fmt.Sprintf("%d", s.ID),
+			s.Category,
+			truncateString(s.Title, 60),
+			fmt.Sprintf("%.1f", s.ImpactScore),
+			firstSeen,
+		)
+	}
+	tbl.Print()
+	fmt.Println()
+	fmt.Println(output.StyleMuted.Render(" Mark one done with 'claudewatch suggestions resolve <id>'."))
+	return nil
+}
+
+// latestSuggestions keeps the most recently stored suggestion for each
+// category and title, since every track snapshot stores its own copy. The
+// result is ordered by impact, highest first, then by ID.
+func latestSuggestions(suggestions []store.Suggestion) []store.Suggestion {
+	type key struct{ category, title string }
+	latest := make(map[key]store.Suggestion)
+	for _, s := range suggestions {
+		k := key{s.Category, s.Title}
+		if prev, ok := latest[k]; !ok || s.ID > prev.ID {
+			latest[k] = s
+		}
+	}
+	result := make([]store.Suggestion, 0, len(latest))
+	for _, s := range latest {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ImpactScore != result[j].ImpactScore {
+			return result[i].ImpactScore > result[j].ImpactScore
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+func runSuggestResolve(cmd *cobra.Command, args []string) error {
+	return updateSuggestionStatus(args[0], "resolved", (*store.DB).ResolveSuggestionManually)
+}
+
+func runSuggestReopen(cmd *cobra.Command, args []string) error {
+	return updateSuggestionStatus(args[0], "open", (*store.DB).ReopenSuggestion)
+}
+
+// updateSuggestionStatus looks up the suggestion with the given ID and
+// applies update to it, reporting the new status.
+func updateSuggestionStatus(arg, status string, update func(*store.DB, *store.Suggestion) error) error {
+	if _, err := loadConfig(); err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || id < 1 {
+		return fmt.Errorf("invalid suggestion ID %q", arg)
+	}
+
+	if _, err := os.Stat(config.DBPath()); os.IsNotExist(err) {
+		return fmt.Errorf("no suggestion with ID %d; run 'claudewatch track' to record suggestions", id)
+	}
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	s, err := db.GetSuggestion(id)
+	if err != nil {
+		return fmt.Errorf("loading suggestion: %w", err)
+	}
+	if s == nil {
+		return fmt.Errorf("no suggestion with ID %d (see 'claudewatch suggestions list')", id)
+	}
+	if err := update(db, s); err != nil {
+		return fmt.Errorf("updating suggestion: %w", err)
+	}
+
+	if flagJSON {
+		s.Status = status
+		s.ResolvedManually = status == "resolved"
+		return writeJSON(s)
+	}
+	fmt.Printf("Suggestion #%d marked %s: %s\n", s.ID, status, s.Title)
+	return nil
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/store"
+	"github.com/blackwell-systems/claudewatch/internal/suggest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSuggestionHistoryRows(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	resolvedAt := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+	history := []store.SuggestionHistory{
+		{Category: "friction", Title: "Fix friction", Status: "resolved",
+			FirstRaised: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), ResolvedAt: &resolvedAt},
+		{Category: "configuration", Title: "Add CLAUDE.md", Status: "open",
+			FirstRaised: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)},
+	}
+
+	rows := buildSuggestionHistoryRows(history, "", "", now)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 4.0, rows[0].OpenDays, "resolved suggestions count up to resolution")
+	assert.Equal(t, 10.0, rows[1].OpenDays, "open suggestions count up to now")
+
+	rows = buildSuggestionHistoryRows(history, "", "open", now)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Add CLAUDE.md", rows[0].Title)
+
+	rows = buildSuggestionHistoryRows(history, "friction", "", now)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Fix friction", rows[0].Title)
+
+	assert.NotNil(t, buildSuggestionHistoryRows(history, "agents", "", now), "no matches should be an empty slice for JSON")
+}
+
+func TestLatestSuggestions(t *testing.T) {
+	open := []store.Suggestion{
+		{ID: 1, Category: "configuration", Title: "Add CLAUDE.md", ImpactScore: 5},
+		{ID: 2, Category: "friction", Title: "Fix friction", ImpactScore: 8},
+		{ID: 3, Category: "configuration", Title: "Add CLAUDE.md", ImpactScore: 6},
+		{ID: 4, Category: "friction", Title: "Fix friction", ImpactScore: 7},
+		{ID: 5, Category: "agents", Title: "Add CLAUDE.md", ImpactScore: 7},
+	}
+
+	got := latestSuggestions(open)
+	require.Len(t, got, 3, "one entry per category and title")
+	assert.Equal(t, []int64{4, 5, 3}, []int64{got[0].ID, got[1].ID, got[2].ID}, "newest copy of each, by impact then ID")
+
+	assert.NotNil(t, latestSuggestions(nil), "no suggestions should be an empty slice for JSON")
+}
+
+func TestSuggestSubcommands_Registered(t *testing.T) {
+	for _, name := range []string{"list", "resolve", "reopen"} {
+		cmd, _, err := rootCmd.Find([]string{"suggestions", name})
+		require.NoError(t, err)
+		assert.Equal(t, name, cmd.Name())
+	}
+}
+
+func TestParseSuggestPriority(t *testing.T) {
+	p, err := parseSuggestPriority("HIGH")
+	require.NoError(t, err)
+	assert.Equal(t, suggest.PriorityHigh, p)
+
+	_, err = parseSuggestPriority("urgent")
+	assert.ErrorContains(t, err, `invalid --fail-on-priority "urgent"`)
+}
+
+func TestSuggestionsAtPriority(t *testing.T) {
+	suggestions := []suggest.Suggestion{
+		{Title: "critical", Priority: suggest.PriorityCritical},
+		{Title: "medium", Priority: suggest.PriorityMedium},
+		{Title: "high", Priority: suggest.PriorityHigh},
+		{Title: "low", Priority: suggest.PriorityLow},
+	}
+
+	got := suggestionsAtPriority(suggestions, suggest.PriorityHigh)
+	require.Len(t, got, 2)
+	assert.Equal(t, "critical", got[0].Title)
+	assert.Equal(t, "high", got[1].Title)
+
+	assert.Len(t, suggestionsAtPriority(suggestions, suggest.PriorityLow), 4)
+	assert.Empty(t, suggestionsAtPriority(suggestions[3:], suggest.PriorityMedium))
+}
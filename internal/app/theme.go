@@ -0,0 +1,182 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var themeCmd = &cobra.Command{
+	Use:   "theme",
+	Short: "Browse the built-in color themes",
+	Long: `Browse the built-in color themes before choosing one with --theme or
+output.theme.
+
+Subcommands: preview`,
+}
+
+func init() {
+	rootCmd.AddCommand(themeCmd)
+}
+
+// theme preview
+
+var themePreviewCmd = &cobra.Command{
+	Use:   "preview [name]",
+	Short: "Render a sample of every style in a theme",
+	Long: `Render every semantic style (labels, values, success, warning, error,
+muted and bold text, section headers, and tables) and a mock metrics section
+in the named theme, whatever theme is configured.
+
+Without a name, list the built-in themes with a color swatch for each and
+mark the active one:
+
+  claudewatch theme preview
+  claudewatch theme preview high-contrast
+  claudewatch theme preview --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runThemePreview,
+}
+
+func init() {
+	themeCmd.AddCommand(themePreviewCmd)
+}
+
+// themeInfo is the JSON form of a built-in theme.
+type themeInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Active      bool   `json:"active"`
+	Primary     string `json:"primary,omitempty"`
+	Success     string `json:"success,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Warning     string `json:"warning,omitempty"`
+	Muted       string `json:"muted,omitempty"`
+	Text        string `json:"text,omitempty"`
+}
+
+func newThemeInfo(t output.Theme, active bool) themeInfo {
+	return themeInfo{
+		Name:        t.Name,
+		Description: t.Description,
+		Active:      active,
+		Primary:     string(t.Primary),
+		Success:     string(t.Success),
+		Error:       string(t.Error),
+		Warning:     string(t.Warning),
+		Muted:       string(t.Muted),
+		Text:        string(t.Text),
+	}
+}
+
+func runThemePreview(cmd *cobra.Command, args []string) error {
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	if _, err := loadConfig(); err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	active := output.ActiveTheme()
+
+	if len(args) == 0 {
+		if flagJSON {
+			infos := make([]themeInfo, 0, len(output.ThemeNames()))
+			for _, name := range output.ThemeNames() {
+				t, _ := output.LookupTheme(name)
+				infos = append(infos, newThemeInfo(t, t.Name == active.Name))
+			}
+			return writeJSON(infos)
+		}
+		renderThemeList(active)
+		return nil
+	}
+
+	t, ok := output.LookupTheme(args[0])
+	if !ok {
+		return fmt.Errorf("unknown theme %q (valid: %s)", args[0], strings.Join(output.ThemeNames(), ", "))
+	}
+	if flagJSON {
+		return writeJSON(newThemeInfo(t, t.Name == active.Name))
+	}
+
+	output.SetTheme(t)
+	defer output.SetTheme(active)
+	renderThemePreview(t, t.Name == active.Name)
+	return nil
+}
+
+// renderThemeList prints every built-in theme with a swatch in its own
+// colors, marking active.
+func renderThemeList(active output.Theme) {
+	fmt.Println(output.Section("Themes"))
+	fmt.Println()
+	for _, name := range output.ThemeNames() {
+		t, _ := output.LookupTheme(name)
+		output.SetTheme(t)
+		marker := "  "
+		if t.Name == active.Name {
+			marker = output.StyleSuccess.Render("* ")
+		}
+		fmt.Printf(" %s%s  %s\n", marker, output.StyleLabel.Render(output.StyleHeader.Render(t.Name)), themeSwatch())
+		fmt.Printf("   %s\n", output.StyleMuted.Render(t.Description))
+	}
+	output.SetTheme(active)
+	fmt.Println()
+	fmt.Printf(" %s\n", output.StyleMuted.Render("Preview one with: claudewatch theme preview <name>"))
+}
+
+// themeSwatch renders one word in each semantic color of the active theme.
+func themeSwatch() string {
+	return strings.Join([]string{
+		output.StyleSuccess.Render("success"),
+		output.StyleWarning.Render("warning"),
+		output.StyleError.Render("error"),
+		output.StyleMuted.Render("muted"),
+	}, " ")
+}
+
+// renderThemePreview prints a sample of every style and a mock metrics
+// section in the active theme, which is t.
+func renderThemePreview(t output.Theme, active bool) {
+	title := fmt.Sprintf("Theme: %s", t.Name)
+	if active {
+		title += " (active)"
+	}
+	fmt.Println(output.Section(title))
+	fmt.Printf(" %s\n\n", output.StyleMuted.Render(t.Description))
+
+	sample := func(label, text string) {
+		fmt.Printf(" %s  %s\n", output.StyleLabel.Render(label), text)
+	}
+	sample("Label", output.StyleLabel.Render("Sessions analyzed"))
+	sample("Value", output.StyleValue.Render("42"))
+	sample("Success", output.StyleSuccess.Render("Commit rate improved"))
+	sample("Warning", output.StyleWarning.Render("Facet coverage is low"))
+	sample("Error", output.StyleError.Render("Friction regressed"))
+	sample("Muted", output.StyleMuted.Render("Secondary detail"))
+	sample("Bold", output.StyleBold.Render("Emphasized text"))
+	sample("Section header", output.StyleHeader.Render("Session Stats"))
+	fmt.Println()
+
+	tbl := output.NewTable("Project", "Sessions", "Friction", "Trend")
+	tbl.AddRow("api", "18", "0.8", output.TrendArrow(-0.4, false))
+	tbl.AddRow("web", "11", "2.1", output.TrendArrow(0.9, false))
+	tbl.AddRow("cli", "7", "1.2", output.TrendArrow(0, false))
+	tbl.Print()
+
+	// A mock metrics section, as metrics renders it.
+	fmt.Println(output.Section("Sample Metrics"))
+	fmt.Println()
+	metric := func(label, value, note string) {
+		fmt.Printf(" %s %s %s\n", output.StyleLabel.Render(label), output.StyleValue.Render(value), note)
+	}
+	metric("Sessions", "42", output.StyleMuted.Render("38min avg"))
+	metric("Commits/session", "1.6", output.TrendArrowPercent(12, true))
+	metric("Friction/session", "1.1", output.TrendArrowPercent(18, false))
+	metric("Satisfaction", "72", output.ScoreBar(72, 20))
+	metric("Budget used", "$48.20", output.UsageBar(48.2, 60, 20))
+	fmt.Println()
+}
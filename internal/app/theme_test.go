@@ -0,0 +1,56 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+)
+
+func TestRenderThemeList_RestoresActiveTheme(t *testing.T) {
+	defer output.SetTheme(output.DefaultTheme)
+	output.SetTheme(output.LightTheme)
+
+	renderThemeList(output.LightTheme)
+	if got := output.ActiveTheme().Name; got != "light" {
+		t.Errorf("active theme after listing = %q, want light", got)
+	}
+}
+
+func TestApplyTheme_NamesSource(t *testing.T) {
+	defer output.SetTheme(output.DefaultTheme)
+	defer func(prev string) { flagTheme = prev }(flagTheme)
+	cfg := &config.Config{Output: config.Output{Theme: "neon"}}
+
+	flagTheme = ""
+	if err := applyTheme(cfg); err == nil || !strings.Contains(err.Error(), `invalid output.theme "neon"`) {
+		t.Errorf("applyTheme() = %v, want an invalid output.theme error", err)
+	}
+	flagTheme = "glow"
+	if err := applyTheme(cfg); err == nil || !strings.Contains(err.Error(), `invalid --theme "glow"`) {
+		t.Errorf("applyTheme() = %v, want an invalid --theme error", err)
+	}
+	flagTheme = "light"
+	if err := applyTheme(cfg); err != nil || output.ActiveTheme().Name != "light" {
+		t.Errorf("applyTheme() = %v with %q active, want light", err, output.ActiveTheme().Name)
+	}
+}
+
+func TestNewThemeInfo(t *testing.T) {
+	info := newThemeInfo(output.HighContrastTheme, true)
+	if info.Name != "high-contrast" || !info.Active || info.Error != "#ff8c00" || info.Description == "" {
+		t.Errorf("newThemeInfo(high-contrast) = %+v", info)
+	}
+	if mono := newThemeInfo(output.MonoTheme, false); mono.Primary != "" || mono.Active {
+		t.Errorf("newThemeInfo(mono) = %+v, want no colors", mono)
+	}
+}
+
+func TestThemeNamesHaveDescriptions(t *testing.T) {
+	for _, name := range output.ThemeNames() {
+		if th, _ := output.LookupTheme(name); th.Description == "" {
+			t.Errorf("theme %q has no description", name)
+		}
+	}
+}
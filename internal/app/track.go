@@ -1,14 +1,23 @@
 package app
 
 import (
-	"encoding/json"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"math"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/guard"
 	"github.com/blackwell-systems/claudewatch/internal/output"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
 	"github.com/blackwell-systems/claudewatch/internal/store"
@@ -17,9 +26,19 @@ import (
 )
 
 var (
-	trackCompare int
-	trackHistory int
-	trackJSON    bool
+	trackCompare         int
+	trackHistory         int
+	trackJSON            bool
+	trackDryRun          bool
+	trackNoStore         bool
+	trackFormat          string
+	trackProgress        bool
+	trackDiffSuggestions bool
+	trackMetrics         []string
+	trackOnly            []string
+
+	trackProject     string
+	trackProjectPath string
 )
 
 var trackCmd = &cobra.Command{
@@ -27,7 +46,52 @@ var trackCmd = &cobra.Command{
 	Short: "Snapshot and compare metrics over time",
 	Long: `Run analysis, store a new snapshot, and compare against the most recent
 previous snapshot to show deltas with trend arrows. Auto-resolves suggestions
-whose trigger conditions are no longer true.`,
+whose trigger conditions are no longer true.
+
+With --dry-run, runs the same analysis and shows what the snapshot would
+record, the deltas against the previous snapshot, and which open suggestions
+would be auto-resolved, without writing anything to the database.
+
+With --no-store, renders the same comparison as a real run, against the
+latest stored snapshot, but keeps the new snapshot in memory: nothing is
+inserted and no suggestions are auto-resolved or expired. Use it to
+experiment without cluttering the history.
+
+--format markdown renders the comparison (or --history timeline) as a
+Markdown table with ↑/↓/→ trend arrows, ready to paste into a changelog.
+--format csv emits metric,previous,current,delta,direction rows, or one
+column per snapshot with --history. --json is an alias for --format json.
+
+--metric limits --history to the named metrics, by raw name
+(total_friction_events) or table label ("Friction Events"). Repeat it to
+show several.
+
+--only records a subset of the snapshot: any of scores, metrics, friction,
+agents, and suggestions, comma-separated or repeated. Sections left out are
+neither computed nor stored, and comparisons and --history skip snapshots
+that lack metrics.
+
+--project (by name) or --project-path (by exact path) records a snapshot of
+one project. It is compared with, and --history shows, only earlier
+snapshots of the same project, and whole-history snapshots never see it.
+Suggestions cover every project, so a project snapshot doesn't record them.
+
+--diff-suggestions replaces the top suggestions with what changed since the
+previous snapshot that recorded suggestions, matched by title: new ones,
+resolved ones, and ones still open, with counts in --json.
+
+Examples:
+  claudewatch track
+  claudewatch track --format markdown >> CHANGELOG.md
+  claudewatch track --history 10 --format csv > trends.csv
+  claudewatch track --history 10 --metric total_friction_events --metric avg_tool_errors
+  claudewatch track --only metrics,friction
+  claudewatch track --diff-suggestions
+  claudewatch track --project-path ~/src/api
+
+On a terminal, a spinner on stderr shows the current phase while large
+datasets load. It is off for JSON output and non-terminal stderr;
+--progress=false turns it off everywhere.`,
 	RunE: runTrack,
 }
 
@@ -35,11 +99,22 @@ func init() {
 	trackCmd.Flags().IntVar(&trackCompare, "compare", 1, "Compare against Nth previous snapshot (1 = most recent)")
 	trackCmd.Flags().IntVar(&trackHistory, "history", 0, "Show metric trends across N most recent snapshots")
 	trackCmd.Flags().BoolVar(&trackJSON, "json", false, "Output as JSON")
+	trackCmd.Flags().BoolVar(&trackDryRun, "dry-run", false, "Show what would be recorded without writing a snapshot")
+	trackCmd.Flags().BoolVar(&trackNoStore, "no-store", false, "Compare against the latest snapshot without storing a new one")
+	trackCmd.Flags().StringVar(&trackFormat, "format", "table", "Output format (table|markdown|csv|json)")
+	trackCmd.Flags().BoolVar(&trackProgress, "progress", true, "Show a progress spinner on stderr while loading")
+	trackCmd.Flags().BoolVar(&trackDiffSuggestions, "diff-suggestions", false, "Show suggestions as new, resolved, and still open since the previous snapshot")
+	trackCmd.Flags().StringArrayVar(&trackMetrics, "metric", nil, "Limit --history to this metric (raw or short name; repeatable)")
+	trackCmd.Flags().StringSliceVar(&trackOnly, "only", nil, "Record only these sections (scores,metrics,friction,agents,suggestions)")
+	trackCmd.Flags().StringVar(&trackProject, "project", "", "Record a snapshot of the project matching this name (fuzzy)")
+	trackCmd.Flags().StringVar(&trackProjectPath, "project-path", "", "Record a snapshot of the project at exactly this path")
+	trackCmd.MarkFlagsMutuallyExclusive("project", "project-path")
+	trackCmd.MarkFlagsMutuallyExclusive("dry-run", "no-store")
 	rootCmd.AddCommand(trackCmd)
 }
 
 func runTrack(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -48,19 +123,76 @@ func runTrack(cmd *cobra.Command, args []string) error {
 		output.SetNoColor(true)
 	}
 
-	// Open the database.
-	db, err := store.Open(config.DBPath())
+	format, err := trackOutputFormat(trackFormat, trackJSON || flagJSON)
+	if err != nil {
+		return err
+	}
+
+	if len(trackMetrics) > 0 && trackHistory <= 0 {
+		return errors.New("--metric requires --history")
+	}
+	historyMetrics, err := resolveHistoryMetrics(trackMetrics)
+	if err != nil {
+		return err
+	}
+	sections, err := resolveTrackSections(trackOnly)
+	if err != nil {
+		return err
+	}
+	if trackProject != "" || trackProjectPath != "" {
+		if len(trackOnly) > 0 && slices.Contains(sections, store.SectionSuggestions) {
+			return errors.New("--only suggestions can't be recorded for one project: suggestions cover every project")
+		}
+		sections = slices.DeleteFunc(slices.Clone(sections), func(s string) bool { return s == store.SectionSuggestions })
+	}
+	record := func(section string) bool { return slices.Contains(sections, section) }
+	if trackDiffSuggestions {
+		switch {
+		case trackHistory > 0:
+			return errors.New("--diff-suggestions can't be combined with --history")
+		case format == "markdown" || format == "csv":
+			return errors.New("--diff-suggestions supports table and json output only")
+		case !record(store.SectionSuggestions):
+			return errors.New("--diff-suggestions needs suggestions recorded, which --only or a project snapshot leaves out")
+		}
+	}
+
+	if trackNoStore && trackHistory > 0 {
+		return errors.New("--no-store can't be combined with --history, which only reads stored snapshots")
+	}
+
+	// --read-only turns every run into a dry run, except --no-store, which
+	// writes nothing either.
+	dryRun := trackDryRun || (guard.ReadOnly() && !trackNoStore)
+
+	progress := startProgress(trackProgress, format == "json")
+	defer progress.Stop()
+
+	// Open the database. A dry run or --no-store must not create one, and
+	// without one there is nothing to compare against, so an empty in-memory
+	// database stands in.
+	var db *store.DB
+	if _, statErr := os.Stat(config.DBPath()); (dryRun || trackNoStore) && os.IsNotExist(statErr) {
+		db, err = store.OpenInMemory()
+	} else {
+		db, err = store.Open(config.DBPath())
+	}
 	if err != nil {
 		return fmt.Errorf("opening database: %w", err)
 	}
 	defer func() { _ = db.Close() }()
 
-	// Run all analysis.
-	projects, err := scanner.DiscoverProjects(cfg.ScanPaths)
-	if err != nil {
-		return fmt.Errorf("discovering projects: %w", err)
+	// Run the analysis the recorded sections need.
+	var projects []scanner.Project
+	if record(store.SectionScores) {
+		progress.Phase("Scanning projects")
+		projects, err = scanner.DiscoverProjects(cfg.ScanPaths)
+		if err != nil {
+			return fmt.Errorf("discovering projects: %w", err)
+		}
 	}
 
+	progress.Phase("Parsing sessions")
 	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
 	if err != nil {
 		return fmt.Errorf("parsing session meta: %w", err)
@@ -85,144 +217,209 @@ func runTrack(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
+	// Narrow everything to one project when asked.
+	project, err := resolveProjectFilter(trackProject, trackProjectPath, sessions)
 	if err != nil {
-		return fmt.Errorf("parsing facets: %w", err)
+		return err
 	}
-
-	settings, err := claude.ParseSettings(cfg.ClaudeHome)
-	if err != nil {
-		return fmt.Errorf("parsing settings: %w", err)
+	var total int
+	var scope string
+	if project != "" {
+		total = len(sessions)
+		sessions = filterSessionsByProject(sessions, project)
+		projects = slices.DeleteFunc(projects, func(p scanner.Project) bool { return claude.NormalizePath(p.Path) != project })
+		scope = "for project " + project
 	}
 
-	agentTasks, err := claude.ParseAgentTasks(cfg.ClaudeHome)
-	if err != nil {
-		agentTasks = nil
+	// Without sessions there is nothing to measure, and an empty snapshot
+	// would only skew later comparisons, so explain instead of recording one.
+	if len(sessions) == 0 && trackHistory == 0 {
+		progress.Stop()
+		switch format {
+		case "json":
+			return writeJSON(map[string]any{"snapshot": nil})
+		case "table":
+			printNoSessions(os.Stdout, cfg, total, scope)
+		default:
+			printNoSessions(os.Stderr, cfg, total, scope)
+		}
+		return nil
 	}
 
-	// Compute metrics.
-	friction := analyzer.AnalyzeFriction(facets, cfg.Friction.RecurringThreshold)
-	velocity := analyzer.AnalyzeVelocity(sessions, 0)
-	satisfaction := analyzer.AnalyzeSatisfaction(facets)
-	efficiency := analyzer.AnalyzeEfficiency(sessions)
-	agentPerf := analyzer.AnalyzeAgents(agentTasks)
-
-	// Score projects.
-	for i := range projects {
-		projects[i].Score = scanner.ComputeReadiness(&projects[i], sessions, facets, settings)
-		// Count sessions for this project.
-		count := 0
-		for _, s := range sessions {
-			if claude.NormalizePath(s.ProjectPath) == claude.NormalizePath(projects[i].Path) {
-				count++
-			}
+	var facets []claude.SessionFacet
+	if record(store.SectionScores) || record(store.SectionMetrics) || record(store.SectionFriction) {
+		facets, err = claude.ParseAllFacets(cfg.ClaudeHome)
+		if err != nil {
+			return fmt.Errorf("parsing facets: %w", err)
+		}
+		if project != "" {
+			facets = filterFacetsBySessionIDs(facets, sessions)
 		}
-		projects[i].SessionCount = count
 	}
 
-	// Create new snapshot.
-	snapshotID, err := db.CreateSnapshot("track", appVersion)
-	if err != nil {
-		return fmt.Errorf("creating snapshot: %w", err)
+	var agentTasks []claude.AgentTask
+	if record(store.SectionMetrics) || record(store.SectionAgents) {
+		progress.Phase("Parsing transcripts")
+		agentTasks, err = claude.ParseAgentTasks(cfg.ClaudeHome)
+		if err != nil {
+			agentTasks = nil
+		}
+		if project != "" {
+			agentTasks = filterAgentTasksBySessionIDs(agentTasks, sessions)
+		}
 	}
 
-	// Insert project scores.
-	for _, p := range projects {
-		ps := &store.ProjectScore{
-			SnapshotID:       snapshotID,
-			Project:          p.Path,
-			Score:            p.Score,
-			HasClaudeMD:      p.HasClaudeMD,
-			HasDotClaude:     p.HasDotClaude,
-			HasLocalSettings: p.HasLocalSettings,
-			SessionCount:     p.SessionCount,
-			LastSessionDate:  p.LastSessionDate,
-			PrimaryLanguage:  p.PrimaryLanguage,
-			GitCommit30D:     p.CommitsLast30Days,
+	progress.Phase("Analyzing")
+
+	// Score projects.
+	if record(store.SectionScores) {
+		settings, err := claude.ParseSettings(cfg.ClaudeHome)
+		if err != nil {
+			return fmt.Errorf("parsing settings: %w", err)
 		}
-		if err := db.InsertProjectScore(ps); err != nil {
-			return fmt.Errorf("inserting project score: %w", err)
+		for i := range projects {
+			projects[i].Score = scanner.ComputeReadiness(&projects[i], sessions, facets, settings)
+			// Count sessions for this project.
+			count := 0
+			for _, s := range sessions {
+				if claude.NormalizePath(s.ProjectPath) == claude.NormalizePath(projects[i].Path) {
+					count++
+				}
+			}
+			projects[i].SessionCount = count
 		}
 	}
 
-	// Insert aggregate metrics.
-	metrics := buildAggregateMetrics(friction, velocity, satisfaction, efficiency, agentPerf)
-	for name, value := range metrics {
-		if err := db.InsertAggregateMetric(snapshotID, name, value, ""); err != nil {
-			return fmt.Errorf("inserting metric %s: %w", name, err)
+	// Compute metrics.
+	var metrics map[string]float64
+	if record(store.SectionMetrics) {
+		friction := analyzer.AnalyzeFriction(facets, cfg.Friction.RecurringThreshold)
+		velocity := analyzer.AnalyzeVelocity(sessions, 0)
+		satisfaction := analyzer.AnalyzeSatisfaction(facets)
+		efficiency := analyzer.AnalyzeEfficiency(sessions)
+		agentPerf := analyzer.AnalyzeAgents(agentTasks, cfg.AgentAliases)
+		metrics = buildAggregateMetrics(friction, velocity, satisfaction, efficiency, agentPerf)
+	}
+
+	var suggestCtx *suggest.AnalysisContext
+	var suggestions []suggest.Suggestion
+	if record(store.SectionSuggestions) {
+		suggestCtx, err = buildAnalysisContext(cfg, os.Stderr)
+		if err != nil {
+			return fmt.Errorf("building suggest context: %w", err)
 		}
+		engine := newSuggestEngine(os.Stderr)
+		suggestions = engine.Run(suggestCtx)
 	}
 
-	// Insert friction events.
-	for _, f := range facets {
-		for frictionType, count := range f.FrictionCounts {
-			fe := &store.FrictionEvent{
-				SnapshotID:   snapshotID,
-				SessionID:    f.SessionID,
-				FrictionType: frictionType,
-				Count:        count,
+	if dryRun {
+		progress.Stop()
+		frictionEvents := 0
+		if record(store.SectionFriction) {
+			for _, f := range facets {
+				frictionEvents += len(f.FrictionCounts)
 			}
-			if err := db.InsertFrictionEvent(fe); err != nil {
-				return fmt.Errorf("inserting friction event: %w", err)
+		}
+		preview, err := previewTrack(db, trackCompare, project, metrics, suggestions, suggestCtx)
+		if err != nil {
+			return err
+		}
+		if suggestCtx != nil {
+			if preview.WouldExpire, err = previewExpiredSuggestions(db, cfg.SuggestionTTL, suggestions, time.Now()); err != nil {
+				return fmt.Errorf("loading suggestions to expire: %w", err)
 			}
 		}
-	}
-
-	// Insert agent tasks.
-	for _, task := range agentTasks {
-		at := &store.AgentTaskRow{
-			SnapshotID:  snapshotID,
-			SessionID:   task.SessionID,
-			AgentID:     task.AgentID,
-			AgentType:   task.AgentType,
-			Description: task.Description,
-			Status:      task.Status,
-			DurationMs:  task.DurationMs,
-			TotalTokens: task.TotalTokens,
-			ToolUses:    task.ToolUses,
-			Background:  task.Background,
-			CreatedAt:   task.CreatedAt,
+		preview.Sections = sections
+		preview.Project = project
+		preview.ProjectScores = len(projects)
+		preview.FrictionEvents = frictionEvents
+		if record(store.SectionAgents) {
+			preview.AgentTasks = len(agentTasks)
+		}
+		if trackDiffSuggestions {
+			pending, err := pendingSuggestions(db, suggestions)
+			if err != nil {
+				return err
+			}
+			if preview.SuggestionDiff, err = loadSuggestionDiff(db, 0, pending); err != nil {
+				return err
+			}
 		}
-		if err := db.InsertAgentTask(at); err != nil {
-			return fmt.Errorf("inserting agent task: %w", err)
+		preview = anonymized(preview)
+		switch format {
+		case "json":
+			return writeJSON(preview)
+		case "markdown":
+			writeTrackMarkdown(os.Stdout, "Track: Dry Run", preview.Previous, preview.Metrics, preview.Deltas)
+			return nil
+		case "csv":
+			return writeTrackCSV(os.Stdout, preview.Metrics, preview.Deltas)
 		}
+		renderTrackPreview(preview)
+		return nil
 	}
 
-	// Run suggest engine and store suggestions.
-	suggestCtx, err := buildAnalysisContext(cfg)
+	rec, err := buildSnapshotRecord(db, project, sections, projects, metrics, facets, agentTasks, suggestions)
 	if err != nil {
-		return fmt.Errorf("building suggest context: %w", err)
+		return err
 	}
-	engine := suggest.NewEngine()
-	suggestions := engine.Run(suggestCtx)
-	for _, s := range suggestions {
-		ss := &store.Suggestion{
-			SnapshotID:  snapshotID,
-			Category:    s.Category,
-			Priority:    s.Priority,
-			Title:       s.Title,
-			Description: s.Description,
-			ImpactScore: s.ImpactScore,
-			Status:      "open",
+
+	if trackNoStore {
+		progress.Stop()
+		currentSnapshot, diff, summary, err := compareUnstored(db, trackCompare, rec, time.Now())
+		if err != nil {
+			return err
 		}
-		if err := db.InsertSuggestion(ss); err != nil {
-			return fmt.Errorf("inserting suggestion: %w", err)
+		if summary != nil && trackDiffSuggestions {
+			if summary.Diff, err = loadSuggestionDiff(db, 0, rec.Suggestions); err != nil {
+				return err
+			}
 		}
+		return writeTrackResult(format, currentSnapshot, diff, aggregateMetricList(metrics), summary)
+	}
+
+	// Record the snapshot and every row in it in one transaction, so a
+	// failure part way leaves no partial snapshot behind.
+	progress.Phase("Recording snapshot")
+	snapshotID, err := db.RecordSnapshot(rec)
+	if err != nil {
+		return fmt.Errorf("recording snapshot: %w", err)
 	}
 
+	progress.Stop()
+
 	// Handle --history mode: show trends across N snapshots.
 	if trackHistory > 0 {
-		if trackJSON || flagJSON {
-			return outputHistoryJSON(db, trackHistory)
+		if format == "json" {
+			var only []string
+			if len(trackMetrics) > 0 {
+				only = historyMetrics
+			}
+			return outputHistoryJSON(db, trackHistory, project, only)
+		}
+		timeline, err := loadHistory(db, trackHistory, project)
+		if err != nil {
+			return err
 		}
-		return renderHistory(db, trackHistory)
+		switch format {
+		case "markdown":
+			writeHistoryMarkdown(os.Stdout, timeline, historyMetrics)
+			return nil
+		case "csv":
+			return writeHistoryCSV(os.Stdout, timeline, historyMetrics)
+		}
+		renderHistory(timeline, historyMetrics)
+		return nil
 	}
 
-	// Load previous snapshot for comparison.
-	// trackCompare=1 means compare against the immediate predecessor (offset 2 from newest).
-	prevSnapshot, err := db.GetSnapshotN(trackCompare + 1)
-	if err != nil {
-		return fmt.Errorf("loading previous snapshot: %w", err)
+	// Load previous snapshot for comparison, skipping the one just recorded
+	// and any recorded without metrics.
+	var prevSnapshot *store.Snapshot
+	if record(store.SectionMetrics) {
+		prevSnapshot, err = previousMetricsSnapshot(db, trackCompare, 1, project)
+		if err != nil {
+			return fmt.Errorf("loading previous snapshot: %w", err)
+		}
 	}
 
 	currentSnapshot, err := db.GetSnapshot(snapshotID)
@@ -230,6 +427,11 @@ func runTrack(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading current snapshot: %w", err)
 	}
 
+	currMetrics, err := db.GetAggregateMetrics(snapshotID)
+	if err != nil {
+		return fmt.Errorf("loading current metrics: %w", err)
+	}
+
 	// Compute deltas.
 	var diff *store.SnapshotDiff
 	if prevSnapshot != nil {
@@ -238,32 +440,148 @@ func runTrack(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("loading previous metrics: %w", err)
 		}
 
-		currMetrics, err := db.GetAggregateMetrics(snapshotID)
-		if err != nil {
-			return fmt.Errorf("loading current metrics: %w", err)
-		}
-
 		deltas := computeDeltas(prevMetrics, currMetrics)
 		diff = &store.SnapshotDiff{
 			Previous: prevSnapshot,
 			Current:  currentSnapshot,
 			Deltas:   deltas,
 		}
+	}
 
-		// Auto-resolve suggestions whose conditions have cleared.
-		if err := autoResolveSuggestions(db, suggestCtx); err != nil {
-			return fmt.Errorf("auto-resolving suggestions: %w", err)
+	// Auto-resolve suggestions whose conditions have cleared, once there is
+	// an earlier snapshot to have raised them.
+	var summary *trackSuggestions
+	if record(store.SectionSuggestions) {
+		summary = &trackSuggestions{}
+		// The previous suggestions are read before auto-resolving, which
+		// marks them resolved, so the diff sees what was open then.
+		var prevSuggestionSnapshot *store.Snapshot
+		var prevSuggestions []store.Suggestion
+		if trackDiffSuggestions {
+			if prevSuggestionSnapshot, prevSuggestions, err = loadPreviousSuggestions(db, 1); err != nil {
+				return err
+			}
+		}
+		earlier, err := db.GetSnapshotN(2)
+		if err != nil {
+			return fmt.Errorf("loading previous snapshot: %w", err)
+		}
+		if earlier != nil {
+			if summary.AutoResolved, err = autoResolveSuggestions(db, suggestCtx); err != nil {
+				return fmt.Errorf("auto-resolving suggestions: %w", err)
+			}
+			if summary.Expired, err = expireSuggestions(db, cfg.SuggestionTTL, time.Now()); err != nil {
+				return fmt.Errorf("expiring suggestions: %w", err)
+			}
+		}
+		recorded, err := db.GetSnapshotSuggestions(snapshotID)
+		if err != nil {
+			return fmt.Errorf("loading suggestions: %w", err)
+		}
+		summarizeTrackSuggestions(summary, recorded)
+		if trackDiffSuggestions {
+			summary.Diff = diffSuggestions(prevSuggestionSnapshot, prevSuggestions, recorded)
 		}
 	}
 
-	if trackJSON || flagJSON {
-		return outputTrackJSON(currentSnapshot, diff)
+	return writeTrackResult(format, currentSnapshot, diff, currMetrics, summary)
+}
+
+// compareUnstored builds the snapshot rec describes without storing it, for
+// --no-store, and compares it against the compare-th most recent stored
+// snapshot with metrics. The snapshot has ID 0. Nothing is written: open
+// suggestions are neither auto-resolved nor expired, so the summary only
+// lists the new snapshot's suggestions.
+func compareUnstored(db *store.DB, compare int, rec store.SnapshotRecord, now time.Time) (*store.Snapshot, *store.SnapshotDiff, *trackSuggestions, error) {
+	current := &store.Snapshot{
+		TakenAt:  now,
+		Command:  rec.Command,
+		Version:  rec.Version,
+		Sections: rec.Sections,
+		Project:  rec.Project,
+	}
+
+	var diff *store.SnapshotDiff
+	if current.Has(store.SectionMetrics) {
+		prev, err := previousMetricsSnapshot(db, compare, 0, rec.Project)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("loading previous snapshot: %w", err)
+		}
+		if prev != nil {
+			prevMetrics, err := db.GetAggregateMetrics(prev.ID)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("loading previous metrics: %w", err)
+			}
+			currMetrics := slices.Clone(rec.AggregateMetrics)
+			sort.Slice(currMetrics, func(i, j int) bool { return currMetrics[i].MetricName < currMetrics[j].MetricName })
+			diff = &store.SnapshotDiff{
+				Previous: prev,
+				Current:  current,
+				Deltas:   computeDeltas(prevMetrics, currMetrics),
+			}
+		}
+	}
+
+	var summary *trackSuggestions
+	if current.Has(store.SectionSuggestions) {
+		summary = &trackSuggestions{}
+		summarizeTrackSuggestions(summary, rec.Suggestions)
+	}
+	return current, diff, summary, nil
+}
+
+// writeTrackResult renders a track comparison in format. A current snapshot
+// with ID 0 was not stored.
+func writeTrackResult(format string, currentSnapshot *store.Snapshot, diff *store.SnapshotDiff, currMetrics []store.AggregateMetric, summary *trackSuggestions) error {
+	currentSnapshot, diff = anonymized(currentSnapshot), anonymized(diff)
+	switch format {
+	case "json":
+		return outputTrackJSON(currentSnapshot, diff, summary)
+	case "markdown":
+		heading := fmt.Sprintf("Snapshot #%d (%s)", currentSnapshot.ID, currentSnapshot.TakenAt.Format("2006-01-02 15:04"))
+		if currentSnapshot.ID == 0 {
+			heading = fmt.Sprintf("Unstored snapshot (%s)", currentSnapshot.TakenAt.Format("2006-01-02 15:04"))
+		}
+		if diff == nil {
+			writeTrackMarkdown(os.Stdout, heading, nil, currMetrics, nil)
+		} else {
+			writeTrackMarkdown(os.Stdout, heading, diff.Previous, currMetrics, diff.Deltas)
+		}
+		return nil
+	case "csv":
+		if diff == nil {
+			return writeTrackCSV(os.Stdout, currMetrics, nil)
+		}
+		return writeTrackCSV(os.Stdout, currMetrics, diff.Deltas)
 	}
 
 	renderTrackOutput(currentSnapshot, diff)
+	switch {
+	case summary != nil && summary.Diff != nil:
+		renderSuggestionDiff(summary.Diff)
+	case summary != nil:
+		renderTrackSuggestions(summary)
+	}
 	return nil
 }
 
+// trackOutputFormat validates --format and folds --json into it.
+func trackOutputFormat(format string, jsonFlag bool) (string, error) {
+	format = strings.ToLower(format)
+	switch format {
+	case "table", "markdown", "csv", "json":
+	default:
+		return "", fmt.Errorf("invalid --format %q (valid: table, markdown, csv, json)", format)
+	}
+	if jsonFlag {
+		if format != "table" && format != "json" {
+			return "", fmt.Errorf("--json conflicts with --format %s", format)
+		}
+		return "json", nil
+	}
+	return format, nil
+}
+
 // buildAggregateMetrics produces a flat map of metric name to value from
 // the various analyzer results.
 func buildAggregateMetrics(
@@ -312,7 +630,125 @@ var metricDirection = map[string]bool{
 	"agent_background_ratio":      true,
 }
 
-// computeDeltas compares two sets of aggregate metrics and returns MetricDelta entries.
+// defaultMetricNoise is each metric's default noise band: changes no larger
+// than it are reported as unchanged. Metrics without an entry use
+// defaultNoiseBand.
+var defaultMetricNoise = map[string]config.NoiseBand{
+	"total_sessions":              {Pct: 2},
+	"avg_lines_added_per_session": {Pct: 5},
+	"avg_commits_per_session":     {Abs: 0.05, Pct: 5},
+	"avg_files_modified":          {Pct: 5},
+	"avg_duration_minutes":        {Abs: 1, Pct: 5},
+	"avg_messages_per_session":    {Pct: 5},
+	"total_friction_events":       {Pct: 5},
+	"sessions_with_friction":      {Pct: 5},
+	"satisfaction_score":          {Abs: 1},
+	"avg_tool_errors":             {Abs: 0.1, Pct: 5},
+	"avg_interruptions":           {Abs: 0.1, Pct: 5},
+	"avg_tokens_per_session":      {Pct: 5},
+	"agent_total":                 {Pct: 5},
+	"agent_success_rate":          {Abs: 2}, // percentage points
+	"agent_background_ratio":      {Abs: 2},
+}
+
+// defaultNoiseBand applies to metrics without a defaultMetricNoise entry.
+var defaultNoiseBand = config.NoiseBand{Pct: 2}
+
+// metricNoise is the noise band of each metric: defaultMetricNoise with the
+// configured noise_bands merged over it by loadConfig.
+var metricNoise = defaultMetricNoise
+
+// applyNoiseBands merges the configured noise_bands over the default noise
+// bands, rejecting metrics track doesn't record, which are most likely typos.
+func applyNoiseBands(cfg *config.Config) error {
+	var unknown []string
+	for name := range cfg.NoiseBands {
+		if _, ok := metricDirection[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown metric in noise_bands: %s (valid: %s)", strings.Join(unknown, ", "), strings.Join(slices.Sorted(maps.Keys(metricDirection)), ", "))
+	}
+
+	metricNoise = maps.Clone(defaultMetricNoise)
+	maps.Copy(metricNoise, cfg.NoiseBands)
+	return nil
+}
+
+// significantDelta returns delta, the change in the named metric from prev,
+// or zero when the change is inside the metric's noise band.
+func significantDelta(name string, prev, delta float64) float64 {
+	band, ok := metricNoise[name]
+	if !ok {
+		band = defaultNoiseBand
+	}
+	change := math.Abs(delta)
+	if change <= band.Abs || change <= math.Abs(prev)*band.Pct/100 {
+		return 0
+	}
+	return delta
+}
+
+// Units stored with aggregate metrics and used to format their values.
+const (
+	unitCount   = "count"
+	unitMinutes = "minutes"
+	unitPercent = "percent"
+	unitDollars = "dollars"
+)
+
+// metricUnits maps metric names to the unit their values are measured in.
+// Metrics without an entry, such as the 0-100 satisfaction score, are
+// unitless.
+var metricUnits = map[string]string{
+	"total_sessions":              unitCount,
+	"avg_lines_added_per_session": unitCount,
+	"avg_commits_per_session":     unitCount,
+	"avg_files_modified":          unitCount,
+	"avg_duration_minutes":        unitMinutes,
+	"avg_messages_per_session":    unitCount,
+	"total_friction_events":       unitCount,
+	"sessions_with_friction":      unitCount,
+	"avg_tool_errors":             unitCount,
+	"avg_interruptions":           unitCount,
+	"avg_tokens_per_session":      unitCount,
+	"agent_total":                 unitCount,
+	"agent_success_rate":          unitPercent,
+	"agent_background_ratio":      unitPercent,
+}
+
+// formatUnitValue formats v in unit for display: "42.0 min", "85%",
+// "$1.23". Counts and unitless values keep one decimal place.
+func formatUnitValue(unit string, v float64) string {
+	switch unit {
+	case unitMinutes:
+		return fmt.Sprintf("%.1f min", v)
+	case unitPercent:
+		return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64) + "%"
+	case unitDollars:
+		if v < 0 {
+			return fmt.Sprintf("-$%.2f", -v)
+		}
+		return fmt.Sprintf("$%.2f", v)
+	default:
+		return fmt.Sprintf("%.1f", v)
+	}
+}
+
+// formatUnitDelta formats a change in a metric measured in unit, always
+// with a sign: "+2.5 min", "-5%", "+$0.40".
+func formatUnitDelta(unit string, d float64) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+	}
+	return sign + formatUnitValue(unit, math.Abs(d))
+}
+
+// computeDeltas compares two sets of aggregate metrics and returns MetricDelta
+// entries. Changes inside a metric's noise band are reported as unchanged.
 func computeDeltas(prev, curr []store.AggregateMetric) []store.MetricDelta {
 	prevMap := make(map[string]float64)
 	for _, m := range prev {
@@ -324,48 +760,93 @@ func computeDeltas(prev, curr []store.AggregateMetric) []store.MetricDelta {
 		prevVal := prevMap[m.MetricName]
 		delta := m.MetricValue - prevVal
 
-		direction := "unchanged"
-		if delta != 0 {
-			higherIsBetter, known := metricDirection[m.MetricName]
-			if !known {
-				higherIsBetter = true // default assumption
-			}
-			isPositive := delta > 0
-			if (isPositive && higherIsBetter) || (!isPositive && !higherIsBetter) {
-				direction = "improved"
-			} else {
-				direction = "regressed"
-			}
-		}
-
 		deltas = append(deltas, store.MetricDelta{
 			Name:      m.MetricName,
 			Previous:  prevVal,
 			Current:   m.MetricValue,
 			Delta:     delta,
-			Direction: direction,
+			Unit:      m.Unit,
+			Direction: deltaDirection(m.MetricName, significantDelta(m.MetricName, prevVal, delta)),
 		})
 	}
 
 	return deltas
 }
 
+// deltaDirection classifies a change in the named metric as "improved",
+// "regressed", or "unchanged".
+func deltaDirection(name string, delta float64) string {
+	if delta == 0 {
+		return "unchanged"
+	}
+	higherIsBetter, known := metricDirection[name]
+	if !known {
+		higherIsBetter = true // default assumption
+	}
+	isPositive := delta > 0
+	if (isPositive && higherIsBetter) || (!isPositive && !higherIsBetter) {
+		return "improved"
+	}
+	return "regressed"
+}
+
 // autoResolveSuggestions resolves open suggestions whose trigger conditions
-// are no longer true.
-func autoResolveSuggestions(db *store.DB, ctx *suggest.AnalysisContext) error {
+// are no longer true and returns how many it resolved.
+func autoResolveSuggestions(db *store.DB, ctx *suggest.AnalysisContext) (int, error) {
 	openSuggestions, err := db.GetOpenSuggestions()
 	if err != nil {
-		return err
+		return 0, err
+	}
+
+	resolved := resolvableSuggestions(openSuggestions, ctx)
+	for _, s := range resolved {
+		if err := db.ResolveSuggestion(s.ID); err != nil {
+			return 0, err
+		}
 	}
 
-	// Build a set of current project names that still lack CLAUDE.md.
-	missingCMD := make(map[string]bool)
-	for _, p := range ctx.Projects {
-		if p.SessionCount > 0 && !p.HasClaudeMD {
-			missingCMD[p.Name] = true
+	return len(resolved), nil
+}
+
+// trackTopSuggestions is how many of a snapshot's suggestions track shows.
+const trackTopSuggestions = 3
+
+// trackSuggestions summarizes the suggestions a track run recorded.
+type trackSuggestions struct {
+	// Open counts the snapshot's suggestions still open, leaving out those
+	// the user already resolved.
+	Open int `json:"open"`
+	// AutoResolved counts earlier suggestions this run found cleared.
+	AutoResolved int `json:"auto_resolved"`
+	// Expired counts earlier suggestions this run closed for going unraised
+	// past the suggestion_ttl.
+	Expired int `json:"expired"`
+	// Top lists the trackTopSuggestions open ones with the highest impact.
+	Top []store.Suggestion `json:"top"`
+	// Diff groups the suggestions against the previous snapshot, with
+	// --diff-suggestions.
+	Diff *suggestionDiff `json:"diff,omitempty"`
+}
+
+// summarizeTrackSuggestions fills in s from a snapshot's suggestions, which
+// come highest impact first.
+func summarizeTrackSuggestions(s *trackSuggestions, recorded []store.Suggestion) {
+	s.Top = []store.Suggestion{}
+	for _, sg := range recorded {
+		if sg.Status != "open" {
+			continue
+		}
+		s.Open++
+		if len(s.Top) < trackTopSuggestions {
+			s.Top = append(s.Top, sg)
 		}
 	}
+}
 
+// resolvableSuggestions returns the open suggestions whose trigger conditions
+// are no longer true.
+func resolvableSuggestions(openSuggestions []store.Suggestion, ctx *suggest.AnalysisContext) []store.Suggestion {
+	var resolved []store.Suggestion
 	for _, s := range openSuggestions {
 		shouldResolve := false
 
@@ -386,33 +867,327 @@ func autoResolveSuggestions(db *store.DB, ctx *suggest.AnalysisContext) error {
 		}
 
 		if shouldResolve {
-			if err := db.ResolveSuggestion(s.ID); err != nil {
-				return err
+			resolved = append(resolved, s)
+		}
+	}
+
+	return resolved
+}
+
+// trackPreview is what a track run would record, produced by --dry-run.
+type trackPreview struct {
+	DryRun         bool                    `json:"dry_run"`
+	Sections       []string                `json:"sections"`
+	Project        string                  `json:"project,omitempty"`
+	ProjectScores  int                     `json:"project_scores"`
+	FrictionEvents int                     `json:"friction_events"`
+	AgentTasks     int                     `json:"agent_tasks"`
+	Metrics        []store.AggregateMetric `json:"metrics"`
+	Suggestions    []suggest.Suggestion    `json:"suggestions"`
+	Previous       *store.Snapshot         `json:"previous,omitempty"`
+	Deltas         []store.MetricDelta     `json:"deltas,omitempty"`
+	WouldResolve   []store.Suggestion      `json:"would_resolve,omitempty"`
+	WouldExpire    []store.Suggestion      `json:"would_expire,omitempty"`
+	SuggestionDiff *suggestionDiff         `json:"suggestion_diff,omitempty"`
+}
+
+// buildSnapshotRecord collects the rows a track run records for the given
+// sections. Suggestions the user resolved by hand are recorded as resolved,
+// so they don't reappear as open.
+func buildSnapshotRecord(db *store.DB, project string, sections []string, projects []scanner.Project, metrics map[string]float64,
+	facets []claude.SessionFacet, agentTasks []claude.AgentTask, suggestions []suggest.Suggestion) (store.SnapshotRecord, error) {
+	rec := store.SnapshotRecord{
+		Command:  "track",
+		Version:  appVersion,
+		Project:  project,
+		Sections: sections,
+	}
+
+	for _, p := range projects {
+		rec.ProjectScores = append(rec.ProjectScores, store.ProjectScore{
+			Project:          p.Path,
+			Score:            p.Score,
+			HasClaudeMD:      p.HasClaudeMD,
+			HasDotClaude:     p.HasDotClaude,
+			HasLocalSettings: p.HasLocalSettings,
+			SessionCount:     p.SessionCount,
+			LastSessionDate:  p.LastSessionDate,
+			PrimaryLanguage:  p.PrimaryLanguage,
+			GitCommit30D:     p.CommitsLast30Days,
+		})
+	}
+
+	for name, value := range metrics {
+		rec.AggregateMetrics = append(rec.AggregateMetrics, store.AggregateMetric{
+			MetricName:  name,
+			MetricValue: value,
+			Unit:        metricUnits[name],
+		})
+	}
+
+	if slices.Contains(sections, store.SectionFriction) {
+		for _, f := range facets {
+			for frictionType, count := range f.FrictionCounts {
+				rec.FrictionEvents = append(rec.FrictionEvents, store.FrictionEvent{
+					SessionID:    f.SessionID,
+					FrictionType: frictionType,
+					Count:        count,
+				})
 			}
 		}
 	}
 
-	return nil
+	if slices.Contains(sections, store.SectionAgents) {
+		for _, task := range agentTasks {
+			rec.AgentTasks = append(rec.AgentTasks, store.AgentTaskRow{
+				SessionID:   task.SessionID,
+				AgentID:     task.AgentID,
+				AgentType:   task.AgentType,
+				Description: task.Description,
+				Status:      task.Status,
+				DurationMs:  task.DurationMs,
+				TotalTokens: task.TotalTokens,
+				ToolUses:    task.ToolUses,
+				Background:  task.Background,
+				CreatedAt:   task.CreatedAt,
+			})
+		}
+	}
+
+	for _, s := range suggestions {
+		ss := store.Suggestion{
+			Category:    s.Category,
+			Priority:    s.Priority,
+			Title:       s.Title,
+			Description: s.Description,
+			ImpactScore: s.ImpactScore,
+			Status:      "open",
+		}
+		manual, err := db.SuggestionResolvedManually(s.Category, s.Title)
+		if err != nil {
+			return rec, fmt.Errorf("checking suggestion status: %w", err)
+		}
+		if manual {
+			ss.Status = "resolved"
+			ss.ResolvedManually = true
+		}
+		rec.Suggestions = append(rec.Suggestions, ss)
+	}
+
+	return rec, nil
+}
+
+// previewTrack compares metrics against the compare-th most recent snapshot
+// of project that recorded metrics and finds the open suggestions a real
+// run would auto-resolve. It only reads from db. Nil metrics or ctx mean
+// that section isn't being recorded, and its comparison is skipped. As in a
+// real run, suggestions are only auto-resolved when there is a previous
+// snapshot.
+func previewTrack(
+	db *store.DB,
+	compare int,
+	project string,
+	metrics map[string]float64,
+	suggestions []suggest.Suggestion,
+	ctx *suggest.AnalysisContext,
+) (*trackPreview, error) {
+	preview := &trackPreview{
+		DryRun:      true,
+		Metrics:     aggregateMetricList(metrics),
+		Suggestions: suggestions,
+	}
+
+	// Nothing is inserted, so no snapshot needs skipping.
+	if metrics != nil {
+		prev, err := previousMetricsSnapshot(db, compare, 0, project)
+		if err != nil {
+			return nil, fmt.Errorf("loading previous snapshot: %w", err)
+		}
+		if prev != nil {
+			preview.Previous = prev
+			prevMetrics, err := db.GetAggregateMetrics(prev.ID)
+			if err != nil {
+				return nil, fmt.Errorf("loading previous metrics: %w", err)
+			}
+			preview.Deltas = computeDeltas(prevMetrics, preview.Metrics)
+		}
+	}
+
+	if ctx != nil {
+		earlier, err := db.GetSnapshotN(1)
+		if err != nil {
+			return nil, fmt.Errorf("loading previous snapshot: %w", err)
+		}
+		if earlier != nil {
+			openSuggestions, err := db.GetOpenSuggestions()
+			if err != nil {
+				return nil, fmt.Errorf("loading open suggestions: %w", err)
+			}
+			preview.WouldResolve = resolvableSuggestions(openSuggestions, ctx)
+		}
+	}
+
+	return preview, nil
 }
 
-func outputTrackJSON(current *store.Snapshot, diff *store.SnapshotDiff) error {
+// previousMetricsSnapshot returns the nth most recent snapshot of project
+// ("" for whole-history snapshots) that recorded metrics, ignoring the skip
+// newest snapshots, or nil when there are fewer than n.
+func previousMetricsSnapshot(db *store.DB, n, skip int, project string) (*store.Snapshot, error) {
+	return previousSnapshotWith(db, store.SectionMetrics, n, skip, project)
+}
+
+// previousSnapshotWith returns the nth most recent snapshot of project that
+// recorded section, ignoring the skip newest snapshots, or nil when there
+// are fewer than n.
+func previousSnapshotWith(db *store.DB, section string, n, skip int, project string) (*store.Snapshot, error) {
+	for offset := skip + 1; ; offset++ {
+		s, err := db.GetSnapshotN(offset)
+		if err != nil || s == nil {
+			return nil, err
+		}
+		if s.Project != project || !s.Has(section) {
+			continue
+		}
+		if n--; n == 0 {
+			return s, nil
+		}
+	}
+}
+
+// resolveTrackSections validates --only values against the snapshot
+// sections and returns them in recording order. No values selects every
+// section.
+func resolveTrackSections(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return store.SnapshotSections, nil
+	}
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(store.SnapshotSections, name) {
+			return nil, fmt.Errorf("unknown --only section %q (want %s)", name, strings.Join(store.SnapshotSections, ", "))
+		}
+		selected[name] = true
+	}
+	var sections []string
+	for _, s := range store.SnapshotSections {
+		if selected[s] {
+			sections = append(sections, s)
+		}
+	}
+	return sections, nil
+}
+
+// aggregateMetricList converts a metric map into the rows a snapshot would
+// store, sorted by name.
+func aggregateMetricList(metrics map[string]float64) []store.AggregateMetric {
+	list := make([]store.AggregateMetric, 0, len(metrics))
+	for name, value := range metrics {
+		list = append(list, store.AggregateMetric{MetricName: name, MetricValue: value, Unit: metricUnits[name]})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].MetricName < list[j].MetricName })
+	return list
+}
+
+func renderTrackPreview(p *trackPreview) {
+	fmt.Println(output.Section("Track: Dry Run"))
+	fmt.Println()
+	fmt.Println(output.StyleMuted.Render(" Dry run: no snapshot was written."))
+	fmt.Println()
+	fmt.Printf(" Would record %d project scores, %d metrics, %d friction events, %d agent tasks, and %d suggestions.\n\n",
+		p.ProjectScores, len(p.Metrics), p.FrictionEvents, p.AgentTasks, len(p.Suggestions))
+
+	if p.Project != "" {
+		fmt.Printf(" %s\n", output.StyleMuted.Render("Project: "+p.Project))
+	}
+	if len(p.Sections) < len(store.SnapshotSections) {
+		fmt.Printf(" %s\n\n", output.StyleMuted.Render("Recording only: "+strings.Join(p.Sections, ", ")))
+	}
+
+	if !slices.Contains(p.Sections, store.SectionMetrics) {
+		fmt.Println(" Metrics would not be recorded, so there is nothing to compare.")
+	} else if p.Previous == nil {
+		tbl := output.NewTable("Metric", "Value")
+		for _, m := range p.Metrics {
+			tbl.AddRow(m.MetricName, formatUnitValue(m.Unit, m.MetricValue))
+		}
+		tbl.Print()
+		fmt.Println()
+		fmt.Println(" No previous snapshot to compare against.")
+	} else {
+		fmt.Printf(" Comparing against snapshot #%d (%s)\n\n",
+			p.Previous.ID, p.Previous.TakenAt.Format("2006-01-02 15:04:05"))
+		deltaTable(p.Deltas).Print()
+	}
+
+	if p.SuggestionDiff != nil {
+		renderSuggestionDiff(p.SuggestionDiff)
+	} else if len(p.Suggestions) > 0 {
+		fmt.Println()
+		fmt.Println(output.Section("Suggestions"))
+		for _, s := range p.Suggestions {
+			fmt.Printf(" %s %s\n", output.StyleMuted.Render("["+s.Category+"]"), s.Title)
+		}
+	}
+
+	if p.Previous != nil || len(p.WouldResolve) > 0 || len(p.WouldExpire) > 0 {
+		fmt.Println()
+		fmt.Println(output.Section("Auto-resolution"))
+		if len(p.WouldResolve) == 0 && len(p.WouldExpire) == 0 {
+			fmt.Println(" No open suggestions would be auto-resolved.")
+		}
+		for _, s := range p.WouldResolve {
+			fmt.Printf(" Would resolve #%d %s\n", s.ID, s.Title)
+		}
+		for _, s := range p.WouldExpire {
+			fmt.Printf(" Would expire #%d %s\n", s.ID, s.Title)
+		}
+	}
+}
+
+func outputTrackJSON(current *store.Snapshot, diff *store.SnapshotDiff, suggestions *trackSuggestions) error {
 	result := map[string]any{
 		"snapshot": current,
 	}
+	if current.ID == 0 {
+		result["stored"] = false
+	}
 	if diff != nil {
 		result["diff"] = diff
 	}
+	if suggestions != nil {
+		result["suggestions"] = suggestions
+	}
 
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(result)
+	return writeJSON(result)
 }
 
 func renderTrackOutput(current *store.Snapshot, diff *store.SnapshotDiff) {
 	fmt.Println(output.Section("Track: Snapshot Comparison"))
 	fmt.Println()
-	fmt.Printf(" Snapshot #%d taken at %s\n\n", current.ID, current.TakenAt.Format("2006-01-02 15:04:05"))
+	if current.ID == 0 {
+		fmt.Printf(" Snapshot taken at %s %s\n\n", current.TakenAt.Format("2006-01-02 15:04:05"),
+			output.StyleMuted.Render("(not stored: --no-store)"))
+	} else {
+		fmt.Printf(" Snapshot #%d taken at %s\n\n", current.ID, current.TakenAt.Format("2006-01-02 15:04:05"))
+	}
+	if current.Project != "" {
+		fmt.Printf(" %s\n\n", output.StyleMuted.Render("Project: "+current.Project))
+	}
+
+	if len(current.Sections) < len(store.SnapshotSections) {
+		fmt.Printf(" %s\n\n", output.StyleMuted.Render("Recorded only: "+strings.Join(current.Sections, ", ")))
+	}
+	if !current.Has(store.SectionMetrics) {
+		fmt.Println(" Metrics were not recorded, so there is nothing to compare.")
+		return
+	}
 
+	if diff == nil && current.ID == 0 {
+		fmt.Println(" No stored snapshot to compare against.")
+		return
+	}
 	if diff == nil {
 		fmt.Println(" First snapshot recorded. Run 'claudewatch track' again later to see trends.")
 		return
@@ -421,26 +1196,59 @@ func renderTrackOutput(current *store.Snapshot, diff *store.SnapshotDiff) {
 	fmt.Printf(" Comparing against snapshot #%d (%s)\n\n",
 		diff.Previous.ID, diff.Previous.TakenAt.Format("2006-01-02 15:04:05"))
 
+	deltaTable(diff.Deltas).Print()
+}
+
+// renderTrackSuggestions shows the highest-impact open suggestions of the
+// new snapshot and how many earlier ones cleared since the last one.
+func renderTrackSuggestions(s *trackSuggestions) {
+	fmt.Println()
+	fmt.Println(output.Section("Top Suggestions"))
+	fmt.Println()
+	if s.AutoResolved > 0 {
+		fmt.Printf(" %s\n\n", output.StyleSuccess.Render(
+			fmt.Sprintf("%d suggestion(s) auto-resolved since the last snapshot.", s.AutoResolved)))
+	}
+	if s.Expired > 0 {
+		fmt.Printf(" %s\n\n", output.StyleMuted.Render(
+			fmt.Sprintf("%d suggestion(s) expired after going unraised past suggestion_ttl.", s.Expired)))
+	}
+	if s.Open == 0 {
+		fmt.Printf(" %s\n", output.StyleSuccess.Render("No open suggestions. Nothing needs action right now."))
+		return
+	}
+	for i, sg := range s.Top {
+		fmt.Printf(" %d. %s %s\n", i+1, sg.Title,
+			output.StyleMuted.Render(fmt.Sprintf("(%s, impact %.1f)", sg.Category, sg.ImpactScore)))
+	}
+	if more := s.Open - len(s.Top); more > 0 {
+		fmt.Printf("\n %s\n", output.StyleMuted.Render(
+			fmt.Sprintf("%d more open. Run 'claudewatch suggest' to see them all.", more)))
+	}
+}
+
+// deltaTable renders metric deltas with trend arrows.
+func deltaTable(deltas []store.MetricDelta) *output.Table {
 	tbl := output.NewTable("Metric", "Previous", "Current", "Delta", "Trend")
 
-	for _, d := range diff.Deltas {
+	for _, d := range deltas {
 		higherIsBetter, known := metricDirection[d.Name]
 		if !known {
 			higherIsBetter = true
 		}
 
-		trend := output.TrendArrow(d.Delta, higherIsBetter)
+		trend := output.TrendArrow(significantDelta(d.Name, d.Previous, d.Delta), higherIsBetter)
 
 		tbl.AddRow(
 			d.Name,
-			fmt.Sprintf("%.1f", d.Previous),
-			fmt.Sprintf("%.1f", d.Current),
-			fmt.Sprintf("%+.1f", d.Delta),
+			formatUnitValue(d.Unit, d.Previous),
+			formatUnitValue(d.Unit, d.Current),
+			formatUnitDelta(d.Unit, d.Delta),
 			trend,
 		)
 	}
 
-	tbl.Print()
+	return tbl
 }
 
 // metricDisplayOrder defines the order metrics appear in history output.
@@ -487,39 +1295,97 @@ func metricShortName(name string) string {
 	return name
 }
 
-// renderHistory shows a multi-snapshot timeline table.
-func renderHistory(db *store.DB, n int) error {
-	snapshots, err := db.GetRecentSnapshots(n)
-	if err != nil {
-		return fmt.Errorf("loading snapshots: %w", err)
+// resolveHistoryMetrics maps --metric values to raw metric names in
+// metricDisplayOrder. Values match a raw name or its short label, ignoring
+// case. No values selects every metric.
+func resolveHistoryMetrics(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return metricDisplayOrder, nil
 	}
 
-	if len(snapshots) == 0 {
-		fmt.Println(" No snapshots found. Run 'claudewatch track' to create one.")
-		return nil
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, m := range metricDisplayOrder {
+			if key == m || key == strings.ToLower(metricShortName(m)) {
+				selected[m] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid --metric %q (valid: %s)", name, strings.Join(metricDisplayOrder, ", "))
+		}
+	}
+
+	var result []string
+	for _, m := range metricDisplayOrder {
+		if selected[m] {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// historyPoint is one snapshot's metrics in a --history timeline.
+type historyPoint struct {
+	snapshot store.Snapshot
+	metrics  map[string]float64
+}
+
+// loadHistory loads the n most recent snapshots of project ("" for
+// whole-history snapshots) and their metrics, oldest first.
+func loadHistory(db *store.DB, n int, project string) ([]historyPoint, error) {
+	snapshots, err := db.GetRecentProjectSnapshots(project, n)
+	if err != nil {
+		return nil, fmt.Errorf("loading snapshots: %w", err)
 	}
 
 	// Reverse so oldest is first (left to right = chronological).
 	for i, j := 0, len(snapshots)-1; i < j; i, j = i+1, j-1 {
 		snapshots[i], snapshots[j] = snapshots[j], snapshots[i]
 	}
+	return loadTimeline(db, snapshots)
+}
 
-	// Load metrics for each snapshot.
-	type snapshotMetrics struct {
-		snapshot store.Snapshot
-		metrics  map[string]float64
-	}
-	var timeline []snapshotMetrics
+// loadTimeline loads the metrics of each snapshot, keeping their order.
+// Snapshots recorded without metrics are left out.
+func loadTimeline(db *store.DB, snapshots []store.Snapshot) ([]historyPoint, error) {
+	timeline := make([]historyPoint, 0, len(snapshots))
 	for _, s := range snapshots {
+		if !s.Has(store.SectionMetrics) {
+			continue
+		}
 		metrics, err := db.GetAggregateMetrics(s.ID)
 		if err != nil {
-			return fmt.Errorf("loading metrics for snapshot #%d: %w", s.ID, err)
+			return nil, fmt.Errorf("loading metrics for snapshot #%d: %w", s.ID, err)
 		}
 		m := make(map[string]float64)
 		for _, am := range metrics {
 			m[am.MetricName] = am.MetricValue
 		}
-		timeline = append(timeline, snapshotMetrics{snapshot: s, metrics: m})
+		timeline = append(timeline, historyPoint{snapshot: s, metrics: m})
+	}
+	return timeline, nil
+}
+
+// historyDelta returns the change in the named metric from the first to the
+// last snapshot, zero when it is inside the metric's noise band, and false
+// when there are fewer than two snapshots.
+func historyDelta(timeline []historyPoint, name string) (float64, bool) {
+	if len(timeline) < 2 {
+		return 0, false
+	}
+	first := timeline[0].metrics[name]
+	return significantDelta(name, first, timeline[len(timeline)-1].metrics[name]-first), true
+}
+
+// renderHistory shows a multi-snapshot timeline table of the named metrics.
+func renderHistory(timeline []historyPoint, names []string) {
+	if len(timeline) == 0 {
+		fmt.Println(" No snapshots found. Run 'claudewatch track' to create one.")
+		return
 	}
 
 	fmt.Println(output.Section("Track: Metric History"))
@@ -534,19 +1400,15 @@ func renderHistory(db *store.DB, n int) error {
 	headers = append(headers, "Trend")
 	tbl := output.NewTable(headers...)
 
-	for _, name := range metricDisplayOrder {
+	for _, name := range names {
 		row := []string{metricShortName(name)}
-		var vals []float64
 		for _, sm := range timeline {
-			v := sm.metrics[name]
-			vals = append(vals, v)
-			row = append(row, fmt.Sprintf("%.1f", v))
+			row = append(row, formatUnitValue(metricUnits[name], sm.metrics[name]))
 		}
 
 		// Compute trend from first to last.
 		trend := ""
-		if len(vals) >= 2 {
-			delta := vals[len(vals)-1] - vals[0]
+		if delta, ok := historyDelta(timeline, name); ok {
 			higherIsBetter, known := metricDirection[name]
 			if !known {
 				higherIsBetter = true
@@ -558,12 +1420,151 @@ func renderHistory(db *store.DB, n int) error {
 	}
 
 	tbl.Print()
-	return nil
 }
 
-// outputHistoryJSON writes the history data as JSON.
-func outputHistoryJSON(db *store.DB, n int) error {
-	snapshots, err := db.GetRecentSnapshots(n)
+// trendArrowText is a plain-text trend arrow for Markdown output.
+func trendArrowText(delta float64) string {
+	switch {
+	case delta > 0:
+		return "↑"
+	case delta < 0:
+		return "↓"
+	default:
+		return "→"
+	}
+}
+
+// markdownRow formats cells as a Markdown table row.
+func markdownRow(cells ...string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+// markdownTable writes a Markdown table with the given header and rows.
+func markdownTable(w io.Writer, header []string, rows [][]string) {
+	sep := make([]string, len(header))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	_, _ = fmt.Fprintln(w, markdownRow(header...))
+	_, _ = fmt.Fprintln(w, markdownRow(sep...))
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(w, markdownRow(row...))
+	}
+}
+
+// writeTrackMarkdown writes a snapshot comparison as a Markdown section.
+// Without a previous snapshot it lists the current metric values instead of
+// deltas.
+func writeTrackMarkdown(w io.Writer, heading string, previous *store.Snapshot, metrics []store.AggregateMetric, deltas []store.MetricDelta) {
+	_, _ = fmt.Fprintf(w, "## %s\n\n", heading)
+
+	if previous == nil {
+		_, _ = fmt.Fprint(w, "No previous snapshot to compare against.\n\n")
+		rows := make([][]string, 0, len(metrics))
+		for _, m := range metrics {
+			rows = append(rows, []string{m.MetricName, fmt.Sprintf("%.1f", m.MetricValue)})
+		}
+		markdownTable(w, []string{"Metric", "Value"}, rows)
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Compared with snapshot #%d (%s).\n\n", previous.ID, previous.TakenAt.Format("2006-01-02 15:04"))
+	rows := make([][]string, 0, len(deltas))
+	for _, d := range deltas {
+		rows = append(rows, []string{
+			d.Name,
+			fmt.Sprintf("%.1f", d.Previous),
+			fmt.Sprintf("%.1f", d.Current),
+			fmt.Sprintf("%+.1f", d.Delta),
+			trendArrowText(significantDelta(d.Name, d.Previous, d.Delta)),
+		})
+	}
+	markdownTable(w, []string{"Metric", "Previous", "Current", "Delta", "Trend"}, rows)
+}
+
+// writeTrackCSV writes metric,previous,current,delta,direction rows. Without
+// deltas (no previous snapshot) the previous, delta, and direction columns
+// are left empty.
+func writeTrackCSV(w io.Writer, metrics []store.AggregateMetric, deltas []store.MetricDelta) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"metric", "previous", "current", "delta", "direction"})
+	if deltas == nil {
+		for _, m := range metrics {
+			_ = cw.Write([]string{m.MetricName, "", csvFloat(m.MetricValue), "", ""})
+		}
+	}
+	for _, d := range deltas {
+		_ = cw.Write([]string{d.Name, csvFloat(d.Previous), csvFloat(d.Current), csvFloat(d.Delta), d.Direction})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeHistoryMarkdown writes the --history timeline of the named metrics as
+// a Markdown table.
+func writeHistoryMarkdown(w io.Writer, timeline []historyPoint, names []string) {
+	_, _ = fmt.Fprint(w, "## Metric History\n\n")
+	if len(timeline) == 0 {
+		_, _ = fmt.Fprintln(w, "No snapshots found.")
+		return
+	}
+
+	header := []string{"Metric"}
+	for _, sm := range timeline {
+		header = append(header, fmt.Sprintf("#%d %s", sm.snapshot.ID, sm.snapshot.TakenAt.Format("2006-01-02")))
+	}
+	header = append(header, "Trend")
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		row := []string{metricShortName(name)}
+		for _, sm := range timeline {
+			row = append(row, fmt.Sprintf("%.1f", sm.metrics[name]))
+		}
+		trend := ""
+		if delta, ok := historyDelta(timeline, name); ok {
+			trend = trendArrowText(delta)
+		}
+		rows = append(rows, append(row, trend))
+	}
+	markdownTable(w, header, rows)
+}
+
+// writeHistoryCSV writes the --history timeline of the named metrics with one
+// column per snapshot and the first-to-last direction in the final column.
+func writeHistoryCSV(w io.Writer, timeline []historyPoint, names []string) error {
+	cw := csv.NewWriter(w)
+	header := []string{"metric"}
+	for _, sm := range timeline {
+		header = append(header, fmt.Sprintf("#%d %s", sm.snapshot.ID, sm.snapshot.TakenAt.Format(time.RFC3339)))
+	}
+	_ = cw.Write(append(header, "direction"))
+
+	for _, name := range names {
+		row := []string{name}
+		for _, sm := range timeline {
+			row = append(row, csvFloat(sm.metrics[name]))
+		}
+		direction := ""
+		if delta, ok := historyDelta(timeline, name); ok {
+			direction = deltaDirection(name, delta)
+		}
+		_ = cw.Write(append(row, direction))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvFloat formats a metric value for CSV output.
+func csvFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// outputHistoryJSON writes the history of project ("" for whole-history
+// snapshots) as JSON, leaving out snapshots recorded without metrics. When
+// only is non-empty, each snapshot lists just those metrics.
+func outputHistoryJSON(db *store.DB, n int, project string, only []string) error {
+	snapshots, err := db.GetRecentProjectSnapshots(project, n)
 	if err != nil {
 		return fmt.Errorf("loading snapshots: %w", err)
 	}
@@ -580,14 +1581,20 @@ func outputHistoryJSON(db *store.DB, n int) error {
 
 	var entries []snapshotEntry
 	for _, s := range snapshots {
+		if !s.Has(store.SectionMetrics) {
+			continue
+		}
 		metrics, err := db.GetAggregateMetrics(s.ID)
 		if err != nil {
 			return fmt.Errorf("loading metrics for snapshot #%d: %w", s.ID, err)
 		}
+		if len(only) > 0 {
+			metrics = slices.DeleteFunc(metrics, func(m store.AggregateMetric) bool {
+				return !slices.Contains(only, m.MetricName)
+			})
+		}
 		entries = append(entries, snapshotEntry{Snapshot: s, Metrics: metrics})
 	}
 
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(map[string]any{"history": entries})
+	return writeJSON(map[string]any{"history": entries})
 }
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/store"
+	"github.com/blackwell-systems/claudewatch/internal/suggest"
+)
+
+// suggestionDiff compares a snapshot's open suggestions with the previous
+// snapshot that recorded suggestions, matched by title.
+type suggestionDiff struct {
+	// Previous is the snapshot compared against, or nil when there is none
+	// and every open suggestion is new.
+	Previous *store.Snapshot `json:"previous,omitempty"`
+	// New holds open suggestions the previous snapshot didn't have open.
+	New []store.Suggestion `json:"new"`
+	// Resolved holds the previous snapshot's open suggestions that are no
+	// longer open.
+	Resolved []store.Suggestion `json:"resolved"`
+	// StillOpen holds open suggestions the previous snapshot also raised.
+	StillOpen      []store.Suggestion `json:"still_open"`
+	NewCount       int                `json:"new_count"`
+	ResolvedCount  int                `json:"resolved_count"`
+	StillOpenCount int                `json:"still_open_count"`
+}
+
+// diffSuggestions groups the open suggestions of previous and current into
+// new, resolved, and still open by title. Only open ones count on either
+// side, so a suggestion already resolved in previous isn't resolved again,
+// and one resolved by hand, which is marked in every snapshot, is no longer
+// an action item in either.
+func diffSuggestions(prevSnapshot *store.Snapshot, previous, current []store.Suggestion) *suggestionDiff {
+	d := &suggestionDiff{
+		Previous:  prevSnapshot,
+		New:       []store.Suggestion{},
+		Resolved:  []store.Suggestion{},
+		StillOpen: []store.Suggestion{},
+	}
+
+	open := make(map[string]bool, len(current))
+	for _, s := range current {
+		if s.Status == "open" {
+			open[s.Title] = true
+		}
+	}
+	before := make(map[string]bool, len(previous))
+	for _, s := range previous {
+		if s.Status != "open" || before[s.Title] {
+			continue
+		}
+		before[s.Title] = true
+		if !open[s.Title] {
+			d.Resolved = append(d.Resolved, s)
+		}
+	}
+	for _, s := range current {
+		if s.Status != "open" {
+			continue
+		}
+		if before[s.Title] {
+			d.StillOpen = append(d.StillOpen, s)
+		} else {
+			d.New = append(d.New, s)
+		}
+	}
+
+	d.NewCount, d.ResolvedCount, d.StillOpenCount = len(d.New), len(d.Resolved), len(d.StillOpen)
+	return d
+}
+
+// loadSuggestionDiff diffs current against the most recent whole-history
+// snapshot that recorded suggestions, ignoring the skip newest snapshots.
+func loadSuggestionDiff(db *store.DB, skip int, current []store.Suggestion) (*suggestionDiff, error) {
+	prev, previous, err := loadPreviousSuggestions(db, skip)
+	if err != nil {
+		return nil, err
+	}
+	return diffSuggestions(prev, previous, current), nil
+}
+
+// loadPreviousSuggestions returns the most recent whole-history snapshot that
+// recorded suggestions, ignoring the skip newest snapshots, and its
+// suggestions. The snapshot is nil when there is none.
+func loadPreviousSuggestions(db *store.DB, skip int) (*store.Snapshot, []store.Suggestion, error) {
+	prev, err := previousSnapshotWith(db, store.SectionSuggestions, 1, skip, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading previous snapshot: %w", err)
+	}
+	if prev == nil {
+		return nil, nil, nil
+	}
+	previous, err := db.GetSnapshotSuggestions(prev.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading previous suggestions: %w", err)
+	}
+	return prev, previous, nil
+}
+
+// pendingSuggestions converts the suggestions a dry run would record into
+// store rows, resolved where the user resolved them by hand, as a real run
+// would store them.
+func pendingSuggestions(db *store.DB, suggestions []suggest.Suggestion) ([]store.Suggestion, error) {
+	rows := make([]store.Suggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		manual, err := db.SuggestionResolvedManually(s.Category, s.Title)
+		if err != nil {
+			return nil, fmt.Errorf("checking suggestion status: %w", err)
+		}
+		status := "open"
+		if manual {
+			status = "resolved"
+		}
+		rows = append(rows, store.Suggestion{
+			Category:         s.Category,
+			Priority:         s.Priority,
+			Title:            s.Title,
+			Description:      s.Description,
+			ImpactScore:      s.ImpactScore,
+			Status:           status,
+			ResolvedManually: manual,
+		})
+	}
+	return rows, nil
+}
+
+// renderSuggestionDiff prints the new, resolved, and still open suggestions.
+func renderSuggestionDiff(d *suggestionDiff) {
+	fmt.Println()
+	fmt.Println(output.Section("Suggestion Changes"))
+	fmt.Println()
+	if d.Previous == nil {
+		fmt.Printf(" %s\n\n", output.StyleMuted.Render("No earlier snapshot recorded suggestions; every open one is new."))
+	} else {
+		fmt.Printf(" %s\n\n", output.StyleMuted.Render(fmt.Sprintf("Since snapshot #%d (%s)",
+			d.Previous.ID, d.Previous.TakenAt.Format("2006-01-02 15:04"))))
+	}
+
+	group := func(label string, style func(...string) string, suggestions []store.Suggestion) {
+		fmt.Printf(" %s\n", style(fmt.Sprintf("%s %d", label, len(suggestions))))
+		for _, s := range suggestions {
+			fmt.Printf("   %s %s\n", output.StyleMuted.Render("["+s.Category+"]"), s.Title)
+		}
+	}
+	group("New:", output.StyleWarning.Render, d.New)
+	group("Resolved:", output.StyleSuccess.Render, d.Resolved)
+	group("Still open:", output.StyleBold.Render, d.StillOpen)
+}
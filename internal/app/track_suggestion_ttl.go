@@ -0,0 +1,105 @@
+package app
+
+import (
+	"sort"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/store"
+	"github.com/blackwell-systems/claudewatch/internal/suggest"
+)
+
+// expireSuggestions marks the open suggestions that went unraised past ttl
+// as expired and returns how many it expired. It runs after the new
+// snapshot is stored, so suggestions that snapshot raised are never expired.
+func expireSuggestions(db *store.DB, ttl config.SuggestionTTL, now time.Time) (int, error) {
+	if ttl == (config.SuggestionTTL{}) {
+		return 0, nil
+	}
+	open, err := db.GetOpenSuggestions()
+	if err != nil {
+		return 0, err
+	}
+	snapshots, err := db.GetSuggestionSnapshots()
+	if err != nil {
+		return 0, err
+	}
+
+	expired := staleSuggestions(open, snapshots, ttl, now)
+	for _, s := range expired {
+		if err := db.ExpireSuggestion(&s); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+// previewExpiredSuggestions returns the suggestions a track run raising
+// current would expire, without changing db.
+func previewExpiredSuggestions(db *store.DB, ttl config.SuggestionTTL, current []suggest.Suggestion, now time.Time) ([]store.Suggestion, error) {
+	if ttl == (config.SuggestionTTL{}) {
+		return nil, nil
+	}
+	open, err := db.GetOpenSuggestions()
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := db.GetSuggestionSnapshots()
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+
+	// Stand in for the snapshot the run would record, which re-raises
+	// current.
+	raised := make(map[[2]string]bool, len(current))
+	for _, s := range current {
+		raised[[2]string{s.Category, s.Title}] = true
+	}
+	var pending []store.Suggestion
+	for _, s := range open {
+		if !raised[[2]string{s.Category, s.Title}] {
+			pending = append(pending, s)
+		}
+	}
+	next := store.Snapshot{ID: snapshots[len(snapshots)-1].ID + 1, TakenAt: now}
+	return staleSuggestions(pending, append(snapshots, next), ttl, now), nil
+}
+
+// staleSuggestions returns the latest open copy of each suggestion that the
+// ttl.Snapshots most recent snapshots all left out, or that was last raised
+// at least ttl.Days days before now. A zero limit is off. snapshots are the
+// snapshots that recorded suggestions, oldest first; a suggestion the latest
+// one raised is never stale.
+func staleSuggestions(open []store.Suggestion, snapshots []store.Snapshot, ttl config.SuggestionTTL, now time.Time) []store.Suggestion {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	latest := make(map[[2]string]store.Suggestion)
+	for _, s := range open {
+		k := [2]string{s.Category, s.Title}
+		if prev, ok := latest[k]; !ok || s.SnapshotID > prev.SnapshotID {
+			latest[k] = s
+		}
+	}
+
+	var stale []store.Suggestion
+	for _, s := range latest {
+		// The first snapshot after the one that last raised s.
+		i := sort.Search(len(snapshots), func(i int) bool { return snapshots[i].ID > s.SnapshotID })
+		missed := len(snapshots) - i
+		if missed == 0 {
+			continue
+		}
+		var lastRaised time.Time
+		if i > 0 && snapshots[i-1].ID == s.SnapshotID {
+			lastRaised = snapshots[i-1].TakenAt
+		}
+		tooMany := ttl.Snapshots > 0 && missed >= ttl.Snapshots
+		tooOld := ttl.Days > 0 && !lastRaised.IsZero() && now.Sub(lastRaised) >= time.Duration(ttl.Days)*24*time.Hour
+		if tooMany || tooOld {
+			stale = append(stale, s)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].ID < stale[j].ID })
+	return stale
+}
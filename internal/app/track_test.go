@@ -0,0 +1,688 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/store"
+	"github.com/blackwell-systems/claudewatch/internal/suggest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvableSuggestions(t *testing.T) {
+	open := []store.Suggestion{
+		{ID: 1, Category: "configuration", Title: "Add CLAUDE.md to alpha"},
+		{ID: 2, Category: "configuration", Title: "Add CLAUDE.md to beta"},
+		{ID: 3, Category: "friction", Title: "Address recurring friction"},
+		{ID: 4, Category: "agents", Title: "Improve agent success"},
+	}
+	ctx := &suggest.AnalysisContext{
+		Projects: []suggest.ProjectContext{
+			{Name: "alpha", HasClaudeMD: true},
+			{Name: "beta", HasClaudeMD: false},
+		},
+	}
+
+	resolved := resolvableSuggestions(open, ctx)
+	var ids []int64
+	for _, s := range resolved {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []int64{1, 3}, ids)
+
+	ctx.RecurringFriction = []string{"wrong_approach"}
+	resolved = resolvableSuggestions(open, ctx)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, int64(1), resolved[0].ID)
+}
+
+func TestSummarizeTrackSuggestions(t *testing.T) {
+	recorded := []store.Suggestion{
+		{ID: 1, Title: "a", ImpactScore: 9, Status: "open"},
+		{ID: 2, Title: "b", ImpactScore: 8, Status: "resolved"},
+		{ID: 3, Title: "c", ImpactScore: 5, Status: "open"},
+		{ID: 4, Title: "d", ImpactScore: 3, Status: "open"},
+		{ID: 5, Title: "e", ImpactScore: 1, Status: "open"},
+	}
+	s := &trackSuggestions{AutoResolved: 2}
+	summarizeTrackSuggestions(s, recorded)
+	assert.Equal(t, 4, s.Open)
+	assert.Equal(t, 2, s.AutoResolved)
+	require.Len(t, s.Top, trackTopSuggestions)
+	assert.Equal(t, []string{"a", "c", "d"}, []string{s.Top[0].Title, s.Top[1].Title, s.Top[2].Title})
+
+	empty := &trackSuggestions{}
+	summarizeTrackSuggestions(empty, nil)
+	assert.Zero(t, empty.Open)
+	assert.NotNil(t, empty.Top, "top should encode as [] rather than null")
+}
+
+func TestDiffSuggestions(t *testing.T) {
+	prev := &store.Snapshot{ID: 1}
+	previous := []store.Suggestion{
+		{Title: "Add CLAUDE.md to alpha", Status: "open"},
+		{Title: "Reduce tool errors", Status: "open"},
+		{Title: "Configure hooks", Status: "resolved", ResolvedManually: true},
+	}
+	current := []store.Suggestion{
+		{Title: "Add CLAUDE.md to alpha", Status: "resolved"},
+		{Title: "Reduce tool errors", Status: "open"},
+		{Title: "Use task agents", Status: "open"},
+		{Title: "Configure hooks", Status: "resolved", ResolvedManually: true},
+	}
+
+	d := diffSuggestions(prev, previous, current)
+	assert.Equal(t, prev, d.Previous)
+	titles := func(s []store.Suggestion) []string {
+		out := []string{}
+		for _, sg := range s {
+			out = append(out, sg.Title)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"Use task agents"}, titles(d.New))
+	assert.Equal(t, []string{"Add CLAUDE.md to alpha"}, titles(d.Resolved))
+	assert.Equal(t, []string{"Reduce tool errors"}, titles(d.StillOpen))
+	assert.Equal(t, [3]int{1, 1, 1}, [3]int{d.NewCount, d.ResolvedCount, d.StillOpenCount})
+
+	first := diffSuggestions(nil, nil, current)
+	assert.Equal(t, 2, first.NewCount)
+	assert.NotNil(t, first.Resolved, "resolved should encode as [] rather than null")
+}
+
+func TestDiffSuggestions_ComparesOpenStatuses(t *testing.T) {
+	previous := []store.Suggestion{
+		{Title: "Already resolved", Status: "resolved"},
+		{Title: "Reopened", Status: "resolved"},
+	}
+	current := []store.Suggestion{
+		{Title: "Already resolved", Status: "resolved"},
+		{Title: "Reopened", Status: "open"},
+	}
+
+	d := diffSuggestions(&store.Snapshot{ID: 1}, previous, current)
+	assert.Empty(t, d.Resolved, "a suggestion resolved in the previous snapshot is not resolved again")
+	require.Len(t, d.New, 1)
+	assert.Equal(t, "Reopened", d.New[0].Title, "a suggestion open again after being resolved is new")
+	assert.Empty(t, d.StillOpen)
+}
+
+func TestLoadSuggestionDiff_SkipsSnapshotsWithoutSuggestions(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	withSuggestions, err := db.CreateSnapshot("track", "v1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, db.InsertSuggestion(&store.Suggestion{
+		SnapshotID: withSuggestions, Category: "friction", Title: "Address recurring friction", Status: "open",
+	}))
+	_, err = db.CreateSnapshot("track", "v1.0.0", store.SectionMetrics)
+	require.NoError(t, err)
+
+	pending, err := pendingSuggestions(db, []suggest.Suggestion{{Category: "agents", Title: "Use task agents"}})
+	require.NoError(t, err)
+	d, err := loadSuggestionDiff(db, 0, pending)
+	require.NoError(t, err)
+	require.NotNil(t, d.Previous)
+	assert.Equal(t, withSuggestions, d.Previous.ID)
+	assert.Equal(t, 1, d.NewCount)
+	assert.Equal(t, 1, d.ResolvedCount)
+
+	// Should not panic.
+	renderSuggestionDiff(d)
+}
+
+func TestBuildSnapshotRecord(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	// A suggestion the user resolved by hand stays resolved.
+	earlier, err := db.CreateSnapshot("track", "v1.0.0")
+	require.NoError(t, err)
+	resolved := &store.Suggestion{SnapshotID: earlier, Category: "agents", Title: "Use task agents", Status: "open"}
+	require.NoError(t, db.InsertSuggestion(resolved))
+	stored, err := db.GetSnapshotSuggestions(earlier)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	require.NoError(t, db.ResolveSuggestionManually(&stored[0]))
+
+	facets := []claude.SessionFacet{{SessionID: "s1", FrictionCounts: map[string]int{"wrong_approach": 2}}}
+	tasks := []claude.AgentTask{{SessionID: "s1", AgentID: "a1", AgentType: "Explore"}}
+	suggestions := []suggest.Suggestion{
+		{Category: "agents", Title: "Use task agents"},
+		{Category: "friction", Title: "Reduce errors"},
+	}
+
+	rec, err := buildSnapshotRecord(db, "/code/api", []string{store.SectionMetrics, store.SectionSuggestions}, nil,
+		map[string]float64{"total_sessions": 4}, facets, tasks, suggestions)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/code/api", rec.Project)
+	require.Len(t, rec.AggregateMetrics, 1)
+	assert.Equal(t, metricUnits["total_sessions"], rec.AggregateMetrics[0].Unit)
+	assert.Empty(t, rec.FrictionEvents, "friction isn't a recorded section")
+	assert.Empty(t, rec.AgentTasks, "agents isn't a recorded section")
+	require.Len(t, rec.Suggestions, 2)
+	assert.Equal(t, "resolved", rec.Suggestions[0].Status)
+	assert.True(t, rec.Suggestions[0].ResolvedManually)
+	assert.Equal(t, "open", rec.Suggestions[1].Status)
+}
+
+func TestBuildSnapshotRecord_FailedRecordLeavesNoSnapshot(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	// Fail the agent task insert, after the snapshot row, metrics, and
+	// friction events of the track run are in.
+	_, err = db.Conn().Exec(`CREATE TRIGGER fail_agents BEFORE INSERT ON agent_tasks
+		BEGIN SELECT RAISE(ABORT, 'injected failure'); END`)
+	require.NoError(t, err)
+
+	facets := []claude.SessionFacet{{SessionID: "s1", FrictionCounts: map[string]int{"wrong_approach": 2}}}
+	tasks := []claude.AgentTask{{SessionID: "s1", AgentID: "a1", AgentType: "Explore"}}
+	rec, err := buildSnapshotRecord(db, "", store.SnapshotSections, nil,
+		map[string]float64{"total_sessions": 4}, facets, tasks, []suggest.Suggestion{{Category: "friction", Title: "Reduce errors"}})
+	require.NoError(t, err)
+
+	_, err = db.RecordSnapshot(rec)
+	require.ErrorContains(t, err, "injected failure")
+
+	snapshots, err := db.GetRecentSnapshots(10)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots, "a failed track run must not leave a partial snapshot")
+	open, err := db.GetOpenSuggestions()
+	require.NoError(t, err)
+	assert.Empty(t, open)
+}
+
+func TestPreviewTrack_NoPreviousSnapshot(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	metrics := map[string]float64{"total_sessions": 4, "avg_tool_errors": 1.5}
+	suggestions := []suggest.Suggestion{{Category: "friction", Title: "Reduce errors"}}
+
+	preview, err := previewTrack(db, 1, "", metrics, suggestions, &suggest.AnalysisContext{})
+	require.NoError(t, err)
+
+	assert.True(t, preview.DryRun)
+	assert.Nil(t, preview.Previous)
+	assert.Empty(t, preview.Deltas)
+	assert.Empty(t, preview.WouldResolve)
+	assert.Equal(t, suggestions, preview.Suggestions)
+	require.Len(t, preview.Metrics, 2)
+	assert.Equal(t, "avg_tool_errors", preview.Metrics[0].MetricName)
+	assert.Equal(t, "total_sessions", preview.Metrics[1].MetricName)
+}
+
+func TestPreviewTrack_ComparesWithoutWriting(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	prevID, err := db.CreateSnapshot("track", "v1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, db.InsertAggregateMetric(prevID, "total_sessions", 2, ""))
+	require.NoError(t, db.InsertAggregateMetric(prevID, "avg_tool_errors", 3, ""))
+	require.NoError(t, db.InsertSuggestion(&store.Suggestion{
+		SnapshotID: prevID, Category: "friction", Title: "Address recurring friction", Status: "open",
+	}))
+
+	metrics := map[string]float64{"total_sessions": 5, "avg_tool_errors": 1}
+	preview, err := previewTrack(db, 1, "", metrics, nil, &suggest.AnalysisContext{})
+	require.NoError(t, err)
+
+	require.NotNil(t, preview.Previous)
+	assert.Equal(t, prevID, preview.Previous.ID)
+
+	byName := make(map[string]store.MetricDelta)
+	for _, d := range preview.Deltas {
+		byName[d.Name] = d
+	}
+	assert.Equal(t, 3.0, byName["total_sessions"].Delta)
+	assert.Equal(t, "improved", byName["total_sessions"].Direction)
+	assert.Equal(t, -2.0, byName["avg_tool_errors"].Delta)
+	assert.Equal(t, "improved", byName["avg_tool_errors"].Direction)
+	assert.Equal(t, "count", byName["total_sessions"].Unit)
+
+	require.Len(t, preview.WouldResolve, 1)
+	assert.Equal(t, "Address recurring friction", preview.WouldResolve[0].Title)
+
+	// Nothing was written: no new snapshot, and the suggestion is still open.
+	snapshots, err := db.GetRecentSnapshots(10)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	open, err := db.GetOpenSuggestions()
+	require.NoError(t, err)
+	assert.Len(t, open, 1)
+
+	// Should not panic.
+	renderTrackPreview(preview)
+}
+
+func TestCompareUnstored_ComparesWithoutWriting(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	prevID, err := db.CreateSnapshot("track", "v1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, db.InsertAggregateMetric(prevID, "total_sessions", 2, ""))
+	require.NoError(t, db.InsertSuggestion(&store.Suggestion{
+		SnapshotID: prevID, Category: "friction", Title: "Address recurring friction", Status: "open",
+	}))
+
+	metrics := map[string]float64{"total_sessions": 5}
+	rec, err := buildSnapshotRecord(db, "", store.SnapshotSections, nil, metrics, nil, nil,
+		[]suggest.Suggestion{{Category: "agents", Title: "Improve agent success"}})
+	require.NoError(t, err)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	current, diff, summary, err := compareUnstored(db, 1, rec, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), current.ID)
+	assert.Equal(t, now, current.TakenAt)
+	require.NotNil(t, diff)
+	assert.Equal(t, prevID, diff.Previous.ID)
+	require.Len(t, diff.Deltas, 1)
+	assert.Equal(t, 3.0, diff.Deltas[0].Delta)
+	require.NotNil(t, summary)
+	assert.Equal(t, 1, summary.Open)
+	assert.Zero(t, summary.AutoResolved)
+
+	// Nothing was written, and the earlier suggestion is still open.
+	snapshots, err := db.GetRecentSnapshots(10)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	open, err := db.GetOpenSuggestions()
+	require.NoError(t, err)
+	assert.Len(t, open, 1)
+
+	// Should not panic.
+	renderTrackOutput(current, diff)
+}
+
+func TestCompareUnstored_NoStoredSnapshot(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	rec, err := buildSnapshotRecord(db, "", []string{store.SectionMetrics}, nil, map[string]float64{"total_sessions": 5}, nil, nil, nil)
+	require.NoError(t, err)
+
+	current, diff, summary, err := compareUnstored(db, 1, rec, time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+	assert.Nil(t, summary, "suggestions weren't recorded")
+
+	// Should not panic.
+	renderTrackOutput(current, diff)
+}
+
+func TestTrackOutputFormat(t *testing.T) {
+	for _, tc := range []struct {
+		format   string
+		jsonFlag bool
+		want     string
+	}{
+		{"table", false, "table"},
+		{"Markdown", false, "markdown"},
+		{"csv", false, "csv"},
+		{"table", true, "json"},
+		{"json", true, "json"},
+	} {
+		got, err := trackOutputFormat(tc.format, tc.jsonFlag)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, "format %q json=%v", tc.format, tc.jsonFlag)
+	}
+
+	_, err := trackOutputFormat("yaml", false)
+	assert.Error(t, err)
+	_, err = trackOutputFormat("csv", true)
+	assert.Error(t, err)
+}
+
+func TestWriteTrackMarkdown(t *testing.T) {
+	prev := &store.Snapshot{ID: 3, TakenAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)}
+	deltas := computeDeltas(
+		[]store.AggregateMetric{{MetricName: "total_sessions", MetricValue: 4}, {MetricName: "avg_tool_errors", MetricValue: 2}},
+		[]store.AggregateMetric{{MetricName: "total_sessions", MetricValue: 6}, {MetricName: "avg_tool_errors", MetricValue: 2}},
+	)
+
+	var buf bytes.Buffer
+	writeTrackMarkdown(&buf, "Snapshot #4", prev, nil, deltas)
+	assert.Equal(t, `## Snapshot #4
+
+Compared with snapshot #3 (2026-03-01 09:30).
+
+| Metric | Previous | Current | Delta | Trend |
+| --- | --- | --- | --- | --- |
+| total_sessions | 4.0 | 6.0 | +2.0 | ↑ |
+| avg_tool_errors | 2.0 | 2.0 | +0.0 | → |
+`, buf.String())
+
+	buf.Reset()
+	writeTrackMarkdown(&buf, "Snapshot #1", nil, []store.AggregateMetric{{MetricName: "total_sessions", MetricValue: 4}}, nil)
+	assert.Contains(t, buf.String(), "No previous snapshot")
+	assert.Contains(t, buf.String(), "| total_sessions | 4.0 |")
+}
+
+func TestComputeDeltas_NoiseBand(t *testing.T) {
+	prev := []store.AggregateMetric{
+		{MetricName: "avg_duration_minutes", MetricValue: 40},
+		{MetricName: "satisfaction_score", MetricValue: 70},
+		{MetricName: "avg_tool_errors", MetricValue: 2},
+		{MetricName: "agent_success_rate", MetricValue: 80},
+	}
+	curr := []store.AggregateMetric{
+		{MetricName: "avg_duration_minutes", MetricValue: 41.5}, // under 5% of 40
+		{MetricName: "satisfaction_score", MetricValue: 70.5},   // under 1 point
+		{MetricName: "avg_tool_errors", MetricValue: 2.5},       // over 0.1 and 5%
+		{MetricName: "agent_success_rate", MetricValue: 85},     // over 2 points
+	}
+
+	want := map[string]string{
+		"avg_duration_minutes": "unchanged",
+		"satisfaction_score":   "unchanged",
+		"avg_tool_errors":      "regressed",
+		"agent_success_rate":   "improved",
+	}
+	for _, d := range computeDeltas(prev, curr) {
+		assert.Equal(t, want[d.Name], d.Direction, d.Name)
+		assert.NotZero(t, d.Delta, "%s keeps its raw delta", d.Name)
+	}
+}
+
+func TestApplyNoiseBands(t *testing.T) {
+	defer func() { metricNoise = defaultMetricNoise }()
+
+	require.NoError(t, applyNoiseBands(&config.Config{NoiseBands: map[string]config.NoiseBand{
+		"avg_tool_errors": {Abs: 1},
+	}}))
+	assert.Zero(t, significantDelta("avg_tool_errors", 2, 0.5))
+	assert.Equal(t, 1.5, significantDelta("avg_tool_errors", 2, 1.5))
+	assert.Equal(t, 5.0, significantDelta("avg_duration_minutes", 40, 5), "other metrics keep their defaults")
+	assert.Zero(t, significantDelta("avg_duration_minutes", 40, 1.5))
+	assert.Equal(t, defaultMetricNoise["avg_tool_errors"], config.NoiseBand{Abs: 0.1, Pct: 5}, "defaults are not modified")
+
+	err := applyNoiseBands(&config.Config{NoiseBands: map[string]config.NoiseBand{"avg_tool_eror": {Abs: 1}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "avg_tool_eror")
+}
+
+func TestWriteTrackCSV(t *testing.T) {
+	deltas := computeDeltas(
+		[]store.AggregateMetric{{MetricName: "avg_tool_errors", MetricValue: 2}},
+		[]store.AggregateMetric{{MetricName: "avg_tool_errors", MetricValue: 3.5}},
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTrackCSV(&buf, nil, deltas))
+	assert.Equal(t, "metric,previous,current,delta,direction\navg_tool_errors,2.00,3.50,1.50,regressed\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeTrackCSV(&buf, []store.AggregateMetric{{MetricName: "total_sessions", MetricValue: 4}}, nil))
+	assert.Equal(t, "metric,previous,current,delta,direction\ntotal_sessions,,4.00,,\n", buf.String())
+}
+
+func TestWriteHistoryFormats(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	for _, sessions := range []float64{2, 5} {
+		id, err := db.CreateSnapshot("track", "v1.0.0")
+		require.NoError(t, err)
+		require.NoError(t, db.InsertAggregateMetric(id, "total_sessions", sessions, ""))
+		require.NoError(t, db.InsertAggregateMetric(id, "avg_tool_errors", 1, ""))
+	}
+
+	timeline, err := loadHistory(db, 10, "")
+	require.NoError(t, err)
+	require.Len(t, timeline, 2)
+	assert.Less(t, timeline[0].snapshot.ID, timeline[1].snapshot.ID, "timeline should be oldest first")
+
+	var buf bytes.Buffer
+	writeHistoryMarkdown(&buf, timeline, metricDisplayOrder)
+	assert.Contains(t, buf.String(), "| Sessions | 2.0 | 5.0 | ↑ |")
+	assert.Contains(t, buf.String(), "| Avg Tool Errors | 1.0 | 1.0 | → |")
+
+	buf.Reset()
+	require.NoError(t, writeHistoryCSV(&buf, timeline, metricDisplayOrder))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, len(metricDisplayOrder)+1)
+	assert.True(t, strings.HasPrefix(lines[0], "metric,#"))
+	assert.True(t, strings.HasSuffix(lines[0], ",direction"))
+	assert.Equal(t, "total_sessions,2.00,5.00,improved", lines[1])
+}
+
+func TestResolveHistoryMetrics(t *testing.T) {
+	all, err := resolveHistoryMetrics(nil)
+	require.NoError(t, err)
+	assert.Equal(t, metricDisplayOrder, all)
+
+	// Raw and short names, any case, come back deduplicated in display order.
+	got, err := resolveHistoryMetrics([]string{"avg_tool_errors", "friction events", "AVG_TOOL_ERRORS"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"total_friction_events", "avg_tool_errors"}, got)
+
+	_, err = resolveHistoryMetrics([]string{"frictoin"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --metric "frictoin"`)
+	assert.Contains(t, err.Error(), "total_friction_events")
+}
+
+func TestWriteHistoryCSV_MetricFilter(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	id, err := db.CreateSnapshot("track", "v1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, db.InsertAggregateMetric(id, "total_friction_events", 7, ""))
+
+	timeline, err := loadHistory(db, 10, "")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeHistoryCSV(&buf, timeline, []string{"total_friction_events"}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "total_friction_events,7.00,", lines[1])
+}
+
+func TestFormatUnitValue(t *testing.T) {
+	for _, tc := range []struct {
+		unit  string
+		value float64
+		want  string
+		delta string
+	}{
+		{"minutes", 42, "42.0 min", "+42.0 min"},
+		{"percent", 85, "85%", "+85%"},
+		{"percent", 66.67, "66.7%", "+66.7%"},
+		{"dollars", -1.234, "-$1.23", "-$1.23"},
+		{"count", 3, "3.0", "+3.0"},
+		{"", -2.5, "-2.5", "-2.5"},
+	} {
+		assert.Equal(t, tc.want, formatUnitValue(tc.unit, tc.value), "%s %v", tc.unit, tc.value)
+		assert.Equal(t, tc.delta, formatUnitDelta(tc.unit, tc.value), "%s %v delta", tc.unit, tc.value)
+	}
+}
+
+func TestMetricUnits_CoverAggregateMetrics(t *testing.T) {
+	metrics := buildAggregateMetrics(analyzer.FrictionSummary{}, analyzer.VelocityMetrics{},
+		analyzer.SatisfactionScore{}, analyzer.EfficiencyMetrics{}, analyzer.AgentPerformance{})
+	for name := range metrics {
+		if name == "satisfaction_score" {
+			continue
+		}
+		assert.Contains(t, metricUnits, name)
+	}
+	assert.Equal(t, "minutes", metricUnits["avg_duration_minutes"])
+	assert.Equal(t, "percent", metricUnits["agent_success_rate"])
+}
+
+func TestResolveTrackSections(t *testing.T) {
+	all, err := resolveTrackSections(nil)
+	require.NoError(t, err)
+	assert.Equal(t, store.SnapshotSections, all)
+
+	got, err := resolveTrackSections([]string{"suggestions", " Metrics", "metrics"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{store.SectionMetrics, store.SectionSuggestions}, got)
+
+	_, err = resolveTrackSections([]string{"tokens"})
+	assert.ErrorContains(t, err, `unknown --only section "tokens"`)
+}
+
+func TestPreviousMetricsSnapshot_SkipsPartialSnapshots(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	withMetrics, err := db.CreateSnapshot("track", "v1.0.0")
+	require.NoError(t, err)
+	_, err = db.CreateSnapshot("track", "v1.0.0", store.SectionFriction)
+	require.NoError(t, err)
+	current, err := db.CreateSnapshot("track", "v1.0.0", store.SectionMetrics)
+	require.NoError(t, err)
+
+	prev, err := previousMetricsSnapshot(db, 1, 0, "")
+	require.NoError(t, err)
+	require.NotNil(t, prev)
+	assert.Equal(t, current, prev.ID)
+
+	prev, err = previousMetricsSnapshot(db, 1, 1, "")
+	require.NoError(t, err)
+	require.NotNil(t, prev)
+	assert.Equal(t, withMetrics, prev.ID)
+
+	prev, err = previousMetricsSnapshot(db, 2, 1, "")
+	require.NoError(t, err)
+	assert.Nil(t, prev)
+}
+
+func TestPreviousMetricsSnapshot_MatchesProject(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	all, err := db.CreateSnapshot("track", "v1.0.0")
+	require.NoError(t, err)
+	api, err := db.CreateProjectSnapshot("track", "v1.0.0", "/code/api")
+	require.NoError(t, err)
+	_, err = db.CreateProjectSnapshot("track", "v1.0.0", "/code/web")
+	require.NoError(t, err)
+
+	prev, err := previousMetricsSnapshot(db, 1, 0, "")
+	require.NoError(t, err)
+	require.NotNil(t, prev)
+	assert.Equal(t, all, prev.ID)
+
+	prev, err = previousMetricsSnapshot(db, 1, 0, "/code/api")
+	require.NoError(t, err)
+	require.NotNil(t, prev)
+	assert.Equal(t, api, prev.ID)
+
+	prev, err = previousMetricsSnapshot(db, 1, 0, "/code/cli")
+	require.NoError(t, err)
+	assert.Nil(t, prev)
+}
+
+func TestPreviewTrack_WithoutMetrics(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	prevID, err := db.CreateSnapshot("track", "v1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, db.InsertAggregateMetric(prevID, "total_sessions", 2, ""))
+
+	preview, err := previewTrack(db, 1, "", nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, preview.Previous)
+	assert.Empty(t, preview.Deltas)
+	assert.Empty(t, preview.Metrics)
+	assert.Empty(t, preview.WouldResolve)
+}
+
+func TestStaleSuggestions(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	snapshots := []store.Snapshot{
+		{ID: 1, TakenAt: now.AddDate(0, 0, -20)},
+		{ID: 3, TakenAt: now.AddDate(0, 0, -10)},
+		{ID: 4, TakenAt: now.AddDate(0, 0, -5)},
+		{ID: 6, TakenAt: now},
+	}
+	open := []store.Suggestion{
+		{ID: 1, SnapshotID: 1, Category: "friction", Title: "Old friction"},
+		{ID: 2, SnapshotID: 3, Category: "friction", Title: "Old friction"},
+		{ID: 3, SnapshotID: 1, Category: "agents", Title: "Ancient agents"},
+		{ID: 4, SnapshotID: 6, Category: "configuration", Title: "Still raised"},
+	}
+	titles := func(s []store.Suggestion) []string {
+		out := []string{}
+		for _, sg := range s {
+			out = append(out, sg.Title)
+		}
+		return out
+	}
+
+	// Old friction was last raised 2 snapshots and 10 days ago, Ancient
+	// agents 3 snapshots and 20 days ago.
+	assert.Equal(t, []string{"Old friction", "Ancient agents"},
+		titles(staleSuggestions(open, snapshots, config.SuggestionTTL{Snapshots: 2}, now)))
+	assert.Equal(t, []string{"Ancient agents"},
+		titles(staleSuggestions(open, snapshots, config.SuggestionTTL{Snapshots: 3}, now)))
+	assert.Equal(t, []string{"Ancient agents"},
+		titles(staleSuggestions(open, snapshots, config.SuggestionTTL{Days: 15}, now)))
+	assert.Equal(t, []string{"Ancient agents"},
+		titles(staleSuggestions(open, snapshots, config.SuggestionTTL{Snapshots: 5, Days: 15}, now)))
+	assert.Empty(t, staleSuggestions(open, snapshots, config.SuggestionTTL{}, now))
+	assert.Empty(t, staleSuggestions(open, nil, config.SuggestionTTL{Snapshots: 1}, now))
+}
+
+func TestExpireSuggestions(t *testing.T) {
+	db, err := store.OpenInMemory()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ttl := config.SuggestionTTL{Snapshots: 1}
+	for _, titles := range [][]string{{"Lingering", "Recurring"}, {"Recurring"}} {
+		id, err := db.CreateSnapshot("track", "v1.0.0")
+		require.NoError(t, err)
+		for _, title := range titles {
+			require.NoError(t, db.InsertSuggestion(&store.Suggestion{SnapshotID: id, Category: "friction", Title: title, Status: "open"}))
+		}
+	}
+
+	// A run raising only Lingering would keep it open and expire Recurring.
+	wouldExpire, err := previewExpiredSuggestions(db, ttl, []suggest.Suggestion{{Category: "friction", Title: "Lingering"}}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, wouldExpire, 1)
+	assert.Equal(t, "Recurring", wouldExpire[0].Title)
+
+	expired, err := expireSuggestions(db, ttl, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	history, err := db.GetSuggestionHistory()
+	require.NoError(t, err)
+	status := make(map[string]string)
+	for _, h := range history {
+		status[h.Title] = h.Status
+	}
+	assert.Equal(t, map[string]string{"Lingering": "expired", "Recurring": "open"}, status)
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trendsDays   int
+	trendsWeeks  int
+	trendsMetric string
+	trendsWindow int
+	trendsASCII  bool
+)
+
+// Chart heights: small multiples for every metric, a larger chart for one.
+const (
+	trendsChartHeight       = 5
+	trendsSingleChartHeight = 14
+)
+
+var trendsCmd = &cobra.Command{
+	Use:   "trends",
+	Short: "Chart metrics across track snapshots over time",
+	Long: `Render a line chart per metric across every snapshot recorded by
+'claudewatch track' within the horizon, with a moving average drawn beneath
+each line to smooth out snapshot-to-snapshot noise.
+
+--metric charts a single metric at a larger size; it takes the raw metric
+name or the label shown by 'track --history'. Terminals without a UTF-8
+locale get a plain '*' chart; --ascii forces it. --json emits each metric's
+values and moving average as arrays, aligned with the snapshot list.
+
+Examples:
+  claudewatch trends                           # every metric, last 90 days
+  claudewatch trends --weeks 26                # last six months
+  claudewatch trends --metric satisfaction_score
+  claudewatch trends --days 0 --window 5       # all snapshots, smoother line`,
+	RunE: runTrends,
+}
+
+func init() {
+	trendsCmd.Flags().IntVar(&trendsDays, "days", 90, "Horizon in days (0 for every snapshot)")
+	trendsCmd.Flags().IntVar(&trendsWeeks, "weeks", 0, "Horizon in weeks, instead of --days")
+	trendsCmd.Flags().StringVar(&trendsMetric, "metric", "", "Chart only this metric, at a larger size (raw or short name)")
+	trendsCmd.Flags().IntVar(&trendsWindow, "window", 3, "Snapshots in the moving average")
+	trendsCmd.Flags().BoolVar(&trendsASCII, "ascii", false, "Draw charts with ASCII characters only")
+	trendsCmd.Flags().BoolVar(&flagJSON, "json", false, "Output as JSON")
+	trendsCmd.MarkFlagsMutuallyExclusive("days", "weeks")
+	rootCmd.AddCommand(trendsCmd)
+}
+
+// trendsOutput is the JSON form of trends. Each series has one value per
+// snapshot, in snapshot order.
+type trendsOutput struct {
+	Days      int              `json:"days"`
+	Window    int              `json:"window"`
+	Snapshots []store.Snapshot `json:"snapshots"`
+	Series    []trendSeries    `json:"series"`
+}
+
+// trendSeries is one metric's values over time and their trailing moving
+// average.
+type trendSeries struct {
+	Metric        string    `json:"metric"`
+	Label         string    `json:"label"`
+	Unit          string    `json:"unit,omitempty"`
+	Values        []float64 `json:"values"`
+	MovingAverage []float64 `json:"moving_average"`
+}
+
+func runTrends(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	if trendsWindow < 1 {
+		return fmt.Errorf("--window must be at least 1")
+	}
+	days := trendsDays
+	if cmd.Flags().Changed("weeks") {
+		if trendsWeeks < 1 {
+			return fmt.Errorf("--weeks must be at least 1")
+		}
+		days = trendsWeeks * 7
+	}
+	names := metricDisplayOrder
+	if trendsMetric != "" {
+		if names, err = resolveHistoryMetrics([]string{trendsMetric}); err != nil {
+			return err
+		}
+	}
+
+	var timeline []historyPoint
+	// Reading trends must not create a database.
+	if _, statErr := os.Stat(config.DBPath()); statErr == nil {
+		db, err := store.Open(config.DBPath())
+		if err != nil {
+			return fmt.Errorf("opening database: %w", err)
+		}
+		defer func() { _ = db.Close() }()
+
+		var since time.Time
+		if days > 0 {
+			since = time.Now().AddDate(0, 0, -days)
+		}
+		snapshots, err := db.GetSnapshotsSince(since)
+		if err != nil {
+			return fmt.Errorf("loading snapshots: %w", err)
+		}
+		// Trends follow whole-history snapshots, not track --project ones.
+		snapshots = slices.DeleteFunc(snapshots, func(s store.Snapshot) bool { return s.Project != "" })
+		if timeline, err = loadTimeline(db, snapshots); err != nil {
+			return err
+		}
+	}
+
+	out := buildTrends(timeline, names, trendsWindow)
+	out.Days = days
+	if flagJSON {
+		return writeJSON(out)
+	}
+
+	ascii := trendsASCII || !output.SupportsUnicode()
+	renderTrends(anonymized(out), cfg.Output.Width, ascii)
+	return nil
+}
+
+// buildTrends extracts the named metrics from timeline as series.
+func buildTrends(timeline []historyPoint, names []string, window int) trendsOutput {
+	out := trendsOutput{
+		Window:    window,
+		Snapshots: make([]store.Snapshot, 0, len(timeline)),
+		Series:    make([]trendSeries, 0, len(names)),
+	}
+	for _, p := range timeline {
+		out.Snapshots = append(out.Snapshots, p.snapshot)
+	}
+	for _, name := range names {
+		values := make([]float64, 0, len(timeline))
+		for _, p := range timeline {
+			values = append(values, p.metrics[name])
+		}
+		out.Series = append(out.Series, trendSeries{
+			Metric:        name,
+			Label:         metricShortName(name),
+			Unit:          metricUnits[name],
+			Values:        values,
+			MovingAverage: movingAverage(values, window),
+		})
+	}
+	return out
+}
+
+// movingAverage returns the trailing moving average of values over window
+// points. The first points average over as many as are available.
+func movingAverage(values []float64, window int) []float64 {
+	avg := make([]float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		avg[i] = sum / float64(min(i+1, window))
+	}
+	return avg
+}
+
+// renderTrends draws one chart per series, sized to fit width columns.
+func renderTrends(out trendsOutput, width int, ascii bool) {
+	fmt.Println(output.Section("Trends"))
+	fmt.Println()
+
+	if len(out.Snapshots) == 0 {
+		fmt.Println(" No snapshots in range. Run 'claudewatch track' to record one.")
+		return
+	}
+
+	horizon := "all snapshots"
+	if out.Days > 0 {
+		horizon = fmt.Sprintf("last %d days", out.Days)
+	}
+	fmt.Printf(" %s\n\n", output.StyleMuted.Render(fmt.Sprintf("%d snapshots, %s; moving average over %d", len(out.Snapshots), horizon, out.Window)))
+
+	height := trendsChartHeight
+	if len(out.Series) == 1 {
+		height = trendsSingleChartHeight
+	}
+	// Leave room for the y-axis labels.
+	chartWidth := max(width-16, 20)
+
+	start := out.Snapshots[0].TakenAt.Local().Format("Jan 02")
+	end := ""
+	if len(out.Snapshots) > 1 {
+		end = out.Snapshots[len(out.Snapshots)-1].TakenAt.Local().Format("Jan 02")
+	}
+	for _, s := range out.Series {
+		latest := s.Values[len(s.Values)-1]
+		header := fmt.Sprintf(" %s %s", output.StyleBold.Render(s.Label), formatUnitValue(s.Unit, latest))
+		if len(s.Values) > 1 {
+			higherIsBetter, known := metricDirection[s.Metric]
+			if !known {
+				higherIsBetter = true
+			}
+			header += " " + output.TrendArrow(significantDelta(s.Metric, s.Values[0], latest-s.Values[0]), higherIsBetter)
+		}
+		fmt.Println(header)
+
+		lines := output.LineChart(s.Values, s.MovingAverage, output.ChartOptions{
+			Width:      chartWidth,
+			Height:     height,
+			ASCII:      ascii,
+			FormatY:    func(v float64) string { return formatUnitValue(s.Unit, v) },
+			StartLabel: start,
+			EndLabel:   end,
+		})
+		for _, line := range lines {
+			fmt.Printf(" %s\n", line)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/store"
+)
+
+func TestMovingAverage(t *testing.T) {
+	got := movingAverage([]float64{2, 4, 6, 8}, 2)
+	want := []float64{2, 3, 5, 7}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("movingAverage = %v, want %v", got, want)
+		}
+	}
+
+	got = movingAverage([]float64{1, 2, 3}, 1)
+	if got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("expected a window of 1 to return the values, got %v", got)
+	}
+}
+
+func TestBuildTrends_SeriesAlignWithSnapshots(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	timeline := []historyPoint{
+		{snapshot: store.Snapshot{ID: 1, TakenAt: base}, metrics: map[string]float64{"total_sessions": 10, "avg_tool_errors": 3}},
+		{snapshot: store.Snapshot{ID: 2, TakenAt: base.AddDate(0, 0, 7)}, metrics: map[string]float64{"total_sessions": 14}},
+		{snapshot: store.Snapshot{ID: 3, TakenAt: base.AddDate(0, 0, 14)}, metrics: map[string]float64{"total_sessions": 18, "avg_tool_errors": 1}},
+	}
+
+	out := buildTrends(timeline, []string{"total_sessions", "avg_tool_errors"}, 3)
+	if len(out.Snapshots) != 3 || out.Snapshots[2].ID != 3 {
+		t.Fatalf("unexpected snapshots: %+v", out.Snapshots)
+	}
+	if len(out.Series) != 2 {
+		t.Fatalf("expected 2 series, got %d", len(out.Series))
+	}
+
+	sessions := out.Series[0]
+	if sessions.Label != "Sessions" || sessions.Unit != unitCount {
+		t.Errorf("unexpected series metadata: %+v", sessions)
+	}
+	if len(sessions.Values) != 3 || sessions.Values[2] != 18 {
+		t.Errorf("unexpected values: %v", sessions.Values)
+	}
+	if sessions.MovingAverage[2] != 14 {
+		t.Errorf("expected a 3-point average of 14, got %v", sessions.MovingAverage[2])
+	}
+
+	// A metric missing from a snapshot is charted as 0 there.
+	if errs := out.Series[1].Values; errs[1] != 0 {
+		t.Errorf("expected missing metric as 0, got %v", errs)
+	}
+}
+
+func TestBuildTrends_NoSnapshots(t *testing.T) {
+	out := buildTrends(nil, metricDisplayOrder, 3)
+	if out.Snapshots == nil || len(out.Snapshots) != 0 {
+		t.Errorf("expected an empty, non-nil snapshot list, got %v", out.Snapshots)
+	}
+	for _, s := range out.Series {
+		if s.Values == nil || len(s.Values) != 0 {
+			t.Errorf("expected empty values for %s, got %v", s.Metric, s.Values)
+		}
+	}
+}
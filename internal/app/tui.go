@@ -0,0 +1,239 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/suggest"
+	"github.com/blackwell-systems/claudewatch/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tuiFlagDays    int
+	tuiFlagProject string
+	tuiFlagTrivial bool
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Interactive terminal dashboard",
+	Long: `Open a full-screen, read-only dashboard with tabs for Metrics, Sessions,
+Gaps, and Suggestions. Each tab is built from the same analyzers as the
+corresponding command.
+
+Keys:
+  ←/→, tab, 1-4   switch tabs
+  ↑/↓, pgup/pgdn  scroll
+  r               reload all data
+  q, esc          quit
+
+--days limits the Metrics, Sessions, and Gaps tabs to recent sessions.
+--project limits the Metrics and Sessions tabs to one project, and
+--include-trivial=false leaves trivial sessions out of the Metrics tab, as
+for the metrics command.
+Requires an interactive terminal; use the individual commands when piping.
+
+Examples:
+  claudewatch tui
+  claudewatch tui --days 7
+  claudewatch tui --project api`,
+	RunE: runTUI,
+}
+
+func init() {
+	tuiCmd.Flags().IntVar(&tuiFlagDays, "days", 30, "Number of days to look back")
+	tuiCmd.Flags().StringVar(&tuiFlagProject, "project", "", "Limit the Metrics and Sessions tabs to the project matching this name (fuzzy)")
+	tuiCmd.Flags().BoolVar(&tuiFlagTrivial, "include-trivial", true, "Count trivial sessions in the Metrics tab (see trivial_session in the config)")
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	if !ui.IsTTY() {
+		return errors.New("tui requires an interactive terminal; use metrics, sessions, gaps, or suggest for non-interactive output")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	opts := metricsOptions{days: tuiFlagDays, project: tuiFlagProject, includeTrivial: tuiFlagTrivial, stopWhenEmpty: true}
+	return ui.RunDashboard("claudewatch", func() ([]ui.Tab, error) {
+		return buildTUITabs(cfg, opts, time.Now())
+	})
+}
+
+// buildTUITabs loads all data sources and renders the dashboard tabs. The
+// Metrics tab renders the same data as the metrics command.
+func buildTUITabs(cfg *config.Config, opts metricsOptions, now time.Time) ([]ui.Tab, error) {
+	metrics, _, err := buildMetrics(cfg, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
+	if err != nil {
+		return nil, fmt.Errorf("parsing session meta: %w", err)
+	}
+
+	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
+	if err != nil {
+		return nil, fmt.Errorf("parsing facets: %w", err)
+	}
+
+	cutoff := now.AddDate(0, 0, -opts.days)
+	windowSessions := analyzer.FilterSessionsSince(sessions, cutoff)
+	windowFacets := filterFacetsBySessionIDs(facets, windowSessions)
+
+	pricing := analyzer.DefaultPricing["sonnet"]
+	cacheRatio := analyzer.NoCacheRatio()
+	if sc, scErr := claude.ParseStatsCache(cfg.ClaudeHome); scErr == nil && sc != nil {
+		cacheRatio = analyzer.ComputeCacheRatio(*sc)
+	}
+
+	facetMap := make(map[string]*claude.SessionFacet, len(facets))
+	for i := range facets {
+		facetMap[facets[i].SessionID] = &facets[i]
+	}
+	rows := buildSessionRows(sessions, facetMap, cutoff, metrics.Project, pricing, cacheRatio)
+	sortSessionRows(rows, "recent")
+
+	// Warnings would garble the full-screen view; 'gaps' and 'suggest'
+	// report them.
+	gaps, _ := collectGaps(cfg, windowSessions, windowFacets, cutoff, io.Discard)
+	gaps = anonymizedGaps(gaps)
+
+	ctx, err := buildAnalysisContext(cfg, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("building analysis context: %w", err)
+	}
+	suggestions := newSuggestEngine(io.Discard).Run(anonymized(ctx))
+
+	return []ui.Tab{
+		{Title: "Metrics", Lines: metricsTabLines(anonymized(metrics))},
+		{Title: "Sessions", Lines: sessionsTabLines(anonymized(rows))},
+		{Title: "Gaps", Lines: gapsTabLines(gaps)},
+		{Title: "Suggestions", Lines: suggestionsTabLines(suggestions)},
+	}, nil
+}
+
+// metricsTabLines summarizes the headline numbers from the metrics command.
+func metricsTabLines(m metricsOutput) []string {
+	var lines []string
+	section := func(title string) {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, " "+output.StyleHeader.Render(title))
+	}
+	row := func(label, value string) {
+		lines = append(lines, fmt.Sprintf("   %-24s %s", label, output.StyleBold.Render(value)))
+	}
+	note := func(text string) {
+		lines = append(lines, "   "+output.StyleMuted.Render(text))
+	}
+
+	if m.Sessions == 0 {
+		return []string{" " + output.StyleMuted.Render("No sessions in this window.")}
+	}
+
+	v := m.Velocity
+	section("Sessions")
+	row("Total sessions", fmt.Sprintf("%d", v.TotalSessions))
+	if m.TrivialSkipped > 0 {
+		note(fmt.Sprintf("%d trivial sessions skipped", m.TrivialSkipped))
+	}
+	if r := m.Resumes; r.Resumes > 0 {
+		row("Logical sessions", fmt.Sprintf("%d", r.LogicalSessions))
+		note(fmt.Sprintf("%d resumed within %d min merged", r.Resumes, r.GapMinutes))
+	}
+	row("Avg duration", fmt.Sprintf("%.0f min", v.AvgDurationMinutes))
+	row("Avg messages/session", fmt.Sprintf("%.1f", v.AvgMessagesPerSession))
+
+	section("Productivity")
+	row("Commits/session", fmt.Sprintf("%.1f", v.AvgCommitsPerSession))
+	row("Zero-commit rate", fmt.Sprintf("%.0f%%", m.Commits.ZeroCommitRate*100))
+	row("Lines added/session", fmt.Sprintf("%.0f", v.AvgLinesAddedPerSession))
+
+	section("Efficiency")
+	row("Tool errors/session", fmt.Sprintf("%.1f", m.Efficiency.AvgToolErrorsPerSession))
+	row("Interruptions/session", fmt.Sprintf("%.1f", m.Efficiency.AvgInterruptionsPerSession))
+	for _, t := range m.ToolErrors.Flakiest {
+		row(t.Tool, fmt.Sprintf("%.0f%% of %d calls failed", t.ErrorRate*100, t.Uses))
+	}
+
+	section("Satisfaction")
+	row("Weighted score", fmt.Sprintf("%.0f/100", m.Satisfaction.WeightedScore))
+	row("Facets analyzed", fmt.Sprintf("%d", m.Satisfaction.TotalFacets))
+	if n := facetCoverageNote(m.FacetCoverage); n != "" {
+		lines = append(lines, "   "+n)
+	}
+
+	a := m.Agents
+	section("Agents")
+	if a.TotalAgents == 0 {
+		note("No agent tasks found in session transcripts")
+	} else {
+		row("Total agents spawned", fmt.Sprintf("%d", a.TotalAgents))
+		row("Success rate", fmt.Sprintf("%.0f%%", a.SuccessRate*100))
+		row("Kill rate", fmt.Sprintf("%.0f%%", a.KillRate*100))
+	}
+
+	o := m.CostPerOutcome
+	section("Cost")
+	row("Total estimated cost", fmt.Sprintf("$%.2f", o.TotalCost))
+	row("Cost/session", fmt.Sprintf("$%.2f", o.AvgCostPerSession))
+	for _, d := range o.Drivers.Top {
+		row(d.Name, fmt.Sprintf("$%.2f (%.0f%%)", d.Cost, d.Share*100))
+	}
+
+	return lines
+}
+
+// sessionsTabLines renders the sessions table, most recent first.
+func sessionsTabLines(rows []sessionRow) []string {
+	if len(rows) == 0 {
+		return []string{" " + output.StyleMuted.Render("No sessions in this window.")}
+	}
+	return strings.Split(strings.TrimRight(sessionsTable(rows).Render(), "\n"), "\n")
+}
+
+// gapsTabLines renders gaps grouped by category under a severity summary.
+func gapsTabLines(gaps []gap) []string {
+	if len(gaps) == 0 {
+		return []string{" " + output.StyleMuted.Render("No gaps found.")}
+	}
+	counts := make(map[string]int)
+	for _, g := range gaps {
+		counts[g.Severity]++
+	}
+	lines := []string{
+		fmt.Sprintf(" Found %d gaps: %s critical, %s warnings, %s info",
+			len(gaps),
+			output.StyleError.Render(fmt.Sprintf("%d", counts["critical"])),
+			output.StyleWarning.Render(fmt.Sprintf("%d", counts["warning"])),
+			output.StyleMuted.Render(fmt.Sprintf("%d", counts["info"]))),
+		"",
+	}
+	return append(lines, gapsByCategoryLines(gaps)...)
+}
+
+// suggestionsTabLines renders ranked suggestions.
+func suggestionsTabLines(suggestions []suggest.Suggestion) []string {
+	if len(suggestions) == 0 {
+		return []string{" No suggestions. Your workflow looks good!"}
+	}
+	return suggestionLines(suggestions)
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+func TestRunTUI_RequiresTerminal(t *testing.T) {
+	// Test binaries never run with a TTY on both stdin and stdout.
+	err := runTUI(tuiCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "interactive terminal") {
+		t.Fatalf("expected interactive terminal error, got %v", err)
+	}
+}
+
+func TestBuildTUITabs_EmptyHome(t *testing.T) {
+	cfg := &config.Config{ClaudeHome: t.TempDir()}
+
+	tabs, err := buildTUITabs(cfg, metricsOptions{days: 30, includeTrivial: true}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"Metrics", "Sessions", "Gaps", "Suggestions"}
+	if len(tabs) != len(want) {
+		t.Fatalf("got %d tabs, want %d", len(tabs), len(want))
+	}
+	for i, title := range want {
+		if tabs[i].Title != title {
+			t.Errorf("tab %d = %q, want %q", i, tabs[i].Title, title)
+		}
+		if len(tabs[i].Lines) == 0 {
+			t.Errorf("tab %q has no lines", title)
+		}
+	}
+}
+
+func TestMetricsTabLines_SharesMetricsData(t *testing.T) {
+	m := metricsOutput{
+		Sessions:       4,
+		TrivialSkipped: 2,
+		Resumes:        analyzer.ResumeAnalysis{GapMinutes: 30, LogicalSessions: 3, Resumes: 1},
+		ToolErrors:     analyzer.ToolErrorRates{Flakiest: []analyzer.ToolErrorRate{{Tool: "Bash", Uses: 20, Errors: 5, ErrorRate: 0.25}}},
+		CostPerOutcome: analyzer.OutcomeAnalysis{Drivers: analyzer.CostDrivers{Top: []analyzer.CostDriver{{Name: "Explore", Cost: 1.5, Share: 0.6}}}},
+	}
+	text := strings.Join(metricsTabLines(m), "\n")
+	for _, want := range []string{"2 trivial sessions skipped", "1 resumed within 30 min merged", "25% of 20 calls failed", "$1.50 (60%)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Metrics tab missing %q:\n%s", want, text)
+		}
+	}
+
+	if lines := metricsTabLines(metricsOutput{}); len(lines) != 1 || !strings.Contains(lines[0], "No sessions") {
+		t.Errorf("empty Metrics tab = %q, want a no-sessions note", lines)
+	}
+}
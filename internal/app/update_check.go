@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/guard"
+	"github.com/blackwell-systems/claudewatch/internal/logging"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/blackwell-systems/claudewatch/internal/ui"
+	"github.com/blackwell-systems/claudewatch/internal/update"
+	"github.com/spf13/cobra"
+)
+
+// backgroundUpdateTimeout bounds how long a background check can delay the
+// end of a command. It only applies when the cached result has expired.
+const backgroundUpdateTimeout = 2 * time.Second
+
+var updateCheckFlagForce bool
+
+var updateCheckCmd = &cobra.Command{
+	Use:   "update-check",
+	Short: "Check GitHub for a newer claudewatch release",
+	Long: `Query the GitHub releases API for the latest claudewatch release and
+compare it with this build. Results are cached for 24 hours in the config
+directory, so repeated runs don't hit the API.
+
+Network failures are reported but never fail the command. Set
+update_check.enabled: false in the config file to disable all update checks,
+for example on offline machines. Set update_check.background: true to check
+at most once a day after interactive commands.
+
+Examples:
+  claudewatch update-check
+  claudewatch update-check --force
+  claudewatch update-check --json`,
+	Args: cobra.NoArgs,
+	RunE: runUpdateCheck,
+}
+
+func init() {
+	updateCheckCmd.Flags().BoolVar(&updateCheckFlagForce, "force", false, "Ignore the cached result and query GitHub now")
+	rootCmd.AddCommand(updateCheckCmd)
+}
+
+// updateCheckOutput is the JSON form of the update-check command.
+type updateCheckOutput struct {
+	Enabled bool `json:"enabled"`
+	*update.Result
+	Error string `json:"error,omitempty"`
+}
+
+func runUpdateCheck(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	if !cfg.UpdateCheck.Enabled {
+		if flagJSON {
+			return writeJSON(updateCheckOutput{Enabled: false})
+		}
+		fmt.Println(output.StyleMuted.Render(" Update checks are disabled (update_check.enabled is false)."))
+		return nil
+	}
+	if err := guard.Network("update-check"); err != nil {
+		return err
+	}
+
+	checker := update.NewChecker(config.ConfigDir(), appVersion)
+	result, err := checker.Check(cmd.Context(), appVersion, updateCheckFlagForce)
+	if err != nil {
+		// Offline or rate-limited: report it, but don't fail scripts.
+		logging.Warn("update check failed", "err", err)
+		if flagJSON {
+			return writeJSON(updateCheckOutput{Enabled: true, Error: err.Error()})
+		}
+		fmt.Println(output.StyleMuted.Render(" Could not check for updates (run with -v for details)."))
+		return nil
+	}
+
+	if flagJSON {
+		return writeJSON(updateCheckOutput{Enabled: true, Result: &result})
+	}
+	renderUpdateCheck(result)
+	return nil
+}
+
+func renderUpdateCheck(r update.Result) {
+	switch {
+	case r.UpdateAvailable:
+		fmt.Printf(" %s\n", output.StyleWarning.Render(fmt.Sprintf("claudewatch %s is available (you have %s).", r.LatestVersion, r.CurrentVersion)))
+		if r.ReleaseURL != "" {
+			fmt.Printf(" %s\n", output.StyleMuted.Render("Release notes and downloads: "+r.ReleaseURL))
+		}
+	case !update.Comparable(r.CurrentVersion):
+		fmt.Printf(" Latest release is %s; this is a development build (%s).\n", output.StyleBold.Render(r.LatestVersion), r.CurrentVersion)
+	default:
+		fmt.Printf(" %s\n", output.StyleSuccess.Render(fmt.Sprintf("claudewatch %s is up to date.", r.CurrentVersion)))
+	}
+	if r.Cached {
+		fmt.Printf(" %s\n", output.StyleMuted.Render("Checked "+r.CheckedAt.Local().Format("2006-01-02 15:04")+"; use --force to check again."))
+	}
+}
+
+// pendingUpdateCheck delivers the result of the background check started for
+// this invocation, or is nil when none was started.
+var pendingUpdateCheck <-chan *update.Result
+
+// startBackgroundUpdateCheck begins the opt-in daily update check for cmd. It
+// does nothing unless update_check.background is enabled and the command is
+// interactive, so scripts, --json output, and MCP/hook stdio never see it.
+// bench is skipped too, since it must not write any state.
+func startBackgroundUpdateCheck(cmd *cobra.Command) {
+	if cmd == updateCheckCmd || cmd == benchCmd || flagJSON || !ui.IsTTY() {
+		return
+	}
+	cfg, err := loadConfig()
+	if err != nil || !cfg.UpdateCheck.Enabled || !cfg.UpdateCheck.Background || guard.Offline() {
+		return
+	}
+
+	ch := make(chan *update.Result, 1)
+	pendingUpdateCheck = ch
+	checker := update.NewChecker(config.ConfigDir(), appVersion)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), backgroundUpdateTimeout)
+		defer cancel()
+		result, err := checker.Check(ctx, appVersion, false)
+		if err != nil {
+			logging.Debug("background update check failed", "err", err)
+			ch <- nil
+			return
+		}
+		ch <- &result
+	}()
+}
+
+// finishBackgroundUpdateCheck waits for the background check, if one was
+// started, and prints a one-line hint to stderr when a newer release exists.
+func finishBackgroundUpdateCheck() {
+	if pendingUpdateCheck == nil {
+		return
+	}
+	result := <-pendingUpdateCheck
+	pendingUpdateCheck = nil
+	if result == nil || !result.UpdateAvailable {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n%s\n", output.StyleMuted.Render(fmt.Sprintf(
+		"claudewatch %s is available (you have %s). Run 'claudewatch update-check' for details.",
+		result.LatestVersion, result.CurrentVersion)))
+}
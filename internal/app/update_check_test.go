@@ -0,0 +1,30 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunUpdateCheck_DisabledSkipsNetwork(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("update_check:\n  enabled: false\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	prev := flagConfig
+	flagConfig = path
+	t.Cleanup(func() { flagConfig = prev })
+
+	if err := runUpdateCheck(updateCheckCmd, nil); err != nil {
+		t.Fatalf("expected disabled update check to succeed, got %v", err)
+	}
+}
+
+func TestStartBackgroundUpdateCheck_SkipsNonInteractive(t *testing.T) {
+	// Test binaries have no TTY, so no check should be started.
+	startBackgroundUpdateCheck(metricsCmd)
+	if pendingUpdateCheck != nil {
+		t.Fatal("expected no background check without a terminal")
+	}
+	finishBackgroundUpdateCheck()
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+// appVersion, appCommit, and appBuildDate describe the running binary. They
+// are set from main's ldflags values via SetVersion and SetBuildInfo.
+var (
+	appVersion   = "dev"
+	appCommit    = ""
+	appBuildDate = ""
+)
+
+// SetVersion sets the application version (called from main with ldflags value).
+// When no version was embedded, the module version recorded by `go install`
+// is used instead, so snapshots and bug reports still name a real release.
+func SetVersion(v string) {
+	if v == "" || v == "dev" {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			v = moduleVersion(info, v)
+		}
+	}
+	if v == "" {
+		v = "dev"
+	}
+	appVersion = v
+	rootCmd.Version = v
+}
+
+// SetBuildInfo sets the git commit and build date (called from main with
+// ldflags values). Either may be empty; missing values fall back to the VCS
+// stamp Go embeds when building from a checkout.
+func SetBuildInfo(commit, date string) {
+	if commit == "" || date == "" {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			vcsCommit, vcsTime := vcsStamp(info)
+			if commit == "" {
+				commit = vcsCommit
+			}
+			if date == "" {
+				date = vcsTime
+			}
+		}
+	}
+	appCommit = commit
+	appBuildDate = date
+}
+
+// moduleVersion returns the main module's version from info, or fallback
+// when the binary was built from a local checkout ("(devel)").
+func moduleVersion(info *debug.BuildInfo, fallback string) string {
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		return v
+	}
+	return fallback
+}
+
+// vcsStamp returns the short revision and commit time Go embeds for builds
+// from a VCS checkout.
+func vcsStamp(info *debug.BuildInfo) (commit, date string) {
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			commit = s.Value
+			if len(commit) > 7 {
+				commit = commit[:7]
+			}
+		case "vcs.time":
+			date = s.Value
+		}
+	}
+	return commit, date
+}
+
+// versionInfo is the JSON form of the version command.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	GoVersion string `json:"go_version"`
+	BuildDate string `json:"build_date,omitempty"`
+}
+
+func currentVersionInfo() versionInfo {
+	return versionInfo{
+		Version:   appVersion,
+		Commit:    appCommit,
+		GoVersion: runtime.Version(),
+		BuildDate: appBuildDate,
+	}
+}
+
+// String returns the one-line form printed by `claudewatch version`.
+func (v versionInfo) String() string {
+	line := "claudewatch " + v.Version
+	details := ""
+	if v.Commit != "" {
+		details = "commit " + v.Commit + ", "
+	}
+	if v.BuildDate != "" {
+		details += "built " + v.BuildDate + ", "
+	}
+	return fmt.Sprintf("%s (%s%s)", line, details, v.GoVersion)
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version and build information",
+	Long: `Print the claudewatch version, git commit, Go version, and build date.
+
+Use --json for a machine-readable object, e.g. for bug reports or scripts
+that check for updates.
+
+Examples:
+  claudewatch version
+  claudewatch version --json`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	info := currentVersionInfo()
+	if flagJSON {
+		return writeJSON(info)
+	}
+	fmt.Println(info.String())
+	return nil
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestVersionInfo_String(t *testing.T) {
+	full := versionInfo{Version: "1.2.3", Commit: "abc1234", BuildDate: "2026-01-01T00:00:00Z", GoVersion: "go1.26.0"}
+	if got, want := full.String(), "claudewatch 1.2.3 (commit abc1234, built 2026-01-01T00:00:00Z, go1.26.0)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	bare := versionInfo{Version: "dev", GoVersion: "go1.26.0"}
+	if got, want := bare.String(), "claudewatch dev (go1.26.0)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSetVersion_UpdatesSnapshotVersion(t *testing.T) {
+	prevVersion, prevCommit, prevDate := appVersion, appCommit, appBuildDate
+	t.Cleanup(func() {
+		appVersion, appCommit, appBuildDate = prevVersion, prevCommit, prevDate
+		rootCmd.Version = prevVersion
+	})
+
+	SetVersion("1.2.3")
+	SetBuildInfo("abc1234", "2026-01-01T00:00:00Z")
+
+	// track records appVersion in snapshots; --version and the version
+	// command must agree with it.
+	if appVersion != "1.2.3" || rootCmd.Version != "1.2.3" {
+		t.Errorf("appVersion = %q, rootCmd.Version = %q; want 1.2.3", appVersion, rootCmd.Version)
+	}
+	info := currentVersionInfo()
+	if info.Version != "1.2.3" || info.Commit != "abc1234" || info.BuildDate != "2026-01-01T00:00:00Z" {
+		t.Errorf("currentVersionInfo() = %+v", info)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("GoVersion = %q, want %q", info.GoVersion, runtime.Version())
+	}
+}
+
+func TestModuleVersion(t *testing.T) {
+	installed := &debug.BuildInfo{Main: debug.Module{Version: "v0.16.0"}}
+	if got := moduleVersion(installed, "dev"); got != "v0.16.0" {
+		t.Errorf("moduleVersion(installed) = %q, want v0.16.0", got)
+	}
+	local := &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}}
+	if got := moduleVersion(local, "dev"); got != "dev" {
+		t.Errorf("moduleVersion(local) = %q, want dev", got)
+	}
+}
+
+func TestVCSStamp(t *testing.T) {
+	info := &debug.BuildInfo{Settings: []debug.BuildSetting{
+		{Key: "vcs.revision", Value: "0123456789abcdef"},
+		{Key: "vcs.time", Value: "2026-01-01T00:00:00Z"},
+	}}
+	commit, date := vcsStamp(info)
+	if commit != "0123456" || !strings.HasPrefix(date, "2026-01-01") {
+		t.Errorf("vcsStamp() = %q, %q", commit, date)
+	}
+}
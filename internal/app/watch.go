@@ -20,6 +20,8 @@ var (
 	watchStop     bool
 	watchQuiet    bool
 	watchBudget   float64
+	watchBaseline bool
+	watchOnce     bool
 )
 
 var watchCmd = &cobra.Command{
@@ -30,11 +32,26 @@ data for changes. When notable events are detected (friction spikes, new
 patterns, session completions), desktop notifications and/or terminal
 alerts are emitted.
 
+The watcher's state is saved between runs, so restarting it only reports
+changes since it last checked. On the first run (or with --baseline) the
+current data is recorded silently as the baseline instead of alerting on
+historical sessions.
+
+--once checks a single time against the saved state, reports what changed
+since the last run, saves the new state, and exits, so cron can run the
+watcher without leaving a process behind. The first --once run only
+records the baseline.
+
+Each alert is printed with a signature. Run 'claudewatch ack <signature>' to
+stop an alert repeating until its condition gets materially worse.
+
 Examples:
   claudewatch watch                    # run in foreground (ctrl-c to stop)
   claudewatch watch --daemon           # run in background, write PID file
   claudewatch watch --interval 5m      # check every 5 minutes (default: 10m)
   claudewatch watch --budget 20        # alert if daily cost exceeds $20
+  claudewatch watch --baseline         # re-record the baseline, alert only on new changes
+  claudewatch watch --once             # report changes since the last run, then exit
   claudewatch watch --stop             # stop the background daemon`,
 	RunE: runWatch,
 }
@@ -45,6 +62,9 @@ func init() {
 	watchCmd.Flags().BoolVar(&watchStop, "stop", false, "Stop a running background daemon")
 	watchCmd.Flags().BoolVar(&watchQuiet, "quiet", false, "Suppress terminal output, only send notifications")
 	watchCmd.Flags().Float64Var(&watchBudget, "budget", 0, "Daily cost budget in USD; alert when exceeded (e.g. --budget 20)")
+	watchCmd.Flags().BoolVar(&watchBaseline, "baseline", false, "Discard the saved baseline and silently record the current state as the new one")
+	watchCmd.Flags().BoolVar(&watchOnce, "once", false, "Check once against the saved state, report what changed, and exit")
+	watchCmd.MarkFlagsMutuallyExclusive("once", "daemon", "stop")
 	rootCmd.AddCommand(watchCmd)
 }
 
@@ -53,6 +73,16 @@ func pidFilePath() string {
 	return filepath.Join(config.ConfigDir(), "watch.pid")
 }
 
+// baselineFilePath returns the path to the persisted watcher baseline.
+func baselineFilePath() string {
+	return filepath.Join(config.ConfigDir(), "watch-baseline.json")
+}
+
+// ackFilePath returns the path to the alert acknowledgments written by ack.
+func ackFilePath() string {
+	return filepath.Join(config.ConfigDir(), "watch-acks.json")
+}
+
 // logFilePath returns the path to the daemon log file.
 func logFilePath() string {
 	return filepath.Join(config.ConfigDir(), "watch.log")
@@ -63,11 +93,15 @@ func runWatch(cmd *cobra.Command, args []string) error {
 		return stopDaemon()
 	}
 
-	cfg, err := config.Load(flagConfig)
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
 
+	if watchOnce {
+		return runOnce(cfg)
+	}
+
 	interval, err := time.ParseDuration(watchInterval)
 	if err != nil {
 		return fmt.Errorf("invalid interval %q: %w", watchInterval, err)
@@ -83,6 +117,45 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	return runForeground(cfg, interval)
 }
 
+// newWatcher returns a watcher configured from cfg and the watch flags,
+// persisting its state in the baseline file.
+func newWatcher(cfg *config.Config, interval time.Duration, alertFn func(watcher.Alert)) *watcher.Watcher {
+	w := watcher.New(cfg.ClaudeHome, interval, alertFn)
+	w.BudgetUSD = watchBudget
+	w.StaleWeeks = cfg.Friction.StaleWeeks
+	w.Trivial = trivialSession(cfg)
+	w.BaselinePath = baselineFilePath()
+	w.ResetBaseline = watchBaseline
+	w.AckPath = ackFilePath()
+	return w
+}
+
+// runOnce compares the current data with the saved state a single time,
+// notifies and prints the alerts, and saves the new state. With no saved
+// state it records the baseline and exits without alerting.
+func runOnce(cfg *config.Config) error {
+	w := newWatcher(cfg, 0, nil)
+	alerts, compared, err := w.Once()
+	if err != nil {
+		return err
+	}
+	for _, a := range alerts {
+		_ = watcher.Notify(a)
+		if !watchQuiet {
+			printAlert(a)
+		}
+	}
+	if watchQuiet || len(alerts) > 0 {
+		return nil
+	}
+	if compared {
+		fmt.Printf("[%s] %s No changes since the last run\n", time.Now().Format("15:04:05"), checkMark())
+	} else {
+		fmt.Printf("[%s] %s Baseline recorded; the next --once run reports changes since now\n", time.Now().Format("15:04:05"), checkMark())
+	}
+	return nil
+}
+
 // runForeground runs the watcher in the foreground with live terminal output.
 func runForeground(cfg *config.Config, interval time.Duration) error {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -110,8 +183,7 @@ func runForeground(cfg *config.Config, interval time.Duration) error {
 		}
 	}
 
-	w := watcher.New(cfg.ClaudeHome, interval, alertFn)
-	w.BudgetUSD = watchBudget
+	w := newWatcher(cfg, interval, alertFn)
 
 	// Take initial snapshot and display baseline.
 	initial, err := w.Snapshot()
@@ -192,11 +264,10 @@ func runDaemon(cfg *config.Config, interval time.Duration) error {
 		_ = watcher.Notify(a)
 
 		// Log to file.
-		writeLog(logFile, "[%s] %s: %s", a.Level, a.Title, a.Message)
+		writeLog(logFile, "[%s] %s: %s (ack %s)", a.Level, a.Title, a.Message, a.Signature)
 	}
 
-	w := watcher.New(cfg.ClaudeHome, interval, alertFn)
-	w.BudgetUSD = watchBudget
+	w := newWatcher(cfg, interval, alertFn)
 
 	err = w.Run(ctx)
 	if err == context.Canceled {
@@ -226,7 +297,7 @@ func writeLog(f *os.File, format string, args ...any) {
 func printAlert(a watcher.Alert) {
 	timestamp := a.Time.Format("15:04:05")
 	icon := alertIcon(a.Level)
-	fmt.Printf("[%s] %s %s\n", timestamp, icon, a.Title)
+	fmt.Printf("[%s] %s %s  (ack %s)\n", timestamp, icon, a.Title, a.Signature)
 	if a.Message != "" {
 		fmt.Printf("         %s\n", a.Message)
 	}
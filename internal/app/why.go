@@ -0,0 +1,430 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	whyFlagDays        int
+	whyFlagProject     string
+	whyFlagProjectPath string
+	whyFlagLimit       int
+	whyFlagTrivial     bool
+)
+
+var whyCmd = &cobra.Command{
+	Use:   "why <metric>",
+	Short: "Show which sessions and projects drive a metric's value",
+	Long: `Explain an aggregate metric by listing the sessions and projects that
+contribute most to it, so "zero-commit rate: 45%" leads straight to the
+sessions behind it.
+
+Supported metrics:
+  zero-commit-rate   share of sessions without a commit; the longest
+                     zero-commit sessions and the projects with most of them
+  cost               total estimated cost; the priciest sessions and projects
+  tool-errors        tool errors per session; the sessions and projects with
+                     the most errors
+  friction           friction events per faceted session; the sessions and
+                     projects with the most friction
+  duration           average session length; the longest sessions and the
+                     projects with the most session time
+
+Examples:
+  claudewatch why zero-commit-rate
+  claudewatch why cost --days 7 --limit 5
+  claudewatch why friction --project api --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWhy,
+}
+
+func init() {
+	whyCmd.Flags().IntVar(&whyFlagDays, "days", 30, "Number of days to analyze")
+	whyCmd.Flags().StringVar(&whyFlagProject, "project", "", "Filter to the project matching this name (fuzzy)")
+	whyCmd.Flags().StringVar(&whyFlagProjectPath, "project-path", "", "Filter to the project at exactly this path")
+	whyCmd.MarkFlagsMutuallyExclusive("project", "project-path")
+	whyCmd.Flags().IntVar(&whyFlagLimit, "limit", 10, "Maximum sessions and projects to list")
+	whyCmd.Flags().BoolVar(&whyFlagTrivial, "include-trivial", true, "Count trivial sessions (see trivial_session in the config)")
+	rootCmd.AddCommand(whyCmd)
+}
+
+// whyMetric is a metric why can explain.
+type whyMetric struct {
+	Name        string
+	Description string
+	explain     func(rows []sessionRow) whyResult
+}
+
+// whyMetrics lists the supported metrics in help order.
+var whyMetrics = []whyMetric{
+	{"zero-commit-rate", "Share of sessions that produced no git commit", explainZeroCommitRate},
+	{"cost", "Total estimated cost of the sessions", explainCost},
+	{"tool-errors", "Average tool errors per session", explainToolErrors},
+	{"friction", "Average friction events per faceted session", explainFriction},
+	{"duration", "Average session length", explainDuration},
+}
+
+// lookupWhyMetric finds the metric named name, accepting underscores for
+// dashes and any case.
+func lookupWhyMetric(name string) (whyMetric, bool) {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", "-"))
+	for _, m := range whyMetrics {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return whyMetric{}, false
+}
+
+func whyMetricNames() []string {
+	names := make([]string, len(whyMetrics))
+	for i, m := range whyMetrics {
+		names[i] = m.Name
+	}
+	return names
+}
+
+// whyResult is a metric's value and what contributes to it.
+type whyResult struct {
+	Metric      string `json:"metric"`
+	Description string `json:"description"`
+	// Value is the metric over every session analyzed; Display formats it.
+	Value   float64 `json:"value"`
+	Display string  `json:"display"`
+	// Sessions is the number of sessions analyzed.
+	Sessions    int          `json:"sessions"`
+	TopSessions []whySession `json:"top_sessions"`
+	TopProjects []whyProject `json:"top_projects"`
+}
+
+// whySession is one session's contribution to a metric.
+type whySession struct {
+	SessionID string    `json:"session_id"`
+	Project   string    `json:"project"`
+	Date      time.Time `json:"date"`
+	Value     float64   `json:"value"`
+	Display   string    `json:"display"`
+	Detail    string    `json:"detail,omitempty"`
+}
+
+// whyProject is one project's contribution to a metric.
+type whyProject struct {
+	Project  string  `json:"project"`
+	Sessions int     `json:"sessions"`
+	Value    float64 `json:"value"`
+	Display  string  `json:"display"`
+	// Share is the project's fraction of the metric's total contribution.
+	Share float64 `json:"share"`
+}
+
+func runWhy(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if flagNoColor {
+		output.SetNoColor(true)
+	}
+
+	metric, ok := lookupWhyMetric(args[0])
+	if !ok {
+		return fmt.Errorf("unknown metric %q (supported: %s)", args[0], strings.Join(whyMetricNames(), ", "))
+	}
+	if whyFlagLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1, got %d", whyFlagLimit)
+	}
+
+	pricing := analyzer.DefaultPricing["sonnet"]
+	cacheRatio := analyzer.NoCacheRatio()
+	if sc, scErr := claude.ParseStatsCache(cfg.ClaudeHome); scErr == nil && sc != nil {
+		cacheRatio = analyzer.ComputeCacheRatio(*sc)
+	}
+
+	sessions, err := claude.ParseAllSessionMeta(cfg.ClaudeHome)
+	if err != nil {
+		return fmt.Errorf("parsing session meta: %w", err)
+	}
+	total := len(sessions)
+
+	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
+	if err != nil {
+		return fmt.Errorf("parsing facets: %w", err)
+	}
+	facetMap := make(map[string]*claude.SessionFacet, len(facets))
+	for i := range facets {
+		facetMap[facets[i].SessionID] = &facets[i]
+	}
+
+	project, err := resolveProjectFilter(whyFlagProject, whyFlagProjectPath, sessions)
+	if err != nil {
+		return err
+	}
+	if !whyFlagTrivial {
+		sessions, _ = analyzer.FilterTrivialSessions(sessions, trivialSession(cfg))
+	}
+	rows := buildSessionRows(sessions, facetMap, time.Now().AddDate(0, 0, -whyFlagDays), project, pricing, cacheRatio)
+
+	if len(rows) == 0 && !flagJSON {
+		scope := fmt.Sprintf("in the last %d days", whyFlagDays)
+		if project != "" {
+			scope += fmt.Sprintf(" for project %s", project)
+		}
+		printNoSessions(cmd.OutOrStdout(), cfg, total, scope)
+		return nil
+	}
+
+	result := metric.explain(rows)
+	result.Metric = metric.Name
+	result.Description = metric.Description
+	result.Sessions = len(rows)
+	result.TopSessions = limitSlice(result.TopSessions, whyFlagLimit)
+	result.TopProjects = limitSlice(result.TopProjects, whyFlagLimit)
+
+	if flagJSON {
+		return writeJSON(result)
+	}
+	renderWhy(anonymized(result))
+	return nil
+}
+
+// limitSlice returns at most n elements of s, never nil.
+func limitSlice[T any](s []T, n int) []T {
+	if len(s) > n {
+		s = s[:n]
+	}
+	if s == nil {
+		s = []T{}
+	}
+	return s
+}
+
+func explainZeroCommitRate(rows []sessionRow) whyResult {
+	metas := make([]claude.SessionMeta, len(rows))
+	for i, r := range rows {
+		metas[i] = r.Meta
+	}
+	ca := analyzer.AnalyzeCommits(metas)
+
+	res := whyResult{Value: ca.ZeroCommitRate, Display: fmt.Sprintf("%.0f%%", ca.ZeroCommitRate*100)}
+	// ZeroCommitSessions is already longest first.
+	for _, z := range ca.ZeroCommitSessions {
+		project := z.ProjectName
+		if project == "." || project == "" {
+			project = "(unknown)"
+		}
+		detail := fmt.Sprintf("%d messages", z.Messages)
+		if len(z.TopTools) > 0 {
+			detail += ", mostly " + strings.Join(z.TopTools, ", ")
+		}
+		res.TopSessions = append(res.TopSessions, whySession{
+			SessionID: z.SessionID,
+			Project:   project,
+			Date:      z.Date,
+			Value:     float64(z.Duration),
+			Display:   fmt.Sprintf("%dm", z.Duration),
+			Detail:    detail,
+		})
+	}
+
+	projects := make([]analyzer.ProjectCommitStats, 0, len(ca.ByProject))
+	for _, p := range ca.ByProject {
+		if p.ZeroCommitSessions > 0 {
+			projects = append(projects, p)
+		}
+	}
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].ZeroCommitSessions > projects[j].ZeroCommitSessions
+	})
+	for _, p := range projects {
+		res.TopProjects = append(res.TopProjects, whyProject{
+			Project:  p.ProjectName,
+			Sessions: p.Sessions,
+			Value:    float64(p.ZeroCommitSessions),
+			Display:  fmt.Sprintf("%d of %d (%.0f%%)", p.ZeroCommitSessions, p.Sessions, p.ZeroCommitRate*100),
+			Share:    float64(p.ZeroCommitSessions) / float64(ca.SessionsZeroCommits),
+		})
+	}
+	return res
+}
+
+func explainCost(rows []sessionRow) whyResult {
+	cost := func(r sessionRow) float64 { return r.EstimatedCost }
+	res := whyContributions(rows, cost, func(v float64) string { return fmt.Sprintf("$%.2f", v) },
+		func(r sessionRow) string {
+			return fmt.Sprintf("%s tokens", formatTokenCount(int64(r.Meta.InputTokens+r.Meta.OutputTokens)))
+		})
+	res.Value = sumRows(rows, cost)
+	res.Display = fmt.Sprintf("$%.2f", res.Value)
+	return res
+}
+
+func explainToolErrors(rows []sessionRow) whyResult {
+	errs := func(r sessionRow) float64 { return float64(r.Meta.ToolErrors) }
+	res := whyContributions(rows, errs, func(v float64) string { return fmt.Sprintf("%.0f errors", v) },
+		func(r sessionRow) string { return topCounts(r.Meta.ToolErrorsByTool, 3) })
+	if len(rows) > 0 {
+		res.Value = sumRows(rows, errs) / float64(len(rows))
+	}
+	res.Display = fmt.Sprintf("%.1f per session", res.Value)
+	return res
+}
+
+func explainFriction(rows []sessionRow) whyResult {
+	var faceted []sessionRow
+	for _, r := range rows {
+		if r.Facet != nil {
+			faceted = append(faceted, r)
+		}
+	}
+	friction := func(r sessionRow) float64 { return float64(r.frictionTotal()) }
+	res := whyContributions(faceted, friction, func(v float64) string { return fmt.Sprintf("%.0f events", v) },
+		func(r sessionRow) string { return topCounts(r.Facet.FrictionCounts, 3) })
+	if len(faceted) > 0 {
+		res.Value = sumRows(faceted, friction) / float64(len(faceted))
+	}
+	res.Display = fmt.Sprintf("%.1f per session (%d of %d sessions faceted)", res.Value, len(faceted), len(rows))
+	return res
+}
+
+func explainDuration(rows []sessionRow) whyResult {
+	minutes := func(r sessionRow) float64 { return float64(r.Meta.DurationMinutes) }
+	res := whyContributions(rows, minutes, func(v float64) string { return fmt.Sprintf("%.0fm", v) },
+		func(r sessionRow) string {
+			return fmt.Sprintf("%d messages, %d commits", r.Meta.UserMessageCount+r.Meta.AssistantMessageCount, r.Meta.GitCommits)
+		})
+	if len(rows) > 0 {
+		res.Value = sumRows(rows, minutes) / float64(len(rows))
+	}
+	res.Display = fmt.Sprintf("%.0fm average", res.Value)
+	return res
+}
+
+// whyContributions ranks the rows with a positive value, highest first, and
+// sums value by project with each project's share of the total.
+func whyContributions(rows []sessionRow, value func(sessionRow) float64, format func(float64) string, detail func(sessionRow) string) whyResult {
+	var res whyResult
+	type projectTotal struct {
+		sessions int
+		value    float64
+	}
+	byProject := make(map[string]*projectTotal)
+	var total float64
+	for _, r := range rows {
+		v := value(r)
+		if v <= 0 {
+			continue
+		}
+		total += v
+		res.TopSessions = append(res.TopSessions, whySession{
+			SessionID: r.Meta.SessionID,
+			Project:   r.projectName(),
+			Date:      claude.ParseTimestamp(r.Meta.StartTime),
+			Value:     v,
+			Display:   format(v),
+			Detail:    detail(r),
+		})
+		pt := byProject[r.projectName()]
+		if pt == nil {
+			pt = &projectTotal{}
+			byProject[r.projectName()] = pt
+		}
+		pt.sessions++
+		pt.value += v
+	}
+	sort.SliceStable(res.TopSessions, func(i, j int) bool {
+		return res.TopSessions[i].Value > res.TopSessions[j].Value
+	})
+
+	for name, pt := range byProject {
+		res.TopProjects = append(res.TopProjects, whyProject{
+			Project:  name,
+			Sessions: pt.sessions,
+			Value:    pt.value,
+			Display:  format(pt.value),
+			Share:    pt.value / total,
+		})
+	}
+	sort.Slice(res.TopProjects, func(i, j int) bool {
+		if res.TopProjects[i].Value != res.TopProjects[j].Value {
+			return res.TopProjects[i].Value > res.TopProjects[j].Value
+		}
+		return res.TopProjects[i].Project < res.TopProjects[j].Project
+	})
+	return res
+}
+
+func sumRows(rows []sessionRow, value func(sessionRow) float64) float64 {
+	var sum float64
+	for _, r := range rows {
+		sum += value(r)
+	}
+	return sum
+}
+
+// topCounts formats the n largest counts as "name count" pairs, largest
+// first.
+func topCounts(counts map[string]int, n int) string {
+	names := make([]string, 0, len(counts))
+	for name, c := range counts {
+		if c > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, counts[name])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func renderWhy(r whyResult) {
+	fmt.Println(output.Section(fmt.Sprintf("Why %s is %s", r.Metric, r.Display)))
+	fmt.Printf(" %s\n", output.StyleMuted.Render(fmt.Sprintf("%s, over %d sessions.", r.Description, r.Sessions)))
+	fmt.Println()
+
+	if len(r.TopSessions) == 0 {
+		fmt.Printf(" %s\n\n", output.StyleSuccess.Render("No session contributes to this metric."))
+		return
+	}
+
+	fmt.Printf(" %s\n", output.StyleBold.Render("Top sessions"))
+	tbl := output.NewTable("Session", "Date", "Project", "Value", "Detail")
+	for _, s := range r.TopSessions {
+		date := ""
+		if !s.Date.IsZero() {
+			date = s.Date.Local().Format("Jan 02 15:04")
+		}
+		tbl.AddRow(truncateID(s.SessionID), date, s.Project, s.Display, truncateString(s.Detail, 40))
+	}
+	tbl.Print()
+	fmt.Println()
+
+	fmt.Printf(" %s\n", output.StyleBold.Render("Top projects"))
+	ptbl := output.NewTable("Project", "Sessions", "Value", "Share")
+	for _, p := range r.TopProjects {
+		ptbl.AddRow(p.Project, fmt.Sprintf("%d", p.Sessions), p.Display, fmt.Sprintf("%.0f%%", p.Share*100))
+	}
+	ptbl.Print()
+	fmt.Println()
+
+	fmt.Printf(" %s\n", output.StyleMuted.Render("Use claudewatch sessions <session-id> to inspect a session"))
+	fmt.Println()
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"math"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func whyRow(id, project string, commits, minutes, toolErrors int, cost float64, facet *claude.SessionFacet) sessionRow {
+	return sessionRow{
+		Meta: claude.SessionMeta{
+			SessionID:       id,
+			ProjectPath:     "/src/" + project,
+			StartTime:       "2026-03-10T09:00:00Z",
+			GitCommits:      commits,
+			DurationMinutes: minutes,
+			ToolErrors:      toolErrors,
+		},
+		Facet:         facet,
+		EstimatedCost: cost,
+	}
+}
+
+func TestLookupWhyMetric(t *testing.T) {
+	for _, name := range []string{"zero-commit-rate", "zero_commit_rate", " Cost "} {
+		if _, ok := lookupWhyMetric(name); !ok {
+			t.Errorf("lookupWhyMetric(%q) not found", name)
+		}
+	}
+	if _, ok := lookupWhyMetric("commits"); ok {
+		t.Error("lookupWhyMetric(commits) found, want unknown")
+	}
+}
+
+func TestExplainCost(t *testing.T) {
+	rows := []sessionRow{
+		whyRow("a", "api", 1, 10, 0, 2, nil),
+		whyRow("b", "web", 1, 10, 0, 5, nil),
+		whyRow("c", "api", 1, 10, 0, 3, nil),
+		whyRow("d", "web", 1, 10, 0, 0, nil),
+	}
+
+	r := explainCost(rows)
+
+	if r.Value != 10 {
+		t.Errorf("Value = %v, want 10", r.Value)
+	}
+	// Sessions that cost nothing don't contribute.
+	if len(r.TopSessions) != 3 || r.TopSessions[0].SessionID != "b" || r.TopSessions[2].SessionID != "a" {
+		t.Errorf("TopSessions = %+v, want b, c, a", r.TopSessions)
+	}
+	if len(r.TopProjects) != 2 {
+		t.Fatalf("TopProjects = %+v, want 2", r.TopProjects)
+	}
+	// api and web tie at $5; ties break by name.
+	if p := r.TopProjects[0]; p.Project != "api" || p.Sessions != 2 || p.Share != 0.5 {
+		t.Errorf("TopProjects[0] = %+v, want api with 2 sessions and half the cost", p)
+	}
+}
+
+func TestExplainZeroCommitRate(t *testing.T) {
+	rows := []sessionRow{
+		whyRow("a", "api", 0, 10, 0, 0, nil),
+		whyRow("b", "api", 0, 40, 0, 0, nil),
+		whyRow("c", "api", 2, 30, 0, 0, nil),
+		whyRow("d", "web", 0, 20, 0, 0, nil),
+	}
+
+	r := explainZeroCommitRate(rows)
+
+	if r.Value != 0.75 || r.Display != "75%" {
+		t.Errorf("Value = %v (%s), want 0.75 (75%%)", r.Value, r.Display)
+	}
+	if len(r.TopSessions) != 3 || r.TopSessions[0].SessionID != "b" {
+		t.Errorf("TopSessions = %+v, want 3 zero-commit sessions, longest (b) first", r.TopSessions)
+	}
+	if len(r.TopProjects) != 2 || r.TopProjects[0].Project != "api" || r.TopProjects[0].Value != 2 {
+		t.Fatalf("TopProjects = %+v, want api with 2 zero-commit sessions first", r.TopProjects)
+	}
+	if math.Abs(r.TopProjects[0].Share-2.0/3) > 1e-9 {
+		t.Errorf("api Share = %v, want 2/3", r.TopProjects[0].Share)
+	}
+}
+
+func TestExplainFriction_OnlyFacetedSessions(t *testing.T) {
+	rows := []sessionRow{
+		whyRow("a", "api", 1, 10, 0, 0, &claude.SessionFacet{FrictionCounts: map[string]int{"wrong_approach": 3, "buggy_code": 1}}),
+		whyRow("b", "api", 1, 10, 0, 0, &claude.SessionFacet{}),
+		whyRow("c", "web", 1, 10, 0, 0, nil),
+	}
+
+	r := explainFriction(rows)
+
+	if r.Value != 2 {
+		t.Errorf("Value = %v, want 2 per faceted session", r.Value)
+	}
+	if len(r.TopSessions) != 1 || r.TopSessions[0].Detail != "wrong_approach 3, buggy_code 1" {
+		t.Errorf("TopSessions = %+v, want a with its friction types", r.TopSessions)
+	}
+}
+
+func TestWhyCmd_Registered(t *testing.T) {
+	for _, name := range []string{"days", "project", "project-path", "limit", "include-trivial"} {
+		if whyCmd.Flags().Lookup(name) == nil {
+			t.Errorf("why is missing --%s", name)
+		}
+	}
+}
@@ -48,7 +48,7 @@ func FindActiveSessionPath(claudeHome string) (string, error) {
 	projectsDir := filepath.Join(claudeHome, "projects")
 
 	// Enumerate all .jsonl files under projects/<hash>/<session>.jsonl.
-	entries, err := os.ReadDir(projectsDir)
+	entries, err := readDir(projectsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return "", nil
@@ -65,7 +65,7 @@ func FindActiveSessionPath(claudeHome string) (string, error) {
 			continue
 		}
 		dirPath := filepath.Join(projectsDir, entry.Name())
-		files, err := os.ReadDir(dirPath)
+		files, err := readDir(dirPath)
 		if err != nil {
 			continue
 		}
@@ -120,7 +120,7 @@ func FindActiveSessionPathForMCP(claudeHome string) (string, error) {
 	projectsDir := filepath.Join(claudeHome, "projects")
 
 	// Enumerate all .jsonl files under projects/<hash>/<session>.jsonl.
-	entries, err := os.ReadDir(projectsDir)
+	entries, err := readDir(projectsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return "", nil
@@ -137,7 +137,7 @@ func FindActiveSessionPathForMCP(claudeHome string) (string, error) {
 			continue
 		}
 		dirPath := filepath.Join(projectsDir, entry.Name())
-		files, err := os.ReadDir(dirPath)
+		files, err := readDir(dirPath)
 		if err != nil {
 			continue
 		}
@@ -220,7 +220,7 @@ func findOpenFileForParentProcess(jsonlFiles []string) string {
 	var bestPath string
 	var bestMtime time.Time
 	for _, p := range matches {
-		fi, err := os.Stat(p)
+		fi, err := statFile(p)
 		if err != nil {
 			continue
 		}
@@ -276,7 +276,7 @@ func findOpenFileWithLsof(jsonlFiles []string) string {
 	var bestPath string
 	var bestMtime time.Time
 	for _, p := range matches {
-		fi, err := os.Stat(p)
+		fi, err := statFile(p)
 		if err != nil {
 			continue
 		}
@@ -293,7 +293,7 @@ func findOpenFileWithLsof(jsonlFiles []string) string {
 // success (even if partially populated). Returns (nil, error) only on
 // file read failure.
 func ParseActiveSession(path string) (*SessionMeta, error) {
-	data, err := os.ReadFile(path)
+	data, err := readFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -337,7 +337,7 @@ func ParseActiveSession(path string) (*SessionMeta, error) {
 		if !startTimeSet && entry.Timestamp != "" {
 			t := ParseTimestamp(entry.Timestamp)
 			if !t.IsZero() {
-				meta.StartTime = t.Format(time.RFC3339)
+				meta.StartTime = t.UTC().Format(time.RFC3339)
 				startTimeSet = true
 			}
 		}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
-	"os"
 	"sort"
 	"strings"
 	"time"
@@ -54,7 +53,7 @@ type LiveCommitAttemptStats struct {
 // as ParseActiveSession: read entire file, truncate at last newline, scan with
 // a 10MB buffer, skip malformed lines.
 func readLiveJSONL(path string) ([]TranscriptEntry, error) {
-	data, err := os.ReadFile(path)
+	data, err := readFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,71 @@
+package claude
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/logging"
+)
+
+// SessionActivity is when, within one session's transcript, work was
+// committed.
+type SessionActivity struct {
+	// Minutes is the session's length, from its first to its last
+	// timestamped transcript entry.
+	Minutes float64 `json:"minutes"`
+	// CommitMinutes are the minutes after the first entry at which the
+	// session ran git commit, in transcript order.
+	CommitMinutes []float64 `json:"commit_minutes"`
+}
+
+// ParseSessionActivity walks every transcript under claudeDir/projects/ and
+// returns each session's length and the times it ran git commit, keyed by
+// session ID. Sessions without timestamped entries are omitted.
+func ParseSessionActivity(claudeDir string) (map[string]SessionActivity, error) {
+	done := logging.Phase("parse session activity", "dir", claudeDir)
+	type span struct {
+		first, last time.Time
+		commits     []time.Time
+	}
+	spans := make(map[string]*span)
+	err := WalkTranscriptEntries(claudeDir, func(entry TranscriptEntry, sessionID string, _ string) {
+		ts := ParseTimestamp(entry.Timestamp)
+		if ts.IsZero() {
+			return
+		}
+		s := spans[sessionID]
+		if s == nil {
+			s = &span{first: ts, last: ts}
+			spans[sessionID] = s
+		}
+		if ts.Before(s.first) {
+			s.first = ts
+		}
+		if ts.After(s.last) {
+			s.last = ts
+		}
+		if entry.Type != "assistant" || entry.Message == nil {
+			return
+		}
+		var msg AssistantMessage
+		if err := json.Unmarshal(entry.Message, &msg); err != nil {
+			return
+		}
+		for _, block := range msg.Content {
+			if block.Type == "tool_use" && block.Name == "Bash" && isActionToolUse(block) {
+				s.commits = append(s.commits, ts)
+			}
+		}
+	})
+
+	activity := make(map[string]SessionActivity, len(spans))
+	for id, s := range spans {
+		a := SessionActivity{Minutes: s.last.Sub(s.first).Minutes(), CommitMinutes: []float64{}}
+		for _, c := range s.commits {
+			a.CommitMinutes = append(a.CommitMinutes, c.Sub(s.first).Minutes())
+		}
+		activity[id] = a
+	}
+	done("sessions", len(activity))
+	return activity, err
+}
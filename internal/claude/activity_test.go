@@ -0,0 +1,36 @@
+package claude
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseSessionActivity(t *testing.T) {
+	dir := t.TempDir()
+	createTestJSONL(t, dir, "hash1", "sess1", []string{
+		`{"type":"user","timestamp":"2026-01-10T09:00:00Z","message":{"role":"user","content":"start"}}`,
+		`{"type":"assistant","timestamp":"2026-01-10T09:12:00Z","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{"command":"git commit -m 'first'"}}]}}`,
+		`{"type":"assistant","timestamp":"2026-01-10T09:20:00Z","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}}]}}`,
+		`{"type":"assistant","timestamp":"2026-01-10T09:30:00Z","message":{"role":"assistant","content":[{"type":"tool_use","name":"Edit","input":{}}]}}`,
+		`{"type":"assistant","timestamp":"2026-01-10T09:45:00Z","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{"command":"git add . && git commit -m second"}}]}}`,
+		`{"type":"user","timestamp":"2026-01-10T10:30:00Z","message":{"role":"user","content":"thanks"}}`,
+	})
+	createTestJSONL(t, dir, "hash1", "sess2", []string{
+		`{"type":"summary","summary":"no timestamps"}`,
+	})
+
+	activity, err := ParseSessionActivity(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(activity) != 1 {
+		t.Fatalf("expected 1 session with timestamps, got %+v", activity)
+	}
+	a := activity["sess1"]
+	if math.Abs(a.Minutes-90) > 1e-9 {
+		t.Errorf("Minutes = %.1f, want 90", a.Minutes)
+	}
+	if len(a.CommitMinutes) != 2 || a.CommitMinutes[0] != 12 || a.CommitMinutes[1] != 45 {
+		t.Errorf("CommitMinutes = %v, want [12 45]", a.CommitMinutes)
+	}
+}
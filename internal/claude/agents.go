@@ -8,7 +8,12 @@ func ParseAgentTasks(claudeDir string) ([]AgentTask, error) {
 	if err != nil {
 		return nil, err
 	}
+	return AgentTasksFromSpans(spans), nil
+}
 
+// AgentTasksFromSpans converts transcript agent spans to agent tasks, for
+// callers that also need the spans themselves.
+func AgentTasksFromSpans(spans []AgentSpan) []AgentTask {
 	tasks := make([]AgentTask, 0, len(spans))
 	for _, span := range spans {
 		status := "completed"
@@ -28,8 +33,8 @@ func ParseAgentTasks(claudeDir string) ([]AgentTask, error) {
 			TotalTokens: span.TotalTokens,
 			ToolUses:    0, // Tool use counts not available in transcript data.
 			Background:  span.Background,
-			CreatedAt:   span.LaunchedAt.Format("2006-01-02T15:04:05Z"),
+			CreatedAt:   span.LaunchedAt.UTC().Format("2006-01-02T15:04:05Z"),
 		})
 	}
-	return tasks, nil
+	return tasks
 }
@@ -9,7 +9,7 @@ import (
 // ListCommands lists custom slash command files from ~/.claude/commands/*.md.
 func ListCommands(claudeHome string) ([]CommandFile, error) {
 	dir := filepath.Join(claudeHome, "commands")
-	entries, err := os.ReadDir(dir)
+	entries, err := readDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -23,7 +23,7 @@ func ListCommands(claudeHome string) ([]CommandFile, error) {
 			continue
 		}
 		path := filepath.Join(dir, entry.Name())
-		data, err := os.ReadFile(path)
+		data, err := readFile(path)
 		if err != nil {
 			continue
 		}
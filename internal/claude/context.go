@@ -0,0 +1,56 @@
+package claude
+
+import (
+	"encoding/json"
+
+	"github.com/blackwell-systems/claudewatch/internal/logging"
+)
+
+// ContextPeak is the largest context a session sent in a single turn.
+type ContextPeak struct {
+	// PeakTokens is the largest input_tokens + cache_read_input_tokens +
+	// cache_creation_input_tokens of any assistant turn: the full prompt the
+	// model saw, cached or not.
+	PeakTokens int `json:"peak_tokens"`
+	// Turns counts assistant turns that reported usage.
+	Turns int `json:"turns"`
+	// Compactions counts summary entries left by /compact or auto-compaction.
+	Compactions int `json:"compactions"`
+}
+
+// ParseContextPeaks walks every transcript under claudeDir/projects/ and
+// returns the peak per-turn context size of each session, keyed by session
+// ID. Sessions without any assistant usage are omitted.
+func ParseContextPeaks(claudeDir string) (map[string]ContextPeak, error) {
+	done := logging.Phase("parse context peaks", "dir", claudeDir)
+	peaks := make(map[string]ContextPeak)
+	err := WalkTranscriptEntries(claudeDir, func(entry TranscriptEntry, sessionID string, _ string) {
+		switch {
+		case entry.Type == "summary":
+			p := peaks[sessionID]
+			p.Compactions++
+			peaks[sessionID] = p
+		case entry.Type == "assistant" && entry.Message != nil:
+			var msg assistantMsgUsage
+			if err := json.Unmarshal(entry.Message, &msg); err != nil {
+				return
+			}
+			u := msg.Usage
+			size := u.InputTokens + u.CacheReadInputTokens + u.CacheCreationInputTokens
+			if size == 0 {
+				return
+			}
+			p := peaks[sessionID]
+			p.Turns++
+			p.PeakTokens = max(p.PeakTokens, size)
+			peaks[sessionID] = p
+		}
+	})
+	for id, p := range peaks {
+		if p.Turns == 0 {
+			delete(peaks, id)
+		}
+	}
+	done("sessions", len(peaks))
+	return peaks, err
+}
@@ -0,0 +1,41 @@
+package claude
+
+import "testing"
+
+func TestParseContextPeaks(t *testing.T) {
+	dir := t.TempDir()
+	createTestJSONL(t, dir, "hash1", "sess1", []string{
+		`{"type":"assistant","message":{"role":"assistant","usage":{"input_tokens":10,"cache_read_input_tokens":40000,"cache_creation_input_tokens":2000}}}`,
+		`{"type":"summary","summary":"compacted"}`,
+		`{"type":"assistant","message":{"role":"assistant","usage":{"input_tokens":5,"cache_read_input_tokens":150000,"cache_creation_input_tokens":500}}}`,
+		`{"type":"assistant","message":{"role":"assistant","usage":{"input_tokens":8,"cache_read_input_tokens":20000}}}`,
+	})
+	createTestJSONL(t, dir, "hash1", "sess2", []string{
+		`{"type":"user","message":{"role":"user","content":"hi"}}`,
+	})
+
+	peaks, err := ParseContextPeaks(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(peaks) != 1 {
+		t.Fatalf("expected 1 session with usage, got %d: %+v", len(peaks), peaks)
+	}
+	p := peaks["sess1"]
+	if p.PeakTokens != 150505 {
+		t.Errorf("PeakTokens = %d, want 150505", p.PeakTokens)
+	}
+	if p.Turns != 3 || p.Compactions != 1 {
+		t.Errorf("Turns=%d Compactions=%d, want 3 and 1", p.Turns, p.Compactions)
+	}
+}
+
+func TestParseContextPeaks_NoProjects(t *testing.T) {
+	peaks, err := ParseContextPeaks(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(peaks) != 0 {
+		t.Errorf("expected no peaks, got %+v", peaks)
+	}
+}
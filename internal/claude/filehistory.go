@@ -11,7 +11,7 @@ import (
 // edit metadata. It does not read file contents — only collects counts and sizes.
 func ParseAllFileHistory(claudeHome string) ([]FileHistorySession, error) {
 	dir := filepath.Join(claudeHome, "file-history")
-	sessionDirs, err := os.ReadDir(dir)
+	sessionDirs, err := readDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -26,7 +26,7 @@ func ParseAllFileHistory(claudeHome string) ([]FileHistorySession, error) {
 		}
 
 		sessionDir := filepath.Join(dir, sd.Name())
-		files, err := os.ReadDir(sessionDir)
+		files, err := readDir(sessionDir)
 		if err != nil {
 			continue
 		}
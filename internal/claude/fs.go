@@ -0,0 +1,50 @@
+package claude
+
+import (
+	"io/fs"
+	"os"
+	"sync/atomic"
+)
+
+// FS is the filesystem the parsers read a Claude home through. Local homes
+// are read with OSFS; a remote home (see internal/remote) installs an FS
+// that keeps its local mirror fresh before reads.
+type FS interface {
+	Open(name string) (fs.File, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// OSFS reads the local filesystem directly.
+type OSFS struct{}
+
+func (OSFS) Open(name string) (fs.File, error)          { return os.Open(name) }
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (OSFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (OSFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+
+// homeFS holds the FS the parsers read through.
+var homeFS atomic.Pointer[FS]
+
+// SetFS makes the parsers read through f; nil restores OSFS.
+func SetFS(f FS) {
+	if f == nil {
+		homeFS.Store(nil)
+		return
+	}
+	homeFS.Store(&f)
+}
+
+// currentFS returns the FS the parsers read through.
+func currentFS() FS {
+	if f := homeFS.Load(); f != nil {
+		return *f
+	}
+	return OSFS{}
+}
+
+func openFile(name string) (fs.File, error)      { return currentFS().Open(name) }
+func readDir(name string) ([]fs.DirEntry, error) { return currentFS().ReadDir(name) }
+func readFile(name string) ([]byte, error)       { return currentFS().ReadFile(name) }
+func statFile(name string) (fs.FileInfo, error)  { return currentFS().Stat(name) }
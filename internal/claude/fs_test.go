@@ -0,0 +1,33 @@
+package claude
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+)
+
+// failingFS fails every read with err.
+type failingFS struct{ err error }
+
+func (f failingFS) Open(string) (fs.File, error)          { return nil, f.err }
+func (f failingFS) ReadDir(string) ([]fs.DirEntry, error) { return nil, f.err }
+func (f failingFS) ReadFile(string) ([]byte, error)       { return nil, f.err }
+func (f failingFS) Stat(string) (fs.FileInfo, error)      { return nil, f.err }
+
+func TestSetFS_ParsersReadThroughIt(t *testing.T) {
+	injected := errors.New("injected")
+	SetFS(failingFS{injected})
+	defer SetFS(nil)
+
+	if _, err := ParseAllSessionMeta(t.TempDir()); !errors.Is(err, injected) {
+		t.Errorf("ParseAllSessionMeta err = %v, want the FS error", err)
+	}
+	if _, err := ParseHistory(t.TempDir()); !errors.Is(err, injected) {
+		t.Errorf("ParseHistory err = %v, want the FS error", err)
+	}
+
+	SetFS(nil)
+	if _, ok := currentFS().(OSFS); !ok {
+		t.Errorf("SetFS(nil) left %T, want OSFS", currentFS())
+	}
+}
@@ -11,7 +11,7 @@ import (
 // It uses streaming JSONL parsing to handle large files efficiently.
 func ParseHistory(claudeHome string) ([]HistoryEntry, error) {
 	path := filepath.Join(claudeHome, "history.jsonl")
-	f, err := os.Open(path)
+	f, err := openFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -10,7 +10,7 @@ import (
 // the list of installed plugins. Returns nil if the file does not exist.
 func ParsePlugins(claudeHome string) ([]PluginEntry, error) {
 	path := filepath.Join(claudeHome, "plugins", "installed_plugins.json")
-	data, err := os.ReadFile(path)
+	data, err := readFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -9,7 +9,7 @@ import (
 // Each directory represents a project that Claude has been used with.
 func ListProjects(claudeHome string) ([]ProjectDir, error) {
 	dir := filepath.Join(claudeHome, "projects")
-	entries, err := os.ReadDir(dir)
+	entries, err := readDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
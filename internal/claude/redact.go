@@ -0,0 +1,32 @@
+package claude
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+var redactPrompts atomic.Bool
+
+// SetRedactPrompts turns first-prompt redaction on or off.
+func SetRedactPrompts(on bool) { redactPrompts.Store(on) }
+
+// RedactPrompts reports whether first-prompt redaction is on.
+func RedactPrompts() bool { return redactPrompts.Load() }
+
+// RedactPrompt returns prompt, or a placeholder giving only its length while
+// redaction is on. Empty prompts stay empty, so "none recorded" still shows.
+func RedactPrompt(prompt string) string {
+	if !RedactPrompts() || prompt == "" {
+		return prompt
+	}
+	return fmt.Sprintf("[redacted: %d chars]", utf8.RuneCountInString(prompt))
+}
+
+// RedactFirstPrompts applies RedactPrompt to the FirstPrompt of every
+// session in place.
+func RedactFirstPrompts(sessions []SessionMeta) {
+	for i := range sessions {
+		sessions[i].FirstPrompt = RedactPrompt(sessions[i].FirstPrompt)
+	}
+}
@@ -0,0 +1,25 @@
+package claude
+
+import "testing"
+
+func TestRedactPrompt(t *testing.T) {
+	if got := RedactPrompt("deploy with key sk-123"); got != "deploy with key sk-123" {
+		t.Errorf("redaction off: got %q", got)
+	}
+
+	SetRedactPrompts(true)
+	defer SetRedactPrompts(false)
+
+	if got := RedactPrompt("fix the café bug"); got != "[redacted: 16 chars]" {
+		t.Errorf("RedactPrompt = %q, want [redacted: 16 chars]", got)
+	}
+	if got := RedactPrompt(""); got != "" {
+		t.Errorf("empty prompt = %q, want empty", got)
+	}
+
+	sessions := []SessionMeta{{SessionID: "a", FirstPrompt: "secret"}, {SessionID: "b"}}
+	RedactFirstPrompts(sessions)
+	if sessions[0].FirstPrompt != "[redacted: 6 chars]" || sessions[1].FirstPrompt != "" {
+		t.Errorf("RedactFirstPrompts = %+v", sessions)
+	}
+}
@@ -8,9 +8,22 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/logging"
+	"github.com/blackwell-systems/claudewatch/internal/workers"
 )
 
+var sessionMetaCacheReadOnly atomic.Bool
+
+// SetSessionMetaCacheReadOnly stops ParseAllSessionMeta from writing rebuilt
+// entries back to the session-meta cache. Stale or missing entries are still
+// parsed from the JSONL on every call.
+func SetSessionMetaCacheReadOnly(readOnly bool) {
+	sessionMetaCacheReadOnly.Store(readOnly)
+}
+
 // ParseAllSessionMeta walks ~/.claude/projects/<hash>/*.jsonl and returns a
 // SessionMeta for every transcript file found. Results are loaded from a JSON
 // cache when fresh; stale or missing caches are rebuilt from the JSONL and
@@ -20,38 +33,59 @@ func ParseAllSessionMeta(claudeHome string) ([]SessionMeta, error) {
 	projectsDir := filepath.Join(claudeHome, "projects")
 	cacheDir := filepath.Join(claudeHome, "usage-data", "session-meta")
 
-	entries, err := os.ReadDir(projectsDir)
+	done := logging.Phase("parse session meta", "dir", projectsDir)
+
+	entries, err := readDir(projectsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
+			logging.Debug("no projects directory", "dir", projectsDir)
 			return nil, nil
 		}
+		logging.Warn("reading projects directory", "dir", projectsDir, "err", err)
 		return nil, err
 	}
 
-	var results []SessionMeta
+	var paths []string
 	for _, proj := range entries {
 		if !proj.IsDir() {
 			continue
 		}
 		projDir := filepath.Join(projectsDir, proj.Name())
-		files, err := os.ReadDir(projDir)
+		files, err := readDir(projDir)
 		if err != nil {
+			logging.Warn("reading project directory", "dir", projDir, "err", err)
 			continue
 		}
 		for _, f := range files {
 			if f.IsDir() || !strings.HasSuffix(f.Name(), ".jsonl") {
 				continue
 			}
-			sessionID := strings.TrimSuffix(f.Name(), ".jsonl")
-			jsonlPath := filepath.Join(projDir, f.Name())
-			cachePath := filepath.Join(cacheDir, sessionID+".json")
-			meta, err := loadOrParseSession(jsonlPath, cachePath, cacheDir, sessionID)
-			if err != nil || meta == nil {
-				continue
-			}
+			paths = append(paths, filepath.Join(projDir, f.Name()))
+		}
+	}
+
+	// Parse in parallel into per-file slots so the result order matches a
+	// sequential walk.
+	metas := make([]*SessionMeta, len(paths))
+	workers.Each(len(paths), func(i int) {
+		jsonlPath := paths[i]
+		sessionID := strings.TrimSuffix(filepath.Base(jsonlPath), ".jsonl")
+		cachePath := filepath.Join(cacheDir, sessionID+".json")
+		meta, err := loadOrParseSession(jsonlPath, cachePath, cacheDir, sessionID)
+		if err != nil || meta == nil {
+			logging.Warn("skipping session transcript", "path", jsonlPath, "err", err)
+			return
+		}
+		metas[i] = meta
+	})
+
+	var results []SessionMeta
+	for _, meta := range metas {
+		if meta != nil {
 			results = append(results, *meta)
 		}
 	}
+	done("projects", len(entries), "transcripts", len(paths), "sessions", len(results), "skipped", len(paths)-len(results))
 	return results, nil
 }
 
@@ -59,14 +93,17 @@ func ParseAllSessionMeta(claudeHome string) ([]SessionMeta, error) {
 // otherwise parses the JSONL transcript and writes a new cache entry.
 func loadOrParseSession(jsonlPath, cachePath, cacheDir, sessionID string) (*SessionMeta, error) {
 	// Cache-hit condition: cache file exists AND jsonl mtime is NOT after cache mtime.
-	jsonlInfo, jsonlErr := os.Stat(jsonlPath)
-	cacheInfo, cacheErr := os.Stat(cachePath)
+	jsonlInfo, jsonlErr := statFile(jsonlPath)
+	cacheInfo, cacheErr := statFile(cachePath)
 	if jsonlErr == nil && cacheErr == nil && !jsonlInfo.ModTime().After(cacheInfo.ModTime()) {
 		// Try to load from cache.
-		data, err := os.ReadFile(cachePath)
+		data, err := readFile(cachePath)
 		if err == nil {
 			var meta SessionMeta
 			if err := json.Unmarshal(data, &meta); err == nil {
+				if meta.ToolErrorsByTool == nil {
+					backfillToolErrors(&meta, jsonlPath, cacheDir, sessionID)
+				}
 				return &meta, nil
 			}
 		}
@@ -74,12 +111,27 @@ func loadOrParseSession(jsonlPath, cachePath, cacheDir, sessionID string) (*Sess
 	}
 
 	meta, err := ParseJSONLToSessionMeta(jsonlPath)
-	if err == nil && meta != nil {
+	if err == nil && meta != nil && !sessionMetaCacheReadOnly.Load() {
 		_ = writeSessionMetaCache(cacheDir, sessionID, meta)
 	}
 	return meta, err
 }
 
+// backfillToolErrors fills in ToolErrorsByTool, from the transcript, for a
+// cache entry written before it was recorded or by Claude Code, and writes
+// the entry back. Its other fields are kept, since Claude Code's entries
+// hold some the transcript can't provide.
+func backfillToolErrors(meta *SessionMeta, jsonlPath, cacheDir, sessionID string) {
+	parsed, err := ParseJSONLToSessionMeta(jsonlPath)
+	if err != nil || parsed == nil {
+		return
+	}
+	meta.ToolErrorsByTool = parsed.ToolErrorsByTool
+	if !sessionMetaCacheReadOnly.Load() {
+		_ = writeSessionMetaCache(cacheDir, sessionID, meta)
+	}
+}
+
 // ParseJSONLToSessionMeta performs a single-pass scan over a JSONL transcript
 // file and derives a SessionMeta. It is the authoritative source for all
 // fields derivable from the transcript; fields that only Claude Code can
@@ -87,7 +139,7 @@ func loadOrParseSession(jsonlPath, cachePath, cacheDir, sessionID string) (*Sess
 // UserInterruptions) are left at their zero values.
 // This function handles live (incomplete) sessions via line-atomic truncation.
 func ParseJSONLToSessionMeta(jsonlPath string) (*SessionMeta, error) {
-	data, err := os.ReadFile(jsonlPath)
+	data, err := readFile(jsonlPath)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +152,7 @@ func ParseJSONLToSessionMeta(jsonlPath string) (*SessionMeta, error) {
 		meta := &SessionMeta{
 			ToolCounts:          make(map[string]int),
 			ToolErrorCategories: make(map[string]int),
+			ToolErrorsByTool:    make(map[string]int),
 			ModelUsage:          make(map[string]ModelStats),
 		}
 		meta.SessionID = strings.TrimSuffix(filepath.Base(jsonlPath), ".jsonl")
@@ -110,8 +163,12 @@ func ParseJSONLToSessionMeta(jsonlPath string) (*SessionMeta, error) {
 	var meta SessionMeta
 	meta.ToolCounts = make(map[string]int)
 	meta.ToolErrorCategories = make(map[string]int)
+	meta.ToolErrorsByTool = make(map[string]int)
 	meta.ModelUsage = make(map[string]ModelStats)
 
+	// Tool names by tool_use ID, to attribute tool_result errors.
+	toolUseNames := make(map[string]string)
+
 	var startTimeSet bool
 	var firstEntryTime, lastEntryTime time.Time
 	var lastAssistantTime time.Time
@@ -141,7 +198,7 @@ func ParseJSONLToSessionMeta(jsonlPath string) (*SessionMeta, error) {
 		if !startTimeSet && entry.Timestamp != "" {
 			t := ParseTimestamp(entry.Timestamp)
 			if !t.IsZero() {
-				meta.StartTime = t.Format(time.RFC3339)
+				meta.StartTime = t.UTC().Format(time.RFC3339)
 				firstEntryTime = t
 				startTimeSet = true
 				_ = firstEntryTime // used below for DurationMinutes
@@ -184,6 +241,7 @@ func ParseJSONLToSessionMeta(jsonlPath string) (*SessionMeta, error) {
 							continue
 						}
 						meta.ToolCounts[block.Name]++
+						toolUseNames[block.ID] = block.Name
 						switch {
 						case block.Name == "Task":
 							meta.UsesTaskAgent = true
@@ -229,6 +287,9 @@ func ParseJSONLToSessionMeta(jsonlPath string) (*SessionMeta, error) {
 					for _, block := range msg.Content {
 						if block.Type == "tool_result" && block.IsError {
 							meta.ToolErrors++
+							if name, ok := toolUseNames[block.ToolUseID]; ok {
+								meta.ToolErrorsByTool[name]++
+							}
 						}
 					}
 				}
@@ -299,7 +360,7 @@ func writeSessionMetaCache(cacheDir, sessionID string, meta *SessionMeta) error
 
 // ParseSessionMeta reads a single session meta file.
 func ParseSessionMeta(path string) (*SessionMeta, error) {
-	data, err := os.ReadFile(path)
+	data, err := readFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -368,28 +429,39 @@ func gitCommitCount(dir, startTime string) int {
 // parseJSONDir reads all .json files from a directory and unmarshals them
 // into a slice of the given type. Skips files that fail to parse.
 func parseJSONDir[T any](dir string) ([]T, error) {
-	entries, err := os.ReadDir(dir)
+	done := logging.Phase("parse json dir", "dir", dir)
+
+	entries, err := readDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
+			logging.Debug("json dir missing", "dir", dir)
 			return nil, nil
 		}
+		logging.Warn("reading json dir", "dir", dir, "err", err)
 		return nil, err
 	}
 
 	var results []T
+	var skipped int
 	for _, entry := range entries {
 		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
 			continue
 		}
-		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		path := filepath.Join(dir, entry.Name())
+		data, err := readFile(path)
 		if err != nil {
+			skipped++
+			logging.Warn("skipping unreadable file", "path", path, "err", err)
 			continue
 		}
 		var item T
 		if err := json.Unmarshal(data, &item); err != nil {
+			skipped++
+			logging.Warn("skipping malformed file", "path", path, "err", err)
 			continue
 		}
 		results = append(results, item)
 	}
+	done("files", len(results), "skipped", skipped)
 	return results, nil
 }
@@ -187,6 +187,53 @@ func TestParseAllSessionMeta_CacheHit(t *testing.T) {
 	}
 }
 
+func TestParseAllSessionMeta_CacheBackfillsToolErrors(t *testing.T) {
+	dir := t.TempDir()
+	lines := []string{
+		`{"type":"user","sessionId":"sess1","timestamp":"2026-01-15T10:00:00Z","cwd":"/proj","message":{"role":"user","content":[{"type":"text","text":"run something"}]}}`,
+		`{"type":"assistant","sessionId":"sess1","timestamp":"2026-01-15T10:01:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Bash"}],"usage":{"input_tokens":50,"output_tokens":20}}}`,
+		`{"type":"user","sessionId":"sess1","timestamp":"2026-01-15T10:02:00Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","is_error":true,"content":"command not found"}]}}`,
+	}
+	jsonlPath := createTestJSONL(t, dir, "hash1", "sess1", lines)
+
+	// A fresh entry without tool_errors_by_tool, holding a field only
+	// Claude Code records.
+	cacheDir := filepath.Join(dir, "usage-data", "session-meta")
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		t.Fatalf("mkdir cacheDir: %v", err)
+	}
+	cachePath := filepath.Join(cacheDir, "sess1.json")
+	if err := os.WriteFile(cachePath, []byte(`{"session_id":"sess1","lines_added":42}`), 0644); err != nil {
+		t.Fatalf("write cache: %v", err)
+	}
+	jsonlMtime := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(jsonlPath, jsonlMtime, jsonlMtime); err != nil {
+		t.Fatalf("chtimes jsonl: %v", err)
+	}
+
+	metas, err := ParseAllSessionMeta(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metas) != 1 {
+		t.Fatalf("expected 1 meta, got %d", len(metas))
+	}
+	if metas[0].ToolErrorsByTool["Bash"] != 1 {
+		t.Errorf("ToolErrorsByTool = %v, want Bash:1 from the transcript", metas[0].ToolErrorsByTool)
+	}
+	if metas[0].LinesAdded != 42 {
+		t.Errorf("LinesAdded = %d, want the cached 42", metas[0].LinesAdded)
+	}
+
+	cached, err := ParseSessionMeta(cachePath)
+	if err != nil {
+		t.Fatalf("reading cache: %v", err)
+	}
+	if cached.ToolErrorsByTool["Bash"] != 1 || cached.LinesAdded != 42 {
+		t.Errorf("cache = %+v, want the backfilled entry written back", cached)
+	}
+}
+
 func TestParseAllSessionMeta_CacheMiss(t *testing.T) {
 	dir := t.TempDir()
 	createTestJSONL(t, dir, "hash1", "sess1", minimalJSONL("s1", "/home/user/proj"))
@@ -211,6 +258,26 @@ func TestParseAllSessionMeta_CacheMiss(t *testing.T) {
 	}
 }
 
+func TestParseAllSessionMeta_ReadOnlyCache(t *testing.T) {
+	dir := t.TempDir()
+	createTestJSONL(t, dir, "hash1", "sess1", minimalJSONL("s1", "/home/user/proj"))
+	SetSessionMetaCacheReadOnly(true)
+	t.Cleanup(func() { SetSessionMetaCacheReadOnly(false) })
+
+	metas, err := ParseAllSessionMeta(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metas) != 1 {
+		t.Fatalf("expected 1 meta, got %d", len(metas))
+	}
+
+	cacheDir := filepath.Join(dir, "usage-data", "session-meta")
+	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
+		t.Errorf("expected no cache directory in read-only mode, stat err = %v", err)
+	}
+}
+
 func TestParseAllSessionMeta_StaleCache(t *testing.T) {
 	dir := t.TempDir()
 	// Create a JSONL with sessionID "jsonl-session".
@@ -354,6 +421,9 @@ func TestParseJSONLToSessionMeta_ToolErrors(t *testing.T) {
 	if meta.ToolErrors != 2 {
 		t.Errorf("ToolErrors = %d, want 2", meta.ToolErrors)
 	}
+	if meta.ToolErrorsByTool["Bash"] != 1 || meta.ToolErrorsByTool["Read"] != 1 {
+		t.Errorf("ToolErrorsByTool = %v, want Bash:1 Read:1", meta.ToolErrorsByTool)
+	}
 }
 
 func TestParseJSONLToSessionMeta_WebFlags(t *testing.T) {
@@ -9,7 +9,7 @@ import (
 // ParseSettings reads ~/.claude/settings.json and returns the parsed settings.
 func ParseSettings(claudeHome string) (*GlobalSettings, error) {
 	path := filepath.Join(claudeHome, "settings.json")
-	data, err := os.ReadFile(path)
+	data, err := readFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
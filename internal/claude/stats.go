@@ -4,22 +4,28 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+
+	"github.com/blackwell-systems/claudewatch/internal/logging"
 )
 
 // ParseStatsCache reads ~/.claude/stats-cache.json and returns the parsed stats.
 func ParseStatsCache(claudeHome string) (*StatsCache, error) {
 	path := filepath.Join(claudeHome, "stats-cache.json")
-	data, err := os.ReadFile(path)
+	data, err := readFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
+			logging.Debug("no stats cache", "path", path)
 			return nil, nil
 		}
+		logging.Warn("reading stats cache", "path", path, "err", err)
 		return nil, err
 	}
 
 	var stats StatsCache
 	if err := json.Unmarshal(data, &stats); err != nil {
+		logging.Warn("parsing stats cache", "path", path, "err", err)
 		return nil, err
 	}
+	logging.Debug("loaded stats cache", "path", path)
 	return &stats, nil
 }
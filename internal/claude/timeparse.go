@@ -0,0 +1,54 @@
+package claude
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// sessionTimeLayouts are the timestamp formats found in session data, tried
+// in order. Layouts without a zone are interpreted in SessionTimeLocation.
+var sessionTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+var sessionTimeLoc atomic.Pointer[time.Location]
+
+// SetSessionTimeLocation sets the zone ParseSessionTime returns times in and
+// uses for timestamps that carry no zone of their own. A nil loc restores the
+// default, the system local zone.
+func SetSessionTimeLocation(loc *time.Location) {
+	sessionTimeLoc.Store(loc)
+}
+
+// SessionTimeLocation returns the zone set by SetSessionTimeLocation, or
+// time.Local if none has been set.
+func SessionTimeLocation() *time.Location {
+	if loc := sessionTimeLoc.Load(); loc != nil {
+		return loc
+	}
+	return time.Local
+}
+
+// ParseSessionTime parses a session timestamp such as SessionMeta.StartTime.
+// It accepts RFC3339, RFC3339Nano, a plain datetime with either a "T" or a
+// space separator, and a date-only form. Timestamps without a zone are taken
+// to be in SessionTimeLocation, and the result is always expressed in that
+// zone so that calendar bucketing (days, weeks, months) is consistent
+// regardless of how the source timestamp was written.
+func ParseSessionTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty session timestamp")
+	}
+	loc := SessionTimeLocation()
+	for _, layout := range sessionTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t.In(loc), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized session timestamp %q", s)
+}
@@ -0,0 +1,94 @@
+package claude
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSessionTime_Formats(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	SetSessionTimeLocation(berlin)
+	t.Cleanup(func() { SetSessionTimeLocation(nil) })
+
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{"RFC3339", "2026-01-15T10:00:00Z", time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{"RFC3339 with offset", "2026-01-15T10:00:00+05:00", time.Date(2026, 1, 15, 5, 0, 0, 0, time.UTC)},
+		{"RFC3339Nano", "2026-01-15T10:00:00.123456789Z", time.Date(2026, 1, 15, 10, 0, 0, 123456789, time.UTC)},
+		{"plain datetime", "2026-01-15T10:00:00", time.Date(2026, 1, 15, 10, 0, 0, 0, berlin)},
+		{"space-separated datetime", "2026-01-15 10:00:00", time.Date(2026, 1, 15, 10, 0, 0, 0, berlin)},
+		{"date only", "2026-01-15", time.Date(2026, 1, 15, 0, 0, 0, 0, berlin)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSessionTime(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSessionTime(%q): %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseSessionTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if got.Location() != berlin {
+				t.Errorf("ParseSessionTime(%q) location = %v, want Europe/Berlin", tt.input, got.Location())
+			}
+		})
+	}
+}
+
+func TestParseSessionTime_Invalid(t *testing.T) {
+	for _, input := range []string{"", "not-a-date", "2026-13-45", "15/01/2026"} {
+		if _, err := ParseSessionTime(input); err == nil {
+			t.Errorf("ParseSessionTime(%q): expected error", input)
+		}
+	}
+}
+
+func TestParseSessionTime_DSTBoundary(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	SetSessionTimeLocation(ny)
+	t.Cleanup(func() { SetSessionTimeLocation(nil) })
+
+	// Clocks spring forward at 02:00 EST on 2026-03-08. 06:30Z is 01:30 EST
+	// and 07:30Z is 03:30 EDT; both must land on Sunday the 8th locally.
+	before, err := ParseSessionTime("2026-03-08T06:30:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := ParseSessionTime("2026-03-08T07:30:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Hour() != 1 || after.Hour() != 3 {
+		t.Errorf("local hours = %d, %d; want 1, 3", before.Hour(), after.Hour())
+	}
+	if before.Weekday() != time.Sunday || after.Weekday() != time.Sunday {
+		t.Errorf("weekdays = %s, %s; want Sunday, Sunday", before.Weekday(), after.Weekday())
+	}
+
+	// A UTC timestamp just past midnight Monday is still Sunday evening in
+	// New York.
+	late, err := ParseSessionTime("2026-03-09T02:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if late.Weekday() != time.Sunday || late.Day() != 8 {
+		t.Errorf("got %v, want Sunday 8 March local", late)
+	}
+}
+
+func TestSessionTimeLocation_DefaultLocal(t *testing.T) {
+	SetSessionTimeLocation(nil)
+	if SessionTimeLocation() != time.Local {
+		t.Errorf("SessionTimeLocation() = %v, want time.Local", SessionTimeLocation())
+	}
+}
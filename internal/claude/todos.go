@@ -11,7 +11,7 @@ import (
 // parsed SessionTodos entries. Each file represents one session-agent pair.
 func ParseAllTodos(claudeHome string) ([]SessionTodos, error) {
 	dir := filepath.Join(claudeHome, "todos")
-	entries, err := os.ReadDir(dir)
+	entries, err := readDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -25,7 +25,7 @@ func ParseAllTodos(claudeHome string) ([]SessionTodos, error) {
 			continue
 		}
 
-		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		data, err := readFile(filepath.Join(dir, entry.Name()))
 		if err != nil {
 			continue
 		}
@@ -3,11 +3,16 @@ package claude
 import (
 	"bufio"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/logging"
+	"github.com/blackwell-systems/claudewatch/internal/workers"
 )
 
 // AgentSpan represents a single agent task extracted from a session transcript.
@@ -26,22 +31,92 @@ type AgentSpan struct {
 	ResultLength int           `json:"result_length"`
 	ToolUseID    string        `json:"tool_use_id"`
 	TotalTokens  int           `json:"total_tokens"`
+	// NextActionAt is when the session next edited a file or ran git commit
+	// after the agent finished, or zero if it never did.
+	NextActionAt time.Time `json:"next_action_at"`
+}
+
+// transcriptReadAttempts is how many times a transcript is read before it is
+// skipped. Claude Code appends to the live session's file, so a read can
+// catch it mid-write; a short pause is usually enough for it to settle.
+const transcriptReadAttempts = 2
+
+// transcriptRetryDelay is the pause between read attempts. Tests shorten it.
+var transcriptRetryDelay = 100 * time.Millisecond
+
+// ParseReport summarizes a transcript walk. Files that fail to read are
+// recorded here instead of failing the walk.
+type ParseReport struct {
+	// Transcripts is the number of .jsonl files found.
+	Transcripts int `json:"transcripts"`
+	// Skipped lists the files that could not be read in full.
+	Skipped []SkippedTranscript `json:"skipped,omitempty"`
+}
+
+// SkippedTranscript is a transcript that could not be read in full.
+type SkippedTranscript struct {
+	Path string `json:"path"`
+	Err  string `json:"error"`
+	// Recovered is the number of agent spans kept from the lines read
+	// before the error.
+	Recovered int `json:"recovered,omitempty"`
 }
 
+// ProgressFunc receives periodic progress from a long parse: done of total
+// files have been read. Calls are serialized, never concurrent.
+type ProgressFunc func(done, total int)
+
+// progressSteps is roughly how many progress reports a parse makes, so a
+// walk over thousands of files doesn't flood the callback.
+const progressSteps = 20
+
 // ParseSessionTranscripts scans all JSONL files under claudeDir/projects/
 // and extracts AgentSpan data from Task tool_use / tool_result pairs.
+// Unreadable files are logged and skipped; use ParseSessionTranscriptsReport
+// to find out which.
 func ParseSessionTranscripts(claudeDir string) ([]AgentSpan, error) {
+	spans, _, err := ParseSessionTranscriptsReport(claudeDir)
+	return spans, err
+}
+
+// ParseSessionTranscriptsReport is ParseSessionTranscripts with a report of
+// the files it skipped. Each file is parsed in isolation: a read error is
+// retried once, and if it persists the file is recorded in the report along
+// with any spans recovered before the error. Only a failure to list the
+// projects directory itself is returned as an error.
+func ParseSessionTranscriptsReport(claudeDir string) ([]AgentSpan, ParseReport, error) {
+	return parseSessionTranscripts(claudeDir, nil)
+}
+
+// ParseSessionTranscriptsProgress is ParseSessionTranscripts, calling
+// progress about every twentieth of the files and once more when the last
+// one is read. A nil progress reports nothing.
+func ParseSessionTranscriptsProgress(claudeDir string, progress ProgressFunc) ([]AgentSpan, error) {
+	spans, _, err := parseSessionTranscripts(claudeDir, progress)
+	return spans, err
+}
+
+// parseSessionTranscripts implements ParseSessionTranscriptsReport and
+// ParseSessionTranscriptsProgress.
+func parseSessionTranscripts(claudeDir string, progress ProgressFunc) ([]AgentSpan, ParseReport, error) {
+	var report ParseReport
 	projectsDir := filepath.Join(claudeDir, "projects")
-	entries, err := os.ReadDir(projectsDir)
+	done := logging.Phase("parse agent transcripts", "dir", projectsDir)
+
+	entries, err := readDir(projectsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			logging.Debug("no projects directory", "dir", projectsDir)
+			return nil, report, nil
 		}
-		return nil, err
+		logging.Warn("reading projects directory", "dir", projectsDir, "err", err)
+		return nil, report, err
 	}
 
-	var allSpans []AgentSpan
-
+	type transcriptFile struct {
+		path, projectHash string
+	}
+	var files []transcriptFile
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -49,37 +124,95 @@ func ParseSessionTranscripts(claudeDir string) ([]AgentSpan, error) {
 		projectHash := entry.Name()
 		dirPath := filepath.Join(projectsDir, projectHash)
 
-		files, err := os.ReadDir(dirPath)
+		dirFiles, err := readDir(dirPath)
 		if err != nil {
+			logging.Warn("reading project directory", "dir", dirPath, "err", err)
 			continue
 		}
 
-		for _, f := range files {
+		for _, f := range dirFiles {
 			if f.IsDir() || !strings.HasSuffix(f.Name(), ".jsonl") {
 				continue
 			}
+			files = append(files, transcriptFile{path: filepath.Join(dirPath, f.Name()), projectHash: projectHash})
+		}
+	}
+	report.Transcripts = len(files)
+	tick := progressTicker(len(files), progress)
+
+	// Parse in parallel into per-file slots so spans and skipped files come
+	// out in the same order as a sequential walk.
+	spansByFile := make([][]AgentSpan, len(files))
+	errsByFile := make([]error, len(files))
+	workers.Each(len(files), func(i int) {
+		spans, err := parseTranscriptWithRetry(files[i].path)
+		if err != nil {
+			logging.Warn("skipping transcript", "path", files[i].path, "err", err, "recovered_spans", len(spans))
+		}
+		// Fill in project hash for all spans.
+		for j := range spans {
+			spans[j].ProjectHash = files[i].projectHash
+		}
+		spansByFile[i], errsByFile[i] = spans, err
+		tick()
+	})
 
-			filePath := filepath.Join(dirPath, f.Name())
-			spans, err := ParseSingleTranscript(filePath)
-			if err != nil {
-				continue
-			}
+	var allSpans []AgentSpan
+	for i, spans := range spansByFile {
+		if err := errsByFile[i]; err != nil {
+			report.Skipped = append(report.Skipped, SkippedTranscript{Path: files[i].path, Err: err.Error(), Recovered: len(spans)})
+		}
+		allSpans = append(allSpans, spans...)
+	}
 
-			// Fill in project hash for all spans.
-			for i := range spans {
-				spans[i].ProjectHash = projectHash
-			}
+	done("transcripts", report.Transcripts, "agent_spans", len(allSpans), "skipped", len(report.Skipped))
+	return allSpans, report, nil
+}
 
-			allSpans = append(allSpans, spans...)
+// progressTicker returns a func to call as each of total files is read. It
+// passes the count to progress every total/progressSteps files and on the
+// last one. Safe for concurrent use.
+func progressTicker(total int, progress ProgressFunc) func() {
+	if progress == nil {
+		return func() {}
+	}
+	step := max(1, total/progressSteps)
+	var mu sync.Mutex
+	done := 0
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if done%step == 0 || done == total {
+			progress(done, total)
 		}
 	}
+}
 
-	return allSpans, nil
+// parseTranscriptWithRetry parses path, retrying once after
+// transcriptRetryDelay when the read fails. Missing files and permission
+// errors are not retried, as waiting won't fix them.
+func parseTranscriptWithRetry(path string) ([]AgentSpan, error) {
+	var spans []AgentSpan
+	var err error
+	for attempt := 1; attempt <= transcriptReadAttempts; attempt++ {
+		spans, err = ParseSingleTranscript(path)
+		if err == nil || os.IsNotExist(err) || os.IsPermission(err) {
+			break
+		}
+		if attempt < transcriptReadAttempts {
+			logging.Debug("retrying transcript", "path", path, "err", err)
+			time.Sleep(transcriptRetryDelay)
+		}
+	}
+	return spans, err
 }
 
-// ParseSingleTranscript parses one JSONL file and returns agent spans.
+// ParseSingleTranscript parses one JSONL file and returns agent spans. If
+// reading fails partway, the spans from the lines read so far are returned
+// along with the error.
 func ParseSingleTranscript(path string) ([]AgentSpan, error) {
-	f, err := os.Open(path)
+	f, err := openFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +236,8 @@ func ParseSingleTranscript(path string) ([]AgentSpan, error) {
 	taskNotifications := make(map[string]taskNotification)
 
 	var spans []AgentSpan
+	// Times of the session's file edits and commits, for NextActionAt.
+	var actions []time.Time
 
 	scanner := bufio.NewScanner(f)
 	// Increase buffer for long JSONL lines (up to 10MB).
@@ -118,7 +253,7 @@ func ParseSingleTranscript(path string) ([]AgentSpan, error) {
 
 		switch entry.Type {
 		case "assistant":
-			processAssistantEntry(&entry, sessionID, pending, killedAgentIDs)
+			processAssistantEntry(&entry, sessionID, pending, killedAgentIDs, &actions)
 		case "user":
 			processUserEntry(&entry, pending, &spans)
 		case "progress":
@@ -127,6 +262,7 @@ func ParseSingleTranscript(path string) ([]AgentSpan, error) {
 			processQueueOperationEntry(&entry, taskNotifications)
 		}
 	}
+	readErr := scanner.Err()
 
 	// Mark killed tasks using the agentId -> toolUseId mapping.
 	for agentID := range killedAgentIDs {
@@ -148,9 +284,13 @@ func ParseSingleTranscript(path string) ([]AgentSpan, error) {
 	}
 
 	// Any remaining pending tasks never got a result — mark them incomplete.
-	for _, p := range pending {
-		p.span.Success = false
-		spans = append(spans, p.span)
+	// After a read error their result may be in the unread part of the file,
+	// so leave them out rather than count them as failures.
+	if readErr == nil {
+		for _, p := range pending {
+			p.span.Success = false
+			spans = append(spans, p.span)
+		}
 	}
 
 	// Backfill real completion times for background agents. Background task
@@ -171,6 +311,13 @@ func ParseSingleTranscript(path string) ([]AgentSpan, error) {
 		}
 	}
 
+	for i := range spans {
+		spans[i].NextActionAt = firstActionAfter(actions, spans[i].CompletedAt)
+	}
+
+	if readErr != nil {
+		return spans, fmt.Errorf("reading %s: %w", filepath.Base(path), readErr)
+	}
 	return spans, nil
 }
 
@@ -244,8 +391,9 @@ type taskNotification struct {
 }
 
 // processAssistantEntry handles assistant-type entries, extracting Task
-// launches and TaskStop calls.
-func processAssistantEntry(entry *TranscriptEntry, sessionID string, pending map[string]*pendingTask, killedAgentIDs map[string]bool) {
+// launches and TaskStop calls and appending the time of any file edit or
+// git commit to actions.
+func processAssistantEntry(entry *TranscriptEntry, sessionID string, pending map[string]*pendingTask, killedAgentIDs map[string]bool, actions *[]time.Time) {
 	if entry.Message == nil {
 		return
 	}
@@ -296,10 +444,42 @@ func processAssistantEntry(entry *TranscriptEntry, sessionID string, pending map
 			if input.TaskID != "" {
 				killedAgentIDs[input.TaskID] = true
 			}
+
+		case block.Type == "tool_use" && isActionToolUse(block):
+			if !ts.IsZero() {
+				*actions = append(*actions, ts)
+			}
 		}
 	}
 }
 
+// isActionToolUse reports whether a tool_use block acts on the work: edits a
+// file or runs git commit.
+func isActionToolUse(block ContentBlock) bool {
+	switch block.Name {
+	case "Edit", "MultiEdit", "Write", "NotebookEdit":
+		return true
+	case "Bash":
+		return strings.Contains(string(block.Input), "git commit")
+	}
+	return false
+}
+
+// firstActionAfter returns the earliest of actions at or after t, or zero
+// when t is zero or none follow it.
+func firstActionAfter(actions []time.Time, t time.Time) time.Time {
+	var first time.Time
+	if t.IsZero() {
+		return first
+	}
+	for _, a := range actions {
+		if !a.Before(t) && (first.IsZero() || a.Before(first)) {
+			first = a
+		}
+	}
+	return first
+}
+
 // processUserEntry handles user-type entries, looking for tool_result blocks
 // that complete a pending Task.
 func processUserEntry(entry *TranscriptEntry, pending map[string]*pendingTask, spans *[]AgentSpan) {
@@ -437,7 +617,7 @@ func resultContentLength(raw json.RawMessage, text string) int {
 // hash (the directory name under projects/).
 func WalkTranscriptEntries(claudeDir string, fn func(entry TranscriptEntry, sessionID string, projectHash string)) error {
 	projectsDir := filepath.Join(claudeDir, "projects")
-	projectDirs, err := os.ReadDir(projectsDir)
+	projectDirs, err := readDir(projectsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
@@ -452,7 +632,7 @@ func WalkTranscriptEntries(claudeDir string, fn func(entry TranscriptEntry, sess
 		projectHash := projEntry.Name()
 		dirPath := filepath.Join(projectsDir, projectHash)
 
-		files, err := os.ReadDir(dirPath)
+		files, err := readDir(dirPath)
 		if err != nil {
 			continue
 		}
@@ -465,7 +645,7 @@ func WalkTranscriptEntries(claudeDir string, fn func(entry TranscriptEntry, sess
 			filePath := filepath.Join(dirPath, f.Name())
 			sessionID := strings.TrimSuffix(f.Name(), ".jsonl")
 
-			file, err := os.Open(filePath)
+			file, err := openFile(filePath)
 			if err != nil {
 				continue
 			}
@@ -487,23 +667,12 @@ func WalkTranscriptEntries(claudeDir string, fn func(entry TranscriptEntry, sess
 	return nil
 }
 
-// ParseTimestamp parses an ISO 8601 timestamp string. It tries RFC3339Nano,
-// RFC3339, and a plain datetime format without timezone. Returns the zero time
-// if the string is empty or cannot be parsed by any supported format.
+// ParseTimestamp parses a timestamp with ParseSessionTime, returning the zero
+// time if the string is empty or cannot be parsed by any supported format.
 func ParseTimestamp(s string) time.Time {
-	if s == "" {
-		return time.Time{}
-	}
-	t, err := time.Parse(time.RFC3339Nano, s)
+	t, err := ParseSessionTime(s)
 	if err != nil {
-		t, err = time.Parse(time.RFC3339, s)
-		if err != nil {
-			// Fallback for datetime strings without a timezone suffix.
-			t, err = time.Parse("2006-01-02T15:04:05", s)
-			if err != nil {
-				return time.Time{}
-			}
-		}
+		return time.Time{}
 	}
 	return t
 }
@@ -1,6 +1,7 @@
 package claude
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -265,6 +266,36 @@ func TestParseSingleTranscript_ErrorResult(t *testing.T) {
 	}
 }
 
+func TestParseSingleTranscript_NextActionAt(t *testing.T) {
+	dir := t.TempDir()
+	jsonl := strings.Join([]string{
+		// An edit before the agent finishes doesn't count.
+		`{"type":"assistant","timestamp":"2026-01-15T09:59:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_e0","name":"Edit","input":{"file_path":"a.go"}}]}}`,
+		`{"type":"assistant","timestamp":"2026-01-15T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_a","name":"Task","input":{"subagent_type":"Explore","description":"Find callers"}},{"type":"tool_use","id":"tu_b","name":"Task","input":{"subagent_type":"Explore","description":"Find tests"}}]}}`,
+		`{"type":"user","timestamp":"2026-01-15T10:02:00Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tu_a","content":"callers"}]}}`,
+		// Reading is not acting.
+		`{"type":"assistant","timestamp":"2026-01-15T10:03:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_r","name":"Read","input":{"file_path":"a.go"}}]}}`,
+		`{"type":"assistant","timestamp":"2026-01-15T10:04:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_c","name":"Bash","input":{"command":"git commit -m fix"}}]}}`,
+		`{"type":"user","timestamp":"2026-01-15T10:05:00Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tu_b","content":"tests"}]}}`,
+	}, "\n")
+
+	spans, err := ParseSingleTranscript(writeJSONL(t, dir, "s.jsonl", jsonl))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	want := time.Date(2026, 1, 15, 10, 4, 0, 0, time.UTC)
+	if !spans[0].NextActionAt.Equal(want) {
+		t.Errorf("first agent NextActionAt = %v, want the commit at %v", spans[0].NextActionAt, want)
+	}
+	if !spans[1].NextActionAt.IsZero() {
+		t.Errorf("second agent NextActionAt = %v, want zero (nothing followed it)", spans[1].NextActionAt)
+	}
+}
+
 func TestParseSessionTranscripts_IntegrationWithProjectHash(t *testing.T) {
 	// Set up a fake claude dir with projects/<hash>/<session>.jsonl
 	claudeDir := t.TempDir()
@@ -304,6 +335,81 @@ func TestParseSessionTranscripts_MissingProjectsDir(t *testing.T) {
 	}
 }
 
+func TestParseSessionTranscriptsReport_SkipsUnreadableFiles(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("root can read files regardless of mode")
+	}
+	transcriptRetryDelay = time.Millisecond
+	t.Cleanup(func() { transcriptRetryDelay = 100 * time.Millisecond })
+
+	claudeDir := t.TempDir()
+	projectDir := filepath.Join(claudeDir, "projects", "abc123")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	jsonl := strings.Join([]string{
+		`{"type":"assistant","timestamp":"2026-01-15T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_ok","name":"Task","input":{"subagent_type":"helper","description":"Help","prompt":"Help me","run_in_background":false}}]}}`,
+		`{"type":"user","timestamp":"2026-01-15T10:01:00Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tu_ok","content":"Helped","is_error":false}]}}`,
+	}, "\n")
+	writeJSONL(t, projectDir, "a-readable.jsonl", jsonl)
+	locked := writeJSONL(t, projectDir, "b-locked.jsonl", jsonl)
+	writeJSONL(t, projectDir, "c-readable.jsonl", jsonl)
+	if err := os.Chmod(locked, 0o000); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(locked, 0o644) })
+
+	spans, report, err := ParseSessionTranscriptsReport(claudeDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spans) != 2 {
+		t.Errorf("expected 2 spans from the readable files, got %d", len(spans))
+	}
+	if report.Transcripts != 3 {
+		t.Errorf("Transcripts = %d, want 3", report.Transcripts)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Path != locked {
+		t.Fatalf("Skipped = %+v, want only %s", report.Skipped, locked)
+	}
+	if report.Skipped[0].Err == "" {
+		t.Error("expected the skip to carry the read error")
+	}
+}
+
+func TestParseSessionTranscriptsReport_RecoversSpansBeforeReadError(t *testing.T) {
+	transcriptRetryDelay = time.Millisecond
+	t.Cleanup(func() { transcriptRetryDelay = 100 * time.Millisecond })
+
+	claudeDir := t.TempDir()
+	projectDir := filepath.Join(claudeDir, "projects", "abc123")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	// A completed agent, a launch whose result would come after the bad
+	// line, then a line longer than the scanner accepts.
+	jsonl := strings.Join([]string{
+		`{"type":"assistant","timestamp":"2026-01-15T10:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_done","name":"Task","input":{"subagent_type":"helper","description":"Help","prompt":"Help me","run_in_background":false}}]}}`,
+		`{"type":"user","timestamp":"2026-01-15T10:01:00Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tu_done","content":"Helped","is_error":false}]}}`,
+		`{"type":"assistant","timestamp":"2026-01-15T10:02:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_open","name":"Task","input":{"subagent_type":"helper","description":"More","prompt":"More","run_in_background":false}}]}}`,
+		strings.Repeat("x", 11*1024*1024),
+	}, "\n")
+	path := writeJSONL(t, projectDir, "sess1.jsonl", jsonl)
+
+	spans, report, err := ParseSessionTranscriptsReport(claudeDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spans) != 1 || spans[0].ToolUseID != "tu_done" || !spans[0].Success {
+		t.Errorf("expected only the completed span to be recovered, got %+v", spans)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Path != path || report.Skipped[0].Recovered != 1 {
+		t.Errorf("Skipped = %+v, want %s with 1 recovered span", report.Skipped, path)
+	}
+}
+
 func TestResultContentLength(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -362,7 +468,7 @@ func TestParseTimestamp(t *testing.T) {
 		{"datetime without timezone", "2026-01-15T10:00:00", false},
 		{"empty", "", true},
 		{"invalid", "not-a-date", true},
-		{"partial date", "2026-01-15", true},
+		{"date only", "2026-01-15", false},
 	}
 
 	for _, tc := range tests {
@@ -486,3 +592,34 @@ func TestWalkTranscriptEntries_SkipsNonJSONLFiles(t *testing.T) {
 		t.Errorf("expected 1 entry, got %d", count)
 	}
 }
+
+func TestParseSessionTranscriptsProgress(t *testing.T) {
+	claudeDir := t.TempDir()
+	projectDir := filepath.Join(claudeDir, "projects", "abc123")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for i := range 45 {
+		writeJSONL(t, projectDir, fmt.Sprintf("s%02d.jsonl", i), `{"type":"user","timestamp":"2026-01-15T10:00:00Z","message":{"role":"user","content":"hi"}}`)
+	}
+
+	var reports [][2]int
+	_, err := ParseSessionTranscriptsProgress(claudeDir, func(done, total int) {
+		reports = append(reports, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 45 files report every 2, plus the last.
+	if len(reports) != 23 {
+		t.Fatalf("got %d reports, want 23: %v", len(reports), reports)
+	}
+	for i, r := range reports {
+		if r[1] != 45 || (i > 0 && r[0] <= reports[i-1][0]) {
+			t.Fatalf("reports = %v, want increasing counts out of 45", reports)
+		}
+	}
+	if last := reports[len(reports)-1]; last[0] != 45 {
+		t.Errorf("last report = %v, want 45 of 45", last)
+	}
+}
@@ -80,6 +80,7 @@ type SessionMeta struct {
 	UserResponseTimes        []float64             `json:"user_response_times"`
 	ToolErrors               int                   `json:"tool_errors"`
 	ToolErrorCategories      map[string]int        `json:"tool_error_categories"`
+	ToolErrorsByTool         map[string]int        `json:"tool_errors_by_tool"`
 	UsesTaskAgent            bool                  `json:"uses_task_agent"`
 	UsesMCP                  bool                  `json:"uses_mcp"`
 	UsesWebSearch            bool                  `json:"uses_web_search"`
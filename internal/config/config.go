@@ -1,55 +1,392 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// ProfileEnvVar names the environment variable that pins the active profile
+// when no profile is passed explicitly.
+const ProfileEnvVar = "CLAUDEWATCH_PROFILE"
+
+// ProjectConfigFile is the name of the per-project config file, read from a
+// project's root, whose thresholds override the global config for that
+// project.
+const ProjectConfigFile = ".claudewatch.yaml"
+
 // Config is the top-level claudewatch configuration.
 type Config struct {
-	ScanPaths       []string                    `mapstructure:"scan_paths"`
-	ClaudeHome      string                      `mapstructure:"claude_home"`
-	ActiveThreshold int                         `mapstructure:"active_threshold"`
-	Weights         Weights                     `mapstructure:"weights"`
-	Friction        Friction                    `mapstructure:"friction"`
-	Output          Output                      `mapstructure:"output"`
-	CustomMetrics   map[string]MetricDefinition `mapstructure:"custom_metrics"`
+	ScanPaths       []string                    `mapstructure:"scan_paths" json:"scan_paths"`
+	ClaudeHome      string                      `mapstructure:"claude_home" json:"claude_home"`
+	ActiveThreshold int                         `mapstructure:"active_threshold" json:"active_threshold"`
+	Weights         Weights                     `mapstructure:"weights" json:"weights"`
+	Friction        Friction                    `mapstructure:"friction" json:"friction"`
+	Output          Output                      `mapstructure:"output" json:"output"`
+	Budget          Budget                      `mapstructure:"budget" json:"budget"`
+	Baseline        Baseline                    `mapstructure:"baseline" json:"baseline"`
+	NoiseBands      map[string]NoiseBand        `mapstructure:"noise_bands" json:"noise_bands,omitempty"`
+	AgentAliases    map[string]string           `mapstructure:"agent_aliases" json:"agent_aliases,omitempty"`
+	Thresholds      Thresholds                  `mapstructure:"thresholds" json:"thresholds"`
+	SuggestionTTL   SuggestionTTL               `mapstructure:"suggestion_ttl" json:"suggestion_ttl"`
+	UpdateCheck     UpdateCheck                 `mapstructure:"update_check" json:"update_check"`
+	ReadinessVolume ReadinessVolume             `mapstructure:"readiness_volume" json:"readiness_volume"`
+	HealthWeights   HealthWeights               `mapstructure:"health_weights" json:"health_weights"`
+	Pricing         Pricing                     `mapstructure:"pricing" json:"pricing"`
+	CustomMetrics   map[string]MetricDefinition `mapstructure:"custom_metrics" json:"custom_metrics"`
+
+	// Timezone is the IANA zone (e.g. "Europe/Berlin") used to interpret and
+	// bucket session timestamps. Empty means the system local zone.
+	Timezone string `mapstructure:"timezone" json:"timezone"`
+
+	// ResumeGapMinutes is the longest gap between sessions on the same
+	// project for the later one to count as a resume of the earlier.
+	ResumeGapMinutes int `mapstructure:"resume_gap_minutes" json:"resume_gap_minutes"`
+
+	// ContextWindowTokens is the model context window, in tokens, that
+	// session context sizes are compared against.
+	ContextWindowTokens int `mapstructure:"context_window_tokens" json:"context_window_tokens"`
+
+	// FirstPromptBuckets are the ascending first-prompt length boundaries,
+	// in characters, used to relate prompt length to friction and outcome.
+	// The first bucket holds very short prompts and the last very long ones.
+	FirstPromptBuckets []int `mapstructure:"first_prompt_buckets" json:"first_prompt_buckets"`
+
+	// TrivialSession defines the quick one-off sessions that some analyses
+	// leave out.
+	TrivialSession TrivialSession `mapstructure:"trivial_session" json:"trivial_session"`
+
+	// Sessions holds defaults for the sessions command.
+	Sessions Sessions `mapstructure:"sessions" json:"sessions"`
+
+	// Offline blocks every network request: AI fixes, update checks, and
+	// fetching pricing.url. The --offline flag turns it on for one run.
+	Offline bool `mapstructure:"offline" json:"offline"`
+
+	// ReadOnly opens the claudewatch database read-only, so nothing is
+	// recorded and track only previews. The --read-only flag turns it on
+	// for one run.
+	ReadOnly bool `mapstructure:"read_only" json:"read_only"`
+
+	// RedactPrompts replaces session first prompts with their length
+	// wherever they are shown or sent to the AI fixer. The --redact-prompts
+	// flag turns it on for one run.
+	RedactPrompts bool `mapstructure:"redact_prompts" json:"redact_prompts"`
+
+	// ClaudeMDSections adds CLAUDE.md sections to detect and score, or
+	// replaces the built-in section with the same name.
+	ClaudeMDSections []ClaudeMDSection `mapstructure:"claude_md_sections" json:"claude_md_sections,omitempty"`
+
+	// ClaudeMDStaleDays is how long a CLAUDE.md can go unchanged before
+	// heavy code churn since then makes gaps flag it as stale.
+	ClaudeMDStaleDays int `mapstructure:"claude_md_stale_days" json:"claude_md_stale_days"`
+
+	// AgentResultWindowMinutes is how soon after an agent finishes an edit
+	// or commit must follow for its result to count as used.
+	AgentResultWindowMinutes int `mapstructure:"agent_result_window_minutes" json:"agent_result_window_minutes"`
+
+	// Jobs caps how many projects or transcripts are processed at once. 0
+	// means one per CPU; 1 processes them sequentially.
+	Jobs int `mapstructure:"jobs" json:"jobs"`
+
+	// Profile is the name of the profile merged over the base config, or ""
+	// when none is active. It is not read from the config file.
+	Profile string `mapstructure:"-" json:"profile,omitempty"`
+
+	// ProjectFile is the project config file merged over this config by
+	// ForProject, or "" for the global config.
+	ProjectFile string `mapstructure:"-" json:"project_file,omitempty"`
 }
 
 // Weights defines the scoring weights for project readiness.
 type Weights struct {
-	ClaudeMDExists    float64 `mapstructure:"claude_md_exists"`
-	ClaudeMDQuality   float64 `mapstructure:"claude_md_quality"`
-	DotClaudeDir      float64 `mapstructure:"dot_claude_dir"`
-	LocalSettings     float64 `mapstructure:"local_settings"`
-	SessionHistory    float64 `mapstructure:"session_history"`
-	FacetsCoverage    float64 `mapstructure:"facets_coverage"`
-	ActiveDevelopment float64 `mapstructure:"active_development"`
-	HookAdoption      float64 `mapstructure:"hook_adoption"`
-	PluginUsage       float64 `mapstructure:"plugin_usage"`
+	ClaudeMDExists    float64 `mapstructure:"claude_md_exists" json:"claude_md_exists"`
+	ClaudeMDQuality   float64 `mapstructure:"claude_md_quality" json:"claude_md_quality"`
+	DotClaudeDir      float64 `mapstructure:"dot_claude_dir" json:"dot_claude_dir"`
+	LocalSettings     float64 `mapstructure:"local_settings" json:"local_settings"`
+	SessionHistory    float64 `mapstructure:"session_history" json:"session_history"`
+	FacetsCoverage    float64 `mapstructure:"facets_coverage" json:"facets_coverage"`
+	ActiveDevelopment float64 `mapstructure:"active_development" json:"active_development"`
+	HookAdoption      float64 `mapstructure:"hook_adoption" json:"hook_adoption"`
+	PluginUsage       float64 `mapstructure:"plugin_usage" json:"plugin_usage"`
 }
 
 // Friction defines thresholds for friction analysis.
 type Friction struct {
-	RecurringThreshold  float64 `mapstructure:"recurring_threshold"`
-	HighErrorMultiplier float64 `mapstructure:"high_error_multiplier"`
+	RecurringThreshold  float64 `mapstructure:"recurring_threshold" json:"recurring_threshold"`
+	HighErrorMultiplier float64 `mapstructure:"high_error_multiplier" json:"high_error_multiplier"`
+	// StaleWeeks is how many consecutive weeks a friction type must persist
+	// without improving before it is reported as stale. Must be at least 2.
+	StaleWeeks int `mapstructure:"stale_weeks" json:"stale_weeks"`
 }
 
 // Output defines output preferences.
 type Output struct {
-	Color bool `mapstructure:"color"`
-	Width int  `mapstructure:"width"`
+	Color bool `mapstructure:"color" json:"color"`
+	Width int  `mapstructure:"width" json:"width"`
+	// Theme names the color theme: default, light, high-contrast, or mono.
+	Theme string `mapstructure:"theme" json:"theme"`
+}
+
+// TrivialSession defines a trivial session: one shorter than
+// MinDurationMinutes with fewer than MinUserMessages user messages. Meeting
+// either threshold makes a session non-trivial.
+type TrivialSession struct {
+	MinDurationMinutes int `mapstructure:"min_duration_minutes" json:"min_duration_minutes"`
+	MinUserMessages    int `mapstructure:"min_user_messages" json:"min_user_messages"`
+}
+
+// Sessions holds defaults for the sessions command.
+type Sessions struct {
+	// DefaultDays is how many days the session list looks back when --days
+	// isn't given.
+	DefaultDays int `mapstructure:"default_days" json:"default_days"`
+}
+
+// Budget defines spending caps.
+type Budget struct {
+	// MonthlyUSD caps estimated spend per calendar month; 0 means no cap.
+	MonthlyUSD float64 `mapstructure:"monthly_usd" json:"monthly_usd"`
+}
+
+// Baseline sets how far metrics may fall behind a `metrics --baseline` file
+// before they count as regressed.
+type Baseline struct {
+	// TolerancePct is how much worse than its baseline value, as a percent
+	// of that value, a metric may get without regressing.
+	TolerancePct float64 `mapstructure:"tolerance_pct" json:"tolerance_pct"`
+	// Tolerances overrides TolerancePct for the named metrics.
+	Tolerances map[string]float64 `mapstructure:"tolerances" json:"tolerances,omitempty"`
+}
+
+// Tolerance returns the tolerance percent for the named metric.
+func (b Baseline) Tolerance(metric string) float64 {
+	if pct, ok := b.Tolerances[metric]; ok {
+		return pct
+	}
+	return b.TolerancePct
+}
+
+// Validate rejects negative tolerances.
+func (b Baseline) Validate() error {
+	if b.TolerancePct < 0 {
+		return fmt.Errorf("tolerance_pct %g must not be negative", b.TolerancePct)
+	}
+	for metric, pct := range b.Tolerances {
+		if pct < 0 {
+			return fmt.Errorf("tolerance for %s %g must not be negative", metric, pct)
+		}
+	}
+	return nil
+}
+
+// NoiseBand is the smallest change in a track metric that counts as a real
+// move rather than noise. A change must exceed both Abs, in the metric's own
+// unit, and Pct percent of the earlier value; a zero field doesn't apply.
+type NoiseBand struct {
+	Abs float64 `mapstructure:"abs" json:"abs"`
+	Pct float64 `mapstructure:"pct" json:"pct"`
+}
+
+// Validate rejects negative bands.
+func (n NoiseBand) Validate() error {
+	if n.Abs < 0 || n.Pct < 0 {
+		return fmt.Errorf("abs %g and pct %g must not be negative", n.Abs, n.Pct)
+	}
+	return nil
+}
+
+// Thresholds sets when gaps and suggest flag a project.
+type Thresholds struct {
+	// ZeroCommitRate is the share of sessions without a commit above which
+	// suggest flags the workflow.
+	ZeroCommitRate float64 `mapstructure:"zero_commit_rate" json:"zero_commit_rate"`
+	// AgentKillRate is the share of a project's agents of one type killed
+	// before finishing above which suggest flags that type.
+	AgentKillRate float64 `mapstructure:"agent_kill_rate" json:"agent_kill_rate"`
+	// ClaudeMDQuality is the CLAUDE.md quality score, out of 100, below
+	// which gaps flags a project.
+	ClaudeMDQuality int `mapstructure:"claude_md_quality" json:"claude_md_quality"`
+	// ProjectFrictionMultiplier is how many times the average friction per
+	// session a project must exceed for gaps to flag it.
+	ProjectFrictionMultiplier float64 `mapstructure:"project_friction_multiplier" json:"project_friction_multiplier"`
+}
+
+// Validate rejects rates outside (0, 1], scores outside 0-100, and
+// multipliers below 1.
+func (t Thresholds) Validate() error {
+	if t.ZeroCommitRate <= 0 || t.ZeroCommitRate > 1 {
+		return fmt.Errorf("zero_commit_rate %g must be greater than 0 and at most 1", t.ZeroCommitRate)
+	}
+	if t.AgentKillRate <= 0 || t.AgentKillRate > 1 {
+		return fmt.Errorf("agent_kill_rate %g must be greater than 0 and at most 1", t.AgentKillRate)
+	}
+	if t.ClaudeMDQuality < 0 || t.ClaudeMDQuality > 100 {
+		return fmt.Errorf("claude_md_quality %d must be between 0 and 100", t.ClaudeMDQuality)
+	}
+	if t.ProjectFrictionMultiplier < 1 {
+		return fmt.Errorf("project_friction_multiplier %g must be at least 1", t.ProjectFrictionMultiplier)
+	}
+	return nil
+}
+
+// SuggestionTTL sets when track expires a stored suggestion that stays open
+// without being raised again, for suggestions whose trigger track can't
+// re-check. Either limit expires it; zero turns a limit off.
+type SuggestionTTL struct {
+	// Snapshots is how many snapshots in a row may leave the suggestion out.
+	Snapshots int `mapstructure:"snapshots" json:"snapshots"`
+	// Days is how long after it was last raised the suggestion may stay open.
+	Days int `mapstructure:"days" json:"days"`
+}
+
+// Validate rejects negative limits.
+func (t SuggestionTTL) Validate() error {
+	if t.Snapshots < 0 {
+		return fmt.Errorf("snapshots %d must not be negative", t.Snapshots)
+	}
+	if t.Days < 0 {
+		return fmt.Errorf("days %d must not be negative", t.Days)
+	}
+	return nil
+}
+
+// UpdateCheck controls checking GitHub for newer releases.
+type UpdateCheck struct {
+	// Enabled allows any network check, including `claudewatch update-check`.
+	// Set it to false on offline machines.
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+	// Background runs a cached, at-most-daily check after interactive
+	// commands and prints a hint when a newer release exists.
+	Background bool `mapstructure:"background" json:"background"`
+}
+
+// ReadinessVolume tunes the session-volume weighting of readiness scores:
+// weighted = score × (1 + Weight × log_LogBase(1 + sessions)).
+type ReadinessVolume struct {
+	// LogBase is the logarithm base; must be greater than 1.
+	LogBase float64 `mapstructure:"log_base" json:"log_base"`
+	// Weight scales the volume term; 0 disables weighting.
+	Weight float64 `mapstructure:"weight" json:"weight"`
+}
+
+// HealthWeights sets the relative weight of each component of the project
+// health score. Components without data are dropped and the rest
+// renormalized, so the weights need not sum to 1.
+type HealthWeights struct {
+	Readiness float64 `mapstructure:"readiness" json:"readiness"`
+	Friction  float64 `mapstructure:"friction" json:"friction"`
+	Commits   float64 `mapstructure:"commits" json:"commits"`
+	Agents    float64 `mapstructure:"agents" json:"agents"`
+}
+
+// Validate rejects negative weights and an all-zero set.
+func (w HealthWeights) Validate() error {
+	for _, f := range []struct {
+		name   string
+		weight float64
+	}{
+		{"readiness", w.Readiness},
+		{"friction", w.Friction},
+		{"commits", w.Commits},
+		{"agents", w.Agents},
+	} {
+		if f.weight < 0 {
+			return fmt.Errorf("%s weight %g must not be negative", f.name, f.weight)
+		}
+	}
+	if w.Readiness+w.Friction+w.Commits+w.Agents == 0 {
+		return fmt.Errorf("at least one weight must be positive")
+	}
+	return nil
+}
+
+// Pricing overrides the compiled-in model pricing used for cost estimates.
+// Rates from URL are applied first, then Models, so local entries win.
+type Pricing struct {
+	// Models maps a model tier ("opus", "sonnet", "haiku") to its rates.
+	// Rates left at zero keep the built-in value.
+	Models map[string]ModelRates `mapstructure:"models" json:"models,omitempty"`
+	// URL is an optional JSON document of the same model → rates shape,
+	// fetched at most once a day and cached in the config directory.
+	URL string `mapstructure:"url" json:"url,omitempty"`
+}
+
+// ModelRates are the USD prices per million tokens for one model tier.
+type ModelRates struct {
+	Input      float64 `mapstructure:"input" json:"input"`
+	Output     float64 `mapstructure:"output" json:"output"`
+	CacheRead  float64 `mapstructure:"cache_read" json:"cache_read"`
+	CacheWrite float64 `mapstructure:"cache_write" json:"cache_write"`
+}
+
+// Validate rejects negative rates.
+func (r ModelRates) Validate() error {
+	for _, f := range []struct {
+		name string
+		rate float64
+	}{
+		{"input", r.Input},
+		{"output", r.Output},
+		{"cache_read", r.CacheRead},
+		{"cache_write", r.CacheWrite},
+	} {
+		if f.rate < 0 {
+			return fmt.Errorf("%s rate %g must not be negative", f.name, f.rate)
+		}
+	}
+	return nil
+}
+
+// ClaudeMDSection defines a CLAUDE.md section: it is present when a ## header
+// contains one of HeaderKeywords, or else when two lines contain one of
+// ContentKeywords, and then adds Weight points to the quality score.
+type ClaudeMDSection struct {
+	Name            string   `mapstructure:"name" json:"name"`
+	HeaderKeywords  []string `mapstructure:"header_keywords" json:"header_keywords"`
+	ContentKeywords []string `mapstructure:"content_keywords" json:"content_keywords"`
+	Weight          int      `mapstructure:"weight" json:"weight"`
+}
+
+// Validate requires a name, at least one header and one content keyword,
+// no blank keywords, and a non-negative weight.
+func (s ClaudeMDSection) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	for _, f := range []struct {
+		name     string
+		keywords []string
+	}{
+		{"header_keywords", s.HeaderKeywords},
+		{"content_keywords", s.ContentKeywords},
+	} {
+		if len(f.keywords) == 0 {
+			return fmt.Errorf("%s must not be empty", f.name)
+		}
+		for _, kw := range f.keywords {
+			if strings.TrimSpace(kw) == "" {
+				return fmt.Errorf("%s must not contain blank keywords", f.name)
+			}
+		}
+	}
+	if s.Weight < 0 {
+		return fmt.Errorf("weight %d must not be negative", s.Weight)
+	}
+	return nil
 }
 
 // MetricDefinition describes a user-defined custom metric.
 type MetricDefinition struct {
-	Type        string     `mapstructure:"type"`
-	Range       [2]float64 `mapstructure:"range"`
-	Direction   string     `mapstructure:"direction"`
-	Description string     `mapstructure:"description"`
+	Type        string     `mapstructure:"type" json:"type"`
+	Range       [2]float64 `mapstructure:"range" json:"range"`
+	Direction   string     `mapstructure:"direction" json:"direction"`
+	Description string     `mapstructure:"description" json:"description"`
 }
 
 // expandPath replaces a leading ~ with the user's home directory.
@@ -65,14 +402,35 @@ func expandPath(path string) string {
 }
 
 // Load reads configuration from the given path (or the default location)
-// and returns a Config with all defaults applied.
+// and returns a Config with all defaults applied. The profile named by
+// CLAUDEWATCH_PROFILE, if set, is merged over the base config.
 func Load(cfgFile string) (*Config, error) {
+	return LoadProfile(cfgFile, "")
+}
+
+// LoadProfile is like Load but merges the named entry of the top-level
+// `profiles` map over the base config. An empty profile falls back to the
+// CLAUDEWATCH_PROFILE environment variable; if that is also empty, no profile
+// is applied. Naming a profile that isn't defined is an error.
+func LoadProfile(cfgFile, profile string) (*Config, error) {
 	v := viper.New()
 
 	// Set defaults.
 	v.SetDefault("scan_paths", DefaultScanPaths)
 	v.SetDefault("claude_home", DefaultClaudeHome)
 	v.SetDefault("active_threshold", DefaultActiveThreshold)
+	v.SetDefault("resume_gap_minutes", DefaultResumeGapMinutes)
+	v.SetDefault("context_window_tokens", DefaultContextWindowTokens)
+	v.SetDefault("first_prompt_buckets", DefaultFirstPromptBuckets)
+	v.SetDefault("trivial_session.min_duration_minutes", DefaultTrivialSession.MinDurationMinutes)
+	v.SetDefault("trivial_session.min_user_messages", DefaultTrivialSession.MinUserMessages)
+	v.SetDefault("sessions.default_days", DefaultSessionsDays)
+	v.SetDefault("offline", false)
+	v.SetDefault("read_only", false)
+	v.SetDefault("redact_prompts", false)
+	v.SetDefault("jobs", DefaultJobs)
+	v.SetDefault("claude_md_stale_days", DefaultClaudeMDStaleDays)
+	v.SetDefault("agent_result_window_minutes", DefaultAgentResultWindowMinutes)
 	v.SetDefault("weights.claude_md_exists", DefaultWeights.ClaudeMDExists)
 	v.SetDefault("weights.claude_md_quality", DefaultWeights.ClaudeMDQuality)
 	v.SetDefault("weights.dot_claude_dir", DefaultWeights.DotClaudeDir)
@@ -84,8 +442,26 @@ func Load(cfgFile string) (*Config, error) {
 	v.SetDefault("weights.plugin_usage", DefaultWeights.PluginUsage)
 	v.SetDefault("friction.recurring_threshold", DefaultFriction.RecurringThreshold)
 	v.SetDefault("friction.high_error_multiplier", DefaultFriction.HighErrorMultiplier)
+	v.SetDefault("friction.stale_weeks", DefaultFriction.StaleWeeks)
 	v.SetDefault("output.color", DefaultOutput.Color)
 	v.SetDefault("output.width", DefaultOutput.Width)
+	v.SetDefault("output.theme", DefaultOutput.Theme)
+	v.SetDefault("budget.monthly_usd", DefaultBudget.MonthlyUSD)
+	v.SetDefault("baseline.tolerance_pct", DefaultBaseline.TolerancePct)
+	v.SetDefault("thresholds.zero_commit_rate", DefaultThresholds.ZeroCommitRate)
+	v.SetDefault("thresholds.agent_kill_rate", DefaultThresholds.AgentKillRate)
+	v.SetDefault("thresholds.claude_md_quality", DefaultThresholds.ClaudeMDQuality)
+	v.SetDefault("thresholds.project_friction_multiplier", DefaultThresholds.ProjectFrictionMultiplier)
+	v.SetDefault("suggestion_ttl.snapshots", DefaultSuggestionTTL.Snapshots)
+	v.SetDefault("suggestion_ttl.days", DefaultSuggestionTTL.Days)
+	v.SetDefault("update_check.enabled", DefaultUpdateCheck.Enabled)
+	v.SetDefault("update_check.background", DefaultUpdateCheck.Background)
+	v.SetDefault("readiness_volume.log_base", DefaultReadinessVolume.LogBase)
+	v.SetDefault("readiness_volume.weight", DefaultReadinessVolume.Weight)
+	v.SetDefault("health_weights.readiness", DefaultHealthWeights.Readiness)
+	v.SetDefault("health_weights.friction", DefaultHealthWeights.Friction)
+	v.SetDefault("health_weights.commits", DefaultHealthWeights.Commits)
+	v.SetDefault("health_weights.agents", DefaultHealthWeights.Agents)
 
 	if cfgFile != "" {
 		v.SetConfigFile(expandPath(cfgFile))
@@ -106,10 +482,99 @@ func Load(cfgFile string) (*Config, error) {
 		}
 	}
 
+	if profile == "" {
+		profile = os.Getenv(ProfileEnvVar)
+	}
+	if profile != "" {
+		if err := applyProfile(v, profile); err != nil {
+			return nil, err
+		}
+	}
+
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	cfg.Profile = profile
+
+	if _, err := cfg.Location(); err != nil {
+		return nil, err
+	}
+	if cfg.ResumeGapMinutes < 1 {
+		return nil, fmt.Errorf("invalid resume_gap_minutes %d: must be at least 1", cfg.ResumeGapMinutes)
+	}
+	if cfg.ContextWindowTokens < 1 {
+		return nil, fmt.Errorf("invalid context_window_tokens %d: must be at least 1", cfg.ContextWindowTokens)
+	}
+	if err := validateFirstPromptBuckets(cfg.FirstPromptBuckets); err != nil {
+		return nil, fmt.Errorf("invalid first_prompt_buckets %v: %w", cfg.FirstPromptBuckets, err)
+	}
+	if cfg.TrivialSession.MinDurationMinutes < 1 {
+		return nil, fmt.Errorf("invalid trivial_session.min_duration_minutes %d: must be at least 1", cfg.TrivialSession.MinDurationMinutes)
+	}
+	if cfg.TrivialSession.MinUserMessages < 1 {
+		return nil, fmt.Errorf("invalid trivial_session.min_user_messages %d: must be at least 1", cfg.TrivialSession.MinUserMessages)
+	}
+	if cfg.Sessions.DefaultDays < 1 {
+		return nil, fmt.Errorf("invalid sessions.default_days %d: must be at least 1", cfg.Sessions.DefaultDays)
+	}
+	if cfg.ClaudeMDStaleDays < 1 {
+		return nil, fmt.Errorf("invalid claude_md_stale_days %d: must be at least 1", cfg.ClaudeMDStaleDays)
+	}
+	if cfg.AgentResultWindowMinutes < 1 {
+		return nil, fmt.Errorf("invalid agent_result_window_minutes %d: must be at least 1", cfg.AgentResultWindowMinutes)
+	}
+	if cfg.Jobs < 0 {
+		return nil, fmt.Errorf("invalid jobs %d: must not be negative", cfg.Jobs)
+	}
+	if cfg.ReadinessVolume.LogBase <= 1 {
+		return nil, fmt.Errorf("invalid readiness_volume.log_base %g: must be greater than 1", cfg.ReadinessVolume.LogBase)
+	}
+	if cfg.ReadinessVolume.Weight < 0 {
+		return nil, fmt.Errorf("invalid readiness_volume.weight %g: must not be negative", cfg.ReadinessVolume.Weight)
+	}
+	if err := cfg.HealthWeights.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid health_weights: %w", err)
+	}
+	if err := cfg.Baseline.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid baseline: %w", err)
+	}
+	for metric, band := range cfg.NoiseBands {
+		if err := band.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid noise_bands.%s: %w", metric, err)
+		}
+	}
+	// Agent types are matched case-insensitively against lowercase keys.
+	aliases := make(map[string]string, len(cfg.AgentAliases))
+	for alias, canonical := range cfg.AgentAliases {
+		if strings.TrimSpace(canonical) == "" {
+			return nil, fmt.Errorf("invalid agent_aliases.%s: canonical name is empty", alias)
+		}
+		aliases[strings.ToLower(alias)] = canonical
+	}
+	cfg.AgentAliases = aliases
+	if err := cfg.Thresholds.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid thresholds: %w", err)
+	}
+	if err := cfg.SuggestionTTL.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid suggestion_ttl: %w", err)
+	}
+	if cfg.Friction.HighErrorMultiplier < 1 {
+		return nil, fmt.Errorf("invalid friction.high_error_multiplier %g: must be at least 1", cfg.Friction.HighErrorMultiplier)
+	}
+	if cfg.Friction.StaleWeeks < 2 {
+		return nil, fmt.Errorf("invalid friction.stale_weeks %d: must be at least 2", cfg.Friction.StaleWeeks)
+	}
+	for i, s := range cfg.ClaudeMDSections {
+		if err := s.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid claude_md_sections[%d] %q: %w", i, s.Name, err)
+		}
+	}
+	for model, rates := range cfg.Pricing.Models {
+		if err := rates.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid pricing for %q: %w", model, err)
+		}
+	}
 
 	// Apply custom metrics defaults if none configured.
 	if len(cfg.CustomMetrics) == 0 {
@@ -125,11 +590,142 @@ func Load(cfgFile string) (*Config, error) {
 	return &cfg, nil
 }
 
+// validateFirstPromptBuckets requires at least two positive, strictly
+// ascending boundaries, so there is a very short, a middle, and a very long
+// bucket.
+func validateFirstPromptBuckets(boundaries []int) error {
+	if len(boundaries) < 2 {
+		return fmt.Errorf("need at least two boundaries")
+	}
+	prev := 0
+	for _, b := range boundaries {
+		if b <= prev {
+			return fmt.Errorf("boundaries must be positive and strictly ascending")
+		}
+		prev = b
+	}
+	return nil
+}
+
+// Location returns the zone named by Timezone, or time.Local when it is empty.
+func (c *Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	return loc, nil
+}
+
+// applyProfile merges profiles.<name> over the base config held by v.
+func applyProfile(v *viper.Viper, name string) error {
+	profiles := v.GetStringMap("profiles")
+
+	// Viper lowercases keys, so match profile names case-insensitively.
+	raw, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		names := ProfileNames(profiles)
+		if len(names) == 0 {
+			return fmt.Errorf("unknown profile %q: no profiles defined in config", name)
+		}
+		return fmt.Errorf("unknown profile %q (defined profiles: %s)", name, strings.Join(names, ", "))
+	}
+
+	overrides, ok := raw.(map[string]any)
+	if !ok {
+		if raw == nil {
+			// An empty profile is valid and changes nothing.
+			return nil
+		}
+		return fmt.Errorf("profile %q must be a mapping of config keys", name)
+	}
+	return v.MergeConfigMap(overrides)
+}
+
+// projectConfigKeys are the keys a project config file may set. Anything
+// else, such as scan paths or pricing, only makes sense globally.
+var projectConfigKeys = map[string]bool{
+	"thresholds":                     true,
+	"friction.high_error_multiplier": true,
+	"claude_md_stale_days":           true,
+}
+
+// ForProject returns the effective config for the project at dir: c with
+// the project's ProjectConfigFile merged over it. It returns c itself when
+// the project has no such file. A file that can't be parsed, sets a key
+// projects can't override, or holds an invalid value is an error.
+func (c *Config) ForProject(dir string) (*Config, error) {
+	path := filepath.Join(dir, ProjectConfigFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return c, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var unknown []string
+	for _, key := range v.AllKeys() {
+		if !projectConfigKeys[key] && !projectConfigKeys[strings.SplitN(key, ".", 2)[0]] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%s: %s can't be set per project (allowed: thresholds.*, friction.high_error_multiplier, claude_md_stale_days)", path, strings.Join(unknown, ", "))
+	}
+
+	eff := *c
+	if err := v.Unmarshal(&eff); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	eff.ProjectFile = path
+
+	if err := eff.Thresholds.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: invalid thresholds: %w", path, err)
+	}
+	if eff.Friction.HighErrorMultiplier < 1 {
+		return nil, fmt.Errorf("%s: invalid friction.high_error_multiplier %g: must be at least 1", path, eff.Friction.HighErrorMultiplier)
+	}
+	if eff.ClaudeMDStaleDays < 1 {
+		return nil, fmt.Errorf("%s: invalid claude_md_stale_days %d: must be at least 1", path, eff.ClaudeMDStaleDays)
+	}
+	return &eff, nil
+}
+
+// ProfileNames returns the sorted names of the given profiles map.
+func ProfileNames(profiles map[string]any) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // DBPath returns the full path to the SQLite database.
 func DBPath() string {
 	return filepath.Join(expandPath(DefaultConfigDir), DefaultDBName)
 }
 
+// SuggestRulesPath returns the full path to the custom suggestion rules file.
+func SuggestRulesPath() string {
+	return filepath.Join(expandPath(DefaultConfigDir), DefaultSuggestRulesFile)
+}
+
+// FilePath returns the config file Load reads for cfgFile: cfgFile itself
+// with a leading ~ expanded, or the default config file when it is empty.
+func FilePath(cfgFile string) string {
+	if cfgFile != "" {
+		return expandPath(cfgFile)
+	}
+	return filepath.Join(expandPath(DefaultConfigDir), DefaultConfigFile)
+}
+
 // ConfigDir returns the expanded configuration directory.
 func ConfigDir() string {
 	return expandPath(DefaultConfigDir)
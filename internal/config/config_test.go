@@ -0,0 +1,609 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const profileConfig = `claude_home: /base/claude
+active_threshold: 30
+weights:
+  claude_md_exists: 10
+  hook_adoption: 5
+profiles:
+  ci:
+    active_threshold: 7
+    weights:
+      hook_adoption: 0
+  local: {}
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	return path
+}
+
+func TestLoadProfile_NoProfileUsesBase(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, profileConfig), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ActiveThreshold != 30 {
+		t.Errorf("ActiveThreshold = %d, want 30", cfg.ActiveThreshold)
+	}
+	if cfg.Profile != "" {
+		t.Errorf("Profile = %q, want empty", cfg.Profile)
+	}
+}
+
+func TestLoadProfile_MergesOverBase(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, profileConfig), "ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ActiveThreshold != 7 {
+		t.Errorf("ActiveThreshold = %d, want profile value 7", cfg.ActiveThreshold)
+	}
+	if cfg.Weights.HookAdoption != 0 {
+		t.Errorf("HookAdoption = %v, want profile value 0", cfg.Weights.HookAdoption)
+	}
+	// Keys the profile doesn't set keep their base values, including siblings
+	// inside a partially overridden section.
+	if cfg.Weights.ClaudeMDExists != 10 {
+		t.Errorf("ClaudeMDExists = %v, want base value 10", cfg.Weights.ClaudeMDExists)
+	}
+	if cfg.ClaudeHome != "/base/claude" {
+		t.Errorf("ClaudeHome = %q, want base value", cfg.ClaudeHome)
+	}
+	if cfg.Profile != "ci" {
+		t.Errorf("Profile = %q, want %q", cfg.Profile, "ci")
+	}
+}
+
+func TestLoadProfile_EmptyProfile(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, profileConfig), "local")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ActiveThreshold != 30 {
+		t.Errorf("ActiveThreshold = %d, want base value 30", cfg.ActiveThreshold)
+	}
+}
+
+func TestLoadProfile_EnvVarSelectsProfile(t *testing.T) {
+	path := writeConfig(t, profileConfig)
+	t.Setenv(ProfileEnvVar, "ci")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ActiveThreshold != 7 {
+		t.Errorf("ActiveThreshold = %d, want profile value 7", cfg.ActiveThreshold)
+	}
+
+	// An explicit profile takes precedence over the environment.
+	cfg, err = LoadProfile(path, "local")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Profile != "local" || cfg.ActiveThreshold != 30 {
+		t.Errorf("expected explicit profile to win, got profile %q threshold %d", cfg.Profile, cfg.ActiveThreshold)
+	}
+}
+
+func TestLoadProfile_UnknownProfileListsDefined(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	_, err := LoadProfile(writeConfig(t, profileConfig), "staging")
+	if err == nil {
+		t.Fatal("expected error for unknown profile, got nil")
+	}
+	if !strings.Contains(err.Error(), "ci, local") {
+		t.Errorf("expected error to list defined profiles, got %q", err)
+	}
+}
+
+func TestLoadProfile_UnknownProfileNoneDefined(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	_, err := LoadProfile(writeConfig(t, "active_threshold: 30\n"), "ci")
+	if err == nil {
+		t.Fatal("expected error for unknown profile, got nil")
+	}
+	if !strings.Contains(err.Error(), "no profiles defined") {
+		t.Errorf("unexpected error: %q", err)
+	}
+}
+
+func TestLoadProfile_Timezone(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "timezone: Europe/Berlin\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loc, err := cfg.Location()
+	if err != nil {
+		t.Fatalf("Location: %v", err)
+	}
+	if loc.String() != "Europe/Berlin" {
+		t.Errorf("Location = %q, want Europe/Berlin", loc)
+	}
+}
+
+func TestLoadProfile_InvalidTimezone(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	_, err := LoadProfile(writeConfig(t, "timezone: Mars/Olympus\n"), "")
+	if err == nil {
+		t.Fatal("expected error for invalid timezone, got nil")
+	}
+	if !strings.Contains(err.Error(), "invalid timezone") {
+		t.Errorf("unexpected error: %q", err)
+	}
+}
+
+func TestConfigLocation_DefaultsToLocal(t *testing.T) {
+	loc, err := (&Config{}).Location()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loc != time.Local {
+		t.Errorf("Location = %v, want time.Local", loc)
+	}
+}
+
+func TestLoadProfile_StaleWeeks(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Friction.StaleWeeks != 3 {
+		t.Errorf("default StaleWeeks = %d, want 3", cfg.Friction.StaleWeeks)
+	}
+
+	cfg, err = LoadProfile(writeConfig(t, "friction:\n  stale_weeks: 2\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Friction.StaleWeeks != 2 {
+		t.Errorf("StaleWeeks = %d, want 2", cfg.Friction.StaleWeeks)
+	}
+}
+
+func TestLoadProfile_InvalidStaleWeeks(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	_, err := LoadProfile(writeConfig(t, "friction:\n  stale_weeks: 1\n"), "")
+	if err == nil {
+		t.Fatal("expected error for stale_weeks below 2, got nil")
+	}
+	if !strings.Contains(err.Error(), "friction.stale_weeks") {
+		t.Errorf("unexpected error: %q", err)
+	}
+}
+
+func TestLoadProfile_ResumeGapMinutes(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "resume_gap_minutes: 30\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ResumeGapMinutes != 30 {
+		t.Errorf("ResumeGapMinutes = %d, want 30", cfg.ResumeGapMinutes)
+	}
+
+	_, err = LoadProfile(writeConfig(t, "resume_gap_minutes: 0\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "resume_gap_minutes") {
+		t.Errorf("expected resume_gap_minutes error, got %v", err)
+	}
+}
+
+func TestLoadProfile_ReadinessVolume(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "readiness_volume:\n  log_base: 2\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ReadinessVolume.LogBase != 2 || cfg.ReadinessVolume.Weight != 1 {
+		t.Errorf("ReadinessVolume = %+v, want log_base 2, weight 1", cfg.ReadinessVolume)
+	}
+
+	for _, body := range []string{
+		"readiness_volume:\n  log_base: 1\n",
+		"readiness_volume:\n  weight: -1\n",
+	} {
+		if _, err := LoadProfile(writeConfig(t, body), ""); err == nil || !strings.Contains(err.Error(), "readiness_volume") {
+			t.Errorf("config %q: expected readiness_volume error, got %v", body, err)
+		}
+	}
+}
+
+func TestLoadProfile_HealthWeights(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "health_weights:\n  agents: 0\n  readiness: 0.5\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := HealthWeights{Readiness: 0.5, Friction: 0.3, Commits: 0.2, Agents: 0}
+	if cfg.HealthWeights != want {
+		t.Errorf("HealthWeights = %+v, want %+v", cfg.HealthWeights, want)
+	}
+
+	for _, body := range []string{
+		"health_weights:\n  friction: -0.1\n",
+		"health_weights:\n  readiness: 0\n  friction: 0\n  commits: 0\n  agents: 0\n",
+	} {
+		if _, err := LoadProfile(writeConfig(t, body), ""); err == nil || !strings.Contains(err.Error(), "health_weights") {
+			t.Errorf("config %q: expected health_weights error, got %v", body, err)
+		}
+	}
+}
+
+func TestLoadProfile_Pricing(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "pricing:\n  models:\n    sonnet:\n      input: 2.5\n      cache_read: 0.25\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := cfg.Pricing.Models["sonnet"]
+	if got.Input != 2.5 || got.CacheRead != 0.25 || got.Output != 0 {
+		t.Errorf("Pricing.Models[sonnet] = %+v, want input 2.5, cache_read 0.25", got)
+	}
+
+	_, err = LoadProfile(writeConfig(t, "pricing:\n  models:\n    opus:\n      output: -1\n"), "")
+	if err == nil || !strings.Contains(err.Error(), `invalid pricing for "opus"`) {
+		t.Errorf("expected pricing error, got %v", err)
+	}
+}
+
+func TestLoadProfile_Theme(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Output.Theme != "default" {
+		t.Errorf("default Theme = %q, want default", cfg.Output.Theme)
+	}
+
+	cfg, err = LoadProfile(writeConfig(t, "output:\n  theme: high-contrast\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Output.Theme != "high-contrast" {
+		t.Errorf("Theme = %q, want high-contrast", cfg.Output.Theme)
+	}
+}
+
+func TestLoadProfile_FirstPromptBuckets(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(cfg.FirstPromptBuckets) != fmt.Sprint(DefaultFirstPromptBuckets) {
+		t.Errorf("default FirstPromptBuckets = %v, want %v", cfg.FirstPromptBuckets, DefaultFirstPromptBuckets)
+	}
+
+	cfg, err = LoadProfile(writeConfig(t, "first_prompt_buckets: [100, 500, 2000, 8000]\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(cfg.FirstPromptBuckets) != "[100 500 2000 8000]" {
+		t.Errorf("FirstPromptBuckets = %v, want [100 500 2000 8000]", cfg.FirstPromptBuckets)
+	}
+
+	for _, bad := range []string{"[500]", "[500, 100]", "[0, 100]"} {
+		_, err = LoadProfile(writeConfig(t, "first_prompt_buckets: "+bad+"\n"), "")
+		if err == nil || !strings.Contains(err.Error(), "invalid first_prompt_buckets") {
+			t.Errorf("%s: expected invalid first_prompt_buckets error, got %v", bad, err)
+		}
+	}
+}
+
+func TestLoadProfile_TrivialSession(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TrivialSession != DefaultTrivialSession {
+		t.Errorf("default TrivialSession = %+v, want %+v", cfg.TrivialSession, DefaultTrivialSession)
+	}
+
+	cfg, err = LoadProfile(writeConfig(t, "trivial_session:\n  min_duration_minutes: 3\n  min_user_messages: 2\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TrivialSession.MinDurationMinutes != 3 || cfg.TrivialSession.MinUserMessages != 2 {
+		t.Errorf("TrivialSession = %+v, want 3 minutes and 2 messages", cfg.TrivialSession)
+	}
+
+	for _, bad := range []string{"min_duration_minutes: 0", "min_user_messages: -1"} {
+		_, err = LoadProfile(writeConfig(t, "trivial_session:\n  "+bad+"\n"), "")
+		if err == nil || !strings.Contains(err.Error(), "invalid trivial_session") {
+			t.Errorf("%s: expected invalid trivial_session error, got %v", bad, err)
+		}
+	}
+}
+
+func TestLoadProfile_Jobs(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "jobs: 2\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Jobs != 2 {
+		t.Errorf("Jobs = %d, want 2", cfg.Jobs)
+	}
+
+	_, err = LoadProfile(writeConfig(t, "jobs: -1\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "invalid jobs") {
+		t.Errorf("expected invalid jobs error, got %v", err)
+	}
+}
+
+func TestLoadProfile_ClaudeMDStaleDays(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ClaudeMDStaleDays != DefaultClaudeMDStaleDays {
+		t.Errorf("ClaudeMDStaleDays = %d, want %d", cfg.ClaudeMDStaleDays, DefaultClaudeMDStaleDays)
+	}
+
+	_, err = LoadProfile(writeConfig(t, "claude_md_stale_days: 0\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "invalid claude_md_stale_days") {
+		t.Errorf("expected invalid claude_md_stale_days error, got %v", err)
+	}
+}
+
+func TestLoadProfile_AgentResultWindowMinutes(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AgentResultWindowMinutes != DefaultAgentResultWindowMinutes {
+		t.Errorf("AgentResultWindowMinutes = %d, want %d", cfg.AgentResultWindowMinutes, DefaultAgentResultWindowMinutes)
+	}
+
+	_, err = LoadProfile(writeConfig(t, "agent_result_window_minutes: 0\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "invalid agent_result_window_minutes") {
+		t.Errorf("expected invalid agent_result_window_minutes error, got %v", err)
+	}
+}
+
+func TestLoadProfile_ClaudeMDSections(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, `claude_md_sections:
+  - name: deployment
+    header_keywords: [deploy, release]
+    content_keywords: [kubectl, helm]
+    weight: 10
+`), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.ClaudeMDSections) != 1 {
+		t.Fatalf("ClaudeMDSections = %+v, want one section", cfg.ClaudeMDSections)
+	}
+	if s := cfg.ClaudeMDSections[0]; s.Name != "deployment" || len(s.HeaderKeywords) != 2 || s.ContentKeywords[1] != "helm" || s.Weight != 10 {
+		t.Errorf("section = %+v", s)
+	}
+
+	for _, body := range []string{
+		"claude_md_sections:\n  - name: deployment\n    header_keywords: [deploy]\n    content_keywords: [helm]\n    weight: -5\n",
+		"claude_md_sections:\n  - name: deployment\n    content_keywords: [helm]\n",
+		"claude_md_sections:\n  - name: deployment\n    header_keywords: [deploy, \" \"]\n    content_keywords: [helm]\n",
+		"claude_md_sections:\n  - header_keywords: [deploy]\n    content_keywords: [helm]\n",
+	} {
+		if _, err := LoadProfile(writeConfig(t, body), ""); err == nil || !strings.Contains(err.Error(), "invalid claude_md_sections[0]") {
+			t.Errorf("config %q: expected claude_md_sections error, got %v", body, err)
+		}
+	}
+}
+
+func TestLoadProfile_Baseline(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.Baseline.Tolerance("avg_tool_errors"); got != 5 {
+		t.Errorf("default tolerance = %g, want 5", got)
+	}
+
+	cfg, err = LoadProfile(writeConfig(t, "baseline:\n  tolerance_pct: 10\n  tolerances:\n    avg_tool_errors: 0\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.Baseline.Tolerance("avg_tool_errors"); got != 0 {
+		t.Errorf("avg_tool_errors tolerance = %g, want the 0 override", got)
+	}
+	if got := cfg.Baseline.Tolerance("satisfaction_score"); got != 10 {
+		t.Errorf("satisfaction_score tolerance = %g, want 10", got)
+	}
+}
+
+func TestLoadProfile_InvalidBaseline(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	_, err := LoadProfile(writeConfig(t, "baseline:\n  tolerances:\n    avg_tool_errors: -1\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "baseline") {
+		t.Errorf("err = %v, want a baseline tolerance error", err)
+	}
+}
+
+func TestLoadProfile_InvalidThresholds(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	_, err := LoadProfile(writeConfig(t, "thresholds:\n  zero_commit_rate: 1.5\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "zero_commit_rate") {
+		t.Errorf("err = %v, want a zero_commit_rate error", err)
+	}
+}
+
+func TestLoadProfile_SuggestionTTL(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "suggestion_ttl:\n  snapshots: 5\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SuggestionTTL != (SuggestionTTL{Snapshots: 5}) {
+		t.Errorf("SuggestionTTL = %+v, want 5 snapshots and no day limit", cfg.SuggestionTTL)
+	}
+
+	_, err = LoadProfile(writeConfig(t, "suggestion_ttl:\n  days: -1\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "suggestion_ttl") {
+		t.Errorf("err = %v, want a suggestion_ttl error", err)
+	}
+}
+
+func TestLoadProfile_NoiseBands(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "noise_bands:\n  avg_tool_errors:\n    abs: 0.5\n  total_sessions:\n    pct: 10\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.NoiseBands["avg_tool_errors"]; got != (NoiseBand{Abs: 0.5}) {
+		t.Errorf("avg_tool_errors band = %+v, want abs 0.5", got)
+	}
+	if got := cfg.NoiseBands["total_sessions"]; got != (NoiseBand{Pct: 10}) {
+		t.Errorf("total_sessions band = %+v, want pct 10", got)
+	}
+
+	_, err = LoadProfile(writeConfig(t, "noise_bands:\n  avg_tool_errors:\n    pct: -1\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "noise_bands.avg_tool_errors") {
+		t.Errorf("err = %v, want a noise_bands.avg_tool_errors error", err)
+	}
+}
+
+func TestLoadProfile_AgentAliases(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "agent_aliases:\n  codewriter: code-writer\n  Writer: code-writer\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"codewriter": "code-writer", "writer": "code-writer"}
+	if len(cfg.AgentAliases) != len(want) {
+		t.Fatalf("AgentAliases = %v, want %v", cfg.AgentAliases, want)
+	}
+	for alias, canonical := range want {
+		if cfg.AgentAliases[alias] != canonical {
+			t.Errorf("AgentAliases[%q] = %q, want %q", alias, cfg.AgentAliases[alias], canonical)
+		}
+	}
+
+	_, err = LoadProfile(writeConfig(t, "agent_aliases:\n  writer: \"\"\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "agent_aliases.writer") {
+		t.Errorf("err = %v, want an agent_aliases.writer error", err)
+	}
+}
+
+func writeProjectConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(content), 0o644); err != nil {
+		t.Fatalf("writing project config: %v", err)
+	}
+	return dir
+}
+
+func TestForProject(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	base, err := LoadProfile(writeConfig(t, "claude_md_stale_days: 60\nthresholds:\n  agent_kill_rate: 0.5\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if eff, err := base.ForProject(t.TempDir()); err != nil || eff != base {
+		t.Errorf("ForProject without a file = %p, %v; want the base config", eff, err)
+	}
+
+	dir := writeProjectConfig(t, "thresholds:\n  zero_commit_rate: 1\nfriction:\n  high_error_multiplier: 4\n")
+	eff, err := base.ForProject(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eff.Thresholds.ZeroCommitRate != 1 || eff.Friction.HighErrorMultiplier != 4 {
+		t.Errorf("overrides = %+v, %+v; want zero_commit_rate 1 and multiplier 4", eff.Thresholds, eff.Friction)
+	}
+	if eff.Thresholds.AgentKillRate != 0.5 || eff.ClaudeMDStaleDays != 60 || eff.Friction.RecurringThreshold != base.Friction.RecurringThreshold {
+		t.Errorf("unset keys = %+v, stale days %d; want the global values", eff.Thresholds, eff.ClaudeMDStaleDays)
+	}
+	if eff.ProjectFile != filepath.Join(dir, ProjectConfigFile) {
+		t.Errorf("ProjectFile = %q", eff.ProjectFile)
+	}
+	if base.Thresholds.ZeroCommitRate != DefaultThresholds.ZeroCommitRate || base.ProjectFile != "" {
+		t.Errorf("base config changed: %+v", base.Thresholds)
+	}
+}
+
+func TestForProject_Invalid(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	base, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name, content, want string
+	}{
+		{"malformed", "thresholds: [\n", "reading"},
+		{"global key", "scan_paths: [/src]\nthresholds:\n  claude_md_quality: 20\n", "scan_paths can't be set per project"},
+		{"bad value", "thresholds:\n  agent_kill_rate: 0\n", "agent_kill_rate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := base.ForProject(writeProjectConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadProfile_RemoteClaudeHome(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "claude_home: ssh://devbox/~/.claude\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Left as is; the app mirrors it when a command needs the data.
+	if cfg.ClaudeHome != "ssh://devbox/~/.claude" {
+		t.Errorf("ClaudeHome = %q, want the ssh:// address unchanged", cfg.ClaudeHome)
+	}
+}
+
+func TestLoadProfile_SessionsDefaultDays(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	cfg, err := LoadProfile(writeConfig(t, "scan_paths: []\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Sessions.DefaultDays != DefaultSessionsDays {
+		t.Errorf("default sessions.default_days = %d, want %d", cfg.Sessions.DefaultDays, DefaultSessionsDays)
+	}
+
+	cfg, err = LoadProfile(writeConfig(t, "sessions:\n  default_days: 7\n"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Sessions.DefaultDays != 7 {
+		t.Errorf("sessions.default_days = %d, want 7", cfg.Sessions.DefaultDays)
+	}
+
+	_, err = LoadProfile(writeConfig(t, "sessions:\n  default_days: 0\n"), "")
+	if err == nil || !strings.Contains(err.Error(), "sessions.default_days") {
+		t.Errorf("err = %v, want a sessions.default_days error", err)
+	}
+}
@@ -16,10 +16,46 @@ const DefaultDBName = "claudewatch.db"
 // DefaultConfigFile is the filename for the YAML config.
 const DefaultConfigFile = "config.yaml"
 
+// DefaultSuggestRulesFile is the filename for custom suggestion rules.
+const DefaultSuggestRulesFile = "suggest-rules.yaml"
+
 // DefaultActiveThreshold is the minimum number of sessions for a project
 // to be considered "active".
 const DefaultActiveThreshold = 1
 
+// DefaultResumeGapMinutes is the default gap, in minutes, within which a
+// session on the same project is treated as a resume of the previous one.
+const DefaultResumeGapMinutes = 15
+
+// DefaultContextWindowTokens is the default model context window, in tokens.
+const DefaultContextWindowTokens = 200_000
+
+// DefaultFirstPromptBuckets are the default first-prompt length boundaries,
+// in characters: under 200 is very short, 4000 and over is very long.
+var DefaultFirstPromptBuckets = []int{200, 1000, 4000}
+
+// DefaultTrivialSession counts sessions under 10 minutes with fewer than 5
+// user messages as trivial.
+var DefaultTrivialSession = TrivialSession{
+	MinDurationMinutes: 10,
+	MinUserMessages:    5,
+}
+
+// DefaultSessionsDays is how far back `sessions` looks without --days.
+const DefaultSessionsDays = 30
+
+// DefaultClaudeMDStaleDays flags a CLAUDE.md untouched for 60 days while
+// the code kept changing.
+const DefaultClaudeMDStaleDays = 60
+
+// DefaultAgentResultWindowMinutes gives an agent's result 10 minutes to be
+// followed by an edit or commit before it counts as ignored.
+const DefaultAgentResultWindowMinutes = 10
+
+// DefaultJobs lets project discovery and transcript parsing use one worker
+// per CPU.
+const DefaultJobs = 0
+
 // DefaultWeights holds the default scoring weights for project readiness.
 var DefaultWeights = Weights{
 	ClaudeMDExists:    30,
@@ -37,12 +73,59 @@ var DefaultWeights = Weights{
 var DefaultFriction = Friction{
 	RecurringThreshold:  0.30,
 	HighErrorMultiplier: 2.0,
+	StaleWeeks:          3,
 }
 
 // DefaultOutput holds the default output preferences.
 var DefaultOutput = Output{
 	Color: true,
 	Width: 80,
+	Theme: "default",
+}
+
+// DefaultBudget holds the default spending caps (none).
+var DefaultBudget = Budget{
+	MonthlyUSD: 0,
+}
+
+// DefaultBaseline lets a metric get 5% worse than its baseline before it
+// counts as regressed.
+var DefaultBaseline = Baseline{
+	TolerancePct: 5,
+}
+
+// DefaultThresholds holds the default gap and suggestion thresholds.
+var DefaultThresholds = Thresholds{
+	ZeroCommitRate:            0.40,
+	AgentKillRate:             0.30,
+	ClaudeMDQuality:           50,
+	ProjectFrictionMultiplier: 2.0,
+}
+
+// DefaultSuggestionTTL never expires suggestions.
+var DefaultSuggestionTTL = SuggestionTTL{}
+
+// DefaultUpdateCheck allows explicit update checks but leaves the background
+// check off until the user opts in.
+var DefaultUpdateCheck = UpdateCheck{
+	Enabled:    true,
+	Background: false,
+}
+
+// DefaultReadinessVolume doubles a readiness score at 9 sessions and triples
+// it at 99.
+var DefaultReadinessVolume = ReadinessVolume{
+	LogBase: 10,
+	Weight:  1,
+}
+
+// DefaultHealthWeights favours setup and friction over commit and agent
+// outcomes, which are noisier on small projects.
+var DefaultHealthWeights = HealthWeights{
+	Readiness: 0.3,
+	Friction:  0.3,
+	Commits:   0.2,
+	Agents:    0.2,
 }
 
 // DefaultCustomMetrics provides the preset custom metric definitions.
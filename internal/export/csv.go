@@ -10,7 +10,10 @@ import (
 )
 
 // CSVExporter outputs metrics in CSV format.
-type CSVExporter struct{}
+type CSVExporter struct {
+	// NullValue is how metrics without data are written.
+	NullValue NullValue
+}
 
 // Format returns "csv".
 func (c *CSVExporter) Format() string {
@@ -65,22 +68,22 @@ func (c *CSVExporter) ExportMultiple(snapshots []MetricSnapshot) ([]byte, error)
 			snapshot.ProjectHash,
 			strconv.Itoa(snapshot.SessionCount),
 			formatFloat(snapshot.TotalDurationMin),
-			formatFloat(snapshot.AvgDurationMin),
+			c.formatMetric(snapshot, "AvgDurationMin", snapshot.AvgDurationMin),
 			formatFloat(snapshot.ActiveMinutes),
-			formatFloat(snapshot.FrictionRate),
+			c.formatMetric(snapshot, "FrictionRate", snapshot.FrictionRate),
 			formatMapIntSemicolon(snapshot.FrictionByType),
-			formatFloat(snapshot.AvgToolErrors),
+			c.formatMetric(snapshot, "AvgToolErrors", snapshot.AvgToolErrors),
 			strconv.Itoa(snapshot.TotalCommits),
-			formatFloat(snapshot.AvgCommitsPerSession),
-			formatFloat(snapshot.CommitAttemptRatio),
-			formatFloat(snapshot.ZeroCommitRate),
+			c.formatMetric(snapshot, "AvgCommitsPerSession", snapshot.AvgCommitsPerSession),
+			c.formatMetric(snapshot, "CommitAttemptRatio", snapshot.CommitAttemptRatio),
+			c.formatMetric(snapshot, "ZeroCommitRate", snapshot.ZeroCommitRate),
 			formatFloat(snapshot.TotalCostUSD),
-			formatFloat(snapshot.AvgCostPerSession),
-			formatFloat(snapshot.CostPerCommit),
+			c.formatMetric(snapshot, "AvgCostPerSession", snapshot.AvgCostPerSession),
+			c.formatMetric(snapshot, "CostPerCommit", snapshot.CostPerCommit),
 			formatMapFloatSemicolon(snapshot.ModelUsagePct),
-			formatFloat(snapshot.AgentSuccessRate),
-			formatFloat(snapshot.AgentUsageRate),
-			formatFloat(snapshot.AvgContextPressure),
+			c.formatMetric(snapshot, "AgentSuccessRate", snapshot.AgentSuccessRate),
+			c.formatMetric(snapshot, "AgentUsageRate", snapshot.AgentUsageRate),
+			c.formatMetric(snapshot, "AvgContextPressure", snapshot.AvgContextPressure),
 		}
 
 		if err := writer.Write(record); err != nil {
@@ -151,6 +154,23 @@ func (c *CSVExporter) ExportDetailed(details []SessionDetail) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// formatMetric formats the snapshot field named field, whose value is f,
+// writing it per c.NullValue when it had no data.
+func (c *CSVExporter) formatMetric(s MetricSnapshot, field string, f float64) string {
+	if s.HasData(field) {
+		return formatFloat(f)
+	}
+	switch c.NullValue {
+	case NullEmpty:
+		return ""
+	case NullNull:
+		return "null"
+	case NullNaN:
+		return "NaN"
+	}
+	return formatFloat(f)
+}
+
 // formatFloat formats a float64 with 4 decimal places.
 func formatFloat(f float64) string {
 	return fmt.Sprintf("%.4f", f)
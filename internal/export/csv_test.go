@@ -174,3 +174,31 @@ func TestFormatMapFloatSemicolon(t *testing.T) {
 		})
 	}
 }
+
+func TestCSVExporter_NullValue(t *testing.T) {
+	snapshot := MetricSnapshot{Timestamp: time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC), SessionCount: 2, FrictionRate: 0}
+	snapshot.markNoData("CostPerCommit")
+
+	cases := map[NullValue]string{NullZero: "0.0000", NullEmpty: "", NullNull: "null", NullNaN: "NaN"}
+	for nullValue, want := range cases {
+		output, err := (&CSVExporter{NullValue: nullValue}).Export(snapshot)
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		records, err := csv.NewReader(strings.NewReader(string(output))).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to parse CSV: %v", err)
+		}
+		row := make(map[string]string)
+		for i, h := range records[0] {
+			row[h] = records[1][i]
+		}
+		if row["cost_per_commit"] != want {
+			t.Errorf("%q: cost_per_commit = %q, want %q", nullValue, row["cost_per_commit"], want)
+		}
+		// A computed zero stays zero.
+		if row["friction_rate"] != "0.0000" {
+			t.Errorf("%q: friction_rate = %q, want 0.0000", nullValue, row["friction_rate"])
+		}
+	}
+}
@@ -1,28 +1,99 @@
 package export
 
 import (
+	"bytes"
 	"encoding/json"
+	"reflect"
 )
 
 // JSONExporter outputs metrics in JSON format.
-type JSONExporter struct{}
+type JSONExporter struct {
+	// Compact writes each document on a single line instead of indenting it.
+	Compact bool
+	// NullValue is how metrics without data are written.
+	NullValue NullValue
+}
 
 // Format returns "json".
 func (j *JSONExporter) Format() string {
 	return "json"
 }
 
-// Export renders the MetricSnapshot as pretty-printed JSON.
+// Export renders the MetricSnapshot as JSON, pretty-printed unless Compact.
 func (j *JSONExporter) Export(snapshot MetricSnapshot) ([]byte, error) {
-	return json.MarshalIndent(snapshot, "", "  ")
+	v, err := j.snapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return j.marshal(v)
 }
 
 // ExportMultiple renders multiple MetricSnapshots as a JSON array.
 func (j *JSONExporter) ExportMultiple(snapshots []MetricSnapshot) ([]byte, error) {
-	return json.MarshalIndent(snapshots, "", "  ")
+	values := make([]any, len(snapshots))
+	for i, s := range snapshots {
+		v, err := j.snapshot(s)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return j.marshal(values)
 }
 
 // ExportDetailed renders per-session details as a JSON array.
 func (j *JSONExporter) ExportDetailed(details []SessionDetail) ([]byte, error) {
-	return json.MarshalIndent(details, "", "  ")
+	return j.marshal(details)
+}
+
+// snapshot returns s ready to marshal: s itself, or, when j.NullValue
+// replaces metrics without data, an object with the same keys in the same
+// order and those metrics replaced.
+func (j *JSONExporter) snapshot(s MetricSnapshot) (any, error) {
+	if j.NullValue == NullZero || len(s.NoData) == 0 {
+		return s, nil
+	}
+	var null json.RawMessage
+	switch j.NullValue {
+	case NullEmpty:
+		null = json.RawMessage(`""`)
+	case NullNull:
+		null = json.RawMessage(`null`)
+	case NullNaN:
+		null = json.RawMessage(`"NaN"`)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	t, v := reflect.TypeOf(s), reflect.ValueOf(s)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("json") == "-" {
+			continue
+		}
+		value := null
+		if s.HasData(f.Name) {
+			b, err := json.Marshal(v.Field(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			value = b
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.Name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+func (j *JSONExporter) marshal(v any) ([]byte, error) {
+	if j.Compact {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, "", "  ")
 }
@@ -1,6 +1,7 @@
 package export
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 	"time"
@@ -75,3 +76,69 @@ func TestJSONExporter_Format(t *testing.T) {
 		t.Errorf("Format() = %s, want json", exporter.Format())
 	}
 }
+
+func TestJSONExporter_Compact(t *testing.T) {
+	snapshots := []MetricSnapshot{
+		{ProjectName: "a", SessionCount: 1},
+		{ProjectName: "b", SessionCount: 2},
+	}
+
+	pretty, err := (&JSONExporter{}).ExportMultiple(snapshots)
+	if err != nil {
+		t.Fatalf("ExportMultiple failed: %v", err)
+	}
+	if !bytes.Contains(pretty, []byte("\n  ")) {
+		t.Errorf("expected indented output by default, got %q", pretty)
+	}
+
+	compact, err := (&JSONExporter{Compact: true}).ExportMultiple(snapshots)
+	if err != nil {
+		t.Fatalf("ExportMultiple failed: %v", err)
+	}
+	if bytes.Contains(compact, []byte("\n")) {
+		t.Errorf("expected single-line output, got %q", compact)
+	}
+	var decoded []MetricSnapshot
+	if err := json.Unmarshal(compact, &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if len(decoded) != 2 || decoded[1].ProjectName != "b" {
+		t.Errorf("unexpected decoded snapshots: %+v", decoded)
+	}
+}
+
+func TestJSONExporter_NullValue(t *testing.T) {
+	snapshot := MetricSnapshot{SessionCount: 2, TotalCommits: 0}
+	snapshot.markNoData("CostPerCommit")
+
+	cases := map[NullValue]any{NullZero: 0.0, NullEmpty: "", NullNull: nil, NullNaN: "NaN"}
+	for nullValue, want := range cases {
+		output, err := (&JSONExporter{Compact: true, NullValue: nullValue}).ExportMultiple([]MetricSnapshot{snapshot})
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		var decoded []map[string]any
+		if err := json.Unmarshal(output, &decoded); err != nil {
+			t.Fatalf("Output is not valid JSON: %v", err)
+		}
+		got, ok := decoded[0]["CostPerCommit"]
+		if !ok || got != want {
+			t.Errorf("%q: CostPerCommit = %#v, want %#v", nullValue, got, want)
+		}
+		if decoded[0]["TotalCommits"] != 0.0 {
+			t.Errorf("%q: TotalCommits = %#v, want 0", nullValue, decoded[0]["TotalCommits"])
+		}
+		if _, ok := decoded[0]["NoData"]; ok {
+			t.Errorf("%q: NoData should not be exported", nullValue)
+		}
+	}
+
+	// Keys keep the struct's order.
+	output, err := (&JSONExporter{Compact: true, NullValue: NullNull}).Export(snapshot)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !bytes.HasPrefix(output, []byte(`{"Timestamp":`)) {
+		t.Errorf("output = %s, want Timestamp first", output)
+	}
+}
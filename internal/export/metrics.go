@@ -52,6 +52,11 @@ type MetricSnapshot struct {
 
 	// Context pressure (aggregated status)
 	AvgContextPressure float64 // 0.0-1.0
+
+	// NoData names the fields above that had no data to be computed from,
+	// such as CostPerCommit without commits. They hold zero; exporters
+	// write them according to their NullValue.
+	NoData map[string]bool `json:"-"`
 }
 
 // CollectMetrics gathers safe, aggregated metrics for export.
@@ -108,6 +113,7 @@ func CollectMetrics(cfg *config.Config, projectFilter string, days int) (MetricS
 
 	// Early return if no sessions match filters
 	if len(sessions) == 0 {
+		snapshot.markNoData(rateMetrics...)
 		return snapshot, nil
 	}
 
@@ -126,6 +132,8 @@ func CollectMetrics(cfg *config.Config, projectFilter string, days int) (MetricS
 	frictionSummary := analyzer.AnalyzeFriction(facets, frictionThreshold)
 	if frictionSummary.TotalSessions > 0 {
 		snapshot.FrictionRate = float64(frictionSummary.SessionsWithFriction) / float64(frictionSummary.TotalSessions)
+	} else {
+		snapshot.markNoData("FrictionRate")
 	}
 	// Copy friction by type (safe aggregate counts)
 	for fType, count := range frictionSummary.FrictionByType {
@@ -145,6 +153,9 @@ func CollectMetrics(cfg *config.Config, projectFilter string, days int) (MetricS
 	snapshot.AvgCommitsPerSession = commitAnalysis.AvgCommitsPerSession
 	snapshot.ZeroCommitRate = commitAnalysis.ZeroCommitRate
 	snapshot.CommitAttemptRatio = computeCommitAttemptRatio(sessions)
+	if !hasEditWrites(sessions) {
+		snapshot.markNoData("CommitAttemptRatio")
+	}
 
 	// Compute cost metrics
 	// Use Sonnet pricing as default (most common)
@@ -155,6 +166,8 @@ func CollectMetrics(cfg *config.Config, projectFilter string, days int) (MetricS
 	snapshot.AvgCostPerSession = outcomeAnalysis.AvgCostPerSession
 	if snapshot.TotalCommits > 0 {
 		snapshot.CostPerCommit = snapshot.TotalCostUSD / float64(snapshot.TotalCommits)
+	} else {
+		snapshot.markNoData("CostPerCommit")
 	}
 
 	// Compute model usage percentages (safe - no token counts)
@@ -162,13 +175,15 @@ func CollectMetrics(cfg *config.Config, projectFilter string, days int) (MetricS
 
 	// Compute agent metrics
 	if len(agentTasks) > 0 {
-		agentPerf := analyzer.AnalyzeAgents(agentTasks)
+		agentPerf := analyzer.AnalyzeAgents(agentTasks, cfg.AgentAliases)
 		snapshot.AgentSuccessRate = agentPerf.SuccessRate
 		// Compute agent usage rate: sessions with agents / total sessions
 		sessionsWithAgents := countSessionsWithAgents(agentTasks)
 		if snapshot.SessionCount > 0 {
 			snapshot.AgentUsageRate = float64(sessionsWithAgents) / float64(snapshot.SessionCount)
 		}
+	} else {
+		snapshot.markNoData("AgentSuccessRate")
 	}
 
 	// Compute average context pressure (estimate from token usage)
@@ -256,6 +271,17 @@ func computeCommitAttemptRatio(sessions []claude.SessionMeta) float64 {
 	return float64(totalCommits) / float64(totalEditWrites)
 }
 
+// hasEditWrites reports whether any session used Edit or Write, without
+// which the commit attempt ratio has nothing to divide by.
+func hasEditWrites(sessions []claude.SessionMeta) bool {
+	for _, s := range sessions {
+		if s.ToolCounts["Edit"]+s.ToolCounts["Write"] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // computeModelUsagePercent returns the percentage of sessions using each model.
 // This is safe to export (percentages, not token counts or content).
 func computeModelUsagePercent(sessions []claude.SessionMeta) map[string]float64 {
@@ -419,6 +445,7 @@ func CollectMetricsPerDay(cfg *config.Config, projectFilter string, days int) ([
 
 		daySess := daySessions[dayKey]
 		if len(daySess) == 0 {
+			snapshot.markNoData(rateMetrics...)
 			snapshots = append(snapshots, snapshot)
 			continue
 		}
@@ -435,6 +462,7 @@ func CollectMetricsPerDay(cfg *config.Config, projectFilter string, days int) ([
 		}
 
 		// Compute friction metrics
+		facetSessions := 0
 		facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
 		if err == nil {
 			facets = filterFacetsBySessionIDs(facets, daySess)
@@ -443,6 +471,7 @@ func CollectMetricsPerDay(cfg *config.Config, projectFilter string, days int) ([
 				frictionThreshold = cfg.Friction.RecurringThreshold
 			}
 			frictionSummary := analyzer.AnalyzeFriction(facets, frictionThreshold)
+			facetSessions = frictionSummary.TotalSessions
 			if frictionSummary.TotalSessions > 0 {
 				snapshot.FrictionRate = float64(frictionSummary.SessionsWithFriction) / float64(frictionSummary.TotalSessions)
 			}
@@ -464,6 +493,7 @@ func CollectMetricsPerDay(cfg *config.Config, projectFilter string, days int) ([
 		if snapshot.TotalCommits > 0 {
 			snapshot.CostPerCommit = snapshot.TotalCostUSD / float64(snapshot.TotalCommits)
 		}
+		snapshot.markPartial(facetSessions)
 
 		snapshots = append(snapshots, snapshot)
 	}
@@ -545,6 +575,7 @@ func CollectMetricsPerModel(cfg *config.Config, projectFilter string, days int)
 		}
 
 		// Compute friction metrics
+		facetSessions := 0
 		facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
 		if err == nil {
 			facets = filterFacetsBySessionIDs(facets, modelSess)
@@ -553,6 +584,7 @@ func CollectMetricsPerModel(cfg *config.Config, projectFilter string, days int)
 				frictionThreshold = cfg.Friction.RecurringThreshold
 			}
 			frictionSummary := analyzer.AnalyzeFriction(facets, frictionThreshold)
+			facetSessions = frictionSummary.TotalSessions
 			if frictionSummary.TotalSessions > 0 {
 				snapshot.FrictionRate = float64(frictionSummary.SessionsWithFriction) / float64(frictionSummary.TotalSessions)
 			}
@@ -574,6 +606,7 @@ func CollectMetricsPerModel(cfg *config.Config, projectFilter string, days int)
 		if snapshot.TotalCommits > 0 {
 			snapshot.CostPerCommit = snapshot.TotalCostUSD / float64(snapshot.TotalCommits)
 		}
+		snapshot.markPartial(facetSessions)
 
 		result[modelName] = snapshot
 	}
@@ -662,6 +695,7 @@ func CollectSAWComparison(cfg *config.Config, days int) (saw MetricSnapshot, non
 			FrictionByType: make(map[string]int),
 			ModelUsagePct:  make(map[string]float64),
 		}
+		saw.markNoData(rateMetrics...)
 	}
 
 	// Collect metrics for non-SAW sessions
@@ -678,6 +712,7 @@ func CollectSAWComparison(cfg *config.Config, days int) (saw MetricSnapshot, non
 			FrictionByType: make(map[string]int),
 			ModelUsagePct:  make(map[string]float64),
 		}
+		nonSAW.markNoData(rateMetrics...)
 	}
 
 	return saw, nonSAW, nil
@@ -711,6 +746,7 @@ func collectMetricsForSessions(cfg *config.Config, sessions []claude.SessionMeta
 	}
 
 	// Load facets for friction analysis
+	facetSessions := 0
 	facets, err := claude.ParseAllFacets(cfg.ClaudeHome)
 	if err == nil {
 		facets = filterFacetsBySessionIDs(facets, sessions)
@@ -719,6 +755,7 @@ func collectMetricsForSessions(cfg *config.Config, sessions []claude.SessionMeta
 			frictionThreshold = cfg.Friction.RecurringThreshold
 		}
 		frictionSummary := analyzer.AnalyzeFriction(facets, frictionThreshold)
+		facetSessions = frictionSummary.TotalSessions
 		if frictionSummary.TotalSessions > 0 {
 			snapshot.FrictionRate = float64(frictionSummary.SessionsWithFriction) / float64(frictionSummary.TotalSessions)
 		}
@@ -740,6 +777,7 @@ func collectMetricsForSessions(cfg *config.Config, sessions []claude.SessionMeta
 	if snapshot.TotalCommits > 0 {
 		snapshot.CostPerCommit = snapshot.TotalCostUSD / float64(snapshot.TotalCommits)
 	}
+	snapshot.markPartial(facetSessions)
 
 	return snapshot, nil
 }
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NullValue controls how exporters write metrics that had no data to be
+// computed from, such as cost per commit without any commits, so they can
+// be told apart from metrics that really are zero.
+type NullValue string
+
+const (
+	// NullZero writes metrics without data as 0, the same as a computed
+	// zero. It is the default, matching exports from before NullValue.
+	NullZero NullValue = ""
+	// NullEmpty writes an empty CSV cell or JSON string and leaves the
+	// Prometheus sample out.
+	NullEmpty NullValue = "empty"
+	// NullNull writes null: a JSON null, the CSV text "null", and no
+	// Prometheus sample.
+	NullNull NullValue = "null"
+	// NullNaN writes NaN: a Prometheus NaN sample, the CSV text "NaN", and
+	// the JSON string "NaN", since JSON has no NaN.
+	NullNaN NullValue = "nan"
+)
+
+// ParseNullValue parses a --null-value flag: empty, null, or nan, in any
+// case. An empty string is NullZero.
+func ParseNullValue(s string) (NullValue, error) {
+	switch v := NullValue(strings.ToLower(s)); v {
+	case NullZero, NullEmpty, NullNull, NullNaN:
+		return v, nil
+	}
+	return NullZero, fmt.Errorf("invalid --null-value %q (valid: empty, null, nan)", s)
+}
+
+// rateMetrics are the MetricSnapshot fields computed as averages or ratios,
+// which have nothing to be computed from when there are no sessions.
+var rateMetrics = []string{
+	"AvgDurationMin",
+	"FrictionRate",
+	"AvgToolErrors",
+	"AvgCommitsPerSession",
+	"CommitAttemptRatio",
+	"ZeroCommitRate",
+	"AvgCostPerSession",
+	"CostPerCommit",
+	"AgentSuccessRate",
+	"AgentUsageRate",
+	"AvgContextPressure",
+}
+
+// partialMetrics are the rate metrics that per-day, per-model, and SAW
+// comparison snapshots don't compute.
+var partialMetrics = []string{
+	"AvgCommitsPerSession",
+	"CommitAttemptRatio",
+	"ZeroCommitRate",
+	"AgentSuccessRate",
+	"AgentUsageRate",
+	"AvgContextPressure",
+}
+
+// HasData reports whether the named MetricSnapshot field was computed from
+// data. Fields without data hold zero.
+func (s MetricSnapshot) HasData(field string) bool {
+	return !s.NoData[field]
+}
+
+// markNoData records that the named fields had no data to be computed from.
+func (s *MetricSnapshot) markNoData(fields ...string) {
+	if s.NoData == nil {
+		s.NoData = make(map[string]bool, len(fields))
+	}
+	for _, f := range fields {
+		s.NoData[f] = true
+	}
+}
+
+// markPartial records the metrics a per-day, per-model, or SAW comparison
+// snapshot leaves without data: those it doesn't compute, friction without
+// facets, and cost per commit without commits.
+func (s *MetricSnapshot) markPartial(facetSessions int) {
+	s.markNoData(partialMetrics...)
+	if facetSessions == 0 {
+		s.markNoData("FrictionRate")
+	}
+	if s.TotalCommits == 0 {
+		s.markNoData("CostPerCommit")
+	}
+}
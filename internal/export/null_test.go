@@ -0,0 +1,57 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNullValue(t *testing.T) {
+	cases := map[string]NullValue{"": NullZero, "empty": NullEmpty, "null": NullNull, "NaN": NullNaN}
+	for in, want := range cases {
+		got, err := ParseNullValue(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseNullValue("zero")
+	assert.Error(t, err)
+}
+
+func TestRateMetricsAreSnapshotFields(t *testing.T) {
+	typ := reflect.TypeOf(MetricSnapshot{})
+	for _, name := range append(append([]string{}, rateMetrics...), partialMetrics...) {
+		f, ok := typ.FieldByName(name)
+		if assert.True(t, ok, "%s is not a MetricSnapshot field", name) {
+			assert.Equal(t, reflect.Float64, f.Type.Kind(), name)
+		}
+	}
+}
+
+func TestCollectMetrics_EmptySessionsHaveNoData(t *testing.T) {
+	cfg := &config.Config{ClaudeHome: t.TempDir()}
+
+	snapshot, err := CollectMetrics(cfg, "", 0)
+	require.NoError(t, err)
+	for _, name := range rateMetrics {
+		assert.False(t, snapshot.HasData(name), name)
+	}
+	// Counts and totals are real zeros.
+	assert.True(t, snapshot.HasData("SessionCount"))
+	assert.True(t, snapshot.HasData("TotalCostUSD"))
+}
+
+func TestMarkPartial(t *testing.T) {
+	s := MetricSnapshot{TotalCommits: 2}
+	s.markPartial(3)
+	assert.True(t, s.HasData("FrictionRate"))
+	assert.True(t, s.HasData("CostPerCommit"))
+	assert.False(t, s.HasData("AgentSuccessRate"))
+
+	s = MetricSnapshot{}
+	s.markPartial(0)
+	assert.False(t, s.HasData("FrictionRate"))
+	assert.False(t, s.HasData("CostPerCommit"))
+}
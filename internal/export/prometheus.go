@@ -3,12 +3,16 @@ package export
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 )
 
 // PrometheusExporter outputs metrics in Prometheus text format.
-type PrometheusExporter struct{}
+type PrometheusExporter struct {
+	// NullValue is how metrics without data are written.
+	NullValue NullValue
+}
 
 // Format returns "prometheus".
 func (p *PrometheusExporter) Format() string {
@@ -48,6 +52,22 @@ func (p *PrometheusExporter) Export(snapshot MetricSnapshot) ([]byte, error) {
 		labels["project"] = snapshot.ProjectName
 	}
 
+	// writeRate writes a gauge computed as an average or ratio. Without
+	// data it is left out, or written as NaN, unless p.NullValue is
+	// NullZero.
+	writeRate := func(name, help, field string, value float64) {
+		if !snapshot.HasData(field) {
+			switch p.NullValue {
+			case NullEmpty, NullNull:
+				return
+			case NullNaN:
+				writeMetric(name, "gauge", help, math.NaN(), labels)
+				return
+			}
+		}
+		writeMetric(name, "gauge", help, value, labels)
+	}
+
 	// Session metrics
 	writeMetric(
 		"claudewatch_sessions_total",
@@ -65,12 +85,11 @@ func (p *PrometheusExporter) Export(snapshot MetricSnapshot) ([]byte, error) {
 		labels,
 	)
 
-	writeMetric(
+	writeRate(
 		"claudewatch_session_duration_minutes_avg",
-		"gauge",
 		"Average session duration in minutes",
+		"AvgDurationMin",
 		snapshot.AvgDurationMin,
-		labels,
 	)
 
 	writeMetric(
@@ -82,12 +101,11 @@ func (p *PrometheusExporter) Export(snapshot MetricSnapshot) ([]byte, error) {
 	)
 
 	// Friction metrics
-	writeMetric(
+	writeRate(
 		"claudewatch_friction_rate",
-		"gauge",
 		"Fraction of sessions with friction events (0.0-1.0)",
+		"FrictionRate",
 		snapshot.FrictionRate,
-		labels,
 	)
 
 	// Friction by type (limit to top 10 to avoid cardinality explosion)
@@ -128,12 +146,11 @@ func (p *PrometheusExporter) Export(snapshot MetricSnapshot) ([]byte, error) {
 		}
 	}
 
-	writeMetric(
+	writeRate(
 		"claudewatch_tool_errors_avg",
-		"gauge",
 		"Average tool errors per session",
+		"AvgToolErrors",
 		snapshot.AvgToolErrors,
-		labels,
 	)
 
 	// Productivity metrics
@@ -145,28 +162,25 @@ func (p *PrometheusExporter) Export(snapshot MetricSnapshot) ([]byte, error) {
 		labels,
 	)
 
-	writeMetric(
+	writeRate(
 		"claudewatch_commits_per_session_avg",
-		"gauge",
 		"Average commits per session",
+		"AvgCommitsPerSession",
 		snapshot.AvgCommitsPerSession,
-		labels,
 	)
 
-	writeMetric(
+	writeRate(
 		"claudewatch_commit_attempt_ratio",
-		"gauge",
 		"Ratio of commits to code change attempts",
+		"CommitAttemptRatio",
 		snapshot.CommitAttemptRatio,
-		labels,
 	)
 
-	writeMetric(
+	writeRate(
 		"claudewatch_zero_commit_rate",
-		"gauge",
 		"Fraction of sessions with zero commits (0.0-1.0)",
+		"ZeroCommitRate",
 		snapshot.ZeroCommitRate,
-		labels,
 	)
 
 	// Cost metrics
@@ -178,20 +192,18 @@ func (p *PrometheusExporter) Export(snapshot MetricSnapshot) ([]byte, error) {
 		labels,
 	)
 
-	writeMetric(
+	writeRate(
 		"claudewatch_cost_per_session_avg",
-		"gauge",
 		"Average cost per session in USD",
+		"AvgCostPerSession",
 		snapshot.AvgCostPerSession,
-		labels,
 	)
 
-	writeMetric(
+	writeRate(
 		"claudewatch_cost_per_commit_avg",
-		"gauge",
 		"Average cost per commit in USD",
+		"CostPerCommit",
 		snapshot.CostPerCommit,
-		labels,
 	)
 
 	// Model usage (limit to top 5 models)
@@ -232,29 +244,26 @@ func (p *PrometheusExporter) Export(snapshot MetricSnapshot) ([]byte, error) {
 	}
 
 	// Agent metrics
-	writeMetric(
+	writeRate(
 		"claudewatch_agent_success_rate",
-		"gauge",
 		"Agent task success rate (0.0-1.0)",
+		"AgentSuccessRate",
 		snapshot.AgentSuccessRate,
-		labels,
 	)
 
-	writeMetric(
+	writeRate(
 		"claudewatch_agent_usage_rate",
-		"gauge",
 		"Fraction of sessions using agents (0.0-1.0)",
+		"AgentUsageRate",
 		snapshot.AgentUsageRate,
-		labels,
 	)
 
 	// Context pressure
-	writeMetric(
+	writeRate(
 		"claudewatch_context_pressure_avg",
-		"gauge",
 		"Average context pressure (0.0-1.0)",
+		"AvgContextPressure",
 		snapshot.AvgContextPressure,
-		labels,
 	)
 
 	return buf.Bytes(), nil
@@ -201,3 +201,32 @@ func TestGetExporter(t *testing.T) {
 		})
 	}
 }
+
+func TestPrometheusExporter_NullValue(t *testing.T) {
+	snapshot := MetricSnapshot{ProjectName: "app", SessionCount: 2}
+	snapshot.markNoData("CostPerCommit")
+
+	for nullValue, want := range map[NullValue]string{
+		NullZero:  `claudewatch_cost_per_commit_avg{project="app"} 0`,
+		NullNaN:   `claudewatch_cost_per_commit_avg{project="app"} NaN`,
+		NullEmpty: "",
+		NullNull:  "",
+	} {
+		output, err := (&PrometheusExporter{NullValue: nullValue}).Export(snapshot)
+		if err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		out := string(output)
+		if want == "" {
+			if strings.Contains(out, "claudewatch_cost_per_commit_avg") {
+				t.Errorf("%q: metric without data should be left out", nullValue)
+			}
+		} else if !strings.Contains(out, want+"\n") {
+			t.Errorf("%q: output missing %q", nullValue, want)
+		}
+		// A computed zero is always written.
+		if !strings.Contains(out, `claudewatch_friction_rate{project="app"} 0`) {
+			t.Errorf("%q: friction rate missing", nullValue)
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/guard"
 )
 
 const (
@@ -183,7 +185,7 @@ func buildUserPrompt(ctx *FixContext) string {
 	// Friction patterns.
 	if ctx.FrictionPatterns != nil && len(ctx.FrictionPatterns.Patterns) > 0 {
 		sb.WriteString("## Friction Patterns\n\n")
-		fmt.Fprintf(&sb, "- Stale patterns (3+ weeks): %d\n", ctx.FrictionPatterns.StaleCount)
+		fmt.Fprintf(&sb, "- Stale patterns (%d+ weeks): %d\n", ctx.FrictionPatterns.StaleWeeks, ctx.FrictionPatterns.StaleCount)
 		fmt.Fprintf(&sb, "- Improving patterns: %d\n", ctx.FrictionPatterns.ImprovingCount)
 		fmt.Fprintf(&sb, "- Worsening patterns: %d\n", ctx.FrictionPatterns.WorseningCount)
 		sb.WriteString("\n### Pattern Details\n\n")
@@ -385,7 +387,7 @@ func callClaudeAPI(apiKey, model, systemPrompt, userPrompt string) (string, erro
 	req.Header.Set("anthropic-version", claudeAPIVersion)
 	req.Header.Set("content-type", "application/json")
 
-	client := &http.Client{Timeout: apiTimeout}
+	client := guard.HTTPClient(apiTimeout)
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("sending request: %w", err)
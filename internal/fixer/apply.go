@@ -0,0 +1,80 @@
+package fixer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ApplyResult reports where ApplyAdditions wrote a fix.
+type ApplyResult struct {
+	Path string `json:"path"`
+	// BackupPath holds the CLAUDE.md from before the additions, and is empty
+	// when the file was created.
+	BackupPath string `json:"backup_path,omitempty"`
+	Created    bool   `json:"created"`
+	Additions  int    `json:"additions"`
+}
+
+// FilterByConfidence drops the additions whose confidence is below
+// minConfidence.
+func (f *ProposedFix) FilterByConfidence(minConfidence float64) {
+	kept := make([]Addition, 0, len(f.Additions))
+	for _, a := range f.Additions {
+		if a.Confidence >= minConfidence {
+			kept = append(kept, a)
+		}
+	}
+	f.Additions = kept
+}
+
+// ApplyAdditions appends fix's additions to CLAUDE.md in its project,
+// creating the file with a title when it doesn't exist. An existing file is
+// first copied to CLAUDE.md.bak, replacing any earlier backup, and the
+// result is written to a temporary file and renamed into place, so an
+// interrupted write never leaves CLAUDE.md half-written.
+func ApplyAdditions(fix *ProposedFix) (ApplyResult, error) {
+	path := filepath.Join(fix.ProjectPath, "CLAUDE.md")
+	result := ApplyResult{Path: path, Additions: len(fix.Additions)}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return result, fmt.Errorf("reading CLAUDE.md: %w", err)
+	}
+	result.Created = err != nil
+
+	mode := fs.FileMode(0o644)
+	if !result.Created {
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		result.BackupPath = path + ".bak"
+		if err := os.WriteFile(result.BackupPath, existing, mode); err != nil {
+			return result, fmt.Errorf("backing up CLAUDE.md: %w", err)
+		}
+	}
+
+	content := append(existing, RenderMarkdown(fix, len(existing) > 0)...)
+	tmp, err := os.CreateTemp(fix.ProjectPath, ".CLAUDE.md-*.tmp")
+	if err != nil {
+		return result, fmt.Errorf("writing CLAUDE.md: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return result, fmt.Errorf("writing CLAUDE.md: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return result, fmt.Errorf("writing CLAUDE.md: %w", err)
+	}
+	if err := os.Chmod(tmpName, mode); err != nil {
+		return result, fmt.Errorf("writing CLAUDE.md: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return result, fmt.Errorf("writing CLAUDE.md: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package fixer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFilterByConfidence(t *testing.T) {
+	fix := &ProposedFix{Additions: []Addition{
+		{Section: "## Build", Confidence: 0.9},
+		{Section: "## Testing", Confidence: 0.5},
+		{Section: "## Scope", Confidence: 0.7},
+	}}
+	fix.FilterByConfidence(0.7)
+	if len(fix.Additions) != 2 || fix.Additions[0].Section != "## Build" || fix.Additions[1].Section != "## Scope" {
+		t.Errorf("Additions = %+v, want Build and Scope", fix.Additions)
+	}
+
+	fix.FilterByConfidence(1)
+	if fix.Additions == nil || len(fix.Additions) != 0 {
+		t.Errorf("Additions = %#v, want empty, not nil", fix.Additions)
+	}
+}
+
+func TestApplyAdditions_Creates(t *testing.T) {
+	dir := t.TempDir()
+	fix := &ProposedFix{ProjectPath: dir, ProjectName: "demo", Additions: []Addition{
+		{Section: "## Build", Content: "Run `make`."},
+	}}
+
+	result, err := ApplyAdditions(fix)
+	if err != nil {
+		t.Fatalf("ApplyAdditions: %v", err)
+	}
+	if !result.Created || result.BackupPath != "" || result.Additions != 1 {
+		t.Errorf("result = %+v, want created without a backup", result)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# demo\n") || !strings.Contains(string(data), "## Build\n\nRun `make`.") {
+		t.Errorf("CLAUDE.md = %q", data)
+	}
+}
+
+func TestApplyAdditions_BacksUpExisting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	original := "# demo\n\nExisting notes.\n"
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fix := &ProposedFix{ProjectPath: dir, ProjectName: "demo", Additions: []Addition{
+		{Section: "## Testing", Content: "Run `go test ./...`."},
+	}}
+
+	result, err := ApplyAdditions(fix)
+	if err != nil {
+		t.Fatalf("ApplyAdditions: %v", err)
+	}
+	if result.Created || result.BackupPath != path+".bak" {
+		t.Errorf("result = %+v, want a backup at CLAUDE.md.bak", result)
+	}
+	backup, err := os.ReadFile(path + ".bak")
+	if err != nil || string(backup) != original {
+		t.Errorf("backup = %q, %v; want the original", backup, err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), original) || strings.Count(string(data), "# demo") != 1 {
+		t.Errorf("CLAUDE.md = %q, want the original with additions appended", data)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want the original 0600 kept", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("dir has %d entries, want CLAUDE.md and its backup only", len(entries))
+	}
+}
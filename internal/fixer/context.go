@@ -35,6 +35,10 @@ type FixContext struct {
 	CommitAnalysis   *analyzer.CommitAnalysis
 	ToolProfile      *analyzer.ToolProfile
 	ConversationData *analyzer.ConversationAnalysis
+
+	// PlanModeEffect compares this project's sessions with and without plan
+	// agents; nil when either group is too small to compare.
+	PlanModeEffect *analyzer.ProjectPlanModeEffect
 }
 
 // BuildFixContext loads all session data and runs pre-computed analyses
@@ -52,6 +56,8 @@ func BuildFixContext(project scanner.Project, cfg *config.Config) (*FixContext,
 
 	// Filter sessions for this project.
 	ctx.Sessions = filterSessionsByProject(allSessions, project.Path)
+	// Redact before anything, rules or the AI prompt, can read the prompts.
+	claude.RedactFirstPrompts(ctx.Sessions)
 
 	// Load all facets.
 	allFacets, err := claude.ParseAllFacets(cfg.ClaudeHome)
@@ -87,7 +93,7 @@ func BuildFixContext(project scanner.Project, cfg *config.Config) (*FixContext,
 	}
 
 	// Friction persistence.
-	persistence := analyzer.AnalyzeFrictionPersistence(ctx.Facets, ctx.Sessions)
+	persistence := analyzer.AnalyzeFrictionPersistence(ctx.Facets, ctx.Sessions, cfg.Friction.StaleWeeks)
 	ctx.FrictionPatterns = &persistence
 
 	// Commit analysis.
@@ -101,6 +107,10 @@ func BuildFixContext(project scanner.Project, cfg *config.Config) (*FixContext,
 		ctx.ToolProfile = &profile
 	}
 
+	// Plan mode effect.
+	planEffect := analyzer.AnalyzePlanModeEffect(ctx.Sessions, ctx.AgentTasks, ctx.Facets)
+	ctx.PlanModeEffect = planEffect.ForProject(project.Path)
+
 	// Conversation analysis.
 	convAnalysis, err := analyzer.AnalyzeConversations(cfg.ClaudeHome)
 	if err == nil {
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/scanner"
 )
@@ -57,18 +58,23 @@ func ruleMissingBuildCommands(ctx *FixContext) []Addition {
 	}
 }
 
-// rulePlanModeWarning adds a plan mode warning to Conventions when agent analysis
-// shows Plan agents with a high kill rate.
+// rulePlanModeWarning adds a plan mode warning to Conventions when Plan agents
+// have a high kill rate and sessions that planned demonstrably did worse than
+// sessions that didn't. A high kill rate alone isn't enough: killed plans can
+// still steer the session that follows.
 func rulePlanModeWarning(ctx *FixContext) []Addition {
 	if len(ctx.AgentTasks) == 0 {
 		return nil
 	}
+	effect := ctx.PlanModeEffect
+	if effect == nil || effect.Verdict != "hurts" {
+		return nil
+	}
 
 	// Count Plan-type agents and their kill rate.
 	var planTotal, planKilled int
 	for _, task := range ctx.AgentTasks {
-		agentType := strings.ToLower(task.AgentType)
-		if strings.Contains(agentType, "plan") {
+		if analyzer.IsPlanAgent(task.AgentType) {
 			planTotal++
 			if task.Status == "killed" {
 				planKilled++
@@ -89,9 +95,10 @@ func rulePlanModeWarning(ctx *FixContext) []Addition {
 
 	return []Addition{
 		{
-			Section:    "## Conventions",
-			Content:    "- Do not enter plan mode for this project. Implement directly.",
-			Reason:     fmt.Sprintf("Plan agents have %d%% kill rate across %d plan tasks in this project.", killPct, planTotal),
+			Section: "## Conventions",
+			Content: "- Do not enter plan mode for this project. Implement directly.",
+			Reason: fmt.Sprintf("Plan agents have %d%% kill rate across %d plan tasks in this project, and the %d sessions that planned did worse than the %d that didn't (%s).",
+				killPct, planTotal, effect.WithPlan.Sessions, effect.WithoutPlan.Sessions, planModeEvidence(effect)),
 			Impact:     "Eliminating wasted plan cycles reduces session time and user frustration.",
 			Source:     "plan_mode_warning",
 			Confidence: confidenceFromSessionCount(len(ctx.Sessions)),
@@ -99,8 +106,24 @@ func rulePlanModeWarning(ctx *FixContext) []Addition {
 	}
 }
 
+// planModeEvidence lists the measures on which planned sessions did worse.
+func planModeEvidence(e *analyzer.ProjectPlanModeEffect) string {
+	var parts []string
+	if e.CommitRateDelta <= -analyzer.PlanModeRateThreshold {
+		parts = append(parts, fmt.Sprintf("commit rate %.0f%% vs %.0f%%", e.WithPlan.CommitRate*100, e.WithoutPlan.CommitRate*100))
+	}
+	if e.AchievedRateDelta <= -analyzer.PlanModeRateThreshold {
+		parts = append(parts, fmt.Sprintf("goals achieved %.0f%% vs %.0f%%", e.WithPlan.AchievedRate*100, e.WithoutPlan.AchievedRate*100))
+	}
+	if e.FrictionDelta >= analyzer.PlanModeFrictionThreshold {
+		parts = append(parts, fmt.Sprintf("friction %.1f vs %.1f per session", e.WithPlan.AvgFriction, e.WithoutPlan.AvgFriction))
+	}
+	return strings.Join(parts, "; ")
+}
+
 // ruleKnownFrictionPatterns generates a "## Known Patterns" section from stale
-// friction that has persisted for 3+ weeks without improving.
+// friction that has persisted for the configured number of weeks
+// (friction.stale_weeks) without improving.
 func ruleKnownFrictionPatterns(ctx *FixContext) []Addition {
 	if ctx.FrictionPatterns == nil || ctx.FrictionPatterns.StaleCount == 0 {
 		return nil
@@ -95,11 +95,20 @@ func TestRulePlanModeWarning_Triggers(t *testing.T) {
 			{AgentType: "Plan", Status: "completed"},
 			{AgentType: "Plan", Status: "killed"},
 		},
+		PlanModeEffect: &analyzer.ProjectPlanModeEffect{
+			WithPlan:        analyzer.PlanModeGroupStats{Sessions: 6, CommitRate: 0.2},
+			WithoutPlan:     analyzer.PlanModeGroupStats{Sessions: 8, CommitRate: 0.6},
+			CommitRateDelta: -0.4,
+			Verdict:         "hurts",
+		},
 	}
 
 	additions := rulePlanModeWarning(ctx)
 	if len(additions) == 0 {
-		t.Fatal("expected additions when plan kill rate > 30%")
+		t.Fatal("expected additions when plan kill rate > 30% and planning hurts")
+	}
+	if !strings.Contains(additions[0].Reason, "commit rate 20% vs 60%") {
+		t.Errorf("expected commit-rate evidence in reason, got %q", additions[0].Reason)
 	}
 	if additions[0].Section != "## Conventions" {
 		t.Errorf("expected section '## Conventions', got %q", additions[0].Section)
@@ -129,6 +138,34 @@ func TestRulePlanModeWarning_NoTriggerLowKillRate(t *testing.T) {
 	}
 }
 
+func TestRulePlanModeWarning_NoTriggerWithoutEvidence(t *testing.T) {
+	tasks := []claude.AgentTask{
+		{AgentType: "Plan", Status: "killed"},
+		{AgentType: "Plan", Status: "killed"},
+		{AgentType: "Plan", Status: "completed"},
+	}
+
+	// Too few sessions to compare.
+	ctx := &FixContext{
+		Project:    scanner.Project{Path: "/tmp/test", Name: "test"},
+		AgentTasks: tasks,
+	}
+	if additions := rulePlanModeWarning(ctx); len(additions) != 0 {
+		t.Errorf("expected no additions without a plan mode comparison, got %d", len(additions))
+	}
+
+	// Planning helps despite the kill rate.
+	ctx.PlanModeEffect = &analyzer.ProjectPlanModeEffect{
+		WithPlan:        analyzer.PlanModeGroupStats{Sessions: 6, CommitRate: 0.8},
+		WithoutPlan:     analyzer.PlanModeGroupStats{Sessions: 8, CommitRate: 0.5},
+		CommitRateDelta: 0.3,
+		Verdict:         "helps",
+	}
+	if additions := rulePlanModeWarning(ctx); len(additions) != 0 {
+		t.Errorf("expected no additions when planning helps, got %d", len(additions))
+	}
+}
+
 func TestRulePlanModeWarning_NoTriggerNoAgents(t *testing.T) {
 	ctx := &FixContext{
 		Project: scanner.Project{Path: "/tmp/test", Name: "test"},
@@ -0,0 +1,60 @@
+// Package guard enforces the global --offline and --read-only modes. Every
+// outbound HTTP request goes through HTTPClient and every database is opened
+// by the store package, which consults ReadOnly, so a new feature can't
+// reach the network or write the database behind the user's back.
+package guard
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrOffline is returned for any network access while offline.
+	ErrOffline = errors.New("network access is disabled (--offline)")
+	// ErrReadOnly is returned for any database write while read-only.
+	ErrReadOnly = errors.New("database writes are disabled (--read-only)")
+)
+
+var offline, readOnly atomic.Bool
+
+// SetOffline turns offline mode on or off.
+func SetOffline(on bool) { offline.Store(on) }
+
+// Offline reports whether offline mode is on.
+func Offline() bool { return offline.Load() }
+
+// SetReadOnly turns read-only mode on or off.
+func SetReadOnly(on bool) { readOnly.Store(on) }
+
+// ReadOnly reports whether read-only mode is on.
+func ReadOnly() bool { return readOnly.Load() }
+
+// Network returns an error naming feature when offline mode is on, for
+// commands that can't do anything useful without the network.
+func Network(feature string) error {
+	if Offline() {
+		return fmt.Errorf("%s needs the network: %w", feature, ErrOffline)
+	}
+	return nil
+}
+
+// HTTPClient returns a client with the given timeout whose requests fail
+// with ErrOffline, without being sent, while offline mode is on.
+func HTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: transport{}}
+}
+
+// transport refuses requests while offline and otherwise defers to
+// http.DefaultTransport.
+type transport struct{}
+
+func (transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if Offline() {
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Host, ErrOffline)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
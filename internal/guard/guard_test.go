@@ -0,0 +1,49 @@
+package guard
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_RefusesWhileOffline(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer srv.Close()
+	client := HTTPClient(time.Second)
+
+	SetOffline(true)
+	defer SetOffline(false)
+	if _, err := client.Get(srv.URL); !errors.Is(err, ErrOffline) {
+		t.Errorf("expected ErrOffline, got %v", err)
+	}
+	if hits != 0 {
+		t.Errorf("expected no request to reach the server, got %d", hits)
+	}
+
+	SetOffline(false)
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("expected the request to go through online, got %v", err)
+	}
+	_ = resp.Body.Close()
+	if hits != 1 {
+		t.Errorf("expected 1 request, got %d", hits)
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	if err := Network("update-check"); err != nil {
+		t.Fatalf("expected no error online, got %v", err)
+	}
+
+	SetOffline(true)
+	defer SetOffline(false)
+	if err := Network("update-check"); !errors.Is(err, ErrOffline) {
+		t.Errorf("expected ErrOffline, got %v", err)
+	}
+}
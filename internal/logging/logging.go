@@ -0,0 +1,60 @@
+// Package logging provides the leveled diagnostic logger behind the global
+// --verbose flag. It is silent by default; once enabled, debug, info, and
+// warn records are written as text to the configured writer (stderr in the
+// CLI), so they never mix with --json output on stdout.
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+var logger atomic.Pointer[slog.Logger]
+
+func init() {
+	Disable()
+}
+
+// Enable writes all records at debug level and above to w.
+func Enable(w io.Writer) {
+	logger.Store(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
+
+// Disable discards all records. This is the default.
+func Disable() {
+	logger.Store(slog.New(slog.DiscardHandler))
+}
+
+// Debug logs fine-grained progress such as parse phases and file counts.
+func Debug(msg string, args ...any) {
+	logger.Load().Debug(msg, args...)
+}
+
+// Info logs notable events.
+func Info(msg string, args ...any) {
+	logger.Load().Info(msg, args...)
+}
+
+// Warn logs recoverable problems, including errors that are otherwise
+// swallowed so a command can keep going with partial data.
+func Warn(msg string, args ...any) {
+	logger.Load().Warn(msg, args...)
+}
+
+// Phase logs the start of a named phase and returns a function that logs its
+// completion with the elapsed time and any extra attributes:
+//
+//	done := logging.Phase("parse session meta", "dir", dir)
+//	...
+//	done("sessions", len(results))
+func Phase(name string, args ...any) func(args ...any) {
+	start := time.Now()
+	Debug(name+": start", args...)
+	return func(extra ...any) {
+		attrs := append(append([]any{}, args...), extra...)
+		attrs = append(attrs, "elapsed", time.Since(start).Round(time.Microsecond))
+		Debug(name+": done", attrs...)
+	}
+}
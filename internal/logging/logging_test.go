@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDisabledByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	Enable(&buf)
+	Disable()
+
+	Warn("should not appear")
+	if buf.Len() != 0 {
+		t.Errorf("expected no output when disabled, got %q", buf.String())
+	}
+}
+
+func TestEnable_WritesAllLevels(t *testing.T) {
+	var buf bytes.Buffer
+	Enable(&buf)
+	t.Cleanup(Disable)
+
+	Debug("debug msg", "files", 3)
+	Info("info msg")
+	Warn("warn msg", "err", "boom")
+
+	out := buf.String()
+	for _, want := range []string{"level=DEBUG", "debug msg", "files=3", "level=INFO", "level=WARN", "err=boom"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPhase_LogsStartAndDone(t *testing.T) {
+	var buf bytes.Buffer
+	Enable(&buf)
+	t.Cleanup(Disable)
+
+	done := Phase("parse facets", "dir", "/tmp/x")
+	done("count", 7)
+
+	out := buf.String()
+	for _, want := range []string{`msg="parse facets: start"`, `msg="parse facets: done"`, "dir=/tmp/x", "count=7", "elapsed="} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
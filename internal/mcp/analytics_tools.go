@@ -72,7 +72,7 @@ func (s *Server) handleGetAgentPerformance(args json.RawMessage) (any, error) {
 		tasks = nil
 	}
 
-	perf := analyzer.AnalyzeAgents(tasks)
+	perf := analyzer.AnalyzeAgents(tasks, s.agentAliases)
 
 	byType := make(map[string]AgentTypePerfDetail, len(perf.ByType))
 	for agentType, stats := range perf.ByType {
@@ -21,10 +21,10 @@ type ProjectAnomaliesResult struct {
 // addAnomalyTools registers the get_project_anomalies tool on s.
 func addAnomalyTools(s *Server) {
 	s.registerTool(toolDef{
-		Name:        "get_project_anomalies",
-		Description: "Detect anomalous sessions for a project using z-score analysis against a historical baseline. Returns sessions with cost or friction deviating beyond the threshold.",
-		InputSchema: json.RawMessage(`{"type":"object","properties":{"project":{"type":"string","description":"Project name (e.g. 'commitmux'). Omit to use the current session's project."},"threshold":{"type":"number","description":"Z-score threshold for anomaly detection (default 2.0)"}},"additionalProperties":false}`),
-		Handler:     s.handleGetProjectAnomalies,
+		Name:            "get_project_anomalies",
+		Description:     "Detect anomalous sessions for a project using z-score analysis against a historical baseline. Returns sessions with cost or friction deviating beyond the threshold.",
+		InputSchema:     json.RawMessage(`{"type":"object","properties":{"project":{"type":"string","description":"Project name (e.g. 'commitmux'). Omit to use the current session's project."},"threshold":{"type":"number","description":"Z-score threshold for anomaly detection (default 2.0)"}},"additionalProperties":false}`),
+		ProgressHandler: s.handleGetProjectAnomalies,
 	})
 }
 
@@ -32,7 +32,7 @@ func addAnomalyTools(s *Server) {
 // Project resolution follows the same pattern as handleGetProjectHealth:
 // active session first, then most recent closed session.
 // If no baseline exists in the DB, it computes one on the fly and persists it.
-func (s *Server) handleGetProjectAnomalies(args json.RawMessage) (any, error) {
+func (s *Server) handleGetProjectAnomalies(args json.RawMessage, progress claude.ProgressFunc) (any, error) {
 	var params struct {
 		Project   *string  `json:"project"`
 		Threshold *float64 `json:"threshold"`
@@ -122,7 +122,7 @@ func (s *Server) handleGetProjectAnomalies(args json.RawMessage) (any, error) {
 	// If no baseline exists, compute it on the fly.
 	if baseline == nil {
 		// Build SAWIDs set for the project sessions.
-		sawIDs, sawErr := buildSAWIDSet(s.claudeHome, projectSessions)
+		sawIDs, sawErr := buildSAWIDSet(s.claudeHome, projectSessions, progress)
 		if sawErr != nil {
 			// Non-fatal: proceed with empty SAW set.
 			sawIDs = map[string]bool{}
@@ -162,8 +162,8 @@ func (s *Server) handleGetProjectAnomalies(args json.RawMessage) (any, error) {
 
 // buildSAWIDSet parses session transcripts and returns a set of session IDs
 // that were detected as SAW (Scout-and-Wave) sessions.
-func buildSAWIDSet(claudeHome string, sessions []claude.SessionMeta) (map[string]bool, error) {
-	spans, err := claude.ParseSessionTranscripts(claudeHome)
+func buildSAWIDSet(claudeHome string, sessions []claude.SessionMeta, progress claude.ProgressFunc) (map[string]bool, error) {
+	spans, err := claude.ParseSessionTranscriptsProgress(claudeHome, progress)
 	if err != nil {
 		return nil, err
 	}
@@ -74,12 +74,12 @@ func addCorrelateTools(s *Server) {
             "required": ["outcome"],
             "additionalProperties": false
         }`),
-		Handler: s.handleGetCausalInsights,
+		ProgressHandler: s.handleGetCausalInsights,
 	})
 }
 
 // handleGetCausalInsights handles the get_causal_insights MCP tool.
-func (s *Server) handleGetCausalInsights(args json.RawMessage) (any, error) {
+func (s *Server) handleGetCausalInsights(args json.RawMessage, progress claude.ProgressFunc) (any, error) {
 	// Parse arguments.
 	var params struct {
 		Outcome *string `json:"outcome"`
@@ -139,7 +139,7 @@ func (s *Server) handleGetCausalInsights(args json.RawMessage) (any, error) {
 
 	// Load SAW sessions (non-fatal on error — treat as empty map).
 	sawSessionMap := make(map[string]bool)
-	spans, err := claude.ParseSessionTranscripts(s.claudeHome)
+	spans, err := claude.ParseSessionTranscriptsProgress(s.claudeHome, progress)
 	if err == nil {
 		sawSessions := claude.ComputeSAWWaves(spans)
 		for _, saw := range sawSessions {
@@ -2,7 +2,9 @@ package mcp
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
@@ -36,7 +38,19 @@ type ProjectSpend struct {
 	Sessions int     `json:"sessions"`
 }
 
-// addCostTools registers the get_cost_summary handler on s.
+// CostEstimateResult holds the estimated cost of a hypothetical session.
+type CostEstimateResult struct {
+	// Model is the pricing tier the estimate used.
+	Model        string  `json:"model"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	EstimatedUSD float64 `json:"estimated_usd"`
+	// CacheAdjusted reports whether the cache ratio from the stats cache was
+	// applied; without one, all input is priced as uncached.
+	CacheAdjusted bool `json:"cache_adjusted"`
+}
+
+// addCostTools registers the get_cost_summary and estimate_cost handlers on s.
 func addCostTools(s *Server) {
 	s.registerTool(toolDef{
 		Name:        "get_cost_summary",
@@ -44,6 +58,60 @@ func addCostTools(s *Server) {
 		InputSchema: noArgsSchema,
 		Handler:     s.handleGetCostSummary,
 	})
+	s.registerTool(toolDef{
+		Name:        "estimate_cost",
+		Description: "Estimated USD cost of a hypothetical session or operation from its input and output token counts, priced like recorded sessions (including the user's cache hit ratio). Use to tell the user roughly what an operation would cost before running it.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"input_tokens":{"type":"integer","description":"Input tokens the operation would use"},"output_tokens":{"type":"integer","description":"Output tokens the operation would generate"},"model":{"type":"string","description":"Model tier or name, e.g. 'opus' or 'claude-opus-4-1' (default sonnet)"}},"additionalProperties":false}`),
+		Handler:     s.handleEstimateCost,
+	})
+}
+
+// handleEstimateCost prices input and output token counts at the requested
+// model's rates, with the cache ratio computed from the stats cache.
+func (s *Server) handleEstimateCost(args json.RawMessage) (any, error) {
+	var params struct {
+		InputTokens  int    `json:"input_tokens"`
+		OutputTokens int    `json:"output_tokens"`
+		Model        string `json:"model"`
+	}
+	if len(args) > 0 && string(args) != "null" {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+	if params.InputTokens < 0 || params.OutputTokens < 0 {
+		return nil, fmt.Errorf("input_tokens and output_tokens must be non-negative")
+	}
+
+	tier, err := pricingTier(params.Model)
+	if err != nil {
+		return nil, err
+	}
+	ratio := s.loadCacheRatio()
+	return CostEstimateResult{
+		Model:         tier,
+		InputTokens:   params.InputTokens,
+		OutputTokens:  params.OutputTokens,
+		EstimatedUSD:  analyzer.EstimateTokenCost(params.InputTokens, params.OutputTokens, analyzer.DefaultPricing[tier], ratio),
+		CacheAdjusted: ratio != analyzer.NoCacheRatio(),
+	}, nil
+}
+
+// pricingTier resolves a model argument to a DefaultPricing tier: sonnet when
+// empty, the tier itself when named (including tiers added by pricing
+// overrides), or the tier a full model name belongs to.
+func pricingTier(model string) (string, error) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return "sonnet", nil
+	}
+	if _, ok := analyzer.DefaultPricing[model]; ok {
+		return model, nil
+	}
+	if tier := analyzer.ClassifyModelTier(model); tier != analyzer.TierOther {
+		return string(tier), nil
+	}
+	return "", fmt.Errorf("unrecognized model %q: use opus, sonnet, haiku, or a model name containing one", model)
 }
 
 // handleGetCostSummary returns aggregated cost data across today, this week,
@@ -374,3 +374,61 @@ func TestGetCostSummary_PerModelCost(t *testing.T) {
 			r.AllTimeUSD, allSonnetCost)
 	}
 }
+
+// TestEstimateCost_DefaultsToSonnet verifies that an estimate without a model
+// uses sonnet rates and, with no stats cache, prices all input uncached.
+func TestEstimateCost_DefaultsToSonnet(t *testing.T) {
+	s := newCostTestServer(t.TempDir())
+
+	result, err := callTool(s, "estimate_cost", json.RawMessage(`{"input_tokens":1000000,"output_tokens":100000}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, ok := result.(CostEstimateResult)
+	if !ok {
+		t.Fatalf("expected CostEstimateResult, got %T", result)
+	}
+
+	p := analyzer.DefaultPricing["sonnet"]
+	want := p.InputPerMillion + 0.1*p.OutputPerMillion
+	if r.Model != "sonnet" || r.CacheAdjusted {
+		t.Errorf("Model = %q, CacheAdjusted = %v; want sonnet, false", r.Model, r.CacheAdjusted)
+	}
+	if diff := r.EstimatedUSD - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("EstimatedUSD = %f, want %f", r.EstimatedUSD, want)
+	}
+}
+
+// TestEstimateCost_Model verifies that tier names and full model names pick
+// their tier's rates.
+func TestEstimateCost_Model(t *testing.T) {
+	s := newCostTestServer(t.TempDir())
+
+	for _, model := range []string{"opus", "Claude-Opus-4-1"} {
+		result, err := callTool(s, "estimate_cost", json.RawMessage(fmt.Sprintf(`{"input_tokens":1000000,"model":%q}`, model)))
+		if err != nil {
+			t.Fatalf("model %q: unexpected error: %v", model, err)
+		}
+		r := result.(CostEstimateResult)
+		if r.Model != "opus" || r.EstimatedUSD != analyzer.DefaultPricing["opus"].InputPerMillion {
+			t.Errorf("model %q: got %+v, want opus at its input rate", model, r)
+		}
+	}
+}
+
+// TestEstimateCost_InvalidInput verifies that negative token counts and
+// unknown models are rejected.
+func TestEstimateCost_InvalidInput(t *testing.T) {
+	s := newCostTestServer(t.TempDir())
+
+	for _, args := range []string{
+		`{"input_tokens":-1}`,
+		`{"output_tokens":-5}`,
+		`{"input_tokens":10,"model":"gpt-4"}`,
+		`{"input_tokens":"lots"}`,
+	} {
+		if _, err := callTool(s, "estimate_cost", json.RawMessage(args)); err == nil {
+			t.Errorf("args %s: expected an error", args)
+		}
+	}
+}
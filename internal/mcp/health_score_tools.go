@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+)
+
+// HealthScoreResult is a project's composite health score.
+type HealthScoreResult struct {
+	Project string `json:"project"`
+	Path    string `json:"path,omitempty"`
+	scanner.HealthScore
+}
+
+// addHealthScoreTools registers the get_project_health_score MCP tool on s.
+func addHealthScoreTools(s *Server) {
+	s.registerTool(toolDef{
+		Name:        "get_project_health_score",
+		Description: "Composite 0-100 health score and letter grade (A-F) for a project, combining readiness, inverse friction rate, commit rate, and agent success. Projects with fewer than 3 sessions are graded 'insufficient data'.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"project":{"type":"string","description":"Project name (e.g. 'commitmux'). Omit to use the current session's project."}},"additionalProperties":false}`),
+		Handler:     s.handleGetProjectHealthScore,
+	})
+}
+
+// handleGetProjectHealthScore computes the health score for the named
+// project, or the current session's project when none is given.
+func (s *Server) handleGetProjectHealthScore(args json.RawMessage) (any, error) {
+	var params struct {
+		Project *string `json:"project"`
+	}
+	if len(args) > 0 && string(args) != "null" {
+		_ = json.Unmarshal(args, &params)
+	}
+
+	project := s.resolveProject(params.Project)
+	if project == "" {
+		return nil, errors.New("no project found")
+	}
+
+	sessions, err := claude.ParseAllSessionMeta(s.claudeHome)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := s.loadTags()
+	allWeights := loadAllWeights(s.weightsStorePath)
+
+	// Sessions can be attributed to a project by tag or weight rather than
+	// by path, so pin matches to one path for the scanner's path filters.
+	var projectPath string
+	var projectSessions []claude.SessionMeta
+	for _, sess := range sessions {
+		if !sessionMatchesProject(sess.SessionID, sess.ProjectPath, tags, allWeights[sess.SessionID], project) {
+			continue
+		}
+		if projectPath == "" && sess.ProjectPath != "" {
+			projectPath = sess.ProjectPath
+		}
+		projectSessions = append(projectSessions, sess)
+	}
+	for i := range projectSessions {
+		projectSessions[i].ProjectPath = projectPath
+	}
+
+	// Facets, agent tasks, and settings are optional.
+	facets, _ := claude.ParseAllFacets(s.claudeHome)
+	agentTasks, _ := claude.ParseAgentTasks(s.claudeHome)
+	settings, _ := claude.ParseSettings(s.claudeHome)
+
+	var p scanner.Project
+	if projectPath != "" {
+		p = scanner.InspectProject(projectPath)
+	}
+
+	w := s.healthWeights
+	if w == (config.HealthWeights{}) {
+		w = config.DefaultHealthWeights
+	}
+
+	return HealthScoreResult{
+		Project:     project,
+		Path:        projectPath,
+		HealthScore: scanner.ComputeProjectHealth(&p, projectSessions, facets, agentTasks, settings, w),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/scanner"
+)
+
+func TestGetProjectHealthScore_Graded(t *testing.T) {
+	dir := t.TempDir()
+	writeSessionMetaFull(t, dir, "sess-a1", "2026-01-10T10:00:00Z", "/home/user/projectA", 0, 1)
+	writeSessionMetaFull(t, dir, "sess-a2", "2026-01-11T10:00:00Z", "/home/user/projectA", 0, 1)
+	writeSessionMetaFull(t, dir, "sess-a3", "2026-01-12T10:00:00Z", "/home/user/projectA", 0, 0)
+	writeSessionMetaFull(t, dir, "sess-b1", "2026-01-13T10:00:00Z", "/home/user/projectB", 0, 1)
+
+	s := newTestServer(dir, 0)
+
+	result, err := callTool(s, "get_project_health_score", json.RawMessage(`{"project":"projectA"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, ok := result.(HealthScoreResult)
+	if !ok {
+		t.Fatalf("expected HealthScoreResult, got %T", result)
+	}
+	if r.Project != "projectA" || r.Sessions != 3 {
+		t.Errorf("Project, Sessions = %q, %d; want projectA, 3", r.Project, r.Sessions)
+	}
+	if r.Insufficient() {
+		t.Fatalf("expected a graded score, got %q", r.Grade)
+	}
+	// Readiness 0 (no such directory), commits 2/3, no facets or agents:
+	// (0.3×0 + 0.2×0.667) / 0.5 = 26.7.
+	if r.Score != 26.7 || r.Grade != "F" {
+		t.Errorf("Score, Grade = %v, %q; want 26.7, F", r.Score, r.Grade)
+	}
+}
+
+func TestGetProjectHealthScore_InsufficientData(t *testing.T) {
+	dir := t.TempDir()
+	writeSessionMetaFull(t, dir, "sess-b1", "2026-01-13T10:00:00Z", "/home/user/projectB", 0, 1)
+
+	s := newTestServer(dir, 0)
+
+	result, err := callTool(s, "get_project_health_score", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := result.(HealthScoreResult)
+	if r.Project != "projectB" || r.Grade != scanner.GradeInsufficient {
+		t.Errorf("got %+v, want projectB with %q", r, scanner.GradeInsufficient)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"path/filepath"
 
+	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/config"
 )
 
@@ -19,6 +20,14 @@ type Server struct {
 	budgetUSD        float64
 	tagStorePath     string
 	weightsStorePath string
+	suggestRulesPath string
+	healthWeights    config.HealthWeights
+	staleWeeks       int
+	agentAliases     map[string]string
+	version          string
+	// baseConfig is the global config that projects' .claudewatch.yaml
+	// files override. Nil leaves every project on the defaults.
+	baseConfig *config.Config
 }
 
 // toolDef describes a registered MCP tool.
@@ -27,11 +36,26 @@ type toolDef struct {
 	Description string
 	InputSchema json.RawMessage
 	Handler     toolHandler
+	// ProgressHandler replaces Handler for long-running tools that report
+	// progress while they parse.
+	ProgressHandler progressToolHandler
 }
 
 // toolHandler is the function signature for MCP tool handlers.
 type toolHandler func(args json.RawMessage) (any, error)
 
+// progressToolHandler is the signature for tool handlers that report
+// progress. progress is nil when the client didn't ask for progress.
+type progressToolHandler func(args json.RawMessage, progress claude.ProgressFunc) (any, error)
+
+// call runs the tool's handler, passing progress to a ProgressHandler.
+func (t *toolDef) call(args json.RawMessage, progress claude.ProgressFunc) (any, error) {
+	if t.ProgressHandler != nil {
+		return t.ProgressHandler(args, progress)
+	}
+	return t.Handler(args)
+}
+
 // jsonrpcRequest is a JSON-RPC 2.0 request message.
 type jsonrpcRequest struct {
 	JSONRPC string           `json:"jsonrpc"`
@@ -58,6 +82,18 @@ type jsonrpcError struct {
 type toolsCallParams struct {
 	Name      string          `json:"name"`
 	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Meta      struct {
+		// ProgressToken asks for notifications/progress while the call
+		// runs, tagged with this token.
+		ProgressToken json.RawMessage `json:"progressToken,omitempty"`
+	} `json:"_meta"`
+}
+
+// progressParams is the params structure for notifications/progress.
+type progressParams struct {
+	ProgressToken json.RawMessage `json:"progressToken"`
+	Progress      int             `json:"progress"`
+	Total         int             `json:"total"`
 }
 
 // toolsCallResult wraps a tool result as an MCP content response.
@@ -87,11 +123,22 @@ func NewServer(cfg *config.Config, budgetUSD float64) *Server {
 		budgetUSD:        budgetUSD,
 		tagStorePath:     filepath.Join(config.ConfigDir(), "session-tags.json"),
 		weightsStorePath: filepath.Join(config.ConfigDir(), "session-project-weights.json"),
+		suggestRulesPath: config.SuggestRulesPath(),
+		healthWeights:    cfg.HealthWeights,
+		staleWeeks:       cfg.Friction.StaleWeeks,
+		agentAliases:     cfg.AgentAliases,
+		version:          "dev",
+		baseConfig:       cfg,
 	}
 	addTools(s)
 	return s
 }
 
+// SetVersion sets the version reported in serverInfo on initialize.
+func (s *Server) SetVersion(v string) {
+	s.version = v
+}
+
 // registerTool appends a toolDef to s.tools.
 func (s *Server) registerTool(def toolDef) {
 	s.tools = append(s.tools, def)
@@ -168,7 +215,7 @@ func (s *Server) handleLine(_ context.Context, line string, bw *bufio.Writer) er
 			},
 			"serverInfo": map[string]any{
 				"name":    "claudewatch",
-				"version": "0.1.0",
+				"version": s.version,
 			},
 		}
 
@@ -214,7 +261,15 @@ func (s *Server) handleLine(_ context.Context, line string, bw *bufio.Writer) er
 			args = json.RawMessage(`{}`)
 		}
 
-		result, err := found.Handler(args)
+		var progress claude.ProgressFunc
+		if token := params.Meta.ProgressToken; len(token) > 0 && string(token) != "null" {
+			progress = func(done, total int) {
+				// A failed write surfaces when the response is written.
+				_ = s.writeNotification(bw, "notifications/progress", progressParams{ProgressToken: token, Progress: done, Total: total})
+			}
+		}
+
+		result, err := found.call(args, progress)
 		if err != nil {
 			resp.Result = toolsCallResult{
 				Content: []mcpContent{{Type: "text", Text: err.Error()}},
@@ -255,9 +310,24 @@ func (s *Server) writeError(bw *bufio.Writer, id *json.RawMessage, code int, mes
 	return s.writeResponse(bw, resp)
 }
 
+// writeNotification writes a JSON-RPC notification, which has no id and
+// expects no response.
+func (s *Server) writeNotification(bw *bufio.Writer, method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.writeMessage(bw, jsonrpcRequest{JSONRPC: "2.0", Method: method, Params: data})
+}
+
 // writeResponse marshals resp as a single JSON line and flushes the writer.
 func (s *Server) writeResponse(bw *bufio.Writer, resp jsonrpcResponse) error {
-	data, err := json.Marshal(resp)
+	return s.writeMessage(bw, resp)
+}
+
+// writeMessage marshals msg as a single JSON line and flushes the writer.
+func (s *Server) writeMessage(bw *bufio.Writer, msg any) error {
+	data, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/blackwell-systems/claudewatch/internal/claude"
 	"github.com/blackwell-systems/claudewatch/internal/config"
 )
 
@@ -82,9 +83,10 @@ func runServer(t *testing.T, s *Server) (
 }
 
 // TestRun_Initialize verifies the server responds to "initialize" with the
-// correct protocolVersion and serverInfo.name.
+// correct protocolVersion, serverInfo.name, and serverInfo.version.
 func TestRun_Initialize(t *testing.T) {
 	s := newEmptyServer()
+	s.SetVersion("1.2.3")
 	sendLine, _, cleanup := runServer(t, s)
 	defer cleanup()
 
@@ -95,7 +97,8 @@ func TestRun_Initialize(t *testing.T) {
 		Result struct {
 			ProtocolVersion string `json:"protocolVersion"`
 			ServerInfo      struct {
-				Name string `json:"name"`
+				Name    string `json:"name"`
+				Version string `json:"version"`
 			} `json:"serverInfo"`
 		} `json:"result"`
 	}
@@ -109,6 +112,9 @@ func TestRun_Initialize(t *testing.T) {
 		t.Errorf("expected serverInfo.name == 'claudewatch', got %q; response: %s",
 			parsed.Result.ServerInfo.Name, resp)
 	}
+	if parsed.Result.ServerInfo.Version != "1.2.3" {
+		t.Errorf("expected serverInfo.version == '1.2.3', got %q", parsed.Result.ServerInfo.Version)
+	}
 }
 
 // TestRun_ToolsList verifies the server responds to "tools/list" with a list
@@ -283,3 +289,74 @@ func TestRun_EOFClean(t *testing.T) {
 		t.Error("Run did not return after EOF")
 	}
 }
+
+// runLines feeds lines to s.Run and returns every line it writes.
+func runLines(t *testing.T, s *Server, lines ...string) []string {
+	t.Helper()
+	var out strings.Builder
+	if err := s.Run(context.Background(), strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+}
+
+// newProgressServer returns a server with one tool, "slow", that reports
+// two steps of progress.
+func newProgressServer() *Server {
+	s := &Server{}
+	s.registerTool(toolDef{
+		Name:        "slow",
+		InputSchema: json.RawMessage(`{"type":"object"}`),
+		ProgressHandler: func(_ json.RawMessage, progress claude.ProgressFunc) (any, error) {
+			if progress != nil {
+				progress(1, 2)
+				progress(2, 2)
+			}
+			return map[string]string{"status": "done"}, nil
+		},
+	})
+	return s
+}
+
+// TestRun_ProgressNotifications verifies that a tools/call carrying a
+// progressToken streams notifications/progress before its response.
+func TestRun_ProgressNotifications(t *testing.T) {
+	lines := runLines(t, newProgressServer(),
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow","arguments":{},"_meta":{"progressToken":"tok-1"}}}`)
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 2 notifications and a response: %v", len(lines), lines)
+	}
+	for i, want := range []int{1, 2} {
+		var note struct {
+			ID     *json.RawMessage `json:"id"`
+			Method string           `json:"method"`
+			Params progressParams   `json:"params"`
+		}
+		if err := json.Unmarshal([]byte(lines[i]), &note); err != nil {
+			t.Fatalf("unmarshal notification: %v", err)
+		}
+		if note.ID != nil || note.Method != "notifications/progress" {
+			t.Errorf("line %d = %s, want a notifications/progress without id", i, lines[i])
+		}
+		if string(note.Params.ProgressToken) != `"tok-1"` || note.Params.Progress != want || note.Params.Total != 2 {
+			t.Errorf("params = %+v, want tok-1 at %d of 2", note.Params, want)
+		}
+	}
+	var resp jsonrpcResponse
+	if err := json.Unmarshal([]byte(lines[2]), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.ID == nil || string(*resp.ID) != "7" || resp.Error != nil {
+		t.Errorf("response = %s, want the result for id 7", lines[2])
+	}
+}
+
+// TestRun_NoProgressToken verifies that without a progressToken the tool
+// gets no progress callback and only the response is written.
+func TestRun_NoProgressToken(t *testing.T) {
+	lines := runLines(t, newProgressServer(),
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slow","arguments":{}}}`)
+	if len(lines) != 1 || !strings.Contains(lines[0], `"id":1`) {
+		t.Errorf("lines = %v, want only the response", lines)
+	}
+}
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/store"
+)
+
+// defaultOpenSuggestionsLimit is how many suggestions open_suggestions
+// returns without a limit.
+const defaultOpenSuggestionsLimit = 10
+
+// OpenSuggestionsResult is the MCP response for open_suggestions.
+type OpenSuggestionsResult struct {
+	Suggestions []SuggestionItem `json:"suggestions"`
+	// TotalCount is the number of open suggestions in the category before
+	// the limit was applied.
+	TotalCount int `json:"total_count"`
+	// Source is "store" when the suggestions are the open ones of the latest
+	// track snapshot, or "computed" when none has recorded suggestions yet.
+	Source string `json:"source"`
+	// SnapshotID is the snapshot the stored suggestions come from.
+	SnapshotID int64  `json:"snapshot_id,omitempty"`
+	Category   string `json:"category,omitempty"`
+	// RuleErrors describes invalid custom rules that were skipped when the
+	// suggestions were computed.
+	RuleErrors string `json:"rule_errors,omitempty"`
+}
+
+// addOpenSuggestionsTools registers the open_suggestions MCP tool on s.
+func addOpenSuggestionsTools(s *Server) {
+	s.registerTool(toolDef{
+		Name:        "open_suggestions",
+		Description: "The open improvement suggestions for this workspace, highest impact first: the ones the latest track snapshot left open, or freshly computed when nothing has been tracked. Call to answer \"what should we improve about how I work here?\". Optionally filter by category.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"category":{"type":"string","description":"Only return suggestions in this category (e.g. 'friction', 'quality', 'agents')."},"limit":{"type":"integer","description":"Maximum suggestions to return (default 10, max 20)."}},"additionalProperties":false}`),
+		Handler:     s.handleOpenSuggestions,
+	})
+}
+
+// handleOpenSuggestions implements the open_suggestions MCP tool. It reads
+// the open suggestions of the latest snapshot that recorded suggestions, and
+// runs the suggestion engine instead when there is none.
+func (s *Server) handleOpenSuggestions(args json.RawMessage) (any, error) {
+	var params struct {
+		Category string `json:"category"`
+		Limit    *int   `json:"limit"`
+	}
+	if len(args) > 0 && string(args) != "null" {
+		_ = json.Unmarshal(args, &params)
+	}
+
+	limit := defaultOpenSuggestionsLimit
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+	if limit > maxSuggestLimit {
+		limit = maxSuggestLimit
+	}
+
+	result := OpenSuggestionsResult{Source: "store", Category: params.Category}
+	items, snapshotID, err := storedOpenSuggestions(config.DBPath())
+	if err != nil {
+		return nil, err
+	}
+	if snapshotID == 0 {
+		raw, ruleErrors := s.runSuggestEngine()
+		items = suggestionItems(raw)
+		result.Source = "computed"
+		result.RuleErrors = ruleErrors
+	}
+	result.SnapshotID = snapshotID
+
+	if params.Category != "" {
+		filtered := items[:0]
+		for _, item := range items {
+			if strings.EqualFold(item.Category, params.Category) {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].ImpactScore > items[j].ImpactScore })
+
+	result.TotalCount = len(items)
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	result.Suggestions = items
+	return result, nil
+}
+
+// storedOpenSuggestions returns the open suggestions of the latest
+// whole-history snapshot in the database at dbPath that recorded
+// suggestions, and that snapshot's ID. Project-scoped snapshots are
+// skipped. The ID is 0 when there is no such snapshot, or no database; a
+// missing database isn't created.
+func storedOpenSuggestions(dbPath string) ([]SuggestionItem, int64, error) {
+	if _, err := os.Stat(dbPath); errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, nil
+	}
+	db, err := store.Open(dbPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = db.Close() }()
+
+	snapshots, err := db.GetSuggestionSnapshots()
+	if err != nil {
+		return nil, 0, err
+	}
+	var latest int64
+	for _, snap := range snapshots {
+		if snap.Project == "" {
+			latest = snap.ID
+		}
+	}
+	if latest == 0 {
+		return nil, 0, nil
+	}
+	stored, err := db.GetSnapshotSuggestions(latest)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]SuggestionItem, 0, len(stored))
+	for _, sg := range stored {
+		if sg.Status != "open" {
+			continue
+		}
+		items = append(items, SuggestionItem{
+			Category:    sg.Category,
+			Priority:    sg.Priority,
+			Title:       sg.Title,
+			Description: sg.Description,
+			ImpactScore: sg.ImpactScore,
+		})
+	}
+	return items, latest, nil
+}
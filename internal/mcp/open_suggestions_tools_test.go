@@ -0,0 +1,144 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/store"
+)
+
+// callOpenSuggestions calls open_suggestions through the tool registry and
+// type-asserts the result.
+func callOpenSuggestions(t *testing.T, s *Server, args string) OpenSuggestionsResult {
+	t.Helper()
+	result, err := callTool(s, "open_suggestions", json.RawMessage(args))
+	if err != nil {
+		t.Fatalf("open_suggestions error: %v", err)
+	}
+	r, ok := result.(OpenSuggestionsResult)
+	if !ok {
+		t.Fatalf("expected OpenSuggestionsResult, got %T", result)
+	}
+	return r
+}
+
+// recordSuggestions stores a track snapshot with the given suggestions in
+// the database under the test's HOME.
+func recordSuggestions(t *testing.T, suggestions []store.Suggestion) int64 {
+	t.Helper()
+	return recordProjectSuggestions(t, "", suggestions)
+}
+
+// recordProjectSuggestions is recordSuggestions for a snapshot scoped to
+// project.
+func recordProjectSuggestions(t *testing.T, project string, suggestions []store.Suggestion) int64 {
+	t.Helper()
+	db, err := store.Open(config.DBPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	id, err := db.RecordSnapshot(store.SnapshotRecord{
+		Command:     "track",
+		Project:     project,
+		Sections:    store.SnapshotSections,
+		Suggestions: suggestions,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestOpenSuggestions_FromStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	// An earlier snapshot's suggestions no longer count.
+	recordSuggestions(t, []store.Suggestion{{Category: "agents", Title: "Old", ImpactScore: 99, Status: "open"}})
+	latest := recordSuggestions(t, []store.Suggestion{
+		{Category: "friction", Title: "Low", Description: "d", Priority: 3, ImpactScore: 1, Status: "open"},
+		{Category: "quality", Title: "High", ImpactScore: 9, Status: "open"},
+		{Category: "friction", Title: "Resolved", ImpactScore: 5, Status: "resolved"},
+	})
+
+	s := newTestServer(dir, 0)
+	r := callOpenSuggestions(t, s, `{}`)
+
+	if r.Source != "store" || r.SnapshotID != latest {
+		t.Errorf("Source = %q SnapshotID = %d, want store and %d", r.Source, r.SnapshotID, latest)
+	}
+	if r.TotalCount != 2 || len(r.Suggestions) != 2 {
+		t.Fatalf("got %d of %d suggestions, want the 2 open ones", len(r.Suggestions), r.TotalCount)
+	}
+	if r.Suggestions[0].Title != "High" || r.Suggestions[1].Title != "Low" {
+		t.Errorf("order = %q, %q; want High, Low", r.Suggestions[0].Title, r.Suggestions[1].Title)
+	}
+	if low := r.Suggestions[1]; low.Category != "friction" || low.Priority != 3 || low.Description != "d" {
+		t.Errorf("Low = %+v, want its stored fields", low)
+	}
+
+	r = callOpenSuggestions(t, s, `{"category":"Friction"}`)
+	if r.TotalCount != 1 || r.Suggestions[0].Title != "Low" {
+		t.Errorf("category filter = %+v, want only Low", r.Suggestions)
+	}
+}
+
+func TestOpenSuggestions_SkipsProjectSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	latest := recordSuggestions(t, []store.Suggestion{{Category: "friction", Title: "Workspace", Status: "open"}})
+	recordProjectSuggestions(t, "alpha", []store.Suggestion{{Category: "friction", Title: "Alpha only", Status: "open"}})
+
+	r := callOpenSuggestions(t, newTestServer(dir, 0), `{}`)
+	if r.SnapshotID != latest || r.TotalCount != 1 || r.Suggestions[0].Title != "Workspace" {
+		t.Errorf("got snapshot %d with %+v, want %d with only Workspace", r.SnapshotID, r.Suggestions, latest)
+	}
+}
+
+func TestOpenSuggestions_Limit(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	var suggestions []store.Suggestion
+	for i := range 25 {
+		suggestions = append(suggestions, store.Suggestion{Category: "friction", Title: fmt.Sprint(i), ImpactScore: float64(i), Status: "open"})
+	}
+	recordSuggestions(t, suggestions)
+
+	s := newTestServer(dir, 0)
+
+	r := callOpenSuggestions(t, s, `{}`)
+	if len(r.Suggestions) != defaultOpenSuggestionsLimit || r.TotalCount != 25 {
+		t.Errorf("got %d of %d, want %d of 25", len(r.Suggestions), r.TotalCount, defaultOpenSuggestionsLimit)
+	}
+	if r.Suggestions[0].Title != "24" {
+		t.Errorf("first = %q, want the highest impact", r.Suggestions[0].Title)
+	}
+	if r = callOpenSuggestions(t, s, `{"limit":100}`); len(r.Suggestions) != maxSuggestLimit {
+		t.Errorf("got %d with limit 100, want the cap of %d", len(r.Suggestions), maxSuggestLimit)
+	}
+}
+
+func TestOpenSuggestions_ComputedWithoutStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	writeSessionMeta(t, dir, "sess-1", "2026-01-15T10:00:00Z", "/home/user/project", 1000, 500)
+
+	s := newTestServer(dir, 0)
+	r := callOpenSuggestions(t, s, `{}`)
+
+	if r.Source != "computed" || r.SnapshotID != 0 {
+		t.Errorf("Source = %q SnapshotID = %d, want computed and 0", r.Source, r.SnapshotID)
+	}
+	if r.Suggestions == nil || r.TotalCount < len(r.Suggestions) {
+		t.Errorf("inconsistent result: %d of %d", len(r.Suggestions), r.TotalCount)
+	}
+	if _, err := os.Stat(config.DBPath()); !os.IsNotExist(err) {
+		t.Errorf("database was created: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/blackwell-systems/claudewatch/internal/watcher"
+)
+
+// RecentChange is one notable change detected by the watcher's comparison.
+type RecentChange struct {
+	Level   string `json:"level"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// RecentChangesResult is the MCP response for recent_changes.
+type RecentChangesResult struct {
+	// SinceSessionID is the most recent session, whose effect the changes
+	// describe. It is empty when there is too little history to compare.
+	SinceSessionID string         `json:"since_session_id,omitempty"`
+	SinceProject   string         `json:"since_project,omitempty"`
+	SinceStart     string         `json:"since_start,omitempty"`
+	Changes        []RecentChange `json:"changes"`
+}
+
+// addRecentChangesTools registers the recent_changes MCP tool on s.
+func addRecentChangesTools(s *Server) {
+	s.registerTool(toolDef{
+		Name:        "recent_changes",
+		Description: "What changed with the most recent session: new or spiking friction types, agent kill or success rate shifts, stale friction, new projects, and high-correction sessions, as the watch command would alert. Call at session start to pick up where the last session left off. Returns an empty list with fewer than two sessions.",
+		InputSchema: noArgsSchema,
+		Handler:     s.handleRecentChanges,
+	})
+}
+
+// handleRecentChanges compares the state before the most recent session
+// with the current state using the watcher's alert rules.
+func (s *Server) handleRecentChanges(args json.RawMessage) (any, error) {
+	w := watcher.New(s.claudeHome, 0, nil)
+	w.StaleWeeks = s.staleWeeks
+
+	last, alerts, err := w.LastSessionChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	result := RecentChangesResult{Changes: make([]RecentChange, 0, len(alerts))}
+	if last != nil {
+		result.SinceSessionID = last.SessionID
+		result.SinceProject = sessionPrimaryProject(last.SessionID, last.ProjectPath, s.loadTags(), loadAllWeights(s.weightsStorePath)[last.SessionID])
+		result.SinceStart = last.StartTime
+	}
+	for _, a := range alerts {
+		result.Changes = append(result.Changes, RecentChange{Level: a.Level, Title: a.Title, Message: a.Message})
+	}
+	return result, nil
+}
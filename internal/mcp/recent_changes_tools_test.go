@@ -0,0 +1,51 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRecentChanges_InsufficientHistory(t *testing.T) {
+	dir := t.TempDir()
+	writeSessionMeta(t, dir, "sess-01", "2026-01-15T10:00:00Z", "/home/user/proj", 1000, 500)
+	s := newTestServer(dir, 0)
+
+	result, err := callTool(s, "recent_changes", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("recent_changes: %v", err)
+	}
+	r := result.(RecentChangesResult)
+	if r.Changes == nil || len(r.Changes) != 0 {
+		t.Errorf("Changes = %#v, want an empty non-nil list", r.Changes)
+	}
+	if r.SinceSessionID != "" {
+		t.Errorf("SinceSessionID = %q, want empty", r.SinceSessionID)
+	}
+}
+
+func TestRecentChanges_ReportsNewFriction(t *testing.T) {
+	dir := t.TempDir()
+	writeSessionMeta(t, dir, "sess-01", "2026-01-15T10:00:00Z", "/home/user/proj", 1000, 500)
+	writeSessionMeta(t, dir, "sess-02", "2026-01-16T10:00:00Z", "/home/user/proj", 1000, 500)
+	writeFacet(t, dir, "sess-02", map[string]int{"wrong_approach": 3})
+	s := newTestServer(dir, 0)
+
+	result, err := callTool(s, "recent_changes", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("recent_changes: %v", err)
+	}
+	r := result.(RecentChangesResult)
+	if r.SinceSessionID != "sess-02" || r.SinceProject != "proj" {
+		t.Errorf("since = %q (%q), want sess-02 (proj)", r.SinceSessionID, r.SinceProject)
+	}
+
+	found := false
+	for _, c := range r.Changes {
+		if c.Level == "warning" && c.Title == "New friction type: wrong_approach" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a new friction warning, got %+v", r.Changes)
+	}
+}
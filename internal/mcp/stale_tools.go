@@ -5,7 +5,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 )
@@ -90,11 +89,7 @@ func (s *Server) handleGetStalePatterns(args json.RawMessage) (any, error) {
 
 	// Find the oldest session's StartTime in window.
 	oldestStartTime := window[len(window)-1].StartTime
-	oldestTime, err := time.Parse(time.RFC3339, oldestStartTime)
-	if err != nil {
-		// Try without timezone suffix.
-		oldestTime, _ = time.Parse("2006-01-02T15:04:05", oldestStartTime)
-	}
+	oldestTime, _ := claude.ParseSessionTime(oldestStartTime)
 
 	// Collect unique project paths in the window.
 	projectPaths := make(map[string]struct{})
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/logging"
 	"github.com/blackwell-systems/claudewatch/internal/suggest"
 )
 
@@ -25,6 +27,8 @@ type SuggestionsResult struct {
 	Suggestions []SuggestionItem `json:"suggestions"`
 	TotalCount  int              `json:"total_count"`
 	Project     string           `json:"project,omitempty"`
+	// RuleErrors describes invalid custom rules that were skipped.
+	RuleErrors string `json:"rule_errors,omitempty"`
 }
 
 const (
@@ -58,12 +62,7 @@ func (s *Server) handleGetSuggestions(args json.RawMessage) (any, error) {
 		project = *params.Project
 	}
 
-	// Build analysis context — non-fatal errors use zero values.
-	ctx := s.buildSuggestContext()
-
-	// Run the suggestion engine.
-	engine := suggest.NewEngine()
-	raw := engine.Run(ctx)
+	raw, ruleErrors := s.runSuggestEngine()
 
 	// Filter by project if specified.
 	if project != "" {
@@ -77,7 +76,35 @@ func (s *Server) handleGetSuggestions(args json.RawMessage) (any, error) {
 		raw = raw[:limit]
 	}
 
-	// Convert to MCP result type.
+	return SuggestionsResult{
+		Suggestions: suggestionItems(raw),
+		TotalCount:  totalCount,
+		Project:     project,
+		RuleErrors:  ruleErrors,
+	}, nil
+}
+
+// runSuggestEngine builds the analysis context and runs the suggestion
+// engine with any valid custom rules. ruleErrors describes the invalid ones.
+func (s *Server) runSuggestEngine() (suggestions []suggest.Suggestion, ruleErrors string) {
+	// Build analysis context — non-fatal errors use zero values.
+	ctx := s.buildSuggestContext()
+
+	engine := suggest.NewEngine()
+	if s.suggestRulesPath != "" {
+		rules, err := suggest.LoadCustomRules(s.suggestRulesPath)
+		if err != nil {
+			ruleErrors = err.Error()
+		}
+		for _, r := range rules {
+			engine.AddRules(r.Rule())
+		}
+	}
+	return engine.Run(ctx), ruleErrors
+}
+
+// suggestionItems converts suggestions to the MCP result type.
+func suggestionItems(raw []suggest.Suggestion) []SuggestionItem {
 	items := make([]SuggestionItem, 0, len(raw))
 	for _, r := range raw {
 		items = append(items, SuggestionItem{
@@ -88,12 +115,7 @@ func (s *Server) handleGetSuggestions(args json.RawMessage) (any, error) {
 			ImpactScore: r.ImpactScore,
 		})
 	}
-
-	return SuggestionsResult{
-		Suggestions: items,
-		TotalCount:  totalCount,
-		Project:     project,
-	}, nil
+	return items
 }
 
 // buildSuggestContext constructs the AnalysisContext inline from session metadata and
@@ -183,9 +205,10 @@ func (s *Server) buildSuggestContext() *suggest.AnalysisContext {
 		typeSuccess := make(map[string]int)
 		totalSuccess := 0
 		for _, task := range agentTasks {
-			typeCount[task.AgentType]++
+			agentType := analyzer.CanonicalAgentType(task.AgentType, s.agentAliases)
+			typeCount[agentType]++
 			if task.Status == "completed" {
-				typeSuccess[task.AgentType]++
+				typeSuccess[agentType]++
 				totalSuccess++
 			}
 		}
@@ -202,14 +225,31 @@ func (s *Server) buildSuggestContext() *suggest.AnalysisContext {
 		projectSessions[key] = append(projectSessions[key], sess)
 	}
 
+	subagentCandidates := make(map[string]analyzer.SubagentCandidate)
+	for _, c := range analyzer.AnalyzeSubagentOpportunity(sessions, agentTasks).Candidates {
+		subagentCandidates[c.ProjectPath] = c
+	}
+
 	projectContexts := make([]suggest.ProjectContext, 0, len(projectSessions))
+	ownZeroCommitThreshold := make(map[string]bool)
+	var claudeMDChanges []analyzer.ClaudeMDChange
+	var missingClaudeMD []string
 	for projPath, projSessions := range projectSessions {
-		var toolErrors, interruptions, agentCount, sequentialCount int
+		var toolErrors, interruptions, zeroCommits, agentCount, sequentialCount int
+		var projTasks []claude.AgentTask
 		hasFacets := false
 
 		for _, sess := range projSessions {
 			toolErrors += sess.ToolErrors
 			interruptions += sess.UserInterruptions
+			if sess.GitCommits == 0 {
+				zeroCommits++
+			}
+		}
+
+		thresholds := s.projectThresholds(projPath)
+		if thresholds.ZeroCommitRate > 0 {
+			ownZeroCommitThreshold[projPath] = true
 		}
 
 		// Check facets for this project.
@@ -223,6 +263,7 @@ func (s *Server) buildSuggestContext() *suggest.AnalysisContext {
 		for _, task := range agentTasks {
 			if claude.NormalizePath(sessionProject[task.SessionID]) == projPath {
 				agentCount++
+				projTasks = append(projTasks, task)
 				if !task.Background {
 					sequentialCount++
 				}
@@ -232,26 +273,52 @@ func (s *Server) buildSuggestContext() *suggest.AnalysisContext {
 		// Check if CLAUDE.md exists in the project directory.
 		claudeMDPath := filepath.Join(projPath, "CLAUDE.md")
 		hasClaudeMD := false
-		if _, statErr := os.Stat(claudeMDPath); statErr == nil {
+		if info, statErr := os.Stat(claudeMDPath); statErr == nil {
 			hasClaudeMD = true
+			claudeMDChanges = append(claudeMDChanges, analyzer.ClaudeMDChange{ProjectPath: projPath, ModifiedAt: info.ModTime()})
+		} else {
+			missingClaudeMD = append(missingClaudeMD, projPath)
 		}
 
 		projectContexts = append(projectContexts, suggest.ProjectContext{
-			Path:            projPath,
-			Name:            filepath.Base(projPath),
-			HasClaudeMD:     hasClaudeMD,
-			SessionCount:    len(projSessions),
-			ToolErrors:      toolErrors,
-			Interruptions:   interruptions,
-			Score:           0.0, // not available without scanner
-			HasFacets:       hasFacets,
-			AgentCount:      agentCount,
-			SequentialCount: sequentialCount,
+			Path:                   projPath,
+			Name:                   filepath.Base(projPath),
+			HasClaudeMD:            hasClaudeMD,
+			SessionCount:           len(projSessions),
+			ToolErrors:             toolErrors,
+			Interruptions:          interruptions,
+			Score:                  0.0, // not available without scanner
+			HasFacets:              hasFacets,
+			AgentCount:             agentCount,
+			SequentialCount:        sequentialCount,
+			ParallelSavingsMinutes: analyzer.EstimateParallelSavings(projTasks).EstimatedSavedMinutes(),
+			AgentTypeStats:         suggest.AgentTypeStatsFor(projTasks, s.agentAliases),
+			SubagentOpportunity:    suggest.SubagentOpportunityFor(subagentCandidates, projPath),
+			ZeroCommitRate:         float64(zeroCommits) / float64(len(projSessions)),
+			Thresholds:             thresholds,
 		})
 	}
 
-	// Commit analysis for zero-commit rate.
-	commitAnalysis := analyzer.AnalyzeCommits(sessions)
+	// Estimated savings of adding a CLAUDE.md to projects without one.
+	roi := analyzer.EstimateClaudeMDROI(claudeMDChanges, missingClaudeMD, sessions, facets, analyzer.DefaultPricing["sonnet"], s.loadCacheRatio())
+	savings := suggest.ClaudeMDSavingsByProject(roi)
+	for i := range projectContexts {
+		projectContexts[i].ClaudeMDSavings = savings[claude.NormalizePath(projectContexts[i].Path)]
+	}
+
+	// Commit analysis for zero-commit rate, leaving out projects judged
+	// against their own threshold.
+	zeroCommitSessions := sessions
+	if len(ownZeroCommitThreshold) > 0 {
+		zeroCommitSessions = slices.DeleteFunc(slices.Clone(sessions), func(sess claude.SessionMeta) bool {
+			return ownZeroCommitThreshold[claude.NormalizePath(sess.ProjectPath)]
+		})
+	}
+	commitAnalysis := analyzer.AnalyzeCommits(zeroCommitSessions)
+	zeroCommitThreshold := suggest.DefaultConfig().Thresholds.ZeroCommitRate
+	if s.baseConfig != nil {
+		zeroCommitThreshold = s.baseConfig.Thresholds.ZeroCommitRate
+	}
 
 	// Cost analysis for cache savings.
 	var cacheSavingsPercent, totalCost float64
@@ -278,10 +345,29 @@ func (s *Server) buildSuggestContext() *suggest.AnalysisContext {
 		AgentTypeStats:     agentTypeStats,
 		CustomMetricTrends: make(map[string]string),
 		// ClaudeMDSectionCorrelation is left nil (no project scanner available)
-		ZeroCommitRate:      commitAnalysis.ZeroCommitRate,
-		CacheSavingsPercent: cacheSavingsPercent,
-		TotalCost:           totalCost,
+		ZeroCommitRate:         commitAnalysis.ZeroCommitRate,
+		ZeroCommitSessions:     len(zeroCommitSessions),
+		ZeroCommitThreshold:    zeroCommitThreshold,
+		WorstZeroCommitProject: suggest.WorstZeroCommitProject(commitAnalysis),
+		CacheSavingsPercent:    cacheSavingsPercent,
+		TotalCost:              totalCost,
+	}
+}
+
+// projectThresholds returns the suggest thresholds for the project at path,
+// from its .claudewatch.yaml merged over the global config. A project config
+// that fails to load is logged and ignored; without a global config every
+// project is on the defaults.
+func (s *Server) projectThresholds(path string) suggest.ProjectThresholds {
+	if s.baseConfig == nil {
+		return suggest.ThresholdsFor(nil, nil)
+	}
+	eff, err := s.baseConfig.ForProject(path)
+	if err != nil {
+		logging.Warn("ignoring invalid project config", "project", path, "err", err)
+		eff = nil
 	}
+	return suggest.ThresholdsFor(s.baseConfig, eff)
 }
 
 // filterSuggestionsByProject keeps only suggestions whose Title or Description
@@ -163,3 +163,38 @@ func TestGetSuggestions_MissingClaudeMD(t *testing.T) {
 			len(r.Suggestions), r.Suggestions)
 	}
 }
+
+// TestGetSuggestions_CustomRules verifies that valid custom rules from the
+// rules file add suggestions and invalid ones are reported in RuleErrors.
+func TestGetSuggestions_CustomRules(t *testing.T) {
+	dir := t.TempDir()
+	writeSessionMeta(t, dir, "sess-01", "2026-01-15T10:00:00Z", "/home/user/proj", 1000, 500)
+
+	rulesPath := filepath.Join(dir, "suggest-rules.yaml")
+	rules := `rules:
+  - name: any-sessions
+    metric: total_sessions
+    op: ">="
+    threshold: 1
+    impact: 1000
+    title: "Custom: {{.Value}} sessions"
+  - name: broken
+    metric: total_sessions
+    op: "~"
+    threshold: 1
+    title: never
+`
+	if err := os.WriteFile(rulesPath, []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{claudeHome: dir, suggestRulesPath: rulesPath}
+	r := callSuggestions(t, s, json.RawMessage(`{}`))
+
+	if len(r.Suggestions) == 0 || r.Suggestions[0].Title != "Custom: 1 sessions" {
+		t.Errorf("expected the custom suggestion first, got %+v", r.Suggestions)
+	}
+	if !strings.Contains(r.RuleErrors, `suggest-rules.yaml:8: rule "broken": invalid op "~"`) {
+		t.Errorf("RuleErrors = %q, want the broken rule reported", r.RuleErrors)
+	}
+}
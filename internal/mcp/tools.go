@@ -110,16 +110,16 @@ func addTools(s *Server) {
 		Handler:     s.handleGetRecentSessions,
 	})
 	s.registerTool(toolDef{
-		Name:        "get_saw_sessions",
-		Description: "Recent Claude Code sessions that used Scout-and-Wave parallel agents, with wave count and agent count.",
-		InputSchema: recentNSchema,
-		Handler:     s.handleGetSAWSessions,
+		Name:            "get_saw_sessions",
+		Description:     "Recent Claude Code sessions that used Scout-and-Wave parallel agents, with wave count and agent count.",
+		InputSchema:     recentNSchema,
+		ProgressHandler: s.handleGetSAWSessions,
 	})
 	s.registerTool(toolDef{
-		Name:        "get_saw_wave_breakdown",
-		Description: "Per-wave timing and agent status breakdown for a SAW session.",
-		InputSchema: json.RawMessage(`{"type":"object","properties":{"session_id":{"type":"string","description":"Session ID from get_saw_sessions"}},"required":["session_id"],"additionalProperties":false}`),
-		Handler:     s.handleGetSAWWaveBreakdown,
+		Name:            "get_saw_wave_breakdown",
+		Description:     "Per-wave timing and agent status breakdown for a SAW session.",
+		InputSchema:     json.RawMessage(`{"type":"object","properties":{"session_id":{"type":"string","description":"Session ID from get_saw_sessions"}},"required":["session_id"],"additionalProperties":false}`),
+		ProgressHandler: s.handleGetSAWWaveBreakdown,
 	})
 	s.registerTool(toolDef{
 		Name:        "get_project_health",
@@ -161,6 +161,9 @@ func addTools(s *Server) {
 	addMultiProjectTools(s)
 	addMemoryTools(s)
 	addUnifiedContextTools(s)
+	addHealthScoreTools(s)
+	addRecentChangesTools(s)
+	addOpenSuggestionsTools(s)
 	s.registerTool(toolDef{
 		Name:        "get_project_comparison",
 		Description: "All projects compared side by side in a single call. Returns a ranked list of all projects with health score, friction rate, has_claude_md, agent success rate, and session count.",
@@ -387,7 +390,7 @@ func (s *Server) handleGetRecentSessions(args json.RawMessage) (any, error) {
 }
 
 // handleGetSAWSessions returns the last N SAW sessions with wave and agent counts.
-func (s *Server) handleGetSAWSessions(args json.RawMessage) (any, error) {
+func (s *Server) handleGetSAWSessions(args json.RawMessage, progress claude.ProgressFunc) (any, error) {
 	// Parse optional n argument.
 	n := 5
 	if len(args) > 0 && string(args) != "null" {
@@ -405,7 +408,7 @@ func (s *Server) handleGetSAWSessions(args json.RawMessage) (any, error) {
 		n = 50
 	}
 
-	spans, err := claude.ParseSessionTranscripts(s.claudeHome)
+	spans, err := claude.ParseSessionTranscriptsProgress(s.claudeHome, progress)
 	if err != nil {
 		return nil, err
 	}
@@ -459,7 +462,7 @@ func (s *Server) handleGetSAWSessions(args json.RawMessage) (any, error) {
 }
 
 // handleGetSAWWaveBreakdown returns per-wave timing and agent status for a SAW session.
-func (s *Server) handleGetSAWWaveBreakdown(args json.RawMessage) (any, error) {
+func (s *Server) handleGetSAWWaveBreakdown(args json.RawMessage, progress claude.ProgressFunc) (any, error) {
 	var params struct {
 		SessionID string `json:"session_id"`
 	}
@@ -467,7 +470,7 @@ func (s *Server) handleGetSAWWaveBreakdown(args json.RawMessage) (any, error) {
 		return nil, errors.New("session_id is required")
 	}
 
-	spans, err := claude.ParseSessionTranscripts(s.claudeHome)
+	spans, err := claude.ParseSessionTranscriptsProgress(s.claudeHome, progress)
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +78,9 @@ func newTestServer(tmpDir string, budgetUSD float64) *Server {
 
 // callTool invokes the named tool handler and returns the typed result.
 func callTool(s *Server, name string, args json.RawMessage) (any, error) {
-	for _, tool := range s.tools {
-		if tool.Name == name {
-			return tool.Handler(args)
+	for i := range s.tools {
+		if tool := &s.tools[i]; tool.Name == name {
+			return tool.call(args, nil)
 		}
 	}
 	return nil, fmt.Errorf("tool not found: %s", name)
@@ -0,0 +1,141 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// chartGlyphs are the characters a chart is drawn with.
+type chartGlyphs struct {
+	point, overlay, axis, corner, rule string
+}
+
+var (
+	unicodeGlyphs = chartGlyphs{point: "●", overlay: "·", axis: "┤", corner: "└", rule: "─"}
+	asciiGlyphs   = chartGlyphs{point: "*", overlay: ".", axis: "|", corner: "+", rule: "-"}
+)
+
+// SupportsUnicode reports whether the terminal locale is UTF-8, judged from
+// LC_ALL, LC_CTYPE, and LANG in that order of precedence.
+func SupportsUnicode() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
+
+// ChartOptions configures LineChart.
+type ChartOptions struct {
+	// Width is the plot width in columns and Height its height in rows,
+	// excluding axis labels.
+	Width, Height int
+	// ASCII draws with only '*', '.', '|', '+', and '-', for terminals
+	// without Unicode.
+	ASCII bool
+	// FormatY labels the top and bottom of the y axis; nil uses "%.1f".
+	FormatY func(float64) string
+	// StartLabel and EndLabel, when set, label the ends of the x axis.
+	StartLabel, EndLabel string
+}
+
+// LineChart plots values as a line chart, with overlay (such as a moving
+// average) drawn beneath it in a lighter glyph. Each column interpolates
+// between neighbouring values, so a handful of points still spans the full
+// width. It returns the chart's lines, or nil when there is nothing to plot.
+func LineChart(values, overlay []float64, opts ChartOptions) []string {
+	width, height := opts.Width, opts.Height
+	if len(values) == 0 || width < 1 || height < 1 {
+		return nil
+	}
+	format := opts.FormatY
+	if format == nil {
+		format = func(v float64) string { return fmt.Sprintf("%.1f", v) }
+	}
+
+	lo, hi := values[0], values[0]
+	for _, series := range [][]float64{values, overlay} {
+		for _, v := range series {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+
+	cols := width
+	if len(values) == 1 {
+		cols = 1
+	}
+	row := func(v float64) int {
+		if hi == lo {
+			return (height - 1) / 2
+		}
+		return int(math.Round((v - lo) / (hi - lo) * float64(height-1)))
+	}
+
+	g := unicodeGlyphs
+	if opts.ASCII {
+		g = asciiGlyphs
+	}
+
+	grid := make([][]string, height)
+	for r := range grid {
+		grid[r] = make([]string, cols)
+		for c := range grid[r] {
+			grid[r][c] = " "
+		}
+	}
+	plot := func(series []float64, glyph string) {
+		if len(series) == 0 {
+			return
+		}
+		for c := 0; c < cols; c++ {
+			grid[row(sampleAt(series, c, cols))][c] = glyph
+		}
+	}
+	plot(overlay, StyleMuted.Render(g.overlay))
+	plot(values, StyleValue.UnsetWidth().Render(g.point))
+
+	top, bottom := format(hi), format(lo)
+	gutter := max(len(top), len(bottom))
+	lines := make([]string, 0, height+2)
+	for r := height - 1; r >= 0; r-- {
+		label := ""
+		switch r {
+		case height - 1:
+			label = top
+		case 0:
+			label = bottom
+		}
+		lines = append(lines, StyleMuted.Render(strings.Repeat(" ", gutter-len(label))+label+" "+g.axis)+strings.Join(grid[r], ""))
+	}
+	indent := strings.Repeat(" ", gutter+1)
+	lines = append(lines, StyleMuted.Render(indent+g.corner+strings.Repeat(g.rule, cols)))
+
+	if opts.StartLabel != "" || opts.EndLabel != "" {
+		pad := cols + 1 - len(opts.StartLabel) - len(opts.EndLabel)
+		if pad < 1 {
+			pad = 1
+		}
+		lines = append(lines, StyleMuted.Render(indent+opts.StartLabel+strings.Repeat(" ", pad)+opts.EndLabel))
+	}
+	return lines
+}
+
+// sampleAt returns series linearly interpolated at column c of cols, with
+// the first and last columns at the first and last values.
+func sampleAt(series []float64, c, cols int) float64 {
+	if len(series) == 1 || cols == 1 {
+		return series[0]
+	}
+	pos := float64(c) * float64(len(series)-1) / float64(cols-1)
+	i := int(pos)
+	if i >= len(series)-1 {
+		return series[len(series)-1]
+	}
+	frac := pos - float64(i)
+	return series[i] + (series[i+1]-series[i])*frac
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLineChart_PlotsRisingSeries(t *testing.T) {
+	SetNoColor(true)
+	defer SetNoColor(false)
+
+	lines := LineChart([]float64{0, 5, 10}, nil, ChartOptions{Width: 11, Height: 3, ASCII: true})
+	// Three plot rows and the x axis.
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if !strings.HasPrefix(lines[0], "10.0 |") || !strings.HasPrefix(lines[2], " 0.0 |") {
+		t.Errorf("expected max and min y labels, got:\n%s", strings.Join(lines, "\n"))
+	}
+	top, bottom := strings.TrimPrefix(lines[0], "10.0 |"), strings.TrimPrefix(lines[2], " 0.0 |")
+	if !strings.HasSuffix(top, "*") || strings.HasPrefix(top, "*") {
+		t.Errorf("expected the rising line to end at the top, got %q", top)
+	}
+	if !strings.HasPrefix(bottom, "*") {
+		t.Errorf("expected the rising line to start at the bottom, got %q", bottom)
+	}
+	if lines[3] != "     +-----------" {
+		t.Errorf("unexpected x axis %q", lines[3])
+	}
+}
+
+func TestLineChart_OverlayAndLabels(t *testing.T) {
+	SetNoColor(true)
+	defer SetNoColor(false)
+
+	lines := LineChart([]float64{0, 10}, []float64{5, 5}, ChartOptions{
+		Width: 10, Height: 3, ASCII: true, StartLabel: "Jan 01", EndLabel: "Mar 01",
+	})
+	if !strings.Contains(lines[1], ".") {
+		t.Errorf("expected the overlay on the middle row, got %q", lines[1])
+	}
+	if last := lines[len(lines)-1]; !strings.Contains(last, "Jan 01") || !strings.HasSuffix(last, "Mar 01") {
+		t.Errorf("expected x labels at both ends, got %q", last)
+	}
+}
+
+func TestLineChart_UnicodeAndFlat(t *testing.T) {
+	SetNoColor(true)
+	defer SetNoColor(false)
+
+	lines := LineChart([]float64{3, 3, 3}, nil, ChartOptions{Width: 5, Height: 3})
+	if !strings.Contains(lines[1], "●●●●●") {
+		t.Errorf("expected a flat series on the middle row, got %q", lines[1])
+	}
+	if !strings.Contains(lines[len(lines)-1], "└─────") {
+		t.Errorf("expected a Unicode x axis, got %q", lines[len(lines)-1])
+	}
+
+	if LineChart(nil, nil, ChartOptions{Width: 5, Height: 3}) != nil {
+		t.Error("expected no chart for an empty series")
+	}
+}
+
+func TestSupportsUnicode(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+	if !SupportsUnicode() {
+		t.Error("expected a UTF-8 LANG to support Unicode")
+	}
+
+	t.Setenv("LC_ALL", "C")
+	if SupportsUnicode() {
+		t.Error("expected LC_ALL=C to take precedence over LANG")
+	}
+}
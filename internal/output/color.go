@@ -3,72 +3,184 @@ package output
 
 import "github.com/charmbracelet/lipgloss"
 
-// Color constants for consistent styling across the CLI.
+// Theme maps the semantic colors used by the package styles to terminal
+// colors. An empty color leaves text in the terminal's default color.
+type Theme struct {
+	Name        string
+	Description string
+	Primary     lipgloss.Color // headers and emphasis
+	Success     lipgloss.Color // positive indicators and improvements
+	Error       lipgloss.Color // negative indicators and regressions
+	Warning     lipgloss.Color // caution indicators
+	Muted       lipgloss.Color // secondary text and borders
+	Text        lipgloss.Color // primary text
+}
+
+// Built-in themes.
+var (
+	// DefaultTheme suits dark terminal backgrounds.
+	DefaultTheme = Theme{
+		Name:        "default",
+		Description: "Suits dark terminal backgrounds",
+		Primary:     "#64b5f6",
+		Success:     "#66bb6a",
+		Error:       "#ef5350",
+		Warning:     "#fff59d",
+		Muted:       "#888888",
+		Text:        "#ffffff",
+	}
+
+	// LightTheme uses darker shades that stay readable on light backgrounds.
+	LightTheme = Theme{
+		Name:        "light",
+		Description: "Darker shades for light terminal backgrounds",
+		Primary:     "#1565c0",
+		Success:     "#2e7d32",
+		Error:       "#c62828",
+		Warning:     "#e65100",
+		Muted:       "#616161",
+		Text:        "#000000",
+	}
+
+	// HighContrastTheme uses bright, saturated colors from the Okabe-Ito
+	// palette: blue for good and orange for bad, so improvements and
+	// regressions stay distinct for red-green colorblind users.
+	HighContrastTheme = Theme{
+		Name:        "high-contrast",
+		Description: "Bright colors; blue for good and orange for bad, for red-green colorblindness",
+		Primary:     "#56b4e9",
+		Success:     "#0096ff",
+		Error:       "#ff8c00",
+		Warning:     "#f0e442",
+		Muted:       "#c0c0c0",
+		Text:        "#ffffff",
+	}
+
+	// MonoTheme drops all colors but keeps bold emphasis, unlike SetNoColor,
+	// which removes styling entirely.
+	MonoTheme = Theme{Name: "mono", Description: "No colors, bold emphasis only"}
+)
+
+// themes lists the built-in themes in the order ThemeNames reports them.
+var themes = []Theme{DefaultTheme, LightTheme, HighContrastTheme, MonoTheme}
+
+// ThemeNames returns the names of the built-in themes.
+func ThemeNames() []string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// LookupTheme returns the built-in theme with the given name.
+func LookupTheme(name string) (Theme, bool) {
+	for _, t := range themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// Colors of the active theme, for consistent styling across the CLI. They
+// are reassigned by SetTheme.
 var (
 	// ColorPrimary is used for headers and emphasis.
-	ColorPrimary = lipgloss.Color("#64b5f6")
+	ColorPrimary lipgloss.Color
 
 	// ColorSuccess is used for positive indicators and improvements.
-	ColorSuccess = lipgloss.Color("#66bb6a")
+	ColorSuccess lipgloss.Color
 
 	// ColorError is used for negative indicators and regressions.
-	ColorError = lipgloss.Color("#ef5350")
+	ColorError lipgloss.Color
 
 	// ColorWarning is used for caution indicators.
-	ColorWarning = lipgloss.Color("#fff59d")
+	ColorWarning lipgloss.Color
 
 	// ColorMuted is used for secondary text and borders.
-	ColorMuted = lipgloss.Color("#888888")
+	ColorMuted lipgloss.Color
 
 	// ColorWhite is used for primary text.
-	ColorWhite = lipgloss.Color("#ffffff")
+	ColorWhite lipgloss.Color
 )
 
-// Styles provides reusable lipgloss styles.
+// Styles provides reusable lipgloss styles built from the active theme. They
+// are reassigned by SetTheme and SetNoColor.
 var (
 	// StyleHeader is used for section headers.
-	StyleHeader = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true)
+	StyleHeader lipgloss.Style
 
 	// StyleSuccess is used for positive values.
-	StyleSuccess = lipgloss.NewStyle().
-			Foreground(ColorSuccess)
+	StyleSuccess lipgloss.Style
 
 	// StyleError is used for negative values.
-	StyleError = lipgloss.NewStyle().
-			Foreground(ColorError)
+	StyleError lipgloss.Style
 
 	// StyleWarning is used for cautionary values.
-	StyleWarning = lipgloss.NewStyle().
-			Foreground(ColorWarning)
+	StyleWarning lipgloss.Style
 
 	// StyleMuted is used for de-emphasized text.
-	StyleMuted = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+	StyleMuted lipgloss.Style
 
 	// StyleBold is used for emphasized text.
-	StyleBold = lipgloss.NewStyle().
-			Bold(true)
+	StyleBold lipgloss.Style
 
 	// StyleLabel is used for metric labels.
-	StyleLabel = lipgloss.NewStyle().
-			Width(24)
+	StyleLabel lipgloss.Style
 
 	// StyleValue is used for metric values.
-	StyleValue = lipgloss.NewStyle().
-			Bold(true).
-			Width(12)
+	StyleValue lipgloss.Style
 )
 
+// activeTheme is the theme the styles are built from.
+var activeTheme = DefaultTheme
+
 // noColor tracks whether color output is disabled.
 var noColor bool
 
+func init() {
+	applyStyles()
+}
+
+// SetTheme makes t the active theme and rebuilds the package styles from it.
+// While color is disabled the styles stay plain; the theme takes effect if
+// color is enabled again.
+func SetTheme(t Theme) {
+	activeTheme = t
+	applyStyles()
+}
+
+// ActiveTheme returns the theme the package styles are built from.
+func ActiveTheme() Theme {
+	return activeTheme
+}
+
 // SetNoColor disables or enables color output globally.
-// When disabled, all package-level styles are reassigned to unstyled renderers.
+// When disabled, all package-level styles are reassigned to unstyled
+// renderers; when enabled again, they are rebuilt from the active theme.
 func SetNoColor(disabled bool) {
 	noColor = disabled
-	if disabled {
+	applyStyles()
+}
+
+// IsNoColor returns whether color output is currently disabled.
+func IsNoColor() bool {
+	return noColor
+}
+
+// applyStyles rebuilds the package colors and styles from the active theme,
+// or as plain styles when color is disabled.
+func applyStyles() {
+	t := activeTheme
+	ColorPrimary = t.Primary
+	ColorSuccess = t.Success
+	ColorError = t.Error
+	ColorWarning = t.Warning
+	ColorMuted = t.Muted
+	ColorWhite = t.Text
+
+	if noColor {
 		plain := lipgloss.NewStyle()
 		StyleHeader = plain
 		StyleSuccess = plain
@@ -78,10 +190,25 @@ func SetNoColor(disabled bool) {
 		StyleBold = plain
 		StyleLabel = plain.Width(24)
 		StyleValue = plain.Width(12)
+		return
 	}
-}
 
-// IsNoColor returns whether color output is currently disabled.
-func IsNoColor() bool {
-	return noColor
+	StyleHeader = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+	StyleSuccess = lipgloss.NewStyle().
+		Foreground(t.Success)
+	StyleError = lipgloss.NewStyle().
+		Foreground(t.Error)
+	StyleWarning = lipgloss.NewStyle().
+		Foreground(t.Warning)
+	StyleMuted = lipgloss.NewStyle().
+		Foreground(t.Muted)
+	StyleBold = lipgloss.NewStyle().
+		Bold(true)
+	StyleLabel = lipgloss.NewStyle().
+		Width(24)
+	StyleValue = lipgloss.NewStyle().
+		Bold(true).
+		Width(12)
 }
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range ThemeNames() {
+		th, ok := LookupTheme(name)
+		if !ok {
+			t.Errorf("LookupTheme(%q) not found", name)
+			continue
+		}
+		if th.Name != name {
+			t.Errorf("LookupTheme(%q).Name = %q", name, th.Name)
+		}
+	}
+	if _, ok := LookupTheme("solarized"); ok {
+		t.Error("LookupTheme(\"solarized\") found, want not found")
+	}
+}
+
+func TestSetTheme_RebuildsStyles(t *testing.T) {
+	t.Cleanup(func() {
+		SetNoColor(false)
+		SetTheme(DefaultTheme)
+	})
+
+	SetTheme(LightTheme)
+	if ActiveTheme().Name != "light" {
+		t.Errorf("ActiveTheme = %q, want light", ActiveTheme().Name)
+	}
+	if ColorPrimary != LightTheme.Primary {
+		t.Errorf("ColorPrimary = %q, want %q", ColorPrimary, LightTheme.Primary)
+	}
+	if got := StyleError.GetForeground(); got != LightTheme.Error {
+		t.Errorf("StyleError foreground = %v, want %v", got, LightTheme.Error)
+	}
+}
+
+func TestSetNoColor_OverridesTheme(t *testing.T) {
+	t.Cleanup(func() {
+		SetNoColor(false)
+		SetTheme(DefaultTheme)
+	})
+
+	SetTheme(HighContrastTheme)
+	SetNoColor(true)
+	if got := StyleSuccess.GetForeground(); got != (lipgloss.NoColor{}) {
+		t.Errorf("StyleSuccess foreground with no color = %v, want none", got)
+	}
+
+	SetNoColor(false)
+	if got := StyleSuccess.GetForeground(); got != HighContrastTheme.Success {
+		t.Errorf("StyleSuccess foreground after re-enabling = %v, want %v", got, HighContrastTheme.Success)
+	}
+}
+
+func TestMonoTheme_KeepsBold(t *testing.T) {
+	t.Cleanup(func() { SetTheme(DefaultTheme) })
+
+	SetTheme(MonoTheme)
+	if !StyleHeader.GetBold() {
+		t.Error("StyleHeader under mono theme is not bold")
+	}
+	if ColorError != "" {
+		t.Errorf("ColorError under mono theme = %q, want empty", ColorError)
+	}
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"strings"
+)
+
+// ColorizeJSON returns data with syntax highlighting applied using the
+// package styles: object keys use StyleHeader, strings StyleSuccess, numbers
+// StyleWarning, and true/false/null StyleMuted. Punctuation and whitespace
+// are passed through untouched, so stripping the ANSI escapes yields the
+// original bytes. Input is assumed to be valid JSON (e.g. from encoding/json).
+func ColorizeJSON(data []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(data) * 2)
+
+	n := len(data)
+	for i := 0; i < n; {
+		c := data[i]
+		switch {
+		case c == '"':
+			end := scanJSONString(data, i)
+			token := string(data[i:end])
+			if isJSONKey(data, end) {
+				sb.WriteString(StyleHeader.Render(token))
+			} else {
+				sb.WriteString(StyleSuccess.Render(token))
+			}
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < n && isJSONNumberByte(data[end]) {
+				end++
+			}
+			sb.WriteString(StyleWarning.Render(string(data[i:end])))
+			i = end
+		case c == 't' || c == 'f' || c == 'n':
+			end := i + 1
+			for end < n && data[end] >= 'a' && data[end] <= 'z' {
+				end++
+			}
+			sb.WriteString(StyleMuted.Render(string(data[i:end])))
+			i = end
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+
+	return sb.String()
+}
+
+// scanJSONString returns the index just past the closing quote of the string
+// starting at data[start], honoring backslash escapes.
+func scanJSONString(data []byte, start int) int {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+// isJSONKey reports whether the string ending at data[end] is an object key,
+// i.e. the next non-whitespace byte is a colon.
+func isJSONKey(data []byte, end int) bool {
+	for i := end; i < len(data); i++ {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case ':':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// isJSONNumberByte reports whether b can continue a JSON number literal.
+func isJSONNumberByte(b byte) bool {
+	return (b >= '0' && b <= '9') || b == '.' || b == 'e' || b == 'E' || b == '+' || b == '-'
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestColorizeJSON_StripsToOriginal(t *testing.T) {
+	v := map[string]any{
+		"name":    `quoted "value" with \ backslash`,
+		"count":   42,
+		"ratio":   -1.5e-3,
+		"enabled": true,
+		"missing": nil,
+		"tags":    []string{"a", "b:c"},
+		"nested":  map[string]any{"empty": []int{}},
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	got := ansiRegex.ReplaceAllString(ColorizeJSON(data), "")
+	if got != string(data) {
+		t.Errorf("colorized JSON does not strip back to the original.\ngot:\n%s\nwant:\n%s", got, data)
+	}
+}
+
+func TestColorizeJSON_Empty(t *testing.T) {
+	if got := ColorizeJSON(nil); got != "" {
+		t.Errorf("ColorizeJSON(nil) = %q, want empty", got)
+	}
+}
+
+func TestIsJSONKey(t *testing.T) {
+	data := []byte(`{"key" : "value"}`)
+	keyEnd := scanJSONString(data, 1)
+	if !isJSONKey(data, keyEnd) {
+		t.Error("expected \"key\" to be detected as an object key")
+	}
+	valStart := 9
+	valEnd := scanJSONString(data, valStart)
+	if isJSONKey(data, valEnd) {
+		t.Error("expected \"value\" not to be detected as an object key")
+	}
+}
+
+func TestScanJSONString_EscapedQuote(t *testing.T) {
+	data := []byte(`"a\"b" rest`)
+	if end := scanJSONString(data, 0); end != 6 {
+		t.Errorf("scanJSONString end = %d, want 6", end)
+	}
+}
@@ -34,6 +34,40 @@ func ScoreBar(score float64, width int) string {
 	return fmt.Sprintf("%s %s", style(bar), StyleMuted.Render(fmt.Sprintf("%.0f/100", score)))
 }
 
+// UsageBar renders a horizontal bar showing used against limit. Unlike
+// ScoreBar, fuller is worse: the bar is green below 75% of the limit, yellow
+// up to the limit, and red once the limit is exceeded.
+func UsageBar(used, limit float64, width int) string {
+	if width <= 0 {
+		width = 20
+	}
+	var fraction float64
+	if limit > 0 {
+		fraction = used / limit
+	}
+	filled := int(fraction * float64(width))
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+
+	var styled string
+	switch {
+	case fraction > 1:
+		styled = StyleError.Render(bar)
+	case fraction >= 0.75:
+		styled = StyleWarning.Render(bar)
+	default:
+		styled = StyleSuccess.Render(bar)
+	}
+
+	return fmt.Sprintf("%s %s", styled, StyleMuted.Render(fmt.Sprintf("%.0f%%", fraction*100)))
+}
+
 // TrendArrow returns a styled trend indicator for a delta value.
 // Positive delta shows an up arrow, negative shows down, zero shows a dash.
 // The improved parameter indicates whether higher values are better.
@@ -14,6 +14,16 @@ func visualLen(s string) int {
 	return len(ansiRegex.ReplaceAllString(s, ""))
 }
 
+// cellFilter, when set, rewrites every cell added to a table.
+var cellFilter func(string) string
+
+// SetCellFilter installs f to rewrite table cells as they are added, before
+// column widths are measured, so a filter that changes text length (such as
+// anonymization) keeps columns aligned. Pass nil to remove it.
+func SetCellFilter(f func(string) string) {
+	cellFilter = f
+}
+
 // Table is a simple styled table renderer.
 type Table struct {
 	headers []string
@@ -41,6 +51,9 @@ func (t *Table) AddRow(values ...string) {
 		if i < len(values) {
 			row[i] = values[i]
 		}
+		if cellFilter != nil {
+			row[i] = cellFilter(row[i])
+		}
 		if vl := visualLen(row[i]); vl > t.widths[i] {
 			t.widths[i] = vl
 		}
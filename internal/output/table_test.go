@@ -177,3 +177,21 @@ func TestSetNoColor(t *testing.T) {
 	// and that the function is idempotent.
 	SetNoColor(false)
 }
+
+func TestTable_CellFilter(t *testing.T) {
+	SetNoColor(true)
+	defer SetNoColor(false)
+	SetCellFilter(func(s string) string { return strings.ReplaceAll(s, "acme", "project-1234") })
+	defer SetCellFilter(nil)
+
+	tbl := NewTable("Project", "Score")
+	tbl.AddRow("acme", "95")
+
+	lines := strings.Split(tbl.Render(), "\n")
+	if !strings.HasPrefix(lines[2], "project-1234  95") {
+		t.Errorf("expected filtered cell with aligned columns, got %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[0], "Project       Score") {
+		t.Errorf("expected header padded to the filtered width, got %q", lines[0])
+	}
+}
@@ -0,0 +1,158 @@
+// Package pricing fetches model pricing overrides from a user-specified URL.
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/guard"
+)
+
+const (
+	// CacheFileName is the file in the config dir holding the last fetch.
+	CacheFileName = "pricing.json"
+
+	// CacheTTL is how long a cached fetch is trusted before asking the URL
+	// again.
+	CacheTTL = 24 * time.Hour
+
+	requestTimeout = 5 * time.Second
+)
+
+// cacheEntry is the on-disk form of the last successful fetch.
+type cacheEntry struct {
+	FetchedAt time.Time                    `json:"fetched_at"`
+	URL       string                       `json:"url"`
+	Models    map[string]config.ModelRates `json:"models"`
+}
+
+// Fetcher downloads a pricing document, caching successful fetches on disk.
+// The document is a JSON object mapping model tiers to rates, the same shape
+// as pricing.models in the config file.
+type Fetcher struct {
+	// URL is the pricing document to fetch.
+	URL string
+	// CachePath is where fetches are cached; caching is skipped when empty.
+	CachePath string
+	// ReadOnlyCache uses a cached fetch but never writes one, for callers
+	// that must not change any state.
+	ReadOnlyCache bool
+	// Client performs the request; a client with a short timeout is used
+	// when nil.
+	Client *http.Client
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+}
+
+// NewFetcher returns a Fetcher for url that caches results in configDir.
+func NewFetcher(configDir, url string) *Fetcher {
+	return &Fetcher{
+		URL:       url,
+		CachePath: filepath.Join(configDir, CacheFileName),
+	}
+}
+
+// Fetch returns the rates from the URL. A cached fetch of the same URL
+// younger than CacheTTL is used without a request. When the request fails,
+// the error is returned together with any older cached rates for the same
+// URL, so a caller that is offline can keep using the last known prices.
+func (f *Fetcher) Fetch(ctx context.Context) (map[string]config.ModelRates, error) {
+	now := f.now()
+	cached, fresh := f.loadCache(now)
+	if fresh {
+		return cached.Models, nil
+	}
+
+	models, err := f.fetch(ctx)
+	if err != nil {
+		return cached.Models, err
+	}
+
+	if !f.ReadOnlyCache {
+		// A cache write failure only costs an extra request next time.
+		_ = f.saveCache(cacheEntry{FetchedAt: now, URL: f.URL, Models: models})
+	}
+	return models, nil
+}
+
+func (f *Fetcher) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+func (f *Fetcher) fetch(ctx context.Context) (map[string]config.ModelRates, error) {
+	client := f.Client
+	if client == nil {
+		client = guard.HTTPClient(requestTimeout)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching pricing: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching pricing: %s", resp.Status)
+	}
+
+	var models map[string]config.ModelRates
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		return nil, fmt.Errorf("decoding pricing: %w", err)
+	}
+	if len(models) == 0 {
+		return nil, fmt.Errorf("pricing document has no models")
+	}
+	for model, rates := range models {
+		if err := rates.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid pricing for %q: %w", model, err)
+		}
+	}
+	return models, nil
+}
+
+// loadCache returns the cached entry for f.URL, if any, and whether it is
+// younger than CacheTTL.
+func (f *Fetcher) loadCache(now time.Time) (cacheEntry, bool) {
+	if f.CachePath == "" {
+		return cacheEntry{}, false
+	}
+	data, err := os.ReadFile(f.CachePath)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != f.URL || len(entry.Models) == 0 {
+		return cacheEntry{}, false
+	}
+	age := now.Sub(entry.FetchedAt)
+	return entry, age >= 0 && age < CacheTTL
+}
+
+func (f *Fetcher) saveCache(entry cacheEntry) error {
+	if f.CachePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.CachePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(f.CachePath, data, 0o644)
+}
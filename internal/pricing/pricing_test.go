@@ -0,0 +1,147 @@
+package pricing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T, body *string, status *int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(*status)
+		_, _ = w.Write([]byte(*body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func newTestFetcher(t *testing.T, url string, now *time.Time) *Fetcher {
+	t.Helper()
+	return &Fetcher{
+		URL:       url,
+		CachePath: filepath.Join(t.TempDir(), CacheFileName),
+		Now:       func() time.Time { return *now },
+	}
+}
+
+func TestFetch_CachesFor24Hours(t *testing.T) {
+	body, status := `{"sonnet":{"input":2.5,"output":12}}`, http.StatusOK
+	srv, hits := newTestServer(t, &body, &status)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := newTestFetcher(t, srv.URL, &now)
+
+	models, err := f.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := models["sonnet"]; got.Input != 2.5 || got.Output != 12 {
+		t.Errorf("sonnet = %+v, want input 2.5, output 12", got)
+	}
+
+	now = now.Add(23 * time.Hour)
+	if _, err := f.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected cached result within TTL, got %d hits", hits.Load())
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := f.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected refetch after TTL, got %d hits", hits.Load())
+	}
+}
+
+func TestFetch_StaleCacheOnError(t *testing.T) {
+	body, status := `{"opus":{"input":10}}`, http.StatusOK
+	srv, _ := newTestServer(t, &body, &status)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := newTestFetcher(t, srv.URL, &now)
+
+	if _, err := f.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(48 * time.Hour)
+	status = http.StatusInternalServerError
+	models, err := f.Fetch(context.Background())
+	if err == nil {
+		t.Fatal("expected error for non-200 response")
+	}
+	if models["opus"].Input != 10 {
+		t.Errorf("expected stale cached rates alongside the error, got %+v", models)
+	}
+}
+
+func TestFetch_CacheIsPerURL(t *testing.T) {
+	body, status := `{"opus":{"input":10}}`, http.StatusOK
+	srv, hits := newTestServer(t, &body, &status)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := newTestFetcher(t, srv.URL, &now)
+
+	if _, err := f.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	f.URL = srv.URL + "/other"
+	if _, err := f.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected a new URL to bypass the cache, got %d hits", hits.Load())
+	}
+}
+
+func TestFetch_ReadOnlyCache(t *testing.T) {
+	body, status := `{"opus":{"input":10}}`, http.StatusOK
+	srv, hits := newTestServer(t, &body, &status)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := newTestFetcher(t, srv.URL, &now)
+	f.ReadOnlyCache = true
+
+	if _, err := f.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(f.CachePath); !os.IsNotExist(err) {
+		t.Errorf("expected no cache file, got %v", err)
+	}
+
+	// An existing cache is still used.
+	f.ReadOnlyCache = false
+	if _, err := f.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	f.ReadOnlyCache = true
+	if _, err := f.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected the cached fetch to be used, got %d hits", hits.Load())
+	}
+}
+
+func TestFetch_RejectsInvalidDocument(t *testing.T) {
+	for _, body := range []string{
+		`{"sonnet":{"input":-1}}`,
+		`{}`,
+		`not json`,
+	} {
+		status := http.StatusOK
+		srv, _ := newTestServer(t, &body, &status)
+		now := time.Now()
+		f := newTestFetcher(t, srv.URL, &now)
+		if models, err := f.Fetch(context.Background()); err == nil {
+			t.Errorf("body %q: expected error, got %+v", body, models)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package remote
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FS reads a remote Claude home through its local mirror, for the claude
+// parsers (it satisfies claude.FS). Before a read under the mirror it syncs
+// the mirror again once the last sync is older than MaxAge, so long-running
+// commands such as watch and the MCP server keep seeing new sessions. Paths
+// outside the mirror are read from the local filesystem as is.
+type FS struct {
+	cacheDir string
+	home     Home
+	warn     func(error)
+	now      func() time.Time
+
+	mu     sync.Mutex
+	synced time.Time
+}
+
+// NewFS returns an FS for h mirrored under cacheDir. warn is called when a
+// sync fails and an earlier mirror is used instead.
+func NewFS(cacheDir string, h Home, warn func(error)) *FS {
+	return &FS{cacheDir: cacheDir, home: h, warn: warn, now: time.Now}
+}
+
+// Dir returns the mirror directory, the local path of the remote home.
+func (f *FS) Dir() string {
+	return MirrorDir(f.cacheDir, f.home)
+}
+
+// Sync brings the mirror up to date unless it was synced within MaxAge. It
+// returns an error only when the sync failed and there is no earlier mirror.
+func (f *FS) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now()
+	if now.Sub(f.synced) < MaxAge {
+		return nil
+	}
+	if _, err := Mirror(f.cacheDir, f.home, now, f.warn); err != nil {
+		return err
+	}
+	// A fallback to an earlier mirror counts too, so a down host is retried
+	// (and warned about) at most once per MaxAge.
+	f.synced = now
+	return nil
+}
+
+// sync syncs the mirror when name lies inside it.
+func (f *FS) sync(name string) error {
+	dir := f.Dir()
+	if name != dir && !strings.HasPrefix(name, dir+string(filepath.Separator)) {
+		return nil
+	}
+	return f.Sync()
+}
+
+func (f *FS) Open(name string) (fs.File, error) {
+	if err := f.sync(name); err != nil {
+		return nil, err
+	}
+	return os.Open(name)
+}
+
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := f.sync(name); err != nil {
+		return nil, err
+	}
+	return os.ReadDir(name)
+}
+
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	if err := f.sync(name); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(name)
+}
+
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	if err := f.sync(name); err != nil {
+		return nil, err
+	}
+	return os.Stat(name)
+}
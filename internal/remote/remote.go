@@ -0,0 +1,215 @@
+// Package remote mirrors a Claude home on another machine, named by an
+// ssh://[user@]host[:port]/path claude_home, into a local cache directory so
+// the claude parsers can read it like any local home. The mirror is kept up
+// to date with rsync over ssh, which uses the user's own ssh config, keys,
+// and agent; claudewatch never handles credentials itself.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/guard"
+)
+
+// Scheme is the URL scheme of a remote Claude home.
+const Scheme = "ssh"
+
+// SyncTimeout bounds one rsync run, connection included.
+const SyncTimeout = 5 * time.Minute
+
+// MaxAge is how long a mirror is used without syncing again, so a burst of
+// commands (or hooks) doesn't reconnect every time.
+const MaxAge = time.Minute
+
+// stampFile records the time of the last successful sync in a mirror.
+const stampFile = ".claudewatch-synced"
+
+// Home is a parsed remote Claude home.
+type Home struct {
+	User string
+	Host string
+	Port string
+	// Path is the directory on the remote host. A leading "~/" is resolved
+	// by the remote shell, relative to the user's home directory.
+	Path string
+}
+
+// IsRemote reports whether claudeHome names a remote Claude home.
+func IsRemote(claudeHome string) bool {
+	return strings.HasPrefix(claudeHome, Scheme+"://")
+}
+
+// Parse parses an ssh://[user@]host[:port]/path Claude home. The path is
+// absolute unless it starts with /~/, which makes it relative to the
+// remote user's home: ssh://devbox/~/.claude.
+func Parse(claudeHome string) (Home, error) {
+	u, err := url.Parse(claudeHome)
+	if err != nil {
+		return Home{}, fmt.Errorf("invalid remote claude_home %q: %w", claudeHome, err)
+	}
+	if u.Scheme != Scheme {
+		return Home{}, fmt.Errorf("invalid remote claude_home %q: scheme must be %s://", claudeHome, Scheme)
+	}
+	h := Home{Host: u.Hostname(), Port: u.Port(), Path: strings.TrimRight(u.Path, "/")}
+	if u.User != nil {
+		h.User = u.User.Username()
+		if _, ok := u.User.Password(); ok {
+			return Home{}, fmt.Errorf("invalid remote claude_home %q: passwords aren't supported, use an ssh key or agent", claudeHome)
+		}
+	}
+	if h.Host == "" {
+		return Home{}, fmt.Errorf("invalid remote claude_home %q: missing host", claudeHome)
+	}
+	// ssh would read a host or user starting with "-" as an option.
+	if strings.HasPrefix(h.Host, "-") || strings.HasPrefix(h.User, "-") {
+		return Home{}, fmt.Errorf("invalid remote claude_home %q: host and user must not start with \"-\"", claudeHome)
+	}
+	if h.Path == "" || h.Path == "/~" {
+		return Home{}, fmt.Errorf("invalid remote claude_home %q: missing path, e.g. ssh://%s/~/.claude", claudeHome, u.Host)
+	}
+	if strings.HasPrefix(h.Path, "/~/") {
+		h.Path = h.Path[1:]
+	}
+	return h, nil
+}
+
+// String returns h in its ssh:// form.
+func (h Home) String() string {
+	host := h.Host
+	if h.User != "" {
+		host = h.User + "@" + host
+	}
+	if h.Port != "" {
+		host += ":" + h.Port
+	}
+	path := h.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return Scheme + "://" + host + path
+}
+
+// MirrorDir returns the directory under cacheDir that mirrors h. Each remote
+// home gets its own, named after the host and a hash of the full address.
+func MirrorDir(cacheDir string, h Home) string {
+	sum := sha256.Sum256([]byte(h.String()))
+	return filepath.Join(cacheDir, "remote", safeName(h.Host)+"-"+hex.EncodeToString(sum[:6]))
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func safeName(s string) string {
+	return unsafeChars.ReplaceAllString(s, "_")
+}
+
+// rsyncArgs returns the rsync arguments that mirror h into dir. ssh runs in
+// batch mode so a missing key fails instead of prompting for a password, and
+// "--" keeps rsync from reading the source or destination as options.
+func rsyncArgs(h Home, dir string) []string {
+	ssh := "ssh -o BatchMode=yes -o ConnectTimeout=10"
+	if h.Port != "" {
+		ssh += " -p " + h.Port
+	}
+	src := h.Host + ":" + h.Path + "/"
+	if h.User != "" {
+		src = h.User + "@" + src
+	}
+	return []string{"-a", "--delete", "--exclude", stampFile, "-e", ssh, "--", src, dir + "/"}
+}
+
+// syncFunc runs one sync of h into dir; tests replace it.
+var syncFunc = rsync
+
+func rsync(ctx context.Context, h Home, dir string) error {
+	if _, err := exec.LookPath("rsync"); err != nil {
+		return errors.New("rsync isn't installed; a remote claude_home needs rsync here and on the remote host")
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "rsync", rsyncArgs(h, dir)...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("timed out after %s", SyncTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, lastLine(msg))
+		}
+		return err
+	}
+	return nil
+}
+
+// lastLine returns the last line of s, where rsync and ssh put the reason a
+// transfer failed.
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// Mirror brings the local mirror of h under cacheDir up to date and returns
+// its path. A mirror synced within MaxAge is used as is. When the sync
+// fails, or offline mode is on, an earlier mirror is used instead and warn
+// is called with the reason; without one, Mirror returns the error.
+func Mirror(cacheDir string, h Home, now time.Time, warn func(error)) (string, error) {
+	dir := MirrorDir(cacheDir, h)
+	synced, hasMirror := lastSync(dir)
+	if hasMirror && now.Sub(synced) < MaxAge {
+		return dir, nil
+	}
+
+	err := guard.Network("reading remote claude_home " + h.String())
+	if err == nil {
+		err = syncMirror(dir, h, now)
+	}
+	if err == nil {
+		return dir, nil
+	}
+	if !hasMirror {
+		return "", err
+	}
+	warn(fmt.Errorf("%w; using the copy from %s", err, synced.Local().Format("2006-01-02 15:04")))
+	return dir, nil
+}
+
+func syncMirror(dir string, h Home, now time.Time) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating mirror for %s: %w", h, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), SyncTimeout)
+	defer cancel()
+	if err := syncFunc(ctx, h, dir); err != nil {
+		return fmt.Errorf("syncing %s: %w", h, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, stampFile), []byte(now.UTC().Format(time.RFC3339)), 0o600); err != nil {
+		return fmt.Errorf("recording sync of %s: %w", h, err)
+	}
+	return nil
+}
+
+// lastSync returns when dir was last synced, and whether it holds a
+// completed sync at all.
+func lastSync(dir string) (time.Time, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, stampFile))
+	if err != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -6,14 +6,18 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+	"github.com/blackwell-systems/claudewatch/internal/workers"
 )
 
 // DiscoverProjects walks each provided path looking for directories that
 // contain a .git/ directory (i.e., Git repositories). For each discovered
 // project it checks for CLAUDE.md, .claude/ directory, .claude/settings.local.json,
-// detects primary language, and counts recent git commits.
+// detects primary language, and counts recent git commits. Projects are
+// inspected in parallel, up to workers.Limit() at a time.
 func DiscoverProjects(paths []string) ([]Project, error) {
-	var projects []Project
+	var repos []string
 	seen := make(map[string]bool)
 
 	for _, root := range paths {
@@ -52,41 +56,18 @@ func DiscoverProjects(paths []string) ([]Project, error) {
 			}
 			seen[abs] = true
 
-			p := Project{
-				Path:   abs,
-				Name:   entry.Name(),
-				HasGit: true,
-			}
-
-			// Check CLAUDE.md.
-			claudeMDPath := filepath.Join(abs, "CLAUDE.md")
-			if info, err := os.Stat(claudeMDPath); err == nil {
-				p.HasClaudeMD = true
-				p.ClaudeMDSize = info.Size()
-			}
-
-			// Check .claude/ directory.
-			dotClaudePath := filepath.Join(abs, ".claude")
-			if info, err := os.Stat(dotClaudePath); err == nil && info.IsDir() {
-				p.HasDotClaude = true
-			}
-
-			// Check .claude/settings.local.json.
-			localSettingsPath := filepath.Join(abs, ".claude", "settings.local.json")
-			if _, err := os.Stat(localSettingsPath); err == nil {
-				p.HasLocalSettings = true
-			}
-
-			// Detect primary language.
-			p.PrimaryLanguage = detectLanguage(abs)
-
-			// Count recent git commits.
-			p.CommitsLast30Days = countRecentCommits(abs)
-
-			projects = append(projects, p)
+			repos = append(repos, abs)
 		}
 	}
 
+	var projects []Project
+	if len(repos) > 0 {
+		projects = make([]Project, len(repos))
+		workers.Each(len(repos), func(i int) {
+			projects[i] = InspectProject(repos[i])
+		})
+	}
+
 	// Sort by name.
 	sort.Slice(projects, func(i, j int) bool {
 		return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
@@ -95,6 +76,50 @@ func DiscoverProjects(paths []string) ([]Project, error) {
 	return projects, nil
 }
 
+// InspectProject collects the scoring metadata for the git repository at the
+// absolute path abs: CLAUDE.md, .claude/ setup, primary language, and recent
+// commit count.
+func InspectProject(abs string) Project {
+	p := Project{
+		Path:   abs,
+		Name:   filepath.Base(abs),
+		HasGit: true,
+	}
+
+	// Check CLAUDE.md.
+	claudeMDPath := filepath.Join(abs, "CLAUDE.md")
+	if info, err := os.Stat(claudeMDPath); err == nil {
+		p.HasClaudeMD = true
+		p.ClaudeMDSize = info.Size()
+	}
+
+	// Check .claude/ directory.
+	dotClaudePath := filepath.Join(abs, ".claude")
+	if info, err := os.Stat(dotClaudePath); err == nil && info.IsDir() {
+		p.HasDotClaude = true
+	}
+
+	// Check .claude/settings.local.json.
+	localSettingsPath := filepath.Join(abs, ".claude", "settings.local.json")
+	if _, err := os.Stat(localSettingsPath); err == nil {
+		p.HasLocalSettings = true
+	}
+
+	// Check for a per-project config file.
+	configPath := filepath.Join(abs, config.ProjectConfigFile)
+	if _, err := os.Stat(configPath); err == nil {
+		p.ConfigFile = configPath
+	}
+
+	// Detect primary language.
+	p.PrimaryLanguage = detectLanguage(abs)
+
+	// Count recent git commits.
+	p.CommitsLast30Days = countRecentCommits(abs)
+
+	return p
+}
+
 // detectLanguage infers the primary language from the presence of
 // well-known project files.
 func detectLanguage(projectPath string) string {
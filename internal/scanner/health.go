@@ -0,0 +1,135 @@
+package scanner
+
+import (
+	"math"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+// HealthMinSessions is the fewest sessions a project needs before
+// ComputeProjectHealth grades it.
+const HealthMinSessions = 3
+
+// GradeInsufficient is the grade given to projects with too little data.
+const GradeInsufficient = "insufficient data"
+
+// HealthComponents holds each health component as a 0-1 value. A nil
+// component had no data and was left out of the score.
+type HealthComponents struct {
+	// Readiness is the readiness score divided by 100.
+	Readiness float64 `json:"readiness"`
+	// Friction is 1 minus the share of faceted sessions with any friction.
+	Friction *float64 `json:"friction,omitempty"`
+	// Commits is the share of sessions that made at least one commit.
+	Commits float64 `json:"commits"`
+	// Agents is the share of agent tasks that completed.
+	Agents *float64 `json:"agents,omitempty"`
+}
+
+// HealthScore is a project's composite health: a 0-100 score and a letter
+// grade, or GradeInsufficient when it has fewer than HealthMinSessions
+// sessions.
+type HealthScore struct {
+	Score      float64          `json:"score"`
+	Grade      string           `json:"grade"`
+	Sessions   int              `json:"sessions"`
+	Components HealthComponents `json:"components"`
+}
+
+// Insufficient reports whether the project had too little data to grade.
+func (h HealthScore) Insufficient() bool {
+	return h.Grade == GradeInsufficient
+}
+
+// ComputeProjectHealth combines readiness, inverse friction rate, commit rate,
+// and agent success into a 0-100 score:
+//
+//	score = 100 × Σ(weight × component) / Σ(weight)
+//
+// summed over the components with data. Friction needs facets and agent
+// success needs agent tasks; readiness and commit rate are always present.
+// Projects with fewer than HealthMinSessions sessions, or with data only for
+// zero-weighted components, get GradeInsufficient and a zero score.
+func ComputeProjectHealth(p *Project, sessions []claude.SessionMeta, facets []claude.SessionFacet, tasks []claude.AgentTask, settings *claude.GlobalSettings, w config.HealthWeights) HealthScore {
+	projectSessions := filterByProject(sessions, p.Path)
+	h := HealthScore{Sessions: len(projectSessions)}
+	if h.Sessions < HealthMinSessions {
+		h.Grade = GradeInsufficient
+		return h
+	}
+
+	c := &h.Components
+	c.Readiness = ComputeReadiness(p, sessions, facets, settings) / 100
+
+	sessionIDs := make(map[string]bool, len(projectSessions))
+	committed := 0
+	for _, s := range projectSessions {
+		sessionIDs[s.SessionID] = true
+		if s.GitCommits > 0 {
+			committed++
+		}
+	}
+	c.Commits = float64(committed) / float64(len(projectSessions))
+
+	if projectFacets := FilterFacetsByProject(facets, sessions, p.Path); len(projectFacets) > 0 {
+		withFriction := 0
+		for _, f := range projectFacets {
+			if len(f.FrictionCounts) > 0 {
+				withFriction++
+			}
+		}
+		v := 1 - float64(withFriction)/float64(len(projectFacets))
+		c.Friction = &v
+	}
+
+	var total, completed int
+	for _, t := range tasks {
+		if !sessionIDs[t.SessionID] {
+			continue
+		}
+		total++
+		if t.Status == "completed" {
+			completed++
+		}
+	}
+	if total > 0 {
+		v := float64(completed) / float64(total)
+		c.Agents = &v
+	}
+
+	sum, weight := w.Readiness*c.Readiness+w.Commits*c.Commits, w.Readiness+w.Commits
+	if c.Friction != nil {
+		sum += w.Friction * *c.Friction
+		weight += w.Friction
+	}
+	if c.Agents != nil {
+		sum += w.Agents * *c.Agents
+		weight += w.Agents
+	}
+	if weight == 0 {
+		// Only zero-weighted components have data.
+		h.Grade = GradeInsufficient
+		return h
+	}
+	h.Score = math.Round(100*sum/weight*10) / 10
+	h.Grade = HealthGrade(h.Score)
+	return h
+}
+
+// HealthGrade maps a 0-100 health score to a letter grade: A from 90, B from
+// 80, C from 70, D from 60, and F below.
+func HealthGrade(score float64) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
@@ -0,0 +1,85 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+func healthSessions(path string, commits ...int) []claude.SessionMeta {
+	sessions := make([]claude.SessionMeta, len(commits))
+	for i, c := range commits {
+		sessions[i] = claude.SessionMeta{
+			SessionID:   string(rune('a' + i)),
+			ProjectPath: path,
+			GitCommits:  c,
+		}
+	}
+	return sessions
+}
+
+func TestComputeProjectHealth_InsufficientData(t *testing.T) {
+	p := &Project{Path: "/p", HasClaudeMD: true}
+	h := ComputeProjectHealth(p, healthSessions("/p", 1, 1), nil, nil, nil, config.DefaultHealthWeights)
+	if !h.Insufficient() || h.Score != 0 || h.Sessions != 2 {
+		t.Errorf("got %+v, want insufficient data with 2 sessions", h)
+	}
+}
+
+func TestComputeProjectHealth_AllComponents(t *testing.T) {
+	// Readiness 30 (CLAUDE.md) + 10 (facets) = 40 → 0.4.
+	p := &Project{Path: "/p", HasClaudeMD: true}
+	sessions := healthSessions("/p", 1, 0, 2, 3)
+	facets := []claude.SessionFacet{
+		{SessionID: "a", FrictionCounts: map[string]int{"wrong_approach": 1}},
+		{SessionID: "b"},
+	}
+	tasks := []claude.AgentTask{
+		{SessionID: "a", Status: "completed"},
+		{SessionID: "c", Status: "killed"},
+		{SessionID: "other", Status: "killed"},
+	}
+
+	h := ComputeProjectHealth(p, sessions, facets, tasks, nil, config.DefaultHealthWeights)
+
+	// 0.3×0.4 + 0.3×0.5 + 0.2×0.75 + 0.2×0.5 = 0.52
+	if h.Score != 52 || h.Grade != "F" {
+		t.Errorf("Score, Grade = %v, %q; want 52, F", h.Score, h.Grade)
+	}
+	if h.Components.Friction == nil || *h.Components.Friction != 0.5 {
+		t.Errorf("Friction = %v, want 0.5", h.Components.Friction)
+	}
+	if h.Components.Agents == nil || *h.Components.Agents != 0.5 {
+		t.Errorf("Agents = %v, want 0.5", h.Components.Agents)
+	}
+}
+
+func TestComputeProjectHealth_RenormalizesMissingComponents(t *testing.T) {
+	p := &Project{Path: "/p", HasClaudeMD: true, ClaudeMDSize: 1000, HasDotClaude: true, HasLocalSettings: true, CommitsLast30Days: 25}
+	sessions := healthSessions("/p", 1, 1, 1)
+
+	// Readiness 65 → 0.65, commits 1.0; no facets or agent tasks.
+	// (0.3×0.65 + 0.2×1) / 0.5 = 0.79
+	h := ComputeProjectHealth(p, sessions, nil, nil, nil, config.DefaultHealthWeights)
+	if h.Score != 79 || h.Grade != "C" {
+		t.Errorf("Score, Grade = %v, %q; want 79, C", h.Score, h.Grade)
+	}
+	if h.Components.Friction != nil || h.Components.Agents != nil {
+		t.Errorf("expected friction and agents to be omitted, got %+v", h.Components)
+	}
+
+	// Only the agents weight is set, and there are no agent tasks.
+	h = ComputeProjectHealth(p, sessions, nil, nil, nil, config.HealthWeights{Agents: 1})
+	if !h.Insufficient() {
+		t.Errorf("Grade = %q, want %q", h.Grade, GradeInsufficient)
+	}
+}
+
+func TestHealthGrade(t *testing.T) {
+	for score, want := range map[float64]string{100: "A", 90: "A", 89.9: "B", 80: "B", 70: "C", 60: "D", 59.9: "F", 0: "F"} {
+		if got := HealthGrade(score); got != want {
+			t.Errorf("HealthGrade(%v) = %q, want %q", score, got, want)
+		}
+	}
+}
@@ -193,6 +193,29 @@ func TestDiscoverProjects_DetectsLocalSettings(t *testing.T) {
 	}
 }
 
+func TestDiscoverProjects_DetectsProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	project := filepath.Join(root, "myproj")
+	if err := os.MkdirAll(filepath.Join(project, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(project, ".claudewatch.yaml")
+	if err := os.WriteFile(configPath, []byte("thresholds: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := DiscoverProjects([]string{root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+	if projects[0].ConfigFile != configPath {
+		t.Errorf("ConfigFile = %q, want %q", projects[0].ConfigFile, configPath)
+	}
+}
+
 func TestDiscoverProjects_DetectsLanguage(t *testing.T) {
 	tests := []struct {
 		file string
@@ -1,11 +1,13 @@
 package scanner
 
 import (
+	"math"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
 )
 
 // ComputeReadiness calculates a 0-100 readiness score for a project based on
@@ -82,19 +84,33 @@ func ComputeReadiness(p *Project, sessions []claude.SessionMeta, facets []claude
 	return score
 }
 
+// WeightedReadiness scales a readiness score by session volume so projects
+// where most work happens rank first:
+//
+//	weighted = score × (1 + Weight × log_LogBase(1 + sessions))
+//
+// A project with no sessions keeps its raw score. A LogBase of 1 or less
+// falls back to config.DefaultReadinessVolume.LogBase.
+func WeightedReadiness(score float64, sessions int, w config.ReadinessVolume) float64 {
+	if sessions <= 0 {
+		return score
+	}
+	base := w.LogBase
+	if base <= 1 {
+		base = config.DefaultReadinessVolume.LogBase
+	}
+	return score * (1 + w.Weight*math.Log(1+float64(sessions))/math.Log(base))
+}
+
 // recencyWeight returns a linear decay weight from 1.0 (today) to 0.0 (30+ days ago).
 func recencyWeight(startTime string) float64 {
 	if startTime == "" {
 		return 0
 	}
 
-	t, err := time.Parse(time.RFC3339, startTime)
+	t, err := claude.ParseSessionTime(startTime)
 	if err != nil {
-		// Try parsing date-only format as fallback.
-		t, err = time.Parse("2006-01-02", startTime)
-		if err != nil {
-			return 0
-		}
+		return 0
 	}
 
 	daysSince := time.Since(t).Hours() / 24
@@ -1,10 +1,12 @@
 package scanner
 
 import (
+	"math"
 	"testing"
 	"time"
 
 	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
 )
 
 func TestComputeReadiness_PerfectScore(t *testing.T) {
@@ -187,3 +189,29 @@ func TestNormalizePath(t *testing.T) {
 		}
 	}
 }
+
+func TestWeightedReadiness(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    float64
+		sessions int
+		w        config.ReadinessVolume
+		want     float64
+	}{
+		{"no sessions keeps raw score", 50, 0, config.DefaultReadinessVolume, 50},
+		{"9 sessions doubles at base 10", 50, 9, config.DefaultReadinessVolume, 100},
+		{"99 sessions triples at base 10", 40, 99, config.DefaultReadinessVolume, 120},
+		{"base 2 grows faster", 10, 3, config.ReadinessVolume{LogBase: 2, Weight: 1}, 30},
+		{"weight halves the boost", 50, 9, config.ReadinessVolume{LogBase: 10, Weight: 0.5}, 75},
+		{"zero weight disables weighting", 50, 99, config.ReadinessVolume{LogBase: 10, Weight: 0}, 50},
+		{"invalid base falls back to default", 50, 9, config.ReadinessVolume{LogBase: 1, Weight: 1}, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WeightedReadiness(tt.score, tt.sessions, tt.w)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("WeightedReadiness(%v, %d, %+v) = %v, want %v", tt.score, tt.sessions, tt.w, got, tt.want)
+			}
+		})
+	}
+}
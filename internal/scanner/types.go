@@ -21,6 +21,10 @@ type Project struct {
 	// HasLocalSettings indicates whether .claude/settings.local.json exists.
 	HasLocalSettings bool `json:"has_local_settings"`
 
+	// ConfigFile is the path to the project's .claudewatch.yaml, or "" if it
+	// has none.
+	ConfigFile string `json:"config_file,omitempty"`
+
 	// PrimaryLanguage is the most-used language detected from session data.
 	PrimaryLanguage string `json:"primary_language,omitempty"`
 
@@ -41,4 +45,7 @@ type Project struct {
 
 	// Score is the computed readiness score (0-100).
 	Score float64 `json:"score"`
+
+	// WeightedScore is Score scaled by session volume (see WeightedReadiness).
+	WeightedScore float64 `json:"weighted_score"`
 }
@@ -2,10 +2,14 @@ package store
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
-	_ "modernc.org/sqlite"
+	"github.com/blackwell-systems/claudewatch/internal/guard"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 // DB wraps a sql.DB connection to the claudewatch SQLite database.
@@ -14,8 +18,14 @@ type DB struct {
 }
 
 // Open opens or creates the SQLite database at the given path.
-// It creates the parent directory if it does not exist.
+// It creates the parent directory if it does not exist. In read-only mode
+// (see guard.ReadOnly) it opens an existing database read-only instead, so
+// SQLite rejects every write.
 func Open(dbPath string) (*DB, error) {
+	if guard.ReadOnly() {
+		return openReadOnly(dbPath)
+	}
+
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, err
@@ -49,6 +59,42 @@ func Open(dbPath string) (*DB, error) {
 	return db, nil
 }
 
+// openReadOnly opens an existing database without creating, migrating, or
+// otherwise modifying it. A database an older claudewatch left at an earlier
+// schema version is refused, since queries would fail on the missing tables
+// and columns.
+func openReadOnly(dbPath string) (*DB, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s does not exist and can't be created: %w", dbPath, guard.ErrReadOnly)
+		}
+		return nil, err
+	}
+
+	conn, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec("PRAGMA foreign_keys=ON"); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	db := &DB{conn: conn}
+	if v := db.version(); v < schemaVersion {
+		_ = conn.Close()
+		return nil, fmt.Errorf("%s is at schema version %d, older than this claudewatch's %d; run once without --read-only to migrate it: %w",
+			dbPath, v, schemaVersion, guard.ErrReadOnly)
+	}
+	return db, nil
+}
+
+// IsReadOnlyError reports whether err is SQLite refusing a write to a
+// database opened read-only.
+func IsReadOnlyError(err error) bool {
+	var se *sqlite.Error
+	return errors.As(err, &se) && se.Code()&0xff == sqlite3.SQLITE_READONLY
+}
+
 // OpenInMemory opens an in-memory SQLite database, useful for testing.
 func OpenInMemory() (*DB, error) {
 	conn, err := sql.Open("sqlite", ":memory:")
@@ -2,6 +2,20 @@ package store
 
 import "fmt"
 
+// schemaVersion is the schema version Migrate brings a database to.
+const schemaVersion = 9
+
+// version returns the database's schema version, 0 for a fresh database.
+func (db *DB) version() int {
+	version := 0
+	row := db.conn.QueryRow("SELECT version FROM schema_version LIMIT 1")
+	if err := row.Scan(&version); err != nil {
+		// No rows, or no table, means version 0 (fresh database).
+		version = 0
+	}
+	return version
+}
+
 // Migrate runs forward migrations to bring the database schema up to date.
 func (db *DB) Migrate() error {
 	// Create the schema_version table if it does not exist.
@@ -13,12 +27,7 @@ func (db *DB) Migrate() error {
 		return fmt.Errorf("creating schema_version table: %w", err)
 	}
 
-	version := 0
-	row := db.conn.QueryRow("SELECT version FROM schema_version LIMIT 1")
-	if err := row.Scan(&version); err != nil {
-		// No rows means version 0 (fresh database).
-		version = 0
-	}
+	version := db.version()
 
 	if version < 1 {
 		if err := db.migrateV1(); err != nil {
@@ -38,6 +47,42 @@ func (db *DB) Migrate() error {
 		}
 	}
 
+	if version < 4 {
+		if err := db.migrateV4(); err != nil {
+			return fmt.Errorf("migration v4: %w", err)
+		}
+	}
+
+	if version < 5 {
+		if err := db.migrateV5(); err != nil {
+			return fmt.Errorf("migration v5: %w", err)
+		}
+	}
+
+	if version < 6 {
+		if err := db.migrateV6(); err != nil {
+			return fmt.Errorf("migration v6: %w", err)
+		}
+	}
+
+	if version < 7 {
+		if err := db.migrateV7(); err != nil {
+			return fmt.Errorf("migration v7: %w", err)
+		}
+	}
+
+	if version < 8 {
+		if err := db.migrateV8(); err != nil {
+			return fmt.Errorf("migration v8: %w", err)
+		}
+	}
+
+	if version < 9 {
+		if err := db.migrateV9(); err != nil {
+			return fmt.Errorf("migration v9: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -275,3 +320,167 @@ func (db *DB) migrateV3() error {
 
 	return tx.Commit()
 }
+
+// migrateV4 adds the unit column to aggregate_metrics. Rows written before
+// it get an empty unit.
+func (db *DB) migrateV4() error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`ALTER TABLE aggregate_metrics ADD COLUMN unit TEXT NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("adding aggregate_metrics.unit: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_version"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 4); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// migrateV5 adds the resolved_manually column to suggestions, so a
+// suggestion the user resolved stays resolved when later snapshots raise it
+// again.
+func (db *DB) migrateV5() error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`ALTER TABLE suggestions ADD COLUMN resolved_manually INTEGER NOT NULL DEFAULT 0`); err != nil {
+		return fmt.Errorf("adding suggestions.resolved_manually: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_version"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 5); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// migrateV6 adds the session_notes table for user notes and tags on
+// sessions. Rows are keyed by session ID, independent of snapshots.
+func (db *DB) migrateV6() error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS session_notes (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			session_id TEXT    NOT NULL,
+			kind       TEXT    NOT NULL,
+			text       TEXT    NOT NULL,
+			created_at TEXT    NOT NULL
+		)`,
+
+		`CREATE INDEX IF NOT EXISTS idx_session_notes_session ON session_notes(session_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_session_notes_kind_text ON session_notes(kind, text)`,
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			l := len(stmt)
+			if l > 40 {
+				l = 40
+			}
+			return fmt.Errorf("executing %q: %w", stmt[:l], err)
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_version"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 6); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// migrateV7 adds the sections column to snapshots, recording which parts of
+// a track run each snapshot holds. Existing rows get an empty list, meaning all of them.
+func (db *DB) migrateV7() error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`ALTER TABLE snapshots ADD COLUMN sections TEXT NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("adding snapshots.sections: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_version"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 7); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// migrateV8 adds the project column to snapshots, scoping a track run to one
+// project. Existing rows get an empty project, meaning every project.
+func (db *DB) migrateV8() error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`ALTER TABLE snapshots ADD COLUMN project TEXT NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("adding snapshots.project: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_version"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 8); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// migrateV9 adds the first_seen column to suggestions, recording when a
+// suggestion was first raised so a TTL can expire it. Existing rows get the
+// time of the earliest snapshot that stored their category and title.
+func (db *DB) migrateV9() error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`ALTER TABLE suggestions ADD COLUMN first_seen TEXT NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("adding suggestions.first_seen: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE suggestions SET first_seen = COALESCE((
+		SELECT MIN(sn.taken_at) FROM suggestions s2 JOIN snapshots sn ON sn.id = s2.snapshot_id
+		WHERE s2.category = suggestions.category AND s2.title = suggestions.title), '')`); err != nil {
+		return fmt.Errorf("backfilling suggestions.first_seen: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_version"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 9); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
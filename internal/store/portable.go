@@ -0,0 +1,340 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExportVersion is the version of the portable JSON document written by
+// Export. Import reads this version and older ones; each format change bumps
+// it and teaches Import to upgrade the previous version.
+//
+// Version 2 added SessionNotes; version 1 documents import without them.
+const ExportVersion = 2
+
+// Export is a portable, versioned copy of every track snapshot and the rows
+// recorded with it, plus the notes and tags attached to sessions, for
+// backups and moving history between machines.
+type Export struct {
+	Version    int              `json:"version"`
+	ExportedAt time.Time        `json:"exported_at"`
+	Snapshots  []ExportSnapshot `json:"snapshots"`
+	// SessionNotes are keyed by session ID rather than snapshot. Their IDs
+	// are those of the exporting database.
+	SessionNotes []SessionNote `json:"session_notes"`
+}
+
+// ExportSnapshot is one snapshot with its rows. Row IDs and snapshot IDs are
+// those of the exporting database; Import assigns new ones.
+type ExportSnapshot struct {
+	Snapshot
+	ProjectScores    []ProjectScore    `json:"project_scores"`
+	AggregateMetrics []AggregateMetric `json:"aggregate_metrics"`
+	FrictionEvents   []FrictionEvent   `json:"friction_events"`
+	AgentTasks       []AgentTaskRow    `json:"agent_tasks"`
+	Suggestions      []Suggestion      `json:"suggestions"`
+}
+
+// ImportResult reports what Import added.
+type ImportResult struct {
+	Imported int `json:"imported"`
+	// Skipped counts snapshots already present, matched by time taken,
+	// command, and version, so importing the same file twice adds nothing.
+	Skipped int `json:"skipped"`
+	// IDs maps each imported snapshot's ID in the export to its new ID.
+	IDs map[int64]int64 `json:"ids"`
+	// SessionNotes counts the notes and tags added. Those already present
+	// are skipped: tags by session and text, notes also by creation time.
+	SessionNotes int `json:"session_notes"`
+}
+
+// Export reads every snapshot, ordered by the time it was taken and then by
+// ID, with its project scores, aggregate metrics, friction events, agent
+// tasks, and suggestions, and every session note and tag.
+func (db *DB) Export() (*Export, error) {
+	out := &Export{
+		Version:      ExportVersion,
+		ExportedAt:   time.Now().UTC(),
+		Snapshots:    []ExportSnapshot{},
+		SessionNotes: []SessionNote{},
+	}
+
+	rows, err := db.conn.Query("SELECT " + snapshotColumns + " FROM snapshots ORDER BY taken_at, id")
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[int64]int)
+	for rows.Next() {
+		s, err := scanSnapshotFields(rows)
+		if err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		index[s.ID] = len(out.Snapshots)
+		out.Snapshots = append(out.Snapshots, ExportSnapshot{
+			Snapshot:         s,
+			ProjectScores:    []ProjectScore{},
+			AggregateMetrics: []AggregateMetric{},
+			FrictionEvents:   []FrictionEvent{},
+			AgentTasks:       []AgentTaskRow{},
+			Suggestions:      []Suggestion{},
+		})
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// snapshot returns the export entry of a snapshot ID, or nil for rows
+	// whose snapshot no longer exists.
+	snapshot := func(id int64) *ExportSnapshot {
+		if i, ok := index[id]; ok {
+			return &out.Snapshots[i]
+		}
+		return nil
+	}
+	// each runs query and hands every row to scan.
+	each := func(table, query string, scan func(rows *sql.Rows) error) error {
+		rows, err := db.conn.Query(query)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", table, err)
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			if err := scan(rows); err != nil {
+				return fmt.Errorf("reading %s: %w", table, err)
+			}
+		}
+		return rows.Err()
+	}
+
+	err = each("project scores", `SELECT id, snapshot_id, project, score, has_claude_md, has_dot_claude,
+		 has_local_settings, session_count, last_session_date, primary_language, git_commit_30d
+		 FROM project_scores ORDER BY id`,
+		func(rows *sql.Rows) error {
+			var ps ProjectScore
+			var lastDate, lang sql.NullString
+			if err := rows.Scan(&ps.ID, &ps.SnapshotID, &ps.Project, &ps.Score,
+				&ps.HasClaudeMD, &ps.HasDotClaude, &ps.HasLocalSettings,
+				&ps.SessionCount, &lastDate, &lang, &ps.GitCommit30D); err != nil {
+				return err
+			}
+			ps.LastSessionDate = lastDate.String
+			ps.PrimaryLanguage = lang.String
+			if s := snapshot(ps.SnapshotID); s != nil {
+				s.ProjectScores = append(s.ProjectScores, ps)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = each("aggregate metrics", `SELECT id, snapshot_id, metric_name, metric_value, unit, detail
+		 FROM aggregate_metrics ORDER BY id`,
+		func(rows *sql.Rows) error {
+			var m AggregateMetric
+			var detail sql.NullString
+			if err := rows.Scan(&m.ID, &m.SnapshotID, &m.MetricName, &m.MetricValue, &m.Unit, &detail); err != nil {
+				return err
+			}
+			m.Detail = detail.String
+			if s := snapshot(m.SnapshotID); s != nil {
+				s.AggregateMetrics = append(s.AggregateMetrics, m)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = each("friction events", `SELECT id, snapshot_id, session_id, friction_type, count, detail, project, session_date
+		 FROM friction_events ORDER BY id`,
+		func(rows *sql.Rows) error {
+			var fe FrictionEvent
+			var detail, project, date sql.NullString
+			if err := rows.Scan(&fe.ID, &fe.SnapshotID, &fe.SessionID, &fe.FrictionType, &fe.Count,
+				&detail, &project, &date); err != nil {
+				return err
+			}
+			fe.Detail, fe.Project, fe.SessionDate = detail.String, project.String, date.String
+			if s := snapshot(fe.SnapshotID); s != nil {
+				s.FrictionEvents = append(s.FrictionEvents, fe)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = each("agent tasks", `SELECT id, snapshot_id, session_id, agent_id, agent_type, description, status,
+		 duration_ms, total_tokens, tool_uses, background, needed_correction, created_at
+		 FROM agent_tasks ORDER BY id`,
+		func(rows *sql.Rows) error {
+			var at AgentTaskRow
+			var desc sql.NullString
+			var duration, tokens, tools sql.NullInt64
+			var background, correction sql.NullBool
+			if err := rows.Scan(&at.ID, &at.SnapshotID, &at.SessionID, &at.AgentID, &at.AgentType,
+				&desc, &at.Status, &duration, &tokens, &tools, &background, &correction, &at.CreatedAt); err != nil {
+				return err
+			}
+			at.Description = desc.String
+			at.DurationMs = duration.Int64
+			at.TotalTokens = int(tokens.Int64)
+			at.ToolUses = int(tools.Int64)
+			at.Background = background.Bool
+			at.NeededCorrection = correction.Bool
+			if s := snapshot(at.SnapshotID); s != nil {
+				s.AgentTasks = append(s.AgentTasks, at)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = each("suggestions", "SELECT "+suggestionColumns+" FROM suggestions ORDER BY id",
+		func(rows *sql.Rows) error {
+			sg, err := scanSuggestion(rows)
+			if err != nil {
+				return err
+			}
+			if s := snapshot(sg.SnapshotID); s != nil {
+				s.Suggestions = append(s.Suggestions, sg)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = each("session notes", "SELECT id, session_id, kind, text, created_at FROM session_notes ORDER BY id",
+		func(rows *sql.Rows) error {
+			var n SessionNote
+			var createdAt string
+			if err := rows.Scan(&n.ID, &n.SessionID, &n.Kind, &n.Text, &createdAt); err != nil {
+				return err
+			}
+			n.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+			out.SessionNotes = append(out.SessionNotes, n)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Import adds the snapshots in e, with their rows, to the database in one
+// transaction. It never overwrites: every imported snapshot gets a fresh ID
+// and its rows point at it, so IDs that collide with existing snapshots are
+// remapped. Snapshots already present are skipped, as are session notes and
+// tags. Documents from a newer ExportVersion are rejected.
+func (db *DB) Import(e *Export) (ImportResult, error) {
+	result := ImportResult{IDs: make(map[int64]int64)}
+	switch {
+	case e.Version < 1:
+		return result, fmt.Errorf("missing or invalid export version %d", e.Version)
+	case e.Version > ExportVersion:
+		return result, fmt.Errorf("export version %d is newer than this claudewatch supports (%d); upgrade claudewatch to import it", e.Version, ExportVersion)
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Only snapshots present before the import count as duplicates, so
+	// snapshots in e taken in the same second are all kept.
+	var lastID int64
+	if err := tx.QueryRow("SELECT COALESCE(MAX(id), 0) FROM snapshots").Scan(&lastID); err != nil {
+		return result, err
+	}
+
+	for _, s := range e.Snapshots {
+		takenAt := s.TakenAt.UTC().Format(time.RFC3339)
+		var existing int64
+		err := tx.QueryRow("SELECT id FROM snapshots WHERE id <= ? AND taken_at = ? AND command = ? AND version = ? AND project = ? LIMIT 1",
+			lastID, takenAt, s.Command, s.Version, s.Project).Scan(&existing)
+		if err == nil {
+			result.Skipped++
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return result, fmt.Errorf("checking snapshot #%d: %w", s.ID, err)
+		}
+
+		res, err := tx.Exec("INSERT INTO snapshots (taken_at, command, version, sections, project) VALUES (?, ?, ?, ?, ?)",
+			takenAt, s.Command, s.Version, strings.Join(s.Sections, ","), s.Project)
+		if err != nil {
+			return result, fmt.Errorf("importing snapshot #%d: %w", s.ID, err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return result, err
+		}
+
+		for _, ps := range s.ProjectScores {
+			ps.SnapshotID = id
+			if err := insertProjectScore(tx, &ps); err != nil {
+				return result, fmt.Errorf("importing project score for snapshot #%d: %w", s.ID, err)
+			}
+		}
+		for _, m := range s.AggregateMetrics {
+			m.SnapshotID = id
+			if err := insertAggregateMetric(tx, &m); err != nil {
+				return result, fmt.Errorf("importing metric for snapshot #%d: %w", s.ID, err)
+			}
+		}
+		for _, fe := range s.FrictionEvents {
+			fe.SnapshotID = id
+			if err := insertFrictionEvent(tx, &fe); err != nil {
+				return result, fmt.Errorf("importing friction event for snapshot #%d: %w", s.ID, err)
+			}
+		}
+		for _, at := range s.AgentTasks {
+			at.SnapshotID = id
+			if err := insertAgentTask(tx, &at); err != nil {
+				return result, fmt.Errorf("importing agent task for snapshot #%d: %w", s.ID, err)
+			}
+		}
+		for _, sg := range s.Suggestions {
+			sg.SnapshotID = id
+			if err := insertSuggestion(tx, &sg); err != nil {
+				return result, fmt.Errorf("importing suggestion for snapshot #%d: %w", s.ID, err)
+			}
+		}
+
+		result.Imported++
+		result.IDs[s.ID] = id
+	}
+
+	for _, n := range e.SessionNotes {
+		res, err := tx.Exec(
+			`INSERT INTO session_notes (session_id, kind, text, created_at)
+			 SELECT ?, ?, ?, ?
+			 WHERE NOT EXISTS (
+				SELECT 1 FROM session_notes
+				WHERE session_id = ? AND kind = ? AND text = ? AND (kind = ? OR created_at = ?)
+			 )`,
+			n.SessionID, n.Kind, n.Text, n.CreatedAt.UTC().Format(time.RFC3339),
+			n.SessionID, n.Kind, n.Text, SessionNoteKindTag, n.CreatedAt.UTC().Format(time.RFC3339),
+		)
+		if err != nil {
+			return result, fmt.Errorf("importing %s for session %q: %w", n.Kind, n.SessionID, err)
+		}
+		if added, err := res.RowsAffected(); err == nil {
+			result.SessionNotes += int(added)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ImportResult{IDs: map[int64]int64{}}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of session annotation stored in session_notes.
+const (
+	SessionNoteKindNote = "note"
+	SessionNoteKindTag  = "tag"
+)
+
+// SessionNote is a note or tag the user attached to a session.
+type SessionNote struct {
+	ID        int64     `json:"id"`
+	SessionID string    `json:"session_id"`
+	Kind      string    `json:"kind"` // SessionNoteKindNote or SessionNoteKindTag
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddSessionNote attaches a free-text note to a session.
+func (db *DB) AddSessionNote(sessionID, text string) error {
+	_, err := db.conn.Exec(
+		`INSERT INTO session_notes (session_id, kind, text, created_at) VALUES (?, ?, ?, ?)`,
+		sessionID, SessionNoteKindNote, text, time.Now().UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("adding note to session %q: %w", sessionID, err)
+	}
+	return nil
+}
+
+// AddSessionTag tags a session. It reports false, without error, when the
+// session already has the tag.
+func (db *DB) AddSessionTag(sessionID, tag string) (bool, error) {
+	result, err := db.conn.Exec(
+		`INSERT INTO session_notes (session_id, kind, text, created_at)
+		 SELECT ?, ?, ?, ?
+		 WHERE NOT EXISTS (
+			SELECT 1 FROM session_notes WHERE session_id = ? AND kind = ? AND text = ?
+		 )`,
+		sessionID, SessionNoteKindTag, tag, time.Now().UTC().Format(time.RFC3339),
+		sessionID, SessionNoteKindTag, tag,
+	)
+	if err != nil {
+		return false, fmt.Errorf("tagging session %q: %w", sessionID, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// GetSessionNotes returns the notes and tags attached to a session, oldest
+// first.
+func (db *DB) GetSessionNotes(sessionID string) ([]SessionNote, error) {
+	rows, err := db.conn.Query(
+		`SELECT id, session_id, kind, text, created_at
+		 FROM session_notes WHERE session_id = ? ORDER BY id`,
+		sessionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting notes for session %q: %w", sessionID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var notes []SessionNote
+	for rows.Next() {
+		var n SessionNote
+		var createdAt string
+		if err := rows.Scan(&n.ID, &n.SessionID, &n.Kind, &n.Text, &createdAt); err != nil {
+			return nil, err
+		}
+		n.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
+// SessionIDsWithTag returns the set of session IDs carrying tag.
+func (db *DB) SessionIDsWithTag(tag string) (map[string]bool, error) {
+	rows, err := db.conn.Query(
+		`SELECT DISTINCT session_id FROM session_notes WHERE kind = ? AND text = ?`,
+		SessionNoteKindTag, tag,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions tagged %q: %w", tag, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
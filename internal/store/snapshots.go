@@ -2,14 +2,34 @@ package store
 
 import (
 	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
-// CreateSnapshot inserts a new snapshot and returns its ID.
-func (db *DB) CreateSnapshot(command, version string) (int64, error) {
+// execer runs a statement on a database or inside a transaction.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// snapshotColumns are the snapshot columns scanSnapshotFields reads, in
+// order.
+const snapshotColumns = "id, taken_at, command, version, sections, project"
+
+// CreateSnapshot inserts a new snapshot and returns its ID. sections lists
+// the SnapshotSections it records; none means all of them.
+func (db *DB) CreateSnapshot(command, version string, sections ...string) (int64, error) {
+	return db.CreateProjectSnapshot(command, version, "", sections...)
+}
+
+// CreateProjectSnapshot is CreateSnapshot for a snapshot scoped to the
+// project at path. An empty project covers every project.
+func (db *DB) CreateProjectSnapshot(command, version, project string, sections ...string) (int64, error) {
 	result, err := db.conn.Exec(
-		"INSERT INTO snapshots (taken_at, command, version) VALUES (?, ?, ?)",
-		time.Now().UTC().Format(time.RFC3339), command, version,
+		"INSERT INTO snapshots (taken_at, command, version, sections, project) VALUES (?, ?, ?, ?, ?)",
+		time.Now().UTC().Format(time.RFC3339), command, version, strings.Join(sections, ","), project,
 	)
 	if err != nil {
 		return 0, err
@@ -17,44 +37,136 @@ func (db *DB) CreateSnapshot(command, version string) (int64, error) {
 	return result.LastInsertId()
 }
 
+// SnapshotRecord is a snapshot together with the rows recorded in it.
+type SnapshotRecord struct {
+	Command  string
+	Version  string
+	Project  string
+	Sections []string
+
+	ProjectScores    []ProjectScore
+	AggregateMetrics []AggregateMetric
+	FrictionEvents   []FrictionEvent
+	AgentTasks       []AgentTaskRow
+	Suggestions      []Suggestion
+}
+
+// RecordSnapshot creates the snapshot in rec and inserts all its rows in one
+// transaction and returns the new snapshot's ID. Any failure rolls the whole
+// snapshot back, so a snapshot is either recorded complete or not at all.
+// The rows' SnapshotID fields are ignored; they are set to the new ID.
+func (db *DB) RecordSnapshot(rec SnapshotRecord) (int64, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(
+		"INSERT INTO snapshots (taken_at, command, version, sections, project) VALUES (?, ?, ?, ?, ?)",
+		time.Now().UTC().Format(time.RFC3339), rec.Command, rec.Version, strings.Join(rec.Sections, ","), rec.Project,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("creating snapshot: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, ps := range rec.ProjectScores {
+		ps.SnapshotID = id
+		if err := insertProjectScore(tx, &ps); err != nil {
+			return 0, fmt.Errorf("inserting project score: %w", err)
+		}
+	}
+	for _, m := range rec.AggregateMetrics {
+		m.SnapshotID = id
+		if err := insertAggregateMetric(tx, &m); err != nil {
+			return 0, fmt.Errorf("inserting metric %s: %w", m.MetricName, err)
+		}
+	}
+	for _, fe := range rec.FrictionEvents {
+		fe.SnapshotID = id
+		if err := insertFrictionEvent(tx, &fe); err != nil {
+			return 0, fmt.Errorf("inserting friction event: %w", err)
+		}
+	}
+	for _, at := range rec.AgentTasks {
+		at.SnapshotID = id
+		if err := insertAgentTask(tx, &at); err != nil {
+			return 0, fmt.Errorf("inserting agent task: %w", err)
+		}
+	}
+	for _, s := range rec.Suggestions {
+		s.SnapshotID = id
+		if err := insertSuggestion(tx, &s); err != nil {
+			return 0, fmt.Errorf("inserting suggestion: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
 // GetLatestSnapshot returns the most recent snapshot, or nil if none exist.
 func (db *DB) GetLatestSnapshot() (*Snapshot, error) {
-	row := db.conn.QueryRow("SELECT id, taken_at, command, version FROM snapshots ORDER BY id DESC LIMIT 1")
+	row := db.conn.QueryRow("SELECT " + snapshotColumns + " FROM snapshots ORDER BY taken_at DESC, id DESC LIMIT 1")
 	return scanSnapshot(row)
 }
 
 // GetSnapshot returns a snapshot by ID.
 func (db *DB) GetSnapshot(id int64) (*Snapshot, error) {
-	row := db.conn.QueryRow("SELECT id, taken_at, command, version FROM snapshots WHERE id = ?", id)
+	row := db.conn.QueryRow("SELECT "+snapshotColumns+" FROM snapshots WHERE id = ?", id)
 	return scanSnapshot(row)
 }
 
 // GetSnapshotN returns the Nth most recent snapshot (1 = latest, 2 = previous, etc.).
 func (db *DB) GetSnapshotN(n int) (*Snapshot, error) {
 	row := db.conn.QueryRow(
-		"SELECT id, taken_at, command, version FROM snapshots ORDER BY id DESC LIMIT 1 OFFSET ?",
+		"SELECT "+snapshotColumns+" FROM snapshots ORDER BY taken_at DESC, id DESC LIMIT 1 OFFSET ?",
 		n-1,
 	)
 	return scanSnapshot(row)
 }
 
 func scanSnapshot(row *sql.Row) (*Snapshot, error) {
-	var s Snapshot
-	var takenAt string
-	err := row.Scan(&s.ID, &takenAt, &s.Command, &s.Version)
+	s, err := scanSnapshotFields(row)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	s.TakenAt, _ = time.Parse(time.RFC3339, takenAt)
 	return &s, nil
 }
 
+// scanSnapshotFields scans a row of snapshotColumns. Snapshots recorded
+// before sections were tracked hold every section.
+func scanSnapshotFields(row interface{ Scan(...any) error }) (Snapshot, error) {
+	var s Snapshot
+	var takenAt, sections string
+	if err := row.Scan(&s.ID, &takenAt, &s.Command, &s.Version, &sections, &s.Project); err != nil {
+		return s, err
+	}
+	s.TakenAt, _ = time.Parse(time.RFC3339, takenAt)
+	if sections == "" {
+		s.Sections = append([]string(nil), SnapshotSections...)
+	} else {
+		s.Sections = strings.Split(sections, ",")
+	}
+	return s, nil
+}
+
 // InsertProjectScore inserts a project score for a snapshot.
 func (db *DB) InsertProjectScore(ps *ProjectScore) error {
-	_, err := db.conn.Exec(
+	return insertProjectScore(db.conn, ps)
+}
+
+func insertProjectScore(e execer, ps *ProjectScore) error {
+	_, err := e.Exec(
 		`INSERT INTO project_scores
 		(snapshot_id, project, score, has_claude_md, has_dot_claude, has_local_settings,
 		 session_count, last_session_date, primary_language, git_commit_30d)
@@ -66,18 +178,28 @@ func (db *DB) InsertProjectScore(ps *ProjectScore) error {
 	return err
 }
 
-// InsertAggregateMetric inserts an aggregate metric for a snapshot.
-func (db *DB) InsertAggregateMetric(snapshotID int64, name string, value float64, detail string) error {
-	_, err := db.conn.Exec(
-		"INSERT INTO aggregate_metrics (snapshot_id, metric_name, metric_value, detail) VALUES (?, ?, ?, ?)",
-		snapshotID, name, value, detail,
+// InsertAggregateMetric inserts an aggregate metric for a snapshot. unit
+// names what the value measures, such as "minutes" or "percent", and may be
+// empty.
+func (db *DB) InsertAggregateMetric(snapshotID int64, name string, value float64, unit string) error {
+	return insertAggregateMetric(db.conn, &AggregateMetric{SnapshotID: snapshotID, MetricName: name, MetricValue: value, Unit: unit})
+}
+
+func insertAggregateMetric(e execer, m *AggregateMetric) error {
+	_, err := e.Exec(
+		"INSERT INTO aggregate_metrics (snapshot_id, metric_name, metric_value, unit, detail) VALUES (?, ?, ?, ?, ?)",
+		m.SnapshotID, m.MetricName, m.MetricValue, m.Unit, m.Detail,
 	)
 	return err
 }
 
 // InsertFrictionEvent inserts a friction event for a snapshot.
 func (db *DB) InsertFrictionEvent(fe *FrictionEvent) error {
-	_, err := db.conn.Exec(
+	return insertFrictionEvent(db.conn, fe)
+}
+
+func insertFrictionEvent(e execer, fe *FrictionEvent) error {
+	_, err := e.Exec(
 		`INSERT INTO friction_events
 		(snapshot_id, session_id, friction_type, count, detail, project, session_date)
 		VALUES (?, ?, ?, ?, ?, ?, ?)`,
@@ -89,19 +211,36 @@ func (db *DB) InsertFrictionEvent(fe *FrictionEvent) error {
 
 // InsertSuggestion inserts a suggestion for a snapshot.
 func (db *DB) InsertSuggestion(s *Suggestion) error {
-	_, err := db.conn.Exec(
+	return insertSuggestion(db.conn, s)
+}
+
+// insertSuggestion stores s. A zero FirstSeen is carried forward from an
+// earlier copy of the suggestion, or else set to the snapshot's time.
+func insertSuggestion(e execer, s *Suggestion) error {
+	var firstSeen string
+	if !s.FirstSeen.IsZero() {
+		firstSeen = s.FirstSeen.UTC().Format(time.RFC3339)
+	}
+	_, err := e.Exec(
 		`INSERT INTO suggestions
-		(snapshot_id, category, priority, title, description, impact_score, status)
-		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		(snapshot_id, category, priority, title, description, impact_score, status, resolved_manually, first_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, COALESCE(NULLIF(?, ''),
+			(SELECT MIN(first_seen) FROM suggestions WHERE category = ? AND title = ? AND first_seen != ''),
+			(SELECT taken_at FROM snapshots WHERE id = ?), ''))`,
 		s.SnapshotID, s.Category, s.Priority, s.Title, s.Description,
-		s.ImpactScore, s.Status,
+		s.ImpactScore, s.Status, s.ResolvedManually,
+		firstSeen, s.Category, s.Title, s.SnapshotID,
 	)
 	return err
 }
 
 // InsertAgentTask inserts an agent task record for a snapshot.
 func (db *DB) InsertAgentTask(at *AgentTaskRow) error {
-	_, err := db.conn.Exec(
+	return insertAgentTask(db.conn, at)
+}
+
+func insertAgentTask(e execer, at *AgentTaskRow) error {
+	_, err := e.Exec(
 		`INSERT INTO agent_tasks
 		(snapshot_id, session_id, agent_id, agent_type, description, status,
 		 duration_ms, total_tokens, tool_uses, background, needed_correction, created_at)
@@ -128,7 +267,7 @@ func (db *DB) InsertCustomMetric(cm *CustomMetricRow) error {
 // GetAggregateMetrics returns all aggregate metrics for a snapshot.
 func (db *DB) GetAggregateMetrics(snapshotID int64) ([]AggregateMetric, error) {
 	rows, err := db.conn.Query(
-		"SELECT id, snapshot_id, metric_name, metric_value, detail FROM aggregate_metrics WHERE snapshot_id = ?",
+		"SELECT id, snapshot_id, metric_name, metric_value, unit, detail FROM aggregate_metrics WHERE snapshot_id = ?",
 		snapshotID,
 	)
 	if err != nil {
@@ -140,7 +279,7 @@ func (db *DB) GetAggregateMetrics(snapshotID int64) ([]AggregateMetric, error) {
 	for rows.Next() {
 		var m AggregateMetric
 		var detail sql.NullString
-		if err := rows.Scan(&m.ID, &m.SnapshotID, &m.MetricName, &m.MetricValue, &detail); err != nil {
+		if err := rows.Scan(&m.ID, &m.SnapshotID, &m.MetricName, &m.MetricValue, &m.Unit, &detail); err != nil {
 			return nil, err
 		}
 		m.Detail = detail.String
@@ -180,55 +319,264 @@ func (db *DB) GetProjectScores(snapshotID int64) ([]ProjectScore, error) {
 	return scores, rows.Err()
 }
 
+// suggestionColumns are the suggestion columns scanSuggestion reads, in
+// order.
+const suggestionColumns = "id, snapshot_id, category, priority, title, description, impact_score, status, resolved_manually, first_seen"
+
+func scanSuggestion(row interface{ Scan(...any) error }) (Suggestion, error) {
+	var s Suggestion
+	var firstSeen string
+	if err := row.Scan(&s.ID, &s.SnapshotID, &s.Category, &s.Priority, &s.Title,
+		&s.Description, &s.ImpactScore, &s.Status, &s.ResolvedManually, &firstSeen); err != nil {
+		return s, err
+	}
+	s.FirstSeen, _ = time.Parse(time.RFC3339, firstSeen)
+	return s, nil
+}
+
+// scanSuggestions reads every row of a suggestionColumns query and closes it.
+func scanSuggestions(rows *sql.Rows) ([]Suggestion, error) {
+	defer func() { _ = rows.Close() }()
+
+	var suggestions []Suggestion
+	for rows.Next() {
+		s, err := scanSuggestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, rows.Err()
+}
+
 // GetOpenSuggestions returns all suggestions with status "open".
 func (db *DB) GetOpenSuggestions() ([]Suggestion, error) {
 	rows, err := db.conn.Query(
-		`SELECT id, snapshot_id, category, priority, title, description, impact_score, status
-		 FROM suggestions WHERE status = 'open' ORDER BY impact_score DESC`,
+		"SELECT " + suggestionColumns + " FROM suggestions WHERE status = 'open' ORDER BY impact_score DESC",
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanSuggestions(rows)
+}
+
+// GetSnapshotSuggestions returns the suggestions recorded with a snapshot,
+// highest impact first.
+func (db *DB) GetSnapshotSuggestions(snapshotID int64) ([]Suggestion, error) {
+	rows, err := db.conn.Query(
+		"SELECT "+suggestionColumns+" FROM suggestions WHERE snapshot_id = ? ORDER BY impact_score DESC, id",
+		snapshotID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanSuggestions(rows)
+}
+
+// GetSuggestionSnapshots returns the snapshots that recorded suggestions,
+// oldest first. The others, such as track --only metrics or a
+// project-scoped run, neither raise nor clear any.
+func (db *DB) GetSuggestionSnapshots() ([]Snapshot, error) {
+	rows, err := db.conn.Query("SELECT " + snapshotColumns + " FROM snapshots ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := scanSnapshots(rows)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(snapshots, func(s Snapshot) bool { return !s.Has(SectionSuggestions) }), nil
+}
+
+// GetSuggestionHistory groups stored suggestions by category and title and
+// reports when each was first and last raised and whether it has since been
+// resolved, either explicitly or by no longer being raised, or expired.
+// Results are ordered by first appearance.
+func (db *DB) GetSuggestionHistory() ([]SuggestionHistory, error) {
+	// Snapshot times, oldest first, to date each suggestion and find the
+	// snapshot that stopped raising it.
+	snapshots, err := db.GetSuggestionSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	latestID := snapshots[len(snapshots)-1].ID
+	takenAt := func(id int64) time.Time {
+		i := sort.Search(len(snapshots), func(i int) bool { return snapshots[i].ID >= id })
+		if i < len(snapshots) && snapshots[i].ID == id {
+			return snapshots[i].TakenAt
+		}
+		return time.Time{}
+	}
+
+	rows, err := db.conn.Query(
+		`SELECT category, title, MIN(snapshot_id), MAX(snapshot_id), COUNT(DISTINCT snapshot_id),
+		 SUM(CASE WHEN snapshot_id = ? AND status = 'open' THEN 1 ELSE 0 END),
+		 (SELECT s2.status FROM suggestions s2 WHERE s2.category = suggestions.category
+		  AND s2.title = suggestions.title ORDER BY s2.id DESC LIMIT 1)
+		 FROM suggestions
+		 GROUP BY category, title
+		 ORDER BY MIN(snapshot_id), category, title`,
+		latestID,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = rows.Close() }()
 
-	var suggestions []Suggestion
+	var history []SuggestionHistory
 	for rows.Next() {
-		var s Suggestion
-		if err := rows.Scan(&s.ID, &s.SnapshotID, &s.Category, &s.Priority,
-			&s.Title, &s.Description, &s.ImpactScore, &s.Status); err != nil {
+		var h SuggestionHistory
+		var openInLatest int
+		var latestStatus string
+		if err := rows.Scan(&h.Category, &h.Title, &h.FirstSnapshotID, &h.LastSnapshotID,
+			&h.Snapshots, &openInLatest, &latestStatus); err != nil {
 			return nil, err
 		}
-		suggestions = append(suggestions, s)
+		h.FirstRaised = takenAt(h.FirstSnapshotID)
+		h.LastRaised = takenAt(h.LastSnapshotID)
+
+		switch {
+		case openInLatest > 0:
+			h.Status = "open"
+		case h.LastSnapshotID == latestID:
+			// Still raised, but the latest snapshot resolved it.
+			h.Status = "resolved"
+			resolved := h.LastRaised
+			h.ResolvedAt = &resolved
+		default:
+			h.Status = "resolved"
+			if latestStatus == "expired" {
+				h.Status = "expired"
+			}
+			i := sort.Search(len(snapshots), func(i int) bool { return snapshots[i].ID > h.LastSnapshotID })
+			resolved := snapshots[i].TakenAt
+			h.ResolvedAt = &resolved
+		}
+		history = append(history, h)
 	}
-	return suggestions, rows.Err()
+	return history, rows.Err()
 }
 
 // GetRecentSnapshots returns the N most recent snapshots, ordered newest first.
 func (db *DB) GetRecentSnapshots(n int) ([]Snapshot, error) {
 	rows, err := db.conn.Query(
-		"SELECT id, taken_at, command, version FROM snapshots ORDER BY id DESC LIMIT ?",
+		"SELECT "+snapshotColumns+" FROM snapshots ORDER BY taken_at DESC, id DESC LIMIT ?",
 		n,
 	)
 	if err != nil {
 		return nil, err
 	}
+	return scanSnapshots(rows)
+}
+
+// GetRecentProjectSnapshots returns the N most recent snapshots scoped to
+// project, ordered newest first. An empty project selects the snapshots of
+// every project.
+func (db *DB) GetRecentProjectSnapshots(project string, n int) ([]Snapshot, error) {
+	rows, err := db.conn.Query(
+		"SELECT "+snapshotColumns+" FROM snapshots WHERE project = ? ORDER BY taken_at DESC, id DESC LIMIT ?",
+		project, n,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanSnapshots(rows)
+}
+
+// scanSnapshots reads every row of a snapshotColumns query and closes it.
+func scanSnapshots(rows *sql.Rows) ([]Snapshot, error) {
 	defer func() { _ = rows.Close() }()
 
 	var snapshots []Snapshot
 	for rows.Next() {
-		var s Snapshot
-		var takenAt string
-		if err := rows.Scan(&s.ID, &takenAt, &s.Command, &s.Version); err != nil {
+		s, err := scanSnapshotFields(rows)
+		if err != nil {
 			return nil, err
 		}
-		s.TakenAt, _ = time.Parse(time.RFC3339, takenAt)
 		snapshots = append(snapshots, s)
 	}
 	return snapshots, rows.Err()
 }
 
+// GetSnapshotsSince returns the snapshots taken at or after since, ordered
+// oldest first. A zero since returns every snapshot.
+func (db *DB) GetSnapshotsSince(since time.Time) ([]Snapshot, error) {
+	rows, err := db.conn.Query(
+		"SELECT "+snapshotColumns+" FROM snapshots WHERE taken_at >= ? ORDER BY taken_at, id",
+		since.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanSnapshots(rows)
+}
+
 // ResolveSuggestion marks a suggestion as resolved.
 func (db *DB) ResolveSuggestion(id int64) error {
 	_, err := db.conn.Exec("UPDATE suggestions SET status = 'resolved' WHERE id = ?", id)
 	return err
 }
+
+// ExpireSuggestion marks every open suggestion with s's category and title
+// as expired.
+func (db *DB) ExpireSuggestion(s *Suggestion) error {
+	_, err := db.conn.Exec(
+		"UPDATE suggestions SET status = 'expired' WHERE category = ? AND title = ? AND status = 'open'",
+		s.Category, s.Title,
+	)
+	return err
+}
+
+// GetSuggestion returns the suggestion with the given ID, or nil if there is
+// none.
+func (db *DB) GetSuggestion(id int64) (*Suggestion, error) {
+	s, err := scanSuggestion(db.conn.QueryRow("SELECT "+suggestionColumns+" FROM suggestions WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ResolveSuggestionManually marks s, and every open suggestion with the same
+// category and title from other snapshots, as resolved by the user.
+func (db *DB) ResolveSuggestionManually(s *Suggestion) error {
+	_, err := db.conn.Exec(
+		`UPDATE suggestions SET status = 'resolved', resolved_manually = 1
+		 WHERE id = ? OR (category = ? AND title = ? AND status = 'open')`,
+		s.ID, s.Category, s.Title,
+	)
+	return err
+}
+
+// ReopenSuggestion marks s open again, along with every suggestion with the
+// same category and title that the user resolved, so later snapshots store
+// it as open.
+func (db *DB) ReopenSuggestion(s *Suggestion) error {
+	_, err := db.conn.Exec(
+		`UPDATE suggestions SET status = 'open', resolved_manually = 0
+		 WHERE id = ? OR (category = ? AND title = ? AND resolved_manually = 1)`,
+		s.ID, s.Category, s.Title,
+	)
+	return err
+}
+
+// SuggestionResolvedManually reports whether the most recently stored
+// suggestion with this category and title was resolved by the user.
+func (db *DB) SuggestionResolvedManually(category, title string) (bool, error) {
+	var manual bool
+	err := db.conn.QueryRow(
+		`SELECT resolved_manually FROM suggestions
+		 WHERE category = ? AND title = ? ORDER BY id DESC LIMIT 1`,
+		category, title,
+	).Scan(&manual)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return manual, err
+}
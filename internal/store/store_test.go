@@ -1,9 +1,13 @@
 package store_test
 
 import (
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/blackwell-systems/claudewatch/internal/guard"
 	"github.com/blackwell-systems/claudewatch/internal/store"
 )
 
@@ -342,3 +346,766 @@ func TestSearchTranscripts_DefaultLimit(t *testing.T) {
 	// Empty index, no results expected — just verifying no panic/error.
 	_ = results
 }
+
+// --- Suggestion history tests ---
+
+func TestGetSuggestionHistory(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if history, err := db.GetSuggestionHistory(); err != nil || len(history) != 0 {
+		t.Fatalf("empty db: got %v, %v", history, err)
+	}
+
+	// Three snapshots:
+	//   "Add CLAUDE.md" is raised in all three and still open.
+	//   "Fix friction" is raised in the first two, then no longer.
+	//   "Use agents" is raised only in the latest, which resolved it.
+	raise := map[int][]store.Suggestion{
+		1: {{Category: "configuration", Title: "Add CLAUDE.md"}, {Category: "friction", Title: "Fix friction"}},
+		2: {{Category: "configuration", Title: "Add CLAUDE.md"}, {Category: "friction", Title: "Fix friction"}},
+		3: {{Category: "configuration", Title: "Add CLAUDE.md"}, {Category: "agents", Title: "Use agents", Status: "resolved"}},
+	}
+	var snapshotIDs []int64
+	for n := 1; n <= 3; n++ {
+		id, err := db.CreateSnapshot("track", "test")
+		if err != nil {
+			t.Fatalf("CreateSnapshot: %v", err)
+		}
+		snapshotIDs = append(snapshotIDs, id)
+		for _, s := range raise[n] {
+			s.SnapshotID = id
+			if s.Status == "" {
+				s.Status = "open"
+			}
+			s.Description = "d"
+			if err := db.InsertSuggestion(&s); err != nil {
+				t.Fatalf("InsertSuggestion: %v", err)
+			}
+		}
+	}
+
+	history, err := db.GetSuggestionHistory()
+	if err != nil {
+		t.Fatalf("GetSuggestionHistory: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 suggestions, got %+v", history)
+	}
+	byTitle := make(map[string]store.SuggestionHistory)
+	for _, h := range history {
+		byTitle[h.Title] = h
+	}
+
+	claudeMD := byTitle["Add CLAUDE.md"]
+	if claudeMD.Status != "open" || claudeMD.ResolvedAt != nil || claudeMD.Snapshots != 3 {
+		t.Errorf("Add CLAUDE.md = %+v, want open across 3 snapshots", claudeMD)
+	}
+	if claudeMD.FirstSnapshotID != snapshotIDs[0] || claudeMD.LastSnapshotID != snapshotIDs[2] {
+		t.Errorf("Add CLAUDE.md snapshots %d..%d, want %d..%d",
+			claudeMD.FirstSnapshotID, claudeMD.LastSnapshotID, snapshotIDs[0], snapshotIDs[2])
+	}
+
+	friction := byTitle["Fix friction"]
+	if friction.Status != "resolved" || friction.ResolvedAt == nil || friction.Snapshots != 2 {
+		t.Errorf("Fix friction = %+v, want resolved after 2 snapshots", friction)
+	}
+	if friction.LastSnapshotID != snapshotIDs[1] {
+		t.Errorf("Fix friction last raised in %d, want %d", friction.LastSnapshotID, snapshotIDs[1])
+	}
+
+	agents := byTitle["Use agents"]
+	if agents.Status != "resolved" || agents.ResolvedAt == nil || !agents.ResolvedAt.Equal(agents.LastRaised) {
+		t.Errorf("Use agents = %+v, want resolved in the latest snapshot", agents)
+	}
+
+	// Ordered by first appearance.
+	if history[2].Title != "Use agents" {
+		t.Errorf("expected Use agents last, got %q", history[2].Title)
+	}
+
+	// A later snapshot that didn't record suggestions changes nothing.
+	if _, err := db.CreateProjectSnapshot("track", "test", "/code/api", store.SectionMetrics); err != nil {
+		t.Fatalf("CreateProjectSnapshot: %v", err)
+	}
+	after, err := db.GetSuggestionHistory()
+	if err != nil {
+		t.Fatalf("GetSuggestionHistory: %v", err)
+	}
+	for i := range after {
+		if after[i].Status != history[i].Status {
+			t.Errorf("%s: status %q after a snapshot without suggestions, want %q", after[i].Title, after[i].Status, history[i].Status)
+		}
+	}
+}
+
+func TestAggregateMetricUnits(t *testing.T) {
+	path := t.TempDir() + "/claudewatch.db"
+	db, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+
+	id, err := db.CreateSnapshot("track", "test")
+	if err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+	if err := db.InsertAggregateMetric(id, "avg_duration_minutes", 42, "minutes"); err != nil {
+		t.Fatalf("InsertAggregateMetric: %v", err)
+	}
+	if err := db.InsertAggregateMetric(id, "custom", 1, ""); err != nil {
+		t.Fatalf("InsertAggregateMetric: %v", err)
+	}
+	_ = db.Close()
+
+	// Reopening runs Migrate again against an up-to-date schema.
+	db, err = store.Open(path)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	metrics, err := db.GetAggregateMetrics(id)
+	if err != nil {
+		t.Fatalf("GetAggregateMetrics: %v", err)
+	}
+	units := make(map[string]string)
+	for _, m := range metrics {
+		units[m.MetricName] = m.Unit
+	}
+	if len(units) != 2 || units["avg_duration_minutes"] != "minutes" || units["custom"] != "" {
+		t.Errorf("units = %v, want avg_duration_minutes=minutes and custom empty", units)
+	}
+}
+
+func TestResolveAndReopenSuggestion(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	// The same suggestion stored by two snapshots, plus an unrelated one.
+	var ids []int64
+	for n := 0; n < 2; n++ {
+		snapID, err := db.CreateSnapshot("track", "test")
+		if err != nil {
+			t.Fatalf("CreateSnapshot: %v", err)
+		}
+		for _, title := range []string{"Add hooks", "Use agents"} {
+			s := store.Suggestion{SnapshotID: snapID, Category: "adoption", Title: title, Description: "d", Status: "open"}
+			if err := db.InsertSuggestion(&s); err != nil {
+				t.Fatalf("InsertSuggestion: %v", err)
+			}
+		}
+	}
+	open, err := db.GetOpenSuggestions()
+	if err != nil {
+		t.Fatalf("GetOpenSuggestions: %v", err)
+	}
+	for _, s := range open {
+		if s.Title == "Add hooks" {
+			ids = append(ids, s.ID)
+		}
+	}
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 stored copies of Add hooks, got %v", ids)
+	}
+
+	if s, err := db.GetSuggestion(999); err != nil || s != nil {
+		t.Errorf("GetSuggestion(999) = %v, %v; want nil, nil", s, err)
+	}
+	s, err := db.GetSuggestion(ids[0])
+	if err != nil || s == nil {
+		t.Fatalf("GetSuggestion(%d) = %v, %v", ids[0], s, err)
+	}
+
+	if err := db.ResolveSuggestionManually(s); err != nil {
+		t.Fatalf("ResolveSuggestionManually: %v", err)
+	}
+	open, err = db.GetOpenSuggestions()
+	if err != nil {
+		t.Fatalf("GetOpenSuggestions: %v", err)
+	}
+	for _, o := range open {
+		if o.Title == "Add hooks" {
+			t.Errorf("expected every copy of Add hooks to be resolved, found open #%d", o.ID)
+		}
+	}
+	if len(open) != 2 {
+		t.Errorf("expected the 2 Use agents copies to stay open, got %d open", len(open))
+	}
+	if manual, err := db.SuggestionResolvedManually("adoption", "Add hooks"); err != nil || !manual {
+		t.Errorf("SuggestionResolvedManually after resolve = %v, %v; want true", manual, err)
+	}
+	if manual, err := db.SuggestionResolvedManually("adoption", "Use agents"); err != nil || manual {
+		t.Errorf("SuggestionResolvedManually(Use agents) = %v, %v; want false", manual, err)
+	}
+	if manual, err := db.SuggestionResolvedManually("adoption", "Never stored"); err != nil || manual {
+		t.Errorf("SuggestionResolvedManually(unknown) = %v, %v; want false", manual, err)
+	}
+
+	if err := db.ReopenSuggestion(s); err != nil {
+		t.Fatalf("ReopenSuggestion: %v", err)
+	}
+	if manual, err := db.SuggestionResolvedManually("adoption", "Add hooks"); err != nil || manual {
+		t.Errorf("SuggestionResolvedManually after reopen = %v, %v; want false", manual, err)
+	}
+	reopened, err := db.GetSuggestion(ids[1])
+	if err != nil || reopened == nil || reopened.Status != "open" || reopened.ResolvedManually {
+		t.Errorf("GetSuggestion(%d) after reopen = %+v, %v; want open", ids[1], reopened, err)
+	}
+}
+
+func TestSessionNotesAndTags(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if notes, err := db.GetSessionNotes("s1"); err != nil || len(notes) != 0 {
+		t.Fatalf("empty db: got %v, %v", notes, err)
+	}
+
+	if err := db.AddSessionNote("s1", "auth bug introduced here"); err != nil {
+		t.Fatalf("AddSessionNote: %v", err)
+	}
+	if added, err := db.AddSessionTag("s1", "bug"); err != nil || !added {
+		t.Fatalf("AddSessionTag = %v, %v; want true", added, err)
+	}
+	if added, err := db.AddSessionTag("s1", "bug"); err != nil || added {
+		t.Errorf("AddSessionTag again = %v, %v; want false", added, err)
+	}
+	if _, err := db.AddSessionTag("s2", "bug"); err != nil {
+		t.Fatalf("AddSessionTag: %v", err)
+	}
+	if _, err := db.AddSessionTag("s3", "refactor"); err != nil {
+		t.Fatalf("AddSessionTag: %v", err)
+	}
+
+	notes, err := db.GetSessionNotes("s1")
+	if err != nil {
+		t.Fatalf("GetSessionNotes: %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("expected a note and a tag, got %+v", notes)
+	}
+	if notes[0].Kind != store.SessionNoteKindNote || notes[0].Text != "auth bug introduced here" || notes[0].CreatedAt.IsZero() {
+		t.Errorf("notes[0] = %+v, want the note", notes[0])
+	}
+	if notes[1].Kind != store.SessionNoteKindTag || notes[1].Text != "bug" {
+		t.Errorf("notes[1] = %+v, want the bug tag", notes[1])
+	}
+
+	ids, err := db.SessionIDsWithTag("bug")
+	if err != nil {
+		t.Fatalf("SessionIDsWithTag: %v", err)
+	}
+	if len(ids) != 2 || !ids["s1"] || !ids["s2"] {
+		t.Errorf("SessionIDsWithTag(bug) = %v, want s1 and s2", ids)
+	}
+}
+
+func TestGetSnapshotsSince(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		id, err := db.CreateSnapshot("track", "test")
+		if err != nil {
+			t.Fatalf("CreateSnapshot: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	all, err := db.GetSnapshotsSince(time.Time{})
+	if err != nil {
+		t.Fatalf("GetSnapshotsSince: %v", err)
+	}
+	if len(all) != 3 || all[0].ID != ids[0] || all[2].ID != ids[2] {
+		t.Errorf("expected snapshots %v oldest first, got %+v", ids, all)
+	}
+
+	recent, err := db.GetSnapshotsSince(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetSnapshotsSince: %v", err)
+	}
+	if len(recent) != 3 {
+		t.Errorf("expected 3 snapshots in the last hour, got %d", len(recent))
+	}
+
+	future, err := db.GetSnapshotsSince(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetSnapshotsSince: %v", err)
+	}
+	if len(future) != 0 {
+		t.Errorf("expected no snapshots after now, got %d", len(future))
+	}
+}
+
+func TestOpen_ReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "claudewatch.db")
+	db, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := db.CreateSnapshot("track", "test"); err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+	_ = db.Close()
+
+	guard.SetReadOnly(true)
+	defer guard.SetReadOnly(false)
+
+	if _, err := store.Open(filepath.Join(t.TempDir(), "missing.db")); !errors.Is(err, guard.ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly opening a missing database, got %v", err)
+	}
+
+	db, err = store.Open(path)
+	if err != nil {
+		t.Fatalf("Open read-only: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	if snap, err := db.GetLatestSnapshot(); err != nil || snap == nil {
+		t.Fatalf("expected reads to work read-only, got %v, %v", snap, err)
+	}
+	_, err = db.CreateSnapshot("track", "test")
+	if !store.IsReadOnlyError(err) {
+		t.Errorf("expected a read-only error writing, got %v", err)
+	}
+}
+
+func TestOpen_ReadOnlyOldSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "claudewatch.db")
+	db, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := db.Conn().Exec("UPDATE schema_version SET version = 8"); err != nil {
+		t.Fatalf("downgrading schema_version: %v", err)
+	}
+	_ = db.Close()
+
+	guard.SetReadOnly(true)
+	defer guard.SetReadOnly(false)
+
+	_, err = store.Open(path)
+	if !errors.Is(err, guard.ErrReadOnly) || !strings.Contains(err.Error(), "without --read-only") {
+		t.Errorf("expected an error asking to migrate without --read-only, got %v", err)
+	}
+}
+
+// --- Snapshot section tests ---
+
+func TestCreateSnapshot_Sections(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	allID, err := db.CreateSnapshot("track", "v1.0.0")
+	if err != nil {
+		t.Fatalf("CreateSnapshot() failed: %v", err)
+	}
+	partialID, err := db.CreateSnapshot("track", "v1.0.0", store.SectionMetrics, store.SectionFriction)
+	if err != nil {
+		t.Fatalf("CreateSnapshot() failed: %v", err)
+	}
+
+	all, err := db.GetSnapshot(allID)
+	if err != nil {
+		t.Fatalf("GetSnapshot() failed: %v", err)
+	}
+	if len(all.Sections) != len(store.SnapshotSections) {
+		t.Errorf("snapshot without sections: Sections = %v, want all of %v", all.Sections, store.SnapshotSections)
+	}
+
+	partial, err := db.GetSnapshot(partialID)
+	if err != nil {
+		t.Fatalf("GetSnapshot() failed: %v", err)
+	}
+	if !partial.Has(store.SectionMetrics) || !partial.Has(store.SectionFriction) {
+		t.Errorf("Sections = %v, want metrics and friction", partial.Sections)
+	}
+	if partial.Has(store.SectionScores) || partial.Has(store.SectionSuggestions) {
+		t.Errorf("Sections = %v, want only metrics and friction", partial.Sections)
+	}
+
+	recent, err := db.GetRecentSnapshots(2)
+	if err != nil {
+		t.Fatalf("GetRecentSnapshots() failed: %v", err)
+	}
+	if len(recent) != 2 || len(recent[0].Sections) != 2 {
+		t.Errorf("GetRecentSnapshots() = %+v, want the partial snapshot first with 2 sections", recent)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	src, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	// Burn an ID so the source snapshot collides with one in the target.
+	if _, err := src.CreateSnapshot("scan", "v0.9.0"); err != nil {
+		t.Fatalf("CreateSnapshot() failed: %v", err)
+	}
+	snapID, err := src.CreateSnapshot("track", "v1.0.0", store.SectionScores, store.SectionMetrics, store.SectionSuggestions)
+	if err != nil {
+		t.Fatalf("CreateSnapshot() failed: %v", err)
+	}
+	if err := src.InsertProjectScore(&store.ProjectScore{SnapshotID: snapID, Project: "alpha", Score: 72, SessionCount: 4}); err != nil {
+		t.Fatalf("InsertProjectScore() failed: %v", err)
+	}
+	if err := src.InsertAggregateMetric(snapID, "cost_per_session", 1.5, "usd"); err != nil {
+		t.Fatalf("InsertAggregateMetric() failed: %v", err)
+	}
+	if err := src.InsertSuggestion(&store.Suggestion{SnapshotID: snapID, Category: "configuration", Priority: 1, Title: "Add CLAUDE.md", Status: "open"}); err != nil {
+		t.Fatalf("InsertSuggestion() failed: %v", err)
+	}
+	if err := src.AddSessionNote("sess-1", "flaky tests"); err != nil {
+		t.Fatalf("AddSessionNote() failed: %v", err)
+	}
+	if _, err := src.AddSessionTag("sess-1", "refactor"); err != nil {
+		t.Fatalf("AddSessionTag() failed: %v", err)
+	}
+
+	export, err := src.Export()
+	if err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+	if export.Version != store.ExportVersion || len(export.Snapshots) != 2 {
+		t.Fatalf("Export() = version %d with %d snapshots, want version %d with 2", export.Version, len(export.Snapshots), store.ExportVersion)
+	}
+	if len(export.SessionNotes) != 2 {
+		t.Fatalf("Export() session notes = %+v, want the note and the tag", export.SessionNotes)
+	}
+
+	dst, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = dst.Close() }()
+	existing, err := dst.CreateSnapshot("track", "v0.1.0")
+	if err != nil {
+		t.Fatalf("CreateSnapshot() failed: %v", err)
+	}
+	if _, err := dst.CreateSnapshot("track", "v0.1.0"); err != nil {
+		t.Fatalf("CreateSnapshot() failed: %v", err)
+	}
+
+	result, err := dst.Import(export)
+	if err != nil {
+		t.Fatalf("Import() failed: %v", err)
+	}
+	if result.Imported != 2 || result.Skipped != 0 || result.SessionNotes != 2 {
+		t.Fatalf("Import() = %+v, want 2 imported, 0 skipped, 2 session notes", result)
+	}
+	newID := result.IDs[snapID]
+	if newID == snapID || newID <= existing {
+		t.Fatalf("imported snapshot #%d got ID %d, want a fresh ID", snapID, newID)
+	}
+
+	snap, err := dst.GetSnapshot(newID)
+	if err != nil {
+		t.Fatalf("GetSnapshot() failed: %v", err)
+	}
+	if snap.Command != "track" || snap.Version != "v1.0.0" || snap.Has(store.SectionFriction) || !snap.Has(store.SectionMetrics) {
+		t.Errorf("imported snapshot = %+v, want track v1.0.0 with its sections", snap)
+	}
+	scores, err := dst.GetProjectScores(newID)
+	if err != nil {
+		t.Fatalf("GetProjectScores() failed: %v", err)
+	}
+	if len(scores) != 1 || scores[0].Project != "alpha" || scores[0].Score != 72 {
+		t.Errorf("imported project scores = %+v, want alpha scoring 72", scores)
+	}
+	metrics, err := dst.GetAggregateMetrics(newID)
+	if err != nil {
+		t.Fatalf("GetAggregateMetrics() failed: %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].MetricValue != 1.5 || metrics[0].Unit != "usd" {
+		t.Errorf("imported metrics = %+v, want cost_per_session 1.5 usd", metrics)
+	}
+	if scores, _ := dst.GetProjectScores(existing); len(scores) != 0 {
+		t.Errorf("existing snapshot #%d gained project scores %+v", existing, scores)
+	}
+	notes, err := dst.GetSessionNotes("sess-1")
+	if err != nil {
+		t.Fatalf("GetSessionNotes() failed: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Text != "flaky tests" || notes[1].Kind != store.SessionNoteKindTag {
+		t.Errorf("imported session notes = %+v, want the note and the tag", notes)
+	}
+
+	// Importing the same document again adds nothing.
+	again, err := dst.Import(export)
+	if err != nil {
+		t.Fatalf("second Import() failed: %v", err)
+	}
+	if again.Imported != 0 || again.Skipped != 2 || again.SessionNotes != 0 {
+		t.Errorf("second Import() = %+v, want 0 imported, 2 skipped, no session notes", again)
+	}
+}
+
+func TestImport_Version(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	for _, version := range []int{0, store.ExportVersion + 1} {
+		if _, err := db.Import(&store.Export{Version: version}); err == nil {
+			t.Errorf("Import() of version %d succeeded, want an error", version)
+		}
+	}
+}
+
+func TestCreateProjectSnapshot(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	allID, err := db.CreateSnapshot("track", "v1.0.0")
+	if err != nil {
+		t.Fatalf("CreateSnapshot() failed: %v", err)
+	}
+	apiID, err := db.CreateProjectSnapshot("track", "v1.0.0", "/code/api")
+	if err != nil {
+		t.Fatalf("CreateProjectSnapshot() failed: %v", err)
+	}
+
+	api, err := db.GetSnapshot(apiID)
+	if err != nil {
+		t.Fatalf("GetSnapshot() failed: %v", err)
+	}
+	if api.Project != "/code/api" {
+		t.Errorf("Project = %q, want /code/api", api.Project)
+	}
+
+	for _, tt := range []struct {
+		project string
+		want    int64
+	}{
+		{"", allID},
+		{"/code/api", apiID},
+	} {
+		got, err := db.GetRecentProjectSnapshots(tt.project, 10)
+		if err != nil {
+			t.Fatalf("GetRecentProjectSnapshots(%q) failed: %v", tt.project, err)
+		}
+		if len(got) != 1 || got[0].ID != tt.want {
+			t.Errorf("GetRecentProjectSnapshots(%q) = %+v, want only snapshot #%d", tt.project, got, tt.want)
+		}
+	}
+}
+
+func TestGetSnapshotSuggestions(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	first, _ := db.CreateSnapshot("track", "test")
+	second, _ := db.CreateSnapshot("track", "test")
+	for _, s := range []store.Suggestion{
+		{SnapshotID: first, Title: "Older", ImpactScore: 9, Status: "open"},
+		{SnapshotID: second, Title: "Low", ImpactScore: 1, Status: "open"},
+		{SnapshotID: second, Title: "High", ImpactScore: 7.5, Status: "open"},
+	} {
+		if err := db.InsertSuggestion(&s); err != nil {
+			t.Fatalf("InsertSuggestion: %v", err)
+		}
+	}
+
+	got, err := db.GetSnapshotSuggestions(second)
+	if err != nil {
+		t.Fatalf("GetSnapshotSuggestions: %v", err)
+	}
+	if len(got) != 2 || got[0].Title != "High" || got[1].Title != "Low" {
+		t.Errorf("GetSnapshotSuggestions = %+v, want High then Low", got)
+	}
+	if got, _ := db.GetSnapshotSuggestions(999); len(got) != 0 {
+		t.Errorf("GetSnapshotSuggestions(999) = %+v, want none", got)
+	}
+}
+
+func TestExpireSuggestion(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	// "Add hooks" is raised by the first two snapshots, "Use agents" by all
+	// three.
+	var first store.Snapshot
+	for n := 0; n < 3; n++ {
+		snapID, err := db.CreateSnapshot("track", "test")
+		if err != nil {
+			t.Fatalf("CreateSnapshot: %v", err)
+		}
+		if n == 0 {
+			s, err := db.GetSnapshot(snapID)
+			if err != nil {
+				t.Fatalf("GetSnapshot: %v", err)
+			}
+			first = *s
+		}
+		titles := []string{"Add hooks", "Use agents"}
+		if n == 2 {
+			titles = titles[1:]
+		}
+		for _, title := range titles {
+			s := store.Suggestion{SnapshotID: snapID, Category: "adoption", Title: title, Description: "d", Status: "open"}
+			if err := db.InsertSuggestion(&s); err != nil {
+				t.Fatalf("InsertSuggestion: %v", err)
+			}
+		}
+	}
+
+	open, err := db.GetOpenSuggestions()
+	if err != nil {
+		t.Fatalf("GetOpenSuggestions: %v", err)
+	}
+	for _, s := range open {
+		if !s.FirstSeen.Equal(first.TakenAt) {
+			t.Errorf("%s #%d FirstSeen = %v, want the first snapshot's %v", s.Title, s.ID, s.FirstSeen, first.TakenAt)
+		}
+	}
+
+	if err := db.ExpireSuggestion(&store.Suggestion{Category: "adoption", Title: "Add hooks"}); err != nil {
+		t.Fatalf("ExpireSuggestion: %v", err)
+	}
+	open, err = db.GetOpenSuggestions()
+	if err != nil {
+		t.Fatalf("GetOpenSuggestions: %v", err)
+	}
+	if len(open) != 3 {
+		t.Errorf("expected only the 3 Use agents copies to stay open, got %d", len(open))
+	}
+
+	history, err := db.GetSuggestionHistory()
+	if err != nil {
+		t.Fatalf("GetSuggestionHistory: %v", err)
+	}
+	for _, h := range history {
+		want := "open"
+		if h.Title == "Add hooks" {
+			want = "expired"
+		}
+		if h.Status != want {
+			t.Errorf("%s status = %q, want %q", h.Title, h.Status, want)
+		}
+	}
+}
+
+func TestRecordSnapshot(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	id, err := db.RecordSnapshot(store.SnapshotRecord{
+		Command:          "track",
+		Version:          "v1.0.0",
+		Project:          "/code/api",
+		ProjectScores:    []store.ProjectScore{{Project: "/code/api", Score: 80}},
+		AggregateMetrics: []store.AggregateMetric{{MetricName: "total_sessions", MetricValue: 12, Unit: "sessions"}},
+		FrictionEvents:   []store.FrictionEvent{{SessionID: "s1", FrictionType: "wrong_approach", Count: 2}},
+		AgentTasks:       []store.AgentTaskRow{{SessionID: "s1", AgentID: "a1", AgentType: "Explore", Status: "completed"}},
+		Suggestions:      []store.Suggestion{{Category: "workflow", Title: "Commit more", Status: "open"}},
+	})
+	if err != nil {
+		t.Fatalf("RecordSnapshot() failed: %v", err)
+	}
+
+	snap, err := db.GetSnapshot(id)
+	if err != nil || snap == nil {
+		t.Fatalf("GetSnapshot(%d) = %v, %v", id, snap, err)
+	}
+	if snap.Project != "/code/api" || snap.Command != "track" {
+		t.Errorf("snapshot = %+v, want a track snapshot of /code/api", snap)
+	}
+	metrics, err := db.GetAggregateMetrics(id)
+	if err != nil {
+		t.Fatalf("GetAggregateMetrics() failed: %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].MetricValue != 12 || metrics[0].SnapshotID != id {
+		t.Errorf("metrics = %+v, want total_sessions 12 in snapshot #%d", metrics, id)
+	}
+	for table, want := range map[string]int{"project_scores": 1, "friction_events": 1, "agent_tasks": 1, "suggestions": 1} {
+		if got := countRows(t, db, table); got != want {
+			t.Errorf("%s has %d rows, want %d", table, got, want)
+		}
+	}
+}
+
+func TestRecordSnapshot_RollsBackOnFailure(t *testing.T) {
+	db, err := store.OpenInMemory()
+	if err != nil {
+		t.Fatalf("OpenInMemory() failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	// Fail the second friction event, after the snapshot, its scores,
+	// metrics, and first friction event are inserted.
+	if _, err := db.Conn().Exec(`CREATE TRIGGER fail_friction BEFORE INSERT ON friction_events
+		WHEN NEW.friction_type = 'boom' BEGIN SELECT RAISE(ABORT, 'injected failure'); END`); err != nil {
+		t.Fatalf("creating trigger: %v", err)
+	}
+
+	_, err = db.RecordSnapshot(store.SnapshotRecord{
+		Command:          "track",
+		Version:          "v1.0.0",
+		ProjectScores:    []store.ProjectScore{{Project: "/code/api", Score: 80}},
+		AggregateMetrics: []store.AggregateMetric{{MetricName: "total_sessions", MetricValue: 12}},
+		FrictionEvents: []store.FrictionEvent{
+			{SessionID: "s1", FrictionType: "wrong_approach", Count: 2},
+			{SessionID: "s2", FrictionType: "boom", Count: 1},
+		},
+		AgentTasks:  []store.AgentTaskRow{{SessionID: "s1", AgentID: "a1", AgentType: "Explore", Status: "completed"}},
+		Suggestions: []store.Suggestion{{Category: "workflow", Title: "Commit more", Status: "open"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "injected failure") {
+		t.Fatalf("RecordSnapshot() error = %v, want the injected failure", err)
+	}
+
+	latest, err := db.GetLatestSnapshot()
+	if err != nil {
+		t.Fatalf("GetLatestSnapshot() failed: %v", err)
+	}
+	if latest != nil {
+		t.Errorf("GetLatestSnapshot() = %+v, want no snapshot after the rollback", latest)
+	}
+	for _, table := range []string{"snapshots", "project_scores", "aggregate_metrics", "friction_events", "agent_tasks", "suggestions"} {
+		if got := countRows(t, db, table); got != 0 {
+			t.Errorf("%s has %d rows after the rollback, want 0", table, got)
+		}
+	}
+}
+
+func countRows(t *testing.T, db *store.DB, table string) int {
+	t.Helper()
+	var n int
+	if err := db.Conn().QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		t.Fatalf("counting %s: %v", table, err)
+	}
+	return n
+}
@@ -1,7 +1,10 @@
 // Package store provides SQLite database access for claudewatch metrics and snapshots.
 package store
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // Snapshot represents a point-in-time capture of all metrics.
 type Snapshot struct {
@@ -9,6 +12,28 @@ type Snapshot struct {
 	TakenAt time.Time `json:"taken_at"`
 	Command string    `json:"command"`
 	Version string    `json:"version"`
+	// Sections lists the SnapshotSections this snapshot recorded.
+	Sections []string `json:"sections"`
+	// Project is the path of the one project a scoped snapshot covers, or
+	// empty for a snapshot of everything.
+	Project string `json:"project,omitempty"`
+}
+
+// Snapshot sections: the parts of a track run a snapshot can record.
+const (
+	SectionScores      = "scores"
+	SectionMetrics     = "metrics"
+	SectionFriction    = "friction"
+	SectionAgents      = "agents"
+	SectionSuggestions = "suggestions"
+)
+
+// SnapshotSections lists every snapshot section, in recording order.
+var SnapshotSections = []string{SectionScores, SectionMetrics, SectionFriction, SectionAgents, SectionSuggestions}
+
+// Has reports whether the snapshot recorded section.
+func (s Snapshot) Has(section string) bool {
+	return slices.Contains(s.Sections, section)
 }
 
 // ProjectScore represents a project's readiness score within a snapshot.
@@ -32,6 +57,7 @@ type AggregateMetric struct {
 	SnapshotID  int64   `json:"snapshot_id"`
 	MetricName  string  `json:"metric_name"`
 	MetricValue float64 `json:"metric_value"`
+	Unit        string  `json:"unit,omitempty"`
 	Detail      string  `json:"detail,omitempty"`
 }
 
@@ -56,7 +82,35 @@ type Suggestion struct {
 	Title       string  `json:"title"`
 	Description string  `json:"description"`
 	ImpactScore float64 `json:"impact_score"`
-	Status      string  `json:"status"`
+	// Status is "open", "resolved", or "expired" once it stayed open past
+	// the configured suggestion_ttl without being raised again.
+	Status string `json:"status"`
+	// ResolvedManually is set when the user resolved the suggestion, rather
+	// than track finding its trigger condition cleared.
+	ResolvedManually bool `json:"resolved_manually,omitempty"`
+	// FirstSeen is when a snapshot first stored a suggestion with this
+	// category and title. Later copies carry it forward.
+	FirstSeen time.Time `json:"first_seen"`
+}
+
+// SuggestionHistory follows one suggestion, identified by category and
+// title, across the snapshots that raised it.
+type SuggestionHistory struct {
+	Category        string    `json:"category"`
+	Title           string    `json:"title"`
+	FirstSnapshotID int64     `json:"first_snapshot_id"`
+	LastSnapshotID  int64     `json:"last_snapshot_id"`
+	FirstRaised     time.Time `json:"first_raised"`
+	LastRaised      time.Time `json:"last_raised"`
+	// Snapshots is the number of snapshots that raised the suggestion.
+	Snapshots int `json:"snapshots"`
+	// Status is "open" while the latest snapshot still raises it and has not
+	// resolved it, "expired" when track closed it after it went unraised
+	// past the suggestion_ttl, and "resolved" otherwise.
+	Status string `json:"status"`
+	// ResolvedAt is when the first snapshot after LastRaised was taken, or
+	// LastRaised when the latest snapshot resolved it. Nil while open.
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
 }
 
 // AgentTaskRow represents an agent task record in the database.
@@ -108,6 +162,7 @@ type MetricDelta struct {
 	Previous  float64 `json:"previous"`
 	Current   float64 `json:"current"`
 	Delta     float64 `json:"delta"`
+	Unit      string  `json:"unit,omitempty"`
 	Direction string  `json:"direction"` // "improved", "regressed", "unchanged"
 }
 
@@ -0,0 +1,385 @@
+package suggest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultCustomImpact is the impact score of custom suggestions whose rule
+// sets none.
+const DefaultCustomImpact = 1.0
+
+// customOps maps each comparison operator a custom rule may use to its test.
+var customOps = map[string]func(v, threshold float64) bool{
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// validOps lists customOps in the order shown in error messages.
+const validOps = "<, <=, >, >=, ==, !="
+
+// CustomRule is a declarative suggestion rule loaded from a rules file. It
+// fires when the numeric value at Metric compares true against Threshold.
+//
+// Metric is a dotted path of AnalysisContext JSON field names, such as
+// "avg_tool_errors" or "agent_type_stats.Explore". Paths starting with
+// "projects." are evaluated once per project, e.g. "projects.tool_errors".
+// Booleans count as 1 and 0.
+type CustomRule struct {
+	Name        string
+	Metric      string
+	Op          string
+	Threshold   float64
+	Category    string
+	Priority    int
+	Impact      float64
+	Title       *template.Template
+	Description *template.Template
+	// Line is the rule's line in the rules file.
+	Line int
+}
+
+// CustomRuleData is the data passed to a custom rule's title and
+// description templates.
+type CustomRuleData struct {
+	Metric    string
+	Value     float64
+	Threshold float64
+	// Project is the project name for "projects." metrics, and "" otherwise.
+	Project string
+}
+
+// customRuleSpec is the YAML form of a CustomRule.
+type customRuleSpec struct {
+	Name        string   `yaml:"name"`
+	Metric      string   `yaml:"metric"`
+	Op          string   `yaml:"op"`
+	Threshold   *float64 `yaml:"threshold"`
+	Category    string   `yaml:"category"`
+	Priority    int      `yaml:"priority"`
+	Impact      *float64 `yaml:"impact"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+}
+
+// LoadCustomRules reads custom rules from the YAML file at path, as
+// ParseCustomRules does. A missing file yields no rules and no error.
+func LoadCustomRules(path string) ([]CustomRule, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseCustomRules(data, path)
+}
+
+// ParseCustomRules parses a rules document of the form
+//
+//	rules:
+//	  - name: slow-agents
+//	    metric: agent_success_rate
+//	    op: "<"
+//	    threshold: 0.7
+//	    title: "Agent success is {{printf \"%.0f%%\" (mul .Value 100)}}"
+//
+// The valid rules are returned along with a single joined error reporting
+// every invalid one, each prefixed with source and its line number.
+func ParseCustomRules(data []byte, source string) ([]CustomRule, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil // empty file
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d: expected a mapping with a rules list", source, root.Line)
+	}
+
+	var rulesNode *yaml.Node
+	var errs []error
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value != "rules" {
+			errs = append(errs, fmt.Errorf("%s:%d: unknown key %q (valid: rules)", source, key.Line, key.Value))
+			continue
+		}
+		rulesNode = value
+	}
+	if rulesNode == nil {
+		return nil, errors.Join(errs...)
+	}
+	if rulesNode.Kind != yaml.SequenceNode {
+		errs = append(errs, fmt.Errorf("%s:%d: rules must be a list", source, rulesNode.Line))
+		return nil, errors.Join(errs...)
+	}
+
+	var rules []CustomRule
+	names := make(map[string]int)
+	for _, node := range rulesNode.Content {
+		rule, err := parseCustomRule(node)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s:%d: %w", source, node.Line, err))
+			continue
+		}
+		if prev, ok := names[rule.Name]; ok {
+			errs = append(errs, fmt.Errorf("%s:%d: rule %q is already defined on line %d", source, node.Line, rule.Name, prev))
+			continue
+		}
+		names[rule.Name] = node.Line
+		rules = append(rules, rule)
+	}
+	return rules, errors.Join(errs...)
+}
+
+// parseCustomRule validates one rule node.
+func parseCustomRule(node *yaml.Node) (CustomRule, error) {
+	if node.Kind != yaml.MappingNode {
+		return CustomRule{}, errors.New("rule must be a mapping")
+	}
+	var spec customRuleSpec
+	if err := node.Decode(&spec); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			return CustomRule{}, errors.New(strings.Join(typeErr.Errors, "; "))
+		}
+		return CustomRule{}, err
+	}
+	known := map[string]bool{
+		"name": true, "metric": true, "op": true, "threshold": true, "category": true,
+		"priority": true, "impact": true, "title": true, "description": true,
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if key := node.Content[i].Value; !known[key] {
+			return CustomRule{}, fmt.Errorf("unknown field %q", key)
+		}
+	}
+
+	if spec.Name == "" {
+		return CustomRule{}, errors.New("rule has no name")
+	}
+	rule := CustomRule{
+		Name:     spec.Name,
+		Metric:   spec.Metric,
+		Op:       spec.Op,
+		Category: spec.Category,
+		Priority: spec.Priority,
+		Impact:   DefaultCustomImpact,
+		Line:     node.Line,
+	}
+	fail := func(format string, args ...any) (CustomRule, error) {
+		return CustomRule{}, fmt.Errorf("rule %q: "+format, append([]any{spec.Name}, args...)...)
+	}
+
+	if spec.Metric == "" {
+		return fail("metric is required")
+	}
+	if err := checkMetricPath(spec.Metric); err != nil {
+		return fail("metric %q: %v", spec.Metric, err)
+	}
+	if _, ok := customOps[spec.Op]; !ok {
+		return fail("invalid op %q (valid: %s)", spec.Op, validOps)
+	}
+	if spec.Threshold == nil {
+		return fail("threshold is required")
+	}
+	rule.Threshold = *spec.Threshold
+	if rule.Category == "" {
+		rule.Category = "custom"
+	}
+	if rule.Priority == 0 {
+		rule.Priority = PriorityMedium
+	}
+	if rule.Priority < PriorityCritical || rule.Priority > PriorityLow {
+		return fail("priority %d out of range (%d-%d)", rule.Priority, PriorityCritical, PriorityLow)
+	}
+	if spec.Impact != nil {
+		if *spec.Impact < 0 {
+			return fail("impact %g must not be negative", *spec.Impact)
+		}
+		rule.Impact = *spec.Impact
+	}
+	if spec.Title == "" {
+		return fail("title is required")
+	}
+
+	var err error
+	if rule.Title, err = parseRuleTemplate("title", spec.Title); err != nil {
+		return fail("%v", err)
+	}
+	if rule.Description, err = parseRuleTemplate("description", spec.Description); err != nil {
+		return fail("%v", err)
+	}
+	return rule, nil
+}
+
+// ruleFuncs are the helpers available in custom rule templates besides the
+// text/template builtins.
+var ruleFuncs = template.FuncMap{
+	"mul": func(a, b float64) float64 { return a * b },
+}
+
+// parseRuleTemplate parses text and executes it once against sample data so
+// references to unknown fields fail at load rather than at run time.
+func parseRuleTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(ruleFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, CustomRuleData{}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// checkMetricPath reports whether path names a numeric field reachable from
+// AnalysisContext. Map keys cannot be checked until the rule runs.
+func checkMetricPath(path string) error {
+	t := reflect.TypeOf(AnalysisContext{})
+	segments := strings.Split(path, ".")
+	if segments[0] == "projects" {
+		if len(segments) == 1 {
+			return errors.New("needs a project field, e.g. projects.tool_errors")
+		}
+		t, segments = reflect.TypeOf(ProjectContext{}), segments[1:]
+	}
+	for _, seg := range segments {
+		switch t.Kind() {
+		case reflect.Struct:
+			f, ok := jsonField(t, seg)
+			if !ok {
+				return fmt.Errorf("unknown field %q", seg)
+			}
+			t = f.Type
+		case reflect.Map:
+			if t.Key().Kind() != reflect.String {
+				return fmt.Errorf("cannot index %q", seg)
+			}
+			t = t.Elem()
+		default:
+			return fmt.Errorf("%q is not a struct or map", seg)
+		}
+	}
+	if !isNumericKind(t.Kind()) {
+		return fmt.Errorf("is not numeric (%s)", t)
+	}
+	return nil
+}
+
+// jsonField finds the struct field of t whose JSON name is name.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool:
+		return true
+	}
+	return false
+}
+
+// lookupMetric resolves the dotted path segments against v, reporting false
+// when a map key is absent.
+func lookupMetric(v reflect.Value, segments []string) (float64, bool) {
+	for _, seg := range segments {
+		switch v.Kind() {
+		case reflect.Struct:
+			f, ok := jsonField(v.Type(), seg)
+			if !ok {
+				return 0, false
+			}
+			v = v.FieldByIndex(f.Index)
+		case reflect.Map:
+			v = v.MapIndex(reflect.ValueOf(seg).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return 0, false
+			}
+		default:
+			return 0, false
+		}
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// Rule returns r as a Rule for Engine.AddRules.
+func (r CustomRule) Rule() Rule {
+	return func(ctx *AnalysisContext) []Suggestion {
+		segments := strings.Split(r.Metric, ".")
+		if segments[0] != "projects" {
+			value, ok := lookupMetric(reflect.ValueOf(*ctx), segments)
+			if !ok {
+				return nil
+			}
+			return r.fire(CustomRuleData{Metric: r.Metric, Value: value, Threshold: r.Threshold})
+		}
+
+		var suggestions []Suggestion
+		for _, p := range ctx.Projects {
+			value, ok := lookupMetric(reflect.ValueOf(p), segments[1:])
+			if !ok {
+				continue
+			}
+			suggestions = append(suggestions, r.fire(CustomRuleData{
+				Metric: r.Metric, Value: value, Threshold: r.Threshold, Project: p.Name,
+			})...)
+		}
+		return suggestions
+	}
+}
+
+// fire returns the rule's suggestion when data's value passes the
+// comparison, and nothing otherwise.
+func (r CustomRule) fire(data CustomRuleData) []Suggestion {
+	if !customOps[r.Op](data.Value, r.Threshold) {
+		return nil
+	}
+	var title, desc bytes.Buffer
+	// Both templates ran against the same data shape at load.
+	_ = r.Title.Execute(&title, data)
+	_ = r.Description.Execute(&desc, data)
+	return []Suggestion{{
+		Category:    r.Category,
+		Priority:    r.Priority,
+		Title:       title.String(),
+		Description: desc.String(),
+		ImpactScore: r.Impact,
+	}}
+}
@@ -0,0 +1,197 @@
+package suggest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCustomRules_Valid(t *testing.T) {
+	data := []byte(`rules:
+  - name: error-heavy
+    metric: avg_tool_errors
+    op: ">="
+    threshold: 3
+    category: quality
+    priority: 2
+    impact: 12.5
+    title: "Tool errors average {{printf \"%.1f\" .Value}}"
+    description: "Above {{.Threshold}}."
+  - name: explore-agents
+    metric: agent_type_stats.Explore
+    op: "<"
+    threshold: 0.5
+    title: Explore agents struggle
+`)
+	rules, err := ParseCustomRules(data, "rules.yaml")
+	if err != nil {
+		t.Fatalf("ParseCustomRules: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	r := rules[0]
+	if r.Name != "error-heavy" || r.Op != ">=" || r.Threshold != 3 || r.Category != "quality" ||
+		r.Priority != PriorityHigh || r.Impact != 12.5 || r.Line != 2 {
+		t.Errorf("rule 0 = %+v", r)
+	}
+	// Defaults.
+	r = rules[1]
+	if r.Category != "custom" || r.Priority != PriorityMedium || r.Impact != DefaultCustomImpact || r.Line != 11 {
+		t.Errorf("rule 1 defaults = %+v", r)
+	}
+}
+
+func TestParseCustomRules_ReportsLineNumbers(t *testing.T) {
+	data := []byte(`rules:
+  - name: good
+    metric: total_cost
+    op: ">"
+    threshold: 100
+    title: Spend is high
+  - name: bad-op
+    metric: total_cost
+    op: "=>"
+    threshold: 1
+    title: x
+  - name: bad-metric
+    metric: projects.no_such_field
+    op: ">"
+    threshold: 1
+    title: x
+  - name: not-numeric
+    metric: recurring_friction
+    op: ">"
+    threshold: 1
+    title: x
+  - name: bad-template
+    metric: total_cost
+    op: ">"
+    threshold: 1
+    title: "{{.Nope}}"
+  - name: good
+    metric: total_cost
+    op: "<"
+    threshold: 1
+    title: duplicate
+`)
+	rules, err := ParseCustomRules(data, "rules.yaml")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(rules) != 1 || rules[0].Name != "good" {
+		t.Errorf("expected the valid rule to be returned, got %+v", rules)
+	}
+	msg := err.Error()
+	for _, want := range []string{
+		`rules.yaml:7: rule "bad-op": invalid op "=>"`,
+		`rules.yaml:12: rule "bad-metric": metric "projects.no_such_field": unknown field "no_such_field"`,
+		`rules.yaml:17: rule "not-numeric": metric "recurring_friction": is not numeric`,
+		`rules.yaml:22: rule "bad-template"`,
+		`rules.yaml:27: rule "good" is already defined on line 2`,
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestParseCustomRules_Structure(t *testing.T) {
+	for _, tc := range []struct {
+		name, data, want string
+	}{
+		{"unknown top-level key", "rule:\n  - name: x\n", `rules.yaml:1: unknown key "rule"`},
+		{"rules not a list", "rules: 3\n", "rules.yaml:1: rules must be a list"},
+		{"unknown field", "rules:\n  - name: x\n    metric: total_cost\n    op: \">\"\n    threshold: 1\n    title: t\n    treshold: 2\n", `rules.yaml:2: unknown field "treshold"`},
+		{"missing threshold", "rules:\n  - name: x\n    metric: total_cost\n    op: \">\"\n    title: t\n", `rule "x": threshold is required`},
+		{"bad priority", "rules:\n  - name: x\n    metric: total_cost\n    op: \">\"\n    threshold: 1\n    priority: 9\n    title: t\n", "priority 9 out of range"},
+	} {
+		_, err := ParseCustomRules([]byte(tc.data), "rules.yaml")
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: error = %v, want it to contain %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestLoadCustomRules_MissingFile(t *testing.T) {
+	rules, err := LoadCustomRules(filepath.Join(t.TempDir(), "absent.yaml"))
+	if err != nil || rules != nil {
+		t.Errorf("LoadCustomRules(missing) = %v, %v; want nil, nil", rules, err)
+	}
+}
+
+func TestCustomRule_Fires(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	data := `rules:
+  - name: costly
+    metric: total_cost
+    op: ">"
+    threshold: 50
+    impact: 7
+    title: "Spent ${{printf \"%.0f\" .Value}}"
+  - name: errors-per-project
+    metric: projects.tool_errors
+    op: ">="
+    threshold: 10
+    title: "{{.Project}} has {{.Value}} tool errors"
+  - name: explore
+    metric: agent_type_stats.Explore
+    op: "<"
+    threshold: 0.5
+    title: Explore agents struggle
+  - name: claude-md
+    metric: projects.has_claude_md
+    op: "=="
+    threshold: 0
+    title: "{{.Project}} lacks CLAUDE.md"
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadCustomRules(path)
+	if err != nil {
+		t.Fatalf("LoadCustomRules: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		TotalCost: 80,
+		Projects: []ProjectContext{
+			{Name: "api", ToolErrors: 12, HasClaudeMD: true},
+			{Name: "web", ToolErrors: 3},
+		},
+	}
+	var titles []string
+	for _, r := range rules {
+		for _, s := range r.Rule()(ctx) {
+			titles = append(titles, s.Title)
+		}
+	}
+	want := []string{"Spent $80", "api has 12 tool errors", "web lacks CLAUDE.md"}
+	if strings.Join(titles, "|") != strings.Join(want, "|") {
+		t.Errorf("titles = %q, want %q", titles, want)
+	}
+
+	// The absent map key doesn't fire; once present, it is compared.
+	ctx.AgentTypeStats = map[string]float64{"Explore": 0.25}
+	if got := rules[2].Rule()(ctx); len(got) != 1 || got[0].Category != "custom" {
+		t.Errorf("explore rule = %+v, want one custom suggestion", got)
+	}
+}
+
+func TestEngineAddRules_AugmentsBuiltins(t *testing.T) {
+	ctx := &AnalysisContext{}
+	builtin := len(NewEngine().Run(ctx))
+
+	engine := NewEngine()
+	engine.AddRules(func(*AnalysisContext) []Suggestion {
+		return []Suggestion{{Category: "custom", Title: "extra", ImpactScore: 1000}}
+	})
+	got := engine.Run(ctx)
+	if len(got) != builtin+1 {
+		t.Fatalf("expected %d suggestions, got %d", builtin+1, len(got))
+	}
+	if got[0].Title != "extra" {
+		t.Errorf("expected custom suggestion ranked first, got %q", got[0].Title)
+	}
+}
@@ -18,15 +18,24 @@ func NewEngine() *Engine {
 			AgentAdoption,
 			InterruptionPattern,
 			AgentTypeEffectiveness,
+			ProjectAgentKillRate,
 			ParallelizationOpportunity,
+			SubagentOpportunity,
 			CustomMetricRegression,
 			ClaudeMDSectionSuggestions,
+			ClaudeMDSectionCautionSuggestions,
 			ZeroCommitRateSuggestion,
 			CostOptimizationSuggestion,
 		},
 	}
 }
 
+// AddRules registers extra rules, such as custom rules from a rules file,
+// to run after the built-in ones.
+func (e *Engine) AddRules(rules ...Rule) {
+	e.rules = append(e.rules, rules...)
+}
+
 // Run executes all registered rules against the given context and returns
 // the collected suggestions sorted by impact score (highest first).
 func (e *Engine) Run(ctx *AnalysisContext) []Suggestion {
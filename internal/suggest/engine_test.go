@@ -71,6 +71,7 @@ func TestEngineRun_ProducesSuggestionsFromMultipleRules(t *testing.T) {
 				SessionCount: 5,
 				HasClaudeMD:  false,
 				ToolErrors:   50, // avg=10, threshold=4
+				Thresholds:   ThresholdsFor(nil, nil),
 			},
 		},
 		RecurringFriction: []string{"timeout"},
@@ -128,8 +129,8 @@ func TestEngineRun_CustomRule(t *testing.T) {
 
 func TestNewEngine_HasAllRules(t *testing.T) {
 	engine := NewEngine()
-	// NewEngine registers 13 built-in rules.
-	expectedCount := 13
+	// NewEngine registers 16 built-in rules.
+	expectedCount := 16
 	if len(engine.rules) != expectedCount {
 		t.Errorf("expected %d rules, got %d", expectedCount, len(engine.rules))
 	}
@@ -306,6 +307,7 @@ func TestEngineRun_FullContext(t *testing.T) {
 				Interruptions:           100, // avg=5.0 > 3.0
 				AgentCount:              0,
 				SequentialCount:         5,
+				ParallelSavingsMinutes:  4,
 				ClaudeMDMissingSections: []string{"testing"},
 			},
 			{
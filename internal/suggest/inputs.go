@@ -0,0 +1,100 @@
+package suggest
+
+import (
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+// This file turns config and analyzer results into AnalysisContext inputs.
+// The suggest command and the MCP server build their contexts differently,
+// but share these conversions so both judge projects the same way.
+
+// ThresholdsFor returns the rule thresholds of a project whose effective
+// config is eff, under the global config. ZeroCommitRate is set only when
+// the project overrides the global zero-commit threshold. A nil global
+// stands for the config defaults, and a nil eff for global.
+func ThresholdsFor(global, eff *config.Config) ProjectThresholds {
+	if global == nil {
+		global = DefaultConfig()
+	}
+	if eff == nil {
+		eff = global
+	}
+	t := ProjectThresholds{
+		HighErrorMultiplier: eff.Friction.HighErrorMultiplier,
+		AgentKillRate:       eff.Thresholds.AgentKillRate,
+	}
+	if eff.Thresholds.ZeroCommitRate != global.Thresholds.ZeroCommitRate {
+		t.ZeroCommitRate = eff.Thresholds.ZeroCommitRate
+	}
+	return t
+}
+
+// DefaultConfig returns a config holding the defaults the rules read, for
+// callers without a loaded config.
+func DefaultConfig() *config.Config {
+	return &config.Config{
+		Friction:   config.DefaultFriction,
+		Thresholds: config.DefaultThresholds,
+	}
+}
+
+// ClaudeMDSavingsByProject indexes roi's estimates by normalized project
+// path, for ProjectContext.ClaudeMDSavings.
+func ClaudeMDSavingsByProject(roi analyzer.ClaudeMDROI) map[string]*ProjectClaudeMDSavings {
+	savings := make(map[string]*ProjectClaudeMDSavings, len(roi.Estimates))
+	for _, e := range roi.Estimates {
+		savings[claude.NormalizePath(e.ProjectPath)] = &ProjectClaudeMDSavings{
+			MonthlySavings:    e.EstimatedMonthlySavings,
+			FrictionReduction: e.EstimatedFrictionReduction,
+			References:        roi.References,
+			Confidence:        e.Confidence,
+		}
+	}
+	return savings
+}
+
+// WorstZeroCommitProject returns the project with the highest zero-commit
+// rate in ca, or nil when no project has enough sessions.
+func WorstZeroCommitProject(ca analyzer.CommitAnalysis) *ZeroCommitProject {
+	top := ca.TopZeroCommitProjects(1)
+	if len(top) == 0 {
+		return nil
+	}
+	return &ZeroCommitProject{
+		Name:           top[0].ProjectName,
+		ZeroCommitRate: top[0].ZeroCommitRate,
+		Sessions:       top[0].Sessions,
+	}
+}
+
+// SubagentOpportunityFor returns the subagent opportunity for the project at
+// path, or nil when it isn't among candidates, which are keyed by normalized
+// project path.
+func SubagentOpportunityFor(candidates map[string]analyzer.SubagentCandidate, path string) *ProjectSubagentOpportunity {
+	c, ok := candidates[claude.NormalizePath(path)]
+	if !ok {
+		return nil
+	}
+	return &ProjectSubagentOpportunity{
+		AvgDurationMinutes:    c.AvgDurationMinutes,
+		ExplorationShare:      c.ExplorationShare,
+		ExplorationPerSession: c.ExplorationPerSession,
+		AgentSessionRate:      c.AgentSessionRate,
+	}
+}
+
+// AgentTypeStatsFor summarizes a project's agent tasks by canonical agent
+// type, or returns nil without tasks.
+func AgentTypeStatsFor(tasks []claude.AgentTask, aliases map[string]string) map[string]ProjectAgentTypeStats {
+	if len(tasks) == 0 {
+		return nil
+	}
+	byType := analyzer.AnalyzeAgents(tasks, aliases).ByType
+	stats := make(map[string]ProjectAgentTypeStats, len(byType))
+	for agentType, ts := range byType {
+		stats[agentType] = ProjectAgentTypeStats{Count: ts.Count, KillRate: ts.KillRate}
+	}
+	return stats
+}
@@ -0,0 +1,31 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/blackwell-systems/claudewatch/internal/config"
+)
+
+func TestThresholdsFor(t *testing.T) {
+	if got, want := ThresholdsFor(nil, nil), (ProjectThresholds{
+		HighErrorMultiplier: config.DefaultFriction.HighErrorMultiplier,
+		AgentKillRate:       config.DefaultThresholds.AgentKillRate,
+	}); got != want {
+		t.Errorf("ThresholdsFor(nil, nil) = %+v, want the config defaults %+v", got, want)
+	}
+
+	global := DefaultConfig()
+	global.Thresholds.ZeroCommitRate = 0.5
+	same := *global
+	if got := ThresholdsFor(global, &same); got.ZeroCommitRate != 0 {
+		t.Errorf("ZeroCommitRate = %v for a project on the global threshold, want 0", got.ZeroCommitRate)
+	}
+
+	own := *global
+	own.Friction.HighErrorMultiplier = 4
+	own.Thresholds.AgentKillRate = 0.6
+	own.Thresholds.ZeroCommitRate = 0.9
+	if got, want := ThresholdsFor(global, &own), (ProjectThresholds{HighErrorMultiplier: 4, AgentKillRate: 0.6, ZeroCommitRate: 0.9}); got != want {
+		t.Errorf("ThresholdsFor(global, own) = %+v, want %+v", got, want)
+	}
+}
@@ -3,10 +3,11 @@ package suggest
 import "sort"
 
 // RankSuggestions sorts suggestions by ImpactScore in descending order.
+// Ties keep the order the rules produced them in.
 func RankSuggestions(suggestions []Suggestion) []Suggestion {
 	sorted := make([]Suggestion, len(suggestions))
 	copy(sorted, suggestions)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].ImpactScore > sorted[j].ImpactScore
 	})
 	return sorted
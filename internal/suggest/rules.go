@@ -1,6 +1,10 @@
 package suggest
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
 
 // MissingClaudeMD suggests creating a CLAUDE.md for projects that have
 // sessions but no CLAUDE.md file.
@@ -15,8 +19,8 @@ func MissingClaudeMD(ctx *AnalysisContext) []Suggestion {
 				Description: fmt.Sprintf(
 					"Project %q has %d sessions but no CLAUDE.md. "+
 						"Adding a CLAUDE.md improves Claude's understanding of project context, "+
-						"coding conventions, and reduces friction from wrong approaches.",
-					p.Name, p.SessionCount,
+						"coding conventions, and reduces friction from wrong approaches.%s",
+					p.Name, p.SessionCount, claudeMDSavingsNote(p.ClaudeMDSavings),
 				),
 				ImpactScore: ComputeImpact(p.SessionCount, 1.0, 5.0, 15.0),
 			})
@@ -25,6 +29,23 @@ func MissingClaudeMD(ctx *AnalysisContext) []Suggestion {
 	return suggestions
 }
 
+// claudeMDSavingsNote describes the estimated savings of adding a CLAUDE.md,
+// or is empty when none are projected.
+func claudeMDSavingsNote(s *ProjectClaudeMDSavings) string {
+	if s == nil || s.MonthlySavings <= 0 {
+		return ""
+	}
+	note := fmt.Sprintf(" Estimated monthly savings: ~$%.2f", s.MonthlySavings)
+	if s.FrictionReduction > 0 {
+		note += fmt.Sprintf(", with about %.1f fewer friction events per session", s.FrictionReduction)
+	}
+	projects := "projects"
+	if s.References == 1 {
+		projects = "project"
+	}
+	return note + fmt.Sprintf(" (an estimate from %d %s with a CLAUDE.md; %s confidence).", s.References, projects, s.Confidence)
+}
+
 // RecurringFriction suggests interventions for friction types that appear
 // in more than 30% of sessions.
 func RecurringFriction(ctx *AnalysisContext) []Suggestion {
@@ -113,7 +134,9 @@ func UnusedSkills(ctx *AnalysisContext) []Suggestion {
 	return suggestions
 }
 
-// HighErrorProjects flags projects with tool errors more than 2x the average.
+// HighErrorProjects flags projects with tool errors more than their
+// HighErrorMultiplier times the average. Projects without a multiplier are
+// skipped.
 func HighErrorProjects(ctx *AnalysisContext) []Suggestion {
 	var suggestions []Suggestion
 
@@ -121,12 +144,11 @@ func HighErrorProjects(ctx *AnalysisContext) []Suggestion {
 		return suggestions
 	}
 
-	threshold := ctx.AvgToolErrors * 2.0
-
 	for _, p := range ctx.Projects {
-		if p.SessionCount == 0 {
+		if p.SessionCount == 0 || p.Thresholds.HighErrorMultiplier == 0 {
 			continue
 		}
+		threshold := ctx.AvgToolErrors * p.Thresholds.HighErrorMultiplier
 		projectAvgErrors := float64(p.ToolErrors) / float64(p.SessionCount)
 		if projectAvgErrors > threshold {
 			suggestions = append(suggestions, Suggestion{
@@ -234,21 +256,65 @@ func AgentTypeEffectiveness(ctx *AnalysisContext) []Suggestion {
 	return suggestions
 }
 
+// ProjectAgentKillRate flags agent types that are frequently killed within a
+// specific project, above its AgentKillRate. Unlike AgentTypeEffectiveness,
+// which uses global rates, this surfaces problems that only show up in one
+// codebase. Projects without a kill rate threshold are skipped.
+func ProjectAgentKillRate(ctx *AnalysisContext) []Suggestion {
+	var suggestions []Suggestion
+
+	for _, p := range ctx.Projects {
+		maxKillRate := p.Thresholds.AgentKillRate
+		if maxKillRate == 0 {
+			continue
+		}
+		agentTypes := make([]string, 0, len(p.AgentTypeStats))
+		for agentType := range p.AgentTypeStats {
+			agentTypes = append(agentTypes, agentType)
+		}
+		sort.Strings(agentTypes)
+
+		for _, agentType := range agentTypes {
+			stats := p.AgentTypeStats[agentType]
+			if stats.Count < 4 || stats.KillRate <= maxKillRate {
+				continue
+			}
+			suggestions = append(suggestions, Suggestion{
+				Category: "agents",
+				Priority: PriorityMedium,
+				Title:    fmt.Sprintf("High kill rate for %s agents in %s", agentType, p.Name),
+				Description: fmt.Sprintf(
+					"In project %q, %.0f%% of %d %s agents were killed before finishing. "+
+						"Give these agents more specific prompts with clear stopping criteria, "+
+						"or split the work into smaller tasks.",
+					p.Name, stats.KillRate*100, stats.Count, agentType,
+				),
+				ImpactScore: ComputeImpact(p.SessionCount, stats.KillRate, 5.0, 10.0),
+			})
+		}
+	}
+
+	return suggestions
+}
+
 // ParallelizationOpportunity flags projects running multiple sequential
 // agents that could potentially run in parallel.
 func ParallelizationOpportunity(ctx *AnalysisContext) []Suggestion {
 	var suggestions []Suggestion
 
 	for _, p := range ctx.Projects {
-		if p.SequentialCount > 2 {
-			estimatedMinutes := float64(p.SequentialCount) * 0.5 // rough estimate
+		// ParallelSavingsMinutes comes from analyzer.EstimateParallelSavings;
+		// zero means every sequential agent was chained to or overlapped
+		// another, so there is nothing to gain from parallelizing.
+		if p.SequentialCount > 2 && p.ParallelSavingsMinutes > 0 {
+			estimatedMinutes := p.ParallelSavingsMinutes
 			suggestions = append(suggestions, Suggestion{
 				Category: "agents",
 				Priority: PriorityLow,
 				Title:    fmt.Sprintf("Parallelization opportunity in %s", p.Name),
 				Description: fmt.Sprintf(
 					"Project %q ran %d agents sequentially that could have been parallel, "+
-						"costing an estimated %.0f extra minutes. "+
+						"costing an estimated %.0f extra minutes of wall-clock time. "+
 						"Use background agents for independent tasks like exploration, documentation, "+
 						"and test writing.",
 					p.Name, p.SequentialCount, estimatedMinutes,
@@ -261,6 +327,38 @@ func ParallelizationOpportunity(ctx *AnalysisContext) []Suggestion {
 	return suggestions
 }
 
+// SubagentOpportunity suggests delegating exploration to agents in projects
+// whose long sessions are mostly reading and searching but rarely use
+// agents. It is a targeted version of AgentAdoption, naming the project and
+// the work to hand off.
+func SubagentOpportunity(ctx *AnalysisContext) []Suggestion {
+	var suggestions []Suggestion
+
+	for _, p := range ctx.Projects {
+		o := p.SubagentOpportunity
+		if o == nil {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Category: "agents",
+			Priority: PriorityMedium,
+			Title:    fmt.Sprintf("Delegate exploration to subagents in %s", p.Name),
+			Description: fmt.Sprintf(
+				"Sessions in %q average %.0f minutes, %.0f%% of their tool calls are reads and searches "+
+					"(%.0f per session), and only %.0f%% of sessions use an agent. Ask for an Explore agent "+
+					"when you need to find where something lives or how it works, e.g. \"use a subagent to "+
+					"find every caller of X\", so the search runs in the background and the main session "+
+					"keeps its context for the change itself.",
+				p.Name, o.AvgDurationMinutes, o.ExplorationShare*100, o.ExplorationPerSession, o.AgentSessionRate*100,
+			),
+			// Assume delegating saves a tenth of each session's exploration-heavy time.
+			ImpactScore: ComputeImpact(p.SessionCount, o.ExplorationShare, o.AvgDurationMinutes/10, 5.0),
+		})
+	}
+
+	return suggestions
+}
+
 // CustomMetricRegression flags custom metrics trending in the wrong direction.
 func CustomMetricRegression(ctx *AnalysisContext) []Suggestion {
 	var suggestions []Suggestion
@@ -325,27 +423,83 @@ func ClaudeMDSectionSuggestions(ctx *AnalysisContext) []Suggestion {
 	return suggestions
 }
 
-// ZeroCommitRateSuggestion flags workflows with a high zero-commit rate (>40%).
+// ClaudeMDSectionCautionSuggestions flags CLAUDE.md sections that go with
+// more friction, in each project whose CLAUDE.md has one. Overly
+// prescriptive sections can hurt more than they help.
+func ClaudeMDSectionCautionSuggestions(ctx *AnalysisContext) []Suggestion {
+	var suggestions []Suggestion
+
+	for section, frictionIncrease := range ctx.ClaudeMDSectionCautions {
+		if frictionIncrease <= 0 {
+			continue
+		}
+		for _, p := range ctx.Projects {
+			if !p.HasClaudeMD || slices.Contains(p.ClaudeMDMissingSections, section) {
+				continue
+			}
+			suggestions = append(suggestions, Suggestion{
+				Category: "quality",
+				Priority: PriorityLow,
+				Title:    fmt.Sprintf("Review the %q section of %s CLAUDE.md", section, p.Name),
+				Description: fmt.Sprintf(
+					"Projects with a %q section show %.0f%% more friction than projects without one. "+
+						"Overly prescriptive instructions can get in Claude's way; "+
+						"consider simplifying this section in %s and watching whether friction drops.",
+					section, frictionIncrease, p.Name,
+				),
+				ImpactScore: ComputeImpact(p.SessionCount, frictionIncrease/100.0, 2.0, 10.0),
+			})
+		}
+	}
+
+	return suggestions
+}
+
+// zeroCommitAdvice follows the zero-commit rate in ZeroCommitRateSuggestion
+// descriptions.
+const zeroCommitAdvice = "This may indicate exploratory " +
+	"sessions without deliverables, or incomplete workflows that stall before " +
+	"committing. Consider breaking large tasks into smaller commit-sized chunks, " +
+	"using the /commit skill, or reviewing whether these sessions achieve their goals."
+
+// ZeroCommitRateSuggestion flags workflows whose zero-commit rate is above
+// ZeroCommitThreshold, naming the worst project when one is known. Projects
+// with their own zero-commit threshold are flagged separately against it.
 func ZeroCommitRateSuggestion(ctx *AnalysisContext) []Suggestion {
 	var suggestions []Suggestion
 
-	if ctx.ZeroCommitRate <= 0.40 || ctx.TotalSessions < 5 {
-		return suggestions
+	sessions := ctx.ZeroCommitSessions
+	if sessions == 0 {
+		sessions = ctx.TotalSessions
+	}
+	if ctx.ZeroCommitThreshold > 0 && ctx.ZeroCommitRate > ctx.ZeroCommitThreshold && sessions >= 5 {
+		worst := ""
+		if w := ctx.WorstZeroCommitProject; w != nil {
+			worst = fmt.Sprintf(" The worst project is %q, at %.0f%% of %d sessions.", w.Name, w.ZeroCommitRate*100, w.Sessions)
+		}
+		suggestions = append(suggestions, Suggestion{
+			Category: "quality",
+			Priority: PriorityHigh,
+			Title:    "High zero-commit rate in sessions",
+			Description: fmt.Sprintf("%.0f%% of %d sessions produced zero commits.%s %s",
+				ctx.ZeroCommitRate*100, sessions, worst, zeroCommitAdvice),
+			ImpactScore: ComputeImpact(sessions, ctx.ZeroCommitRate, 5.0, 10.0),
+		})
 	}
 
-	suggestions = append(suggestions, Suggestion{
-		Category: "quality",
-		Priority: PriorityHigh,
-		Title:    "High zero-commit rate in sessions",
-		Description: fmt.Sprintf(
-			"%.0f%% of %d sessions produced zero commits. This may indicate exploratory "+
-				"sessions without deliverables, or incomplete workflows that stall before "+
-				"committing. Consider breaking large tasks into smaller commit-sized chunks, "+
-				"using the /commit skill, or reviewing whether these sessions achieve their goals.",
-			ctx.ZeroCommitRate*100, ctx.TotalSessions,
-		),
-		ImpactScore: ComputeImpact(ctx.TotalSessions, ctx.ZeroCommitRate, 5.0, 10.0),
-	})
+	for _, p := range ctx.Projects {
+		if p.Thresholds.ZeroCommitRate == 0 || p.ZeroCommitRate <= p.Thresholds.ZeroCommitRate || p.SessionCount < 5 {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Category: "quality",
+			Priority: PriorityHigh,
+			Title:    fmt.Sprintf("High zero-commit rate in %s", p.Name),
+			Description: fmt.Sprintf("In project %q, %.0f%% of %d sessions produced zero commits, above its %.0f%% threshold. %s",
+				p.Name, p.ZeroCommitRate*100, p.SessionCount, p.Thresholds.ZeroCommitRate*100, zeroCommitAdvice),
+			ImpactScore: ComputeImpact(p.SessionCount, p.ZeroCommitRate, 5.0, 10.0),
+		})
+	}
 
 	return suggestions
 }
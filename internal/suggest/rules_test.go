@@ -70,6 +70,26 @@ func TestMissingClaudeMD_MultipleProjects(t *testing.T) {
 	}
 }
 
+func TestMissingClaudeMD_EstimatedSavings(t *testing.T) {
+	ctx := &AnalysisContext{
+		Projects: []ProjectContext{
+			{Name: "app1", SessionCount: 10, ClaudeMDSavings: &ProjectClaudeMDSavings{MonthlySavings: 12.5, FrictionReduction: 0.4, References: 1, Confidence: "low"}},
+			{Name: "app2", SessionCount: 10, ClaudeMDSavings: &ProjectClaudeMDSavings{References: 4, Confidence: "medium"}},
+		},
+	}
+	suggestions := MissingClaudeMD(ctx)
+	if len(suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(suggestions))
+	}
+	want := "Estimated monthly savings: ~$12.50, with about 0.4 fewer friction events per session (an estimate from 1 project with a CLAUDE.md; low confidence)."
+	if !strings.Contains(suggestions[0].Description, want) {
+		t.Errorf("expected description to contain %q, got %q", want, suggestions[0].Description)
+	}
+	if strings.Contains(suggestions[1].Description, "savings") {
+		t.Errorf("expected no savings note without projected savings, got %q", suggestions[1].Description)
+	}
+}
+
 func TestMissingClaudeMD_EmptyProjects(t *testing.T) {
 	ctx := &AnalysisContext{}
 	suggestions := MissingClaudeMD(ctx)
@@ -307,11 +327,14 @@ func TestUnusedSkills_BoundaryAgentRatio(t *testing.T) {
 
 // --- HighErrorProjects ---
 
+// defaultThresholds are the thresholds of a project on the config defaults.
+var defaultThresholds = ThresholdsFor(nil, nil)
+
 func TestHighErrorProjects_HighErrors(t *testing.T) {
 	ctx := &AnalysisContext{
 		AvgToolErrors: 2.0,
 		Projects: []ProjectContext{
-			{Name: "buggy", SessionCount: 5, ToolErrors: 30}, // avg=6.0, threshold=4.0
+			{Name: "buggy", SessionCount: 5, ToolErrors: 30, Thresholds: defaultThresholds}, // avg=6.0, threshold=4.0
 		},
 	}
 	suggestions := HighErrorProjects(ctx)
@@ -330,7 +353,7 @@ func TestHighErrorProjects_NormalErrors(t *testing.T) {
 	ctx := &AnalysisContext{
 		AvgToolErrors: 5.0,
 		Projects: []ProjectContext{
-			{Name: "fine", SessionCount: 10, ToolErrors: 50}, // avg=5.0, threshold=10.0
+			{Name: "fine", SessionCount: 10, ToolErrors: 50, Thresholds: defaultThresholds}, // avg=5.0, threshold=10.0
 		},
 	}
 	suggestions := HighErrorProjects(ctx)
@@ -343,7 +366,7 @@ func TestHighErrorProjects_ZeroAvgToolErrors(t *testing.T) {
 	ctx := &AnalysisContext{
 		AvgToolErrors: 0,
 		Projects: []ProjectContext{
-			{Name: "project", SessionCount: 10, ToolErrors: 100},
+			{Name: "project", SessionCount: 10, ToolErrors: 100, Thresholds: defaultThresholds},
 		},
 	}
 	suggestions := HighErrorProjects(ctx)
@@ -356,7 +379,7 @@ func TestHighErrorProjects_NegativeAvgToolErrors(t *testing.T) {
 	ctx := &AnalysisContext{
 		AvgToolErrors: -1.0,
 		Projects: []ProjectContext{
-			{Name: "project", SessionCount: 10, ToolErrors: 100},
+			{Name: "project", SessionCount: 10, ToolErrors: 100, Thresholds: defaultThresholds},
 		},
 	}
 	suggestions := HighErrorProjects(ctx)
@@ -365,11 +388,39 @@ func TestHighErrorProjects_NegativeAvgToolErrors(t *testing.T) {
 	}
 }
 
+func TestHighErrorProjects_ProjectMultiplier(t *testing.T) {
+	ctx := &AnalysisContext{
+		AvgToolErrors: 2.0,
+		Projects: []ProjectContext{
+			// avg=6.0: above the default 4.0 threshold, below this project's 8.0.
+			{Name: "legacy", SessionCount: 5, ToolErrors: 30, Thresholds: ProjectThresholds{HighErrorMultiplier: 4}},
+			// avg=3.0: below the default, above this project's 2.4.
+			{Name: "strict", SessionCount: 5, ToolErrors: 15, Thresholds: ProjectThresholds{HighErrorMultiplier: 1.2}},
+		},
+	}
+	suggestions := HighErrorProjects(ctx)
+	if len(suggestions) != 1 || !strings.Contains(suggestions[0].Title, "strict") {
+		t.Fatalf("expected a single suggestion for strict, got %+v", suggestions)
+	}
+}
+
+func TestHighErrorProjects_NoMultiplier(t *testing.T) {
+	ctx := &AnalysisContext{
+		AvgToolErrors: 2.0,
+		Projects: []ProjectContext{
+			{Name: "unset", SessionCount: 5, ToolErrors: 30},
+		},
+	}
+	if suggestions := HighErrorProjects(ctx); len(suggestions) != 0 {
+		t.Fatalf("expected 0 suggestions without a multiplier, got %d", len(suggestions))
+	}
+}
+
 func TestHighErrorProjects_ZeroSessionProject(t *testing.T) {
 	ctx := &AnalysisContext{
 		AvgToolErrors: 2.0,
 		Projects: []ProjectContext{
-			{Name: "empty", SessionCount: 0, ToolErrors: 0},
+			{Name: "empty", SessionCount: 0, ToolErrors: 0, Thresholds: defaultThresholds},
 		},
 	}
 	suggestions := HighErrorProjects(ctx)
@@ -383,7 +434,7 @@ func TestHighErrorProjects_ExactlyAtThreshold(t *testing.T) {
 	ctx := &AnalysisContext{
 		AvgToolErrors: 2.0,
 		Projects: []ProjectContext{
-			{Name: "borderline", SessionCount: 1, ToolErrors: 4},
+			{Name: "borderline", SessionCount: 1, ToolErrors: 4, Thresholds: defaultThresholds},
 		},
 	}
 	suggestions := HighErrorProjects(ctx)
@@ -587,6 +638,88 @@ func TestAgentTypeEffectiveness_MultipleTypes(t *testing.T) {
 	}
 }
 
+func TestProjectAgentKillRate_HighKillRate(t *testing.T) {
+	ctx := &AnalysisContext{
+		Projects: []ProjectContext{
+			{
+				Name:         "api",
+				Thresholds:   defaultThresholds,
+				SessionCount: 8,
+				AgentTypeStats: map[string]ProjectAgentTypeStats{
+					"Explore": {Count: 6, KillRate: 0.50},
+					"Plan":    {Count: 5, KillRate: 0.10},
+				},
+			},
+		},
+	}
+	suggestions := ProjectAgentKillRate(ctx)
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d", len(suggestions))
+	}
+	s := suggestions[0]
+	if s.Category != "agents" {
+		t.Errorf("expected category %q, got %q", "agents", s.Category)
+	}
+	if s.Priority != PriorityMedium {
+		t.Errorf("expected PriorityMedium, got %d", s.Priority)
+	}
+	if !strings.Contains(s.Title, "Explore") || !strings.Contains(s.Title, "api") {
+		t.Errorf("expected title to name agent type and project, got %q", s.Title)
+	}
+	if !strings.Contains(s.Description, "smaller tasks") {
+		t.Errorf("expected description to suggest smaller tasks, got %q", s.Description)
+	}
+}
+
+func TestProjectAgentKillRate_TooFewTasks(t *testing.T) {
+	ctx := &AnalysisContext{
+		Projects: []ProjectContext{
+			{
+				Name:       "api",
+				Thresholds: defaultThresholds,
+				AgentTypeStats: map[string]ProjectAgentTypeStats{
+					"Explore": {Count: 3, KillRate: 1.0},
+				},
+			},
+		},
+	}
+	if suggestions := ProjectAgentKillRate(ctx); len(suggestions) != 0 {
+		t.Fatalf("expected 0 suggestions below 4 tasks, got %d", len(suggestions))
+	}
+}
+
+func TestProjectAgentKillRate_ExactlyAtThreshold(t *testing.T) {
+	ctx := &AnalysisContext{
+		Projects: []ProjectContext{
+			{
+				Name:       "api",
+				Thresholds: defaultThresholds,
+				AgentTypeStats: map[string]ProjectAgentTypeStats{
+					"Explore": {Count: 10, KillRate: 0.30}, // NOT > 0.30
+				},
+			},
+		},
+	}
+	if suggestions := ProjectAgentKillRate(ctx); len(suggestions) != 0 {
+		t.Fatalf("expected 0 suggestions at exactly 0.30, got %d", len(suggestions))
+	}
+}
+
+func TestProjectAgentKillRate_ProjectThreshold(t *testing.T) {
+	ctx := &AnalysisContext{
+		Projects: []ProjectContext{
+			{
+				Name:           "research",
+				AgentTypeStats: map[string]ProjectAgentTypeStats{"Explore": {Count: 10, KillRate: 0.50}},
+				Thresholds:     ProjectThresholds{AgentKillRate: 0.60},
+			},
+		},
+	}
+	if suggestions := ProjectAgentKillRate(ctx); len(suggestions) != 0 {
+		t.Fatalf("expected 0 suggestions below the project's 60%% threshold, got %d", len(suggestions))
+	}
+}
+
 func TestAgentTypeEffectiveness_NilMap(t *testing.T) {
 	ctx := &AnalysisContext{
 		TotalSessions:  10,
@@ -614,7 +747,7 @@ func TestAgentTypeEffectiveness_EmptyMap(t *testing.T) {
 func TestParallelizationOpportunity_HighSequentialCount(t *testing.T) {
 	ctx := &AnalysisContext{
 		Projects: []ProjectContext{
-			{Name: "serial", SessionCount: 5, SequentialCount: 5},
+			{Name: "serial", SessionCount: 5, SequentialCount: 5, ParallelSavingsMinutes: 12},
 		},
 	}
 	suggestions := ParallelizationOpportunity(ctx)
@@ -656,7 +789,7 @@ func TestParallelizationOpportunity_ExactlyTwo(t *testing.T) {
 func TestParallelizationOpportunity_ExactlyThree(t *testing.T) {
 	ctx := &AnalysisContext{
 		Projects: []ProjectContext{
-			{Name: "threshold", SessionCount: 5, SequentialCount: 3},
+			{Name: "threshold", SessionCount: 5, SequentialCount: 3, ParallelSavingsMinutes: 2},
 		},
 	}
 	suggestions := ParallelizationOpportunity(ctx)
@@ -665,6 +798,38 @@ func TestParallelizationOpportunity_ExactlyThree(t *testing.T) {
 	}
 }
 
+func TestParallelizationOpportunity_UsesEstimatedSavings(t *testing.T) {
+	ctx := &AnalysisContext{
+		Projects: []ProjectContext{
+			{Name: "serial", SessionCount: 5, SequentialCount: 5, ParallelSavingsMinutes: 12},
+		},
+	}
+	suggestions := ParallelizationOpportunity(ctx)
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d", len(suggestions))
+	}
+	if !strings.Contains(suggestions[0].Description, "estimated 12 extra minutes") {
+		t.Errorf("expected description to report the estimated savings, got %q", suggestions[0].Description)
+	}
+	want := ComputeImpact(5, 0.4, 12, 5.0)
+	if suggestions[0].ImpactScore != want {
+		t.Errorf("ImpactScore = %v, want %v", suggestions[0].ImpactScore, want)
+	}
+}
+
+func TestParallelizationOpportunity_NoEstimatedSavings(t *testing.T) {
+	// Sequential agents that were all chained or overlapping yield no savings.
+	ctx := &AnalysisContext{
+		Projects: []ProjectContext{
+			{Name: "chained", SessionCount: 5, SequentialCount: 6, ParallelSavingsMinutes: 0},
+		},
+	}
+	suggestions := ParallelizationOpportunity(ctx)
+	if len(suggestions) != 0 {
+		t.Fatalf("expected 0 suggestions without estimated savings, got %d", len(suggestions))
+	}
+}
+
 func TestParallelizationOpportunity_NoProjects(t *testing.T) {
 	ctx := &AnalysisContext{}
 	suggestions := ParallelizationOpportunity(ctx)
@@ -673,6 +838,42 @@ func TestParallelizationOpportunity_NoProjects(t *testing.T) {
 	}
 }
 
+// --- SubagentOpportunity ---
+
+func TestSubagentOpportunity(t *testing.T) {
+	ctx := &AnalysisContext{
+		Projects: []ProjectContext{
+			{Name: "explorer", SessionCount: 8, SubagentOpportunity: &ProjectSubagentOpportunity{
+				AvgDurationMinutes: 60, ExplorationShare: 0.75, ExplorationPerSession: 40, AgentSessionRate: 0,
+			}},
+			{Name: "delegator", SessionCount: 8},
+		},
+	}
+	suggestions := SubagentOpportunity(ctx)
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d", len(suggestions))
+	}
+	s := suggestions[0]
+	if s.Category != "agents" || !strings.Contains(s.Title, "explorer") {
+		t.Errorf("unexpected suggestion %+v", s)
+	}
+	for _, want := range []string{"average 60 minutes", "75% of their tool calls", "40 per session", "0% of sessions use an agent", "Explore agent"} {
+		if !strings.Contains(s.Description, want) {
+			t.Errorf("description missing %q: %q", want, s.Description)
+		}
+	}
+	if want := ComputeImpact(8, 0.75, 6, 5.0); s.ImpactScore != want {
+		t.Errorf("ImpactScore = %v, want %v", s.ImpactScore, want)
+	}
+}
+
+func TestSubagentOpportunity_NoCandidates(t *testing.T) {
+	ctx := &AnalysisContext{Projects: []ProjectContext{{Name: "fine", SessionCount: 20}}}
+	if suggestions := SubagentOpportunity(ctx); len(suggestions) != 0 {
+		t.Fatalf("expected 0 suggestions, got %d", len(suggestions))
+	}
+}
+
 // --- CustomMetricRegression ---
 
 func TestCustomMetricRegression_RegressingMetric(t *testing.T) {
@@ -885,12 +1086,44 @@ func TestClaudeMDSectionSuggestions_MultipleProjectsMultipleSections(t *testing.
 	}
 }
 
+func TestClaudeMDSectionCautionSuggestions(t *testing.T) {
+	ctx := &AnalysisContext{
+		ClaudeMDSectionCautions: map[string]float64{"testing": 40.0},
+		Projects: []ProjectContext{
+			{Name: "has-it", HasClaudeMD: true, SessionCount: 10},
+			{Name: "lacks-it", HasClaudeMD: true, SessionCount: 10, ClaudeMDMissingSections: []string{"testing"}},
+			{Name: "no-claudemd", HasClaudeMD: false, SessionCount: 10},
+		},
+	}
+	suggestions := ClaudeMDSectionCautionSuggestions(ctx)
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d", len(suggestions))
+	}
+	if !strings.Contains(suggestions[0].Title, "has-it") || !strings.Contains(suggestions[0].Title, "testing") {
+		t.Errorf("expected title naming project and section, got %q", suggestions[0].Title)
+	}
+	if !strings.Contains(suggestions[0].Description, "40% more friction") {
+		t.Errorf("expected description to cite the friction increase, got %q", suggestions[0].Description)
+	}
+}
+
+func TestClaudeMDSectionCautionSuggestions_NoCautions(t *testing.T) {
+	ctx := &AnalysisContext{
+		ClaudeMDSectionCorrelation: map[string]float64{"testing": -40.0},
+		Projects:                   []ProjectContext{{Name: "myapp", HasClaudeMD: true, SessionCount: 10}},
+	}
+	if suggestions := ClaudeMDSectionCautionSuggestions(ctx); len(suggestions) != 0 {
+		t.Errorf("expected no suggestions without cautions, got %d", len(suggestions))
+	}
+}
+
 // --- ZeroCommitRateSuggestion ---
 
 func TestZeroCommitRateSuggestion_HighRate(t *testing.T) {
 	ctx := &AnalysisContext{
-		ZeroCommitRate: 0.60,
-		TotalSessions:  10,
+		ZeroCommitRate:      0.60,
+		TotalSessions:       10,
+		ZeroCommitThreshold: 0.40,
 	}
 	suggestions := ZeroCommitRateSuggestion(ctx)
 	if len(suggestions) != 1 {
@@ -904,10 +1137,27 @@ func TestZeroCommitRateSuggestion_HighRate(t *testing.T) {
 	}
 }
 
+func TestZeroCommitRateSuggestion_NamesWorstProject(t *testing.T) {
+	ctx := &AnalysisContext{
+		ZeroCommitRate:         0.60,
+		TotalSessions:          10,
+		ZeroCommitThreshold:    0.40,
+		WorstZeroCommitProject: &ZeroCommitProject{Name: "sandbox", ZeroCommitRate: 0.875, Sessions: 8},
+	}
+	suggestions := ZeroCommitRateSuggestion(ctx)
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d", len(suggestions))
+	}
+	if want := `The worst project is "sandbox", at 88% of 8 sessions.`; !strings.Contains(suggestions[0].Description, want) {
+		t.Errorf("description %q does not contain %q", suggestions[0].Description, want)
+	}
+}
+
 func TestZeroCommitRateSuggestion_LowRate(t *testing.T) {
 	ctx := &AnalysisContext{
-		ZeroCommitRate: 0.20,
-		TotalSessions:  10,
+		ZeroCommitRate:      0.20,
+		TotalSessions:       10,
+		ZeroCommitThreshold: 0.40,
 	}
 	suggestions := ZeroCommitRateSuggestion(ctx)
 	if len(suggestions) != 0 {
@@ -917,8 +1167,9 @@ func TestZeroCommitRateSuggestion_LowRate(t *testing.T) {
 
 func TestZeroCommitRateSuggestion_ExactlyAtThreshold(t *testing.T) {
 	ctx := &AnalysisContext{
-		ZeroCommitRate: 0.40,
-		TotalSessions:  10,
+		ZeroCommitRate:      0.40,
+		TotalSessions:       10,
+		ZeroCommitThreshold: 0.40,
 	}
 	suggestions := ZeroCommitRateSuggestion(ctx)
 	if len(suggestions) != 0 {
@@ -928,8 +1179,9 @@ func TestZeroCommitRateSuggestion_ExactlyAtThreshold(t *testing.T) {
 
 func TestZeroCommitRateSuggestion_TooFewSessions(t *testing.T) {
 	ctx := &AnalysisContext{
-		ZeroCommitRate: 0.80,
-		TotalSessions:  4,
+		ZeroCommitRate:      0.80,
+		TotalSessions:       4,
+		ZeroCommitThreshold: 0.40,
 	}
 	suggestions := ZeroCommitRateSuggestion(ctx)
 	if len(suggestions) != 0 {
@@ -939,8 +1191,9 @@ func TestZeroCommitRateSuggestion_TooFewSessions(t *testing.T) {
 
 func TestZeroCommitRateSuggestion_ExactlyFiveSessions(t *testing.T) {
 	ctx := &AnalysisContext{
-		ZeroCommitRate: 0.80,
-		TotalSessions:  5,
+		ZeroCommitRate:      0.80,
+		TotalSessions:       5,
+		ZeroCommitThreshold: 0.40,
 	}
 	suggestions := ZeroCommitRateSuggestion(ctx)
 	if len(suggestions) != 1 {
@@ -948,6 +1201,38 @@ func TestZeroCommitRateSuggestion_ExactlyFiveSessions(t *testing.T) {
 	}
 }
 
+func TestZeroCommitRateSuggestion_GlobalThreshold(t *testing.T) {
+	ctx := &AnalysisContext{
+		ZeroCommitRate:      0.60,
+		ZeroCommitThreshold: 0.70,
+		TotalSessions:       10,
+	}
+	if suggestions := ZeroCommitRateSuggestion(ctx); len(suggestions) != 0 {
+		t.Fatalf("expected 0 suggestions below a 70%% threshold, got %d", len(suggestions))
+	}
+}
+
+func TestZeroCommitRateSuggestion_ProjectThreshold(t *testing.T) {
+	ctx := &AnalysisContext{
+		ZeroCommitRate:     0.20,
+		ZeroCommitSessions: 10,
+		TotalSessions:      30,
+		Projects: []ProjectContext{
+			{Name: "research", SessionCount: 10, ZeroCommitRate: 0.90, Thresholds: ProjectThresholds{ZeroCommitRate: 1}},
+			{Name: "release", SessionCount: 10, ZeroCommitRate: 0.30, Thresholds: ProjectThresholds{ZeroCommitRate: 0.10}},
+			// Flagged only through the overall rate.
+			{Name: "app", SessionCount: 10, ZeroCommitRate: 0.90},
+		},
+	}
+	suggestions := ZeroCommitRateSuggestion(ctx)
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %+v", suggestions)
+	}
+	if !strings.Contains(suggestions[0].Title, "release") || !strings.Contains(suggestions[0].Description, "10% threshold") {
+		t.Errorf("expected a suggestion for release against its threshold, got %+v", suggestions[0])
+	}
+}
+
 // --- CostOptimizationSuggestion ---
 
 func TestCostOptimizationSuggestion_LowCacheSavings(t *testing.T) {
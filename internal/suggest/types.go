@@ -56,9 +56,27 @@ type AnalysisContext struct {
 	// Populated from the claudemd analyzer's correlation data.
 	ClaudeMDSectionCorrelation map[string]float64 `json:"claude_md_section_correlation"`
 
-	// ZeroCommitRate is the fraction of sessions with zero commits.
+	// ClaudeMDSectionCautions maps sections that go with more friction to
+	// how much more, in percent. Populated from the claudemd analyzer.
+	ClaudeMDSectionCautions map[string]float64 `json:"claude_md_section_cautions,omitempty"`
+
+	// ZeroCommitRate is the fraction of sessions with zero commits, leaving
+	// out projects with their own zero-commit threshold.
 	ZeroCommitRate float64 `json:"zero_commit_rate"`
 
+	// ZeroCommitSessions is the number of sessions behind ZeroCommitRate.
+	// Zero means TotalSessions.
+	ZeroCommitSessions int `json:"zero_commit_sessions,omitempty"`
+
+	// ZeroCommitThreshold is the zero-commit rate above which the workflow
+	// is flagged, from thresholds.zero_commit_rate. Zero leaves the rule off.
+	ZeroCommitThreshold float64 `json:"zero_commit_threshold,omitempty"`
+
+	// WorstZeroCommitProject is the project with the highest zero-commit
+	// rate among those behind ZeroCommitRate, or nil when none has enough
+	// sessions to judge.
+	WorstZeroCommitProject *ZeroCommitProject `json:"worst_zero_commit_project,omitempty"`
+
 	// CacheSavingsPercent is the cache savings as a percentage of total cost.
 	CacheSavingsPercent float64 `json:"cache_savings_percent"`
 
@@ -75,10 +93,80 @@ type ProjectContext struct {
 	ToolErrors              int      `json:"tool_errors"`
 	Interruptions           int      `json:"interruptions"`
 	Score                   float64  `json:"score"`
+	WeightedScore           float64  `json:"weighted_score"`
 	HasFacets               bool     `json:"has_facets"`
 	AgentCount              int      `json:"agent_count"`
 	SequentialCount         int      `json:"sequential_count"`
+	ParallelSavingsMinutes  float64  `json:"parallel_savings_minutes"`
 	ClaudeMDMissingSections []string `json:"claude_md_missing_sections,omitempty"`
+
+	// AgentTypeStats maps agent type to that type's task stats within this
+	// project.
+	AgentTypeStats map[string]ProjectAgentTypeStats `json:"agent_type_stats,omitempty"`
+
+	// SubagentOpportunity is set when analyzer.AnalyzeSubagentOpportunity
+	// found this project's long, exploration-heavy sessions rarely use
+	// agents.
+	SubagentOpportunity *ProjectSubagentOpportunity `json:"subagent_opportunity,omitempty"`
+
+	// ClaudeMDSavings is set for projects without a CLAUDE.md when
+	// analyzer.EstimateClaudeMDROI could estimate what adding one saves.
+	ClaudeMDSavings *ProjectClaudeMDSavings `json:"claude_md_savings,omitempty"`
+
+	// ZeroCommitRate is the fraction of this project's sessions with zero
+	// commits.
+	ZeroCommitRate float64 `json:"zero_commit_rate"`
+
+	// Thresholds are this project's limits for the per-project rules.
+	Thresholds ProjectThresholds `json:"thresholds"`
+}
+
+// ProjectThresholds are a project's limits for the per-project rules, from
+// its .claudewatch.yaml merged over the global config; see ThresholdsFor.
+// A zero field leaves its rule off for the project.
+type ProjectThresholds struct {
+	// HighErrorMultiplier is how many times the average tool errors per
+	// session the project must exceed to be flagged.
+	HighErrorMultiplier float64 `json:"high_error_multiplier,omitempty"`
+	// AgentKillRate is the kill rate above which an agent type is flagged.
+	AgentKillRate float64 `json:"agent_kill_rate,omitempty"`
+	// ZeroCommitRate is set only when the project overrides the global
+	// zero-commit threshold. The project is then judged on its own rate and
+	// left out of AnalysisContext.ZeroCommitRate.
+	ZeroCommitRate float64 `json:"zero_commit_rate,omitempty"`
+}
+
+// ProjectAgentTypeStats summarizes one agent type's tasks within a project.
+type ProjectAgentTypeStats struct {
+	Count    int     `json:"count"`
+	KillRate float64 `json:"kill_rate"`
+}
+
+// ProjectClaudeMDSavings is the estimated return on adding a CLAUDE.md to a
+// project, from how projects with one changed after writing it.
+type ProjectClaudeMDSavings struct {
+	MonthlySavings    float64 `json:"monthly_savings"`
+	FrictionReduction float64 `json:"friction_reduction"`
+	// References is how many projects the estimate is drawn from.
+	References int `json:"references"`
+	// Confidence is "low", "medium", or "high".
+	Confidence string `json:"confidence"`
+}
+
+// ZeroCommitProject is one project's share of sessions without a commit.
+type ZeroCommitProject struct {
+	Name           string  `json:"name"`
+	ZeroCommitRate float64 `json:"zero_commit_rate"`
+	Sessions       int     `json:"sessions"`
+}
+
+// ProjectSubagentOpportunity describes why a project would benefit from
+// delegating research to agents.
+type ProjectSubagentOpportunity struct {
+	AvgDurationMinutes    float64 `json:"avg_duration_minutes"`
+	ExplorationShare      float64 `json:"exploration_share"`
+	ExplorationPerSession float64 `json:"exploration_per_session"`
+	AgentSessionRate      float64 `json:"agent_session_rate"`
 }
 
 // Rule is a function that examines the analysis context and produces
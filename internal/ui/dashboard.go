@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/output"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Tab is one page of the interactive dashboard: a title for the tab bar and
+// the pre-rendered lines shown when it is selected.
+type Tab struct {
+	Title string
+	Lines []string
+}
+
+// LoadFunc builds the dashboard's tabs. It is called once at startup and
+// again whenever the user asks for a refresh.
+type LoadFunc func() ([]Tab, error)
+
+// dashboardAction is what Update should do after a key press.
+type dashboardAction int
+
+const (
+	actionNone dashboardAction = iota
+	actionRefresh
+	actionQuit
+)
+
+// dashboardChrome is the number of screen rows used by the tab bar, the rule
+// beneath it, and the footer.
+const dashboardChrome = 4
+
+// dashboard is the Bubble Tea model of the interactive dashboard. Its key
+// handling and rendering know nothing about the terminal, so they can be
+// tested without one.
+type dashboard struct {
+	title    string
+	load     LoadFunc
+	tabs     []Tab
+	active   int
+	offsets  []int
+	width    int
+	height   int
+	loading  bool
+	loadedAt time.Time
+	err      error
+}
+
+func newDashboard(title string) *dashboard {
+	return &dashboard{title: title, width: 80, height: 24}
+}
+
+// setTabs replaces the dashboard content after a load, keeping the selected
+// tab and scroll positions where they still make sense.
+func (d *dashboard) setTabs(tabs []Tab, err error, now time.Time) {
+	d.loading = false
+	d.err = err
+	if err != nil {
+		return
+	}
+	d.tabs = tabs
+	d.loadedAt = now
+	if d.active >= len(tabs) {
+		d.active = 0
+	}
+	offsets := make([]int, len(tabs))
+	copy(offsets, d.offsets)
+	d.offsets = offsets
+	for i := range d.offsets {
+		d.clampOffset(i)
+	}
+}
+
+// bodyHeight returns the number of content rows visible at once.
+func (d *dashboard) bodyHeight() int {
+	if h := d.height - dashboardChrome; h > 1 {
+		return h
+	}
+	return 1
+}
+
+func (d *dashboard) clampOffset(i int) {
+	maxOffset := len(d.tabs[i].Lines) - d.bodyHeight()
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	d.offsets[i] = min(max(d.offsets[i], 0), maxOffset)
+}
+
+func (d *dashboard) scroll(delta int) {
+	if len(d.tabs) == 0 {
+		return
+	}
+	d.offsets[d.active] += delta
+	d.clampOffset(d.active)
+}
+
+func (d *dashboard) selectTab(i int) {
+	if n := len(d.tabs); n > 0 {
+		d.active = (i%n + n) % n
+	}
+}
+
+// handleKey applies a key press, named as by tea.KeyMsg.String, and reports
+// what Update should do next.
+func (d *dashboard) handleKey(k string) dashboardAction {
+	switch k {
+	case "q", "esc", "ctrl+c":
+		return actionQuit
+	case "r":
+		return actionRefresh
+	case "left", "h", "shift+tab":
+		d.selectTab(d.active - 1)
+	case "right", "l", "tab":
+		d.selectTab(d.active + 1)
+	case "up", "k":
+		d.scroll(-1)
+	case "down", "j":
+		d.scroll(1)
+	case "pgup":
+		d.scroll(-d.bodyHeight())
+	case "pgdown", " ":
+		d.scroll(d.bodyHeight())
+	case "home", "g":
+		d.scroll(-len(d.activeLines()))
+	case "end", "G":
+		d.scroll(len(d.activeLines()))
+	default:
+		if len(k) == 1 && k[0] >= '1' && k[0] <= '9' {
+			if i := int(k[0] - '1'); i < len(d.tabs) {
+				d.active = i
+			}
+		}
+	}
+	return actionNone
+}
+
+func (d *dashboard) activeLines() []string {
+	if len(d.tabs) == 0 {
+		return nil
+	}
+	return d.tabs[d.active].Lines
+}
+
+// view renders the full screen as lines no wider than the terminal.
+func (d *dashboard) view() []string {
+	fit := lipgloss.NewStyle().MaxWidth(d.width)
+
+	bar := []string{output.StyleHeader.Render(d.title)}
+	for i, t := range d.tabs {
+		label := fmt.Sprintf("%d %s", i+1, t.Title)
+		if i == d.active {
+			bar = append(bar, output.StyleBold.Render("["+label+"]"))
+		} else {
+			bar = append(bar, output.StyleMuted.Render(" "+label+" "))
+		}
+	}
+
+	screen := make([]string, 0, d.height)
+	screen = append(screen, fit.Render(strings.Join(bar, "  ")))
+	screen = append(screen, output.StyleMuted.Render(strings.Repeat("─", max(d.width, 1))))
+
+	body := d.bodyHeight()
+	switch {
+	case len(d.tabs) == 0 && d.err != nil:
+		screen = append(screen, fit.Render(output.StyleError.Render(" "+d.err.Error())))
+	case len(d.tabs) == 0:
+		screen = append(screen, output.StyleMuted.Render(" Loading..."))
+	default:
+		lines := d.activeLines()
+		start := d.offsets[d.active]
+		end := min(start+body, len(lines))
+		for _, line := range lines[start:end] {
+			screen = append(screen, fit.Render(line))
+		}
+	}
+	for len(screen) < body+2 {
+		screen = append(screen, "")
+	}
+
+	screen = append(screen, "", fit.Render(d.footer()))
+	return screen
+}
+
+func (d *dashboard) footer() string {
+	help := "←/→ tabs · ↑/↓ scroll · r refresh · q quit"
+	var status string
+	switch {
+	case d.loading:
+		status = "refreshing..."
+	case d.err != nil && len(d.tabs) > 0:
+		status = output.StyleError.Render("refresh failed: " + d.err.Error())
+	case !d.loadedAt.IsZero():
+		status = "updated " + d.loadedAt.Format("15:04:05")
+	}
+	if n := len(d.activeLines()); n > d.bodyHeight() {
+		start := d.offsets[d.active] + 1
+		end := min(d.offsets[d.active]+d.bodyHeight(), n)
+		status = strings.TrimSpace(fmt.Sprintf("%s  lines %d-%d of %d", status, start, end, n))
+	}
+	return output.StyleMuted.Render(" " + help + "   " + status)
+}
+
+// dashboardLoaded carries the result of a load back to Update.
+type dashboardLoaded struct {
+	tabs []Tab
+	err  error
+	at   time.Time
+}
+
+// loadCmd runs the load function off the UI loop.
+func (d *dashboard) loadCmd() tea.Cmd {
+	load := d.load
+	return func() tea.Msg {
+		tabs, err := load()
+		return dashboardLoaded{tabs: tabs, err: err, at: time.Now()}
+	}
+}
+
+// Init starts the first load.
+func (d *dashboard) Init() tea.Cmd {
+	d.loading = true
+	return d.loadCmd()
+}
+
+// Update applies a Bubble Tea message: a finished load, a terminal resize,
+// or a key press.
+func (d *dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dashboardLoaded:
+		d.setTabs(msg.tabs, msg.err, msg.at)
+	case tea.WindowSizeMsg:
+		d.width, d.height = msg.Width, msg.Height
+		for i := range d.tabs {
+			d.clampOffset(i)
+		}
+	case tea.KeyMsg:
+		switch d.handleKey(msg.String()) {
+		case actionQuit:
+			return d, tea.Quit
+		case actionRefresh:
+			// A refresh already in flight will bring the same data.
+			if !d.loading {
+				d.loading = true
+				return d, d.loadCmd()
+			}
+		}
+	}
+	return d, nil
+}
+
+// View renders the full screen.
+func (d *dashboard) View() string {
+	return strings.Join(d.view(), "\n")
+}
+
+// RunDashboard shows a full-screen, read-only dashboard of tabs built by
+// load, until the user quits. It needs an interactive terminal on both stdin
+// and stdout and returns ErrNotTTY otherwise.
+func RunDashboard(title string, load LoadFunc) error {
+	if !IsTTY() {
+		return ErrNotTTY
+	}
+	d := newDashboard(title)
+	d.load = load
+	if _, err := tea.NewProgram(d, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("running dashboard: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testTabs() []Tab {
+	lines := make([]string, 50)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	return []Tab{
+		{Title: "Metrics", Lines: []string{"a", "b"}},
+		{Title: "Sessions", Lines: lines},
+		{Title: "Gaps"},
+	}
+}
+
+func TestDashboard_Update(t *testing.T) {
+	loads := 0
+	d := newDashboard("test")
+	d.load = func() ([]Tab, error) {
+		loads++
+		return testTabs(), nil
+	}
+
+	cmd := d.Init()
+	if !d.loading || cmd == nil {
+		t.Fatal("Init should start a load")
+	}
+	// A refresh while the first load is in flight is dropped.
+	if _, again := d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); again != nil {
+		t.Error("refresh during a load started another one")
+	}
+	d.Update(cmd())
+	if loads != 1 || d.loading || len(d.tabs) != 3 {
+		t.Fatalf("after load: %d loads, loading %v, %d tabs; want 1, false, 3", loads, d.loading, len(d.tabs))
+	}
+
+	d.Update(tea.WindowSizeMsg{Width: 40, Height: 14})
+	if d.width != 40 || d.bodyHeight() != 10 {
+		t.Errorf("after resize: width %d, body %d; want 40, 10", d.width, d.bodyHeight())
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	d.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if d.active != 1 || d.offsets[1] != 10 {
+		t.Errorf("after right, pgdown: tab %d offset %d; want 1, 10", d.active, d.offsets[1])
+	}
+
+	if _, cmd := d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd == nil || !d.loading {
+		t.Error("refresh did not start a load")
+	} else {
+		d.Update(cmd())
+	}
+	if loads != 2 {
+		t.Errorf("loads = %d, want 2", loads)
+	}
+
+	if _, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd == nil || !reflect.DeepEqual(cmd(), tea.Quit()) {
+		t.Error("esc did not quit")
+	}
+}
+
+func TestDashboard_TabNavigation(t *testing.T) {
+	d := newDashboard("test")
+	d.setTabs(testTabs(), nil, time.Now())
+
+	d.handleKey("left")
+	if d.active != 2 {
+		t.Errorf("left from first tab: active = %d, want 2 (wrap)", d.active)
+	}
+	d.handleKey("right")
+	if d.active != 0 {
+		t.Errorf("right from last tab: active = %d, want 0 (wrap)", d.active)
+	}
+	d.handleKey("2")
+	if d.active != 1 {
+		t.Errorf("digit 2: active = %d, want 1", d.active)
+	}
+	d.handleKey("9")
+	if d.active != 1 {
+		t.Errorf("out-of-range digit changed active tab to %d", d.active)
+	}
+}
+
+func TestDashboard_ScrollClamps(t *testing.T) {
+	d := newDashboard("test")
+	d.height = 14 // 10 body rows
+	d.setTabs(testTabs(), nil, time.Now())
+	d.handleKey("2")
+
+	d.handleKey("up")
+	if d.offsets[1] != 0 {
+		t.Errorf("scrolling above top: offset = %d, want 0", d.offsets[1])
+	}
+	d.handleKey("pgdown")
+	if d.offsets[1] != 10 {
+		t.Errorf("pgdown: offset = %d, want 10", d.offsets[1])
+	}
+	d.handleKey("end")
+	if d.offsets[1] != 40 {
+		t.Errorf("end: offset = %d, want 40", d.offsets[1])
+	}
+	d.handleKey("down")
+	if d.offsets[1] != 40 {
+		t.Errorf("scrolling past bottom: offset = %d, want 40", d.offsets[1])
+	}
+
+	// Short tabs never scroll.
+	d.handleKey("1")
+	d.handleKey("down")
+	if d.offsets[0] != 0 {
+		t.Errorf("short tab scrolled to %d", d.offsets[0])
+	}
+}
+
+func TestDashboard_Actions(t *testing.T) {
+	d := newDashboard("test")
+	for _, k := range []string{"q", "esc", "ctrl+c"} {
+		if got := d.handleKey(k); got != actionQuit {
+			t.Errorf("handleKey(%q) = %v, want actionQuit", k, got)
+		}
+	}
+	if got := d.handleKey("r"); got != actionRefresh {
+		t.Errorf("handleKey(r) = %v, want actionRefresh", got)
+	}
+	if got := d.handleKey("down"); got != actionNone {
+		t.Errorf("handleKey(down) with no tabs = %v, want actionNone", got)
+	}
+}
+
+func TestDashboard_FailedRefreshKeepsContent(t *testing.T) {
+	d := newDashboard("test")
+	d.setTabs(testTabs(), nil, time.Now())
+	d.handleKey("2")
+
+	d.setTabs(nil, errors.New("boom"), time.Now())
+	if len(d.tabs) != 3 || d.active != 1 {
+		t.Fatalf("failed refresh replaced content: %d tabs, active %d", len(d.tabs), d.active)
+	}
+	if !strings.Contains(d.footer(), "refresh failed: boom") {
+		t.Errorf("footer does not report the error: %q", d.footer())
+	}
+}
+
+func TestDashboard_ViewFillsScreen(t *testing.T) {
+	d := newDashboard("claudewatch")
+	d.width, d.height = 60, 20
+	d.setTabs(testTabs(), nil, time.Now())
+
+	screen := d.view()
+	if len(screen) != d.height {
+		t.Errorf("view has %d lines, want %d", len(screen), d.height)
+	}
+	for _, title := range []string{"Metrics", "Sessions", "Gaps"} {
+		if !strings.Contains(screen[0], title) {
+			t.Errorf("tab bar missing %q: %q", title, screen[0])
+		}
+	}
+
+	// A long line is cut to the terminal width.
+	d.tabs[0].Lines = []string{strings.Repeat("x", 200)}
+	if got := d.view()[2]; len(got) > d.width {
+		t.Errorf("body line is %d columns wide, want <= %d", len(got), d.width)
+	}
+}
+
+func TestDashboard_ViewBeforeFirstLoad(t *testing.T) {
+	d := newDashboard("claudewatch")
+	if screen := d.view(); !strings.Contains(strings.Join(screen, "\n"), "Loading") {
+		t.Error("expected a loading message before the first load")
+	}
+
+	d.setTabs(nil, errors.New("no claude home"), time.Now())
+	if screen := d.view(); !strings.Contains(strings.Join(screen, "\n"), "no claude home") {
+		t.Error("expected the load error in the body when nothing has loaded")
+	}
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// spinnerFrames are drawn in turn, one per progress tick.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// progressInterval is how often the spinner advances.
+const progressInterval = 100 * time.Millisecond
+
+// Progress draws a spinner and the current phase on a single line, redrawn
+// in place, until Stop is called. A nil *Progress is valid and draws
+// nothing, so callers can disable progress without checking at each phase.
+type Progress struct {
+	w     io.Writer
+	mu    sync.Mutex
+	phase string
+	frame int
+	drawn bool
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// StartProgress starts a spinner on w, which should be a terminal (usually
+// stderr). The spinner runs on its own goroutine until Stop.
+func StartProgress(w io.Writer) *Progress {
+	p := &Progress{w: w, done: make(chan struct{})}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				p.frame++
+				p.draw()
+				p.mu.Unlock()
+			}
+		}
+	}()
+	return p
+}
+
+// Phase replaces the phase shown next to the spinner.
+func (p *Progress) Phase(name string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.phase = name
+	p.draw()
+}
+
+// Stop halts the spinner and erases its line. It is safe to call more than
+// once.
+func (p *Progress) Stop() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	select {
+	case <-p.done:
+		p.mu.Unlock()
+		return
+	default:
+		close(p.done)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clear()
+}
+
+// draw redraws the line; p.mu must be held.
+func (p *Progress) draw() {
+	if p.phase == "" {
+		return
+	}
+	line := fmt.Sprintf("%s %s...", spinnerFrames[p.frame%len(spinnerFrames)], p.phase)
+	p.clear()
+	_, _ = io.WriteString(p.w, line)
+	p.drawn = true
+}
+
+// clear erases the last drawn line; p.mu must be held.
+func (p *Progress) clear() {
+	if !p.drawn {
+		return
+	}
+	_, _ = io.WriteString(p.w, "\r\x1b[K")
+	p.drawn = false
+}
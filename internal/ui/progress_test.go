@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer is a bytes.Buffer safe for the spinner goroutine to write to.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestProgress_PhaseAndStop(t *testing.T) {
+	var out syncBuffer
+	p := StartProgress(&out)
+	p.Phase("Parsing sessions")
+	p.Phase("Analyzing")
+	p.Stop()
+	p.Stop() // idempotent
+
+	got := out.String()
+	if !strings.Contains(got, "Parsing sessions...") || !strings.Contains(got, "Analyzing...") {
+		t.Errorf("expected both phases in output, got %q", got)
+	}
+	if !strings.HasSuffix(got, "\r\x1b[K") {
+		t.Errorf("expected Stop to erase the line, got %q", got)
+	}
+}
+
+func TestProgress_NilIsNoop(t *testing.T) {
+	var p *Progress
+	p.Phase("Parsing sessions")
+	p.Stop()
+}
@@ -14,3 +14,15 @@ import (
 func IsTTY() bool {
 	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
 }
+
+// IsStdoutTTY returns true if stdout is connected to a terminal. Use this for
+// output-only decisions such as colorizing, where piped stdin is irrelevant.
+func IsStdoutTTY() bool {
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// IsStderrTTY returns true if stderr is connected to a terminal. Use this for
+// progress output, which goes to stderr so it never mixes with stdout.
+func IsStderrTTY() bool {
+	return isatty.IsTerminal(os.Stderr.Fd())
+}
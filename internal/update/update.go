@@ -0,0 +1,289 @@
+// Package update checks GitHub for newer claudewatch releases.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/guard"
+)
+
+const (
+	// DefaultReleasesURL is the GitHub API endpoint for the latest release.
+	DefaultReleasesURL = "https://api.github.com/repos/blackwell-systems/claudewatch/releases/latest"
+
+	// CacheFileName is the file in the config dir holding the last result.
+	CacheFileName = "update-check.json"
+
+	// CacheTTL is how long a cached result is trusted before asking GitHub
+	// again.
+	CacheTTL = 24 * time.Hour
+
+	requestTimeout = 5 * time.Second
+)
+
+// Release is the subset of a GitHub release used for the check.
+type Release struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// cacheEntry is the on-disk form of the last successful check.
+type cacheEntry struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Release   Release   `json:"release"`
+}
+
+// Result describes how the running version compares to the latest release.
+type Result struct {
+	CurrentVersion  string    `json:"current_version"`
+	LatestVersion   string    `json:"latest_version"`
+	ReleaseURL      string    `json:"release_url,omitempty"`
+	UpdateAvailable bool      `json:"update_available"`
+	CheckedAt       time.Time `json:"checked_at"`
+	Cached          bool      `json:"cached"`
+}
+
+// Checker fetches the latest release, caching successful lookups on disk.
+type Checker struct {
+	// URL is the releases endpoint; DefaultReleasesURL when empty.
+	URL string
+	// CachePath is where results are cached; caching is skipped when empty.
+	CachePath string
+	// UserAgent is sent with the request, as GitHub's API requires one.
+	UserAgent string
+	// Client performs the request; a client with a short timeout is used
+	// when nil.
+	Client *http.Client
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+}
+
+// NewChecker returns a Checker for the public GitHub API that caches results
+// in configDir.
+func NewChecker(configDir, currentVersion string) *Checker {
+	return &Checker{
+		URL:       DefaultReleasesURL,
+		CachePath: filepath.Join(configDir, CacheFileName),
+		UserAgent: "claudewatch/" + currentVersion,
+	}
+}
+
+// Check compares currentVersion with the latest release. A cached result
+// younger than CacheTTL is used unless force is set. Errors are returned for
+// the caller to report or ignore; nothing is cached on failure.
+func (c *Checker) Check(ctx context.Context, currentVersion string, force bool) (Result, error) {
+	now := c.now()
+
+	if !force {
+		if entry, ok := c.loadCache(now); ok {
+			return newResult(currentVersion, entry, true), nil
+		}
+	}
+
+	rel, err := c.fetch(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	entry := cacheEntry{CheckedAt: now, Release: rel}
+	// A cache write failure only costs an extra request next time.
+	_ = c.saveCache(entry)
+	return newResult(currentVersion, entry, false), nil
+}
+
+func newResult(currentVersion string, entry cacheEntry, cached bool) Result {
+	return Result{
+		CurrentVersion:  currentVersion,
+		LatestVersion:   entry.Release.TagName,
+		ReleaseURL:      entry.Release.HTMLURL,
+		UpdateAvailable: IsNewer(entry.Release.TagName, currentVersion),
+		CheckedAt:       entry.CheckedAt,
+		Cached:          cached,
+	}
+}
+
+func (c *Checker) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Checker) fetch(ctx context.Context) (Release, error) {
+	url := c.URL
+	if url == "" {
+		url = DefaultReleasesURL
+	}
+	client := c.Client
+	if client == nil {
+		client = guard.HTTPClient(requestTimeout)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Release{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Release{}, fmt.Errorf("fetching latest release: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("fetching latest release: %s", resp.Status)
+	}
+
+	var rel Release
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return Release{}, fmt.Errorf("decoding latest release: %w", err)
+	}
+	if rel.TagName == "" {
+		return Release{}, fmt.Errorf("latest release has no tag")
+	}
+	return rel, nil
+}
+
+func (c *Checker) loadCache(now time.Time) (cacheEntry, bool) {
+	if c.CachePath == "" {
+		return cacheEntry{}, false
+	}
+	data, err := os.ReadFile(c.CachePath)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Release.TagName == "" {
+		return cacheEntry{}, false
+	}
+	age := now.Sub(entry.CheckedAt)
+	if age < 0 || age >= CacheTTL {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *Checker) saveCache(entry cacheEntry) error {
+	if c.CachePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.CachePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.CachePath, data, 0o644)
+}
+
+// describeSuffix matches the "-N-gHASH" suffix git describe appends to builds
+// made after a tag, optionally followed by "-dirty".
+var describeSuffix = regexp.MustCompile(`^\d+-g[0-9a-f]+(-dirty)?$`)
+
+// version is a parsed release version: numeric core plus an optional
+// pre-release tag. Builds past a tag (git describe) sort after the tag.
+type version struct {
+	core       [3]int
+	prerelease string
+	postTag    bool
+}
+
+// parseVersion parses "v1.2.3", "1.2", "1.2.3-rc1", or a git describe string
+// such as "v1.2.3-4-gabc1234". It reports false for anything without a
+// numeric core, such as "dev".
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	core, suffix, _ := strings.Cut(s, "-")
+	core, _, _ = strings.Cut(core, "+") // build metadata doesn't affect order
+
+	parts := strings.Split(core, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return version{}, false
+	}
+	var v version
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		v.core[i] = n
+	}
+
+	switch {
+	case suffix == "":
+	case describeSuffix.MatchString(suffix):
+		v.postTag = true
+	default:
+		v.prerelease = suffix
+	}
+	return v, true
+}
+
+// compare returns -1, 0, or 1 as a is older than, equal to, or newer than b.
+func (a version) compare(b version) int {
+	for i := range a.core {
+		if a.core[i] != b.core[i] {
+			if a.core[i] < b.core[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	rank := func(v version) int {
+		switch {
+		case v.prerelease != "":
+			return 0
+		case v.postTag:
+			return 2
+		default:
+			return 1
+		}
+	}
+	ra, rb := rank(a), rank(b)
+	switch {
+	case ra != rb:
+		if ra < rb {
+			return -1
+		}
+		return 1
+	case a.prerelease < b.prerelease:
+		return -1
+	case a.prerelease > b.prerelease:
+		return 1
+	}
+	return 0
+}
+
+// IsNewer reports whether latest is a strictly newer release than current.
+// Unparseable versions (e.g. "dev") are never considered outdated.
+func IsNewer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	return l.compare(c) > 0
+}
+
+// Comparable reports whether v is a release version IsNewer can compare.
+func Comparable(v string) bool {
+	_, ok := parseVersion(v)
+	return ok
+}
@@ -0,0 +1,143 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T, tag string, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("request missing User-Agent")
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"tag_name":"` + tag + `","html_url":"https://example.com/releases/` + tag + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func newTestChecker(t *testing.T, url string, now *time.Time) *Checker {
+	t.Helper()
+	return &Checker{
+		URL:       url,
+		CachePath: filepath.Join(t.TempDir(), CacheFileName),
+		UserAgent: "claudewatch/test",
+		Now:       func() time.Time { return *now },
+	}
+}
+
+func TestCheck_UpdateAvailable(t *testing.T) {
+	srv, _ := newTestServer(t, "v0.17.0", http.StatusOK)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestChecker(t, srv.URL, &now)
+
+	r, err := c.Check(context.Background(), "0.16.0", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !r.UpdateAvailable || r.LatestVersion != "v0.17.0" || r.Cached {
+		t.Errorf("unexpected result: %+v", r)
+	}
+	if r.ReleaseURL != "https://example.com/releases/v0.17.0" {
+		t.Errorf("ReleaseURL = %q", r.ReleaseURL)
+	}
+}
+
+func TestCheck_CachesFor24Hours(t *testing.T) {
+	srv, hits := newTestServer(t, "v0.16.0", http.StatusOK)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestChecker(t, srv.URL, &now)
+
+	if _, err := c.Check(context.Background(), "0.16.0", false); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(23 * time.Hour)
+	r, err := c.Check(context.Background(), "0.16.0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Cached || hits.Load() != 1 {
+		t.Errorf("expected cached result within TTL; cached=%v hits=%d", r.Cached, hits.Load())
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := c.Check(context.Background(), "0.16.0", false); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected refetch after TTL, got %d hits", hits.Load())
+	}
+}
+
+func TestCheck_ForceBypassesCache(t *testing.T) {
+	srv, hits := newTestServer(t, "v0.16.0", http.StatusOK)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestChecker(t, srv.URL, &now)
+
+	for range 2 {
+		if _, err := c.Check(context.Background(), "0.16.0", true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected --force to hit the API each time, got %d hits", hits.Load())
+	}
+}
+
+func TestCheck_ErrorNotCached(t *testing.T) {
+	srv, hits := newTestServer(t, "", http.StatusForbidden)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestChecker(t, srv.URL, &now)
+
+	if _, err := c.Check(context.Background(), "0.16.0", false); err == nil {
+		t.Fatal("expected error for non-200 response")
+	}
+	if _, err := c.Check(context.Background(), "0.16.0", false); err == nil {
+		t.Fatal("expected error for non-200 response")
+	}
+	if hits.Load() != 2 {
+		t.Errorf("failed checks should not be cached; got %d hits", hits.Load())
+	}
+}
+
+func TestCheck_Unreachable(t *testing.T) {
+	now := time.Now()
+	c := newTestChecker(t, "http://127.0.0.1:1", &now)
+	if _, err := c.Check(context.Background(), "0.16.0", false); err == nil {
+		t.Fatal("expected error for unreachable server")
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v0.17.0", "0.16.0", true},
+		{"v0.16.0", "v0.16.0", false},
+		{"v0.16.0", "0.17.0", false},
+		{"v1.0.0", "0.99.9", true},
+		{"v0.16.1", "0.16", true},
+		{"v0.16.0", "0.16.0-rc1", true},
+		{"v0.16.0-rc2", "0.16.0-rc1", true},
+		{"v0.16.0", "v0.16.0-3-gabc1234", false},
+		{"v0.16.0", "v0.16.0-3-gabc1234-dirty", false},
+		{"v0.17.0", "v0.16.0-3-gabc1234", true},
+		{"v0.17.0", "dev", false},
+		{"nightly", "0.16.0", false},
+	}
+	for _, tt := range tests {
+		if got := IsNewer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package watcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"time"
+)
+
+// AckBreakthroughRatio is how far an acknowledged alert's Value must grow
+// past the acknowledged Value before the alert is shown again.
+const AckBreakthroughRatio = 0.20
+
+// maxRecentAlerts bounds how many alerts the baseline remembers for ack
+// lookup.
+const maxRecentAlerts = 50
+
+// AlertSignature returns the stable identifier of an alert: a short hash of
+// its level and title. The message is left out because it carries counts
+// that change from check to check.
+func AlertSignature(level, title string) string {
+	sum := sha256.Sum256([]byte(level + "\x00" + title))
+	return hex.EncodeToString(sum[:4])
+}
+
+// Ack records that the user has seen an alert. The watcher suppresses
+// alerts with the same signature until the condition materially worsens.
+type Ack struct {
+	Signature string    `json:"signature"`
+	Level     string    `json:"level"`
+	Title     string    `json:"title"`
+	Value     float64   `json:"value"`
+	AckedAt   time.Time `json:"acked_at"`
+}
+
+// NewAck acknowledges a at its current Value.
+func NewAck(a Alert, now time.Time) Ack {
+	return Ack{
+		Signature: a.Signature,
+		Level:     a.Level,
+		Title:     a.Title,
+		Value:     a.Value,
+		AckedAt:   now,
+	}
+}
+
+// Suppresses reports whether k hides a: a has k's signature and its Value is
+// within AckBreakthroughRatio of the acknowledged Value. A different level
+// gives a different signature, so an escalation always breaks through.
+func (k Ack) Suppresses(a Alert) bool {
+	if a.Signature != k.Signature {
+		return false
+	}
+	return a.Value <= k.Value*(1+AckBreakthroughRatio)
+}
+
+// LoadAcks reads acknowledgments keyed by signature. A missing file means no
+// acknowledgments.
+func LoadAcks(path string) (map[string]Ack, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]Ack{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []Ack
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	acks := make(map[string]Ack, len(list))
+	for _, k := range list {
+		acks[k.Signature] = k
+	}
+	return acks, nil
+}
+
+// SaveAcks atomically writes acks to path, ordered by acknowledgment time.
+func SaveAcks(path string, acks map[string]Ack) error {
+	list := make([]Ack, 0, len(acks))
+	for _, k := range acks {
+		list = append(list, k)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].AckedAt.Equal(list[j].AckedAt) {
+			return list[i].AckedAt.Before(list[j].AckedAt)
+		}
+		return list[i].Signature < list[j].Signature
+	})
+	return writeJSONAtomic(path, "watch-acks-*.json", list)
+}
+
+// filterAcked drops alerts suppressed by acks.
+func filterAcked(alerts []Alert, acks map[string]Ack) []Alert {
+	if len(acks) == 0 {
+		return alerts
+	}
+	var kept []Alert
+	for _, a := range alerts {
+		if k, ok := acks[a.Signature]; ok && k.Suppresses(a) {
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
+
+// rememberAlerts merges alerts into recent, keeping the latest alert per
+// signature and at most maxRecentAlerts, newest first.
+func rememberAlerts(recent, alerts []Alert) []Alert {
+	bySig := make(map[string]Alert, len(recent)+len(alerts))
+	for _, a := range recent {
+		bySig[a.Signature] = a
+	}
+	for _, a := range alerts {
+		bySig[a.Signature] = a
+	}
+	merged := make([]Alert, 0, len(bySig))
+	for _, a := range bySig {
+		merged = append(merged, a)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if !merged[i].Time.Equal(merged[j].Time) {
+			return merged[i].Time.After(merged[j].Time)
+		}
+		return merged[i].Signature < merged[j].Signature
+	})
+	if len(merged) > maxRecentAlerts {
+		merged = merged[:maxRecentAlerts]
+	}
+	return merged
+}
@@ -0,0 +1,189 @@
+package watcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAlertSignature_StableAndLevelSensitive(t *testing.T) {
+	a := AlertSignature("critical", "Stale friction: wrong_approach")
+	if a != AlertSignature("critical", "Stale friction: wrong_approach") {
+		t.Error("expected the same level and title to give the same signature")
+	}
+	if len(a) != 8 {
+		t.Errorf("expected an 8-character signature, got %q", a)
+	}
+	if a == AlertSignature("warning", "Stale friction: wrong_approach") {
+		t.Error("expected a different level to give a different signature")
+	}
+	if a == AlertSignature("critical", "Stale friction: buggy_code") {
+		t.Error("expected a different title to give a different signature")
+	}
+}
+
+func TestCompare_AlertsCarrySignatureAndValue(t *testing.T) {
+	prev := makeState()
+	prev.FrictionCounts["wrong_approach"] = 5
+	curr := makeState()
+	curr.FrictionCounts["wrong_approach"] = 10
+
+	alerts := Compare(prev, curr)
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(alerts))
+	}
+	a := alerts[0]
+	if a.Signature != AlertSignature(a.Level, a.Title) {
+		t.Errorf("signature %q does not match level and title", a.Signature)
+	}
+	if a.Value != 10 {
+		t.Errorf("expected value 10, got %v", a.Value)
+	}
+}
+
+func TestAck_Suppresses(t *testing.T) {
+	alert := newAlert("warning", "Friction spike: wrong_approach", "Increased from 5 to 10 (+100%)", 10, time.Now())
+	ack := NewAck(alert, time.Now())
+
+	if !ack.Suppresses(alert) {
+		t.Error("expected the acknowledged alert to be suppressed")
+	}
+
+	slightlyWorse := alert
+	slightlyWorse.Value = 12
+	if !ack.Suppresses(slightlyWorse) {
+		t.Error("expected a change within the breakthrough ratio to stay suppressed")
+	}
+
+	muchWorse := alert
+	muchWorse.Value = 13
+	if ack.Suppresses(muchWorse) {
+		t.Error("expected a material worsening to break through")
+	}
+
+	escalated := newAlert("critical", alert.Title, alert.Message, 10, time.Now())
+	if ack.Suppresses(escalated) {
+		t.Error("expected an escalated level to break through")
+	}
+}
+
+func TestAcks_SaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "watch-acks.json")
+
+	acks, err := LoadAcks(path)
+	if err != nil {
+		t.Fatalf("LoadAcks on missing file: %v", err)
+	}
+	if len(acks) != 0 {
+		t.Errorf("expected no acks from a missing file, got %d", len(acks))
+	}
+
+	a := newAlert("critical", "High zero-commit rate", "100% of last 5 sessions produced no commits", 1, time.Now())
+	acks[a.Signature] = NewAck(a, time.Now())
+	if err := SaveAcks(path, acks); err != nil {
+		t.Fatalf("SaveAcks: %v", err)
+	}
+
+	loaded, err := LoadAcks(path)
+	if err != nil {
+		t.Fatalf("LoadAcks: %v", err)
+	}
+	got, ok := loaded[a.Signature]
+	if !ok {
+		t.Fatalf("ack %s not restored: %v", a.Signature, loaded)
+	}
+	if got.Title != a.Title || got.Level != a.Level || got.Value != 1 {
+		t.Errorf("unexpected restored ack: %+v", got)
+	}
+}
+
+func TestRememberAlerts_LatestPerSignatureAndBounded(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := newAlert("warning", "Friction spike: x", "a", 5, base)
+	later := newAlert("warning", "Friction spike: x", "b", 8, base.Add(time.Minute))
+
+	recent := rememberAlerts([]Alert{first}, []Alert{later})
+	if len(recent) != 1 || recent[0].Value != 8 {
+		t.Fatalf("expected the later alert to replace the earlier one, got %+v", recent)
+	}
+
+	var many []Alert
+	for i := 0; i < maxRecentAlerts+10; i++ {
+		many = append(many, newAlert("info", fmt.Sprintf("New project: p%d", i), "", 0, base.Add(time.Duration(i)*time.Second)))
+	}
+	recent = rememberAlerts(nil, many)
+	if len(recent) != maxRecentAlerts {
+		t.Fatalf("expected %d recent alerts, got %d", maxRecentAlerts, len(recent))
+	}
+	if recent[0].Title != fmt.Sprintf("New project: p%d", maxRecentAlerts+9) {
+		t.Errorf("expected newest alert first, got %q", recent[0].Title)
+	}
+}
+
+func TestWatcher_CheckSuppressesAcknowledgedAlerts(t *testing.T) {
+	dir := t.TempDir()
+	createZeroCommitSessions(t, dir, 6)
+
+	w := New(dir, 5*time.Minute, nil)
+	w.BaselinePath = filepath.Join(t.TempDir(), "watch-baseline.json")
+	w.AckPath = filepath.Join(t.TempDir(), "watch-acks.json")
+	initial, err := w.Snapshot()
+	if err != nil {
+		t.Fatalf("initial snapshot error: %v", err)
+	}
+	w.previous = initial
+
+	var zeroCommit *Alert
+	for _, a := range w.Check() {
+		if a.Title == "High zero-commit rate" {
+			zeroCommit = &a
+		}
+	}
+	if zeroCommit == nil {
+		t.Fatal("expected a zero-commit alert before acknowledging")
+	}
+
+	// The alert is recorded in the baseline for ack lookup.
+	b, err := LoadBaseline(w.BaselinePath)
+	if err != nil {
+		t.Fatalf("LoadBaseline: %v", err)
+	}
+	found := false
+	for _, a := range b.RecentAlerts {
+		if a.Signature == zeroCommit.Signature {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected alert %s in the baseline's recent alerts", zeroCommit.Signature)
+	}
+
+	if err := SaveAcks(w.AckPath, map[string]Ack{zeroCommit.Signature: NewAck(*zeroCommit, time.Now())}); err != nil {
+		t.Fatalf("SaveAcks: %v", err)
+	}
+
+	// Clear deduplication so only the ack can hide the repeat.
+	w.lastAlertKeys = map[string]bool{}
+	for _, a := range w.Check() {
+		if a.Signature == zeroCommit.Signature {
+			t.Errorf("expected acknowledged alert to be suppressed, got %s", a.Title)
+		}
+	}
+
+	// An unreadable ack file suppresses nothing.
+	if err := os.WriteFile(w.AckPath, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w.lastAlertKeys = map[string]bool{}
+	shown := false
+	for _, a := range w.Check() {
+		if a.Signature == zeroCommit.Signature {
+			shown = true
+		}
+	}
+	if !shown {
+		t.Error("expected the alert to show when acknowledgments can't be read")
+	}
+}
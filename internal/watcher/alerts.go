@@ -5,11 +5,13 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
 	"github.com/blackwell-systems/claudewatch/internal/claude"
 )
 
 // Compare detects notable changes between two watch states and returns alerts.
-// It checks for critical, warning, and info-level changes.
+// It checks for critical, warning, and info-level changes. Every alert carries
+// a stable Signature and, where the condition has a magnitude, a Value.
 func Compare(prev, curr *WatchState) []Alert {
 	var alerts []Alert
 
@@ -20,6 +22,18 @@ func Compare(prev, curr *WatchState) []Alert {
 	return alerts
 }
 
+// newAlert builds an alert and its signature.
+func newAlert(level, title, message string, value float64, now time.Time) Alert {
+	return Alert{
+		Level:     level,
+		Title:     title,
+		Message:   message,
+		Time:      now,
+		Signature: AlertSignature(level, title),
+		Value:     value,
+	}
+}
+
 // compareCritical detects critical-level changes.
 func compareCritical(prev, curr *WatchState) []Alert {
 	var alerts []Alert
@@ -37,37 +51,28 @@ func compareCritical(prev, curr *WatchState) []Alert {
 		}
 		for _, p := range curr.persistence.Patterns {
 			if p.Stale && !prevStaleTypes[p.FrictionType] {
-				alerts = append(alerts, Alert{
-					Level:   "critical",
-					Title:   fmt.Sprintf("Stale friction: %s", p.FrictionType),
-					Message: fmt.Sprintf("Persisted for %d consecutive weeks without improvement (%d occurrences)", p.ConsecutiveWeeks, p.OccurrenceCount),
-					Time:    now,
-				})
+				alerts = append(alerts, newAlert("critical", fmt.Sprintf("Stale friction: %s", p.FrictionType),
+					fmt.Sprintf("Persisted for %d consecutive weeks without improvement (%d occurrences)", p.ConsecutiveWeeks, p.OccurrenceCount),
+					float64(p.OccurrenceCount), now))
 			}
 		}
 		if len(alerts) == 0 && newStale > 0 {
-			alerts = append(alerts, Alert{
-				Level:   "critical",
-				Title:   "New stale friction detected",
-				Message: fmt.Sprintf("%d friction pattern(s) now stale (3+ weeks without improvement)", newStale),
-				Time:    now,
-			})
+			alerts = append(alerts, newAlert("critical", "New stale friction detected",
+				fmt.Sprintf("%d friction pattern(s) now stale (%d+ weeks without improvement)", newStale, curr.persistence.StaleWeeks),
+				float64(curr.StalePatterns), now))
 		}
 	}
 
 	// Agent kill rate spiked above 30% in recent sessions.
 	if curr.agentKillRate > 0.30 && prev.agentKillRate <= 0.30 && curr.AgentCount > 0 {
-		alerts = append(alerts, Alert{
-			Level:   "critical",
-			Title:   "Agent kill rate spike",
-			Message: fmt.Sprintf("Kill rate is %.0f%% (was %.0f%%), suggesting agents are failing or being interrupted", curr.agentKillRate*100, prev.agentKillRate*100),
-			Time:    now,
-		})
+		alerts = append(alerts, newAlert("critical", "Agent kill rate spike",
+			fmt.Sprintf("Kill rate is %.0f%% (was %.0f%%), suggesting agents are failing or being interrupted", curr.agentKillRate*100, prev.agentKillRate*100),
+			curr.agentKillRate, now))
 	}
 
 	// Zero-commit rate above 80% over last 5 non-trivial sessions.
-	// Filter out short Q&A sessions (< 5 messages and < 10 min) that aren't coding sessions.
-	nonTrivial := filterNonTrivialSessions(curr.sessions)
+	// Filter out short Q&A sessions that aren't coding sessions.
+	nonTrivial := filterNonTrivialSessions(curr.sessions, curr.trivial)
 	recent := recentSessions(nonTrivial, 5)
 	if len(recent) >= 5 {
 		zeroCount := 0
@@ -78,12 +83,9 @@ func compareCritical(prev, curr *WatchState) []Alert {
 		}
 		zeroRate := float64(zeroCount) / float64(len(recent))
 		if zeroRate > 0.80 {
-			alerts = append(alerts, Alert{
-				Level:   "critical",
-				Title:   "High zero-commit rate",
-				Message: fmt.Sprintf("%.0f%% of last %d sessions produced no commits", zeroRate*100, len(recent)),
-				Time:    now,
-			})
+			alerts = append(alerts, newAlert("critical", "High zero-commit rate",
+				fmt.Sprintf("%.0f%% of last %d sessions produced no commits", zeroRate*100, len(recent)),
+				zeroRate, now))
 		}
 	}
 
@@ -98,12 +100,9 @@ func compareWarning(prev, curr *WatchState) []Alert {
 	// New friction type appeared that wasn't seen before.
 	for frictionType, count := range curr.FrictionCounts {
 		if _, existed := prev.FrictionCounts[frictionType]; !existed && count > 0 {
-			alerts = append(alerts, Alert{
-				Level:   "warning",
-				Title:   fmt.Sprintf("New friction type: %s", frictionType),
-				Message: fmt.Sprintf("First appearance with %d occurrence(s)", count),
-				Time:    now,
-			})
+			alerts = append(alerts, newAlert("warning", fmt.Sprintf("New friction type: %s", frictionType),
+				fmt.Sprintf("First appearance with %d occurrence(s)", count),
+				float64(count), now))
 		}
 	}
 
@@ -115,12 +114,9 @@ func compareWarning(prev, curr *WatchState) []Alert {
 		}
 		increase := float64(currCount-prevCount) / float64(prevCount)
 		if increase > 0.20 && currCount > prevCount {
-			alerts = append(alerts, Alert{
-				Level:   "warning",
-				Title:   fmt.Sprintf("Friction spike: %s", frictionType),
-				Message: fmt.Sprintf("Increased from %d to %d (+%.0f%%)", prevCount, currCount, increase*100),
-				Time:    now,
-			})
+			alerts = append(alerts, newAlert("warning", fmt.Sprintf("Friction spike: %s", frictionType),
+				fmt.Sprintf("Increased from %d to %d (+%.0f%%)", prevCount, currCount, increase*100),
+				float64(currCount), now))
 		}
 	}
 
@@ -130,24 +126,18 @@ func compareWarning(prev, curr *WatchState) []Alert {
 		newSessions := findNewSessions(prev, curr)
 		for _, s := range newSessions {
 			if s.UserInterruptions > 5 {
-				alerts = append(alerts, Alert{
-					Level:   "warning",
-					Title:   "High correction session",
-					Message: fmt.Sprintf("Session in %s had %d interruptions (%.0f min, %d commits)", filepath.Base(s.ProjectPath), s.UserInterruptions, float64(s.DurationMinutes), s.GitCommits),
-					Time:    now,
-				})
+				alerts = append(alerts, newAlert("warning", "High correction session",
+					fmt.Sprintf("Session in %s had %d interruptions (%.0f min, %d commits)", filepath.Base(s.ProjectPath), s.UserInterruptions, float64(s.DurationMinutes), s.GitCommits),
+					float64(s.UserInterruptions), now))
 			}
 		}
 	}
 
 	// Agent success rate dropped below 80%.
 	if curr.agentSuccessRate < 0.80 && prev.agentSuccessRate >= 0.80 && curr.AgentCount > 0 {
-		alerts = append(alerts, Alert{
-			Level:   "warning",
-			Title:   "Agent success rate dropped",
-			Message: fmt.Sprintf("Success rate is %.0f%% (was %.0f%%)", curr.agentSuccessRate*100, prev.agentSuccessRate*100),
-			Time:    now,
-		})
+		alerts = append(alerts, newAlert("warning", "Agent success rate dropped",
+			fmt.Sprintf("Success rate is %.0f%% (was %.0f%%)", curr.agentSuccessRate*100, prev.agentSuccessRate*100),
+			1-curr.agentSuccessRate, now))
 	}
 
 	return alerts
@@ -166,12 +156,9 @@ func compareInfo(prev, curr *WatchState) []Alert {
 			for _, count := range s.ToolCounts {
 				totalTools += count
 			}
-			alerts = append(alerts, Alert{
-				Level:   "info",
-				Title:   fmt.Sprintf("Session completed: %s", filepath.Base(s.ProjectPath)),
-				Message: fmt.Sprintf("%dmin, %d commits, %d tool calls", s.DurationMinutes, s.GitCommits, totalTools),
-				Time:    now,
-			})
+			alerts = append(alerts, newAlert("info", fmt.Sprintf("Session completed: %s", filepath.Base(s.ProjectPath)),
+				fmt.Sprintf("%dmin, %d commits, %d tool calls", s.DurationMinutes, s.GitCommits, totalTools),
+				0, now))
 		}
 	}
 
@@ -183,12 +170,9 @@ func compareInfo(prev, curr *WatchState) []Alert {
 		}
 		decrease := float64(prevCount-currCount) / float64(prevCount)
 		if decrease > 0.20 && currCount < prevCount {
-			alerts = append(alerts, Alert{
-				Level:   "info",
-				Title:   fmt.Sprintf("Friction improved: %s", frictionType),
-				Message: fmt.Sprintf("Decreased from %d to %d (-%.0f%%)", prevCount, currCount, decrease*100),
-				Time:    now,
-			})
+			alerts = append(alerts, newAlert("info", fmt.Sprintf("Friction improved: %s", frictionType),
+				fmt.Sprintf("Decreased from %d to %d (-%.0f%%)", prevCount, currCount, decrease*100),
+				0, now))
 		}
 	}
 
@@ -201,24 +185,18 @@ func compareInfo(prev, curr *WatchState) []Alert {
 		}
 		for _, s := range newSessions {
 			if s.ProjectPath != "" && !prevProjects[s.ProjectPath] {
-				alerts = append(alerts, Alert{
-					Level:   "info",
-					Title:   fmt.Sprintf("New project: %s", filepath.Base(s.ProjectPath)),
-					Message: fmt.Sprintf("First session detected in %s", s.ProjectPath),
-					Time:    now,
-				})
+				alerts = append(alerts, newAlert("info", fmt.Sprintf("New project: %s", filepath.Base(s.ProjectPath)),
+					fmt.Sprintf("First session detected in %s", s.ProjectPath),
+					0, now))
 			}
 		}
 	}
 
 	// Stale pattern count improved (decreased).
 	if curr.StalePatterns < prev.StalePatterns {
-		alerts = append(alerts, Alert{
-			Level:   "info",
-			Title:   "Stale friction resolved",
-			Message: fmt.Sprintf("Stale patterns decreased from %d to %d", prev.StalePatterns, curr.StalePatterns),
-			Time:    now,
-		})
+		alerts = append(alerts, newAlert("info", "Stale friction resolved",
+			fmt.Sprintf("Stale patterns decreased from %d to %d", prev.StalePatterns, curr.StalePatterns),
+			0, now))
 	}
 
 	return alerts
@@ -250,15 +228,10 @@ func findNewSessions(prev, curr *WatchState) []sessionInfo {
 
 // filterNonTrivialSessions returns sessions that represent actual coding work,
 // filtering out short Q&A or exploration sessions that shouldn't count toward
-// commit-rate metrics. A session is non-trivial if it has ≥5 user messages OR
-// lasted ≥10 minutes.
-func filterNonTrivialSessions(sessions []claude.SessionMeta) []claude.SessionMeta {
-	var result []claude.SessionMeta
-	for _, s := range sessions {
-		if s.UserMessageCount >= 5 || s.DurationMinutes >= 10 {
-			result = append(result, s)
-		}
-	}
+// commit-rate metrics. By default a session is non-trivial if it has ≥5 user
+// messages OR lasted ≥10 minutes; see analyzer.TrivialSession.
+func filterNonTrivialSessions(sessions []claude.SessionMeta, trivial analyzer.TrivialSession) []claude.SessionMeta {
+	result, _ := analyzer.FilterTrivialSessions(sessions, trivial)
 	return result
 }
 
@@ -1,6 +1,7 @@
 package watcher
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/blackwell-systems/claudewatch/internal/analyzer"
@@ -394,7 +395,7 @@ func TestFilterNonTrivialSessions(t *testing.T) {
 		{SessionID: "both", DurationMinutes: 20, UserMessageCount: 10},   // non-trivial: both
 	}
 
-	result := filterNonTrivialSessions(sessions)
+	result := filterNonTrivialSessions(sessions, analyzer.TrivialSession{})
 	if len(result) != 3 {
 		t.Fatalf("expected 3 non-trivial sessions, got %d", len(result))
 	}
@@ -434,6 +435,35 @@ func TestCompare_ZeroCommitRateTrivialSessionsFiltered(t *testing.T) {
 	}
 }
 
+func TestCompare_ZeroCommitRateConfiguredTrivialSession(t *testing.T) {
+	// The same short sessions count once the trivial thresholds are lowered.
+	var sessions []claude.SessionMeta
+	for i := 1; i <= 5; i++ {
+		sessions = append(sessions, claude.SessionMeta{
+			SessionID:        fmt.Sprintf("s%d", i),
+			StartTime:        fmt.Sprintf("2026-01-1%dT10:00:00Z", i),
+			DurationMinutes:  3,
+			UserMessageCount: 2,
+		})
+	}
+
+	prev := makeState()
+	curr := makeState()
+	curr.sessions = sessions
+	curr.SessionCount = 5
+	curr.trivial = analyzer.TrivialSession{MinDurationMinutes: 1, MinUserMessages: 1}
+
+	found := false
+	for _, a := range Compare(prev, curr) {
+		if a.Level == "critical" && a.Title == "High zero-commit rate" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected zero-commit alert when short sessions are not trivial")
+	}
+}
+
 func TestRecentSessions_LargerN(t *testing.T) {
 	sessions := []claude.SessionMeta{
 		{SessionID: "s1", StartTime: "2026-01-10T10:00:00Z"},
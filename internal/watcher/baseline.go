@@ -0,0 +1,156 @@
+package watcher
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+// Baseline is the on-disk form of the watcher's last known state. It carries
+// just enough of a WatchState for Compare to detect changes since it was
+// recorded, plus the alert keys that were already reported, so a restarted
+// watcher neither re-alerts on historical data nor repeats alerts. It also
+// keeps the latest alert per signature for `claudewatch ack` to look up.
+type Baseline struct {
+	RecordedAt         time.Time         `json:"recorded_at"`
+	Sessions           []BaselineSession `json:"sessions"`
+	FrictionCounts     map[string]int    `json:"friction_counts"`
+	StaleFrictionTypes []string          `json:"stale_friction_types"`
+	AgentCount         int               `json:"agent_count"`
+	AgentKillRate      float64           `json:"agent_kill_rate"`
+	AgentSuccessRate   float64           `json:"agent_success_rate"`
+	AlertKeys          []string          `json:"alert_keys"`
+	RecentAlerts       []Alert           `json:"recent_alerts,omitempty"`
+}
+
+// BaselineSession identifies a session known at baseline time.
+type BaselineSession struct {
+	SessionID   string `json:"session_id"`
+	ProjectPath string `json:"project_path"`
+}
+
+// NewBaseline captures state and the set of already-reported alert keys.
+func NewBaseline(state *WatchState, alertKeys map[string]bool) *Baseline {
+	b := &Baseline{
+		RecordedAt:       state.Timestamp,
+		Sessions:         make([]BaselineSession, 0, len(state.sessions)),
+		FrictionCounts:   make(map[string]int, len(state.FrictionCounts)),
+		AgentCount:       state.AgentCount,
+		AgentKillRate:    state.agentKillRate,
+		AgentSuccessRate: state.agentSuccessRate,
+		AlertKeys:        make([]string, 0, len(alertKeys)),
+	}
+	for _, s := range state.sessions {
+		b.Sessions = append(b.Sessions, BaselineSession{SessionID: s.SessionID, ProjectPath: s.ProjectPath})
+	}
+	for k, v := range state.FrictionCounts {
+		b.FrictionCounts[k] = v
+	}
+	for _, p := range state.persistence.Patterns {
+		if p.Stale {
+			b.StaleFrictionTypes = append(b.StaleFrictionTypes, p.FrictionType)
+		}
+	}
+	for key := range alertKeys {
+		b.AlertKeys = append(b.AlertKeys, key)
+	}
+	sort.Strings(b.AlertKeys)
+	return b
+}
+
+// State reconstructs a WatchState from the baseline for use as the previous
+// state in Compare.
+func (b *Baseline) State() *WatchState {
+	state := &WatchState{
+		Timestamp:        b.RecordedAt,
+		SessionCount:     len(b.Sessions),
+		TotalSessions:    len(b.Sessions),
+		FrictionCounts:   make(map[string]int, len(b.FrictionCounts)),
+		frictionByType:   make(map[string]int, len(b.FrictionCounts)),
+		AgentCount:       b.AgentCount,
+		StalePatterns:    len(b.StaleFrictionTypes),
+		agentKillRate:    b.AgentKillRate,
+		agentSuccessRate: b.AgentSuccessRate,
+	}
+	for _, s := range b.Sessions {
+		state.sessions = append(state.sessions, claude.SessionMeta{SessionID: s.SessionID, ProjectPath: s.ProjectPath})
+	}
+	for k, v := range b.FrictionCounts {
+		state.FrictionCounts[k] = v
+		state.frictionByType[k] = v
+	}
+	for _, ft := range b.StaleFrictionTypes {
+		state.persistence.Patterns = append(state.persistence.Patterns, analyzer.FrictionPersistence{FrictionType: ft, Stale: true})
+	}
+	return state
+}
+
+// alertKeySet returns the baseline's alert keys as a set.
+func (b *Baseline) alertKeySet() map[string]bool {
+	keys := make(map[string]bool, len(b.AlertKeys))
+	for _, k := range b.AlertKeys {
+		keys[k] = true
+	}
+	return keys
+}
+
+// LoadBaseline reads a baseline previously written by SaveBaseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// SaveBaseline atomically writes b to path, creating parent directories.
+func SaveBaseline(path string, b *Baseline) error {
+	return writeJSONAtomic(path, "watch-baseline-*.json", b)
+}
+
+// writeJSONAtomic writes v as indented JSON to path via a temp file matching
+// pattern in the same directory, creating parent directories.
+func writeJSONAtomic(path, pattern string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package watcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blackwell-systems/claudewatch/internal/analyzer"
+	"github.com/blackwell-systems/claudewatch/internal/claude"
+)
+
+func TestCompare_FreshBaselineProducesNoAlerts(t *testing.T) {
+	state := makeState()
+	state.Timestamp = time.Now()
+	state.SessionCount = 2
+	state.sessions = []claude.SessionMeta{
+		{SessionID: "s1", ProjectPath: "/tmp/a", GitCommits: 1},
+		{SessionID: "s2", ProjectPath: "/tmp/b", GitCommits: 2},
+	}
+	state.FrictionCounts["wrong_approach"] = 4
+	state.frictionByType["wrong_approach"] = 4
+	state.StalePatterns = 1
+	state.persistence = analyzer.PersistenceAnalysis{
+		Patterns:   []analyzer.FrictionPersistence{{FrictionType: "wrong_approach", Stale: true}},
+		StaleCount: 1,
+	}
+	state.AgentCount = 10
+	state.agentKillRate = 0.5
+	state.agentSuccessRate = 0.4
+
+	baseline := NewBaseline(state, nil)
+
+	alerts := Compare(baseline.State(), state)
+	if len(alerts) != 0 {
+		t.Errorf("expected 0 alerts against a fresh baseline, got %d", len(alerts))
+		for _, a := range alerts {
+			t.Logf("  [%s] %s: %s", a.Level, a.Title, a.Message)
+		}
+	}
+}
+
+func TestBaseline_SaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "watch-baseline.json")
+
+	state := makeState()
+	state.sessions = []claude.SessionMeta{{SessionID: "s1", ProjectPath: "/tmp/a"}}
+	state.FrictionCounts["tool_error"] = 2
+
+	if err := SaveBaseline(path, NewBaseline(state, map[string]bool{"info:x:y": true})); err != nil {
+		t.Fatalf("SaveBaseline: %v", err)
+	}
+	loaded, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline: %v", err)
+	}
+
+	restored := loaded.State()
+	if restored.SessionCount != 1 || restored.sessions[0].ProjectPath != "/tmp/a" {
+		t.Errorf("sessions not restored: %+v", restored.sessions)
+	}
+	if restored.FrictionCounts["tool_error"] != 2 {
+		t.Errorf("friction counts not restored: %v", restored.FrictionCounts)
+	}
+	if !loaded.alertKeySet()["info:x:y"] {
+		t.Errorf("alert keys not restored: %v", loaded.AlertKeys)
+	}
+}
+
+// createZeroCommitSessions writes n non-trivial sessions without commits,
+// enough to trip the absolute zero-commit alert.
+func createZeroCommitSessions(t *testing.T, dir string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		createSessionMetaFile(t, dir, fmt.Sprintf("session-%d", i), "/tmp/project-a", 0,
+			fmt.Sprintf("2026-01-%02dT10:00:00Z", i+10))
+	}
+}
+
+func TestWatcher_FirstRunRecordsBaselineSilently(t *testing.T) {
+	dir := t.TempDir()
+	createZeroCommitSessions(t, dir, 6)
+
+	w := New(dir, 5*time.Minute, nil)
+	w.BaselinePath = filepath.Join(t.TempDir(), "watch-baseline.json")
+
+	initial, err := w.Snapshot()
+	if err != nil {
+		t.Fatalf("initial snapshot error: %v", err)
+	}
+	w.start(initial)
+
+	if alerts := w.Check(); len(alerts) != 0 {
+		t.Errorf("expected no alerts after a silent baseline, got %d", len(alerts))
+		for _, a := range alerts {
+			t.Logf("  [%s] %s: %s", a.Level, a.Title, a.Message)
+		}
+	}
+	if _, err := os.Stat(w.BaselinePath); err != nil {
+		t.Errorf("expected baseline to be persisted: %v", err)
+	}
+}
+
+func TestWatcher_RestartResumesFromBaseline(t *testing.T) {
+	dir := t.TempDir()
+	baselinePath := filepath.Join(t.TempDir(), "watch-baseline.json")
+	createZeroCommitSessions(t, dir, 6)
+
+	first := New(dir, 5*time.Minute, nil)
+	first.BaselinePath = baselinePath
+	initial, err := first.Snapshot()
+	if err != nil {
+		t.Fatalf("initial snapshot error: %v", err)
+	}
+	first.start(initial)
+
+	// A restarted watcher resumes from disk and does not re-alert.
+	second := New(dir, 5*time.Minute, nil)
+	second.BaselinePath = baselinePath
+	restarted, err := second.Snapshot()
+	if err != nil {
+		t.Fatalf("restart snapshot error: %v", err)
+	}
+	second.start(restarted)
+	if alerts := second.Check(); len(alerts) != 0 {
+		t.Errorf("expected no alerts after restart, got %d", len(alerts))
+	}
+
+	// Changes after startup are still reported.
+	createSessionMetaFile(t, dir, "session-new", "/tmp/project-b", 2, "2026-01-30T10:00:00Z")
+	hasNewSession := false
+	for _, a := range second.Check() {
+		if a.Title == "Session completed: project-b" {
+			hasNewSession = true
+		}
+	}
+	if !hasNewSession {
+		t.Error("expected an alert for the session added after startup")
+	}
+}
+
+func TestWatcher_ResetBaselineIgnoresSavedState(t *testing.T) {
+	dir := t.TempDir()
+	baselinePath := filepath.Join(t.TempDir(), "watch-baseline.json")
+	createSessionMetaFile(t, dir, "session-1", "/tmp/project-a", 1, "2026-01-15T10:00:00Z")
+
+	// Save an empty baseline, so resuming from it would report session-1.
+	if err := SaveBaseline(baselinePath, NewBaseline(makeState(), nil)); err != nil {
+		t.Fatalf("SaveBaseline: %v", err)
+	}
+
+	w := New(dir, 5*time.Minute, nil)
+	w.BaselinePath = baselinePath
+	w.ResetBaseline = true
+	initial, err := w.Snapshot()
+	if err != nil {
+		t.Fatalf("initial snapshot error: %v", err)
+	}
+	w.start(initial)
+
+	if alerts := w.Check(); len(alerts) != 0 {
+		t.Errorf("expected reset baseline to suppress historical alerts, got %d", len(alerts))
+	}
+}
+
+func TestWatcher_Once(t *testing.T) {
+	dir := t.TempDir()
+	baselinePath := filepath.Join(t.TempDir(), "watch-baseline.json")
+	createZeroCommitSessions(t, dir, 6)
+
+	// First run: records the baseline silently.
+	first := New(dir, 5*time.Minute, nil)
+	first.BaselinePath = baselinePath
+	alerts, compared, err := first.Once()
+	if err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if compared || len(alerts) != 0 {
+		t.Errorf("first run: compared = %v, %d alerts; want a silent baseline", compared, len(alerts))
+	}
+	if _, err := os.Stat(baselinePath); err != nil {
+		t.Fatalf("expected baseline to be persisted: %v", err)
+	}
+
+	// A later run reports what changed since the previous one.
+	createSessionMetaFile(t, dir, "session-new", "/tmp/project-b", 2, "2026-01-30T10:00:00Z")
+	second := New(dir, 5*time.Minute, nil)
+	second.BaselinePath = baselinePath
+	alerts, compared, err = second.Once()
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	hasNewSession := false
+	for _, a := range alerts {
+		if a.Title == "Session completed: project-b" {
+			hasNewSession = true
+		}
+	}
+	if !compared || !hasNewSession {
+		t.Errorf("second run: compared = %v, alerts %+v; want the new session reported", compared, alerts)
+	}
+
+	// And saves its state, so nothing repeats on the next run.
+	third := New(dir, 5*time.Minute, nil)
+	third.BaselinePath = baselinePath
+	if alerts, _, _ := third.Once(); len(alerts) != 0 {
+		t.Errorf("third run: expected no alerts, got %+v", alerts)
+	}
+}
+
+func TestWatcher_OnceNeedsBaselinePath(t *testing.T) {
+	if _, _, err := New(t.TempDir(), time.Minute, nil).Once(); err == nil {
+		t.Error("expected an error without a baseline path")
+	}
+}
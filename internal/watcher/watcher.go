@@ -32,14 +32,25 @@ type WatchState struct {
 	persistence      analyzer.PersistenceAnalysis
 	sessions         []claude.SessionMeta
 	facets           []claude.SessionFacet
+	trivial          analyzer.TrivialSession
 }
 
 // Alert represents a notable event detected by the watcher.
 type Alert struct {
-	Level   string // "info", "warning", "critical"
-	Title   string
-	Message string
-	Time    time.Time
+	Level   string    `json:"level"` // "info", "warning", "critical"
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+
+	// Signature identifies the alert across checks and restarts; see
+	// AlertSignature. It is what `claudewatch ack` takes.
+	Signature string `json:"signature"`
+
+	// Value measures how bad the underlying condition is, higher being worse
+	// (e.g. occurrences of a stale friction type, or the agent kill rate).
+	// It is 0 for alerts with no such measure. An acknowledged alert shows
+	// again once its Value grows past the acknowledged one; see Ack.
+	Value float64 `json:"value"`
 }
 
 // Watcher monitors Claude session data at a regular interval and emits alerts
@@ -51,6 +62,29 @@ type Watcher struct {
 	alertFn       func(Alert)     // callback for emitting alerts
 	lastAlertKeys map[string]bool // dedup: suppress repeated identical alerts
 	BudgetUSD     float64         // daily cost budget; 0 means no budget alert
+	StaleWeeks    int             // consecutive weeks before friction is stale; 0 means the analyzer default
+
+	// Trivial defines the short sessions left out of the zero-commit rate.
+	// The zero value means analyzer.DefaultTrivialSession.
+	Trivial analyzer.TrivialSession
+
+	// BaselinePath, when set, persists the watcher's state between runs so a
+	// restart only reports changes since the last check. If no baseline
+	// exists at startup, the initial state is recorded silently.
+	BaselinePath string
+
+	// ResetBaseline discards any saved baseline at startup and records a new
+	// one silently.
+	ResetBaseline bool
+
+	// AckPath, when set, is the acknowledgment file written by
+	// `claudewatch ack`. It is re-read every check, and acknowledged alerts
+	// are suppressed until they materially worsen.
+	AckPath string
+
+	// recent is the latest alert per signature, persisted in the baseline so
+	// `claudewatch ack` can look signatures up.
+	recent []Alert
 }
 
 // New creates a Watcher that monitors the given Claude data directory.
@@ -71,7 +105,7 @@ func (w *Watcher) Run(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("initial snapshot: %w", err)
 	}
-	w.previous = initial
+	w.start(initial)
 
 	ticker := time.NewTicker(w.interval)
 	defer ticker.Stop()
@@ -93,67 +127,196 @@ func (w *Watcher) Run(ctx context.Context) error {
 
 // Check performs a single check cycle: takes a new snapshot, compares against
 // the previous state, updates the previous state, and returns any alerts.
-// Identical alerts are suppressed until the underlying data changes.
+// Identical alerts are suppressed until the underlying data changes, and
+// acknowledged alerts until they materially worsen.
 func (w *Watcher) Check() []Alert {
 	curr, err := w.Snapshot()
 	if err != nil {
-		return []Alert{{
-			Level:   "warning",
-			Title:   "Snapshot failed",
-			Message: fmt.Sprintf("Could not read session data: %v", err),
-			Time:    time.Now(),
-		}}
+		return []Alert{newAlert("warning", "Snapshot failed",
+			fmt.Sprintf("Could not read session data: %v", err), 0, time.Now())}
 	}
+	return w.check(curr)
+}
 
-	var raw []Alert
-	if w.previous != nil {
-		raw = Compare(w.previous, curr)
+// Once runs a single check against the saved baseline, for running from
+// cron instead of leaving a watcher running. It takes a snapshot, compares
+// it with the baseline at BaselinePath, saves the snapshot as the new
+// baseline, and returns the alerts, deduplicated and filtered like Check's.
+// When there is no saved baseline, or ResetBaseline is set, it records the
+// snapshot silently instead and reports compared as false.
+func (w *Watcher) Once() (alerts []Alert, compared bool, err error) {
+	if w.BaselinePath == "" {
+		return nil, false, fmt.Errorf("a single check needs a baseline path")
 	}
-
-	// Budget alert: fires when today's estimated cost exceeds the threshold.
-	if w.BudgetUSD > 0 && curr.EstimatedDailyCost > w.BudgetUSD {
-		raw = append(raw, Alert{
-			Level:   "warning",
-			Title:   "Daily cost budget exceeded",
-			Message: fmt.Sprintf("Estimated $%.2f today (budget: $%.2f)", curr.EstimatedDailyCost, w.BudgetUSD),
-			Time:    time.Now(),
-		})
+	curr, err := w.Snapshot()
+	if err != nil {
+		return nil, false, fmt.Errorf("snapshot: %w", err)
 	}
+	if !w.start(curr) {
+		return nil, false, nil
+	}
+	return w.check(curr), true, nil
+}
+
+// check compares curr with the previous state, makes it the previous state,
+// and returns the alerts left after deduplication and acknowledgments.
+func (w *Watcher) check(curr *WatchState) []Alert {
+	raw := w.rawAlerts(w.previous, curr)
 
 	// Deduplicate: suppress alerts with the same title+message as last cycle.
 	currentKeys := make(map[string]bool, len(raw))
 	var alerts []Alert
 	for _, a := range raw {
-		key := a.Level + ":" + a.Title + ":" + a.Message
+		key := alertKey(a)
 		currentKeys[key] = true
 		if !w.lastAlertKeys[key] {
 			alerts = append(alerts, a)
 		}
 	}
 	w.lastAlertKeys = currentKeys
+	alerts = w.filterAcked(alerts)
+	w.recent = rememberAlerts(w.recent, raw)
 
 	w.previous = curr
+	w.saveBaseline()
 	return alerts
 }
 
+// Prime records curr as the baseline without emitting alerts: any alert the
+// current data would raise is marked as already reported, so only changes
+// after this point surface from Check.
+func (w *Watcher) Prime(curr *WatchState) {
+	keys := make(map[string]bool)
+	for _, a := range w.rawAlerts(curr, curr) {
+		keys[alertKey(a)] = true
+	}
+	w.lastAlertKeys = keys
+	w.previous = curr
+	w.saveBaseline()
+}
+
+// start establishes the previous state for the first Check. With a baseline
+// path it resumes from the saved baseline when one exists, and otherwise
+// primes silently from initial. It reports whether it resumed.
+func (w *Watcher) start(initial *WatchState) bool {
+	if w.BaselinePath == "" {
+		w.previous = initial
+		return false
+	}
+	if !w.ResetBaseline {
+		if b, err := LoadBaseline(w.BaselinePath); err == nil {
+			w.previous = b.State()
+			w.lastAlertKeys = b.alertKeySet()
+			w.recent = b.RecentAlerts
+			return true
+		}
+	}
+	w.Prime(initial)
+	return false
+}
+
+// saveBaseline persists the current state when a baseline path is set.
+// Failures are non-fatal: the watcher keeps working from memory.
+func (w *Watcher) saveBaseline() {
+	if w.BaselinePath == "" || w.previous == nil {
+		return
+	}
+	b := NewBaseline(w.previous, w.lastAlertKeys)
+	b.RecentAlerts = w.recent
+	_ = SaveBaseline(w.BaselinePath, b)
+}
+
+// filterAcked drops alerts acknowledged in AckPath. An unreadable ack file
+// suppresses nothing.
+func (w *Watcher) filterAcked(alerts []Alert) []Alert {
+	if w.AckPath == "" {
+		return alerts
+	}
+	acks, err := LoadAcks(w.AckPath)
+	if err != nil {
+		return alerts
+	}
+	return filterAcked(alerts, acks)
+}
+
+// rawAlerts returns all alerts for the transition from prev to curr, before
+// deduplication. prev may be nil, in which case only absolute checks apply.
+func (w *Watcher) rawAlerts(prev, curr *WatchState) []Alert {
+	var raw []Alert
+	if prev != nil {
+		raw = Compare(prev, curr)
+	}
+
+	// Budget alert: fires when today's estimated cost exceeds the threshold.
+	if w.BudgetUSD > 0 && curr.EstimatedDailyCost > w.BudgetUSD {
+		raw = append(raw, newAlert("warning", "Daily cost budget exceeded",
+			fmt.Sprintf("Estimated $%.2f today (budget: $%.2f)", curr.EstimatedDailyCost, w.BudgetUSD),
+			curr.EstimatedDailyCost, time.Now()))
+	}
+	return raw
+}
+
+// alertKey identifies an alert for deduplication.
+func alertKey(a Alert) string {
+	return a.Level + ":" + a.Title + ":" + a.Message
+}
+
 // Snapshot captures the current state from Claude data. It reads session meta,
 // facets, and agent tasks, computing summary counts. For efficiency, it checks
 // whether the session-meta directory has been modified before doing a full parse.
 func (w *Watcher) Snapshot() (*WatchState, error) {
-	state := &WatchState{
-		Timestamp:      time.Now(),
-		FrictionCounts: make(map[string]int),
-		frictionByType: make(map[string]int),
-	}
-
 	// Parse session metadata.
 	sessions, err := claude.ParseAllSessionMeta(w.claudeDir)
 	if err != nil {
 		return nil, fmt.Errorf("parsing session meta: %w", err)
 	}
-	state.sessions = sessions
-	state.SessionCount = len(sessions)
-	state.TotalSessions = len(sessions)
+
+	// Parse facets for friction data.
+	facets, err := claude.ParseAllFacets(w.claudeDir)
+	if err != nil {
+		// Non-fatal: friction data may not exist yet.
+		facets = nil
+	}
+
+	// Parse agent tasks.
+	agentTasks, err := claude.ParseAgentTasks(w.claudeDir)
+	if err != nil {
+		// Non-fatal: transcript data may not exist.
+		agentTasks = nil
+	}
+
+	state := w.buildState(sessions, facets, agentTasks)
+
+	// Estimate today's cost from sessions starting today.
+	// Load stats-cache for accurate cache-aware pricing (non-fatal if missing).
+	pricing := analyzer.DefaultPricing["sonnet"]
+	cacheRatio := analyzer.NoCacheRatio()
+	if sc, scErr := claude.ParseStatsCache(w.claudeDir); scErr == nil && sc != nil {
+		cacheRatio = analyzer.ComputeCacheRatio(*sc)
+	}
+	today := time.Now().Format("2006-01-02")
+	for _, s := range sessions {
+		if len(s.StartTime) >= 10 && s.StartTime[:10] == today {
+			state.EstimatedDailyCost += analyzer.EstimateSessionCost(s, pricing, cacheRatio)
+		}
+	}
+
+	return state, nil
+}
+
+// buildState computes a WatchState from already-parsed data.
+func (w *Watcher) buildState(sessions []claude.SessionMeta, facets []claude.SessionFacet, agentTasks []claude.AgentTask) *WatchState {
+	state := &WatchState{
+		Timestamp:      time.Now(),
+		FrictionCounts: make(map[string]int),
+		frictionByType: make(map[string]int),
+		sessions:       sessions,
+		facets:         facets,
+		trivial:        w.Trivial,
+		SessionCount:   len(sessions),
+		TotalSessions:  len(sessions),
+		AgentCount:     len(agentTasks),
+	}
 
 	// Track the most recent session by start time.
 	if len(sessions) > 0 {
@@ -173,14 +336,6 @@ func (w *Watcher) Snapshot() (*WatchState, error) {
 		}
 	}
 
-	// Parse facets for friction data.
-	facets, err := claude.ParseAllFacets(w.claudeDir)
-	if err != nil {
-		// Non-fatal: friction data may not exist yet.
-		facets = nil
-	}
-	state.facets = facets
-
 	for _, f := range facets {
 		for frictionType, count := range f.FrictionCounts {
 			state.FrictionCounts[frictionType] += count
@@ -188,14 +343,6 @@ func (w *Watcher) Snapshot() (*WatchState, error) {
 		}
 	}
 
-	// Parse agent tasks.
-	agentTasks, err := claude.ParseAgentTasks(w.claudeDir)
-	if err != nil {
-		// Non-fatal: transcript data may not exist.
-		agentTasks = nil
-	}
-	state.AgentCount = len(agentTasks)
-
 	for _, t := range agentTasks {
 		if t.Status == "killed" {
 			state.AgentKillCount++
@@ -203,33 +350,79 @@ func (w *Watcher) Snapshot() (*WatchState, error) {
 	}
 
 	if len(agentTasks) > 0 {
-		agentPerf := analyzer.AnalyzeAgents(agentTasks)
+		agentPerf := analyzer.AnalyzeAgents(agentTasks, nil)
 		state.agentKillRate = agentPerf.KillRate
 		state.agentSuccessRate = agentPerf.SuccessRate
 	}
 
 	// Analyze friction persistence for stale pattern detection.
 	if len(facets) > 0 && len(sessions) > 0 {
-		persistence := analyzer.AnalyzeFrictionPersistence(facets, sessions)
+		persistence := analyzer.AnalyzeFrictionPersistence(facets, sessions, w.StaleWeeks)
 		state.StalePatterns = persistence.StaleCount
 		state.persistence = persistence
 	}
 
-	// Estimate today's cost from sessions starting today.
-	// Load stats-cache for accurate cache-aware pricing (non-fatal if missing).
-	pricing := analyzer.DefaultPricing["sonnet"]
-	cacheRatio := analyzer.NoCacheRatio()
-	if sc, scErr := claude.ParseStatsCache(w.claudeDir); scErr == nil && sc != nil {
-		cacheRatio = analyzer.ComputeCacheRatio(*sc)
+	return state
+}
+
+// LastSessionChanges reports what the most recent session changed: it
+// rebuilds the state as it was before that session started and returns the
+// alerts Compare produces against the current state, along with the session.
+// With fewer than two sessions there is nothing to compare, and it returns
+// a nil session and no alerts.
+func (w *Watcher) LastSessionChanges() (*claude.SessionMeta, []Alert, error) {
+	sessions, err := claude.ParseAllSessionMeta(w.claudeDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing session meta: %w", err)
 	}
-	today := time.Now().Format("2006-01-02")
+	if len(sessions) < 2 {
+		return nil, nil, nil
+	}
+	facets, err := claude.ParseAllFacets(w.claudeDir)
+	if err != nil {
+		facets = nil
+	}
+	agentTasks, err := claude.ParseAgentTasks(w.claudeDir)
+	if err != nil {
+		agentTasks = nil
+	}
+
+	curr := w.buildState(sessions, facets, agentTasks)
+	var last claude.SessionMeta
 	for _, s := range sessions {
-		if len(s.StartTime) >= 10 && s.StartTime[:10] == today {
-			state.EstimatedDailyCost += analyzer.EstimateSessionCost(s, pricing, cacheRatio)
+		if s.SessionID == curr.LastSessionID {
+			last = s
+			break
 		}
 	}
 
-	return state, nil
+	// Everything that started before the last session.
+	before := make(map[string]bool, len(sessions))
+	var prevSessions []claude.SessionMeta
+	for _, s := range sessions {
+		if s.StartTime < last.StartTime {
+			before[s.SessionID] = true
+			prevSessions = append(prevSessions, s)
+		}
+	}
+	if len(prevSessions) == 0 {
+		return nil, nil, nil
+	}
+	var prevFacets []claude.SessionFacet
+	for _, f := range facets {
+		if before[f.SessionID] {
+			prevFacets = append(prevFacets, f)
+		}
+	}
+	var prevTasks []claude.AgentTask
+	for _, t := range agentTasks {
+		if before[t.SessionID] {
+			prevTasks = append(prevTasks, t)
+		}
+	}
+
+	prev := w.buildState(prevSessions, prevFacets, prevTasks)
+	return &last, Compare(prev, curr), nil
 }
 
 // recentSessions returns sessions sorted by start time descending, limited to n.
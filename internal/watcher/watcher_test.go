@@ -163,3 +163,58 @@ func TestNew_SetsFields(t *testing.T) {
 		t.Error("expected alertFn to be called")
 	}
 }
+
+func TestLastSessionChanges(t *testing.T) {
+	dir := t.TempDir()
+
+	createSessionMetaFile(t, dir, "session-1", "/tmp/project-a", 2, "2026-01-15T10:00:00Z")
+	createSessionMetaFile(t, dir, "session-2", "/tmp/project-b", 1, "2026-01-16T10:00:00Z")
+
+	facetDir := filepath.Join(dir, "usage-data", "facets")
+	if err := os.MkdirAll(facetDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	facet := `{"session_id":"session-2","friction_counts":{"wrong_approach":2}}`
+	if err := os.WriteFile(filepath.Join(facetDir, "session-2.json"), []byte(facet), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := New(dir, time.Minute, nil)
+	last, alerts, err := w.LastSessionChanges()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last == nil || last.SessionID != "session-2" {
+		t.Fatalf("expected session-2 as the last session, got %+v", last)
+	}
+
+	titles := make(map[string]bool)
+	for _, a := range alerts {
+		titles[a.Title] = true
+	}
+	for _, want := range []string{
+		"New friction type: wrong_approach",
+		"Session completed: project-b",
+		"New project: project-b",
+	} {
+		if !titles[want] {
+			t.Errorf("missing alert %q in %+v", want, alerts)
+		}
+	}
+}
+
+func TestLastSessionChanges_InsufficientHistory(t *testing.T) {
+	dir := t.TempDir()
+	w := New(dir, time.Minute, nil)
+
+	last, alerts, err := w.LastSessionChanges()
+	if err != nil || last != nil || alerts != nil {
+		t.Errorf("no sessions: got %v, %v, %v", last, alerts, err)
+	}
+
+	createSessionMetaFile(t, dir, "session-1", "/tmp/project-a", 2, "2026-01-15T10:00:00Z")
+	last, alerts, err = w.LastSessionChanges()
+	if err != nil || last != nil || alerts != nil {
+		t.Errorf("one session: got %v, %v, %v", last, alerts, err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package workers runs independent jobs on a bounded pool of goroutines.
+// The pool size is process-wide, set from --jobs (config: jobs), so project
+// discovery and transcript parsing can't saturate every core of a laptop.
+package workers
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var limit atomic.Int64
+
+// SetLimit caps how many jobs run at once. Values below 1 restore the
+// default, runtime.NumCPU().
+func SetLimit(n int) {
+	limit.Store(int64(n))
+}
+
+// Limit returns the current cap.
+func Limit() int {
+	if n := limit.Load(); n >= 1 {
+		return int(n)
+	}
+	return runtime.NumCPU()
+}
+
+// Each calls fn(i) for every i in [0, n), running at most Limit() calls at
+// once, and returns when all have finished. Callers collect results by
+// index so their order doesn't depend on scheduling. With a limit of 1 the
+// calls run in order on the calling goroutine.
+func Each(n int, fn func(i int)) {
+	workers := min(Limit(), n)
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimit_DefaultsToNumCPU(t *testing.T) {
+	SetLimit(0)
+	if Limit() != runtime.NumCPU() {
+		t.Errorf("expected %d, got %d", runtime.NumCPU(), Limit())
+	}
+	SetLimit(3)
+	defer SetLimit(0)
+	if Limit() != 3 {
+		t.Errorf("expected 3, got %d", Limit())
+	}
+}
+
+func TestEach_RespectsLimit(t *testing.T) {
+	SetLimit(2)
+	defer SetLimit(0)
+
+	var running, peak atomic.Int32
+	done := make([]bool, 20)
+	Each(len(done), func(i int) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		done[i] = true
+		running.Add(-1)
+	})
+
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent calls, saw %d", peak.Load())
+	}
+	for i, ok := range done {
+		if !ok {
+			t.Errorf("job %d never ran", i)
+		}
+	}
+}
+
+func TestEach_SequentialWithLimitOne(t *testing.T) {
+	SetLimit(1)
+	defer SetLimit(0)
+
+	var order []int
+	Each(5, func(i int) { order = append(order, i) })
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("expected jobs in order, got %v", order)
+		}
+	}
+	if len(order) != 5 {
+		t.Errorf("expected 5 jobs, got %d", len(order))
+	}
+}